@@ -4,61 +4,335 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"daily-planner/internal/bot"
 	"daily-planner/internal/config"
+	"daily-planner/internal/datadir"
+	"daily-planner/internal/federation"
+	"daily-planner/internal/health"
+	"daily-planner/internal/httpclient"
 	"daily-planner/internal/repository"
 	"daily-planner/internal/service"
+	"daily-planner/internal/tracing"
 )
 
+// reportTick is how often due personal reports are looked for.
+const reportTick = time.Minute
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "setup" {
+		if err := runSetup(os.Args[2:]); err != nil {
+			log.Fatalf("setup: %v", err)
+		}
+		return
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if err := config.LoadEnvFile(envFile()); err != nil {
+		log.Fatalf("config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("config: %v", err)
 	}
 
-	db, err := repository.NewDB(cfg.DatabaseURL)
+	checkStorage(cfg)
+
+	db, err := repository.NewDB(cfg.DatabaseURL, repository.PoolConfig{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
+	})
 	if err != nil {
 		log.Fatalf("db: %v", err)
 	}
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingEndpoint)
+	if err != nil {
+		log.Fatalf("tracing: %v", err)
+	}
+	defer func() {
+		// ctx is already cancelled here, so pending spans get a fresh deadline.
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Printf("tracing shutdown: %v", err)
+		}
+	}()
+	if cfg.TracingEndpoint != "" {
+		if err := db.Use(tracing.GormPlugin{}); err != nil {
+			log.Fatalf("tracing: %v", err)
+		}
+	}
 	sqlDB, err := db.DB()
 	if err == nil {
 		defer sqlDB.Close()
 	}
 
+	// Every outbound request shares one connection pool, its timeouts and retries.
+	httpClient, err := httpclient.New(httpclient.Options{Timeout: cfg.HTTPTimeout, Retries: cfg.HTTPMaxRetries})
+	if err != nil {
+		log.Fatalf("http client: %v", err)
+	}
+
 	userRepo := repository.NewUserRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
 	categoryRepo := repository.NewCategoryRepository(db)
 	taskRepo := repository.NewTaskRepository(db)
+	taskMessageRepo := repository.NewTaskMessageRepository(db)
+	contactRepo := repository.NewContactRepository(db)
+	medicationRepo := repository.NewMedicationRepository(db)
+	counterRepo := repository.NewCounterRepository(db)
+	triageRepo := repository.NewTriageRepository(db)
+	reminderRepo := repository.NewReminderRepository(db)
 
-	categorySvc := service.NewCategoryService(categoryRepo)
-	taskSvc := service.NewTaskService(taskRepo, categoryRepo)
-	reminderSvc := service.NewReminderService(taskRepo, categoryRepo)
+	accountSvc := service.NewAccountService(accountRepo, userRepo)
+	workspaceSvc := service.NewWorkspaceService(workspaceRepo)
+	quotaSvc := service.NewQuotaService(taskRepo, categoryRepo, userRepo, service.Limits{
+		MaxActiveTasks:     cfg.MaxActiveTasks,
+		MaxCategories:      cfg.MaxCategories,
+		MaxAttachmentBytes: cfg.MaxAttachmentBytes,
+	}, cfg.AdminIDs)
+	if len(cfg.AllowedUserIDs) > 0 && len(cfg.AdminIDs) == 0 {
+		log.Printf("[warn] ALLOWED_USER_IDS is set without ADMIN_IDS: nobody can approve access requests")
+	}
+	signupSvc := service.NewSignupService(userRepo, repository.NewAccessRequestRepository(db), cfg.SignupInviteCodes, cfg.AllowedUserIDs, cfg.AdminIDs, cfg.RequireCaptcha)
+	retentionSvc := service.NewRetentionService(userRepo, cfg.InactiveMonths, cfg.RetentionGraceDays)
+	categorySvc := service.NewCategoryService(categoryRepo, workspaceSvc)
+	taskSvc := service.NewTaskService(taskRepo, categoryRepo, taskMessageRepo, workspaceSvc, quotaSvc)
+	reminderSvc := service.NewReminderService(taskRepo, categoryRepo, workspaceRepo, counterRepo, userRepo)
+	importSvc := service.NewImportService(taskRepo, subscriptionRepo, userRepo, workspaceSvc, quotaSvc, httpClient)
+	contactSvc := service.NewContactService(contactRepo, taskRepo, userRepo, quotaSvc)
+	medicationSvc := service.NewMedicationService(medicationRepo, userRepo)
+	counterSvc := service.NewCounterService(counterRepo)
+	triageSvc := service.NewTriageService(taskRepo, triageRepo)
+	notificationSvc := service.NewNotificationService(reminderRepo, taskRepo)
+	reportScheduler := service.NewReportScheduler(userRepo, repository.NewReportRunRepository(db), cfg.ReportInterval, reportTick)
+	settingsSvc := service.NewSettingsService(userRepo, categoryRepo, quotaSvc, reportScheduler)
+	fieldSvc := service.NewFieldService(repository.NewFieldRepository(db), workspaceSvc)
+	exportSvc := service.NewExportService(taskRepo, categoryRepo, workspaceSvc, quotaSvc)
+	syncSvc := service.NewSyncService(repository.NewSyncRepository(db), userRepo, categoryRepo, accountSvc, cfg.SyncUserIDs)
 
-	telegramBot, err := bot.New(cfg.TelegramToken, userRepo, categorySvc, taskSvc, reminderSvc, &cfg)
+	telegramBot, err := bot.New(cfg.TelegramToken, userRepo, repository.NewConversationRepository(db), accountSvc, workspaceSvc, categorySvc, taskSvc, reminderSvc, importSvc, quotaSvc, signupSvc, retentionSvc, contactSvc, medicationSvc, counterSvc, triageSvc, notificationSvc, settingsSvc, fieldSvc, exportSvc, reportScheduler, httpClient, &cfg)
 	if err != nil {
 		log.Fatalf("bot: %v", err)
 	}
 
 	scheduler := service.NewSchedulerService(time.Local)
-	if cfg.ReportInterval > 0 {
-		if _, err := scheduler.ScheduleInterval(cfg.ReportInterval, func() {
-			jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	if _, err := scheduler.ScheduleInterval(reportTick, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := telegramBot.SendDailyReports(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("report: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule reports: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(cfg.ReportInterval, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := telegramBot.SendWorkspaceReports(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("workspace report: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule workspace reports: %v", err)
+	}
+	if cfg.ManagerDigestTime != "" {
+		if _, err := scheduler.ScheduleWeekly(time.Sunday, cfg.ManagerDigestTime, func() {
+			jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if err := telegramBot.SendManagerDigests(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("manager digest: %v", err)
+			}
+		}); err != nil {
+			log.Fatalf("schedule manager digest: %v", err)
+		}
+	}
+	if cfg.WeeklySummaryTime != "" {
+		if _, err := scheduler.ScheduleWeekly(time.Sunday, cfg.WeeklySummaryTime, func() {
+			jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if err := telegramBot.SendWeeklySummaries(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("weekly summary: %v", err)
+			}
+		}); err != nil {
+			log.Fatalf("schedule weekly summary: %v", err)
+		}
+	}
+	if _, err := scheduler.ScheduleInterval(15*time.Minute, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := telegramBot.SendDeadlineAlerts(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("deadline alerts: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule deadline alerts: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(service.CountdownStep, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := telegramBot.UpdateCountdowns(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("deadline countdowns: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule deadline countdowns: %v", err)
+	}
+	if _, err := scheduler.ScheduleDaily("11:00", func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := telegramBot.SendProcrastinationNudges(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("procrastination nudges: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule procrastination nudges: %v", err)
+	}
+	if _, err := scheduler.ScheduleDaily("10:00", func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := telegramBot.SendBuddyEscalations(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("buddy escalations: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule buddy escalations: %v", err)
+	}
+	if _, err := scheduler.ScheduleMonthly(1, "10:00", func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := telegramBot.SendBacklogTriage(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("backlog triage: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule backlog triage: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(cfg.CalendarSyncInterval, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := importSvc.SyncSubscriptions(jobCtx, time.Now()); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("calendar sync: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule calendar sync: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(time.Minute, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := telegramBot.SendDoseCheckins(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("dose check-ins: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule dose check-ins: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(time.Minute, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := telegramBot.SendTaskReminders(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("task reminders: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule task reminders: %v", err)
+	}
+	if _, err := scheduler.ScheduleDaily("00:05", func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := contactSvc.SyncReminders(jobCtx, time.Now()); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("contact reminders: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule contact reminders: %v", err)
+	}
+	if _, err := scheduler.ScheduleDaily("03:30", func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := telegramBot.PurgeTrash(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("trash purge: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule trash purge: %v", err)
+	}
+	if _, err := scheduler.ScheduleInterval(time.Minute, func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := telegramBot.DeleteExpiredMessages(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("transient messages: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("schedule transient message cleanup: %v", err)
+	}
+	if retentionSvc.Enabled() {
+		if _, err := scheduler.ScheduleDaily("12:00", func() {
+			jobCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			if err := telegramBot.RunRetention(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("retention: %v", err)
+			}
+		}); err != nil {
+			log.Fatalf("schedule retention: %v", err)
+		}
+	}
+	if cfg.SyncPeerURL != "" {
+		syncClient := federation.NewClient(cfg.SyncPeerURL, cfg.SyncSecret, httpClient, syncSvc)
+		if _, err := scheduler.ScheduleInterval(cfg.SyncInterval, func() {
+			jobCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
-			if err := telegramBot.SendDailyReports(jobCtx); err != nil && !errors.Is(err, context.Canceled) {
-				log.Printf("report: %v", err)
+			result, err := syncClient.Sync(jobCtx, time.Now())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("task sync: %v", err)
+				return
 			}
+			log.Printf("[info] task sync created=%d updated=%d deleted=%d skipped=%d", result.Created, result.Updated, result.Deleted, result.Skipped)
 		}); err != nil {
-			log.Fatalf("schedule reports: %v", err)
+			log.Fatalf("schedule task sync: %v", err)
 		}
-		scheduler.Start()
-		defer scheduler.Stop()
+	}
+	if cfg.SyncListenAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle(federation.Path, federation.Handler(syncSvc, cfg.SyncSecret))
+		syncServer := &http.Server{Addr: cfg.SyncListenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := syncServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("sync server: %v", err)
+			}
+		}()
+		defer syncServer.Close()
+		log.Printf("[info] serving task sync on %s%s", cfg.SyncListenAddr, federation.Path)
+	}
+	scheduler.Start()
+	defer scheduler.Stop()
+	if cfg.HealthListenAddr != "" {
+		schedulerCheck := health.Check{Name: "scheduler", Probe: func(ctx context.Context) error {
+			if !scheduler.Running(time.Now()) {
+				return errors.New("jobs are not firing")
+			}
+			return nil
+		}}
+		ready := []health.Check{
+			{Name: "database", Probe: func(ctx context.Context) error {
+				if sqlDB == nil {
+					return errors.New("no connection pool")
+				}
+				return sqlDB.PingContext(ctx)
+			}},
+			{Name: "telegram", Probe: telegramBot.Ping},
+			schedulerCheck,
+		}
+		healthServer := &http.Server{Addr: cfg.HealthListenAddr, Handler: health.Handler([]health.Check{schedulerCheck}, ready), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := healthServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("health server: %v", err)
+			}
+		}()
+		defer healthServer.Close()
+		log.Printf("[info] serving health probes on %s%s and %s", cfg.HealthListenAddr, health.LivePath, health.ReadyPath)
 	}
 
 	log.Println("Daily planner bot started.")
@@ -67,3 +341,40 @@ func main() {
 	}
 	log.Println("Shutdown complete.")
 }
+
+// envFile is the config file: ENV_FILE, or .env in the working directory.
+func envFile() string {
+	if path := strings.TrimSpace(os.Getenv("ENV_FILE")); path != "" {
+		return path
+	}
+	return config.DefaultEnvFile
+}
+
+// checkStorage makes sure DATA_DIR and the SQLite database are writable before anything is
+// written there, and warns when they would be lost with the container.
+func checkStorage(cfg config.Config) {
+	if err := datadir.Prepare(cfg.DataDir); err != nil {
+		log.Fatalf("DATA_DIR: %v", err)
+	}
+	warnEphemeral("DATA_DIR", cfg.DataDir)
+	path := repository.SQLitePath(cfg.DatabaseURL)
+	if path == "" {
+		return
+	}
+	if err := datadir.Prepare(filepath.Dir(path)); err != nil {
+		log.Fatalf("database: %v", err)
+	}
+	if err := datadir.CheckFile(path); err != nil {
+		log.Fatalf("database: %v", err)
+	}
+	warnEphemeral("database", path)
+}
+
+func warnEphemeral(what, path string) {
+	if !datadir.Ephemeral(path) {
+		return
+	}
+	log.Printf("[warn] !!! %s %s is inside the container's own filesystem, not on a volume: "+
+		"everything written there is LOST when the container is recreated. Mount a volume there "+
+		"(e.g. -v planner-data:/data with DATA_DIR=/data).", what, path)
+}
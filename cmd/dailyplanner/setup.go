@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/httpclient"
+	"daily-planner/internal/repository"
+)
+
+// setupWizard asks a self-hoster the few settings the bot cannot do without and writes
+// them to the config file: `dailyplanner setup [file]`, ENV_FILE or .env by default.
+type setupWizard struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func runSetup(args []string) error {
+	path := envFile()
+	if len(args) > 0 {
+		path = args[0]
+	}
+	w := &setupWizard{in: bufio.NewScanner(os.Stdin), out: os.Stdout}
+	fmt.Fprintf(w.out, "Daily planner setup: answers go to %s. Press Enter to keep the [default].\n\n", path)
+
+	if _, err := os.Stat(path); err == nil {
+		overwrite, err := w.ask(fmt.Sprintf("%s already exists. Overwrite it? (yes/no)", path), "no", parseYes)
+		if err != nil {
+			return err
+		}
+		if overwrite == "no" {
+			fmt.Fprintln(w.out, "Nothing changed.")
+			return nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	var botName string
+	token, err := w.ask("Bot token from @BotFather", "", func(token string) (string, error) {
+		name, err := checkToken(token)
+		botName = name
+		return token, err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "Token works: the bot is @%s.\n", botName)
+
+	dataDir, err := w.ask("Data directory (DATA_DIR)", "data", required)
+	if err != nil {
+		return err
+	}
+	dsn, err := w.ask("SQLite file or postgres:// URL (DATABASE_URL)", filepath.Join(dataDir, "daily_planner.db"), required)
+	if err != nil {
+		return err
+	}
+	admin, err := w.ask("Your Telegram user ID for admin commands, @userinfobot tells it (ADMIN_IDS, optional)", "", func(raw string) (string, error) {
+		if raw == "" {
+			return "", nil
+		}
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return "", errors.New("a Telegram ID is a number")
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return err
+	}
+	interval, err := w.ask("Hours between task reports (REPORT_INTERVAL_HOURS)", "5", func(raw string) (string, error) {
+		if hours, err := strconv.Atoi(raw); err != nil || hours <= 0 {
+			return "", errors.New("a whole number of hours, at least 1")
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return err
+	}
+	weekly, err := w.ask("Sunday time of the weekly summary, HH:MM, or \"off\" (WEEKLY_SUMMARY_TIME)", "19:00", func(raw string) (string, error) {
+		if raw == "off" {
+			return "", nil
+		}
+		if _, err := time.Parse("15:04", raw); err != nil {
+			return "", errors.New("a time like 19:00")
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	settings := [][2]string{
+		{"TELEGRAM_TOKEN", token},
+		{"DATA_DIR", dataDir},
+		{"DATABASE_URL", dsn},
+		{"ADMIN_IDS", admin},
+		{"REPORT_INTERVAL_HOURS", interval},
+		{"WEEKLY_SUMMARY_TIME", weekly},
+	}
+	// The bot has to reach Telegram the same way the token was checked.
+	for _, key := range []string{"TELEGRAM_API_URL", "TELEGRAM_PROXY"} {
+		if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+			settings = append(settings, [2]string{key, value})
+		}
+	}
+	var file strings.Builder
+	file.WriteString("# Written by `dailyplanner setup`; see .env.example for the other settings.\n")
+	for _, setting := range settings {
+		fmt.Fprintf(&file, "%s=%s\n", setting[0], setting[1])
+	}
+	// The token is a secret.
+	if err := os.WriteFile(path, []byte(file.String()), 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "Wrote %s.\n", path)
+
+	db, err := repository.NewDB(dsn, repository.PoolConfig{})
+	if err != nil {
+		return fmt.Errorf("migrate database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+	fmt.Fprintln(w.out, "Database is ready.")
+	fmt.Fprintf(w.out, "\nDone. Start the bot with ./dailyplanner in this directory and send it /start.\n")
+	return nil
+}
+
+// ask repeats the question until check accepts the answer; an empty answer means def.
+func (w *setupWizard) ask(question, def string, check func(string) (string, error)) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", question)
+		}
+		if !w.in.Scan() {
+			if err := w.in.Err(); err != nil {
+				return "", err
+			}
+			return "", errors.New("setup cancelled")
+		}
+		answer := strings.TrimSpace(w.in.Text())
+		if answer == "" {
+			answer = def
+		}
+		value, err := check(answer)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Fprintf(w.out, "  %v\n", err)
+	}
+}
+
+func required(raw string) (string, error) {
+	if raw == "" {
+		return "", errors.New("an answer is required")
+	}
+	return raw, nil
+}
+
+func parseYes(raw string) (string, error) {
+	switch strings.ToLower(raw) {
+	case "y", "yes":
+		return "yes", nil
+	case "n", "no":
+		return "no", nil
+	}
+	return "", errors.New("yes or no")
+}
+
+// checkToken asks the Bot API who the token belongs to, through TELEGRAM_API_URL and
+// TELEGRAM_PROXY when they are set.
+func checkToken(token string) (string, error) {
+	if token == "" {
+		return "", errors.New("a token is required")
+	}
+	client, err := httpclient.New(httpclient.Options{Timeout: 30 * time.Second, Proxy: strings.TrimSpace(os.Getenv("TELEGRAM_PROXY"))})
+	if err != nil {
+		return "", err
+	}
+	endpoint := tgbotapi.APIEndpoint
+	if server := strings.TrimRight(strings.TrimSpace(os.Getenv("TELEGRAM_API_URL")), "/"); server != "" {
+		endpoint = server + "/bot%s/%s"
+	}
+	api, err := tgbotapi.NewBotAPIWithClient(token, endpoint, client)
+	if err != nil {
+		// The error may carry the request URL, which holds the token.
+		return "", errors.New("telegram did not accept the token: check it and the connection and try again")
+	}
+	return api.Self.UserName, nil
+}
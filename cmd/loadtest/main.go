@@ -0,0 +1,131 @@
+// Command loadtest seeds a throwaway database with synthetic users and tasks and
+// measures how fast daily reports are built, without talking to Telegram.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+)
+
+func main() {
+	users := flag.Int("users", 1000, "number of synthetic users")
+	tasks := flag.Int("tasks", 20, "tasks per user")
+	categories := flag.Int("categories", 5, "categories per user")
+	dsn := flag.String("db", "", "SQLite file to use (default: a temporary file)")
+	flag.Parse()
+
+	if *dsn == "" {
+		dir, err := os.MkdirTemp("", "planner-loadtest")
+		if err != nil {
+			log.Fatalf("temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		*dsn = filepath.Join(dir, "loadtest.db")
+	}
+
+	db, err := repository.NewDB(*dsn, repository.PoolConfig{})
+	if err != nil {
+		log.Fatalf("db: %v", err)
+	}
+	var queries atomic.Int64
+	if err := countQueries(db, &queries); err != nil {
+		log.Fatalf("register query counter: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	start := time.Now()
+	if err := seed(ctx, db, *users, *tasks, *categories, now); err != nil {
+		log.Fatalf("seed: %v", err)
+	}
+	fmt.Printf("seeded %d users × %d tasks in %s\n", *users, *tasks, time.Since(start).Round(time.Millisecond))
+
+	userRepo := repository.NewUserRepository(db)
+	accountSvc := service.NewAccountService(repository.NewAccountRepository(db), userRepo)
+	reminderSvc := service.NewReminderService(repository.NewTaskRepository(db), repository.NewCategoryRepository(db), repository.NewWorkspaceRepository(db), repository.NewCounterRepository(db), userRepo)
+
+	queries.Store(0)
+	start = time.Now()
+	latencies, err := buildReports(ctx, userRepo, accountSvc, reminderSvc, now)
+	if err != nil {
+		log.Fatalf("reports: %v", err)
+	}
+	report(latencies, time.Since(start), queries.Load())
+}
+
+// buildReports repeats what Bot.SendDailyReports does for every user, minus the sending.
+func buildReports(ctx context.Context, userRepo *repository.UserRepository, accountSvc *service.AccountService, reminderSvc *service.ReminderService, now time.Time) ([]time.Duration, error) {
+	users, err := userRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latencies := make([]time.Duration, 0, len(users))
+	for i := range users {
+		started := time.Now()
+		owner, err := accountSvc.Owner(ctx, &users[i])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := reminderSvc.DailySummary(ctx, *owner, now); err != nil {
+			return nil, err
+		}
+		if owner.ID == users[i].ID {
+			if _, err := reminderSvc.RoutedSummaries(ctx, model.PersonalScope(owner.ID), owner.DeadlinePolicy(), now); err != nil {
+				return nil, err
+			}
+		}
+		latencies = append(latencies, time.Since(started))
+	}
+	return latencies, nil
+}
+
+func report(latencies []time.Duration, total time.Duration, queries int64) {
+	if len(latencies) == 0 {
+		fmt.Println("no users, nothing to measure")
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) time.Duration {
+		return latencies[int(float64(len(latencies)-1)*p)]
+	}
+	n := len(latencies)
+	fmt.Printf("built %d reports in %s (%.1f reports/s)\n", n, total.Round(time.Millisecond), float64(n)/total.Seconds())
+	fmt.Printf("latency p50=%s p95=%s p99=%s max=%s\n", percentile(0.50), percentile(0.95), percentile(0.99), latencies[n-1])
+	fmt.Printf("db queries: %d total, %.1f per report\n", queries, float64(queries)/float64(n))
+}
+
+// countQueries increments counter after every statement GORM executes.
+func countQueries(db *gorm.DB, counter *atomic.Int64) error {
+	count := func(*gorm.DB) { counter.Add(1) }
+	callbacks := db.Callback()
+	if err := callbacks.Query().After("gorm:query").Register("loadtest:count_query", count); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("loadtest:count_row", count); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().After("gorm:raw").Register("loadtest:count_raw", count); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("loadtest:count_create", count); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("loadtest:count_update", count); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("loadtest:count_delete", count)
+}
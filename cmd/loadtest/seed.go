@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// syntheticIDBase keeps generated Telegram IDs away from real ones if -db points at a copy of production.
+const syntheticIDBase = 9_000_000_000
+
+// seed creates users with a mix of overdue, upcoming, undated, completed and recurring tasks.
+func seed(ctx context.Context, db *gorm.DB, users, tasksPerUser, categoriesPerUser int, now time.Time) error {
+	userRepo := repository.NewUserRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+
+	for i := 0; i < users; i++ {
+		user, err := userRepo.UpsertFromTelegram(ctx, syntheticIDBase+int64(i), fmt.Sprintf("User %d", i), "", "", "")
+		if err != nil {
+			return err
+		}
+		categoryIDs := make([]*uint, 0, categoriesPerUser+1)
+		categoryIDs = append(categoryIDs, nil)
+		for c := 0; c < categoriesPerUser; c++ {
+			category, err := categoryRepo.GetOrCreate(ctx, user.Scope(), fmt.Sprintf("Категория %d", c))
+			if err != nil {
+				return err
+			}
+			categoryIDs = append(categoryIDs, &category.ID)
+		}
+
+		tasks := make([]model.Task, 0, tasksPerUser)
+		for t := 0; t < tasksPerUser; t++ {
+			task := model.Task{
+				UserID:     user.ID,
+				CategoryID: categoryIDs[t%len(categoryIDs)],
+				Title:      fmt.Sprintf("Задача %d", t),
+			}
+			switch t % 5 {
+			case 0:
+				deadline := now.AddDate(0, 0, -1-t%7)
+				task.Deadline = &deadline
+			case 1:
+				deadline := now.AddDate(0, 0, 1+t%30)
+				task.Deadline = &deadline
+			case 2:
+				task.Description = "Синтетическое описание задачи"
+			case 3:
+				task.IsCompleted = true
+				task.LastCompletedAt = &now
+			case 4:
+				task.IsRecurring = true
+				task.RecurType = "monthly"
+				task.RecurDay = 1 + t%28
+				task.RecurWindow = 3
+			}
+			tasks = append(tasks, task)
+		}
+		if len(tasks) > 0 {
+			if err := db.WithContext(ctx).CreateInBatches(tasks, 100).Error; err != nil {
+				return fmt.Errorf("create tasks: %w", err)
+			}
+		}
+	}
+	return nil
+}
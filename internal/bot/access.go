@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const cbApprovePrefix = "approve:"
+
+func (b *Bot) registerAccess(r *router) {
+	r.command("approve", "", b.handleApprove)
+	r.callback(callbackRoute{prefix: cbApprovePrefix, selfAck: true, handle: b.handleApproveButton})
+}
+
+// requestAccess turns an unknown user of a private instance away and, the first time,
+// asks the admins to let them in.
+func (b *Bot) requestAccess(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	from := msg.From
+	request := &model.AccessRequest{
+		TelegramID:   from.ID,
+		FirstName:    from.FirstName,
+		LastName:     from.LastName,
+		Username:     from.UserName,
+		LanguageCode: from.LanguageCode,
+	}
+	created, err := b.signupSvc.RequestAccess(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return b.sendTextWithRemove(ctx, msg.Chat.ID, lang.T("⏳ Запрос на доступ уже у администратора. Я напишу, как только его одобрят."))
+	}
+	log.Printf("[info] access requested telegram_id=%d", from.ID)
+	for _, adminID := range b.config.AdminIDs {
+		b.notifyAccessRequest(ctx, adminID, request)
+	}
+	return b.sendTextWithRemove(ctx, msg.Chat.ID, lang.T("🔒 Это личный бот, и тебя пока нет в списке его пользователей. Я передал запрос администратору и напишу, как только его одобрят."))
+}
+
+// notifyAccessRequest shows the request to the admin with a button to approve it; errors are
+// logged, since an admin who never opened the bot cannot be written to.
+func (b *Bot) notifyAccessRequest(ctx context.Context, adminID int64, request *model.AccessRequest) {
+	lang := i18n.Default
+	if admin, err := b.userRepo.FindByTelegramID(ctx, adminID); err == nil {
+		lang = admin.Lang()
+	}
+	msg := tgbotapi.NewMessage(adminID, lang.Tf("🙋 %s (ID <code>%d</code>) просит доступ к боту.", escape(requestName(request)), request.TelegramID))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Одобрить"), fmt.Sprintf("%s%d", cbApprovePrefix, request.TelegramID)),
+	))
+	if _, err := b.send(ctx, msg); err != nil {
+		log.Printf("notify admin %d of access request: %v", adminID, err)
+	}
+}
+
+// handleApprove lists pending access requests, or approves one: /approve [telegram_id].
+func (b *Bot) handleApprove(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	arg := strings.TrimSpace(msg.CommandArguments())
+	if arg == "" {
+		requests, err := b.signupSvc.PendingRequests(ctx, msg.From.ID)
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Эта команда доступна только администраторам."))
+		case err != nil:
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить запросы: %s", errorText(lang, err)))
+		case len(requests) == 0:
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Запросов на доступ нет."))
+		}
+		var text strings.Builder
+		text.WriteString(lang.T("🙋 <b>Запросы на доступ</b>\n"))
+		for _, request := range requests {
+			text.WriteString(fmt.Sprintf("• %s — <code>/approve %d</code>\n", escape(requestName(&request)), request.TelegramID))
+		}
+		return b.sendText(ctx, msg.Chat.ID, text.String())
+	}
+	telegramID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /approve — запросы на доступ, /approve &lt;telegram_id&gt; — впустить пользователя."))
+	}
+	return b.sendText(ctx, msg.Chat.ID, b.approve(ctx, lang, msg.From.ID, telegramID))
+}
+
+// handleApproveButton approves the request under the admin's notification.
+func (b *Bot) handleApproveButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	telegramID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil || cb.Message == nil {
+		return nil
+	}
+	text := b.approve(ctx, lang, cb.From.ID, telegramID)
+	if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
+		log.Printf("callback ack: %v", err)
+	}
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+// approve lets the user in, tells them so and returns the answer for the admin.
+func (b *Bot) approve(ctx context.Context, lang i18n.Lang, adminID, telegramID int64) string {
+	user, err := b.signupSvc.Approve(ctx, adminID, telegramID)
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		return lang.T("Эта команда доступна только администраторам.")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return lang.T("Запроса от этого пользователя нет — возможно, его уже одобрили.")
+	case err != nil:
+		return lang.Tf("Не удалось одобрить запрос: %s", errorText(lang, err))
+	}
+	log.Printf("[info] access approved admin=%d user=%d", adminID, user.ID)
+	note := tgbotapi.NewMessage(user.TelegramID, user.Lang().T("🎉 Доступ открыт! Набери /start, чтобы начать."))
+	if _, err := b.send(ctx, note); err != nil {
+		log.Printf("tell user %d about approval: %v", user.ID, err)
+	}
+	return lang.Tf("✅ %s теперь может пользоваться ботом.", escape(memberName(*user)))
+}
+
+func requestName(request *model.AccessRequest) string {
+	return memberName(model.User{TelegramID: request.TelegramID, FirstName: request.FirstName, LastName: request.LastName, Username: request.Username})
+}
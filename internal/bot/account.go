@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+)
+
+// handleLink issues a one-time code without arguments and redeems it with /link <code>.
+func (b *Bot) handleLink(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+
+	code := strings.TrimSpace(msg.CommandArguments())
+	if code == "" {
+		link, err := b.accountSvc.IssueLinkCode(ctx, user, time.Now())
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось создать код: %s", errorText(lang, err)))
+		}
+		text := lang.Tf(
+			"🔗 Код привязки: <code>%s</code>\nОтправь со второго Telegram-аккаунта команду <code>/link %s</code> в течение 10 минут — оба аккаунта будут видеть одни и те же задачи.",
+			link.Code, link.Code,
+		)
+		return b.sendText(ctx, msg.Chat.ID, text)
+	}
+
+	err = b.accountSvc.Link(ctx, user, code, time.Now())
+	switch {
+	case errors.Is(err, repository.ErrLinkCodeInvalid):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Код не найден или устарел. Запроси новый через /link на основном аккаунте."))
+	case errors.Is(err, service.ErrLinkLocked):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Слишком много неверных кодов. Попробуй снова через час."))
+	case errors.Is(err, service.ErrLinkOwnData):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("У этого аккаунта есть свои задачи, категории или привязанные аккаунты — после привязки они бы пропали. Привяжи наоборот: получи код здесь через /link и введи его на другом аккаунте."))
+	case errors.Is(err, service.ErrAlreadyLinked):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Этот аккаунт уже привязан."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось привязать аккаунт: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] account linked user=%d account=%d", user.ID, user.AccountID)
+	b.clearConversation(msg.From.ID)
+	return b.sendText(ctx, msg.Chat.ID, lang.T("✅ Аккаунт привязан. Теперь здесь доступны общие задачи. Отвязать можно командой /unlink."))
+}
+
+func (b *Bot) handleUnlink(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+
+	err = b.accountSvc.Unlink(ctx, user)
+	switch {
+	case errors.Is(err, service.ErrPrimaryUnlink):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Это основной аккаунт — отвязать можно только дополнительные."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось отвязать аккаунт: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] account unlinked user=%d", user.ID)
+	return b.sendText(ctx, msg.Chat.ID, lang.T("🔓 Аккаунт отвязан. Здесь снова отображаются только твои собственные задачи."))
+}
@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// snoozeDuration is how long a 😴 reaction postpones a deadline alert.
+const snoozeDuration = 3 * time.Hour
+
+// SendDeadlineAlerts notifies task owners about deadlines that are close or just passed.
+// Alert messages are tracked so reactions to them can snooze or complete the task.
+func (b *Bot) SendDeadlineAlerts(ctx context.Context) error {
+	now := time.Now()
+	tasks, err := b.reminderSvc.DueAlerts(ctx, now)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		task := &tasks[i]
+		user, err := b.userRepo.FindByID(ctx, task.UserID)
+		if err != nil {
+			log.Printf("deadline alert owner of task %d: %v", task.ID, err)
+			continue
+		}
+		if user.ArchivedAt != nil {
+			continue
+		}
+
+		local := now.In(user.Location())
+		lang := user.Lang()
+		hours := service.UserWorkingHours(*user)
+		if task.SnoozedUntil == nil && !hours.Deliverable(local) {
+			// Picked up again by a run in the user's morning.
+			continue
+		}
+		msg := tgbotapi.NewMessage(user.TelegramID, deadlineAlertText(lang, *task, local, user.DeadlinePolicy(), user.DeadlineCountdown))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = snoozeKeyboard(lang, service.SnoozeOptions(*task, local, hours))
+		sent, err := b.send(ctx, msg)
+		if err != nil {
+			log.Printf("send deadline alert to %d: %v", user.TelegramID, err)
+			continue
+		}
+		if user.DeadlineCountdown {
+			err = b.taskSvc.TrackCountdown(ctx, task, user.TelegramID, sent.MessageID, model.CountdownAlert)
+		} else {
+			err = b.taskSvc.TrackMessage(ctx, task, user.TelegramID, sent.MessageID)
+		}
+		if err != nil {
+			log.Printf("track deadline alert %d: %v", task.ID, err)
+		}
+		if err := b.reminderSvc.MarkAlerted(ctx, task, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deadlineAlertText describes the deadline of the task as of now, in the user's time zone,
+// as missed only after the user's grace period; with countdown, a deadline within the hour
+// is shown as the minutes left.
+func deadlineAlertText(lang i18n.Lang, task model.Task, now time.Time, policy model.DeadlinePolicy, countdown bool) string {
+	deadline := task.Deadline.In(now.Location())
+	status := lang.T("истекает ") + deadline.Format("2006-01-02")
+	if minutes, ok := service.CountdownLeft(task, now); ok && countdown {
+		status = lang.Tf("⏳ %s, в %s", minutesLeft(lang, minutes), deadline.Format("15:04"))
+	} else if service.Overdue(task.Deadline, now, policy) {
+		status = lang.T("<b>просрочено</b>")
+	}
+	return lang.Tf("⏰ <b>#%d</b> %s — %s\nОтложить — кнопками ниже, ответом вроде «вечером» или «завтра утром» или реакцией 😴 (на 3 часа); ✅ или 👍 — выполнено.", task.ID, escape(normalizeTitle(task.Title)), status)
+}
+
+// snoozeKeyboard offers the suggested snooze moments; the alert message itself identifies the task.
+func snoozeKeyboard(lang i18n.Lang, options []service.SnoozeOption) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, option := range options {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("😴 "+lang.T(option.Label), fmt.Sprintf("%s%d", cbSnoozePrefix, option.Until.Unix())))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// handleSnoozeButton postpones the alert the button is attached to until the chosen moment.
+func (b *Bot) handleSnoozeButton(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	unix, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	until := time.Unix(unix, 0)
+	if !until.After(now) {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.T("Это время уже прошло, выбери другое или поставь реакцию 😴."))
+	}
+	user := CurrentUser(ctx)
+	_, err = b.snoozeAlert(ctx, user, cb.Message.Chat.ID, cb.Message.MessageID, until)
+	return err
+}
+
+// handleSnoozeReply snoozes the alert the message replies to until the time it names,
+// such as "вечером" or "через 2 часа". It reports false when the message is not such a reply.
+func (b *Bot) handleSnoozeReply(ctx context.Context, msg *tgbotapi.Message) (bool, error) {
+	if msg.ReplyToMessage == nil {
+		return false, nil
+	}
+	user, err := b.ensureUser(ctx, msg.From)
+	if err != nil {
+		return true, err
+	}
+	local := time.Now().In(user.Location())
+	until, ok := service.ParseRelativeTime(msg.Text, local, service.UserWorkingHours(*user))
+	if !ok {
+		return false, nil
+	}
+	return b.snoozeAlert(ctx, user, msg.Chat.ID, msg.ReplyToMessage.MessageID, until)
+}
+
+// snoozeAlert postpones the alert shown in a tracked message and confirms the new time.
+// It reports false when the message does not show a task.
+func (b *Bot) snoozeAlert(ctx context.Context, user *model.User, chatID int64, messageID int, until time.Time) (bool, error) {
+	lang := i18n.FromContext(ctx)
+	now := time.Now()
+	task, err := b.taskSvc.SnoozeByMessage(ctx, user, chatID, messageID, now, until)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return false, nil
+	case errors.Is(err, service.ErrTaskCompleted):
+		return true, b.sendText(ctx, chatID, lang.T("Задача уже выполнена, напоминать не о чем."))
+	case err != nil:
+		return true, b.sendText(ctx, chatID, lang.Tf("Не удалось отложить напоминание: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] deadline alert snoozed id=%d user=%d until=%s", task.ID, user.ID, until.Format(time.RFC3339))
+	local := now.In(user.Location())
+	return true, b.sendText(ctx, chatID, lang.Tf("😴 Напомню о «%s» %s.", escape(normalizeTitle(task.Title)), whenLabel(lang, until.In(local.Location()), local)))
+}
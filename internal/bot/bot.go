@@ -2,21 +2,23 @@ package bot
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html"
 	"log"
-	"sort"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 	"unicode"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"gorm.io/gorm"
 
 	"daily-planner/internal/config"
+	"daily-planner/internal/i18n"
 	"daily-planner/internal/model"
 	"daily-planner/internal/repository"
 	"daily-planner/internal/service"
@@ -30,16 +32,45 @@ const (
 	stageDescription
 	stageCategory
 	stageDeadline
+	stagePriority
 	stageRecurring
+	stageRecurringFrequency
 	stageRecurringDay
+	stageRecurringWeekday
+	stageRecurringInterval
 	stageRecurringWindow
+	stageReminderText
+	stageBreakdown
+	stageEdit
+	stageFields
+	stageCategoryEmoji
+	stageCategoryColor
+	stageReminderTime
+	stageSubtask
+	stagePostpone
+	stageMerge
 )
 
 const (
-	cbCompletePrefix = "complete:"
-	cbDeletePrefix   = "delete:"
-	cbConfirmPrefix  = "confirm:"
-	cbCancelPrefix   = "cancel:"
+	cbCompletePrefix        = "complete:"
+	cbDeletePrefix          = "delete:"
+	cbConfirmPrefix         = "confirm:"
+	cbCancelPrefix          = "cancel:"
+	cbCalendarPrefix        = "ics:"
+	cbCaptchaPrefix         = "captcha:"
+	cbRetentionPrefix       = "retention:"
+	cbDosePrefix            = "dose:"
+	cbCounterPrefix         = "counter:"
+	cbNudgePrefix           = "nudge:"
+	cbTriagePrefix          = "triage:"
+	cbCategoryDeletePrefix  = "catdel:"
+	cbSnoozePrefix          = "snooze:"
+	cbReportPrefix          = "report:"
+	cbEditPrefix            = "edit:"
+	cbCategoryListPrefix    = "catlist:"
+	cbCategoryRestorePrefix = "catrestore:"
+	cbDatePrefix            = "date:"
+	cbWeekdaysPrefix        = "wdays:"
 )
 
 const (
@@ -49,7 +80,10 @@ const (
 	btnConfirm          = "✅ Подтвердить"
 	btnCancel           = "↩️ Отмена"
 	btnCancelDialog     = "⏪ Отменить ввод"
+	btnEndRecurrence    = "⏹ Только будущие повторы"
+	btnDeleteHistory    = "🗑 Полностью с историей"
 	noCategory          = "Без категории"
+	noCategoryIcon      = "📁"
 	noCategoryKey       = "__no_category__"
 	iconDefault         = "🟢"
 	iconDue             = "⏳"
@@ -62,15 +96,23 @@ const (
 )
 
 type conversationState struct {
-	stage conversationStage
-	input service.TaskInput
+	stage  conversationStage
+	input  service.TaskInput
+	taskID uint              // task being broken down, edited, reminded of, postponed or given a subtask
+	field  string            // field being edited at stageEdit
+	fields map[string]string // custom field values typed at stageFields, by field name
+
+	attachments []model.Attachment // files sent at stageDescription
+	mediaGroup  string             // album the last description message belonged to
+
+	categoryID uint // category being styled at stageCategoryEmoji and stageCategoryColor
 }
 
 type confirmationAction int
 
 const (
-	actionComplete confirmationAction = iota
-	actionDelete
+	actionDelete          confirmationAction = iota
+	actionDeleteRecurring                    // choose between ending the repeats and deleting with history
 )
 
 type confirmationRequest struct {
@@ -78,72 +120,185 @@ type confirmationRequest struct {
 	action confirmationAction
 }
 
+// apiEndpoint is the Bot API URL template; tests point it at a fake server.
+var apiEndpoint = tgbotapi.APIEndpoint
+
 // Bot aggregates Telegram API with services.
 type Bot struct {
-	api           *tgbotapi.BotAPI
-	userRepo      *repository.UserRepository
-	categorySvc   *service.CategoryService
-	taskSvc       *service.TaskService
-	reminderSvc   *service.ReminderService
-	config        *config.Config
-	conversations map[int64]*conversationState
-	confirmations map[int64]confirmationRequest
-	mu            sync.Mutex
-}
-
-func New(token string, userRepo *repository.UserRepository, categorySvc *service.CategoryService, taskSvc *service.TaskService, reminderSvc *service.ReminderService, cfg *config.Config) (*Bot, error) {
-	api, err := tgbotapi.NewBotAPI(token)
+	api             *tgbotapi.BotAPI
+	endpoint        string // Bot API method URL template, see apiEndpoint
+	fileEndpoint    string // URL template of uploaded files
+	userRepo        service.UserStore
+	dialogRepo      *repository.ConversationRepository
+	accountSvc      *service.AccountService
+	workspaceSvc    *service.WorkspaceService
+	categorySvc     *service.CategoryService
+	taskSvc         *service.TaskService
+	reminderSvc     *service.ReminderService
+	importSvc       *service.ImportService
+	quotaSvc        *service.QuotaService
+	signupSvc       *service.SignupService
+	retentionSvc    *service.RetentionService
+	contactSvc      *service.ContactService
+	medicationSvc   *service.MedicationService
+	counterSvc      *service.CounterService
+	triageSvc       *service.TriageService
+	notificationSvc *service.NotificationService
+	settingsSvc     *service.SettingsService
+	fieldSvc        *service.FieldService
+	exportSvc       *service.ExportService
+	reportScheduler *service.ReportScheduler
+	config          *config.Config
+	conversations   map[int64]*conversationState
+	confirmations   map[int64]confirmationRequest
+	restored        map[int64]bool // users whose saved dialogs were looked up since the start
+	captchas        map[int64]string
+	mu              sync.Mutex
+	traceCtx        atomic.Pointer[context.Context]
+	handle          Handler
+	extra           []Middleware // added with Use
+	pollSeconds     int          // how long getUpdates waits for updates
+	router          *router
+	floods          map[int64]*tokenBucket
+	floodMu         sync.Mutex
+}
+
+func New(token string, userRepo service.UserStore, dialogRepo *repository.ConversationRepository, accountSvc *service.AccountService, workspaceSvc *service.WorkspaceService, categorySvc *service.CategoryService, taskSvc *service.TaskService, reminderSvc *service.ReminderService, importSvc *service.ImportService, quotaSvc *service.QuotaService, signupSvc *service.SignupService, retentionSvc *service.RetentionService, contactSvc *service.ContactService, medicationSvc *service.MedicationService, counterSvc *service.CounterService, triageSvc *service.TriageService, notificationSvc *service.NotificationService, settingsSvc *service.SettingsService, fieldSvc *service.FieldService, exportSvc *service.ExportService, reportScheduler *service.ReportScheduler, httpClient *http.Client, cfg *config.Config) (*Bot, error) {
+	b := &Bot{
+		userRepo:        userRepo,
+		dialogRepo:      dialogRepo,
+		accountSvc:      accountSvc,
+		workspaceSvc:    workspaceSvc,
+		categorySvc:     categorySvc,
+		taskSvc:         taskSvc,
+		reminderSvc:     reminderSvc,
+		importSvc:       importSvc,
+		quotaSvc:        quotaSvc,
+		signupSvc:       signupSvc,
+		retentionSvc:    retentionSvc,
+		contactSvc:      contactSvc,
+		medicationSvc:   medicationSvc,
+		counterSvc:      counterSvc,
+		triageSvc:       triageSvc,
+		notificationSvc: notificationSvc,
+		settingsSvc:     settingsSvc,
+		fieldSvc:        fieldSvc,
+		exportSvc:       exportSvc,
+		reportScheduler: reportScheduler,
+		config:          cfg,
+		conversations:   make(map[int64]*conversationState),
+		confirmations:   make(map[int64]confirmationRequest),
+		restored:        make(map[int64]bool),
+		captchas:        make(map[int64]string),
+		floods:          make(map[int64]*tokenBucket),
+	}
+	b.router = b.routes()
+	taskSvc.OnChange(b.refreshLists)
+	b.handle = b.pipeline()
+	b.endpoint, b.fileEndpoint = apiEndpoint, tgbotapi.FileEndpoint
+	if cfg.BotAPIURL != "" {
+		// A self-hosted Bot API server serves both under its own address.
+		b.endpoint, b.fileEndpoint = cfg.BotAPIURL+"/bot%s/%s", cfg.BotAPIURL+"/file/bot%s/%s"
+	}
+	b.pollSeconds = longPollSeconds(httpClient.Timeout)
+	client, err := b.newAPIClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+	api, err := tgbotapi.NewBotAPIWithClient(token, b.endpoint, client)
 	if err != nil {
 		return nil, fmt.Errorf("create bot api: %w", err)
 	}
+	b.api = api
 
 	log.Printf("[info] bot authorized on account %s", api.Self.UserName)
-
-	return &Bot{
-		api:           api,
-		userRepo:      userRepo,
-		categorySvc:   categorySvc,
-		taskSvc:       taskSvc,
-		reminderSvc:   reminderSvc,
-		config:        cfg,
-		conversations: make(map[int64]*conversationState),
-		confirmations: make(map[int64]confirmationRequest),
-	}, nil
+	if cfg.BotAPIURL != "" {
+		log.Printf("[info] using Bot API server %s", cfg.BotAPIURL)
+	}
+	if cfg.TelegramProxy != "" {
+		log.Printf("[info] reaching Telegram through a %s proxy", strings.SplitN(cfg.TelegramProxy, ":", 2)[0])
+	}
+	return b, nil
 }
 
-// Start begins polling updates until ctx is cancelled.
+// Start handles updates from the webhook or long polling until ctx is cancelled.
 func (b *Bot) Start(ctx context.Context) error {
-	updateConfig := tgbotapi.NewUpdate(0)
-	updateConfig.Timeout = 60
-	updates := b.api.GetUpdatesChan(updateConfig)
+	updates, err := b.updates(ctx)
+	if err != nil {
+		return err
+	}
+	// The default menu is in Russian; clients in other languages get their own.
+	for _, lang := range i18n.Supported {
+		commands := tgbotapi.NewSetMyCommands(b.router.menu(lang)...)
+		if lang != i18n.Default {
+			commands = tgbotapi.NewSetMyCommandsWithScopeAndLanguage(tgbotapi.NewBotCommandScopeDefault(), string(lang), commands.Commands...)
+		}
+		if _, err := b.api.Request(commands); err != nil {
+			log.Printf("set %s commands: %v", lang, err)
+		}
+	}
 
-	log.Println("[info] start polling updates")
+	workers := b.config.WorkerCount
+	if workers <= 0 {
+		workers = defaultWorkerCount
+	}
+	pool := newWorkerPool(workers, func(update Update) {
+		// Errors are logged by the middleware.
+		_ = b.handle(ctx, update)
+	})
+	for update := range updates {
+		pool.submit(update)
+	}
+	pool.stop()
 
-	go func() {
-		<-ctx.Done()
-		b.api.StopReceivingUpdates()
-	}()
+	return nil
+}
 
-	for update := range updates {
-		switch {
-		case update.CallbackQuery != nil:
-			if err := b.handleCallback(ctx, update.CallbackQuery); err != nil {
-				log.Printf("handle callback: %v", err)
-			}
-		case update.Message != nil:
-			if update.Message.Chat == nil || !update.Message.Chat.IsPrivate() {
-				continue
-			}
-			if err := b.handleMessage(ctx, update.Message); err != nil {
-				log.Printf("handle message: %v", err)
-			}
+// Ping checks that the Bot API answers getMe for the bot's token. Errors never carry the
+// request URL, which holds the token.
+func (b *Bot) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(b.endpoint, b.api.Token, "getMe"), nil)
+	if err != nil {
+		return errors.New("telegram: bad endpoint")
+	}
+	resp, err := b.api.Client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
 		}
+		return fmt.Errorf("telegram: %w", err)
 	}
+	defer resp.Body.Close()
+	var answer tgbotapi.APIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return fmt.Errorf("telegram: %s", resp.Status)
+	}
+	if !answer.Ok {
+		return fmt.Errorf("telegram: %s", answer.Description)
+	}
+	return nil
+}
 
+// dispatch hands an update that made it through the middleware to its handler.
+func (b *Bot) dispatch(ctx context.Context, update Update) error {
+	switch {
+	case update.MessageReaction != nil:
+		return b.handleReaction(ctx, update.MessageReaction)
+	case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, cbCaptchaPrefix):
+		return b.handleCaptcha(ctx, update.CallbackQuery)
+	case update.CallbackQuery != nil:
+		return b.handleCallback(ctx, update.CallbackQuery)
+	case update.Message != nil && !update.Message.Chat.IsPrivate():
+		return b.handleGroupMessage(ctx, update.Message)
+	case update.Message != nil:
+		return b.handleMessage(ctx, update.Message)
+	}
 	return nil
 }
 
 func (b *Bot) handleMessage(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
 	if msg.From == nil {
 		return nil
 	}
@@ -151,10 +306,19 @@ func (b *Bot) handleMessage(ctx context.Context, msg *tgbotapi.Message) error {
 	if !msg.IsCommand() && isCancelDialogInput(msg.Text) {
 		b.clearConversation(msg.From.ID)
 		b.clearConfirmation(msg.From.ID)
-		return b.sendText(msg.Chat.ID, "⏪ Диалог создания задачи отменён. Я здесь, чтобы начать заново.")
+		return b.sendText(ctx, msg.Chat.ID, lang.T("⏪ Диалог создания задачи отменён. Я здесь, чтобы начать заново."))
+	}
+
+	if msg.Document != nil && !b.describing(msg.From.ID) {
+		return b.handleDocument(ctx, msg)
 	}
 
 	if !msg.IsCommand() {
+		if !b.inDialog(msg.From.ID) {
+			if handled, err := b.handleQuickReply(ctx, msg); handled {
+				return err
+			}
+		}
 		if handled, err := b.handleMenuAlias(ctx, msg); handled {
 			return err
 		}
@@ -174,372 +338,211 @@ func (b *Bot) handleMessage(ctx context.Context, msg *tgbotapi.Message) error {
 		return b.handleConversation(ctx, msg)
 	}
 
-	return b.sendText(msg.Chat.ID, "Я пока не понял сообщение. Набери /newtask, чтобы добавить задачу, или /help для списка команд.")
-}
-
-func (b *Bot) handleCommand(ctx context.Context, msg *tgbotapi.Message) error {
-	switch msg.Command() {
-	case "start":
-		return b.handleStartV2(ctx, msg)
-	case "help":
-		return b.handleHelpV3(msg)
-	case "report":
-		return b.handleReport(ctx, msg)
-	case "delete":
-		return b.handleDelete(ctx, msg)
-	case "newtask":
-		return b.startNewTaskConversation(ctx, msg)
-	case "tasks":
-		return b.handleListTasks(ctx, msg)
-	case "complete":
-		return b.handleComplete(ctx, msg)
-	case "categories":
-		return b.handleCategories(ctx, msg)
-	case "interval":
-		return b.handleInterval(msg)
-	case "cancel":
-		b.clearConversation(msg.From.ID)
-		return b.sendText(msg.Chat.ID, "⏪ Диалог создания задачи отменён.")
-	default:
-		return b.sendText(msg.Chat.ID, "Команда не поддерживается. Загляни в /help.")
-	}
-}
-
-// Новые варианты /start, /help и тестового отчёта.
-func (b *Bot) handleStartV2(ctx context.Context, msg *tgbotapi.Message) error {
-	if _, err := b.ensureUser(ctx, msg.From); err != nil {
-		return err
-	}
-
-	name := strings.TrimSpace(msg.From.FirstName)
-	if name == "" {
-		name = "друг"
-	}
-
-	text := fmt.Sprintf(
-		"👋 Привет, %s!\n<b>Я ежедневный планировщик: помогу не забыть задачи.</b>\n\nКоманды:\n"+
-			"• /newtask — добавить новую задачу\n"+
-			"• /tasks — показать текущие задачи\n"+
-			"• /complete &lt;id&gt; — отметить задачу выполненной\n"+
-			"• /categories — список категорий\n"+
-			"• /interval &lt;часы&gt; — интервал отчётов\n"+
-			"• /report — тестовый ежедневный отчёт\n"+
-			"• /help — подсказки\n"+
-			"• /cancel — отменить текущий ввод",
-		escape(name),
-	)
-
-	return b.sendText(msg.Chat.ID, text)
-}
-
-func (b *Bot) handleHelpV3(msg *tgbotapi.Message) error {
-	text := "ℹ️ <b>Подсказки</b>\n" +
-		"• /newtask — добавить задачу пошагово\n" +
-		"• /tasks — показать активные задачи и завершить по кнопке\n" +
-		"• /complete &lt;id&gt; — отметить задачу по номеру (например, /complete 3)\n" +
-		"• /delete &lt;id&gt; — удалить задачу полностью\n" +
-		"• /categories — посмотреть доступные категории\n" +
-		"• /interval &lt;часы&gt; — как часто присылать отчёт (по умолчанию 5 часов)\n" +
-		"• /report — отправить тестовый ежедневный отчёт\n" +
-		"• /cancel — отменить текущий ввод"
-	return b.sendText(msg.Chat.ID, text)
-}
-
-func (b *Bot) handleReport(ctx context.Context, msg *tgbotapi.Message) error {
-	user, err := b.ensureUser(ctx, msg.From)
-	if err != nil {
+	if handled, err := b.handleSnoozeReply(ctx, msg); handled {
 		return err
 	}
-	text, err := b.reminderSvc.DailySummary(ctx, *user, time.Now())
-	if err != nil {
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Не удалось сформировать отчёт: %s", escape(err.Error())))
-	}
-	return b.sendText(msg.Chat.ID, text)
-}
 
-func (b *Bot) startNewTaskConversation(ctx context.Context, msg *tgbotapi.Message) error {
-	if _, err := b.ensureUser(ctx, msg.From); err != nil {
-		return err
-	}
-	log.Printf("[info] start new task conversation user=%d", msg.From.ID)
-	b.setConversation(msg.From.ID, &conversationState{stage: stageTitle})
-	return b.sendWithReplyMarkup(msg.Chat.ID, "🆕 Создаём новую задачу.\n<b>Шаг 1:</b> как её назвать?", cancelKeyboard())
+	return b.sendText(ctx, msg.Chat.ID, lang.T("Я пока не понял сообщение. Набери /newtask, чтобы добавить задачу, или /help для списка команд."))
 }
 
+// handleConversation passes the message to the feature that owns the current dialog step.
 func (b *Bot) handleConversation(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
 	state := b.getConversation(msg.From.ID)
 	if state == nil {
 		return nil
 	}
-
-	text := strings.TrimSpace(msg.Text)
-	switch state.stage {
-	case stageTitle:
-		state.input.Title = text
-		state.stage = stageDescription
-		return b.sendWithReplyMarkup(msg.Chat.ID, "✏️ Добавь короткое описание (или нажми «Пропустить»).", skipKeyboard())
-	case stageDescription:
-		if !isSkipInput(text) {
-			state.input.Description = text
-		}
-		state.stage = stageCategory
-		return b.sendWithReplyMarkup(msg.Chat.ID, "🏷 Выбери категорию или отправь свою (можно «Пропустить»).", categoryKeyboard())
-	case stageCategory:
-		if !isSkipInput(text) {
-			state.input.Category = text
-		}
-		state.stage = stageDeadline
-		return b.sendWithReplyMarkup(msg.Chat.ID, "⏰ Укажи дедлайн в формате <code>2025-11-30</code> (или «Пропустить»).", skipKeyboard())
-	case stageDeadline:
-		if !isSkipInput(text) {
-			parsed, err := time.Parse("2006-01-02", text)
-			if err != nil {
-				return b.sendWithReplyMarkup(msg.Chat.ID, "Не могу распознать дату. Используй формат <code>2025-11-30</code> или «Пропустить».", skipKeyboard())
-			}
-			state.input.Deadline = &parsed
-		}
-		state.stage = stageRecurring
-		return b.sendWithReplyMarkup(msg.Chat.ID, "🔁 Сделать задачу повторяющейся каждый месяц?", yesNoKeyboard())
-	case stageRecurring:
-		lower := strings.ToLower(text)
-		if lower == "да" || lower == "yes" || lower == "y" {
-			state.input.IsRecurring = true
-			state.stage = stageRecurringDay
-			return b.sendWithReplyMarkup(msg.Chat.ID, "📆 В какой день месяца напоминать? (1–31). Если числа нет в месяце, возьмём последний день.", tgbotapi.NewRemoveKeyboard(true))
-		}
-		if lower == "нет" || lower == "no" || lower == "n" || lower == "-" {
-			state.input.IsRecurring = false
-			err := b.finishTaskCreation(ctx, msg.From, state.input, msg.Chat.ID)
-			b.clearConversation(msg.From.ID)
-			return err
-		}
-		return b.sendWithReplyMarkup(msg.Chat.ID, "Нажми «Да» или «Нет».", yesNoKeyboard())
-	case stageRecurringDay:
-		day, err := strconv.Atoi(text)
-		if err != nil || day < 1 || day > 31 {
-			return b.sendText(msg.Chat.ID, "День должен быть числом от 1 до 31.")
-		}
-		state.input.RecurDay = day
-		state.stage = stageRecurringWindow
-		return b.sendWithReplyMarkup(msg.Chat.ID, "⏳ Сколько дней до/после даты считать окном выполнения? (например, 2)", tgbotapi.NewRemoveKeyboard(true))
-	case stageRecurringWindow:
-		window, err := strconv.Atoi(text)
-		if err != nil || window < 0 || window > 14 {
-			return b.sendText(msg.Chat.ID, "Окно должно быть числом от 0 до 14.")
-		}
-		state.input.RecurWindow = window
-		err = b.finishTaskCreation(ctx, msg.From, state.input, msg.Chat.ID)
+	handle, ok := b.router.findConversation(state.stage)
+	if !ok {
 		b.clearConversation(msg.From.ID)
-		return err
-	default:
-		b.clearConversation(msg.From.ID)
-		return b.sendText(msg.Chat.ID, "Диалог сброшен. Попробуй ещё раз через /newtask.")
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Диалог сброшен. Попробуй ещё раз через /newtask."))
 	}
+	return handle(ctx, msg, state)
 }
 
-func (b *Bot) finishTaskCreation(ctx context.Context, from *tgbotapi.User, input service.TaskInput, chatID int64) error {
-	user, err := b.ensureUser(ctx, from)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.CreateTask(ctx, user, input)
-	if err != nil {
-		return b.sendText(chatID, fmt.Sprintf("Не удалось сохранить задачу: %s", escape(err.Error())))
+func (b *Bot) handleCommand(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	route, ok := b.router.findCommand(msg.Command())
+	if !ok {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Команда не поддерживается. Загляни в /help."))
 	}
+	return route.handle(ctx, msg)
+}
 
-	log.Printf("[info] task created id=%d user=%d recurring=%t", task.ID, user.ID, task.IsRecurring)
-
-	var summary strings.Builder
-	summary.WriteString("✅ <b>Задача сохранена</b>\n")
-	summary.WriteString(fmt.Sprintf("• <b>ID:</b> %d\n", task.ID))
-	summary.WriteString(fmt.Sprintf("• <b>Название:</b> %s\n", escape(normalizeTitle(task.Title))))
-	if task.Description != "" {
-		summary.WriteString(fmt.Sprintf("• <b>Описание:</b> %s\n", escape(task.Description)))
-	}
-	if task.Deadline != nil {
-		summary.WriteString(fmt.Sprintf("• <b>Дедлайн:</b> %s\n", task.Deadline.Format("2006-01-02")))
-	}
-	if task.IsRecurring {
-		summary.WriteString(fmt.Sprintf("• <b>Повтор:</b> каждый месяц %d числа (окно +%d дн.)\n", task.RecurDay, task.RecurWindow))
+// Новые варианты /start, /help и тестового отчёта.
+func (b *Bot) handleStartV2(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	if code, ok := strings.CutPrefix(msg.CommandArguments(), joinPayload); ok {
+		return b.joinByLink(ctx, msg, code)
 	}
-
-	msg := tgbotapi.NewMessage(chatID, strings.TrimSpace(summary.String()))
-	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
-	msg.ParseMode = tgbotapi.ModeHTML
-	if _, err := b.api.Send(msg); err != nil {
-		return err
+	if id, ok := strings.CutPrefix(msg.CommandArguments(), taskPayload); ok {
+		if taskID, err := strconv.ParseUint(id, 10, 64); err == nil {
+			return b.openTaskCard(ctx, msg.Chat.ID, msg.From, uint(taskID))
+		}
 	}
-	return b.sendTaskList(ctx, chatID, user)
-}
-
-func (b *Bot) handleListTasks(ctx context.Context, msg *tgbotapi.Message) error {
-	user, err := b.ensureUser(ctx, msg.From)
-	if err != nil {
+	if _, err := b.ensureUser(ctx, msg.From); err != nil {
 		return err
 	}
 
-	log.Printf("[info] list tasks for user=%d", user.ID)
-	return b.sendTaskList(ctx, msg.Chat.ID, user)
-}
-
-func (b *Bot) handleComplete(ctx context.Context, msg *tgbotapi.Message) error {
-	args := strings.TrimSpace(msg.CommandArguments())
-	if args == "" {
-		return b.sendText(msg.Chat.ID, "Укажи ID задачи: /complete 12")
+	name := strings.TrimSpace(msg.From.FirstName)
+	if name == "" {
+		name = lang.T("друг")
 	}
 
-	taskID64, err := strconv.ParseUint(args, 10, 64)
-	if err != nil {
-		return b.sendText(msg.Chat.ID, "ID задачи должен быть числом.")
-	}
+	text := fmt.Sprintf(
+		lang.T("👋 Привет, %s!\n<b>Я ежедневный планировщик: помогу не забыть задачи.</b>\n\nКоманды:\n")+
+			lang.T("• /newtask — добавить новую задачу\n")+
+			lang.T("• /tasks — показать текущие задачи\n")+
+			lang.T("• /complete &lt;id&gt; — отметить задачу выполненной\n")+
+			lang.T("• /categories — список категорий\n")+
+			lang.T("• /interval &lt;часы&gt; — интервал отчётов\n")+
+			lang.T("• /report — тестовый ежедневный отчёт\n")+
+			lang.T("• /help — подсказки\n")+
+			lang.T("• /cancel — отменить текущий ввод"),
+		escape(name),
+	)
 
-	user, err := b.ensureUser(ctx, msg.From)
-	if err != nil {
-		return err
+	return b.sendText(ctx, msg.Chat.ID, text)
+}
+
+func (b *Bot) handleHelpV3(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	text := lang.T("ℹ️ <b>Подсказки</b>\n") +
+		lang.T("• /newtask — добавить задачу пошагово\n") +
+		lang.T("• /tasks — показать активные задачи и завершить по кнопке\n") +
+		lang.T("• /dashboard — закреплённая панель задач, которая обновляется сама; /dashboard off — открепить\n") +
+		lang.T("• /complete &lt;id&gt; — отметить задачу по номеру (например, /complete 3)\n") +
+		lang.T("• /delete &lt;id&gt; — удалить задачу (она попадёт в корзину)\n") +
+		lang.T("• /trash — корзина: удалённые за 30 дней задачи с кнопкой «Вернуть»\n") +
+		lang.T("• /task &lt;id&gt; или 🔎 в списке — карточка задачи: описание, подзадачи, история и кнопки «Отложить», «Напомнить», «Подзадача»\n") +
+		lang.T("• 📤 Поделиться в карточке — аккуратная карточка задачи, которую можно переслать в любой чат\n") +
+		lang.T("• /edit &lt;id&gt; — изменить название, описание, категорию, дедлайн или повтор задачи\n") +
+		lang.T("• /fields — свои поля задач (текст, число, дата): /fields add сумма число\n") +
+		lang.T("• /remind &lt;id&gt; завтра 9:00 — напомнить о задаче в точное время\n") +
+		lang.T("• /postpone &lt;id&gt; 1d — отложить дедлайн на день (2w — на две недели, или дата)\n") +
+		lang.T("• /categories — посмотреть доступные категории\n") +
+		lang.T("• /category route — отправлять напоминания категории в отдельный чат\n") +
+		lang.T("• /category defaults &lt;категория&gt; +3d 12h — дедлайн и напоминание для новых задач\n") +
+		lang.T("• /category del &lt;категория&gt; — удалить категорию, перенеся или архивировав задачи\n") +
+		lang.T("• /category archive &lt;категория&gt; — убрать категорию в архив, /categories archived — вернуть\n") +
+		lang.T("• /category style &lt;категория&gt; — сменить значок и цвет категории\n") +
+		lang.T("• /interval &lt;часы&gt; — как часто присылать отчёт (по умолчанию 5 часов)\n") +
+		lang.T("• /delivery chat|silent|pin — присылать отчёт сообщением, без звука или обновлять закреплённое\n") +
+		lang.T("• /report — отправить тестовый ежедневный отчёт\n") +
+		lang.T("• /link — привязать второй Telegram-аккаунт к своим задачам\n") +
+		lang.T("• /workspace — общие пространства для семьи или команды\n") +
+		lang.T("• /buddy @username — партнёр, который узнает о просроченных задачах с отметкой /buddy watch &lt;id&gt;\n") +
+		lang.T("• /ics &lt;ссылка&gt; — подписаться на календарь (или пришли .ics-файл)\n") +
+		lang.T("• /quota — лимиты на задачи, категории и файлы\n") +
+		lang.T("• /contacts — дни рождения и важные даты, /contact add 15.03 Маша — добавить\n") +
+		lang.T("• /med add Витамин D 9:00 21:00 — напоминать о приёме лекарств, /meds — расписание\n") +
+		lang.T("• /stats — статистика, в том числе соблюдение режима приёма\n") +
+		lang.T("• /weekly — итоги недели; /weekly on — присылать их по воскресеньям\n") +
+		lang.T("• /heatmap [ММ.ГГГГ] — карта выполненных задач за месяц; /heatmap image — картинкой\n") +
+		lang.T("• /counter add 8 Стаканы воды — счётчик с целью на день, /counters — отметить +1\n") +
+		lang.T("• /calendarweek — неделя сеткой, по дням — задачи дня\n") +
+		lang.T("• /done [дней] — выполненные задачи по дням, с кнопкой «Вернуть»\n") +
+		lang.T("• /archive — задачи в архиве, /archive restore &lt;id&gt; — вернуть\n") +
+		lang.T("• /timezone Europe/Moscow — часовой пояс для времени напоминаний\n") +
+		lang.T("• /language en — язык бота: English или русский\n") +
+		lang.T("• /emoji — быстрые ответы: ✅ отмечает последнюю показанную задачу, 📋 — список, ➕ — новая задача\n") +
+		lang.T("• /grace 03:00 — считать задачи просроченными не в полночь, а в 3 часа ночи после дня дедлайна\n") +
+		lang.T("• /workhours 9-18 — рабочие часы: по ним считаются «утром», «вечером», «после работы»\n") +
+		lang.T("• /countdown on — за час до срока напоминания показывают, сколько осталось\n") +
+		lang.T("• /autodelete 5 — удалять подтверждения и уведомления о выполнении через 5 минут\n") +
+		lang.T("• /settings export — файл настроек для переноса на другой сервер\n") +
+		lang.T("• /add Купить молоко #покупки !high @завтра — задача одной строкой\n") +
+		lang.T("• /tasks high — только задачи с высоким и срочным приоритетом\n") +
+		lang.T("• /search молоко — найти открытые задачи по названию или описанию\n") +
+		lang.T("• /cancel — отменить текущий ввод")
+	return b.sendText(ctx, msg.Chat.ID, text)
+}
+
+// ensureUser registers the sender and returns the user whose data the sender works with.
+// For linked Telegram accounts this is the primary user of the shared account.
+func (b *Bot) ensureUser(ctx context.Context, from *tgbotapi.User) (*model.User, error) {
+	if current := loadedUser(ctx, from); current != nil {
+		return current.owner, nil
 	}
-
-	task, err := b.taskSvc.CompleteTask(ctx, user, uint(taskID64), time.Now())
+	user, err := b.telegramUser(ctx, from)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendText(msg.Chat.ID, "Задача не найдена.")
-		}
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
+		return nil, err
 	}
-
-	if task.IsRecurring {
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("✅ Повторяющаяся задача «%s» отмечена выполненной в этом окне.", escape(normalizeTitle(task.Title))))
-	}
-
-	return b.sendText(msg.Chat.ID, fmt.Sprintf("✅ Задача «%s» выполнена.", escape(normalizeTitle(task.Title))))
+	return b.accountSvc.Owner(ctx, user)
 }
 
-func (b *Bot) handleCategories(ctx context.Context, msg *tgbotapi.Message) error {
-	user, err := b.ensureUser(ctx, msg.From)
-	if err != nil {
-		return err
-	}
-	categories, err := b.categorySvc.List(ctx, user)
-	if err != nil {
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Не удалось получить категории: %s", escape(err.Error())))
-	}
-	if len(categories) == 0 {
-		return b.sendText(msg.Chat.ID, "Категории пока пусты. Добавь их при создании задачи.")
-	}
-	var builder strings.Builder
-	builder.WriteString("📂 <b>Категории</b>\n")
-	for _, cat := range categories {
-		builder.WriteString(fmt.Sprintf("• %s\n", escape(strings.TrimSpace(cat.Name))))
+// telegramUser registers the sender and returns their own user record.
+func (b *Bot) telegramUser(ctx context.Context, from *tgbotapi.User) (*model.User, error) {
+	if current := loadedUser(ctx, from); current != nil {
+		return current.self, nil
 	}
-	return b.sendText(msg.Chat.ID, strings.TrimSpace(builder.String()))
+	return b.userRepo.UpsertFromTelegram(ctx, from.ID, from.FirstName, from.LastName, from.UserName, from.LanguageCode)
 }
 
-func (b *Bot) handleConfirmationResponse(ctx context.Context, msg *tgbotapi.Message, req confirmationRequest) error {
-	text := strings.TrimSpace(msg.Text)
-	switch {
-	case isConfirmInput(text):
-		b.clearConfirmation(msg.From.ID)
-		if req.action == actionDelete {
-			return b.deleteTaskAndRefresh(ctx, msg.Chat.ID, msg.From, req.taskID)
-		}
-		return b.completeTaskAndRefresh(ctx, msg.Chat.ID, msg.From, req.taskID)
-	case isCancelInput(text):
-		b.clearConfirmation(msg.From.ID)
-		return b.sendMenuPlaceholder(msg.Chat.ID)
-	default:
-		var prompt string
-		if req.action == actionDelete {
-			prompt = "Подтверди или отмени удаление задачи."
-		} else {
-			prompt = "Подтверди или отмени выполнение задачи."
-		}
-		return b.sendWithReplyMarkup(msg.Chat.ID, prompt, confirmKeyboard())
-	}
+func (b *Bot) sendText(ctx context.Context, chatID int64, text string) error {
+	lang := i18n.FromContext(ctx)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = mainMenuKeyboard(lang)
+	_, err := b.send(ctx, msg)
+	return err
 }
 
-// SendDailyReports sends a summary to every known user.
-func (b *Bot) SendDailyReports(ctx context.Context) error {
-	users, err := b.userRepo.ListAll(ctx)
-	if err != nil {
+// sendTextWithRemove sends the message that closes a one-off keyboard, such as a confirmation;
+// the main menu takes the keyboard's place.
+func (b *Bot) sendTextWithRemove(ctx context.Context, chatID int64, text string) error {
+	lang := i18n.FromContext(ctx)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = b.closingMarkup(lang)
+	if _, err := b.send(ctx, msg); err != nil {
 		return err
 	}
-	now := time.Now()
-	for _, user := range users {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-		text, err := b.reminderSvc.DailySummary(ctx, user, now)
-		if err != nil {
-			log.Printf("build summary for user %d: %v", user.TelegramID, err)
-			continue
-		}
-		if err := b.sendText(user.TelegramID, text); err != nil {
-			log.Printf("send summary to %d: %v", user.TelegramID, err)
-		}
+	if b.config.LegacyMenuPlaceholder {
+		return b.sendMenuPlaceholder(ctx, chatID)
 	}
 	return nil
 }
 
-func (b *Bot) handleInterval(msg *tgbotapi.Message) error {
-	if msg.From == nil {
-		return nil
-	}
-	args := strings.TrimSpace(msg.CommandArguments())
-	if args == "" {
-		current := "5 часов"
-		if b.config != nil && b.config.ReportInterval > 0 {
-			current = fmt.Sprintf("%d часов", int(b.config.ReportInterval.Hours()))
-		}
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Текущий интервал отчётов: %s. Укажи число часов, например: /interval 4", current))
+// closingMarkup replaces a one-off keyboard with the main menu in the same message. With
+// LegacyMenuPlaceholder it just removes the keyboard, and the menu comes in a placeholder.
+func (b *Bot) closingMarkup(lang i18n.Lang) interface{} {
+	if b.config.LegacyMenuPlaceholder {
+		return tgbotapi.NewRemoveKeyboard(true)
 	}
-	hours, err := strconv.Atoi(args)
-	if err != nil || hours <= 0 {
-		return b.sendText(msg.Chat.ID, "Интервал должен быть положительным числом часов, например /interval 6")
-	}
-	b.mu.Lock()
-	b.config.ReportInterval = time.Duration(hours) * time.Hour
-	b.mu.Unlock()
-	return b.sendText(msg.Chat.ID, fmt.Sprintf("Интервал уведомлений обновлён: каждые %d часов.", hours))
-}
-
-func (b *Bot) ensureUser(ctx context.Context, from *tgbotapi.User) (*model.User, error) {
-	return b.userRepo.UpsertFromTelegram(ctx, from.ID, from.FirstName, from.LastName, from.UserName)
+	return mainMenuKeyboard(lang)
 }
 
-func (b *Bot) sendText(chatID int64, text string) error {
+func (b *Bot) sendWithReplyMarkup(ctx context.Context, chatID int64, text string, markup interface{}) error {
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = tgbotapi.ModeHTML
-	msg.ReplyMarkup = mainMenuKeyboard()
-	_, err := b.api.Send(msg)
+	msg.ReplyMarkup = markup
+	_, err := b.send(ctx, msg)
 	return err
 }
 
-func (b *Bot) sendTextWithRemove(chatID int64, text string) error {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = tgbotapi.ModeHTML
-	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
-	if _, err := b.api.Send(msg); err != nil {
-		return err
-	}
-	return b.sendMenuPlaceholder(chatID)
+// sendDocument uploads data as a file called name, with caption under it.
+func (b *Bot) sendDocument(ctx context.Context, chatID int64, name string, data []byte, caption string) error {
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: data})
+	doc.Caption = caption
+	doc.ParseMode = tgbotapi.ModeHTML
+	_, err := b.send(ctx, doc)
+	return err
 }
 
-func (b *Bot) sendWithReplyMarkup(chatID int64, text string, markup interface{}) error {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = tgbotapi.ModeHTML
-	msg.ReplyMarkup = markup
-	_, err := b.api.Send(msg)
-	return err
+func (b *Bot) sendMenuPlaceholder(ctx context.Context, chatID int64) error {
+	lang := i18n.FromContext(ctx)
+	return b.sendTransient(ctx, chatID, lang.T("🔹 Главное меню"), mainMenuKeyboard(lang))
 }
 
-func (b *Bot) sendMenuPlaceholder(chatID int64) error {
-	msg := tgbotapi.NewMessage(chatID, "🔹 Главное меню")
-	msg.ParseMode = tgbotapi.ModeHTML
-	msg.ReplyMarkup = mainMenuKeyboard()
-	_, err := b.api.Send(msg)
-	return err
+// restoreMenu brings the main menu back after a one-off keyboard was dismissed, with a short
+// note of what happened instead of the bare placeholder.
+func (b *Bot) restoreMenu(ctx context.Context, chatID int64, note string) error {
+	lang := i18n.FromContext(ctx)
+	if b.config.LegacyMenuPlaceholder {
+		return b.sendMenuPlaceholder(ctx, chatID)
+	}
+	return b.sendTransient(ctx, chatID, note, mainMenuKeyboard(lang))
 }
 
 func (b *Bot) getConfirmation(userID int64) (confirmationRequest, bool) {
@@ -586,310 +589,21 @@ func (b *Bot) clearConversation(userID int64) {
 	delete(b.conversations, userID)
 }
 
-func (b *Bot) sendTaskList(ctx context.Context, chatID int64, user *model.User) error {
-	tasks, err := b.taskSvc.ListActive(ctx, user)
-	if err != nil {
-		return b.sendText(chatID, fmt.Sprintf("Не удалось получить задачи: %s", escape(err.Error())))
-	}
-
-	categories, _ := b.categorySvc.List(ctx, user)
-	catNames := make(map[uint]string)
-	for _, cat := range categories {
-		catNames[cat.ID] = cat.Name
-	}
-
-	now := time.Now()
-	type categoryGroup struct {
-		Name  string
-		Tasks []model.Task
-	}
-
-	groups := make(map[string]*categoryGroup)
-	order := make([]string, 0, len(tasks))
-
-	for _, task := range tasks {
-		if !task.IsRecurring && task.IsCompleted {
-			continue
-		}
-		key, display := normalizedCategory(task.CategoryID, catNames)
-		group, ok := groups[key]
-		if !ok {
-			group = &categoryGroup{Name: display}
-			groups[key] = group
-			order = append(order, key)
-		}
-		groups[key].Tasks = append(groups[key].Tasks, task)
-	}
-
-	if len(groups) == 0 {
-		return b.sendText(chatID, "У тебя нет активных задач. Добавь новую через /newtask.")
-	}
-
-	sort.Slice(order, func(i, j int) bool {
-		if order[i] == noCategoryKey {
-			return false
-		}
-		if order[j] == noCategoryKey {
-			return true
-		}
-		return strings.Compare(groups[order[i]].Name, groups[order[j]].Name) < 0
-	})
-
-	var builder strings.Builder
-	builder.WriteString("📋 <b>Текущие задачи</b>\n")
-	builder.WriteString("Нажми на кнопку, чтобы отметить задачу выполненной или удалить повторяющуюся.\n\n")
-
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, key := range order {
-		section := groups[key]
-		sort.SliceStable(section.Tasks, func(i, j int) bool {
-			a := section.Tasks[i]
-			b := section.Tasks[j]
-			if a.Deadline != nil && b.Deadline != nil {
-				if !a.Deadline.Equal(*b.Deadline) {
-					return a.Deadline.Before(*b.Deadline)
-				}
-			} else if a.Deadline != nil {
-				return true
-			} else if b.Deadline != nil {
-				return false
-			}
-			if a.IsRecurring != b.IsRecurring {
-				return !a.IsRecurring && b.IsRecurring
-			}
-			return a.ID < b.ID
-		})
-
-		builder.WriteString(fmt.Sprintf("<b>%s</b>\n", section.Name))
-		for _, task := range section.Tasks {
-			var row []tgbotapi.InlineKeyboardButton
-			if task.IsRecurring {
-				builder.WriteString(formatRecurringTask(task, now))
-				row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("\u2705 #%d · %s", task.ID, shortTitle(task.Title, 20)), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)))
-				row = append(row, tgbotapi.NewInlineKeyboardButtonData("\U0001F5D1 Удалить", fmt.Sprintf("%s%d", cbDeletePrefix, task.ID)))
-			} else {
-				builder.WriteString(formatTask(task, now))
-				row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("\u2705 #%d · %s", task.ID, shortTitle(task.Title, 24)), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)))
-			}
-			buttons = append(buttons, row)
-		}
-		builder.WriteByte('\n')
-	}
-
-	msg := tgbotapi.NewMessage(chatID, strings.TrimSpace(builder.String()))
-	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
-	msg.ParseMode = tgbotapi.ModeHTML
-	_, err = b.api.Send(msg)
-	return err
-}
-
 func (b *Bot) handleCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 	if cb == nil || cb.From == nil || cb.Message == nil {
 		return nil
 	}
 
-	data := cb.Data
-
-	switch {
-	case strings.HasPrefix(data, cbCompletePrefix):
-		log.Printf("[info] callback complete request user=%d task=%s", cb.From.ID, strings.TrimPrefix(data, cbCompletePrefix))
-		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
+	route, payload, ok := b.router.findCallback(cb.Data)
+	if !route.selfAck {
+		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, route.ack)); err != nil {
 			log.Printf("callback ack: %v", err)
 		}
-		taskID, err := parseTaskID(data, cbCompletePrefix)
-		if err != nil {
-			return nil
-		}
-		return b.askCompleteConfirmation(ctx, cb.Message.Chat.ID, cb.From, taskID)
-	case strings.HasPrefix(data, cbDeletePrefix):
-		log.Printf("[info] callback delete request user=%d task=%s", cb.From.ID, strings.TrimPrefix(data, cbDeletePrefix))
-		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
-			log.Printf("callback ack: %v", err)
-		}
-		taskID, err := parseTaskID(data, cbDeletePrefix)
-		if err != nil {
-			return nil
-		}
-		return b.askDeleteConfirmation(ctx, cb.Message.Chat.ID, cb.From, taskID)
-	case strings.HasPrefix(data, cbConfirmPrefix):
-		log.Printf("[info] callback confirm complete user=%d task=%s", cb.From.ID, strings.TrimPrefix(data, cbConfirmPrefix))
-		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
-			log.Printf("callback ack: %v", err)
-		}
-		taskID, err := parseTaskID(data, cbConfirmPrefix)
-		if err != nil {
-			return nil
-		}
-		return b.completeTaskAndRefresh(ctx, cb.Message.Chat.ID, cb.From, taskID)
-	case strings.HasPrefix(data, cbCancelPrefix):
-		log.Printf("[info] callback cancel complete user=%d task=%s", cb.From.ID, strings.TrimPrefix(data, cbCancelPrefix))
-		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
-			log.Printf("callback ack: %v", err)
-		}
-		return nil
-	default:
-		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
-			log.Printf("callback ack: %v", err)
-		}
-		return nil
-	}
-}
-
-func (b *Bot) askCompleteConfirmation(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
-	user, err := b.ensureUser(ctx, from)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.GetTask(ctx, user, taskID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendText(chatID, "Задача не найдена.")
-		}
-		return err
-	}
-
-	if task.IsRecurring {
-		if isRecurringDoneInWindow(*task, time.Now()) {
-			return b.sendText(chatID, "Задача уже отмечена выполненной в этом окне.")
-		}
-	} else if task.IsCompleted {
-		return b.sendText(chatID, "Задача уже выполнена.")
-	}
-
-	text := fmt.Sprintf("Отметить задачу «%s» (#%d) как выполненную?", escape(normalizeTitle(task.Title)), task.ID)
-	b.setConfirmation(from.ID, confirmationRequest{taskID: task.ID, action: actionComplete})
-	return b.sendWithReplyMarkup(chatID, text, confirmKeyboard())
-}
-
-func (b *Bot) askDeleteConfirmation(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
-	user, err := b.ensureUser(ctx, from)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.GetTask(ctx, user, taskID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendText(chatID, "Задача не найдена.")
-		}
-		return err
-	}
-
-	text := fmt.Sprintf("Удалить задачу \"%s\" (#%d)?", escape(normalizeTitle(task.Title)), task.ID)
-	b.setConfirmation(from.ID, confirmationRequest{taskID: task.ID, action: actionDelete})
-	return b.sendWithReplyMarkup(chatID, text, confirmKeyboard())
-}
-
-func (b *Bot) completeTaskAndRefresh(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
-	user, err := b.ensureUser(ctx, from)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.GetTask(ctx, user, taskID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendTextWithRemove(chatID, "Задача не найдена или уже удалена.")
-		}
-		return b.sendTextWithRemove(chatID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
-	}
-
-	now := time.Now()
-	if task.IsRecurring && isRecurringDoneInWindow(*task, now) {
-		return b.sendTextWithRemove(chatID, "Эта повторяющаяся задача уже закрыта в текущем окне.")
-	}
-	if !task.IsRecurring && task.IsCompleted {
-		return b.sendTextWithRemove(chatID, "Задача уже была выполнена.")
-	}
-
-	task, err = b.taskSvc.CompleteTask(ctx, user, taskID, now)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendTextWithRemove(chatID, "Задача не найдена или уже удалена.")
-		}
-		return b.sendTextWithRemove(chatID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
-	}
-
-	var info string
-	if task.IsRecurring {
-		info = fmt.Sprintf("♻️ Задача «%s» отмечена выполненной в этом окне.", escape(normalizeTitle(task.Title)))
-	} else {
-		info = fmt.Sprintf("✅ Задача «%s» выполнена.", escape(normalizeTitle(task.Title)))
-	}
-	log.Printf("[info] task completed id=%d user=%d recurring=%t", task.ID, user.ID, task.IsRecurring)
-	if err := b.sendTextWithRemove(chatID, info); err != nil {
-		return err
-	}
-
-	return b.sendTaskList(ctx, chatID, user)
-}
-
-func (b *Bot) deleteTaskAndRefresh(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
-	user, err := b.ensureUser(ctx, from)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.GetTask(ctx, user, taskID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendTextWithRemove(chatID, "Задача не найдена или уже удалена.")
-		}
-		return b.sendTextWithRemove(chatID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
-	}
-
-	if err := b.taskSvc.DeleteTask(ctx, user, taskID); err != nil {
-		return b.sendTextWithRemove(chatID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
-	}
-
-	log.Printf("[info] task deleted id=%d user=%d", task.ID, user.ID)
-	if err := b.sendTextWithRemove(chatID, fmt.Sprintf("\U0001F5D1 Задача \"%s\" удалена.", escape(normalizeTitle(task.Title)))); err != nil {
-		return err
-	}
-
-	return b.sendTaskList(ctx, chatID, user)
-}
-
-func parseTaskID(data, prefix string) (uint, error) {
-	raw := strings.TrimPrefix(data, prefix)
-	value, err := strconv.ParseUint(raw, 10, 64)
-	if err != nil {
-		return 0, err
 	}
-	return uint(value), nil
-}
-
-// handleDelete удаляет задачу полностью (включая повторяющиеся).
-func (b *Bot) handleDelete(ctx context.Context, msg *tgbotapi.Message) error {
-	args := strings.TrimSpace(msg.CommandArguments())
-	if args == "" {
-		return b.sendText(msg.Chat.ID, "Укажи ID задачи: /delete 12")
-	}
-
-	taskID64, err := strconv.ParseUint(args, 10, 64)
-	if err != nil {
-		return b.sendText(msg.Chat.ID, "ID задачи должен быть числом.")
-	}
-
-	user, err := b.ensureUser(ctx, msg.From)
-	if err != nil {
-		return err
-	}
-
-	task, err := b.taskSvc.GetTask(ctx, user, uint(taskID64))
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return b.sendText(msg.Chat.ID, "Задача не найдена.")
-		}
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Ошибка: %s", escape(err.Error())))
-	}
-
-	if err := b.taskSvc.DeleteTask(ctx, user, uint(taskID64)); err != nil {
-		return b.sendText(msg.Chat.ID, fmt.Sprintf("Не удалось удалить задачу: %s", escape(err.Error())))
+	if !ok {
+		return nil
 	}
-
-	return b.sendText(msg.Chat.ID, fmt.Sprintf("🗑 Задача \"%s\" удалена.", escape(normalizeTitle(task.Title))))
+	return route.handle(ctx, cb, payload)
 }
 
 func shortTitle(title string, maxLen int) string {
@@ -906,27 +620,27 @@ func shortTitle(title string, maxLen int) string {
 }
 
 func (b *Bot) handleMenuAlias(ctx context.Context, msg *tgbotapi.Message) (bool, error) {
-	text := strings.TrimSpace(strings.ToLower(msg.Text))
-	switch text {
-	case strings.ToLower(menuLabelNewTask):
+	text := strings.TrimSpace(msg.Text)
+	switch {
+	case i18n.Matches(text, menuLabelNewTask):
 		return true, b.startNewTaskConversation(ctx, msg)
-	case strings.ToLower(menuLabelTasks):
+	case i18n.Matches(text, menuLabelTasks):
 		return true, b.handleListTasks(ctx, msg)
-	case strings.ToLower(menuLabelCategories):
+	case i18n.Matches(text, menuLabelCategories):
 		return true, b.handleCategories(ctx, msg)
-	case strings.ToLower(menuLabelHelp):
-		return true, b.handleHelpV3(msg)
+	case i18n.Matches(text, menuLabelHelp):
+		return true, b.handleHelpV3(ctx, msg)
 	default:
 		return false, nil
 	}
 }
 
-func confirmKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func confirmKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnConfirm),
-			tgbotapi.NewKeyboardButton(btnCancel),
-			tgbotapi.NewKeyboardButton(btnCancelDialog),
+			tgbotapi.NewKeyboardButton(lang.T(btnConfirm)),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancel)),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
 		),
 	)
 	kb.ResizeKeyboard = true
@@ -934,26 +648,16 @@ func confirmKeyboard() tgbotapi.ReplyKeyboardMarkup {
 	return kb
 }
 
-func mainMenuKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func recurringDeleteKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(menuLabelNewTask),
-			tgbotapi.NewKeyboardButton(menuLabelTasks),
+			tgbotapi.NewKeyboardButton(lang.T(btnEndRecurrence)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(menuLabelCategories),
-			tgbotapi.NewKeyboardButton(menuLabelHelp),
+			tgbotapi.NewKeyboardButton(lang.T(btnDeleteHistory)),
 		),
-	)
-	kb.ResizeKeyboard = true
-	kb.OneTimeKeyboard = false
-	return kb
-}
-
-func cancelKeyboard() tgbotapi.ReplyKeyboardMarkup {
-	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnCancelDialog),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancel)),
 		),
 	)
 	kb.ResizeKeyboard = true
@@ -961,26 +665,26 @@ func cancelKeyboard() tgbotapi.ReplyKeyboardMarkup {
 	return kb
 }
 
-func skipKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func mainMenuKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnSkip),
+			tgbotapi.NewKeyboardButton(lang.T(menuLabelNewTask)),
+			tgbotapi.NewKeyboardButton(lang.T(menuLabelTasks)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnCancelDialog),
+			tgbotapi.NewKeyboardButton(lang.T(menuLabelCategories)),
+			tgbotapi.NewKeyboardButton(lang.T(menuLabelHelp)),
 		),
 	)
 	kb.ResizeKeyboard = true
-	kb.OneTimeKeyboard = true
+	kb.OneTimeKeyboard = false
 	return kb
 }
 
-func yesNoKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func cancelKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnYes),
-			tgbotapi.NewKeyboardButton(btnNo),
-			tgbotapi.NewKeyboardButton(btnCancelDialog),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
 		),
 	)
 	kb.ResizeKeyboard = true
@@ -988,19 +692,26 @@ func yesNoKeyboard() tgbotapi.ReplyKeyboardMarkup {
 	return kb
 }
 
-func categoryKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func skipKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Учеба"),
-			tgbotapi.NewKeyboardButton("Работа"),
+			tgbotapi.NewKeyboardButton(lang.T(btnSkip)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Покупки"),
-			tgbotapi.NewKeyboardButton("Здоровье"),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
 		),
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
+func yesNoKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(btnSkip),
-			tgbotapi.NewKeyboardButton(btnCancelDialog),
+			tgbotapi.NewKeyboardButton(lang.T(btnYes)),
+			tgbotapi.NewKeyboardButton(lang.T(btnNo)),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
 		),
 	)
 	kb.ResizeKeyboard = true
@@ -1008,119 +719,96 @@ func categoryKeyboard() tgbotapi.ReplyKeyboardMarkup {
 	return kb
 }
 
+// suggestedCategories are offered as buttons when a task is created.
+var suggestedCategories = []string{i18n.N("Учеба"), i18n.N("Работа"), i18n.N("Покупки"), i18n.N("Здоровье")}
+
+// categoryKeyboard suggests the common categories except the archived ones.
+func categoryKeyboard(lang i18n.Lang, archived map[string]bool) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	var row []tgbotapi.KeyboardButton
+	for _, name := range suggestedCategories {
+		name = lang.T(name)
+		if archived[strings.ToLower(name)] {
+			continue
+		}
+		row = append(row, tgbotapi.NewKeyboardButton(name))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton(lang.T(btnSkip)),
+		tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+	))
+	kb := tgbotapi.NewReplyKeyboard(rows...)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
 func isSkipInput(text string) bool {
 	value := strings.TrimSpace(strings.ToLower(text))
-	return value == "-" || value == strings.ToLower(btnSkip) || value == "пропустить" || value == "skip"
+	return value == "-" || i18n.Matches(value, btnSkip) || value == "пропустить" || value == "skip"
 }
 
 func isConfirmInput(text string) bool {
 	value := strings.TrimSpace(strings.ToLower(text))
-	return value == strings.ToLower(btnConfirm) || value == "подтвердить" || value == "да"
+	return i18n.Matches(value, btnConfirm) || value == "подтвердить" || value == "да" || value == "confirm" || value == "yes"
 }
 
-func isCancelInput(text string) bool {
+func isEndRecurrenceInput(text string) bool {
 	value := strings.TrimSpace(strings.ToLower(text))
-	return value == strings.ToLower(btnCancel) || value == "отмена"
+	return i18n.Matches(value, btnEndRecurrence) || value == "только будущие повторы" || value == "future only"
 }
 
-func isCancelDialogInput(text string) bool {
+func isDeleteHistoryInput(text string) bool {
 	value := strings.TrimSpace(strings.ToLower(text))
-	return value == strings.ToLower(btnCancelDialog) || value == "отменить ввод" || value == "отмена"
+	return i18n.Matches(value, btnDeleteHistory) || value == "полностью с историей"
 }
 
-func isRecurringDoneInWindow(task model.Task, now time.Time) bool {
-	if !task.IsRecurring || task.LastCompletedAt == nil {
-		return false
-	}
-
-	year, month, _ := now.Date()
-	dueDay := task.RecurDay
-	endOfMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, now.Location()).Day()
-	if dueDay > endOfMonth {
-		dueDay = endOfMonth
-	}
-
-	dueDate := time.Date(year, month, dueDay, 0, 0, 0, 0, now.Location())
-	window := time.Duration(task.RecurWindow) * 24 * time.Hour
-	start := dueDate.Add(-window)
-	end := dueDate.Add(window)
+func isCancelInput(text string) bool {
+	value := strings.TrimSpace(strings.ToLower(text))
+	return i18n.Matches(value, btnCancel) || value == "отмена" || value == "cancel"
+}
 
-	last := task.LastCompletedAt.In(now.Location())
-	if last.Before(start) || last.After(end) {
-		return false
-	}
-	if last.Month() != now.Month() || last.Year() != now.Year() {
-		return false
-	}
-	return true
+func isCancelDialogInput(text string) bool {
+	value := strings.TrimSpace(strings.ToLower(text))
+	return i18n.Matches(value, btnCancelDialog) || value == "отменить ввод" || value == "отмена" || value == "cancel"
 }
 
 func escape(s string) string {
 	return html.EscapeString(s)
 }
 
-func normalizedCategory(categoryID *uint, catNames map[uint]string) (string, string) {
-	if categoryID == nil {
-		return noCategoryKey, categoryLabel(noCategory)
-	}
-	if name, ok := catNames[*categoryID]; ok {
-		trimmed := strings.TrimSpace(name)
-		if trimmed == "" {
-			return noCategoryKey, categoryLabel(noCategory)
-		}
-		return strings.ToLower(trimmed), categoryLabel(trimmed)
+// errorText turns a service error into an escaped message for the user.
+func errorText(lang i18n.Lang, err error) string {
+	var quotaErr *service.QuotaError
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		return lang.T("недостаточно прав в этом пространстве")
+	case errors.As(err, &quotaErr):
+		return quotaText(lang, quotaErr)
+	default:
+		return escape(err.Error())
 	}
-	return noCategoryKey, categoryLabel(noCategory)
 }
 
-func formatTask(task model.Task, now time.Time) string {
-	var b strings.Builder
-	icon := iconDefault
-	if task.Deadline != nil {
-		d := task.Deadline.In(now.Location())
-		if now.After(d) {
-			icon = iconOverdue
-		} else if d.Sub(now) <= 48*time.Hour {
-			icon = iconDue
-		}
+func normalizedCategory(lang i18n.Lang, categoryID *uint, categories map[uint]model.Category) (string, string) {
+	if categoryID == nil {
+		return noCategoryKey, categoryLabel(noCategoryItem(lang))
 	}
-	b.WriteString(fmt.Sprintf("%s <b>#%d</b> %s\n", icon, task.ID, escape(normalizeTitle(task.Title))))
-	if task.Deadline != nil {
-		d := task.Deadline.In(now.Location())
-		if now.After(d) {
-			b.WriteString(fmt.Sprintf("   ⏰ Дедлайн: %s — <b>просрочено</b>\n", d.Format("2006-01-02")))
-		} else {
-			daysLeft := int(d.Sub(now).Hours()/24) + 1
-			b.WriteString(fmt.Sprintf("   ⏰ Дедлайн: %s · осталось ≈%d дн.\n", d.Format("2006-01-02"), daysLeft))
+	if category, ok := categories[*categoryID]; ok {
+		trimmed := strings.TrimSpace(category.Name)
+		if trimmed == "" {
+			return noCategoryKey, categoryLabel(noCategoryItem(lang))
 		}
+		return strings.ToLower(trimmed), categoryLabel(category)
 	}
-	if task.Description != "" {
-		b.WriteString(fmt.Sprintf("   📝 %s\n", escape(task.Description)))
-	}
-	b.WriteByte('\n')
-	return b.String()
-}
-
-func formatRecurringTask(task model.Task, now time.Time) string {
-	var b strings.Builder
-	b.WriteString(fmt.Sprintf("%s <b>#%d</b> %s\n", iconRecurring, task.ID, escape(normalizeTitle(task.Title))))
-
-	year, month, _ := now.Date()
-	dueDay := task.RecurDay
-	endOfMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, now.Location()).Day()
-	if dueDay > endOfMonth {
-		dueDay = endOfMonth
-	}
-	dueDate := time.Date(year, month, dueDay, 0, 0, 0, 0, now.Location())
-
-	b.WriteString(fmt.Sprintf("   🔄 Каждый месяц: %s (окно +%d дн.)\n", dueDate.Format("2006-01-02"), task.RecurWindow))
-	if task.LastCompletedAt != nil {
-		b.WriteString(fmt.Sprintf("   ✅ Последнее выполнение: %s\n", task.LastCompletedAt.In(now.Location()).Format("2006-01-02")))
-	} else {
-		b.WriteString("   ✅ Пока не выполнялась\n")
-	}
-	b.WriteByte('\n')
-	return b.String()
+	return noCategoryKey, categoryLabel(noCategoryItem(lang))
 }
 
 func normalizeTitle(value string) string {
@@ -1133,25 +821,15 @@ func normalizeTitle(value string) string {
 	return string(runes)
 }
 
-func categoryLabel(name string) string {
-	base := strings.TrimSpace(name)
-	lower := strings.ToLower(base)
-	var icon string
-	switch lower {
-	case "учеба":
-		icon = "🎓"
-	case "работа":
-		icon = "💼"
-	case "покупки":
-		icon = "🛒"
-	case "здоровье":
-		icon = "🩺"
-	case "личное":
-		icon = "🧩"
-	case strings.ToLower(noCategory):
-		icon = "📁"
-	default:
-		icon = "🏷️"
+// categoryLabel shows the category with its emoji and color mark.
+func categoryLabel(category model.Category) string {
+	icon := category.Emoji
+	if icon == "" {
+		icon = model.CategoryEmojiFallback
+	}
+	label := fmt.Sprintf("%s %s", icon, escape(normalizeTitle(strings.TrimSpace(category.Name))))
+	if mark, ok := colorMarks[category.Color]; ok {
+		label += " " + mark
 	}
-	return fmt.Sprintf("%s %s", icon, escape(normalizeTitle(base)))
+	return label
 }
@@ -0,0 +1,222 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// cbBuddyPrefix carries the partner's answer: "buddy:<yes|no|stop>:<owner user ID>".
+const cbBuddyPrefix = "buddy:"
+
+const buddyUsage = "Команды партнёра:\n" +
+	"• /buddy @username — попросить человека стать партнёром (он должен хотя бы раз написать боту)\n" +
+	"• /buddy watch &lt;id&gt; — партнёр узнает, если задача просрочится\n" +
+	"• /buddy unwatch &lt;id&gt; — снять отметку\n" +
+	"• /buddy off — отказаться от партнёра"
+
+// handleBuddy manages the accountability partner: /buddy [@username|watch <id>|unwatch <id>|off].
+func (b *Bot) handleBuddy(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	sub, arg, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	arg = strings.TrimSpace(arg)
+	switch strings.ToLower(sub) {
+	case "":
+		return b.sendBuddyStatus(ctx, msg.Chat.ID, user)
+	case "watch", "unwatch":
+		return b.watchTask(ctx, msg.Chat.ID, user, arg, strings.ToLower(sub) == "watch")
+	case "off":
+		if err := b.accountSvc.DropBuddy(ctx, user); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] buddy dropped user=%d", user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🤝 Партнёра больше нет, о просрочках никто не узнает."))
+	default:
+		if arg != "" || !strings.HasPrefix(sub, "@") {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(buddyUsage))
+		}
+		return b.nominateBuddy(ctx, msg.Chat.ID, user, sub)
+	}
+}
+
+func (b *Bot) sendBuddyStatus(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	buddy, err := b.accountSvc.Buddy(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	var status string
+	switch {
+	case buddy == nil:
+		status = lang.T("🤝 Партнёра пока нет.")
+	case !user.BuddyAccepted:
+		status = lang.Tf("🤝 Ждём ответа от %s.", escape(memberName(*buddy)))
+	default:
+		status = lang.Tf("🤝 Партнёр: %s. Узнает о задачах с отметкой, просроченных больше чем на %d дн.", escape(memberName(*buddy)), b.config.BuddyOverdueDays)
+	}
+	return b.sendText(ctx, chatID, status+"\n\n"+lang.T(buddyUsage))
+}
+
+func (b *Bot) nominateBuddy(ctx context.Context, chatID int64, user *model.User, username string) error {
+	lang := i18n.FromContext(ctx)
+	buddy, err := b.accountSvc.NominateBuddy(ctx, user, username)
+	switch {
+	case errors.Is(err, service.ErrBuddyUnknown):
+		return b.sendText(ctx, chatID, lang.T("Я не знаю такого пользователя. Попроси его написать боту /start и повтори."))
+	case errors.Is(err, service.ErrBuddySelf):
+		return b.sendText(ctx, chatID, lang.T("Себя в партнёры взять нельзя 🙂"))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить партнёра: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] buddy requested user=%d buddy=%d", user.ID, buddy.ID)
+
+	data := func(answer string) string {
+		return fmt.Sprintf("%s%s:%d", cbBuddyPrefix, answer, user.ID)
+	}
+	buddyLang := buddy.Lang()
+	request := tgbotapi.NewMessage(buddy.TelegramID, buddyLang.Tf(
+		"🤝 %s просит тебя стать партнёром по задачам: если отмеченная задача просрочится больше чем на %d дн., я мягко сообщу тебе об этом. Согласен?",
+		escape(memberName(*user)), b.config.BuddyOverdueDays,
+	))
+	request.ParseMode = tgbotapi.ModeHTML
+	request.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(buddyLang.T("✅ Согласиться"), data("yes")),
+		tgbotapi.NewInlineKeyboardButtonData(buddyLang.T("❌ Отказаться"), data("no")),
+	))
+	if _, err := b.send(ctx, request); err != nil {
+		log.Printf("send buddy request to %d: %v", buddy.TelegramID, err)
+		return b.sendText(ctx, chatID, lang.T("Не получилось написать этому человеку. Попроси его открыть бота и повтори."))
+	}
+	return b.sendText(ctx, chatID, lang.Tf("📨 Запрос отправлен %s. Партнёр начнёт получать уведомления, когда согласится.", escape(memberName(*buddy))))
+}
+
+// watchTask flags or unflags the task for the partner.
+func (b *Bot) watchTask(ctx context.Context, chatID int64, user *model.User, arg string, on bool) error {
+	lang := i18n.FromContext(ctx)
+	taskID, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.T("Укажи ID задачи: /buddy watch 12"))
+	}
+	task, err := b.taskSvc.SetEscalate(ctx, user, uint(taskID), on)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, chatID, lang.T("Задача уже выполнена."))
+	case errors.Is(err, service.ErrNoDeadline):
+		return b.sendText(ctx, chatID, lang.T("У задачи нет дедлайна — просрочить её нельзя. Добавь дедлайн через /edit."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить отметку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task escalation id=%d user=%d on=%t", task.ID, user.ID, on)
+	if !on {
+		return b.sendText(ctx, chatID, lang.Tf("🤝 Партнёр больше не следит за <b>#%d</b> %s.", task.ID, escape(normalizeTitle(task.Title))))
+	}
+	text := lang.Tf("🤝 Если <b>#%d</b> %s просрочится больше чем на %d дн., партнёр узнает об этом.", task.ID, escape(normalizeTitle(task.Title)), b.config.BuddyOverdueDays)
+	if user.BuddyID == 0 || !user.BuddyAccepted {
+		text += lang.T("\nПартнёра пока нет — позови его: /buddy @username")
+	}
+	return b.sendText(ctx, chatID, text)
+}
+
+// handleBuddyButton records the partner's answer and tells the owner about it.
+func (b *Bot) handleBuddyButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	answer, rawOwner, ok := strings.Cut(payload, ":")
+	ownerID, err := strconv.ParseUint(rawOwner, 10, 64)
+	if !ok || err != nil {
+		return nil
+	}
+	buddy, err := b.telegramUser(ctx, cb.From)
+	if err != nil {
+		return err
+	}
+	accept := answer == "yes"
+	owner, err := b.accountSvc.AnswerBuddy(ctx, buddy, uint(ownerID), accept)
+	var text, note string
+	switch {
+	case errors.Is(err, service.ErrNoBuddyRequest), errors.Is(err, gorm.ErrRecordNotFound):
+		text = lang.T("Этот запрос больше не действует.")
+	case err != nil:
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("Не удалось сохранить ответ: %s", errorText(lang, err)))
+	case accept:
+		text = lang.Tf("🤝 Ты партнёр %s. Спасибо, что поддерживаешь!", escape(memberName(*owner)))
+		note = owner.Lang().Tf("🤝 %s согласился быть партнёром. Отметь важные задачи: /buddy watch &lt;id&gt;", escape(memberName(*buddy)))
+	case answer == "stop":
+		text = lang.Tf("🙅 Больше не пришлю уведомлений о задачах %s.", escape(memberName(*owner)))
+		note = owner.Lang().Tf("🤝 %s больше не будет получать уведомления о просрочках. Позвать другого партнёра: /buddy @username", escape(memberName(*buddy)))
+	default:
+		text = lang.T("Хорошо, запрос отклонён.")
+		note = owner.Lang().Tf("🤝 %s не готов быть партнёром.", escape(memberName(*buddy)))
+	}
+	if err == nil {
+		log.Printf("[info] buddy answer owner=%d buddy=%d answer=%s", owner.ID, buddy.ID, answer)
+	}
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	if _, err := b.send(ctx, edit); err != nil {
+		return err
+	}
+	if note == "" {
+		return nil
+	}
+	return b.sendText(withReader(ctx, *owner), owner.TelegramID, note)
+}
+
+// SendBuddyEscalations tells partners about flagged tasks that stayed overdue too long.
+func (b *Bot) SendBuddyEscalations(ctx context.Context) error {
+	now := time.Now()
+	tasks, err := b.reminderSvc.Escalations(ctx, now, b.config.BuddyOverdueDays)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		task := &tasks[i]
+		owner, err := b.userRepo.FindByID(ctx, task.UserID)
+		if err != nil {
+			log.Printf("escalation owner of task %d: %v", task.ID, err)
+			continue
+		}
+		if owner.BuddyID == 0 || !owner.BuddyAccepted || owner.ArchivedAt != nil {
+			continue
+		}
+		buddy, err := b.userRepo.FindByID(ctx, owner.BuddyID)
+		if err != nil {
+			log.Printf("escalation buddy of task %d: %v", task.ID, err)
+			continue
+		}
+		lang := buddy.Lang()
+
+		text := lang.Tf("🤝 %s просил присмотреть: задача «%s» просрочена на %d дн. (срок был %s). Может, стоит поддержать и спросить, как дела?",
+			escape(memberName(*owner)), escape(normalizeTitle(task.Title)), service.OverdueDays(*task.Deadline, now), task.Deadline.Format("02.01.2006"))
+		msg := tgbotapi.NewMessage(buddy.TelegramID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🙅 Больше не присылать"), fmt.Sprintf("%sstop:%d", cbBuddyPrefix, owner.ID)),
+		))
+		if _, err := b.send(ctx, msg); err != nil {
+			log.Printf("send escalation to %d: %v", buddy.TelegramID, err)
+			continue
+		}
+		log.Printf("[info] task escalated id=%d user=%d buddy=%d", task.ID, owner.ID, buddy.ID)
+		if err := b.reminderSvc.MarkEscalated(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// cbWeekPrefix buttons carry "w<date>" to show the week starting on that Monday
+// and "d<date>" to show a single day; dates are "2006-01-02".
+const cbWeekPrefix = "week:"
+
+const weekDateLayout = "2006-01-02"
+
+// handleCalendarWeek shows the current week as a grid of due tasks per day.
+func (b *Bot) handleCalendarWeek(ctx context.Context, msg *tgbotapi.Message) error {
+	user := CurrentUser(ctx)
+	return b.showWeek(ctx, msg.Chat.ID, 0, user, service.WeekStart(time.Now().In(user.Location())))
+}
+
+// handleWeekButton switches the week message to another week or to one day, in place.
+func (b *Bot) handleWeekButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	if payload == "" {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	day, err := time.ParseInLocation(weekDateLayout, payload[1:], user.Location())
+	if err != nil {
+		return nil
+	}
+	switch payload[0] {
+	case 'w':
+		return b.showWeek(ctx, cb.Message.Chat.ID, cb.Message.MessageID, user, service.WeekStart(day))
+	case 'd':
+		return b.showDay(ctx, cb.Message.Chat.ID, cb.Message.MessageID, user, day)
+	}
+	return nil
+}
+
+// showWeek sends the week grid or, with a non-zero messageID, redraws that message with it.
+func (b *Bot) showWeek(ctx context.Context, chatID int64, messageID int, user *model.User, start time.Time) error {
+	lang := i18n.FromContext(ctx)
+	plans, err := b.taskSvc.WeekPlan(ctx, user, start)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось собрать неделю: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] calendar week user=%d start=%s", user.ID, start.Format(weekDateLayout))
+	text := formatWeekGrid(lang, plans, time.Now().In(user.Location())) + b.workspaceTitle(ctx, user)
+
+	days := make([]tgbotapi.InlineKeyboardButton, 0, len(plans))
+	for _, plan := range plans {
+		label := lang.T(weekdayNames[plan.Day.Weekday()][0])
+		if count := len(plan.Deadlines) + len(plan.Recurring); count > 0 {
+			label = fmt.Sprintf("%s·%d", label, count)
+		}
+		days = append(days, tgbotapi.NewInlineKeyboardButtonData(label, cbWeekPrefix+"d"+plan.Day.Format(weekDateLayout)))
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(days, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("⬅️ Назад"), cbWeekPrefix+"w"+start.AddDate(0, 0, -7).Format(weekDateLayout)),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("Эта неделя"), cbWeekPrefix+"w"+time.Now().In(user.Location()).Format(weekDateLayout)),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("Вперёд ➡️"), cbWeekPrefix+"w"+start.AddDate(0, 0, 7).Format(weekDateLayout)),
+	))
+	return b.showWeekMessage(ctx, chatID, messageID, text, markup)
+}
+
+// showDay redraws the week message with the tasks due on one day and a way back to its week.
+func (b *Bot) showDay(ctx context.Context, chatID int64, messageID int, user *model.User, day time.Time) error {
+	lang := i18n.FromContext(ctx)
+	plans, err := b.taskSvc.WeekPlan(ctx, user, day)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось собрать день: %s", errorText(lang, err)))
+	}
+	plan := plans[0]
+
+	var builder strings.Builder
+	weekday := []rune(lang.T(weekdayNames[day.Weekday()][1]))
+	builder.WriteString(fmt.Sprintf("📅 <b>%s%s, %s</b>\n", strings.ToUpper(string(weekday[:1])), string(weekday[1:]), day.Format("02.01.2006")))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(plan.Deadlines) == 0 && len(plan.Recurring) == 0 {
+		builder.WriteString(lang.T("\nНа этот день ничего не запланировано."))
+	}
+	if len(plan.Deadlines) > 0 {
+		builder.WriteString(lang.T("\n⏰ <b>Сроки</b>\n"))
+		for _, task := range plan.Deadlines {
+			builder.WriteString(fmt.Sprintf("• #%d %s%s\n", task.ID, service.PriorityMark(task.Priority), escape(normalizeTitle(task.Title))))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(completeButton(task, 24)))
+		}
+	}
+	if len(plan.Recurring) > 0 {
+		builder.WriteString(lang.T("\n♻️ <b>Регулярные</b>\n"))
+		for _, task := range plan.Recurring {
+			builder.WriteString(fmt.Sprintf("• #%d %s%s\n", task.ID, service.PriorityMark(task.Priority), escape(normalizeTitle(task.Title))))
+		}
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("⬅️ К неделе"), cbWeekPrefix+"w"+day.Format(weekDateLayout)),
+	))
+	return b.showWeekMessage(ctx, chatID, messageID, strings.TrimSpace(builder.String()), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+func (b *Bot) showWeekMessage(ctx context.Context, chatID int64, messageID int, text string, markup tgbotapi.InlineKeyboardMarkup) error {
+	if messageID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup)
+		edit.ParseMode = tgbotapi.ModeHTML
+		_, err := b.api.Request(renderFor(ctx, edit))
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = markup
+	_, err := b.send(ctx, msg)
+	return err
+}
+
+// formatWeekGrid draws the week as a monospace table: a column per day, with the number of
+// deadlines and of recurring tasks due; today's date is bracketed.
+//
+//	        пн   вт   ср   чт   пт   сб   вс
+//	        10   11  [12]  13   14   15   16
+//	Сроки    ·    ·    1    ·    2    ·    ·
+//	Повт.    1    ·    1    ·    1    ·    1
+func formatWeekGrid(lang i18n.Lang, plans []service.DayPlan, now time.Time) string {
+	first, last := plans[0].Day, plans[len(plans)-1].Day
+	var days, dates, deadlines, recurring strings.Builder
+	days.WriteString("      ")
+	dates.WriteString("      ")
+	deadlines.WriteString(lang.T("Сроки "))
+	recurring.WriteString(lang.T("Повт. "))
+	today := now.Format(weekDateLayout)
+	for _, plan := range plans {
+		days.WriteString("  " + lang.T(weekdayNames[plan.Day.Weekday()][0]) + " ")
+		date := plan.Day.Format("02")
+		if plan.Day.Format(weekDateLayout) == today {
+			dates.WriteString(" [" + date + "]")
+		} else {
+			dates.WriteString("  " + date + " ")
+		}
+		deadlines.WriteString(gridCount(len(plan.Deadlines)))
+		recurring.WriteString(gridCount(len(plan.Recurring)))
+	}
+	var lines []string
+	for _, line := range []*strings.Builder{&days, &dates, &deadlines, &recurring} {
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	grid := strings.Join(lines, "\n")
+	return lang.Tf("🗓 <b>Неделя %s – %s</b>\n<pre>%s</pre>\nСроки — задачи с дедлайном, Повт. — регулярные. Нажми на день, чтобы увидеть его задачи.",
+		first.Format("02.01"), last.Format("02.01"), grid)
+}
+
+func gridCount(n int) string {
+	if n == 0 {
+		return "   · "
+	}
+	return fmt.Sprintf("%4d ", n)
+}
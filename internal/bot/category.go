@@ -0,0 +1,516 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// registerCategories wires up listing, configuring, archiving and deleting categories.
+func (b *Bot) registerCategories(r *router) {
+	r.command("categories", "категории", b.handleCategories)
+	r.command("category", "", b.handleCategory)
+	r.callback(callbackRoute{prefix: cbCategoryRestorePrefix, handle: b.handleCategoryRestore})
+	r.callback(callbackRoute{prefix: cbCategoryDeletePrefix, handle: b.handleCategoryDeletion})
+	r.conversation(b.handleCategoryStyleStep, stageCategoryEmoji, stageCategoryColor)
+}
+
+const categoryRouteUsage = "Маршруты напоминаний:\n" +
+	"• в группе: /category route &lt;категория&gt; — присылать напоминания категории сюда\n" +
+	"• здесь: /category route &lt;категория&gt; off — вернуть их в личный отчёт\n" +
+	"• /category route — список маршрутов"
+
+const categoryDeleteUsage = "Удалить категорию: /category del &lt;категория&gt;"
+
+const categoryArchiveUsage = "Убрать категорию в архив: /category archive &lt;категория&gt;, архив — /categories archived"
+
+const categoryStyleUsage = "Значок и цвет категории: /category style &lt;категория&gt;"
+
+const categoryDefaultsUsage = "Настройки новых задач категории:\n" +
+	"• /category defaults &lt;категория&gt; +3d — дедлайн через 3 дня (+2w — через 2 недели)\n" +
+	"• /category defaults &lt;категория&gt; 12h — напоминать за 12 часов до дедлайна (2d — за 2 дня)\n" +
+	"• /category defaults &lt;категория&gt; off — сбросить, без параметров — показать текущие"
+
+// maxMoveTargets caps the categories offered as a new home for tasks of a deleted one.
+const maxMoveTargets = 10
+
+// Actions offered when a category is deleted.
+const (
+	categoryMoveTasks    = "move"
+	categoryDetachTasks  = "none"
+	categoryArchiveTasks = "archive"
+	categoryKeep         = "keep"
+)
+
+// handleCategory serves /category subcommands in private chats.
+func (b *Bot) handleCategory(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+
+	sub, arg, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	if strings.EqualFold(sub, "del") {
+		return b.askCategoryDeletion(ctx, msg.Chat.ID, user, strings.TrimSpace(arg))
+	}
+	if strings.EqualFold(sub, "defaults") {
+		return b.handleCategoryDefaults(ctx, msg.Chat.ID, user, strings.Fields(arg))
+	}
+	if strings.EqualFold(sub, "archive") {
+		return b.archiveCategory(ctx, msg.Chat.ID, user, strings.TrimSpace(arg))
+	}
+	if strings.EqualFold(sub, "style") {
+		return b.startCategoryStyle(ctx, msg.Chat.ID, msg.From.ID, user, strings.TrimSpace(arg))
+	}
+	if !strings.EqualFold(sub, "route") {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(categoryRouteUsage)+"\n\n"+lang.T(categoryDefaultsUsage)+"\n\n"+lang.T(categoryStyleUsage)+"\n"+lang.T(categoryDeleteUsage)+"\n"+lang.T(categoryArchiveUsage))
+	}
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return b.sendCategoryRoutes(ctx, msg.Chat.ID, user)
+	}
+
+	fields := strings.Fields(arg)
+	if len(fields) < 2 || !strings.EqualFold(fields[len(fields)-1], "off") {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Чтобы направить категорию в группу, выполни /category route &lt;категория&gt; прямо в той группе."))
+	}
+	name := strings.Join(fields[:len(fields)-1], " ")
+	category, err := b.categorySvc.Route(ctx, user, name, 0)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, categoryRouteError(lang, err))
+	}
+	log.Printf("[info] category route removed category=%d", category.ID)
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("↩️ Напоминания категории «%s» снова приходят в личный отчёт.", escape(category.Name)))
+}
+
+func (b *Bot) sendCategoryRoutes(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	categories, err := b.categorySvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить категории: %s", errorText(lang, err)))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("📮 <b>Маршруты напоминаний</b>\n"))
+	routed := 0
+	for _, cat := range categories {
+		if cat.RouteChatID == 0 {
+			continue
+		}
+		routed++
+		title := lang.Tf("чат %d", cat.RouteChatID)
+		if chat, err := b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{ChatID: cat.RouteChatID}}); err == nil && chat.Title != "" {
+			title = chat.Title
+		}
+		builder.WriteString(fmt.Sprintf("• %s → %s\n", categoryLabel(cat), escape(title)))
+	}
+	if routed == 0 {
+		builder.WriteString(lang.T("— все напоминания приходят в личный отчёт\n"))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(categoryRouteUsage)
+	return b.sendText(ctx, chatID, builder.String())
+}
+
+// handleGroupCategory binds a category's reminders to the group the command was sent from.
+func (b *Bot) handleGroupCategory(ctx context.Context, msg *tgbotapi.Message, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	sub, name, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	name = strings.TrimSpace(name)
+	if !strings.EqualFold(sub, "route") || name == "" {
+		return b.sendGroupText(msg.Chat.ID, lang.T("Формат: /category route &lt;категория&gt;"))
+	}
+	category, err := b.categorySvc.Route(ctx, user, name, msg.Chat.ID)
+	if err != nil {
+		return b.sendGroupText(msg.Chat.ID, categoryRouteError(lang, err))
+	}
+	log.Printf("[info] category routed category=%d chat=%d", category.ID, msg.Chat.ID)
+	return b.sendGroupText(msg.Chat.ID, lang.Tf("📮 Напоминания категории «%s» теперь приходят сюда.", escape(category.Name)))
+}
+
+// handleCategoryDefaults shows or changes the settings applied to new tasks of a category.
+// Settings follow the name: "+3d" is the deadline, "12h" the alert lead time, "off" resets both.
+func (b *Bot) handleCategoryDefaults(ctx context.Context, chatID int64, user *model.User, args []string) error {
+	lang := i18n.FromContext(ctx)
+	var deadlineDays, alertHours int
+	var reset bool
+	changed := false
+	for len(args) > 1 {
+		last := strings.ToLower(args[len(args)-1])
+		if last == "off" {
+			reset = true
+		} else if days, ok := parseDeadlineOffset(last); ok {
+			deadlineDays = days
+		} else if hours, ok := parseAlertLead(last); ok {
+			alertHours = hours
+		} else {
+			break
+		}
+		changed = true
+		args = args[:len(args)-1]
+	}
+	name := strings.Join(args, " ")
+	if name == "" {
+		return b.sendText(ctx, chatID, lang.T(categoryDefaultsUsage))
+	}
+
+	if !changed {
+		category, err := b.categorySvc.FindByName(ctx, user, name)
+		if err != nil {
+			return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+		}
+		defaults := categoryDefaultsLabel(lang, *category)
+		if defaults == "" {
+			defaults = lang.T("не заданы")
+		}
+		return b.sendText(ctx, chatID, lang.Tf("⚙️ Настройки новых задач «%s»: %s\n\n%s", escape(category.Name), defaults, lang.T(categoryDefaultsUsage)))
+	}
+	if !reset {
+		// Unmentioned settings keep their values.
+		current, err := b.categorySvc.FindByName(ctx, user, name)
+		if err != nil {
+			return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+		}
+		if deadlineDays == 0 {
+			deadlineDays = current.DefaultDeadlineDays
+		}
+		if alertHours == 0 {
+			alertHours = current.DefaultAlertHours
+		}
+	}
+
+	category, err := b.categorySvc.SetDefaults(ctx, user, name, deadlineDays, alertHours)
+	switch {
+	case errors.Is(err, service.ErrInvalidDefaults):
+		return b.sendText(ctx, chatID, lang.Tf("Дедлайн — не дальше %d дней, напоминание — не раньше чем за %d дней.", service.MaxDefaultDeadlineDays, service.MaxDefaultAlertHours/24))
+	case err != nil:
+		return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+	}
+	log.Printf("[info] category defaults category=%d deadline=%d alert=%d", category.ID, category.DefaultDeadlineDays, category.DefaultAlertHours)
+	defaults := categoryDefaultsLabel(lang, *category)
+	if defaults == "" {
+		return b.sendText(ctx, chatID, lang.Tf("⚙️ Настройки новых задач «%s» сброшены.", escape(category.Name)))
+	}
+	return b.sendText(ctx, chatID, lang.Tf("⚙️ Новые задачи «%s»: %s.", escape(category.Name), defaults))
+}
+
+// defaultDeadlineHint describes the deadline a category assigns to new tasks, if any.
+func (b *Bot) defaultDeadlineHint(ctx context.Context, from *tgbotapi.User, name string) string {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return ""
+	}
+	category, err := b.categorySvc.FindByName(ctx, user, name)
+	if err != nil || category.DefaultDeadlineDays == 0 {
+		return ""
+	}
+	deadline := time.Now().AddDate(0, 0, category.DefaultDeadlineDays)
+	return lang.Tf("⏰ Дедлайн по умолчанию для «%s»: %s.", escape(category.Name), deadline.Format("2006-01-02"))
+}
+
+func categoryDefaultsLabel(lang i18n.Lang, category model.Category) string {
+	var parts []string
+	if category.DefaultDeadlineDays > 0 {
+		parts = append(parts, lang.Tf("дедлайн через %d дн.", category.DefaultDeadlineDays))
+	}
+	if category.DefaultAlertHours > 0 {
+		parts = append(parts, lang.Tf("напоминание за %s", leadLabel(lang, category.DefaultAlertHours)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseDeadlineOffset reads "+3d" or "+2w" (also "+3д", "+2н") as a number of days.
+func parseDeadlineOffset(value string) (int, bool) {
+	if !strings.HasPrefix(value, "+") {
+		return 0, false
+	}
+	amount, unit, ok := splitAmount(strings.TrimPrefix(value, "+"))
+	if !ok {
+		return 0, false
+	}
+	switch unit {
+	case "d", "д":
+		return amount, true
+	case "w", "н":
+		return amount * 7, true
+	}
+	return 0, false
+}
+
+// parseAlertLead reads "12h" or "2d" (also "12ч", "2д") as a number of hours.
+func parseAlertLead(value string) (int, bool) {
+	amount, unit, ok := splitAmount(value)
+	if !ok {
+		return 0, false
+	}
+	switch unit {
+	case "h", "ч":
+		return amount, true
+	case "d", "д":
+		return amount * 24, true
+	}
+	return 0, false
+}
+
+// splitAmount splits "12h" into a positive number and its unit.
+func splitAmount(value string) (int, string, bool) {
+	digits := strings.TrimRightFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	amount, err := strconv.Atoi(digits)
+	if err != nil || amount <= 0 {
+		return 0, "", false
+	}
+	return amount, strings.TrimPrefix(value, digits), true
+}
+
+func leadLabel(lang i18n.Lang, hours int) string {
+	if hours%24 == 0 {
+		return lang.Tf("%d дн.", hours/24)
+	}
+	return lang.Tf("%d ч", hours)
+}
+
+// askCategoryDeletion asks what to do with the category's tasks before deleting it.
+func (b *Bot) askCategoryDeletion(ctx context.Context, chatID int64, user *model.User, name string) error {
+	lang := i18n.FromContext(ctx)
+	if name == "" {
+		return b.sendText(ctx, chatID, lang.T(categoryDeleteUsage))
+	}
+	category, err := b.categorySvc.FindByName(ctx, user, name)
+	if err != nil {
+		return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+	}
+	count, err := b.categorySvc.CountActiveTasks(ctx, category)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось посчитать задачи: %s", errorText(lang, err)))
+	}
+	categories, err := b.categorySvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить категории: %s", errorText(lang, err)))
+	}
+
+	data := func(action string) string {
+		return fmt.Sprintf("%s%d:%s", cbCategoryDeletePrefix, category.ID, action)
+	}
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, other := range categories {
+		if other.ID == category.ID || len(rows) == maxMoveTargets {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			lang.Tf("➡️ В «%s»", shortTitle(other.Name, 30)), data(fmt.Sprintf("%s:%d", categoryMoveTasks, other.ID)))))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🚫 Без категории"), data(categoryDetachTasks)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🗄 В архив"), data(categoryArchiveTasks)),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T(btnCancel), data(categoryKeep))),
+	)
+
+	text := lang.Tf("🗑 Удалить категорию «%s»?\nАктивных задач в ней: %d. Куда их деть?", escape(category.Name), count)
+	return b.sendWithReplyMarkup(ctx, chatID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows})
+}
+
+func (b *Bot) handleCategoryDeletion(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	parts := strings.Split(data, ":")
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || len(parts) < 2 {
+		return nil
+	}
+	chatID := cb.Message.Chat.ID
+	var deletion service.CategoryDeletion
+	switch parts[1] {
+	case categoryKeep:
+		edit := tgbotapi.NewEditMessageText(chatID, cb.Message.MessageID, lang.T("↩️ Категория осталась на месте."))
+		_, err := b.send(ctx, edit)
+		return err
+	case categoryMoveTasks:
+		if len(parts) != 3 {
+			return nil
+		}
+		target, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return nil
+		}
+		deletion.TargetID = uint(target)
+	case categoryArchiveTasks:
+		deletion.Archive = true
+	case categoryDetachTasks:
+	default:
+		return nil
+	}
+
+	user := CurrentUser(ctx)
+	result, err := b.categorySvc.Delete(ctx, user, uint(id), deletion, time.Now())
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Категория уже удалена, или та, куда переносятся задачи, пропала."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось удалить категорию: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] category deleted id=%d user=%d tasks=%d archive=%t target=%d", id, user.ID, len(result.Tasks), deletion.Archive, deletion.TargetID)
+	edit := tgbotapi.NewEditMessageText(chatID, cb.Message.MessageID, formatDeletedCategory(lang, *result))
+	edit.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+// maxListedTasks caps the task list in the category deletion summary.
+const maxListedTasks = 20
+
+func formatDeletedCategory(lang i18n.Lang, result service.DeletedCategory) string {
+	var builder strings.Builder
+	builder.WriteString(lang.Tf("🗑 Категория «%s» удалена.", escape(result.Category.Name)))
+	if len(result.Tasks) == 0 {
+		builder.WriteString(lang.T(" Активных задач в ней не было."))
+		return builder.String()
+	}
+	switch {
+	case result.Archived:
+		builder.WriteString(lang.Tf("\nЗадачи отправлены в архив (%d), вернуть можно через /archive:\n", len(result.Tasks)))
+	case result.Target != nil:
+		builder.WriteString(lang.Tf("\nЗадачи перенесены в «%s» (%d):\n", escape(result.Target.Name), len(result.Tasks)))
+	default:
+		builder.WriteString(lang.Tf("\nЗадачи остались без категории (%d):\n", len(result.Tasks)))
+	}
+	for i, task := range result.Tasks {
+		if i == maxListedTasks {
+			builder.WriteString(lang.Tf("…и ещё %d\n", len(result.Tasks)-maxListedTasks))
+			break
+		}
+		builder.WriteString(fmt.Sprintf("• <b>#%d</b> %s\n", task.ID, escape(normalizeTitle(task.Title))))
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+func categoryRouteError(lang i18n.Lang, err error) string {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		return lang.T("Такой категории нет. Список — /categories в личном чате с ботом.")
+	default:
+		return lang.Tf("Не удалось изменить маршрут: %s", errorText(lang, err))
+	}
+}
+
+// dispatchRouted delivers report parts of routed categories to their chats.
+func (b *Bot) dispatchRouted(ctx context.Context, scope model.Scope, policy model.DeadlinePolicy, now time.Time) {
+	reports, err := b.reminderSvc.RoutedSummaries(ctx, scope, policy, now)
+	if err != nil {
+		log.Printf("build routed summaries user=%d workspace=%d: %v", scope.UserID, scope.WorkspaceID, err)
+		return
+	}
+	for _, report := range reports {
+		if err := b.sendGroupText(report.ChatID, report.Text); err != nil {
+			log.Printf("send routed summary to %d: %v", report.ChatID, err)
+		}
+	}
+}
+
+// archiveCategory hides a category from lists and keyboards: /category archive <name>.
+func (b *Bot) archiveCategory(ctx context.Context, chatID int64, user *model.User, name string) error {
+	lang := i18n.FromContext(ctx)
+	if name == "" {
+		return b.sendText(ctx, chatID, lang.T(categoryArchiveUsage))
+	}
+	category, err := b.categorySvc.Archive(ctx, user, name, time.Now())
+	if err != nil {
+		return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+	}
+	log.Printf("[info] category archived id=%d user=%d", category.ID, user.ID)
+	return b.sendText(ctx, chatID, lang.Tf("🗄 Категория «%s» в архиве: её нет в списках и кнопках, а задачи остались как были. Вернуть — /categories archived.", escape(category.Name)))
+}
+
+// sendArchivedCategories lists archived categories with buttons to restore them.
+func (b *Bot) sendArchivedCategories(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	categories, err := b.categorySvc.ListArchived(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить категории: %s", errorText(lang, err)))
+	}
+	if len(categories) == 0 {
+		return b.sendText(ctx, chatID, lang.T("В архиве категорий пусто. ")+lang.T(categoryArchiveUsage))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("🗄 <b>Категории в архиве</b>\n"))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, category := range categories {
+		builder.WriteString(lang.Tf("• %s — с %s\n", escape(strings.TrimSpace(category.Name)), category.ArchivedAt.Format("02.01.2006")))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			lang.Tf("↩️ Вернуть «%s»", shortTitle(category.Name, 30)), fmt.Sprintf("%s%d", cbCategoryRestorePrefix, category.ID))))
+	}
+	return b.sendWithReplyMarkup(ctx, chatID, strings.TrimSpace(builder.String()), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// handleCategoryRestore brings back the archived category whose button was pressed.
+func (b *Bot) handleCategoryRestore(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	id, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	category, err := b.categorySvc.Restore(ctx, user, uint(id))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.T("Категория не найдена."))
+	case err != nil:
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("Не удалось вернуть категорию: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] category restored id=%d user=%d", category.ID, user.ID)
+	return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("📂 Категория «%s» снова в списках.", escape(category.Name)))
+}
+
+// archivedCategoryNames returns the lowercased names of the sender's archived categories.
+func (b *Bot) archivedCategoryNames(ctx context.Context, from *tgbotapi.User) map[string]bool {
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return nil
+	}
+	categories, err := b.categorySvc.ListArchived(ctx, user)
+	if err != nil {
+		log.Printf("list archived categories of %d: %v", user.ID, err)
+		return nil
+	}
+	names := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		names[strings.ToLower(strings.TrimSpace(category.Name))] = true
+	}
+	return names
+}
+
+func (b *Bot) handleCategories(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	if strings.EqualFold(strings.TrimSpace(msg.CommandArguments()), "archived") {
+		return b.sendArchivedCategories(ctx, msg.Chat.ID, user)
+	}
+	categories, err := b.categorySvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить категории: %s", errorText(lang, err)))
+	}
+	if len(categories) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Категории пока пусты. Добавь их при создании задачи."))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("📂 <b>Категории</b>\n"))
+	for _, cat := range categories {
+		line := "• " + categoryLabel(cat)
+		if defaults := categoryDefaultsLabel(lang, cat); defaults != "" {
+			line += " — " + defaults
+		}
+		builder.WriteString(line + "\n")
+	}
+	builder.WriteString(lang.T("\nВ архиве: /categories archived\n") + lang.T(categoryStyleUsage))
+	return b.sendText(ctx, msg.Chat.ID, strings.TrimSpace(builder.String()))
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const btnNoColor = "⭕ Без цвета"
+
+// noCategoryItem stands in for the missing category of tasks that have none.
+func noCategoryItem(lang i18n.Lang) model.Category {
+	return model.Category{Name: lang.T(noCategory), Emoji: noCategoryIcon}
+}
+
+// colorMarks show the category colors, which Telegram cannot render in text.
+var colorMarks = map[string]string{
+	"red": "🔴", "orange": "🟠", "yellow": "🟡", "green": "🟢", "blue": "🔵",
+	"purple": "🟣", "brown": "🟤", "black": "⚫", "white": "⚪",
+}
+
+// colorNames are the color button captions.
+var colorNames = map[string]string{
+	"red": i18n.N("Красный"), "orange": i18n.N("Оранжевый"), "yellow": i18n.N("Жёлтый"), "green": i18n.N("Зелёный"), "blue": i18n.N("Синий"),
+	"purple": i18n.N("Фиолетовый"), "brown": i18n.N("Коричневый"), "black": i18n.N("Чёрный"), "white": i18n.N("Белый"),
+}
+
+// suggestedEmoji are offered as buttons when a category is styled.
+var suggestedEmoji = []string{"🎓", "💼", "🛒", "🩺", "🏠", "💰", "✈️", "📚", "🎯", "🏋️", "🎨", "🧩"}
+
+// startCategoryStyle asks for a new emoji of the category: /category style <name>.
+func (b *Bot) startCategoryStyle(ctx context.Context, chatID, userID int64, user *model.User, name string) error {
+	lang := i18n.FromContext(ctx)
+	if name == "" {
+		return b.sendText(ctx, chatID, lang.T(categoryStyleUsage))
+	}
+	category, err := b.categorySvc.FindByName(ctx, user, name)
+	if err != nil {
+		return b.sendText(ctx, chatID, categoryRouteError(lang, err))
+	}
+	b.setConversation(userID, &conversationState{stage: stageCategoryEmoji, categoryID: category.ID, field: category.Emoji})
+	text := lang.Tf("🎨 Сейчас категория выглядит так: %s\nПришли новый значок — один эмодзи — или выбери из кнопок («Пропустить» оставит прежний).", categoryLabel(*category))
+	return b.sendWithReplyMarkup(ctx, chatID, text, emojiKeyboard(lang))
+}
+
+// handleCategoryStyleStep takes the emoji and then the color of the category being styled.
+func (b *Bot) handleCategoryStyleStep(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	text := strings.TrimSpace(msg.Text)
+	if state.stage == stageCategoryEmoji {
+		if !isSkipInput(text) {
+			if !service.ValidEmoji(text) {
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Значок — это один эмодзи, без букв и цифр. Пришли другой:"), emojiKeyboard(lang))
+			}
+			state.field = text
+		}
+		if state.field == "" {
+			state.field = model.CategoryEmojiFallback
+		}
+		state.stage = stageCategoryColor
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Теперь цвет-метка («Пропустить» оставит прежний):"), colorKeyboard(lang))
+	}
+
+	user := CurrentUser(ctx)
+	color, ok := parseColor(text)
+	if !ok {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Выбери цвет кнопкой."), colorKeyboard(lang))
+	}
+	if isSkipInput(text) {
+		current, err := b.categorySvc.Get(ctx, user, state.categoryID)
+		if err == nil {
+			color = current.Color
+		}
+	}
+	category, err := b.categorySvc.SetStyle(ctx, user, state.categoryID, state.field, color)
+	b.clearConversation(msg.From.ID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Категория не найдена."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось изменить категорию: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] category styled id=%d user=%d color=%s", category.ID, user.ID, category.Color)
+	return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("🎨 Готово: ")+categoryLabel(*category), mainMenuKeyboard(lang))
+}
+
+// parseColor reads a color button, its name or the stored code; skipping and "no color" give "".
+func parseColor(text string) (string, bool) {
+	value := strings.ToLower(strings.TrimSpace(text))
+	if isSkipInput(text) || i18n.Matches(value, btnNoColor) || value == "без цвета" || value == "нет" || value == "no" {
+		return "", true
+	}
+	for _, color := range model.CategoryColors {
+		name := strings.TrimSpace(strings.TrimPrefix(value, colorMarks[color]))
+		if value == color || i18n.Matches(name, colorNames[color]) ||
+			name == strings.ReplaceAll(strings.ToLower(colorNames[color]), "ё", "е") {
+			return color, true
+		}
+	}
+	return "", false
+}
+
+func colorButton(lang i18n.Lang, color string) string {
+	return colorMarks[color] + " " + lang.T(colorNames[color])
+}
+
+func emojiKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	for i := 0; i < len(suggestedEmoji); i += 6 {
+		var row []tgbotapi.KeyboardButton
+		for _, emoji := range suggestedEmoji[i:min(i+6, len(suggestedEmoji))] {
+			row = append(row, tgbotapi.NewKeyboardButton(emoji))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton(lang.T(btnSkip)),
+		tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+	))
+	kb := tgbotapi.NewReplyKeyboard(rows...)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
+func colorKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	var row []tgbotapi.KeyboardButton
+	for _, color := range model.CategoryColors {
+		row = append(row, tgbotapi.NewKeyboardButton(colorButton(lang, color)))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows,
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(lang.T(btnNoColor))),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnSkip)),
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+		),
+	)
+	kb := tgbotapi.NewReplyKeyboard(rows...)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
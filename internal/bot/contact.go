@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+func (b *Bot) registerContacts(r *router) {
+	r.command("contacts", "дни рождения и важные даты", b.handleContacts)
+	r.command("contact", "", b.handleContact)
+}
+
+const contactFormat = "Формат: /contact add ДД.ММ[.ГГГГ] Имя [| повод]\nНапример: /contact add 15.03.1990 Маша или /contact add 20.06 Мама и папа | годовщина свадьбы"
+
+// handleContacts lists birthdays and other yearly dates.
+func (b *Bot) handleContacts(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	contacts, err := b.contactSvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить контакты: %s", errorText(lang, err)))
+	}
+	if len(contacts) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("👥 Контактов пока нет. Добавь день рождения, и я напомню о нём заранее.\n")+lang.T(contactFormat))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("👥 <b>Контакты</b>\n"))
+	for _, contact := range contacts {
+		builder.WriteString(fmt.Sprintf("• <b>%d</b> · %s — %s\n", contact.ID, contactDate(contact), escape(contactName(contact))))
+	}
+	builder.WriteString(lang.T("\nДобавить: /contact add, удалить: /contact del &lt;id&gt;"))
+	return b.sendText(ctx, msg.Chat.ID, builder.String())
+}
+
+// handleContact adds or removes a contact: /contact add <date> <name> [| occasion], /contact del <id>.
+func (b *Bot) handleContact(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		return b.handleContacts(ctx, msg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 3 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(contactFormat))
+		}
+		input, err := parseContact(args[1], strings.Join(args[2:], " "))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(contactFormat))
+		}
+		contact, err := b.contactSvc.Add(ctx, user, input, time.Now())
+		if errors.Is(err, service.ErrInvalidDate) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Такой даты не бывает. ")+lang.T(contactFormat))
+		}
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось добавить контакт: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] contact added id=%d user=%d", contact.ID, user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("👤 Добавлено: %s — %s. Напоминание появится в задачах накануне.", contactDate(*contact), escape(contactName(*contact))))
+	case "del":
+		if len(args) != 2 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /contact del &lt;id&gt;"))
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /contact del &lt;id&gt;"))
+		}
+		removed, err := b.contactSvc.Remove(ctx, user, uint(id))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+		}
+		if !removed {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Контакт не найден."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🗑 Контакт и его напоминание удалены."))
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(contactFormat))
+	}
+}
+
+// parseContact reads a DD.MM or DD.MM.YYYY date and "name | occasion".
+func parseContact(date, rest string) (service.ContactInput, error) {
+	parts := strings.Split(date, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return service.ContactInput{}, fmt.Errorf("invalid date %q", date)
+	}
+	var input service.ContactInput
+	var err error
+	if input.Day, err = strconv.Atoi(parts[0]); err != nil {
+		return service.ContactInput{}, err
+	}
+	if input.Month, err = strconv.Atoi(parts[1]); err != nil {
+		return service.ContactInput{}, err
+	}
+	if len(parts) == 3 {
+		if input.Year, err = strconv.Atoi(parts[2]); err != nil {
+			return service.ContactInput{}, err
+		}
+	}
+	name, occasion, _ := strings.Cut(rest, "|")
+	input.Name = strings.TrimSpace(name)
+	input.Occasion = strings.TrimSpace(occasion)
+	if input.Name == "" {
+		return service.ContactInput{}, fmt.Errorf("name is required")
+	}
+	return input, nil
+}
+
+func contactDate(contact model.Contact) string {
+	if contact.Year != 0 {
+		return fmt.Sprintf("%02d.%02d.%d", contact.Day, contact.Month, contact.Year)
+	}
+	return fmt.Sprintf("%02d.%02d", contact.Day, contact.Month)
+}
+
+func contactName(contact model.Contact) string {
+	if contact.IsBirthday() {
+		return contact.Name + " 🎂"
+	}
+	return contact.Name + ": " + contact.Occasion
+}
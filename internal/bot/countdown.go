@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// handleCountdown shows or switches the live countdown in reminders: /countdown on|off.
+func (b *Bot) handleCountdown(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	var on bool
+	switch strings.ToLower(strings.TrimSpace(msg.CommandArguments())) {
+	case "":
+		state := lang.T("выключен")
+		if user.DeadlineCountdown {
+			state = lang.T("включён")
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("⏳ Обратный отсчёт %s. Когда до срока задачи меньше часа, напоминание о ней каждые 10 минут показывает, сколько осталось.\nВключить: /countdown on, выключить: /countdown off", state))
+	case "on":
+		on = true
+	case "off":
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /countdown on или /countdown off"))
+	}
+	if err := b.userRepo.SetDeadlineCountdown(ctx, user, on); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] deadline countdown user=%d on=%t", user.ID, on)
+	if !on {
+		if err := b.taskSvc.StopCountdowns(ctx, msg.Chat.ID); err != nil {
+			log.Printf("stop countdowns chat=%d: %v", msg.Chat.ID, err)
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("⏳ Обратный отсчёт выключен."))
+	}
+	return b.sendText(ctx, msg.Chat.ID, lang.T("⏳ Обратный отсчёт включён: за час до срока напоминания начнут показывать, сколько осталось."))
+}
+
+// UpdateCountdowns redraws the reminders whose task is due within the hour with the time
+// left. A countdown stops once its task is completed or removed or its deadline passes.
+func (b *Bot) UpdateCountdowns(ctx context.Context) error {
+	messages, err := b.taskSvc.Countdowns(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	users := make(map[int64]*model.User)
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		user, ok := users[message.ChatID]
+		if !ok {
+			if user, err = b.userRepo.FindByTelegramID(ctx, message.ChatID); err != nil {
+				log.Printf("countdown owner of chat %d: %v", message.ChatID, err)
+				continue
+			}
+			users[message.ChatID] = user
+		}
+		if err := b.updateCountdown(withReader(ctx, *user), user, message, now); err != nil {
+			log.Printf("countdown chat=%d message=%d: %v", message.ChatID, message.MessageID, err)
+		}
+	}
+	return nil
+}
+
+func (b *Bot) updateCountdown(ctx context.Context, user *model.User, message model.TaskMessage, now time.Time) error {
+	lang := i18n.FromContext(ctx)
+	task, err := b.taskSvc.CountdownTask(ctx, user, message)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return b.taskSvc.StopCountdown(ctx, message)
+	}
+	if err != nil {
+		return err
+	}
+	local := now.In(user.Location())
+
+	var text string
+	var markup tgbotapi.InlineKeyboardMarkup
+	if message.Countdown == model.CountdownAlert {
+		text = deadlineAlertText(lang, *task, local, user.DeadlinePolicy(), true)
+		markup = snoozeKeyboard(lang, service.SnoozeOptions(*task, local, service.UserWorkingHours(*user)))
+	} else {
+		text = taskReminderText(lang, *task, local, true)
+		markup = taskReminderKeyboard(lang, *task)
+	}
+
+	var edit tgbotapi.Chattable
+	switch _, live := service.CountdownLeft(*task, now); {
+	case task.IsCompleted || task.ArchivedAt != nil || task.Deadline == nil:
+		// Without the buttons: there is nothing left to complete or snooze.
+		done := tgbotapi.NewEditMessageText(message.ChatID, message.MessageID, text+lang.T("\n✅ Выполнено"))
+		done.ParseMode = tgbotapi.ModeHTML
+		edit = done
+	case !task.Deadline.After(now):
+		if message.Countdown == model.CountdownReminder {
+			text += lang.T("\n⌛ Срок наступил")
+		}
+		expired := tgbotapi.NewEditMessageTextAndMarkup(message.ChatID, message.MessageID, text, markup)
+		expired.ParseMode = tgbotapi.ModeHTML
+		edit = expired
+	case live:
+		running := tgbotapi.NewEditMessageTextAndMarkup(message.ChatID, message.MessageID, text, markup)
+		running.ParseMode = tgbotapi.ModeHTML
+		if _, err := b.api.Request(renderFor(ctx, running)); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+			// The message is gone or too old to edit.
+			if stopErr := b.taskSvc.StopCountdown(ctx, message); stopErr != nil {
+				return stopErr
+			}
+			return err
+		}
+		return nil
+	default:
+		// Not due within the hour yet.
+		return nil
+	}
+
+	if err := b.taskSvc.StopCountdown(ctx, message); err != nil {
+		return err
+	}
+	_, err = b.api.Request(renderFor(ctx, edit))
+	return err
+}
+
+// minutesLeft renders "осталось 40 минут" with the right word forms.
+func minutesLeft(lang i18n.Lang, minutes int) string {
+	switch {
+	case minutes%10 == 1 && minutes%100 != 11:
+		return lang.Tf("осталась %d минута", minutes)
+	case minutes%10 >= 2 && minutes%10 <= 4 && (minutes%100 < 12 || minutes%100 > 14):
+		return lang.Tf("осталось %d минуты", minutes)
+	default:
+		return lang.Tf("осталось %d минут", minutes)
+	}
+}
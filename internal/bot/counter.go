@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+func (b *Bot) registerCounters(r *router) {
+	r.command("counters", "счётчики на день", b.handleCounters)
+	r.command("counter", "", b.handleCounter)
+	r.callback(callbackRoute{prefix: cbCounterPrefix, ack: "+1", handle: b.handleCounterIncrement})
+}
+
+const counterFormat = "Формат: /counter add &lt;цель&gt; &lt;название&gt;\nНапример: /counter add 8 Стаканы воды"
+
+// handleCounters shows today's counters with +1 buttons.
+func (b *Bot) handleCounters(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	progress, err := b.counterSvc.Today(ctx, user, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить счётчики: %s", errorText(lang, err)))
+	}
+	if len(progress) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("📈 Счётчиков пока нет.\n")+lang.T(counterFormat))
+	}
+	text, markup := counterPanel(lang, progress)
+	return b.sendWithReplyMarkup(ctx, msg.Chat.ID, text, markup)
+}
+
+// handleCounter adds or removes a counter: /counter add <goal> <name>, /counter del <id>.
+func (b *Bot) handleCounter(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		return b.handleCounters(ctx, msg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 3 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(counterFormat))
+		}
+		goal, err := strconv.Atoi(args[1])
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(counterFormat))
+		}
+		counter, err := b.counterSvc.Add(ctx, user, strings.Join(args[2:], " "), goal)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось добавить счётчик: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] counter added id=%d user=%d", counter.ID, user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("📈 Счётчик «%s» — цель %d в день. Отмечай через /counters, прогресс будет в ежедневном отчёте.", escape(counter.Name), counter.Goal))
+	case "del":
+		if len(args) != 2 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /counter del &lt;id&gt;"))
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /counter del &lt;id&gt;"))
+		}
+		removed, err := b.counterSvc.Remove(ctx, user, uint(id))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+		}
+		if !removed {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Счётчик не найден."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🗑 Счётчик удалён."))
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(counterFormat))
+	}
+}
+
+// handleCounterIncrement adds one to a counter and refreshes the panel in place.
+func (b *Bot) handleCounterIncrement(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	id, err := strconv.ParseUint(data, 10, 64)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	now := time.Now()
+	err = b.counterSvc.Increment(ctx, user, uint(id), 1, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.T("Этот счётчик уже удалён."))
+	}
+	if err != nil {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("Не удалось обновить счётчик: %s", errorText(lang, err)))
+	}
+
+	progress, err := b.counterSvc.Today(ctx, user, now)
+	if err != nil || len(progress) == 0 {
+		return err
+	}
+	text, markup := counterPanel(lang, progress)
+	edit := tgbotapi.NewEditMessageTextAndMarkup(cb.Message.Chat.ID, cb.Message.MessageID, text, markup)
+	edit.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+// counterPanel renders progress bars with a +1 button per counter, two buttons per row.
+func counterPanel(lang i18n.Lang, progress []service.CounterProgress) (string, tgbotapi.InlineKeyboardMarkup) {
+	var builder strings.Builder
+	builder.WriteString(lang.T("📈 <b>Счётчики на сегодня</b>\n"))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, entry := range progress {
+		builder.WriteString(fmt.Sprintf("• <b>%d</b> · %s: %s\n", entry.Counter.ID, escape(entry.Counter.Name), service.ProgressBar(entry)))
+		data := cbCounterPrefix + strconv.FormatUint(uint64(entry.Counter.ID), 10)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("+1 "+shortTitle(entry.Counter.Name, 20), data))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	builder.WriteString(lang.T("\nСчётчики обнуляются в полночь. Удалить: /counter del &lt;id&gt;"))
+	return builder.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const dashboardUsage = "Формат: /dashboard — закрепить панель задач (повторно — опустить её вниз чата), /dashboard off — открепить."
+
+// handleDashboard turns the chat's dashboard on or off: /dashboard [off]. The dashboard is the
+// chat's task list with a summary on top, pinned and redrawn on every change of the tasks;
+// a new one replaces the old message, so the chat always has exactly one.
+func (b *Bot) handleDashboard(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	chatID := msg.Chat.ID
+	board := b.trackedDashboard(ctx, chatID)
+	switch strings.ToLower(strings.TrimSpace(msg.CommandArguments())) {
+	case "", "on":
+		if board == nil {
+			// The current task list, if any, gives way to the dashboard.
+			previous := 0
+			if list, err := b.taskSvc.TrackedList(ctx, chatID); err == nil {
+				previous = list.MessageID
+			}
+			if err := b.taskSvc.TrackList(ctx, user, chatID, previous, string(viewAll), 0, true); err != nil {
+				return b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+			}
+			log.Printf("[info] dashboard on user=%d chat=%d", user.ID, chatID)
+		}
+		return b.sendTaskPage(ctx, chatID, 0, user, viewAll, 0)
+	case "off":
+		if board == nil {
+			return b.sendText(ctx, chatID, lang.T("Панели задач в этом чате нет. Включить: /dashboard"))
+		}
+		if _, err := b.api.Request(tgbotapi.UnpinChatMessageConfig{ChatID: chatID, MessageID: board.MessageID}); err != nil {
+			log.Printf("unpin dashboard chat=%d: %v", chatID, err)
+		}
+		if err := b.taskSvc.TrackList(ctx, user, chatID, board.MessageID, board.View, board.Page, false); err != nil {
+			return b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] dashboard off user=%d chat=%d", user.ID, chatID)
+		return b.sendText(ctx, chatID, lang.T("📌 Панель задач откреплена и стала обычным списком. Вернуть: /dashboard"))
+	default:
+		return b.sendText(ctx, chatID, lang.T(dashboardUsage))
+	}
+}
+
+// trackedDashboard returns the chat's dashboard, nil when the chat has none.
+func (b *Bot) trackedDashboard(ctx context.Context, chatID int64) *model.ListMessage {
+	list, err := b.taskSvc.TrackedList(ctx, chatID)
+	if err != nil || !list.Dashboard {
+		return nil
+	}
+	return list
+}
+
+// replaceDashboard pins the new dashboard message and removes the previous one; a message
+// too old for the bot to delete is only unpinned. Errors are logged.
+func (b *Bot) replaceDashboard(chatID int64, previous, messageID int) {
+	pin := tgbotapi.PinChatMessageConfig{ChatID: chatID, MessageID: messageID, DisableNotification: true}
+	if _, err := b.api.Request(pin); err != nil {
+		log.Printf("pin dashboard chat=%d: %v", chatID, err)
+	}
+	if previous == 0 || previous == messageID {
+		return
+	}
+	if _, err := b.api.Request(tgbotapi.NewDeleteMessage(chatID, previous)); err != nil {
+		if _, err := b.api.Request(tgbotapi.UnpinChatMessageConfig{ChatID: chatID, MessageID: previous}); err != nil {
+			log.Printf("unpin old dashboard chat=%d: %v", chatID, err)
+		}
+	}
+}
+
+// dashboardHeader is the summary on top of the dashboard: open and overdue tasks, tasks
+// done today and when it was last redrawn, all in the owner's time zone.
+func (b *Bot) dashboardHeader(ctx context.Context, lang i18n.Lang, owner *model.User, tasks []model.Task, now time.Time) string {
+	now = now.In(owner.Location())
+	policy := owner.DeadlinePolicy()
+	open, overdue := 0, 0
+	for _, task := range tasks {
+		if task.IsRecurring && isRecurringDoneInWindow(task, now) || !task.IsRecurring && task.IsCompleted {
+			continue
+		}
+		open++
+		if !task.IsRecurring && service.Overdue(task.Deadline, now, policy) {
+			overdue++
+		}
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	done, err := b.taskSvc.ListCompleted(ctx, owner, today)
+	if err != nil {
+		log.Printf("dashboard completions of %d: %v", owner.ID, err)
+	}
+	return lang.Tf("📌 <b>Панель задач</b> · обновлено в %s\n", now.Format("15:04")) +
+		lang.Tf("🔥 Открыто: %d · ⚠️ Просрочено: %d · ✅ Сделано сегодня: %d\n\n", open, overdue, len(done))
+}
+
+// dashboardButtons is the last row of the dashboard: a new task and a manual redraw.
+func dashboardButtons(lang i18n.Lang, view listView, page int) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("➕ Новая задача"), cbReportPrefix+reportActionNew),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("🔄 Обновить"), fmt.Sprintf("%s%s:%d", cbPagePrefix, view, page)),
+	)
+}
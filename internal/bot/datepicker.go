@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+// Date picker callbacks: "date:m:2025-11" shows a month, "date:d:2025-11-30" picks a day,
+// "date:skip" and "date:clear" leave the deadline empty; "date:-" is an inert cell.
+const (
+	datePickMonth = "m:"
+	datePickDay   = "d:"
+	datePickSkip  = "skip"
+	datePickClear = "clear"
+	datePickNone  = "-"
+)
+
+var monthNames = [12]string{i18n.N("Январь"), i18n.N("Февраль"), i18n.N("Март"), i18n.N("Апрель"), i18n.N("Май"), i18n.N("Июнь"), i18n.N("Июль"), i18n.N("Август"), i18n.N("Сентябрь"), i18n.N("Октябрь"), i18n.N("Ноябрь"), i18n.N("Декабрь")}
+
+// calendarKeyboard renders month as a grid of day buttons, weeks starting on Monday.
+// emptyAction is datePickSkip while creating a task, datePickClear while editing one and
+// empty when a date is required, as for postponing.
+func calendarKeyboard(lang i18n.Lang, month, today time.Time, emptyAction string) tgbotapi.InlineKeyboardMarkup {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	button := func(label, payload string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, cbDatePrefix+payload)
+	}
+	inert := func(label string) tgbotapi.InlineKeyboardButton {
+		return button(label, datePickNone)
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{{
+		button("‹", datePickMonth+first.AddDate(0, -1, 0).Format("2006-01")),
+		inert(fmt.Sprintf("%s %d", lang.T(monthNames[first.Month()-1]), first.Year())),
+		button("›", datePickMonth+first.AddDate(0, 1, 0).Format("2006-01")),
+	}}
+	var header []tgbotapi.InlineKeyboardButton
+	for _, day := range []string{lang.T("Пн"), lang.T("Вт"), lang.T("Ср"), lang.T("Чт"), lang.T("Пт"), lang.T("Сб"), lang.T("Вс")} {
+		header = append(header, inert(day))
+	}
+	rows = append(rows, header)
+
+	week := make([]tgbotapi.InlineKeyboardButton, 0, 7)
+	for i := 0; i < (int(first.Weekday())+6)%7; i++ {
+		week = append(week, inert(" "))
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		label := strconv.Itoa(day.Day())
+		if day.Year() == today.Year() && day.YearDay() == today.YearDay() {
+			label = "·" + label + "·"
+		}
+		week = append(week, button(label, datePickDay+day.Format("2006-01-02")))
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]tgbotapi.InlineKeyboardButton, 0, 7)
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, inert(" "))
+		}
+		rows = append(rows, week)
+	}
+
+	switch emptyAction {
+	case datePickClear:
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button(lang.T(btnClear), datePickClear)))
+	case datePickSkip:
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button(lang.T(btnSkip), datePickSkip)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// sendDatePicker asks for a deadline with a calendar of the current month; typing the date still works.
+func (b *Bot) sendDatePicker(ctx context.Context, chatID int64, text, emptyAction string) error {
+	lang := i18n.FromContext(ctx)
+	today := time.Now()
+	return b.sendWithReplyMarkup(ctx, chatID, text, calendarKeyboard(lang, today, today, emptyAction))
+}
+
+// handleDatePicker turns months and takes the picked day as if the user had typed it.
+func (b *Bot) handleDatePicker(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	chatID, messageID := cb.Message.Chat.ID, cb.Message.MessageID
+	state := b.getConversation(cb.From.ID)
+	editing := state != nil && state.stage == stageEdit && state.field == editDeadline
+	postponing := state != nil && state.stage == stagePostpone
+	if state == nil || (state.stage != stageDeadline && !editing && !postponing) {
+		_, err := b.api.Request(tgbotapi.NewCallback(cb.ID, lang.T("Этот календарь уже неактуален.")))
+		return err
+	}
+	if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
+		log.Printf("callback ack: %v", err)
+	}
+
+	var input string
+	switch {
+	case payload == datePickNone:
+		return nil
+	case strings.HasPrefix(payload, datePickMonth):
+		month, err := time.Parse("2006-01", strings.TrimPrefix(payload, datePickMonth))
+		if err != nil {
+			return nil
+		}
+		emptyAction := datePickSkip
+		switch {
+		case editing:
+			emptyAction = datePickClear
+		case postponing:
+			emptyAction = ""
+		}
+		_, err = b.api.Request(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, calendarKeyboard(lang, month, time.Now(), emptyAction)))
+		return err
+	case strings.HasPrefix(payload, datePickDay):
+		input = strings.TrimPrefix(payload, datePickDay)
+		if _, err := time.Parse("2006-01-02", input); err != nil {
+			return nil
+		}
+	case payload == datePickSkip:
+		input = btnSkip
+	case payload == datePickClear:
+		input = btnClear
+	default:
+		return nil
+	}
+
+	// The calendar has done its job; leave only the question.
+	if _, err := b.api.Request(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})); err != nil {
+		log.Printf("remove date picker: %v", err)
+	}
+	return b.handleConversation(ctx, &tgbotapi.Message{From: cb.From, Chat: cb.Message.Chat, Text: input})
+}
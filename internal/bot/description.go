@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	btnDone = "✅ Готово"
+	// cbFilesPrefix sends the attachments of a task: "files:<id>".
+	cbFilesPrefix = "files:"
+)
+
+const descriptionPrompt = "✏️ Добавь описание — можно в несколько сообщений, с фото, видео и файлами. Когда закончишь, нажми «Готово» (или «Пропустить», если описание не нужно)."
+
+// describing reports whether the user is at the description step, where files become attachments.
+func (b *Bot) describing(userID int64) bool {
+	state := b.getConversation(userID)
+	return state != nil && state.stage == stageDescription
+}
+
+// collectDescription adds one message to the description being written: its text or
+// caption is appended, and its photo, video or file becomes an attachment.
+func (b *Bot) collectDescription(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	attachment, size, hasFile := messageAttachment(msg)
+	if hasFile {
+		user := CurrentUser(ctx)
+		if err := b.quotaSvc.CheckAttachment(user, size); err != nil {
+			return b.sendTransient(ctx, msg.Chat.ID, lang.Tf("Файл не принят: %s", errorText(lang, err)), descriptionKeyboard(lang))
+		}
+		if len(state.attachments) >= service.MaxTaskAttachments {
+			return b.sendTransient(ctx, msg.Chat.ID, lang.Tf("К задаче можно приложить не больше %d файлов. Нажми «Готово».", service.MaxTaskAttachments), descriptionKeyboard(lang))
+		}
+		state.attachments = append(state.attachments, attachment)
+	}
+	part := strings.TrimSpace(msg.Text)
+	if part == "" {
+		part = strings.TrimSpace(msg.Caption)
+	}
+	if part != "" {
+		if state.input.Description != "" {
+			state.input.Description += "\n\n"
+		}
+		state.input.Description += part
+	}
+	if !hasFile && part == "" {
+		return b.sendTransient(ctx, msg.Chat.ID, lang.T("В описание идут текст, фото, видео и файлы. Пришли их или нажми «Готово»."), descriptionKeyboard(lang))
+	}
+
+	// An album arrives as one message per file; answer it once.
+	if msg.MediaGroupID != "" && msg.MediaGroupID == state.mediaGroup {
+		return nil
+	}
+	state.mediaGroup = msg.MediaGroupID
+	note := lang.T("📝 Записал.")
+	if len(state.attachments) > 0 {
+		note = lang.Tf("📝 Записал, вложений: %d.", len(state.attachments))
+	}
+	return b.sendTransient(ctx, msg.Chat.ID, note+lang.T(" Присылай продолжение или нажми «Готово»."), descriptionKeyboard(lang))
+}
+
+// messageAttachment picks the photo, video or document of a message and its size.
+func messageAttachment(msg *tgbotapi.Message) (model.Attachment, int64, bool) {
+	attachment := model.Attachment{MediaGroupID: msg.MediaGroupID}
+	var size int
+	switch {
+	case len(msg.Photo) > 0:
+		// Telegram lists the sizes of a photo from the smallest up.
+		photo := msg.Photo[len(msg.Photo)-1]
+		attachment.Kind, attachment.FileID, size = model.AttachmentPhoto, photo.FileID, photo.FileSize
+	case msg.Video != nil:
+		attachment.Kind, attachment.FileID, size = model.AttachmentVideo, msg.Video.FileID, msg.Video.FileSize
+		attachment.FileName = msg.Video.FileName
+	case msg.Document != nil:
+		attachment.Kind, attachment.FileID, size = model.AttachmentDocument, msg.Document.FileID, msg.Document.FileSize
+		attachment.FileName = msg.Document.FileName
+	default:
+		return model.Attachment{}, 0, false
+	}
+	return attachment, int64(size), true
+}
+
+func isDoneInput(text string) bool {
+	value := strings.ToLower(strings.TrimSpace(text))
+	return i18n.Matches(value, btnDone) || value == "готово" || value == "done"
+}
+
+func descriptionKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnDone)),
+			tgbotapi.NewKeyboardButton(lang.T(btnSkip)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+		),
+	)
+	kb.ResizeKeyboard = true
+	return kb
+}
+
+// filesButton sends the attachments of the task from its card.
+func filesButton(lang i18n.Lang, taskID uint, count int) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.Tf("📎 Вложения (%d)", count), fmt.Sprintf("%s%d", cbFilesPrefix, taskID)),
+	)
+}
+
+// sendAttachments sends the files of the task back, photos and videos as albums.
+func (b *Bot) sendAttachments(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	attachments, err := b.taskSvc.Attachments(ctx, user, taskID)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	}
+	if len(attachments) == 0 {
+		return b.sendText(ctx, chatID, lang.T("У задачи нет вложений."))
+	}
+	// Albums cannot mix documents with photos and videos.
+	var media, documents []interface{}
+	for _, attachment := range attachments {
+		file := tgbotapi.FileID(attachment.FileID)
+		switch attachment.Kind {
+		case model.AttachmentPhoto:
+			media = append(media, tgbotapi.NewInputMediaPhoto(file))
+		case model.AttachmentVideo:
+			media = append(media, tgbotapi.NewInputMediaVideo(file))
+		default:
+			documents = append(documents, tgbotapi.NewInputMediaDocument(file))
+		}
+	}
+	for _, group := range [][]interface{}{media, documents} {
+		if err := b.sendAlbum(chatID, group); err != nil {
+			return err
+		}
+	}
+	log.Printf("[info] attachments sent task=%d user=%d count=%d", taskID, user.ID, len(attachments))
+	return nil
+}
+
+// sendAlbum sends the files as one album; a single file goes out as a plain message.
+func (b *Bot) sendAlbum(chatID int64, files []interface{}) error {
+	var request tgbotapi.Chattable
+	switch len(files) {
+	case 0:
+		return nil
+	case 1:
+		switch file := files[0].(type) {
+		case tgbotapi.InputMediaPhoto:
+			request = tgbotapi.NewPhoto(chatID, file.Media)
+		case tgbotapi.InputMediaVideo:
+			request = tgbotapi.NewVideo(chatID, file.Media)
+		case tgbotapi.InputMediaDocument:
+			request = tgbotapi.NewDocument(chatID, file.Media)
+		}
+	default:
+		request = tgbotapi.NewMediaGroup(chatID, files)
+	}
+	_, err := b.api.Request(request)
+	return err
+}
@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// savedDialog is conversationState as it is stored between restarts.
+type savedDialog struct {
+	Stage       conversationStage  `json:"stage"`
+	Input       service.TaskInput  `json:"input"`
+	TaskID      uint               `json:"task_id,omitempty"`
+	Field       string             `json:"field,omitempty"`
+	Fields      map[string]string  `json:"fields,omitempty"`
+	Attachments []model.Attachment `json:"attachments,omitempty"`
+	MediaGroup  string             `json:"media_group,omitempty"`
+	CategoryID  uint               `json:"category_id,omitempty"`
+}
+
+// savedConfirmation is confirmationRequest as it is stored between restarts.
+type savedConfirmation struct {
+	TaskID uint               `json:"task_id"`
+	Action confirmationAction `json:"action"`
+}
+
+// keepDialogs saves the sender's dialog and pending confirmation whenever an update changes
+// them, and brings them back with the first update after a restart.
+func (b *Bot) keepDialogs(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		userID := updateSenderID(update)
+		if userID == 0 {
+			return next(ctx, update)
+		}
+		b.restoreDialogs(ctx, userID)
+		before := b.dialogPayloads(userID)
+		err := next(ctx, update)
+		after := b.dialogPayloads(userID)
+		for _, kind := range []string{model.ConversationDialog, model.ConversationConfirmation} {
+			if before[kind] == after[kind] {
+				continue
+			}
+			var saveErr error
+			if after[kind] == "" {
+				saveErr = b.dialogRepo.Delete(ctx, userID, kind)
+			} else {
+				saveErr = b.dialogRepo.Save(ctx, &model.Conversation{TelegramID: userID, Kind: kind, Payload: after[kind]})
+			}
+			if saveErr != nil {
+				log.Printf("keep %s of %d: %v", kind, userID, saveErr)
+			}
+		}
+		return err
+	}
+}
+
+// restoreDialogs loads the dialogs the user had open before the bot restarted, once per start.
+func (b *Bot) restoreDialogs(ctx context.Context, userID int64) {
+	b.mu.Lock()
+	done := b.restored[userID]
+	b.restored[userID] = true
+	b.mu.Unlock()
+	if done {
+		return
+	}
+	conversations, err := b.dialogRepo.ListByTelegramID(ctx, userID)
+	if err != nil {
+		log.Printf("restore dialogs of %d: %v", userID, err)
+		return
+	}
+	for _, conversation := range conversations {
+		switch conversation.Kind {
+		case model.ConversationDialog:
+			var saved savedDialog
+			if err := json.Unmarshal([]byte(conversation.Payload), &saved); err != nil {
+				log.Printf("restore dialog of %d: %v", userID, err)
+				continue
+			}
+			b.setConversation(userID, &conversationState{
+				stage:       saved.Stage,
+				input:       saved.Input,
+				taskID:      saved.TaskID,
+				field:       saved.Field,
+				fields:      saved.Fields,
+				attachments: saved.Attachments,
+				mediaGroup:  saved.MediaGroup,
+				categoryID:  saved.CategoryID,
+			})
+		case model.ConversationConfirmation:
+			var saved savedConfirmation
+			if err := json.Unmarshal([]byte(conversation.Payload), &saved); err != nil {
+				log.Printf("restore confirmation of %d: %v", userID, err)
+				continue
+			}
+			b.setConfirmation(userID, confirmationRequest{taskID: saved.TaskID, action: saved.Action})
+		}
+	}
+	if len(conversations) > 0 {
+		log.Printf("[info] restored %d dialogs of %d", len(conversations), userID)
+	}
+}
+
+// dialogPayloads is the user's dialog and confirmation as they would be saved, by kind;
+// a missing one is empty.
+func (b *Bot) dialogPayloads(userID int64) map[string]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	payloads := make(map[string]string, 2)
+	if state := b.conversations[userID]; state != nil {
+		payloads[model.ConversationDialog] = encodePayload(savedDialog{
+			Stage:       state.stage,
+			Input:       state.input,
+			TaskID:      state.taskID,
+			Field:       state.field,
+			Fields:      state.fields,
+			Attachments: state.attachments,
+			MediaGroup:  state.mediaGroup,
+			CategoryID:  state.categoryID,
+		})
+	}
+	if req, ok := b.confirmations[userID]; ok {
+		payloads[model.ConversationConfirmation] = encodePayload(savedConfirmation{TaskID: req.taskID, Action: req.action})
+	}
+	return payloads
+}
+
+func encodePayload(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// The saved forms hold nothing json cannot encode.
+		panic(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,2087 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+)
+
+func TestCreateTaskViaConversation(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(101)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Купить хлеб")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, "Покупки")
+	h.expect("дедлайн")
+	h.send(alice, "2030-01-15")
+	h.expect("приоритет")
+	h.send(alice, btnPriorityHigh)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	h.expect("Задача сохранена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	task := tasks[0]
+	if task.Title != "Купить хлеб" || task.CategoryID == nil || task.Deadline == nil || task.Deadline.Format("2006-01-02") != "2030-01-15" || task.Priority != model.PriorityHigh {
+		t.Errorf("unexpected task: %+v", task)
+	}
+}
+
+func TestCompleteTaskViaCallback(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(102)
+	task := h.createTask(alice, service.TaskInput{Title: "Позвонить маме"})
+
+	h.press(alice, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID))
+	h.expect("Отметить задачу «Позвонить маме»")
+	h.press(alice, fmt.Sprintf("%s%d", cbConfirmPrefix, task.ID))
+	h.expect("выполнена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if !stored.IsCompleted || stored.LastCompletedAt == nil {
+		t.Errorf("task not completed: %+v", stored)
+	}
+}
+
+func TestCompleteTaskViaReaction(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(104)
+	task := h.createTask(alice, service.TaskInput{Title: "Полить цветы"})
+
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	card := h.expect("Полить цветы")
+	h.react(alice, card.MessageID, "🔥")
+	h.react(alice, card.MessageID, completeReaction)
+	h.expect("Задача «Полить цветы» выполнена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if !stored.IsCompleted {
+		t.Errorf("task not completed: %+v", stored)
+	}
+}
+
+func TestReportListsActiveTasks(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(103)
+	deadline := time.Now().Add(-24 * time.Hour)
+	h.createTask(alice, service.TaskInput{Title: "Сдать отчёт", Deadline: &deadline})
+	h.createTask(alice, service.TaskInput{Title: "Оплатить интернет", IsRecurring: true, RecurDay: time.Now().Day(), RecurWindow: 2})
+
+	h.send(alice, "/report")
+	report := h.expect("Сдать отчёт")
+	if text := report.Text(); !strings.Contains(text, "просрочено") || !strings.Contains(text, "Оплатить интернет") {
+		t.Errorf("report misses overdue marker or recurring task:\n%s", text)
+	}
+}
+
+func TestDeadlineAlertReactions(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(105)
+	deadline := time.Now().Add(2 * time.Hour)
+	task := h.createTask(alice, service.TaskInput{Title: "Продлить полис", Deadline: &deadline})
+	h.alertAnyTime(alice)
+
+	if err := h.bot.SendDeadlineAlerts(context.Background()); err != nil {
+		t.Fatalf("send alerts: %v", err)
+	}
+	alert := h.expect("Продлить полис")
+	h.react(alice, alert.MessageID, snoozeReaction)
+	h.expect("Напомню о «Продлить полис»")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.SnoozedUntil == nil || stored.SnoozedUntil.Before(time.Now().Add(2*time.Hour)) {
+		t.Errorf("alert not snoozed: %+v", stored)
+	}
+
+	h.react(alice, alert.MessageID, completeAltReaction)
+	h.expect("Задача «Продлить полис» выполнена")
+}
+
+func TestIntervalChangeSendsPreview(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(106)
+	h.createTask(alice, service.TaskInput{Title: "Разобрать почту"})
+
+	h.send(alice, "/interval 3")
+	h.expect("Следующий отчёт: ")
+	h.expect("Разобрать почту")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if user.ReportEveryHours != 3 || user.NextReportAt == nil {
+		t.Errorf("schedule not stored: %+v", user)
+	}
+}
+
+func TestProcrastinationNudges(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(107)
+	stale := h.createTask(alice, service.TaskInput{Title: "Разобрать балкон", Category: "Дом"})
+	postponed := h.createTask(alice, service.TaskInput{Title: "Написать статью"})
+	if err := h.db.Model(stale).Update("created_at", time.Now().AddDate(0, 0, -20)).Error; err != nil {
+		t.Fatalf("age task: %v", err)
+	}
+	if err := h.db.Model(postponed).Update("postpone_count", 4).Error; err != nil {
+		t.Fatalf("postpone task: %v", err)
+	}
+
+	if err := h.bot.SendProcrastinationNudges(context.Background()); err != nil {
+		t.Fatalf("send nudges: %v", err)
+	}
+	h.expect("не трогали уже 20 дн.")
+	h.expect("откладывалось 4 раз")
+
+	h.press(alice, fmt.Sprintf("%s%s:%d", cbNudgePrefix, nudgeKeep, postponed.ID))
+	h.expect("оставлена")
+	h.press(alice, fmt.Sprintf("%s%s:%d", cbNudgePrefix, nudgeSplit, stale.ID))
+	h.expect("Напиши шаги")
+	h.send(alice, "Вынести коробки\nПомыть окно")
+	h.expect("Задача разбита на шаги")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	titles := map[string]*uint{}
+	for _, task := range tasks {
+		titles[task.Title] = task.CategoryID
+	}
+	if len(tasks) != 3 || titles["Вынести коробки"] == nil || titles["Помыть окно"] == nil {
+		t.Errorf("unexpected tasks after breakdown: %+v", tasks)
+	}
+	if kept, err := h.taskRepo.FindByID(context.Background(), user.Scope(), postponed.ID); err != nil || kept.PostponeCount != 0 || kept.TouchedAt == nil {
+		t.Errorf("kept task not reset: %+v, %v", kept, err)
+	}
+}
+
+func TestBacklogTriage(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(108)
+	titles := []string{"Выучить испанский", "Починить велосипед", "Разобрать фото"}
+	for _, title := range titles {
+		task := h.createTask(alice, service.TaskInput{Title: title})
+		if err := h.db.Model(task).Update("created_at", time.Now().AddDate(0, -3, 0)).Error; err != nil {
+			t.Fatalf("age task: %v", err)
+		}
+	}
+	h.createTask(alice, service.TaskInput{Title: "Свежая задача"})
+
+	if err := h.bot.SendBacklogTriage(context.Background()); err != nil {
+		t.Fatalf("send triage: %v", err)
+	}
+	if text := h.expect("Разбор бэклога").Text(); strings.Contains(text, "Свежая задача") {
+		t.Errorf("fresh task offered for triage:\n%s", text)
+	}
+
+	var items []model.TriageItem
+	if err := h.db.Order("id ASC").Find(&items).Error; err != nil || len(items) != 3 {
+		t.Fatalf("triage items: %+v, %v", items, err)
+	}
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbTriagePrefix, items[0].ID, model.TriageArchive))
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbTriagePrefix, items[1].ID, model.TriageDelete))
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbTriagePrefix, items[2].ID, model.TriageKeep))
+	h.expect("Готово: оставлено 1, удалено 1, в архиве 1.")
+
+	h.send(alice, "/tasks")
+	if text := h.expect("Свежая задача").Text(); strings.Contains(text, "Выучить испанский") || strings.Contains(text, "Починить велосипед") {
+		t.Errorf("pruned tasks still listed:\n%s", text)
+	}
+	h.send(alice, "/archive")
+	h.expect("Выучить испанский")
+	h.send(alice, "/stats")
+	h.expect("Убрано 2: удалено 1, в архив 1; оставлено 1")
+}
+
+func TestDeleteRecurringChoice(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(109)
+	task := h.createTask(alice, service.TaskInput{Title: "Оплатить аренду", IsRecurring: true, RecurDay: 5, RecurWindow: 2})
+
+	h.send(alice, fmt.Sprintf("/delete %d", task.ID))
+	h.expect("повторяющаяся задача")
+	h.send(alice, btnEndRecurrence)
+	h.expect("Повторы задачи \"Оплатить аренду\" остановлены")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("ended task was deleted: %v", err)
+	}
+	if stored.RecurEndedAt == nil {
+		t.Errorf("recurrence not ended: %+v", stored)
+	}
+	active, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil || len(active) != 0 {
+		t.Errorf("ended task still active: %+v, %v", active, err)
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d", cbDeletePrefix, task.ID))
+	h.expect("Удалить задачу «Оплатить аренду»")
+	h.press(alice, fmt.Sprintf("%s%d", cbDeleteConfirmPrefix, task.ID))
+	h.expect("в корзине")
+	if _, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID); err == nil {
+		t.Errorf("task with history not deleted")
+	}
+}
+
+func TestDeleteCategoryMovesTasks(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(110)
+	task := h.createTask(alice, service.TaskInput{Title: "Купить молоко", Category: "Магазин"})
+	h.createTask(alice, service.TaskInput{Title: "Купить лампочки", Category: "Покупки"})
+
+	var target model.Category
+	if err := h.db.Where("name = ?", "Покупки").First(&target).Error; err != nil {
+		t.Fatalf("find target category: %v", err)
+	}
+	h.send(alice, "/category del магазин")
+	h.expect("Активных задач в ней: 1")
+	h.press(alice, fmt.Sprintf("%s%d:%s:%d", cbCategoryDeletePrefix, *task.CategoryID, categoryMoveTasks, target.ID))
+	h.expect("Задачи перенесены в «Покупки» (1)")
+
+	var moved model.Task
+	if err := h.db.First(&moved, task.ID).Error; err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if moved.CategoryID == nil || *moved.CategoryID != target.ID {
+		t.Errorf("task not moved: %+v", moved)
+	}
+	var left int64
+	h.db.Model(&model.Category{}).Where("id = ?", *task.CategoryID).Count(&left)
+	if left != 0 {
+		t.Errorf("category not deleted")
+	}
+}
+
+func TestCategoryDefaultsSkipDeadlineStep(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(111)
+	h.createTask(alice, service.TaskInput{Title: "Созвон", Category: "Работа"})
+
+	h.send(alice, "/category defaults Работа +3d 12h")
+	h.expect("дедлайн через 3 дн., напоминание за 12 ч")
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Подготовить презентацию")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, "Работа")
+	h.expect("Дедлайн по умолчанию")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	h.expect("Задача сохранена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	want := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	for _, task := range tasks {
+		if task.Title == "Подготовить презентацию" && (task.Deadline == nil || task.Deadline.Format("2006-01-02") != want || task.AlertBeforeHours != 12) {
+			t.Errorf("defaults not applied: %+v", task)
+		}
+	}
+}
+
+func TestSnoozeAlertWithPreset(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(112)
+	deadline := time.Now().Add(-time.Hour)
+	task := h.createTask(alice, service.TaskInput{Title: "Сдать декларацию", Deadline: &deadline})
+	h.alertAnyTime(alice)
+
+	if err := h.bot.SendDeadlineAlerts(context.Background()); err != nil {
+		t.Fatalf("send alerts: %v", err)
+	}
+	alert := h.expect("Сдать декларацию")
+	markup := alert.Params.Get("reply_markup")
+	if !strings.Contains(markup, "Завтра утром") {
+		t.Fatalf("no tomorrow preset for an overdue task: %s", markup)
+	}
+
+	until := time.Now().Add(30 * time.Hour).Truncate(time.Second)
+	h.pressOn(alice, alert.MessageID, fmt.Sprintf("%s%d", cbSnoozePrefix, until.Unix()))
+	h.expect("Напомню о «Сдать декларацию»")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.SnoozedUntil == nil || !stored.SnoozedUntil.Equal(until) || stored.PostponeCount != 1 {
+		t.Errorf("alert not snoozed until the preset: %+v", stored)
+	}
+}
+
+func TestSnoozeAlertByReply(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(113)
+	deadline := time.Now().Add(time.Hour)
+	task := h.createTask(alice, service.TaskInput{Title: "Оплатить счёт", Deadline: &deadline})
+	h.alertAnyTime(alice)
+
+	if err := h.bot.SendDeadlineAlerts(context.Background()); err != nil {
+		t.Fatalf("send alerts: %v", err)
+	}
+	alert := h.expect("Оплатить счёт")
+	h.reply(alice, alert.MessageID, "через 2 часа")
+	h.expect("Напомню о «Оплатить счёт»")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.SnoozedUntil == nil || stored.SnoozedUntil.Before(time.Now().Add(time.Hour+50*time.Minute)) {
+		t.Errorf("alert not snoozed by reply: %+v", stored)
+	}
+
+	h.send(alice, "/workhours 10-19")
+	h.expect("«вечером» — 20:00")
+}
+
+func TestReportFooterActions(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(114)
+	deadline := time.Now().Add(48 * time.Hour)
+	task := h.createTask(alice, service.TaskInput{Title: "Забрать посылку", Deadline: &deadline})
+
+	h.send(alice, "/report")
+	report := h.expect("Ежедневный отчёт")
+	markup := report.Params.Get("reply_markup")
+	if !strings.Contains(markup, cbReportPrefix+reportActionNew) {
+		t.Fatalf("report has no footer: %s", markup)
+	}
+	if !strings.Contains(markup, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)) {
+		t.Fatalf("report has no completion button for its task: %s", markup)
+	}
+
+	h.press(alice, cbReportPrefix+reportActionDone)
+	picker := h.expect("Что уже сделано?")
+	if markup := picker.Params.Get("reply_markup"); !strings.Contains(markup, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)) {
+		t.Fatalf("picker misses the open task: %s", markup)
+	}
+	h.press(alice, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID))
+	h.expect("Отметить задачу «Забрать посылку»")
+
+	h.press(alice, cbReportPrefix+reportActionList)
+	h.expect("Текущие задачи")
+
+	h.press(alice, cbReportPrefix+reportActionNew)
+	h.expect("Создаём новую задачу")
+}
+
+func TestEditTaskWizard(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(115)
+	task := h.createTask(alice, service.TaskInput{Title: "Починить кран", Category: "Дом"})
+
+	h.send(alice, fmt.Sprintf("/edit %d", task.ID))
+	menu := h.expect("Что изменить в задаче «Починить кран»")
+	if markup := menu.Params.Get("reply_markup"); !strings.Contains(markup, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editDeadline)) {
+		t.Fatalf("edit menu misses the deadline: %s", markup)
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editDeadline))
+	h.expect("Новый дедлайн")
+	h.send(alice, "30.01.2030")
+	h.expect("Не могу распознать дату")
+	h.send(alice, "2030-01-30")
+	h.expect("Задача обновлена")
+	h.expect("Дедлайн:</b> 2030-01-30")
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editRecurrence))
+	h.expect("День месяца или недели")
+	h.send(alice, "15 2")
+	h.expect("каждый месяц 15 числа")
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editCategory))
+	h.expect("Новая категория")
+	h.send(alice, btnClear)
+	h.expect("Задача обновлена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.Title != "Починить кран" || stored.CategoryID != nil || !stored.IsRecurring || stored.RecurDay != 15 || stored.RecurWindow != 2 ||
+		stored.Deadline == nil || stored.Deadline.Format("2006-01-02") != "2030-01-30" {
+		t.Errorf("task not updated: %+v", stored)
+	}
+}
+
+func TestCreateWeeklyTask(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(116)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Вынести мусор")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnYes)
+	h.expect("Как часто")
+	h.send(alice, btnWeekly)
+	h.expect("дням недели")
+	h.send(alice, "чт")
+	h.expect("0–3")
+	h.send(alice, "5")
+	h.expect("от 0 до 3")
+	h.send(alice, "1")
+	h.expect("Текст напоминания")
+	h.send(alice, btnSkip)
+	h.expect("каждую неделю по четвергам")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].RecurType != model.RecurWeekly || tasks[0].RecurWeekday != int(time.Thursday) || tasks[0].RecurWindow != 1 {
+		t.Fatalf("weekly task not stored: %+v", tasks)
+	}
+}
+
+func TestCreateTaskOnSeveralWeekdays(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(128)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Тренировка")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnYes)
+	h.expect("Как часто")
+	h.send(alice, btnWeekly)
+	h.expect("дням недели")
+	h.press(alice, cbWeekdaysPrefix+weekdaysDone)
+	h.expect("хотя бы один день")
+	h.press(alice, cbWeekdaysPrefix+"1")
+	h.press(alice, cbWeekdaysPrefix+"4")
+	h.press(alice, cbWeekdaysPrefix+weekdaysDone)
+	h.expect("0–1")
+	h.send(alice, "1")
+	h.expect("Текст напоминания")
+	h.send(alice, btnSkip)
+	h.expect("по понедельникам и четвергам")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	want := model.WeekdaySet(time.Monday, time.Thursday)
+	if len(tasks) != 1 || tasks[0].RecurWeekdays != want || tasks[0].RecurWeekday != int(time.Monday) {
+		t.Fatalf("weekday set not stored: %+v", tasks)
+	}
+}
+
+func TestCreateQuarterlyTask(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(129)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Заменить фильтр")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnYes)
+	h.expect("Как часто")
+	h.send(alice, btnQuarter)
+	h.expect("от первого выполнения")
+	h.send(alice, "7")
+	h.expect("Текст напоминания")
+	h.send(alice, btnSkip)
+	h.expect("раз в квартал от первого выполнения")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil || len(tasks) != 1 || tasks[0].RecurMonths() != service.QuarterlyMonths {
+		t.Fatalf("quarterly task not stored: %+v %v", tasks, err)
+	}
+	task := tasks[0]
+	done := time.Now()
+	if _, err := h.taskSvc.CompleteTask(context.Background(), user, task.ID, done); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	next := time.Date(done.Year(), done.Month()+3, 1, 0, 0, 0, 0, time.Local)
+	next = next.AddDate(0, 0, min(done.Day(), next.AddDate(0, 1, -1).Day())-1)
+	h.expect("Следующий раз:</b> " + next.Format("2006-01-02"))
+}
+
+func TestCreateEveryFewDaysTask(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(117)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Полить цветы")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnYes)
+	h.expect("Как часто")
+	h.send(alice, btnInterval)
+	h.expect("Через сколько дней")
+	h.send(alice, "3")
+	h.expect("(0–1)")
+	h.send(alice, "1")
+	h.expect("Текст напоминания")
+	h.send(alice, btnSkip)
+	h.expect("раз в 3 дн.")
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Зарядка")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnYes)
+	h.expect("Как часто")
+	h.send(alice, btnDaily)
+	h.expect("Текст напоминания")
+	h.send(alice, btnSkip)
+	h.expect("каждый день")
+
+	h.send(alice, "/report")
+	report := h.expect("Ежедневный отчёт")
+	if !strings.Contains(report.Text(), "Зарядка") || !strings.Contains(report.Text(), "Полить цветы") {
+		t.Errorf("repeats due today are missing from the report:\n%s", report.Text())
+	}
+}
+
+func TestReportCategoryButtons(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(118)
+	h.createTask(alice, service.TaskInput{Title: "Написать отчёт", Category: "Работа"})
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко", Category: "Покупки"})
+	h.createTask(alice, service.TaskInput{Title: "Позвонить маме"})
+
+	h.send(alice, "/report")
+	report := h.expect("Ежедневный отчёт")
+	markup := report.Params.Get("reply_markup")
+	for _, label := range []string{"Работа · 1", "Покупки · 1", "Без категории · 1", cbCategoryListPrefix + "0"} {
+		if !strings.Contains(markup, label) {
+			t.Fatalf("report keyboard misses %q: %s", label, markup)
+		}
+	}
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	categories, err := h.bot.categorySvc.List(context.Background(), user)
+	if err != nil {
+		t.Fatalf("list categories: %v", err)
+	}
+	var work uint
+	for _, category := range categories {
+		if category.Name == "Работа" {
+			work = category.ID
+		}
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d", cbCategoryListPrefix, work))
+	list := h.expect("Текущие задачи")
+	if !strings.Contains(list.Text(), "Написать отчёт") || strings.Contains(list.Text(), "Купить молоко") || strings.Contains(list.Text(), "Позвонить маме") {
+		t.Errorf("list is not limited to the category:\n%s", list.Text())
+	}
+
+	h.press(alice, cbCategoryListPrefix+"0")
+	list = h.expect("Текущие задачи")
+	if !strings.Contains(list.Text(), "Позвонить маме") || strings.Contains(list.Text(), "Написать отчёт") {
+		t.Errorf("list is not limited to tasks without a category:\n%s", list.Text())
+	}
+}
+
+func TestArchiveCategory(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(119)
+	task := h.createTask(alice, service.TaskInput{Title: "Сдать сессию", Category: "Учеба"})
+	h.createTask(alice, service.TaskInput{Title: "Купить хлеб", Category: "Покупки"})
+
+	h.send(alice, "/category archive учеба")
+	h.expect("Категория «Учеба» в архиве")
+
+	h.send(alice, "/categories")
+	list := h.expect("Категории")
+	if strings.Contains(list.Text(), "Учеба") || !strings.Contains(list.Text(), "Покупки") {
+		t.Errorf("archived category is still listed:\n%s", list.Text())
+	}
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Прочитать книгу")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	keyboard := h.expect("Выбери категорию")
+	if markup := keyboard.Params.Get("reply_markup"); strings.Contains(markup, "Учеба") || !strings.Contains(markup, "Работа") {
+		t.Errorf("category keyboard still offers the archived category: %s", markup)
+	}
+	h.send(alice, btnCancelDialog)
+
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	h.expect("Учеба")
+
+	h.send(alice, "/categories archived")
+	archived := h.expect("Категории в архиве")
+	data := fmt.Sprintf("%s%d", cbCategoryRestorePrefix, *task.CategoryID)
+	if markup := archived.Params.Get("reply_markup"); !strings.Contains(markup, data) {
+		t.Fatalf("no restore button: %s", markup)
+	}
+	h.press(alice, data)
+	h.expect("Категория «Учеба» снова в списках")
+
+	h.send(alice, "/categories")
+	if list := h.expect("Категории"); !strings.Contains(list.Text(), "Учеба") {
+		t.Errorf("restored category is missing:\n%s", list.Text())
+	}
+}
+
+func TestTaskReminder(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(121)
+	task := h.createTask(alice, service.TaskInput{Title: "Полить цветы"})
+
+	h.send(alice, fmt.Sprintf("/remind %d через 2 часа", task.ID))
+	h.expect(fmt.Sprintf("Напомню о задаче #%d", task.ID))
+	h.send(alice, fmt.Sprintf("/remind %d", task.ID))
+	h.expect("Напоминания")
+
+	if err := h.db.Model(&model.Reminder{}).Where("task_id = ?", task.ID).Update("at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("move reminder: %v", err)
+	}
+	if err := h.bot.SendTaskReminders(context.Background()); err != nil {
+		t.Fatalf("send reminders: %v", err)
+	}
+	notice := h.expect("Напоминание: «Полить цветы»")
+	if markup := notice.Params.Get("reply_markup"); !strings.Contains(markup, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)) {
+		t.Errorf("reminder has no completion button: %s", markup)
+	}
+
+	var pending int64
+	if err := h.db.Model(&model.Reminder{}).Where("sent_at IS NULL").Count(&pending).Error; err != nil {
+		t.Fatalf("count reminders: %v", err)
+	}
+	if pending != 0 {
+		t.Errorf("reminder still pending after it was sent")
+	}
+
+	h.send(alice, fmt.Sprintf("/remind %d 2001-01-01 09:00", task.ID))
+	h.expect("уже прошло")
+}
+
+func TestQuickReplies(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(122)
+	h.createTask(alice, service.TaskInput{Title: "Вынести мусор"})
+	task := h.createTask(alice, service.TaskInput{Title: "Купить хлеб"})
+
+	h.send(alice, "✅")
+	h.expect("Не знаю, какую задачу отметить")
+
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	h.expect("Купить хлеб")
+	h.send(alice, "✅")
+	h.expect("Задача «Купить хлеб» выполнена")
+
+	h.send(alice, "/emoji 👀 list")
+	h.expect("👀 — список задач")
+	h.send(alice, "👀")
+	if list := h.expect("Вынести мусор").Text(); strings.Contains(list, "Купить хлеб") {
+		t.Errorf("completed task still listed:\n%s", list)
+	}
+
+	h.send(alice, "➕️")
+	h.expect("Создаём новую задачу")
+}
+
+func TestPriorityOrderAndFilter(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(123)
+	h.createTask(alice, service.TaskInput{Title: "Полить цветы", Priority: model.PriorityLow})
+	h.createTask(alice, service.TaskInput{Title: "Сдать отчёт", Priority: model.PriorityUrgent})
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко"})
+
+	h.send(alice, "/tasks")
+	list := h.expect("Текущие задачи").Text()
+	urgent, normal, low := strings.Index(list, "Сдать отчёт"), strings.Index(list, "Купить молоко"), strings.Index(list, "Полить цветы")
+	if urgent < 0 || normal < urgent || low < normal {
+		t.Fatalf("tasks not ordered by priority:\n%s", list)
+	}
+
+	h.send(alice, "/tasks high")
+	filtered := h.expect("Текущие задачи").Text()
+	if !strings.Contains(filtered, "‼️ Сдать отчёт") || strings.Contains(filtered, "Купить молоко") {
+		t.Fatalf("unexpected filtered list:\n%s", filtered)
+	}
+
+	h.send(alice, "/report")
+	report := h.expect("Ежедневный отчёт").Text()
+	if strings.Index(report, "Сдать отчёт") > strings.Index(report, "Купить молоко") {
+		t.Fatalf("report not ordered by priority:\n%s", report)
+	}
+}
+
+func TestReportTimeoutIsPerUser(t *testing.T) {
+	h := newHarness(t)
+	alice, bob := testUser(124), testUser(125)
+	h.createTask(alice, service.TaskInput{Title: "Оплатить интернет"})
+	h.createTask(bob, service.TaskInput{Title: "Позвонить маме"})
+
+	// Every report runs out of time, yet the run goes through all users.
+	h.bot.config.ReportTimeout = time.Nanosecond
+	if err := h.bot.SendDailyReports(context.Background()); err != nil {
+		t.Fatalf("send reports: %v", err)
+	}
+	for _, telegramID := range []int64{alice.ID, bob.ID} {
+		user, err := h.userRepo.FindByTelegramID(context.Background(), telegramID)
+		if err != nil {
+			t.Fatalf("find user: %v", err)
+		}
+		if user.NextReportAt == nil {
+			t.Errorf("user %d was not reached", telegramID)
+		}
+	}
+
+	h.bot.config.ReportTimeout = time.Minute
+	h.send(alice, "/report")
+	h.expect("Оплатить интернет")
+}
+
+func TestReportDelivery(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(160)
+	h.createTask(alice, service.TaskInput{Title: "Оплатить интернет"})
+
+	h.send(alice, "/delivery pin")
+	h.expect("закреплённом сообщении")
+	planner := h.expect("Оплатить интернет")
+	if planner.Method != "sendMessage" || planner.Params.Get("disable_notification") != "true" {
+		t.Fatalf("planner message: %s %v", planner.Method, planner.Params)
+	}
+	if pin := h.expectCall("pinChatMessage"); pin.Params.Get("message_id") != strconv.Itoa(planner.MessageID) {
+		t.Fatalf("pinned message %s, want %d", pin.Params.Get("message_id"), planner.MessageID)
+	}
+
+	// Later reports are edited into the pinned message instead of piling up.
+	h.createTask(alice, service.TaskInput{Title: "Позвонить маме"})
+	h.send(alice, "/report")
+	edit := h.expect("Позвонить маме")
+	if edit.Method != "editMessageText" || edit.Params.Get("message_id") != strconv.Itoa(planner.MessageID) {
+		t.Fatalf("report: %s %v", edit.Method, edit.Params)
+	}
+	h.expect("Отчёт обновлён")
+
+	h.send(alice, "/delivery silent")
+	h.expect("без звука")
+	h.send(alice, "/report")
+	if report := h.expect("Позвонить маме"); report.Method != "sendMessage" || report.Params.Get("disable_notification") != "true" {
+		t.Fatalf("silent report: %s %v", report.Method, report.Params)
+	}
+}
+
+func TestQuickAdd(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(126)
+
+	h.send(alice, "/add Купить молоко #покупки !high @2030-01-15")
+	h.expect("Задача сохранена")
+	h.send(alice, "/add #покупки !сверхважно")
+	h.expect("Не знаю такого приоритета (!сверхважно)")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Купить молоко" || tasks[0].CategoryID == nil || tasks[0].Priority != model.PriorityHigh ||
+		tasks[0].Deadline == nil || tasks[0].Deadline.Format("2006-01-02") != "2030-01-15" {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	h := newHarness(t)
+	admin, alice := testUser(127), testUser(128)
+	h.bot.config.AdminIDs = []int64{admin.ID}
+	h.bot.config.MaintenanceMode = true
+
+	h.send(alice, "/tasks")
+	h.expect("техническом обслуживании")
+	h.send(admin, "/tasks")
+	h.expect("нет активных задач")
+}
+
+func TestDatePicker(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(129)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Продлить страховку")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	picker := h.expect("Выбери дедлайн в календаре")
+	if markup := picker.Params.Get("reply_markup"); !strings.Contains(markup, cbDatePrefix+datePickDay+time.Now().Format("2006-01-02")) {
+		t.Fatalf("calendar misses today: %s", markup)
+	}
+
+	h.pressOn(alice, picker.MessageID, cbDatePrefix+datePickMonth+"2030-01")
+	h.expectCall("editMessageReplyMarkup")
+	h.pressOn(alice, picker.MessageID, cbDatePrefix+datePickDay+"2030-01-15")
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	h.expect("Дедлайн:</b> 2030-01-15")
+
+	// Once the task is saved the calendar no longer applies.
+	h.pressOn(alice, picker.MessageID, cbDatePrefix+datePickDay+"2030-01-16")
+	if answer := h.expectCall("answerCallbackQuery"); !strings.Contains(answer.Params.Get("text"), "неактуален") {
+		t.Errorf("stale calendar answered with %q", answer.Params.Get("text"))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(130)
+
+	h.send(alice, "/add Купить молоко")
+	h.expect("Задача сохранена")
+	h.send(alice, "/add Позвонить маме")
+	h.expect("Задача сохранена")
+
+	h.send(alice, "/search МОЛОКО")
+	h.expect("Найдено: 1")
+	h.send(alice, "/search 100%")
+	h.expect("ничего не нашлось")
+}
+
+func TestTaskListPages(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(131)
+	h.bot.config.TaskPageSize = 2
+	h.createTask(alice, service.TaskInput{Title: "Сдать отчёт", Priority: model.PriorityUrgent})
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко"})
+	h.createTask(alice, service.TaskInput{Title: "Полить цветы", Priority: model.PriorityLow})
+
+	h.send(alice, "/tasks")
+	first := h.expect("Текущие задачи")
+	if strings.Contains(first.Text(), "Полить цветы") || !strings.Contains(first.Params.Get("reply_markup"), "page:all:1") {
+		t.Fatalf("unexpected first page: %s\n%s", first.Text(), first.Params.Get("reply_markup"))
+	}
+
+	h.pressOn(alice, first.MessageID, "page:all:1")
+	second := h.expectCall("editMessageText")
+	if !strings.Contains(second.Text(), "Полить цветы") || strings.Contains(second.Text(), "Сдать отчёт") ||
+		second.Params.Get("message_id") != strconv.Itoa(first.MessageID) {
+		t.Fatalf("unexpected second page: %v", second.Params)
+	}
+}
+
+func TestOpenListFollowsChanges(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(132)
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко"})
+	task := h.createTask(alice, service.TaskInput{Title: "Полить цветы"})
+
+	h.send(alice, "/tasks")
+	list := h.expect("Текущие задачи")
+
+	// The task is completed elsewhere, e.g. from a linked account.
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if _, err := h.taskSvc.CompleteTask(context.Background(), user, task.ID, time.Now()); err != nil {
+		t.Fatalf("complete task: %v", err)
+	}
+	edit := h.expectCall("editMessageText")
+	if edit.Params.Get("message_id") != strconv.Itoa(list.MessageID) || strings.Contains(edit.Text(), "Полить цветы") ||
+		!strings.Contains(edit.Text(), "Купить молоко") {
+		t.Fatalf("list not refreshed: %v", edit.Params)
+	}
+}
+
+func TestRemovedMemberListNotRefreshed(t *testing.T) {
+	h := newHarness(t)
+	alice, bob := testUser(182), testUser(183)
+	h.send(alice, "/workspace create Семья")
+	h.expect("создано")
+	var workspace model.Workspace
+	if err := h.db.First(&workspace).Error; err != nil {
+		t.Fatalf("load workspace: %v", err)
+	}
+	h.send(bob, "/workspace join "+workspace.InviteCode)
+	h.expect("теперь ты в «Семья»")
+	h.createTask(alice, service.TaskInput{Title: "Купить продукты"})
+	h.send(bob, "/tasks")
+	h.expect("Купить продукты")
+
+	member, err := h.userRepo.FindByTelegramID(context.Background(), bob.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	h.send(alice, fmt.Sprintf("/workspace remove %d", member.ID))
+	h.expect("исключён")
+
+	// Tasks added after the removal must not reach the former member's open list.
+	start := h.cursor
+	h.createTask(alice, service.TaskInput{Title: "Подарок для Боба"})
+	for _, call := range h.tg.callsSince(start) {
+		if call.Params.Get("chat_id") == "183" {
+			t.Fatalf("former member's list redrawn: %s %q", call.Method, call.Text())
+		}
+	}
+	var tracked int64
+	if err := h.db.Model(&model.ListMessage{}).Where("chat_id = ?", bob.ID).Count(&tracked).Error; err != nil {
+		t.Fatalf("count lists: %v", err)
+	}
+	if tracked != 0 {
+		t.Errorf("former member's list still tracked")
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(161)
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко"})
+
+	h.send(alice, "/dashboard")
+	board := h.expect("Панель задач")
+	if !strings.Contains(board.Text(), "Открыто: 1") || board.Params.Get("disable_notification") != "true" {
+		t.Fatalf("dashboard: %v", board.Params)
+	}
+	if pin := h.expectCall("pinChatMessage"); pin.Params.Get("message_id") != strconv.Itoa(board.MessageID) {
+		t.Fatalf("pinned %s, want %d", pin.Params.Get("message_id"), board.MessageID)
+	}
+
+	// Every change of the tasks redraws the dashboard in place.
+	h.createTask(alice, service.TaskInput{Title: "Полить цветы"})
+	edit := h.expect("Полить цветы")
+	if edit.Method != "editMessageText" || edit.Params.Get("message_id") != strconv.Itoa(board.MessageID) || !strings.Contains(edit.Text(), "Открыто: 2") {
+		t.Fatalf("dashboard not redrawn: %s %v", edit.Method, edit.Params)
+	}
+
+	// /tasks moves the dashboard down instead of adding a second list.
+	h.send(alice, "/tasks")
+	moved := h.expect("Панель задач")
+	if moved.Method != "sendMessage" {
+		t.Fatalf("moved dashboard: %s", moved.Method)
+	}
+	h.expectCall("pinChatMessage")
+	if deleted := h.expectCall("deleteMessage"); deleted.Params.Get("message_id") != strconv.Itoa(board.MessageID) {
+		t.Fatalf("deleted %s, want the old dashboard %d", deleted.Params.Get("message_id"), board.MessageID)
+	}
+
+	h.send(alice, "/dashboard off")
+	if unpin := h.expectCall("unpinChatMessage"); unpin.Params.Get("message_id") != strconv.Itoa(moved.MessageID) {
+		t.Fatalf("unpinned %s, want %d", unpin.Params.Get("message_id"), moved.MessageID)
+	}
+	h.expect("откреплена")
+	h.createTask(alice, service.TaskInput{Title: "Оплатить интернет"})
+	if list := h.expect("Оплатить интернет"); strings.Contains(list.Text(), "Панель задач") {
+		t.Fatalf("unpinned list still has the summary:\n%s", list.Text())
+	}
+}
+
+func TestListButtonsEditInPlace(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(133)
+	h.createTask(alice, service.TaskInput{Title: "Купить молоко"})
+	task := h.createTask(alice, service.TaskInput{Title: "Полить цветы"})
+
+	h.send(alice, "/tasks")
+	list := h.expect("Текущие задачи")
+	start := h.cursor
+
+	h.pressOn(alice, list.MessageID, fmt.Sprintf("%s%d", cbCompletePrefix, task.ID))
+	h.expect("Отметить задачу «Полить цветы»")
+	ask := h.expectCall("editMessageReplyMarkup")
+	if !strings.Contains(ask.Params.Get("reply_markup"), fmt.Sprintf("%s%d", cbConfirmPrefix, task.ID)) {
+		t.Fatalf("no inline confirmation: %v", ask.Params)
+	}
+
+	h.pressOn(alice, list.MessageID, fmt.Sprintf("%s%d", cbConfirmPrefix, task.ID))
+	edit := h.expectCall("editMessageText")
+	if edit.Params.Get("message_id") != strconv.Itoa(list.MessageID) || strings.Contains(edit.Text(), "Полить цветы") {
+		t.Fatalf("list not redrawn in place: %v", edit.Params)
+	}
+	h.expect("✅ Задача «Полить цветы» выполнена.")
+
+	for _, call := range h.tg.callsSince(start) {
+		if call.Method == "sendMessage" {
+			t.Errorf("button sent a new message: %q", call.Text())
+		}
+	}
+}
+
+func TestDeadlineCountdown(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(134)
+	deadline := time.Now().Add(30 * time.Minute)
+	task := h.createTask(alice, service.TaskInput{Title: "Сдать отчёт", Deadline: &deadline})
+	h.alertAnyTime(alice)
+
+	h.send(alice, "/countdown on")
+	h.expect("Обратный отсчёт включён")
+	if err := h.bot.SendDeadlineAlerts(context.Background()); err != nil {
+		t.Fatalf("send alerts: %v", err)
+	}
+	alert := h.expect("⏳ осталось")
+
+	if err := h.bot.UpdateCountdowns(context.Background()); err != nil {
+		t.Fatalf("update countdowns: %v", err)
+	}
+	edit := h.expectCall("editMessageText")
+	if edit.Params.Get("message_id") != strconv.Itoa(alert.MessageID) || !strings.Contains(edit.Text(), "⏳ осталось") {
+		t.Fatalf("countdown not redrawn: %v", edit.Params)
+	}
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if _, err := h.taskSvc.CompleteTask(context.Background(), user, task.ID, time.Now()); err != nil {
+		t.Fatalf("complete task: %v", err)
+	}
+	if err := h.bot.UpdateCountdowns(context.Background()); err != nil {
+		t.Fatalf("update countdowns: %v", err)
+	}
+	h.expect("✅ Выполнено")
+	if live, err := h.taskSvc.Countdowns(context.Background()); err != nil || len(live) != 0 {
+		t.Errorf("countdown kept running after completion: %+v, %v", live, err)
+	}
+}
+
+func TestDoneHistoryReopen(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(135)
+	task := h.createTask(alice, service.TaskInput{Title: "Вынести мусор"})
+	h.createTask(alice, service.TaskInput{Title: "Поливать цветы", IsRecurring: true, RecurType: model.RecurDaily, RecurWindow: 0})
+
+	h.send(alice, "/done")
+	h.expect("За 7 дней ничего не выполнено")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if _, err := h.taskSvc.CompleteTask(context.Background(), user, task.ID, time.Now()); err != nil {
+		t.Fatalf("complete task: %v", err)
+	}
+	h.send(alice, "/done")
+	history := h.expect("Выполнено за 7 дней: 1")
+	if !strings.Contains(history.Text(), "Сегодня") || !strings.Contains(history.Params.Get("reply_markup"), fmt.Sprintf("%s%d", cbReopenPrefix, task.ID)) {
+		t.Fatalf("unexpected history: %q %s", history.Text(), history.Params.Get("reply_markup"))
+	}
+
+	h.pressOn(alice, history.MessageID, fmt.Sprintf("%s%d", cbReopenPrefix, task.ID))
+	h.expect("снова открыта")
+	stored, err := h.taskRepo.FindByID(context.Background(), user.Scope(), task.ID)
+	if err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	if stored.IsCompleted || stored.LastCompletedAt != nil {
+		t.Errorf("task not reopened: %+v", stored)
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(137)
+	task := h.createTask(alice, service.TaskInput{Title: "Сдать отчёт"})
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if _, err := h.taskSvc.CompleteTask(context.Background(), user, task.ID, time.Now()); err != nil {
+		t.Fatalf("complete task: %v", err)
+	}
+	h.send(alice, "/heatmap")
+	grid := h.expect("выполнено: 1")
+	if !strings.Contains(grid.Text(), "🟩") {
+		t.Fatalf("busiest day is not shaded: %q", grid.Text())
+	}
+
+	h.send(alice, "/heatmap image")
+	h.expectCall("sendPhoto")
+	if user, err = h.userRepo.FindByTelegramID(context.Background(), alice.ID); err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if !user.HeatmapImage {
+		t.Errorf("image preference not saved")
+	}
+
+	h.send(alice, "/heatmap 13.2025")
+	h.expect("Формат: /heatmap")
+}
+
+func TestCustomFields(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(138)
+
+	h.send(alice, "/fields add клиент текст")
+	h.expect("Поле «клиент» (текст) добавлено")
+	h.send(alice, "/fields add сумма число")
+	h.expect("Поле «сумма» (число) добавлено")
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Выставить счёт")
+	h.expect("описание")
+	h.send(alice, btnSkip)
+	h.expect("Заполни поля")
+	h.send(alice, "сумма: много")
+	h.expect("В поле «сумма» нужно число")
+	h.send(alice, "клиент: ООО Ромашка\nсумма: 12 500,5")
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	h.expect("Сумма:</b> 12500.5")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskSvc.ListActive(context.Background(), user)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("tasks: %v %v", tasks, err)
+	}
+	task := tasks[0]
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editFields))
+	h.expect("очистить поле")
+	h.send(alice, "сумма: -")
+	h.expect("Поля обновлены")
+	card := h.expect("Клиент:</b> ООО Ромашка")
+	if strings.Contains(card.Text(), "Сумма") {
+		t.Errorf("cleared field still shown: %q", card.Text())
+	}
+
+	h.send(alice, "/fields del клиент")
+	h.expect("удалено вместе со значениями")
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	if card := h.expect(fmt.Sprintf("#%d", task.ID)); strings.Contains(card.Text(), "Клиент") {
+		t.Errorf("removed field still shown: %q", card.Text())
+	}
+}
+
+func TestTrash(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(139)
+	kept := h.createTask(alice, service.TaskInput{Title: "Позвонить бабушке"})
+	purged := h.createTask(alice, service.TaskInput{Title: "Старый черновик"})
+
+	h.send(alice, "/trash")
+	h.expect("Корзина пуста")
+	h.send(alice, fmt.Sprintf("/delete %d", kept.ID))
+	h.expect("Вернуть её можно из /trash")
+	h.send(alice, fmt.Sprintf("/delete %d", purged.ID))
+	h.expect("Вернуть её можно из /trash")
+
+	// The second task was deleted long ago, so the nightly purge removes it for good.
+	if err := h.db.Unscoped().Model(&model.Task{}).Where("id = ?", purged.ID).
+		Update("deleted_at", time.Now().Add(-service.TrashRetention-time.Hour)).Error; err != nil {
+		t.Fatalf("age deleted task: %v", err)
+	}
+	if err := h.bot.PurgeTrash(context.Background()); err != nil {
+		t.Fatalf("purge trash: %v", err)
+	}
+	var count int64
+	if err := h.db.Unscoped().Model(&model.Task{}).Where("id = ?", purged.ID).Count(&count).Error; err != nil || count != 0 {
+		t.Errorf("old deleted task not purged: %d %v", count, err)
+	}
+
+	h.send(alice, "/trash")
+	trash := h.expect("Корзина: 1")
+	if strings.Contains(trash.Text(), "Старый черновик") {
+		t.Errorf("purged task listed: %q", trash.Text())
+	}
+	h.pressOn(alice, trash.MessageID, fmt.Sprintf("%s%d", cbUndeletePrefix, kept.ID))
+	h.expect("возвращена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if _, err := h.taskRepo.FindByID(context.Background(), user.Scope(), kept.ID); err != nil {
+		t.Errorf("task not restored: %v", err)
+	}
+}
+
+func TestCalendarWeek(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(136)
+	// Tomorrow may fall into the next week, so look at the week of the deadline itself.
+	deadline := time.Now().AddDate(0, 0, 1)
+	task := h.createTask(alice, service.TaskInput{Title: "Записаться к врачу", Deadline: &deadline})
+	day := deadline.Format(weekDateLayout)
+
+	h.send(alice, "/calendarweek")
+	week := h.expect("<b>Неделя")
+	if !strings.Contains(week.Text(), "Сроки") {
+		t.Fatalf("no grid: %q", week.Text())
+	}
+
+	h.pressOn(alice, week.MessageID, cbWeekPrefix+"w"+day)
+	grid := h.expectCall("editMessageText")
+	if !strings.Contains(grid.Params.Get("reply_markup"), cbWeekPrefix+"d"+day) {
+		t.Fatalf("no button for the deadline day: %s", grid.Params.Get("reply_markup"))
+	}
+
+	h.pressOn(alice, week.MessageID, cbWeekPrefix+"d"+day)
+	view := h.expectCall("editMessageText")
+	if !strings.Contains(view.Text(), "Записаться к врачу") || !strings.Contains(view.Params.Get("reply_markup"), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)) {
+		t.Fatalf("day view misses the task: %q", view.Text())
+	}
+}
+
+func TestCategoryStyle(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(163)
+	task := h.createTask(alice, service.TaskInput{Title: "Собрать рюкзак", Category: "Поход"})
+
+	h.send(alice, "/category style поход")
+	h.expect("🏷️ Поход")
+	h.send(alice, "abc")
+	h.expect("один эмодзи")
+	h.send(alice, "⛺")
+	h.expect("цвет-метка")
+	h.send(alice, colorButton(i18n.RU, "green"))
+	h.expect("Готово: ⛺ Поход 🟢")
+
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	h.expect("⛺ Поход 🟢")
+
+	h.send(alice, "/category style поход")
+	h.send(alice, btnSkip)
+	h.send(alice, btnNoColor)
+	h.expect("Готово: ⛺ Поход")
+	h.send(alice, "/categories")
+	if list := h.expect("Категории"); strings.Contains(list.Text(), "🟢") || !strings.Contains(list.Text(), "⛺ Поход") {
+		t.Errorf("category list does not show the new style:\n%s", list.Text())
+	}
+}
+
+func TestListSortIsRemembered(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(164)
+	deadline := time.Now().AddDate(0, 0, 2)
+	h.createTask(alice, service.TaskInput{Title: "Срочное без срока", Priority: model.PriorityHigh})
+	h.createTask(alice, service.TaskInput{Title: "Отчёт к пятнице", Priority: model.PriorityLow, Deadline: &deadline})
+
+	before := func(text, first, second string) bool {
+		return strings.Index(text, first) < strings.Index(text, second)
+	}
+	h.send(alice, "/tasks")
+	list := h.expect("Текущие задачи")
+	if !before(list.Text(), "Срочное", "Отчёт") {
+		t.Fatalf("default order is not by priority:\n%s", list.Text())
+	}
+	data := cbSortPrefix + string(viewAll) + ":" + sortDeadline
+	if !strings.Contains(list.Params.Get("reply_markup"), data) {
+		t.Fatalf("no sort buttons: %s", list.Params.Get("reply_markup"))
+	}
+	h.pressOn(alice, list.MessageID, data)
+	if sorted := h.expect("Текущие задачи"); sorted.Method != "editMessageText" || !before(sorted.Text(), "Отчёт", "Срочное") {
+		t.Fatalf("list is not redrawn by deadline (%s):\n%s", sorted.Method, sorted.Text())
+	}
+
+	h.send(alice, "/tasks")
+	if again := h.expect("Текущие задачи"); !before(again.Text(), "Отчёт", "Срочное") {
+		t.Errorf("chosen order is forgotten:\n%s", again.Text())
+	}
+}
+
+func TestAutoDeleteTransientMessages(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(166)
+	first := h.createTask(alice, service.TaskInput{Title: "Вынести мусор"})
+	second := h.createTask(alice, service.TaskInput{Title: "Помыть посуду"})
+
+	h.send(alice, fmt.Sprintf("/complete %d", first.ID))
+	h.expect("выполнена")
+	h.send(alice, "/autodelete 5")
+	h.expect("через 5 мин")
+	h.send(alice, fmt.Sprintf("/complete %d", second.ID))
+	notice := h.expect("выполнена")
+
+	var scheduled []model.TransientMessage
+	if err := h.db.Find(&scheduled).Error; err != nil {
+		t.Fatalf("list scheduled deletions: %v", err)
+	}
+	if len(scheduled) != 1 || scheduled[0].MessageID != notice.MessageID {
+		t.Fatalf("want only the notice sent after /autodelete scheduled, got %+v", scheduled)
+	}
+	if err := h.bot.DeleteExpiredMessages(context.Background()); err != nil {
+		t.Fatalf("delete expired: %v", err)
+	}
+	if err := h.db.Model(&model.TransientMessage{}).Where("1 = 1").Update("delete_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire: %v", err)
+	}
+	if err := h.bot.DeleteExpiredMessages(context.Background()); err != nil {
+		t.Fatalf("delete expired: %v", err)
+	}
+	call := h.expectCall("deleteMessage")
+	if call.Params.Get("message_id") != strconv.Itoa(notice.MessageID) {
+		t.Errorf("deleted message %s, want %d", call.Params.Get("message_id"), notice.MessageID)
+	}
+	var left int64
+	h.db.Model(&model.TransientMessage{}).Count(&left)
+	if left != 0 {
+		t.Errorf("%d deletions are still scheduled", left)
+	}
+}
+
+func TestTaskDetailCard(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(167)
+	task := h.createTask(alice, service.TaskInput{Title: "Переезд", Description: "в новую квартиру", Category: "Дом"})
+
+	h.send(alice, "/tasks")
+	if list := h.expect("Текущие задачи"); !strings.Contains(list.Params.Get("reply_markup"), fmt.Sprintf("%s%d", cbTaskPrefix, task.ID)) {
+		t.Fatalf("list rows have no card button: %s", list.Params.Get("reply_markup"))
+	}
+	h.press(alice, fmt.Sprintf("%s%d", cbTaskPrefix, task.ID))
+	card := h.expect("в новую квартиру")
+	for _, data := range []string{cbRemindPrefix, cbSubtaskPrefix} {
+		if !strings.Contains(card.Params.Get("reply_markup"), fmt.Sprintf("%s%d", data, task.ID)) {
+			t.Errorf("card has no %s button: %s", data, card.Params.Get("reply_markup"))
+		}
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d", cbSubtaskPrefix, task.ID))
+	h.expect("Название подзадачи")
+	h.send(alice, "Заказать грузчиков")
+	h.expect("Подзадача")
+	if card := h.expect("Подзадачи</b> 0/1"); !strings.Contains(card.Text(), "Заказать грузчиков") {
+		t.Errorf("card does not list the subtask:\n%s", card.Text())
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d", cbRemindPrefix, task.ID))
+	h.expect("Когда напомнить")
+	h.send(alice, "когда-нибудь")
+	h.expect("Не понял время")
+	h.send(alice, "через 2 часа")
+	h.expect("Напомню о задаче")
+	h.press(alice, fmt.Sprintf("%s%d", cbTaskPrefix, task.ID))
+	h.expect("🔔 напомнить")
+}
+
+func TestPostponeOverdueTask(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(168)
+	overdue := time.Now().AddDate(0, 0, -3)
+	task := h.createTask(alice, service.TaskInput{Title: "Оплатить интернет", Deadline: &overdue})
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	h.send(alice, "/tasks")
+	list := h.expect("Текущие задачи")
+	data := fmt.Sprintf("%s%d:1", cbPostponePrefix, task.ID)
+	if !strings.Contains(list.Params.Get("reply_markup"), data) {
+		t.Fatalf("overdue task has no postpone buttons: %s", list.Params.Get("reply_markup"))
+	}
+	h.press(alice, data)
+	h.expect("новый дедлайн " + tomorrow)
+
+	h.send(alice, fmt.Sprintf("/postpone %d 1w", task.ID))
+	h.expect("новый дедлайн " + time.Now().AddDate(0, 0, 8).Format("2006-01-02"))
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbPostponePrefix, task.ID, postponePick))
+	picker := h.expect("Выбери новый дедлайн")
+	if strings.Contains(picker.Params.Get("reply_markup"), cbDatePrefix+datePickSkip) {
+		t.Errorf("postpone calendar offers to skip the date: %s", picker.Params.Get("reply_markup"))
+	}
+	h.pressOn(alice, picker.MessageID, cbDatePrefix+datePickDay+"2030-01-15")
+	h.expect("новый дедлайн 2030-01-15")
+
+	h.send(alice, "/stats")
+	if stats := h.expect("Переносы дедлайнов"); !strings.Contains(stats.Text(), "Оплатить интернет — 3 раз") {
+		t.Errorf("stats do not count the postponements:\n%s", stats.Text())
+	}
+}
+
+func TestMenuComesBackWithoutPlaceholder(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(169)
+	// deleteRecurring deletes a new recurring task with its history and returns the
+	// deletion notice and everything sent after the choice.
+	deleteRecurring := func(title string) (apiCall, []apiCall) {
+		task := h.createTask(alice, service.TaskInput{Title: title, IsRecurring: true, RecurType: model.RecurDaily, RecurInterval: 2})
+		h.send(alice, fmt.Sprintf("/delete %d", task.ID))
+		h.expect("повторяющаяся задача")
+		start := h.cursor
+		h.send(alice, btnDeleteHistory)
+		notice := h.expect("удалена")
+		h.expect("нет активных задач")
+		return notice, h.tg.callsSince(start)
+	}
+	placeholders := func(calls []apiCall) int {
+		count := 0
+		for _, call := range calls {
+			if strings.Contains(call.Text(), "Главное меню") {
+				count++
+			}
+		}
+		return count
+	}
+
+	notice, calls := deleteRecurring("Полить цветы")
+	if !strings.Contains(notice.Params.Get("reply_markup"), menuLabelTasks) {
+		t.Errorf("deletion notice does not bring the menu back: %s", notice.Params.Get("reply_markup"))
+	}
+	if placeholders(calls) != 0 {
+		t.Errorf("menu placeholder sent without LEGACY_MENU_PLACEHOLDER")
+	}
+
+	h.bot.config.LegacyMenuPlaceholder = true
+	if _, calls := deleteRecurring("Покормить кота"); placeholders(calls) != 1 {
+		t.Errorf("want one menu placeholder in legacy mode, got %d", placeholders(calls))
+	}
+}
+
+func TestDescriptionInSeveralMessages(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(170)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Ремонт ванной")
+	h.expect("в несколько сообщений")
+	h.send(alice, "Плитка: белая, 20×20.")
+	h.expect("Записал")
+	h.send(alice, "Затирка — серая.")
+	h.expect("Записал")
+	h.sendPhoto(alice, "photo-1", "Образец плитки", "album-1")
+	h.sendPhoto(alice, "photo-2", "", "album-1")
+	h.expect("вложений: 1")
+	h.send(alice, btnDone)
+	h.expect("категорию")
+	h.send(alice, btnSkip)
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnSkip)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	saved := h.expect("Задача сохранена")
+	if !strings.Contains(saved.Text(), "Плитка: белая, 20×20.\n\nЗатирка — серая.\n\nОбразец плитки") {
+		t.Errorf("description parts are not joined:\n%s", saved.Text())
+	}
+	if !strings.Contains(saved.Text(), "Вложения:</b> 2") {
+		t.Errorf("album is not attached:\n%s", saved.Text())
+	}
+
+	var task model.Task
+	if err := h.db.Where("title = ?", "Ремонт ванной").First(&task).Error; err != nil {
+		t.Fatalf("find task: %v", err)
+	}
+	h.send(alice, fmt.Sprintf("/task %d", task.ID))
+	card := h.expect(fmt.Sprintf("🗂 <b>#%d</b>", task.ID))
+	if !strings.Contains(card.Params.Get("reply_markup"), fmt.Sprintf("%s%d", cbFilesPrefix, task.ID)) {
+		t.Fatalf("card has no attachments button: %s", card.Params.Get("reply_markup"))
+	}
+	h.press(alice, fmt.Sprintf("%s%d", cbFilesPrefix, task.ID))
+	album := h.expectCall("sendMediaGroup")
+	if media := album.Params.Get("media"); !strings.Contains(media, "photo-1") || !strings.Contains(media, "photo-2") || strings.Contains(media, "small") {
+		t.Errorf("album does not carry the largest photos: %s", media)
+	}
+}
+
+func TestWeeklySummary(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(171)
+	h.bot.config.WeeklySummaryTime = "19:00"
+	overdue := time.Now().AddDate(0, 0, -2)
+	h.createTask(alice, service.TaskInput{Title: "Сдать отчёт", Category: "Работа", Deadline: &overdue})
+	done := h.createTask(alice, service.TaskInput{Title: "Купить хлеб", Category: "Дом"})
+	h.send(alice, fmt.Sprintf("/complete %d", done.ID))
+	h.expect("выполнена")
+
+	h.send(alice, "/weekly")
+	summary := h.expect("Итоги недели")
+	for _, want := range []string{"Выполнено: 1", "Открыто: 1", "Просрочено: 1", "Работа — ✅ 0 · 📌 1 · ⚠️ 1", "Дом — ✅ 1 · 📌 0"} {
+		if !strings.Contains(summary.Text(), want) {
+			t.Errorf("summary has no %q:\n%s", want, summary.Text())
+		}
+	}
+	if strings.Contains(summary.Text(), "Дни рождения") {
+		t.Errorf("summary has an empty dates section:\n%s", summary.Text())
+	}
+	h.send(alice, "/contact add "+time.Now().AddDate(0, 0, 2).Format("02.01")+" Маша")
+	h.expect("Маша")
+	h.send(alice, "/weekly")
+	if text := h.expect("Итоги недели").Text(); !strings.Contains(text, "Дни рождения на этой неделе") || !strings.Contains(text, "— Маша") {
+		t.Errorf("summary has no dates of the week ahead:\n%s", text)
+	}
+
+	start := h.cursor
+	if err := h.bot.SendWeeklySummaries(context.Background()); err != nil {
+		t.Fatalf("send summaries: %v", err)
+	}
+	h.send(alice, "/weekly on")
+	h.expect("по воскресеньям в 19:00")
+	for _, call := range h.tg.callsSince(start) {
+		if strings.Contains(call.Text(), "Выполнено:") {
+			t.Errorf("summary sent before /weekly on")
+		}
+	}
+	if err := h.bot.SendWeeklySummaries(context.Background()); err != nil {
+		t.Fatalf("send summaries: %v", err)
+	}
+	h.expect("Итоги недели")
+}
+
+func TestShareTaskCard(t *testing.T) {
+	h := newHarness(t)
+	alice, bob := testUser(172), testUser(173)
+	h.send(alice, "/workspace create Семья")
+	created := h.expect("создано")
+	task := h.createTask(alice, service.TaskInput{Title: "Купить продукты", Description: "молоко и хлеб"})
+
+	h.press(alice, fmt.Sprintf("%s%d", cbSharePrefix, task.ID))
+	card := h.expect("Из пространства «Семья»")
+	if strings.Contains(card.Text(), fmt.Sprintf("#%d", task.ID)) || !strings.Contains(card.Text(), "молоко и хлеб") {
+		t.Errorf("shared card:\n%s", card.Text())
+	}
+	var workspace model.Workspace
+	if err := h.db.First(&workspace).Error; err != nil {
+		t.Fatalf("load workspace: %v", err)
+	}
+	if !strings.Contains(created.Text(), workspace.InviteCode) {
+		t.Fatalf("unexpected workspace %q", workspace.InviteCode)
+	}
+	link := "https://t.me/planner_test_bot?start=" + joinPayload + workspace.InviteCode
+	if !strings.Contains(card.Params.Get("reply_markup"), link) {
+		t.Fatalf("card has no join link %s: %s", link, card.Params.Get("reply_markup"))
+	}
+	h.expect("Перешли карточку")
+
+	h.send(bob, "/start "+joinPayload+workspace.InviteCode)
+	h.expect("Добро пожаловать в «Семья»")
+	h.send(bob, "/tasks")
+	h.expect("Купить продукты")
+}
+
+func TestBuddyEscalation(t *testing.T) {
+	h := newHarness(t)
+	alice, bob := testUser(174), testUser(175)
+	bob.UserName = "Bob_Buddy"
+	h.send(bob, "/start")
+	overdue := time.Now().AddDate(0, 0, -5)
+	task := h.createTask(alice, service.TaskInput{Title: "Записаться к врачу", Deadline: &overdue})
+
+	h.send(alice, fmt.Sprintf("/buddy watch %d", task.ID))
+	h.expect("Партнёра пока нет")
+	h.send(alice, "/buddy @bob_buddy")
+	request := h.expect("просит тебя стать партнёром")
+	if request.Params.Get("chat_id") != "175" {
+		t.Fatalf("request went to chat %s", request.Params.Get("chat_id"))
+	}
+	h.expect("Запрос отправлен")
+
+	// Nothing goes out until the partner agrees.
+	start := h.cursor
+	if err := h.bot.SendBuddyEscalations(context.Background()); err != nil {
+		t.Fatalf("send escalations: %v", err)
+	}
+	if calls := h.tg.callsSince(start); len(calls) != 0 {
+		t.Fatalf("escalated before the partner agreed: %q", calls[0].Text())
+	}
+
+	var owner model.User
+	if err := h.db.Where("telegram_id = ?", alice.ID).First(&owner).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	h.press(bob, fmt.Sprintf("%syes:%d", cbBuddyPrefix, owner.ID))
+	h.expect("Ты партнёр")
+	h.expect("согласился быть партнёром")
+
+	if err := h.bot.SendBuddyEscalations(context.Background()); err != nil {
+		t.Fatalf("send escalations: %v", err)
+	}
+	note := h.expect("просрочена на")
+	if note.Params.Get("chat_id") != "175" || !strings.Contains(note.Text(), "Записаться к врачу") || !strings.Contains(note.Text(), "5 дн.") {
+		t.Errorf("escalation: chat %s, %q", note.Params.Get("chat_id"), note.Text())
+	}
+	start = h.cursor
+	if err := h.bot.SendBuddyEscalations(context.Background()); err != nil {
+		t.Fatalf("send escalations: %v", err)
+	}
+	if calls := h.tg.callsSince(start); len(calls) != 0 {
+		t.Errorf("escalated the same deadline twice")
+	}
+
+	h.press(bob, fmt.Sprintf("%sstop:%d", cbBuddyPrefix, owner.ID))
+	h.expect("Больше не пришлю")
+	h.expect("больше не будет получать")
+}
+
+func TestLanguage(t *testing.T) {
+	h := newHarness(t)
+	bob := testUser(181)
+	bob.LanguageCode = "en-GB"
+
+	h.send(bob, "/tasks")
+	h.expect("You have no active tasks")
+
+	h.createTask(bob, service.TaskInput{Title: "Buy milk", Category: "Shopping"})
+	h.send(bob, "/report")
+	report := h.expect("Daily report")
+	if !strings.Contains(report.Text(), "Buy milk") || strings.Contains(report.Text(), "Текущие задачи") {
+		t.Errorf("report is not in English:\n%s", report.Text())
+	}
+
+	h.send(bob, "/language ru")
+	h.expect("теперь я говорю: Русский")
+	h.send(bob, "/tasks")
+	h.expect("Текущие задачи")
+
+	h.send(bob, "/language ru+en")
+	h.expect("Заголовки отчёта будут на двух языках: Русский + English")
+	h.send(bob, "/report")
+	report = h.expect("Ежедневный отчёт / Daily report")
+	if !strings.Contains(report.Text(), "Текущие задачи / Current tasks") {
+		t.Errorf("report headers are not bilingual:\n%s", report.Text())
+	}
+
+	h.send(bob, "/language auto")
+	h.expect("now I speak English")
+	h.send(bob, "/language klingon")
+	h.expect("Format: /language en")
+}
+
+func TestPlainText(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(166)
+
+	h.send(alice, "/plain on")
+	h.expect("Простой текст включён")
+	h.createTask(alice, service.TaskInput{Title: `Купить "молоко"`, Priority: model.PriorityUrgent})
+	h.send(alice, "/report")
+	report := h.expect("Ежедневный отчёт")
+	if report.Params.Get("parse_mode") != "" || strings.Contains(report.Text(), "<b>") || strings.ContainsAny(report.Text(), "&🔥📋") {
+		t.Errorf("report is not plain:\n%s", report.Text())
+	}
+	if !strings.Contains(report.Text(), `Срочно: Купить "молоко"`) {
+		t.Errorf("report lost the priority label:\n%s", report.Text())
+	}
+
+	h.send(alice, "/plain off")
+	h.expect("Простой текст выключен")
+	h.send(alice, "/report")
+	if report := h.expect("Ежедневный отчёт"); report.Params.Get("parse_mode") != "HTML" || !strings.Contains(report.Text(), "📋") {
+		t.Errorf("report is still plain:\n%s", report.Text())
+	}
+}
+
+func TestExport(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(167)
+
+	h.send(alice, "/export")
+	h.expect("выгружать нечего")
+	h.createTask(alice, service.TaskInput{Title: "Купить хлеб"})
+	h.send(alice, "/export xml")
+	h.expect("Формат: /export")
+	h.send(alice, "/export json")
+	doc := h.expectCall("sendDocument")
+	if caption := doc.Params.Get("caption"); !strings.Contains(caption, "Все задачи") || !strings.HasSuffix(caption, ": 1.") {
+		t.Errorf("caption = %q", caption)
+	}
+}
+
+func TestImport(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(168)
+
+	h.send(alice, "/import")
+	h.expect("Пришли файл из /export")
+	h.sendDocument(alice, "tasks.csv", []byte("title,category,priority,deadline,recur_type,recur_weekdays\n"+
+		"Купить хлеб,Покупки,high,2030-05-01,,\n"+
+		",Покупки,,,,\n"+
+		"Полить цветы,Дом,,,weekly,mon thu\n"+
+		"Позвонить,,важно,,,\n"))
+	reply := h.expect("Добавлено задач: 2, новых категорий: 2")
+	for _, want := range []string{"строка 3: нет названия", "строка 5: неизвестный приоритет «важно»"} {
+		if !strings.Contains(reply.Text(), want) {
+			t.Errorf("report lacks %q:\n%s", want, reply.Text())
+		}
+	}
+	h.send(alice, "/tasks")
+	list := h.expect("Купить хлеб")
+	if !strings.Contains(list.Text(), "Полить цветы") {
+		t.Errorf("imported recurring task is missing:\n%s", list.Text())
+	}
+
+	h.sendDocument(alice, "tasks.json", []byte(`[{"title": "Из JSON", "status": "done"}]`))
+	h.expect("Добавлено задач: 1, новых категорий: 0")
+	h.sendDocument(alice, "tree.json", []byte(`[{"id": 40, "title": "Ремонт", "category": "Дом"}, {"id": 41, "parent_id": 40, "title": "Купить краску"}]`))
+	h.expect("Добавлено задач: 2, новых категорий: 0")
+	var subtask model.Task
+	if err := h.db.Where("title = ?", "Купить краску").First(&subtask).Error; err != nil {
+		t.Fatal(err)
+	}
+	var parent model.Task
+	if subtask.ParentID == nil || h.db.First(&parent, *subtask.ParentID).Error != nil || parent.Title != "Ремонт" {
+		t.Errorf("imported subtask is not under its task: %+v", subtask.ParentID)
+	}
+	h.sendDocument(alice, "broken.csv", []byte("name\nКупить"))
+	h.expect("Не получилось прочитать файл")
+}
+
+func TestDialogSurvivesRestart(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(131)
+	dialogs := repository.NewConversationRepository(h.db)
+
+	h.send(alice, "/newtask")
+	h.expect("Шаг 1")
+	h.send(alice, "Купить хлеб")
+	h.expect("описание")
+	h.await("saved dialog", func() bool {
+		saved, err := dialogs.ListByTelegramID(context.Background(), alice.ID)
+		return err == nil && len(saved) == 1 && strings.Contains(saved[0].Payload, "Купить хлеб")
+	})
+	h.restart()
+
+	h.send(alice, btnSkip)
+	h.expect("категорию")
+	h.send(alice, "Покупки")
+	h.expect("дедлайн")
+	h.send(alice, btnSkip)
+	h.expect("приоритет")
+	h.send(alice, btnPriorityNormal)
+	h.expect("повторяющейся")
+	h.send(alice, btnNo)
+	h.expect("Задача сохранена")
+
+	user, err := h.userRepo.FindByTelegramID(context.Background(), alice.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	tasks, err := h.taskRepo.ListActiveOrRecurring(context.Background(), user.Scope())
+	if err != nil || len(tasks) != 1 || tasks[0].Title != "Купить хлеб" {
+		t.Fatalf("unexpected tasks: %+v, %v", tasks, err)
+	}
+	h.await("finished dialog forgotten", func() bool {
+		saved, err := dialogs.ListByTelegramID(context.Background(), alice.ID)
+		return err == nil && len(saved) == 0
+	})
+}
+
+func TestCatchUpMissedRepeat(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(140)
+	task := h.createTask(alice, service.TaskInput{Title: "Полить цветы", IsRecurring: true, RecurType: model.RecurDaily, RecurInterval: 1})
+	task.CreatedAt = time.Now().AddDate(0, 0, -10)
+	if err := h.taskRepo.Save(context.Background(), task); err != nil {
+		t.Fatalf("age task: %v", err)
+	}
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editCatchUp))
+	h.expect("останется в отчёте")
+	h.expect("Пропущен повтор:</b> " + time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
+
+	h.send(alice, fmt.Sprintf("/complete %d", task.ID))
+	h.expect("Пропущенный повтор «Полить цветы»")
+	h.send(alice, fmt.Sprintf("/complete %d", task.ID))
+	h.expect("отмечена выполненной в этом окне")
+
+	h.press(alice, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, editCatchUp))
+	h.expect("просто забываться")
+}
+
+func TestUseMiddleware(t *testing.T) {
+	seen := make(chan int64, 1)
+	h := newHarness(t, func(next Handler) Handler {
+		return func(ctx context.Context, update Update) error {
+			if user := CurrentUser(ctx); user != nil {
+				seen <- user.TelegramID
+			}
+			return next(ctx, update)
+		}
+	})
+	alice := testUser(162)
+
+	h.send(alice, "/tasks")
+	h.expect("задач")
+	select {
+	case id := <-seen:
+		if id != alice.ID {
+			t.Fatalf("middleware saw user %d, want %d", id, alice.ID)
+		}
+	default:
+		t.Fatal("middleware added with Use did not run")
+	}
+}
+
+func TestPrivateMode(t *testing.T) {
+	h := newHarness(t)
+	admin, friend, stranger := testUser(163), testUser(164), testUser(165)
+	h.bot.config.AdminIDs = []int64{admin.ID}
+	h.bot.signupSvc = service.NewSignupService(h.userRepo, repository.NewAccessRequestRepository(h.db), nil, []int64{friend.ID}, []int64{admin.ID}, false)
+
+	h.send(friend, "/tasks")
+	h.expect("нет активных задач")
+
+	h.send(stranger, "/tasks")
+	notice := h.expect("просит доступ")
+	if notice.Params.Get("chat_id") != strconv.FormatInt(admin.ID, 10) {
+		t.Fatalf("request sent to %s, want the admin", notice.Params.Get("chat_id"))
+	}
+	h.expect("личный бот")
+	if _, err := h.userRepo.FindByTelegramID(context.Background(), stranger.ID); err == nil {
+		t.Fatal("stranger registered before approval")
+	}
+	// Asking again does not bother the admin twice.
+	h.send(stranger, "/start")
+	h.expect("уже у администратора")
+
+	h.send(friend, "/approve")
+	h.expect("только администраторам")
+	h.send(admin, "/approve")
+	h.expect("/approve 165")
+
+	h.pressOn(admin, notice.MessageID, cbApprovePrefix+"165")
+	h.expect("Доступ открыт")
+	h.expect("теперь может пользоваться ботом")
+	h.send(stranger, "/tasks")
+	h.expect("нет активных задач")
+}
+
+func TestTaskReferences(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(169)
+	first := h.createTask(alice, service.TaskInput{Title: "Заказать краску"})
+	second := h.createTask(alice, service.TaskInput{Title: "Покрасить забор", Description: fmt.Sprintf("После #%d, а #999 нет", first.ID)})
+
+	h.send(alice, fmt.Sprintf("/task %d", second.ID))
+	card := h.expect("Покрасить забор")
+	link := fmt.Sprintf(`<a href="https://t.me/planner_test_bot?start=%s%d">#%d</a>`, taskPayload, first.ID, first.ID)
+	if !strings.Contains(card.Text(), link) {
+		t.Errorf("mention is not a link to the card:\n%s", card.Text())
+	}
+
+	h.send(alice, fmt.Sprintf("/start %s%d", taskPayload, first.ID))
+	card = h.expect("Заказать краску")
+	backlink := fmt.Sprintf(`Упоминается в <a href="https://t.me/planner_test_bot?start=%s%d">#%d</a>`, taskPayload, second.ID, second.ID)
+	if !strings.Contains(card.Text(), backlink) {
+		t.Errorf("card lacks the backlink:\n%s", card.Text())
+	}
+}
+
+func TestMergeTasks(t *testing.T) {
+	h := newHarness(t)
+	alice := testUser(170)
+	ctx := context.Background()
+	early, late := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 6, 10, 0, 0, 0, 0, time.UTC)
+	kept := h.createTask(alice, service.TaskInput{Title: "Купить краску", Description: "Белую", Deadline: &late})
+	duplicate := h.createTask(alice, service.TaskInput{Title: "Краска", Description: "Два литра", Deadline: &early})
+	user, err := h.userRepo.FindByTelegramID(ctx, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subtask, err := h.taskSvc.AddSubtask(ctx, user, duplicate.ID, "Взять валик")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested, err := h.taskSvc.AddSubtask(ctx, user, subtask.ID, "Найти валик")
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer := h.createTask(alice, service.TaskInput{Title: "Ремонт", Description: fmt.Sprintf("Сначала #%d", duplicate.ID)})
+	values := []model.FieldValue{{TaskID: duplicate.ID, FieldID: 1, Value: "2"}, {TaskID: duplicate.ID, FieldID: 2, Value: "дубль"}, {TaskID: kept.ID, FieldID: 2, Value: "своё"}}
+	if err := h.db.Create(&values).Error; err != nil {
+		t.Fatal(err)
+	}
+	fieldOwner := func(id uint) uint {
+		var value model.FieldValue
+		if err := h.db.First(&value, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		return value.TaskID
+	}
+	referred := func() uint {
+		var ref model.TaskReference
+		if err := h.db.Where("task_id = ?", referrer.ID).First(&ref).Error; err != nil {
+			t.Fatal(err)
+		}
+		return ref.RefID
+	}
+
+	h.send(alice, fmt.Sprintf("/merge %d %d", kept.ID, kept.ID))
+	h.expect("нельзя объединить с ней же самой")
+	h.send(alice, fmt.Sprintf("/merge %d %d", duplicate.ID, nested.ID))
+	h.expect("нельзя объединить с её подзадачей")
+
+	h.press(alice, fmt.Sprintf("%s%d", cbMergePrefix, kept.ID))
+	h.expect("Номер задачи-дубликата")
+	h.send(alice, fmt.Sprintf("#%d", duplicate.ID))
+	h.expect(fmt.Sprintf("Задача #%d влита в #%d", duplicate.ID, kept.ID))
+	card := h.expect("Купить краску")
+	for _, want := range []string{"Белую\n\nДва литра", "2030-06-01", "Взять валик"} {
+		if !strings.Contains(card.Text(), want) {
+			t.Errorf("merged card lacks %q:\n%s", want, card.Text())
+		}
+	}
+	if _, err := h.taskSvc.GetTask(ctx, user, duplicate.ID); err == nil {
+		t.Error("the duplicate is still open after the merge")
+	}
+	if fieldOwner(values[0].ID) != kept.ID || fieldOwner(values[1].ID) != duplicate.ID {
+		t.Error("only the field the kept task lacks should move over")
+	}
+	if referred() != kept.ID {
+		t.Error("the mention of the duplicate does not point at the kept task")
+	}
+
+	var merge model.TaskMerge
+	if err := h.db.First(&merge).Error; err != nil {
+		t.Fatalf("merge record: %v", err)
+	}
+	h.press(alice, fmt.Sprintf("%s%d", cbUnmergePrefix, merge.ID))
+	h.expect("Объединение отменено")
+	restored, err := h.taskSvc.GetTask(ctx, user, kept.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Description != "Белую" || !restored.Deadline.Equal(late) {
+		t.Errorf("kept task is not restored: %q, %v", restored.Description, restored.Deadline)
+	}
+	if moved, err := h.taskSvc.GetTask(ctx, user, subtask.ID); err != nil || moved.ParentID == nil || *moved.ParentID != duplicate.ID {
+		t.Errorf("subtask did not move back: %+v, %v", moved, err)
+	}
+	if _, err := h.taskSvc.GetTask(ctx, user, duplicate.ID); err != nil {
+		t.Errorf("the duplicate is not back: %v", err)
+	}
+	if fieldOwner(values[0].ID) != duplicate.ID || referred() != duplicate.ID {
+		t.Error("the field value and the mention did not move back")
+	}
+}
@@ -0,0 +1,345 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// Task fields the edit wizard can change.
+const (
+	editTitle       = "title"
+	editDescription = "description"
+	editCategory    = "category"
+	editDeadline    = "deadline"
+	editPriority    = "priority"
+	editRecurrence  = "recurrence"
+	editFields      = "fields"
+	editCatchUp     = "catchup"
+)
+
+const btnClear = "🧹 Очистить"
+
+// handleEdit starts editing a task: /edit <id>.
+func (b *Bot) handleEdit(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	taskID, err := strconv.ParseUint(strings.TrimSpace(msg.CommandArguments()), 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Укажи ID задачи: /edit 12"))
+	}
+	return b.sendEditMenu(ctx, msg.Chat.ID, msg.From, uint(taskID))
+}
+
+// sendEditMenu asks which field of the task to change.
+func (b *Bot) sendEditMenu(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	if task.RecurEndedAt != nil || (!task.IsRecurring && task.IsCompleted) {
+		return b.sendText(ctx, chatID, lang.T("Задача уже закрыта, менять в ней нечего."))
+	}
+
+	button := func(label, field string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d:%s", cbEditPrefix, task.ID, field))
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(button(lang.T("Название"), editTitle), button(lang.T("Описание"), editDescription)),
+		tgbotapi.NewInlineKeyboardRow(button(lang.T("Категория"), editCategory), button(lang.T("Дедлайн"), editDeadline)),
+		tgbotapi.NewInlineKeyboardRow(button(lang.T("Приоритет"), editPriority), button(lang.T("Повтор"), editRecurrence)),
+		tgbotapi.NewInlineKeyboardRow(button(lang.T("🧩 Поля"), editFields)),
+	)
+	if task.IsRecurring {
+		label := lang.T("↪️ Переносить пропуски")
+		if task.CatchUp {
+			label = lang.T("🗑 Забывать пропуски")
+		}
+		markup.InlineKeyboard[3] = append(markup.InlineKeyboard[3], button(label, editCatchUp))
+	}
+	text := lang.Tf("✏️ Что изменить в задаче «%s» (#%d)?", escape(normalizeTitle(task.Title)), task.ID)
+	return b.sendWithReplyMarkup(ctx, chatID, text, markup)
+}
+
+// handleEditButton handles "edit:<id>" from a task card and "edit:<id>:<field>" from the edit menu.
+func (b *Bot) handleEditButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	rawID, field, _ := strings.Cut(payload, ":")
+	taskID, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil
+	}
+	chatID := cb.Message.Chat.ID
+	if field == "" {
+		return b.sendEditMenu(ctx, chatID, cb.From, uint(taskID))
+	}
+
+	var prompt string
+	var markup interface{} = clearKeyboard(lang)
+	switch field {
+	case editTitle:
+		prompt, markup = lang.T("✏️ Новое название задачи:"), cancelKeyboard(lang)
+	case editDescription:
+		prompt = lang.T("📝 Новое описание (или «Очистить», чтобы убрать его).")
+	case editCategory:
+		prompt = lang.T("🏷 Новая категория (или «Очистить», чтобы оставить задачу без категории).")
+	case editDeadline:
+		prompt = lang.T("⏰ Новый дедлайн: выбери день в календаре или напиши дату вроде <code>2025-11-30</code> («Очистить» уберёт дедлайн).")
+		markup = calendarKeyboard(lang, time.Now(), time.Now(), datePickClear)
+	case editPriority:
+		prompt, markup = lang.T("❗ Новый приоритет задачи:"), priorityKeyboard(lang, false)
+	case editFields:
+		fields := b.userFields(ctx, cb.From)
+		if len(fields) == 0 {
+			return b.sendText(ctx, chatID, lang.T("Своих полей пока нет.\n\n")+lang.T(fieldsUsage))
+		}
+		prompt, markup = fieldsPrompt(lang, fields, true), cancelKeyboard(lang)
+	case editCatchUp:
+		return b.toggleCatchUp(ctx, chatID, cb.From, uint(taskID))
+	case editRecurrence:
+		prompt = lang.T("🔁 День месяца или недели и окно в днях через пробел, например <code>15 2</code> или <code>пн 1</code>, «каждый день», «раз в 3 дня» или «Нет», чтобы задача больше не повторялась.")
+		markup = noRepeatKeyboard(lang)
+	default:
+		return nil
+	}
+	log.Printf("[info] edit task user=%d task=%d field=%s", cb.From.ID, taskID, field)
+	b.setConversation(cb.From.ID, &conversationState{stage: stageEdit, taskID: uint(taskID), field: field})
+	return b.sendWithReplyMarkup(ctx, chatID, prompt, markup)
+}
+
+// toggleCatchUp switches between carrying missed occurrences of a recurring task forward
+// and dropping them, and shows the updated card.
+func (b *Bot) toggleCatchUp(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err == nil {
+		task, err = b.taskSvc.SetCatchUp(ctx, user, taskID, !task.CatchUp)
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	case errors.Is(err, service.ErrNotRecurring):
+		return b.sendText(ctx, chatID, lang.T("Задача не повторяется — пропускать у неё нечего."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось изменить задачу: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task catch-up id=%d user=%d on=%t", task.ID, user.ID, task.CatchUp)
+	text := lang.T("🗑 Пропущенные повторы будут просто забываться.")
+	if task.CatchUp {
+		text = lang.T("↪️ Пропущенный повтор останется в отчёте просроченным, пока ты его не отметишь.")
+	}
+	if err := b.sendText(ctx, chatID, text); err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, chatID, user, task.ID)
+}
+
+// finishEdit applies the value typed for the field being edited and shows the updated card.
+// Invalid values keep the conversation so the user can try again.
+func (b *Bot) finishEdit(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	_, input, err := b.taskSvc.EditInput(ctx, user, state.taskID)
+	if err != nil {
+		b.clearConversation(msg.From.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	text := strings.TrimSpace(msg.Text)
+	clearing := isClearInput(text)
+	switch state.field {
+	case editTitle:
+		if text == "" {
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Название не может быть пустым."), cancelKeyboard(lang))
+		}
+		input.Title = text
+	case editDescription:
+		input.Description = text
+		if clearing {
+			input.Description = ""
+		}
+	case editCategory:
+		input.Category = text
+		if clearing {
+			input.Category = ""
+		}
+	case editDeadline:
+		input.Deadline = nil
+		if !clearing {
+			parsed, err := time.Parse("2006-01-02", text)
+			if err != nil {
+				return b.sendDatePicker(ctx, msg.Chat.ID, lang.T("Не могу распознать дату. Выбери день в календаре или напиши его как <code>2025-11-30</code>."), datePickClear)
+			}
+			input.Deadline = &parsed
+		}
+	case editPriority:
+		priority, ok := parsePriority(text)
+		if !ok {
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Выбери приоритет кнопкой."), priorityKeyboard(lang, false))
+		}
+		input.Priority = priority
+	case editFields:
+		return b.finishFieldsEdit(ctx, msg, user, state.taskID)
+	case editRecurrence:
+		switch {
+		case isNoInput(text):
+			input.IsRecurring = false
+		case parseRecurrence(text, &input):
+			input.IsRecurring = true
+		default:
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.Tf("Укажи день месяца (1–31) и окно (0–%d), например <code>15 2</code>, дни недели и окно (0–%d), например <code>пн 1</code> или <code>пн,чт 0</code>, «каждый день», «раз в 3 дня», «раз в квартал 7», «раз в полгода» или «Нет».",
+				service.MaxMonthlyWindow, service.MaxWeeklyWindow), noRepeatKeyboard(lang))
+		}
+	}
+
+	task, err := b.taskSvc.UpdateTask(ctx, user, state.taskID, input, time.Now())
+	b.clearConversation(msg.From.ID)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось изменить задачу: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task edited id=%d user=%d field=%s", task.ID, user.ID, state.field)
+	if err := b.sendText(ctx, msg.Chat.ID, lang.T("✏️ Задача обновлена.")); err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, msg.Chat.ID, user, task.ID)
+}
+
+// finishFieldsEdit stores the custom field values typed for the task and shows the updated card.
+func (b *Bot) finishFieldsEdit(ctx context.Context, msg *tgbotapi.Message, user *model.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	values, ok := parseFieldLines(msg.Text)
+	if !ok {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Пиши поля строками <code>название: значение</code>."), cancelKeyboard(lang))
+	}
+	if err := b.fieldSvc.SetValues(ctx, user, taskID, values); err != nil {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, fieldError(lang, err), cancelKeyboard(lang))
+	}
+	b.clearConversation(msg.From.ID)
+	log.Printf("[info] task fields edited id=%d user=%d fields=%d", taskID, user.ID, len(values))
+	if err := b.sendText(ctx, msg.Chat.ID, lang.T("🧩 Поля обновлены.")); err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, msg.Chat.ID, user, taskID)
+}
+
+var intervalPattern = regexp.MustCompile(`^(?:раз в (\d+) (?:день|дня|дней)|every (\d+) days?)$`)
+
+// parseRecurrence reads "<day of month> <window>" such as "15 2", "<weekdays> <window>"
+// such as "пн 1" or "пн,чт 0", "каждый день", "раз в 3 дня" or "раз в квартал" with an
+// optional window (in English "mon 1", "daily", "every 3 days" or "quarterly") into input.
+func parseRecurrence(text string, input *service.TaskInput) bool {
+	lower := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	window := 0
+	if i := strings.LastIndexByte(lower, ' '); i > 0 {
+		if n, err := strconv.Atoi(lower[i+1:]); err == nil {
+			if _, ok := parseMonths(lower[:i]); ok {
+				lower, window = lower[:i], n
+			}
+		}
+	}
+	if months, ok := parseMonths(lower); ok {
+		if window < 0 || window > service.MaxMonthlyWindow {
+			return false
+		}
+		input.RecurType, input.RecurInterval, input.RecurDay, input.RecurWindow = model.RecurMonthly, months, 0, window
+		return true
+	}
+	if lower == "каждый день" || lower == "ежедневно" || lower == "daily" {
+		input.RecurType, input.RecurInterval, input.RecurWindow = model.RecurDaily, 1, 0
+		return true
+	}
+	if m := intervalPattern.FindStringSubmatch(lower); m != nil {
+		interval, err := strconv.Atoi(m[1] + m[2])
+		if err != nil || interval < 1 || interval > service.MaxRecurInterval {
+			return false
+		}
+		input.RecurType, input.RecurInterval, input.RecurWindow = model.RecurDaily, interval, 0
+		return true
+	}
+	fields := strings.Fields(text)
+	if len(fields) != 2 {
+		return false
+	}
+	window, err := strconv.Atoi(fields[1])
+	if err != nil || window < 0 {
+		return false
+	}
+	if set, ok := parseWeekdays(fields[0]); ok {
+		weekly := service.TaskInput{RecurType: model.RecurWeekly, RecurWeekdays: set}
+		if window > service.MaxWindow(weekly) {
+			return false
+		}
+		input.RecurType, input.RecurWeekdays, input.RecurInterval, input.RecurWindow = model.RecurWeekly, set, 0, window
+		return true
+	}
+	day, err := strconv.Atoi(fields[0])
+	if err != nil || day < 1 || day > 31 || window > service.MaxMonthlyWindow {
+		return false
+	}
+	input.RecurType, input.RecurDay, input.RecurInterval, input.RecurWindow = model.RecurMonthly, day, 0, window
+	return true
+}
+
+func clearKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnClear)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+		),
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
+func noRepeatKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnNo)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+		),
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
+func isClearInput(text string) bool {
+	value := strings.TrimSpace(strings.ToLower(text))
+	return i18n.Matches(value, btnClear) || value == "очистить" || value == "clear" || value == "-"
+}
+
+func isNoInput(text string) bool {
+	value := strings.TrimSpace(strings.ToLower(text))
+	return value == "нет" || value == "no" || value == "n"
+}
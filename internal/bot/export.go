@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+func (b *Bot) registerExport(r *router) {
+	r.command("export", "выгрузить задачи в CSV или JSON", b.handleExport)
+	r.command("import", "загрузить задачи из CSV или JSON", b.handleImport)
+}
+
+// handleExport sends every task of the user as a file: /export [csv|json], CSV by default.
+func (b *Bot) handleExport(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	format := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	if format == "" {
+		format = service.ExportCSV
+	}
+	if format != service.ExportCSV && format != service.ExportJSON {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /export — таблица CSV, /export json — файл JSON."))
+	}
+	export, err := b.exportSvc.Export(ctx, user, format, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось выгрузить задачи: %s", errorText(lang, err)))
+	}
+	if export.Count == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Задач пока нет — выгружать нечего."))
+	}
+	log.Printf("[info] export user=%d format=%s tasks=%d", user.ID, format, export.Count)
+	return b.sendDocument(ctx, msg.Chat.ID, export.Name, export.Data, lang.Tf("📦 Все задачи, включая выполненные, архивные и регулярные: %d.", export.Count))
+}
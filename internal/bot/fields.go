@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const fieldsUsage = "Свои поля задач:\n" +
+	"• /fields add &lt;название&gt; текст|число|дата — добавить поле, например <code>/fields add сумма число</code>\n" +
+	"• /fields del &lt;название&gt; — удалить поле вместе со значениями\n" +
+	"Заполнить поля можно при создании задачи (/newtask) и в /edit."
+
+// fieldTypeNames maps the types users may type to the stored ones.
+var fieldTypeNames = map[string]string{
+	"текст": model.FieldText, "text": model.FieldText,
+	"число": model.FieldNumber, "number": model.FieldNumber,
+	"дата": model.FieldDate, "date": model.FieldDate,
+}
+
+// handleFields lists the custom fields or changes them: /fields [add|del <name>].
+func (b *Bot) handleFields(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	sub, arg, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	switch strings.ToLower(sub) {
+	case "":
+		return b.sendFields(ctx, msg.Chat.ID, user)
+	case "add":
+		words := strings.Fields(arg)
+		if len(words) < 2 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(fieldsUsage))
+		}
+		fieldType, ok := fieldTypeNames[strings.ToLower(words[len(words)-1])]
+		if !ok {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Тип поля — текст, число или дата."))
+		}
+		field, err := b.fieldSvc.Define(ctx, user, strings.Join(words[:len(words)-1], " "), fieldType)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, fieldError(lang, err))
+		}
+		log.Printf("[info] field defined id=%d user=%d type=%s", field.ID, user.ID, field.Type)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🧩 Поле «%s» (%s) добавлено. Заполнить его — в /edit задачи или при создании новой.", escape(field.Name), fieldTypeLabel(lang, field.Type)))
+	case "del":
+		field, err := b.fieldSvc.Remove(ctx, user, strings.TrimSpace(arg))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, fieldError(lang, err))
+		}
+		log.Printf("[info] field removed id=%d user=%d", field.ID, user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🗑 Поле «%s» удалено вместе со значениями.", escape(field.Name)))
+	}
+	return b.sendText(ctx, msg.Chat.ID, lang.T(fieldsUsage))
+}
+
+func (b *Bot) sendFields(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	fields, err := b.fieldSvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить поля: %s", errorText(lang, err)))
+	}
+	if len(fields) == 0 {
+		return b.sendText(ctx, chatID, lang.T("Своих полей пока нет.\n\n")+lang.T(fieldsUsage))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("🧩 <b>Поля задач</b>\n"))
+	for _, field := range fields {
+		builder.WriteString(fmt.Sprintf("• %s — %s\n", escape(field.Name), fieldTypeLabel(lang, field.Type)))
+	}
+	builder.WriteString("\n" + lang.T(fieldsUsage))
+	return b.sendText(ctx, chatID, builder.String())
+}
+
+// fieldsPrompt asks for field values, one "name: value" per line.
+func fieldsPrompt(lang i18n.Lang, fields []model.CustomField, clearing bool) string {
+	var builder strings.Builder
+	builder.WriteString(lang.T("🧩 Заполни поля, по одному в строке: <code>название: значение</code>.\n"))
+	for _, field := range fields {
+		builder.WriteString(fmt.Sprintf("• %s — %s\n", escape(field.Name), fieldTypeLabel(lang, field.Type)))
+	}
+	if clearing {
+		builder.WriteString(lang.T("Чтобы очистить поле, напиши <code>название: -</code>."))
+	} else {
+		builder.WriteString(lang.T("Необязательные поля можно не писать, а шаг — пропустить."))
+	}
+	return builder.String()
+}
+
+// parseFieldLines reads "name: value" (or "name = value") lines; a value of "-" clears the field.
+func parseFieldLines(text string) (map[string]string, bool) {
+	values := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		cut := strings.IndexAny(line, ":=")
+		if cut <= 0 {
+			return nil, false
+		}
+		value := strings.TrimSpace(line[cut+1:])
+		if value == "-" {
+			value = ""
+		}
+		values[strings.TrimSpace(line[:cut])] = value
+	}
+	return values, len(values) > 0
+}
+
+// formatFieldValue shows a stored value the way users type it.
+func formatFieldValue(field model.CustomField, value string) string {
+	if field.Type == model.FieldDate {
+		if date, err := time.Parse("2006-01-02", value); err == nil {
+			return date.Format("02.01.2006")
+		}
+	}
+	return value
+}
+
+// exportTask returns a copy of the task with its custom fields appended to the description,
+// for calendar files and links, which have no place for them otherwise.
+func exportTask(task model.Task, fields []service.TaskField) model.Task {
+	if len(fields) == 0 {
+		return task
+	}
+	lines := make([]string, 0, len(fields)+1)
+	if task.Description != "" {
+		lines = append(lines, task.Description)
+	}
+	for _, field := range fields {
+		lines = append(lines, field.Field.Name+": "+formatFieldValue(field.Field, field.Value))
+	}
+	task.Description = strings.Join(lines, "\n")
+	return task
+}
+
+func fieldTypeLabel(lang i18n.Lang, fieldType string) string {
+	switch fieldType {
+	case model.FieldNumber:
+		return lang.T("число")
+	case model.FieldDate:
+		return lang.T("дата")
+	}
+	return lang.T("текст")
+}
+
+func fieldError(lang i18n.Lang, err error) string {
+	var valueErr *service.FieldValueError
+	switch {
+	case errors.As(err, &valueErr) && valueErr.Field.Type == model.FieldNumber:
+		return lang.Tf("В поле «%s» нужно число, например 1500 или 12,5.", escape(valueErr.Field.Name))
+	case errors.As(err, &valueErr) && valueErr.Field.Type == model.FieldDate:
+		return lang.Tf("В поле «%s» нужна дата, например 2025-11-30 или 30.11.2025.", escape(valueErr.Field.Name))
+	case errors.As(err, &valueErr):
+		return lang.Tf("Значение поля «%s» длиннее %d символов.", escape(valueErr.Field.Name), service.MaxFieldValueLength)
+	case errors.Is(err, service.ErrFieldNotFound):
+		return lang.T("Такого поля нет. Список — /fields")
+	case errors.Is(err, service.ErrFieldExists):
+		return lang.T("Поле с таким названием уже есть.")
+	case errors.Is(err, service.ErrTooManyFields):
+		return lang.Tf("Полей может быть не больше %d.", service.MaxFields)
+	case errors.Is(err, service.ErrInvalidField):
+		return lang.Tf("Название поля — до %d символов, без «:» и «=».", service.MaxFieldNameLength)
+	}
+	return lang.Tf("Не удалось изменить поля: %s", errorText(lang, err))
+}
+
+// formatTaskFields renders the task's custom fields as lines of a task card.
+func formatTaskFields(fields []service.TaskField) string {
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(fmt.Sprintf("• <b>%s:</b> %s\n", escape(normalizeTitle(field.Field.Name)), escape(formatFieldValue(field.Field, field.Value))))
+	}
+	return builder.String()
+}
+
+// userFields returns the custom fields of the sender's active scope; errors are logged.
+func (b *Bot) userFields(ctx context.Context, from *tgbotapi.User) []model.CustomField {
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return nil
+	}
+	fields, err := b.fieldSvc.List(ctx, user)
+	if err != nil {
+		log.Printf("list fields of %d: %v", user.ID, err)
+		return nil
+	}
+	return fields
+}
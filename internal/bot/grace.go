@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const graceUsage = "Формат: /grace 03:00 — просрочка наступает в 3 часа ночи после дня дедлайна, /grace 6 — через 6 часов после его конца (до 48), /grace off — ровно в полночь, /grace start — уже с начала дня дедлайна"
+
+// handleGrace shows or changes when a task becomes overdue: by default at the end of the
+// deadline day, optionally some hours later or already when the day starts.
+func (b *Bot) handleGrace(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	arg := strings.TrimSpace(msg.CommandArguments())
+	if arg == "" {
+		return b.sendText(ctx, msg.Chat.ID, fmt.Sprintf("🌙 %s\n%s", graceText(lang, *user), lang.T(graceUsage)))
+	}
+	startOfDay, hours := isStartOfDayInput(arg), 0
+	if !startOfDay {
+		var err error
+		if hours, err = service.ParseGrace(arg); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(graceUsage))
+		}
+	}
+	if err := b.userRepo.SetDeadlinePolicy(ctx, user, startOfDay, hours); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] deadline policy user=%d start_of_day=%t grace_hours=%d", user.ID, startOfDay, hours)
+	return b.sendText(ctx, msg.Chat.ID, lang.T("🌙 Готово. ")+graceText(lang, *user))
+}
+
+func isStartOfDayInput(text string) bool {
+	switch strings.ToLower(text) {
+	case "start", "начало":
+		return true
+	}
+	return false
+}
+
+func graceText(lang i18n.Lang, user model.User) string {
+	hours := user.OverdueGraceHours
+	switch {
+	case user.DeadlineStartOfDay:
+		return lang.T("Задача становится просроченной с самого начала дня дедлайна.")
+	case hours == 0:
+		return lang.T("Задача становится просроченной ровно в полночь после дня дедлайна.")
+	case hours < 24:
+		return lang.Tf("Задача становится просроченной в %02d:00 после дня дедлайна.", hours)
+	default:
+		return lang.Tf("Задача становится просроченной через %d ч. после конца дня дедлайна.", hours)
+	}
+}
@@ -0,0 +1,428 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/config"
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+)
+
+const (
+	testToken   = "test-token"
+	waitTimeout = 5 * time.Second
+)
+
+// apiCall is a request the bot made to the fake Bot API.
+type apiCall struct {
+	Method    string
+	Params    url.Values
+	MessageID int // ID assigned to a sent message
+}
+
+func (c apiCall) Text() string {
+	return c.Params.Get("text")
+}
+
+// fakeTelegram emulates the parts of the Bot API the bot uses: updates are queued
+// by the test and served via getUpdates, everything the bot sends is recorded.
+type fakeTelegram struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	updates   []Update
+	calls     []apiCall
+	nextID    int
+	nextMsgID int
+	changed   chan struct{}
+	files     map[string][]byte // uploaded documents by file ID
+}
+
+func newFakeTelegram(t *testing.T) *fakeTelegram {
+	t.Helper()
+	f := &fakeTelegram{nextID: 1, nextMsgID: 1000, changed: make(chan struct{}, 1), files: make(map[string][]byte)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeTelegram) endpoint() string {
+	return f.server.URL + "/bot%s/%s"
+}
+
+func (f *fakeTelegram) serve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil && err != http.ErrNotMultipart {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	if strings.HasPrefix(r.URL.Path, "/file/") {
+		f.mu.Lock()
+		data, ok := f.files[method]
+		f.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+		return
+	}
+
+	var result interface{}
+	switch method {
+	case "getFile":
+		result = tgbotapi.File{FileID: r.Form.Get("file_id"), FilePath: "documents/" + r.Form.Get("file_id")}
+	case "getMe":
+		result = tgbotapi.User{ID: 1, IsBot: true, FirstName: "Planner", UserName: "planner_test_bot"}
+	case "getUpdates":
+		offset, _ := strconv.Atoi(r.Form.Get("offset"))
+		result = f.pendingUpdates(offset)
+	case "sendMessage", "editMessageText", "sendDocument", "sendPhoto":
+		chatID, _ := strconv.ParseInt(r.Form.Get("chat_id"), 10, 64)
+		f.mu.Lock()
+		f.nextMsgID++
+		result = tgbotapi.Message{MessageID: f.nextMsgID, Chat: &tgbotapi.Chat{ID: chatID}, Text: r.Form.Get("text")}
+		f.calls = append(f.calls, apiCall{Method: method, Params: r.Form, MessageID: f.nextMsgID})
+		f.mu.Unlock()
+	default:
+		result = true
+		f.record(method, r.Form)
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: true, Result: raw})
+}
+
+// pendingUpdates returns queued updates from offset on, briefly long-polling when there are none.
+func (f *fakeTelegram) pendingUpdates(offset int) []Update {
+	deadline := time.After(100 * time.Millisecond)
+	for {
+		f.mu.Lock()
+		var pending []Update
+		for _, update := range f.updates {
+			if update.UpdateID >= offset {
+				pending = append(pending, update)
+			}
+		}
+		f.mu.Unlock()
+		if len(pending) > 0 {
+			return pending
+		}
+		select {
+		case <-f.changed:
+		case <-deadline:
+			return []Update{}
+		}
+	}
+}
+
+func (f *fakeTelegram) record(method string, params url.Values) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, apiCall{Method: method, Params: params})
+}
+
+func (f *fakeTelegram) push(update Update) {
+	f.mu.Lock()
+	update.UpdateID = f.nextID
+	f.nextID++
+	f.updates = append(f.updates, update)
+	f.mu.Unlock()
+	select {
+	case f.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (f *fakeTelegram) callsSince(index int) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if index >= len(f.calls) {
+		return nil
+	}
+	return append([]apiCall(nil), f.calls[index:]...)
+}
+
+// harness runs a real Bot against the fake API and an in-memory database.
+type harness struct {
+	t      *testing.T
+	tg     *fakeTelegram
+	bot    *Bot
+	db     *gorm.DB
+	cursor int
+
+	userRepo *repository.UserRepository
+	taskRepo *repository.TaskRepository
+	taskSvc  *service.TaskService
+}
+
+func newHarness(t *testing.T, mw ...Middleware) *harness {
+	t.Helper()
+	tg := newFakeTelegram(t)
+	apiEndpoint = tg.endpoint()
+	t.Cleanup(func() { apiEndpoint = tgbotapi.APIEndpoint })
+
+	// Workers handle chats concurrently, and a shared in-memory database answers concurrent
+	// writers with "table is locked" instead of waiting, so all queries share one connection.
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := repository.NewDB(dsn, repository.PoolConfig{MaxOpenConns: 1})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("db handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	cfg := config.Config{TelegramToken: testToken, ReportInterval: 5 * time.Hour}
+	userRepo := repository.NewUserRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	taskRepo := repository.NewTaskRepository(db)
+	taskMessageRepo := repository.NewTaskMessageRepository(db)
+	contactRepo := repository.NewContactRepository(db)
+	medicationRepo := repository.NewMedicationRepository(db)
+	counterRepo := repository.NewCounterRepository(db)
+	triageRepo := repository.NewTriageRepository(db)
+	reminderRepo := repository.NewReminderRepository(db)
+
+	accountSvc := service.NewAccountService(accountRepo, userRepo)
+	workspaceSvc := service.NewWorkspaceService(workspaceRepo)
+	quotaSvc := service.NewQuotaService(taskRepo, categoryRepo, userRepo, service.Limits{}, nil)
+	signupSvc := service.NewSignupService(userRepo, repository.NewAccessRequestRepository(db), nil, nil, nil, false)
+	retentionSvc := service.NewRetentionService(userRepo, 0, 0)
+	categorySvc := service.NewCategoryService(categoryRepo, workspaceSvc)
+	taskSvc := service.NewTaskService(taskRepo, categoryRepo, taskMessageRepo, workspaceSvc, quotaSvc)
+	reminderSvc := service.NewReminderService(taskRepo, categoryRepo, workspaceRepo, counterRepo, userRepo)
+	importSvc := service.NewImportService(taskRepo, subscriptionRepo, userRepo, workspaceSvc, quotaSvc, http.DefaultClient)
+	contactSvc := service.NewContactService(contactRepo, taskRepo, userRepo, quotaSvc)
+	medicationSvc := service.NewMedicationService(medicationRepo, userRepo)
+	counterSvc := service.NewCounterService(counterRepo)
+	triageSvc := service.NewTriageService(taskRepo, triageRepo)
+	notificationSvc := service.NewNotificationService(reminderRepo, taskRepo)
+	reportScheduler := service.NewReportScheduler(userRepo, repository.NewReportRunRepository(db), cfg.ReportInterval, time.Minute)
+	settingsSvc := service.NewSettingsService(userRepo, categoryRepo, quotaSvc, reportScheduler)
+	fieldSvc := service.NewFieldService(repository.NewFieldRepository(db), workspaceSvc)
+
+	b, err := New(testToken, userRepo, repository.NewConversationRepository(db), accountSvc, workspaceSvc, categorySvc, taskSvc, reminderSvc, importSvc, quotaSvc, signupSvc, retentionSvc, contactSvc, medicationSvc, counterSvc, triageSvc, notificationSvc, settingsSvc, fieldSvc, service.NewExportService(taskRepo, categoryRepo, workspaceSvc, quotaSvc), reportScheduler, &http.Client{}, &cfg)
+	if err != nil {
+		t.Fatalf("create bot: %v", err)
+	}
+	b.Use(mw...)
+	b.fileEndpoint = tg.server.URL + "/file/bot%s/%s"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := b.Start(ctx); err != nil {
+			t.Errorf("bot stopped: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return &harness{t: t, tg: tg, bot: b, db: db, userRepo: userRepo, taskRepo: taskRepo, taskSvc: taskSvc}
+}
+
+func testUser(id int64) *tgbotapi.User {
+	return &tgbotapi.User{ID: id, FirstName: fmt.Sprintf("User%d", id)}
+}
+
+// send delivers a private text message; texts starting with "/" are sent as commands.
+func (h *harness) send(from *tgbotapi.User, text string) {
+	msg := &tgbotapi.Message{
+		MessageID: int(time.Now().UnixNano() % 1_000_000),
+		From:      from,
+		Chat:      &tgbotapi.Chat{ID: from.ID, Type: "private"},
+		Date:      int(time.Now().Unix()),
+		Text:      text,
+	}
+	if strings.HasPrefix(text, "/") {
+		length := len(text)
+		if i := strings.IndexByte(text, ' '); i >= 0 {
+			length = i
+		}
+		msg.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}}
+	}
+	h.tg.push(Update{Update: tgbotapi.Update{Message: msg}})
+}
+
+// reply delivers a private text message answering one of the bot's messages.
+func (h *harness) reply(from *tgbotapi.User, messageID int, text string) {
+	h.tg.push(Update{Update: tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID:      int(time.Now().UnixNano() % 1_000_000),
+		From:           from,
+		Chat:           &tgbotapi.Chat{ID: from.ID, Type: "private"},
+		Date:           int(time.Now().Unix()),
+		Text:           text,
+		ReplyToMessage: &tgbotapi.Message{MessageID: messageID, Chat: &tgbotapi.Chat{ID: from.ID, Type: "private"}},
+	}}})
+}
+
+// sendPhoto delivers a private photo message; a non-empty album groups photos like a Telegram album.
+func (h *harness) sendPhoto(from *tgbotapi.User, fileID, caption, album string) {
+	h.tg.push(Update{Update: tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID:    int(time.Now().UnixNano() % 1_000_000),
+		From:         from,
+		Chat:         &tgbotapi.Chat{ID: from.ID, Type: "private"},
+		Date:         int(time.Now().Unix()),
+		Caption:      caption,
+		MediaGroupID: album,
+		Photo: []tgbotapi.PhotoSize{
+			{FileID: fileID + "-small", Width: 90, Height: 90, FileSize: 1 << 10},
+			{FileID: fileID, Width: 1280, Height: 960, FileSize: 200 << 10},
+		},
+	}}})
+}
+
+// sendDocument delivers a private message with a file the bot can download.
+func (h *harness) sendDocument(from *tgbotapi.User, name string, data []byte) {
+	fileID := fmt.Sprintf("doc-%d", time.Now().UnixNano())
+	h.tg.mu.Lock()
+	h.tg.files[fileID] = data
+	h.tg.mu.Unlock()
+	h.tg.push(Update{Update: tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID: int(time.Now().UnixNano() % 1_000_000),
+		From:      from,
+		Chat:      &tgbotapi.Chat{ID: from.ID, Type: "private"},
+		Date:      int(time.Now().Unix()),
+		Document:  &tgbotapi.Document{FileID: fileID, FileName: name, FileSize: len(data)},
+	}}})
+}
+
+// alertAnyTime stretches the user's working hours so deadline alerts go out whatever the clock says.
+func (h *harness) alertAnyTime(from *tgbotapi.User) {
+	h.t.Helper()
+	ctx := context.Background()
+	user, err := h.userRepo.UpsertFromTelegram(ctx, from.ID, from.FirstName, from.LastName, from.UserName, from.LanguageCode)
+	if err != nil {
+		h.t.Fatalf("register user: %v", err)
+	}
+	if err := h.userRepo.SetWorkingHours(ctx, user, 0, 22); err != nil {
+		h.t.Fatalf("set working hours: %v", err)
+	}
+}
+
+// createTask registers the Telegram user and stores a task for them directly.
+func (h *harness) createTask(from *tgbotapi.User, input service.TaskInput) *model.Task {
+	h.t.Helper()
+	ctx := context.Background()
+	user, err := h.userRepo.UpsertFromTelegram(ctx, from.ID, from.FirstName, from.LastName, from.UserName, from.LanguageCode)
+	if err != nil {
+		h.t.Fatalf("register user: %v", err)
+	}
+	task, err := h.taskSvc.CreateTask(ctx, user, input)
+	if err != nil {
+		h.t.Fatalf("create task: %v", err)
+	}
+	return task
+}
+
+// press emulates tapping an inline button with the given callback data.
+func (h *harness) press(from *tgbotapi.User, data string) {
+	h.pressOn(from, 1, data)
+}
+
+// pressOn emulates tapping an inline button under a particular message the bot sent.
+func (h *harness) pressOn(from *tgbotapi.User, messageID int, data string) {
+	h.tg.push(Update{Update: tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+		ID:      strconv.FormatInt(time.Now().UnixNano(), 10),
+		From:    from,
+		Message: &tgbotapi.Message{MessageID: messageID, Chat: &tgbotapi.Chat{ID: from.ID, Type: "private"}},
+		Data:    data,
+	}}})
+}
+
+// react emulates putting an emoji reaction on a message the bot sent.
+func (h *harness) react(from *tgbotapi.User, messageID int, emoji string) {
+	h.tg.push(Update{MessageReaction: &messageReactionUpdated{
+		Chat:        &tgbotapi.Chat{ID: from.ID, Type: "private"},
+		MessageID:   messageID,
+		User:        from,
+		Date:        int(time.Now().Unix()),
+		NewReaction: []reactionType{{Type: "emoji", Emoji: emoji}},
+	}})
+}
+
+// await waits until cond holds, such as for something the bot does after its last reply.
+func (h *harness) await(what string, cond func() bool) {
+	h.t.Helper()
+	deadline := time.Now().Add(waitTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("no %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// restart drops what the bot keeps in memory about dialogs, as a restart would.
+func (h *harness) restart() {
+	h.bot.mu.Lock()
+	defer h.bot.mu.Unlock()
+	h.bot.conversations = make(map[int64]*conversationState)
+	h.bot.confirmations = make(map[int64]confirmationRequest)
+	h.bot.restored = make(map[int64]bool)
+}
+
+// expect waits for the next outgoing message whose text contains substr
+// and skips everything sent before it.
+func (h *harness) expect(substr string) apiCall {
+	h.t.Helper()
+	return h.expectMatch(fmt.Sprintf("message containing %q", substr), func(call apiCall) bool {
+		return call.Params.Has("text") && strings.Contains(call.Text(), substr)
+	})
+}
+
+// expectCall waits for the next Bot API call of the given method, such as answerCallbackQuery.
+func (h *harness) expectCall(method string) apiCall {
+	h.t.Helper()
+	return h.expectMatch(method+" call", func(call apiCall) bool {
+		return call.Method == method
+	})
+}
+
+func (h *harness) expectMatch(what string, match func(apiCall) bool) apiCall {
+	h.t.Helper()
+	deadline := time.Now().Add(waitTimeout)
+	for time.Now().Before(deadline) {
+		for i, call := range h.tg.callsSince(h.cursor) {
+			if match(call) {
+				h.cursor += i + 1
+				return call
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	var texts []string
+	for _, call := range h.tg.callsSince(h.cursor) {
+		texts = append(texts, fmt.Sprintf("%s: %q", call.Method, call.Text()))
+	}
+	h.t.Fatalf("no %s; sent since last match:\n%s", what, strings.Join(texts, "\n"))
+	return apiCall{}
+}
@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+const heatmapUsage = "Формат: /heatmap [ММ.ГГГГ] — календарь выполненных задач за месяц.\n" +
+	"/heatmap image — присылать картинкой, /heatmap text — квадратиками эмодзи."
+
+// heatShades go from no completions to the busiest days of the month.
+var heatShades = [...]string{"⬜", "🟨", "🟧", "🟩"}
+
+// heatColors are the picture counterparts of heatShades.
+var heatColors = [...]color.RGBA{
+	{0xeb, 0xed, 0xf0, 0xff},
+	{0x9b, 0xe9, 0xa8, 0xff},
+	{0x40, 0xc4, 0x63, 0xff},
+	{0x21, 0x6e, 0x39, 0xff},
+}
+
+// handleHeatmap shows completions per day of a month as a grid of weeks, like GitHub's
+// contribution graph: /heatmap [MM.YYYY], /heatmap image|text switches the presentation.
+func (b *Bot) handleHeatmap(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	now := time.Now().In(user.Location())
+	month := now
+	switch args := strings.ToLower(strings.TrimSpace(msg.CommandArguments())); args {
+	case "":
+	case "image", "text":
+		if err := b.userRepo.SetHeatmapImage(ctx, user, args == "image"); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+		}
+	default:
+		var err error
+		if month, err = time.ParseInLocation("01.2006", args, now.Location()); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(heatmapUsage))
+		}
+	}
+
+	counts, err := b.taskSvc.MonthCompletions(ctx, user, month)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать историю: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] heatmap user=%d month=%s image=%t", user.ID, month.Format("2006-01"), user.HeatmapImage)
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	summary := heatmapSummary(lang, counts, first) + b.workspaceTitle(ctx, user)
+	if !user.HeatmapImage {
+		return b.sendText(ctx, msg.Chat.ID, summary+"\n\n"+heatmapText(lang, counts, first)+lang.T("\nменьше ")+strings.Join(heatShades[:], "")+lang.T(" больше"))
+	}
+
+	picture, err := heatmapImage(counts, first)
+	if err != nil {
+		return err
+	}
+	photo := tgbotapi.NewPhoto(msg.Chat.ID, tgbotapi.FileBytes{Name: "heatmap.png", Bytes: picture})
+	photo.Caption = summary
+	photo.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, photo)
+	return err
+}
+
+// heatmapSummary is the heading with the month's total and its busiest day.
+func heatmapSummary(lang i18n.Lang, counts []int, first time.Time) string {
+	total, best := 0, 0
+	for day, count := range counts {
+		total += count
+		if count > counts[best] {
+			best = day
+		}
+	}
+	text := lang.Tf("🔥 <b>%s %d</b> · выполнено: %d", lang.T(monthNames[first.Month()-1]), first.Year(), total)
+	if total > 0 {
+		text += lang.Tf("\nЛучший день — %s: %d", first.AddDate(0, 0, best).Format("02.01"), counts[best])
+	}
+	return text
+}
+
+// heatLevel maps a day's count to a shade relative to the busiest day of the month.
+func heatLevel(count, busiest int) int {
+	if count == 0 {
+		return 0
+	}
+	return (count*(len(heatShades)-1) + busiest - 1) / busiest
+}
+
+// heatmapWeeks lays the month out by weekday rows (Monday first) and week columns;
+// cells outside the month hold -1.
+func heatmapWeeks(counts []int, first time.Time) [7][]int {
+	lead := (int(first.Weekday()) + 6) % 7
+	columns := (lead + len(counts) + 6) / 7
+	var rows [7][]int
+	for weekday := range rows {
+		rows[weekday] = make([]int, columns)
+		for week := range rows[weekday] {
+			day := week*7 + weekday - lead
+			if day < 0 || day >= len(counts) {
+				rows[weekday][week] = -1
+			} else {
+				rows[weekday][week] = counts[day]
+			}
+		}
+	}
+	return rows
+}
+
+func heatmapText(lang i18n.Lang, counts []int, first time.Time) string {
+	busiest := 0
+	for _, count := range counts {
+		busiest = max(busiest, count)
+	}
+	var builder strings.Builder
+	for weekday, row := range heatmapWeeks(counts, first) {
+		builder.WriteString(lang.T(weekdayNames[(weekday+1)%7][0]) + " ")
+		for _, count := range row {
+			if count < 0 {
+				builder.WriteString("▫️")
+				continue
+			}
+			builder.WriteString(heatShades[heatLevel(count, busiest)])
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// heatmapImage draws the same grid as heatmapText as a PNG picture.
+func heatmapImage(counts []int, first time.Time) ([]byte, error) {
+	const cell, gap = 28, 6
+	busiest := 0
+	for _, count := range counts {
+		busiest = max(busiest, count)
+	}
+	rows := heatmapWeeks(counts, first)
+	canvas := image.NewRGBA(image.Rect(0, 0, gap+len(rows[0])*(cell+gap), gap+len(rows)*(cell+gap)))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	for weekday, row := range rows {
+		for week, count := range row {
+			if count < 0 {
+				continue
+			}
+			x, y := gap+week*(cell+gap), gap+weekday*(cell+gap)
+			shade := image.NewUniform(heatColors[heatLevel(count, busiest)])
+			draw.Draw(canvas, image.Rect(x, y, x+cell, y+cell), shade, image.Point{}, draw.Src)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, fmt.Errorf("encode heatmap: %w", err)
+	}
+	return buf.Bytes(), nil
+}
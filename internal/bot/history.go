@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	cbReopenPrefix = "reopen:"
+	// historyDays is how far back /done looks by default; maxHistoryDays caps /done <days>.
+	historyDays    = 7
+	maxHistoryDays = 90
+	// historyLimit caps how many completed tasks one /done message lists.
+	historyLimit = 50
+)
+
+// handleDone lists the tasks completed in the last days, grouped by day: /done [days].
+// Completed one-time tasks get a button to reopen them.
+func (b *Bot) handleDone(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	days := historyDays
+	if args := strings.TrimSpace(msg.CommandArguments()); args != "" {
+		n, err := strconv.Atoi(args)
+		if err != nil || n < 1 || n > maxHistoryDays {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Укажи число дней от 1 до %d, например /done 30", maxHistoryDays))
+		}
+		days = n
+	}
+	user := CurrentUser(ctx)
+
+	now := time.Now().In(user.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tasks, err := b.taskSvc.ListCompleted(ctx, user, today.AddDate(0, 0, 1-days))
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить историю: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] history user=%d days=%d tasks=%d", user.ID, days, len(tasks))
+	if len(tasks) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("За %s ничего не выполнено. Посмотреть период длиннее: /done 30", daysLabel(lang, days)))
+	}
+
+	text, buttons := formatHistory(lang, tasks, days, now)
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text+b.workspaceTitle(ctx, user))
+	reply.ParseMode = tgbotapi.ModeHTML
+	if len(buttons) > 0 {
+		reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+	}
+	_, err = b.send(ctx, reply)
+	return err
+}
+
+// formatHistory renders completed tasks, most recent first, under a heading per day.
+func formatHistory(lang i18n.Lang, tasks []model.Task, days int, now time.Time) (string, [][]tgbotapi.InlineKeyboardButton) {
+	var builder strings.Builder
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	builder.WriteString(lang.Tf("✅ <b>Выполнено за %s: %d</b>", daysLabel(lang, days), len(tasks)))
+
+	var day string
+	for i, task := range tasks {
+		if i == historyLimit {
+			builder.WriteString(lang.Tf("\n\n…и ещё %d. Укажи период короче: /done %d", len(tasks)-historyLimit, max(days/2, 1)))
+			break
+		}
+		done := task.LastCompletedAt.In(now.Location())
+		if label := historyDayLabel(lang, done, now); label != day {
+			day = label
+			builder.WriteString("\n\n📅 <b>" + day + "</b>")
+		}
+		mark := "✅"
+		if task.IsRecurring {
+			mark = "♻️"
+		}
+		builder.WriteString(fmt.Sprintf("\n%s %s #%d %s%s", mark, done.Format("15:04"), task.ID, service.PriorityMark(task.Priority), escape(normalizeTitle(task.Title))))
+		if !task.IsRecurring && task.IsCompleted {
+			buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(lang.Tf("↩️ Вернуть #%d · %s", task.ID, shortTitle(task.Title, 24)), fmt.Sprintf("%s%d", cbReopenPrefix, task.ID)),
+			))
+		}
+	}
+	return builder.String(), buttons
+}
+
+// historyDayLabel names the day of t relative to now: «Сегодня», «Вчера» or the date with the weekday.
+func historyDayLabel(lang i18n.Lang, t, now time.Time) string {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := now.Date()
+	day := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	switch today.Sub(day) {
+	case 0:
+		return lang.T("Сегодня")
+	case 24 * time.Hour:
+		return lang.T("Вчера")
+	}
+	return fmt.Sprintf("%s, %s", t.Format("02.01"), lang.T(weekdayNames[t.Weekday()][0]))
+}
+
+func daysLabel(lang i18n.Lang, days int) string {
+	switch {
+	case days == 1:
+		return lang.T("сегодня")
+	case days%10 == 1 && days%100 != 11:
+		return lang.Tf("%d день", days)
+	case days%10 >= 2 && days%10 <= 4 && (days%100 < 12 || days%100 > 14):
+		return lang.Tf("%d дня", days)
+	default:
+		return lang.Tf("%d дней", days)
+	}
+}
+
+// handleReopenButton puts a completed task back among the open ones and drops its button.
+func (b *Bot) handleReopenButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	user, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	if task, err = b.taskSvc.ReopenTask(ctx, user, task.ID); err != nil {
+		if errors.Is(err, service.ErrTaskNotCompleted) {
+			b.answerCallback(cb, lang.T("Задача уже открыта."))
+			return b.dropTaskRows(ctx, cb, task.ID)
+		}
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] task reopened id=%d user=%d", task.ID, user.ID)
+	b.answerCallback(cb, lang.Tf("↩️ Задача «%s» снова открыта.", normalizeTitle(task.Title)))
+	return b.dropTaskRows(ctx, cb, task.ID)
+}
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// handleDocument imports supported files sent to the bot.
+func (b *Bot) handleDocument(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	doc := msg.Document
+	switch ext := strings.ToLower(filepath.Ext(doc.FileName)); {
+	case ext == ".csv" || ext == ".json":
+		return b.importFile(ctx, msg, strings.TrimPrefix(ext, "."))
+	case ext != ".ics" && doc.MimeType != "text/calendar":
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Я умею импортировать календари в формате .ics, задачи из /export и файлы настроек из /settings export."))
+	}
+
+	user := CurrentUser(ctx)
+
+	if err := b.quotaSvc.CheckAttachment(user, int64(doc.FileSize)); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Файл не принят: %s", errorText(lang, err)))
+	}
+
+	body, err := b.downloadFile(ctx, doc.FileID)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось скачать файл: %s", errorText(lang, err)))
+	}
+	defer body.Close()
+
+	result, err := b.importSvc.ImportICS(ctx, user, body, time.Now())
+	var quotaErr *service.QuotaError
+	if errors.As(err, &quotaErr) {
+		return b.sendText(ctx, msg.Chat.ID, importSummary(lang, result)+lang.T("\n⚠️ Остальные события не добавлены: ")+errorText(lang, err))
+	}
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось импортировать календарь: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] ics imported user=%d created=%d updated=%d", user.ID, result.Created, result.Updated)
+	return b.sendText(ctx, msg.Chat.ID, importSummary(lang, result))
+}
+
+// maxImportProblems is how many skipped rows an import report lists.
+const maxImportProblems = 10
+
+// handleImport explains how to bring tasks in: the file itself comes as a document.
+func (b *Bot) handleImport(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	return b.sendText(ctx, msg.Chat.ID, lang.T("📥 Пришли файл из /export — таблицу .csv или .json, — и я добавлю задачи из него. В таблице обязателен столбец title, остальные (description, category, priority, status, deadline, recur_type…) можно опустить. Новые категории создаются сами, подзадачи по столбцам id и parent_id снова встают под свои задачи, строки с ошибками пропускаются, и я скажу, какие именно. Файл — до 5 МБ."))
+}
+
+// importFile imports tasks from a CSV or JSON file; a JSON object rather than an array is a
+// settings profile.
+func (b *Bot) importFile(ctx context.Context, msg *tgbotapi.Message, format string) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	if err := b.quotaSvc.CheckAttachment(user, int64(msg.Document.FileSize)); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Файл не принят: %s", errorText(lang, err)))
+	}
+	body, err := b.downloadFile(ctx, msg.Document.FileID)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось скачать файл: %s", errorText(lang, err)))
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось скачать файл: %s", errorText(lang, err)))
+	}
+	if format == service.ExportJSON && !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return b.importSettings(ctx, msg, bytes.NewReader(data))
+	}
+
+	result, err := b.exportSvc.ImportTasks(ctx, user, bytes.NewReader(data), format, time.Now())
+	switch {
+	case errors.Is(err, service.ErrInvalidImport):
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не получилось прочитать файл: %s. Подойдёт файл из /export.", escape(err.Error())))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Задачи не добавлены: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] tasks imported user=%d format=%s created=%d categories=%d skipped=%d", user.ID, format, result.Created, result.Categories, len(result.Problems))
+	var text strings.Builder
+	text.WriteString(lang.Tf("📥 Добавлено задач: %d, новых категорий: %d.", result.Created, result.Categories))
+	if len(result.Problems) > 0 {
+		text.WriteString("\n" + lang.Tf("Пропущено: %d.", len(result.Problems)))
+		for i, problem := range result.Problems {
+			if i == maxImportProblems {
+				text.WriteString("\n" + lang.Tf("…и ещё %d.", len(result.Problems)-i))
+				break
+			}
+			reason := lang.T(problem.Reason)
+			if problem.Value != "" {
+				reason = lang.Tf(problem.Reason, problem.Value)
+			}
+			place := lang.Tf("строка %d", problem.Row)
+			if format == service.ExportJSON {
+				place = lang.Tf("запись %d", problem.Row)
+			}
+			text.WriteString(fmt.Sprintf("\n• %s: %s", place, escape(reason)))
+		}
+	}
+	return b.sendText(ctx, msg.Chat.ID, text.String())
+}
+
+// handleICS manages calendar feed subscriptions: /ics, /ics <url>, /ics off <id>.
+func (b *Bot) handleICS(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+
+	args := strings.TrimSpace(msg.CommandArguments())
+	switch {
+	case args == "":
+		return b.sendSubscriptions(ctx, msg.Chat.ID, user)
+	case strings.HasPrefix(strings.ToLower(args), "off"):
+		id, err := strconv.ParseUint(strings.TrimSpace(args[len("off"):]), 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /ics off &lt;id&gt;"))
+		}
+		removed, err := b.importSvc.Unsubscribe(ctx, user, uint(id))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+		}
+		if !removed {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Подписка не найдена."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🔕 Подписка удалена. Уже импортированные задачи остались."))
+	default:
+		_, result, err := b.importSvc.Subscribe(ctx, user, args, time.Now())
+		if errors.Is(err, service.ErrInvalidFeedURL) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Ссылка должна начинаться с http:// или https://"))
+		}
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось подписаться: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] ics subscribed user=%d created=%d", user.ID, result.Created)
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🔔 Подписка оформлена, календарь будет обновляться автоматически.\n")+importSummary(lang, result))
+	}
+}
+
+func (b *Bot) sendSubscriptions(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	subs, err := b.importSvc.ListSubscriptions(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить подписки: %s", errorText(lang, err)))
+	}
+	if len(subs) == 0 {
+		return b.sendText(ctx, chatID, lang.T("📅 Подписок на календари нет.\nОтправь /ics &lt;ссылка на .ics&gt;, чтобы подписаться, или просто пришли .ics-файл."))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("📅 <b>Подписки на календари</b>\n"))
+	for _, sub := range subs {
+		builder.WriteString(fmt.Sprintf("• <b>%d</b> · %s\n", sub.ID, escape(sub.URL)))
+		if sub.LastError != "" {
+			builder.WriteString(fmt.Sprintf("   ⚠️ %s\n", escape(sub.LastError)))
+		} else if sub.LastSyncedAt != nil {
+			builder.WriteString(lang.Tf("   🔄 обновлено %s\n", sub.LastSyncedAt.Format("2006-01-02 15:04")))
+		}
+	}
+	builder.WriteString(lang.T("\nОтписаться: /ics off &lt;id&gt;"))
+	return b.sendText(ctx, chatID, builder.String())
+}
+
+func importSummary(lang i18n.Lang, result service.ImportResult) string {
+	return lang.Tf("📥 Импорт календаря: добавлено %d, обновлено %d, пропущено %d.", result.Created, result.Updated, result.Skipped)
+}
+
+// downloadFile opens a file uploaded to Telegram for reading.
+func (b *Bot) downloadFile(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	file, err := b.api.GetFile(tgbotapi.FileConfig{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+	if b.config.BotAPIURL != "" && filepath.IsAbs(file.FilePath) {
+		// A Bot API server started with --local gives the path on its disk, shared with the bot.
+		return os.Open(file.FilePath)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(b.fileEndpoint, b.api.Token, file.FilePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.api.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download file: unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
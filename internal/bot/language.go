@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+const languageUsage = "Формат: /language en — English, /language ru — русский, /language auto — как в Telegram, /language ru+en — заголовки отчёта ещё и на втором языке"
+
+// handleLanguage shows or changes the language of the bot's messages: /language [ru|en|auto][+second].
+// A second language repeats the headers of the daily report in it, for a family that shares one.
+func (b *Bot) handleLanguage(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	if arg == "" {
+		text := lang.Tf("🌐 Язык: %s", lang.Name())
+		if user.Language == "" {
+			text += lang.T(" (как в Telegram)")
+		}
+		if second := user.SecondLang(); second != "" && second != lang {
+			text += "\n" + lang.Tf("📋 Заголовки отчёта: %s + %s", lang.Name(), second.Name())
+		}
+		return b.sendText(ctx, msg.Chat.ID, text+"\n"+lang.T(languageUsage))
+	}
+	arg, secondArg, bilingual := strings.Cut(arg, "+")
+	var chosen, second string
+	if strings.TrimSpace(arg) != "auto" {
+		parsed, ok := i18n.Parse(arg)
+		if !ok {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(languageUsage))
+		}
+		chosen = string(parsed)
+	}
+	if bilingual {
+		parsed, ok := i18n.Parse(secondArg)
+		if !ok {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(languageUsage))
+		}
+		second = string(parsed)
+	}
+	if err := b.userRepo.SetLanguage(ctx, user, chosen, second); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] language user=%d language=%q second=%q", user.ID, chosen, second)
+	lang = user.Lang()
+	text := lang.Tf("🌐 Готово, теперь я говорю: %s.", lang.Name())
+	if second := user.SecondLang(); second != "" && second != lang {
+		text += "\n" + lang.Tf("📋 Заголовки отчёта будут на двух языках: %s + %s.", lang.Name(), second.Name())
+	}
+	return b.sendText(i18n.WithLang(ctx, lang), msg.Chat.ID, text)
+}
@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+func (b *Bot) registerMedications(r *router) {
+	r.command("meds", "расписание лекарств", b.handleMedications)
+	r.command("med", "", b.handleMedication)
+	r.callback(callbackRoute{prefix: cbDosePrefix, handle: b.handleDoseAnswer})
+}
+
+const (
+	doseTaken  = "taken"
+	doseMissed = "missed"
+
+	medicationFormat = "Формат: /med add Название 9:00 21:00\nНапример: /med add Витамин D в 9:00 и 21:00"
+)
+
+// handleMedications lists medication schedules.
+func (b *Bot) handleMedications(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	medications, err := b.medicationSvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить расписание: %s", errorText(lang, err)))
+	}
+	if len(medications) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("💊 Расписания приёма пока нет.\n")+lang.T(medicationFormat))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("💊 <b>Расписание приёма</b>\n"))
+	for _, medication := range medications {
+		builder.WriteString(fmt.Sprintf("• <b>%d</b> · %s — %s\n", medication.ID, escape(medication.Name), strings.Join(medication.Slots(), ", ")))
+	}
+	builder.WriteString(lang.T("\nВ назначенное время придёт сообщение с кнопками «Принял» / «Пропустил». Соблюдение режима — в /stats.\nУдалить: /med del &lt;id&gt;"))
+	return b.sendText(ctx, msg.Chat.ID, builder.String())
+}
+
+// handleMedication adds or removes a schedule: /med add <name> <HH:MM>..., /med del <id>.
+func (b *Bot) handleMedication(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		return b.handleMedications(ctx, msg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		name, times := parseMedication(args[1:])
+		if name == "" || len(times) == 0 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(medicationFormat))
+		}
+		medication, err := b.medicationSvc.Add(ctx, user, name, times)
+		if errors.Is(err, service.ErrInvalidTime) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Время указывается как 9:00 или 21:30, не больше восьми раз в день.\n")+lang.T(medicationFormat))
+		}
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось добавить расписание: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] medication added id=%d user=%d", medication.ID, user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("💊 %s — каждый день в %s.", escape(medication.Name), strings.Join(medication.Slots(), ", ")))
+	case "del":
+		if len(args) != 2 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /med del &lt;id&gt;"))
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /med del &lt;id&gt;"))
+		}
+		removed, err := b.medicationSvc.Remove(ctx, user, uint(id))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+		}
+		if !removed {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Расписание не найдено."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🗑 Расписание и история приёма удалены."))
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(medicationFormat))
+	}
+}
+
+// SendDoseCheckins asks about doses whose time has come and marks long-unanswered ones as missed.
+func (b *Bot) SendDoseCheckins(ctx context.Context) error {
+	now := time.Now()
+	doses, err := b.medicationSvc.DueDoses(ctx, now)
+	if err != nil {
+		return err
+	}
+	owners := make(map[uint]*model.User) // nil for archived owners
+	for _, due := range doses {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		owner, ok := owners[due.Dose.UserID]
+		if !ok {
+			user, err := b.userRepo.FindByID(ctx, due.Dose.UserID)
+			if err != nil {
+				log.Printf("dose check-in owner of medication %d: %v", due.Medication.ID, err)
+				continue
+			}
+			if user.ArchivedAt == nil {
+				owner = user
+			}
+			owners[due.Dose.UserID] = owner
+		}
+		if owner == nil {
+			continue
+		}
+		chatID, lang := owner.TelegramID, owner.Lang()
+
+		msg := tgbotapi.NewMessage(chatID, doseText(due, ""))
+		msg.ParseMode = tgbotapi.ModeHTML
+		id := strconv.FormatUint(uint64(due.Dose.ID), 10)
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Принял"), cbDosePrefix+id+":"+doseTaken),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("⏭ Пропустил"), cbDosePrefix+id+":"+doseMissed),
+		))
+		if _, err := b.send(ctx, msg); err != nil {
+			log.Printf("send dose check-in to %d: %v", chatID, err)
+		}
+	}
+
+	if _, err := b.medicationSvc.MissOverdue(ctx, now); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleDoseAnswer records a check-in button press and replaces the buttons with the answer.
+func (b *Bot) handleDoseAnswer(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	rawID, answer, _ := strings.Cut(data, ":")
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	due, err := b.medicationSvc.Record(ctx, user, uint(id), answer == doseTaken, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.T("Это расписание уже удалено."))
+	}
+	if err != nil {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("Не удалось отметить приём: %s", errorText(lang, err)))
+	}
+
+	status := lang.T("⏭ пропущено")
+	if answer == doseTaken {
+		status = lang.T("✅ принято")
+	}
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, doseText(*due, status))
+	edit.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+func doseText(due service.DueDose, status string) string {
+	text := fmt.Sprintf("💊 <b>%s</b> · %s", escape(due.Medication.Name), due.Dose.ScheduledAt.Format("15:04"))
+	if status != "" {
+		return text + " — " + status
+	}
+	return text
+}
+
+// parseMedication splits "Витамин D в 9:00 и 21:00" into the name and intake times.
+func parseMedication(args []string) (string, []string) {
+	var name, times []string
+	for _, arg := range args {
+		arg = strings.TrimSuffix(arg, ",")
+		switch {
+		case strings.Contains(arg, ":"):
+			times = append(times, arg)
+		case len(times) > 0 || strings.EqualFold(arg, "в"):
+			// Connectives between times ("и") and before them ("в") are not part of the name.
+		default:
+			name = append(name, arg)
+		}
+	}
+	return strings.Join(name, " "), times
+}
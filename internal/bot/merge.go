@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// Merge buttons: "merge:<id>" on the card asks for the duplicate to fold into the task,
+// "unmerge:<merge id>" under the result undoes it.
+const (
+	cbMergePrefix   = "merge:"
+	cbUnmergePrefix = "unmerge:"
+)
+
+const mergeUsage = "Формат: /merge 12 15 — влить задачу #15 в #12"
+
+// handleMerge folds a duplicate into a task: /merge <id> <duplicate id>.
+func (b *Bot) handleMerge(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) != 2 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(mergeUsage))
+	}
+	keptID, ok := parseTaskRef(args[0])
+	duplicateID, ok2 := parseTaskRef(args[1])
+	if !ok || !ok2 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(mergeUsage))
+	}
+	return b.merge(ctx, msg.Chat.ID, CurrentUser(ctx), keptID, duplicateID)
+}
+
+// parseTaskRef reads a task ID written as 15 or #15.
+func parseTaskRef(text string) (uint, bool) {
+	id, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(text), "#"), 10, 64)
+	return uint(id), err == nil && id > 0
+}
+
+// askMergeDuplicate asks which task to fold into the one whose card was tapped.
+func (b *Bot) askMergeDuplicate(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	b.setConversation(from.ID, &conversationState{stage: stageMerge, taskID: taskID})
+	return b.sendWithReplyMarkup(ctx, chatID, lang.Tf("🔀 Номер задачи-дубликата, которую влить в #%d? Её описание допишется сюда, срок останется более ранний, подзадачи, напоминания и вложения перейдут, а сама она уйдёт в корзину.", taskID), cancelKeyboard(lang))
+}
+
+// finishMerge merges the duplicate named in the reply; anything but a task number keeps the conversation.
+func (b *Bot) finishMerge(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	duplicateID, ok := parseTaskRef(msg.Text)
+	if !ok {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Пришли номер задачи, например <code>15</code>."), cancelKeyboard(lang))
+	}
+	b.clearConversation(msg.From.ID)
+	return b.merge(ctx, msg.Chat.ID, CurrentUser(ctx), state.taskID, duplicateID)
+}
+
+// merge folds the duplicate into the kept task, offers to undo it and shows the merged card.
+func (b *Bot) merge(ctx context.Context, chatID int64, user *model.User, keptID, duplicateID uint) error {
+	lang := i18n.FromContext(ctx)
+	merge, err := b.taskSvc.MergeTasks(ctx, user, keptID, duplicateID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	case errors.Is(err, service.ErrMergeSame):
+		return b.sendText(ctx, chatID, lang.T("Задачу нельзя объединить с ней же самой."))
+	case errors.Is(err, service.ErrMergeSubtask):
+		return b.sendText(ctx, chatID, lang.T("Задачу нельзя объединить с её подзадачей."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось объединить задачи: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] tasks merged id=%d kept=%d duplicate=%d user=%d", merge.ID, keptID, duplicateID, user.ID)
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("↩️ Отменить объединение"), fmt.Sprintf("%s%d", cbUnmergePrefix, merge.ID)),
+	))
+	if err := b.sendWithReplyMarkup(ctx, chatID, lang.Tf("🔀 Задача #%d влита в #%d и лежит в корзине.", duplicateID, keptID), markup); err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, chatID, user, keptID)
+}
+
+// handleUnmergeButton undoes the merge whose result message the button is under.
+func (b *Bot) handleUnmergeButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	mergeID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil || cb.Message == nil {
+		b.answerCallback(cb, "")
+		return nil
+	}
+	user := CurrentUser(ctx)
+	merge, err := b.taskSvc.UndoMerge(ctx, user, uint(mergeID))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		b.answerCallback(cb, lang.T("Отменить уже нельзя: объединение отменено раньше или задачи больше нет."))
+		return nil
+	case err != nil:
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] merge undone id=%d kept=%d duplicate=%d user=%d", merge.ID, merge.TaskID, merge.DuplicateID, user.ID)
+	b.answerCallback(cb, lang.T("↩️ Объединение отменено."))
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, lang.Tf("↩️ Объединение отменено: #%d и #%d снова отдельные задачи.", merge.TaskID, merge.DuplicateID))
+	_, err = b.send(ctx, edit)
+	return err
+}
@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"context"
+	"expvar"
+	"time"
+)
+
+// Update metrics, keyed by updateKind and published with expvar.
+var (
+	updatesHandled = expvar.NewMap("bot_updates_handled")
+	updatesFailed  = expvar.NewMap("bot_updates_failed")
+	updatesDropped = expvar.NewMap("bot_updates_dropped")
+	updateSeconds  = expvar.NewMap("bot_update_seconds")
+)
+
+// countUpdate counts handled and failed updates and the time spent on them.
+func (b *Bot) countUpdate(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		started := time.Now()
+		err := next(ctx, update)
+		kind := updateKind(update)
+		updatesHandled.Add(kind, 1)
+		updateSeconds.AddFloat(kind, time.Since(started).Seconds())
+		if err != nil {
+			updatesFailed.Add(kind, 1)
+		}
+		return err
+	}
+}
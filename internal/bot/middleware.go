@@ -0,0 +1,360 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/tracing"
+)
+
+// Handler processes one incoming update.
+type Handler func(ctx context.Context, update Update) error
+
+// Middleware wraps a Handler with a concern shared by all updates.
+type Middleware func(next Handler) Handler
+
+// slowUpdate is how long handling an update may take before it is logged as slow.
+const slowUpdate = 2 * time.Second
+
+// Flood protection: every user has a bucket of config.RateLimitBurst updates that refills
+// at config.RateLimit updates a minute; updates finding it empty are dropped.
+
+const slowDownText = "🐢 Не так быстро! Подожди пару секунд и попробуй снова."
+
+const maintenanceText = "🛠 Бот на техническом обслуживании. Загляни чуть позже!"
+
+// chain wraps handler so that the first middleware runs outermost.
+func chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Use adds middleware to the end of the pipeline, in the given order. It runs after the
+// built-in ones, for updates from signed-up users, with the sender in ctx (see CurrentUser).
+// Use must be called before Start.
+func (b *Bot) Use(mw ...Middleware) {
+	b.extra = append(b.extra, mw...)
+	b.handle = b.pipeline()
+}
+
+// pipeline is the path every update takes to its handler.
+func (b *Bot) pipeline() Handler {
+	builtin := []Middleware{
+		b.recoverPanics,
+		b.traceUpdate,
+		b.countUpdate,
+		b.logUpdate,
+		b.maintenance,
+		b.limitFlood,
+		b.localize,
+		b.authorize,
+		b.loadUser,
+		b.keepDialogs,
+	}
+	return chain(b.dispatch, append(builtin, b.extra...)...)
+}
+
+// recoverPanics turns a panicking handler into an error, so one bad update does not stop the bot.
+func (b *Bot) recoverPanics(next Handler) Handler {
+	return func(ctx context.Context, update Update) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				log.Printf("panic handling update %d: %v\n%s", update.UpdateID, recovered, debug.Stack())
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+		}()
+		return next(ctx, update)
+	}
+}
+
+// traceUpdate runs the update inside its own span, the parent of everything it causes.
+func (b *Bot) traceUpdate(next Handler) Handler {
+	return func(ctx context.Context, update Update) (err error) {
+		ctx, span := tracing.Start(ctx, "telegram.update", updateAttributes(update)...)
+		b.traceCtx.Store(&ctx)
+		defer func() {
+			b.traceCtx.Store(nil)
+			tracing.End(span, err)
+		}()
+		return next(ctx, update)
+	}
+}
+
+// logUpdate logs failed and slow updates.
+func (b *Bot) logUpdate(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		started := time.Now()
+		err := next(ctx, update)
+		kind, senderID := updateKind(update), updateSenderID(update)
+		if err != nil {
+			log.Printf("handle %s from %d: %v", kind, senderID, err)
+		}
+		if elapsed := time.Since(started); elapsed > slowUpdate {
+			log.Printf("[warn] slow %s from %d took=%s", kind, senderID, elapsed.Round(time.Millisecond))
+		}
+		return err
+	}
+}
+
+// maintenance answers everyone but admins with a notice while MAINTENANCE_MODE is on.
+func (b *Bot) maintenance(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		if !b.config.MaintenanceMode || slices.Contains(b.config.AdminIDs, updateSenderID(update)) {
+			return next(ctx, update)
+		}
+		// The sender's choice of language is not looked up: the database is spared here.
+		lang := i18n.Default
+		if from := updateSender(update); from != nil {
+			lang = i18n.Resolve("", from.LanguageCode)
+		}
+		ctx = i18n.WithLang(ctx, lang)
+		switch {
+		case update.CallbackQuery != nil:
+			_, err := b.api.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, lang.T(maintenanceText)))
+			return err
+		case update.Message != nil && update.Message.Chat != nil && update.Message.Chat.IsPrivate():
+			return b.sendText(ctx, update.Message.Chat.ID, lang.T(maintenanceText))
+		}
+		return nil
+	}
+}
+
+// localize puts the sender's language into ctx: the one they chose with /language,
+// otherwise the one of their Telegram client.
+func (b *Bot) localize(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		from := updateSender(update)
+		if from == nil {
+			return next(ctx, update)
+		}
+		chosen := ""
+		if user, err := b.userRepo.FindByTelegramID(ctx, from.ID); err == nil {
+			chosen = user.Language
+		}
+		return next(i18n.WithLang(ctx, i18n.Resolve(chosen, from.LanguageCode)), update)
+	}
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	warned  bool // the sender was asked to slow down since their last update went through
+}
+
+// limitFlood drops updates from users who send too many of them, sparing the database,
+// and asks them once to slow down.
+func (b *Bot) limitFlood(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		senderID := updateSenderID(update)
+		if senderID == 0 || b.config.RateLimit == 0 {
+			return next(ctx, update)
+		}
+		ok, warn := b.allowUpdate(senderID, time.Now())
+		if ok {
+			return next(ctx, update)
+		}
+		log.Printf("[warn] flood from %d, update %d dropped", senderID, update.UpdateID)
+		updatesDropped.Add(updateKind(update), 1)
+		// Like maintenance, the notice spares the database the sender's choice of language.
+		lang := i18n.Resolve("", updateSender(update).LanguageCode)
+		switch {
+		case update.CallbackQuery != nil:
+			text := ""
+			if warn {
+				text = lang.T(slowDownText)
+			}
+			// The button stops spinning either way.
+			_, err := b.api.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, text))
+			return err
+		case warn && update.Message != nil && update.Message.Chat != nil && update.Message.Chat.IsPrivate():
+			return b.sendText(i18n.WithLang(ctx, lang), update.Message.Chat.ID, lang.T(slowDownText))
+		}
+		return nil
+	}
+}
+
+// allowUpdate takes a token from the sender's bucket. warn is set for the first update
+// dropped since one went through.
+func (b *Bot) allowUpdate(senderID int64, now time.Time) (ok, warn bool) {
+	b.floodMu.Lock()
+	defer b.floodMu.Unlock()
+	burst := float64(max(b.config.RateLimitBurst, 1))
+	perSecond := float64(b.config.RateLimit) / 60
+	bucket, found := b.floods[senderID]
+	if !found {
+		// Forget buckets that have refilled so the map does not grow with every user ever seen.
+		for id, other := range b.floods {
+			if other.tokens+now.Sub(other.updated).Seconds()*perSecond >= burst {
+				delete(b.floods, id)
+			}
+		}
+		bucket = &tokenBucket{tokens: burst, updated: now}
+		b.floods[senderID] = bucket
+	}
+	bucket.tokens = min(burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*perSecond)
+	bucket.updated = now
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		bucket.warned = false
+		return true, false
+	}
+	warn = !bucket.warned
+	bucket.warned = true
+	return false, warn
+}
+
+// authorize lets through only users who passed signup. New users in a private chat go
+// through signup instead; the captcha answer is the one update they may send before that.
+func (b *Bot) authorize(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		switch {
+		case update.MessageReaction != nil:
+			if !b.registered(ctx, update.MessageReaction.User) {
+				return nil
+			}
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, cbCaptchaPrefix):
+		case update.CallbackQuery != nil:
+			if !b.registered(ctx, update.CallbackQuery.From) {
+				return nil
+			}
+		case update.Message != nil:
+			if update.Message.Chat == nil {
+				return nil
+			}
+			if !update.Message.Chat.IsPrivate() {
+				// Unknown users cannot sign up from a group, so their commands are ignored there.
+				if !b.registered(ctx, update.Message.From) {
+					return nil
+				}
+				break
+			}
+			ok, err := b.admit(ctx, update.Message)
+			if err != nil {
+				return fmt.Errorf("signup: %w", err)
+			}
+			if !ok {
+				return nil
+			}
+		default:
+			return nil
+		}
+		return next(ctx, update)
+	}
+}
+
+// updateUser is the sender of the update being handled: their own record and the owner
+// of the tasks they work with.
+type updateUser struct {
+	self, owner *model.User
+}
+
+type updateUserKey struct{}
+
+// loadUser registers the sender once per update and puts them into ctx, so handlers and
+// later middleware need not look them up again.
+func (b *Bot) loadUser(next Handler) Handler {
+	return func(ctx context.Context, update Update) error {
+		from := updateSender(update)
+		if from == nil {
+			return next(ctx, update)
+		}
+		self, err := b.telegramUser(ctx, from)
+		if err != nil {
+			return fmt.Errorf("load user: %w", err)
+		}
+		owner, err := b.accountSvc.Owner(ctx, self)
+		if err != nil {
+			return fmt.Errorf("load user: %w", err)
+		}
+		return next(context.WithValue(ctx, updateUserKey{}, &updateUser{self: self, owner: owner}), update)
+	}
+}
+
+// CurrentUser is the owner of the tasks the sender of the update works with: themselves, or
+// the primary account they are linked to. It is nil outside of an update from a signed-up user.
+func CurrentUser(ctx context.Context) *model.User {
+	if current, ok := ctx.Value(updateUserKey{}).(*updateUser); ok {
+		return current.owner
+	}
+	return nil
+}
+
+// loadedUser is the user loadUser put into ctx, nil when from is not the sender of the update.
+func loadedUser(ctx context.Context, from *tgbotapi.User) *updateUser {
+	current, ok := ctx.Value(updateUserKey{}).(*updateUser)
+	if !ok || from == nil || current.self.TelegramID != from.ID {
+		return nil
+	}
+	return current
+}
+
+// updateKind names the update for logs and spans.
+func updateKind(update Update) string {
+	switch {
+	case update.MessageReaction != nil:
+		return "reaction"
+	case update.CallbackQuery != nil:
+		return "callback"
+	case update.Message != nil && update.Message.Chat != nil && !update.Message.Chat.IsPrivate():
+		return "group message"
+	case update.Message != nil:
+		return "message"
+	default:
+		return "update"
+	}
+}
+
+// updateSender is whoever caused the update, nil when unknown.
+func updateSender(update Update) *tgbotapi.User {
+	switch {
+	case update.MessageReaction != nil:
+		return update.MessageReaction.User
+	case update.CallbackQuery != nil:
+		return update.CallbackQuery.From
+	case update.Message != nil:
+		return update.Message.From
+	}
+	return nil
+}
+
+// updateSenderID is the Telegram ID of whoever caused the update, 0 when unknown.
+func updateSenderID(update Update) int64 {
+	if from := updateSender(update); from != nil {
+		return from.ID
+	}
+	return 0
+}
+
+// updateAttributes describes an update on its span without any message text.
+func updateAttributes(update Update) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Int("telegram.update_id", update.UpdateID),
+		attribute.String("telegram.update_kind", updateKind(update)),
+	}
+	if update.Message != nil && update.Message.IsCommand() {
+		attrs = append(attrs, attribute.String("telegram.command", update.Message.Command()))
+	}
+	return attrs
+}
+
+// traceParent is the span context of the update being handled. Bot API requests carry no
+// context, so this is how sends join the update's trace; sends from scheduled jobs running
+// at the same moment may end up attributed to it too.
+func (b *Bot) traceParent() context.Context {
+	if ctx := b.traceCtx.Load(); ctx != nil {
+		return *ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/config"
+)
+
+func TestChainOrderAndRecovery(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, update Update) error {
+				order = append(order, name)
+				return next(ctx, update)
+			}
+		}
+	}
+	b := &Bot{}
+	handler := chain(func(context.Context, Update) error {
+		panic("boom")
+	}, b.recoverPanics, mark("first"), mark("second"))
+
+	err := handler(context.Background(), Update{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("panic not turned into an error: %v", err)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("middleware ran as %v", order)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := &Bot{config: &config.Config{RateLimit: 30, RateLimitBurst: 10}, floods: make(map[int64]*tokenBucket)}
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		if ok, _ := b.allowUpdate(1, now); !ok {
+			t.Fatalf("update %d of the burst dropped", i+1)
+		}
+	}
+	if ok, warn := b.allowUpdate(1, now); ok || !warn {
+		t.Errorf("flood not limited: ok=%v warn=%v", ok, warn)
+	}
+	if _, warn := b.allowUpdate(1, now.Add(time.Second)); warn {
+		t.Error("asked to slow down twice")
+	}
+	if ok, _ := b.allowUpdate(2, now.Add(time.Second)); !ok {
+		t.Error("another user limited too")
+	}
+	// 30 a minute is one token every 2 seconds.
+	if ok, _ := b.allowUpdate(1, now.Add(2*time.Second)); !ok {
+		t.Error("bucket did not refill")
+	}
+	if ok, warn := b.allowUpdate(1, now.Add(2*time.Second)); ok || !warn {
+		t.Errorf("refilled token spent twice: ok=%v warn=%v", ok, warn)
+	}
+}
@@ -0,0 +1,201 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	nudgeSplit    = "split"
+	nudgeDelegate = "delegate"
+	nudgeDrop     = "drop"
+	nudgeKeep     = "keep"
+)
+
+// SendProcrastinationNudges asks owners what to do with tasks they keep putting off.
+func (b *Bot) SendProcrastinationNudges(ctx context.Context) error {
+	now := time.Now()
+	nudges, err := b.reminderSvc.Nudges(ctx, now)
+	if err != nil {
+		return err
+	}
+	for i := range nudges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nudge := &nudges[i]
+		user, err := b.userRepo.FindByID(ctx, nudge.Task.UserID)
+		if err != nil {
+			log.Printf("nudge owner of task %d: %v", nudge.Task.ID, err)
+			continue
+		}
+		if user.ArchivedAt != nil {
+			continue
+		}
+		lang := user.Lang()
+
+		reason := lang.Tf("Задачу не трогали уже %d дн.", nudge.IdleDays)
+		if nudge.Postponed {
+			reason = lang.Tf("Напоминание о ней откладывалось %d раз.", nudge.Task.PostponeCount)
+		}
+		text := lang.Tf("🤔 <b>#%d</b> %s\n%s Может, разбить её на шаги, поручить кому-то или отказаться?", nudge.Task.ID, escape(normalizeTitle(nudge.Task.Title)), reason)
+		msg := tgbotapi.NewMessage(user.TelegramID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = nudgeKeyboard(lang, nudge.Task.ID)
+		if _, err := b.send(ctx, msg); err != nil {
+			log.Printf("send nudge to %d: %v", user.TelegramID, err)
+			continue
+		}
+		if err := b.reminderSvc.MarkNudged(ctx, &nudge.Task, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nudgeKeyboard(lang i18n.Lang, taskID uint) tgbotapi.InlineKeyboardMarkup {
+	data := func(action string) string {
+		return fmt.Sprintf("%s%s:%d", cbNudgePrefix, action, taskID)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✂️ Разбить"), data(nudgeSplit)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🤝 Поручить"), data(nudgeDelegate)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🗑 Отказаться"), data(nudgeDrop)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("📌 Оставить"), data(nudgeKeep)),
+		),
+	)
+}
+
+// handleNudgeAnswer applies the action picked under a nudge. Nudges only cover
+// personal tasks, so the answer is handled outside the active workspace.
+func (b *Bot) handleNudgeAnswer(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	action, rawID, _ := strings.Cut(data, ":")
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil
+	}
+	owner := CurrentUser(ctx)
+	user := personalUser(owner)
+	chatID := cb.Message.Chat.ID
+
+	task, err := b.taskSvc.GetTask(ctx, user, uint(id))
+	if err == nil && !task.IsRecurring && task.IsCompleted {
+		err = service.ErrTaskCompleted
+	}
+	if err == nil {
+		switch action {
+		case nudgeSplit:
+			b.setConversation(cb.From.ID, &conversationState{stage: stageBreakdown, taskID: task.ID})
+			return b.sendWithReplyMarkup(ctx, chatID, lang.Tf("✂️ Напиши шаги для «%s», каждый с новой строки. Они заменят задачу.", escape(normalizeTitle(task.Title))), cancelKeyboard(lang))
+		case nudgeDelegate:
+			_, err = b.taskSvc.CompleteTask(ctx, user, task.ID, time.Now())
+		case nudgeDrop:
+			err = b.taskSvc.DeleteTask(ctx, user, task.ID)
+		case nudgeKeep:
+			_, err = b.taskSvc.KeepTask(ctx, user, task.ID, time.Now())
+		default:
+			return nil
+		}
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена или уже удалена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, chatID, lang.T("Задача уже выполнена."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось изменить задачу: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] nudge answered id=%d user=%d action=%s", task.ID, user.ID, action)
+	var status string
+	switch action {
+	case nudgeDelegate:
+		status = lang.T("🤝 поручено")
+	case nudgeDrop:
+		status = lang.T("🗑 удалена")
+	case nudgeKeep:
+		status = lang.T("📌 оставлена")
+	}
+	edit := tgbotapi.NewEditMessageText(chatID, cb.Message.MessageID, fmt.Sprintf("🤔 <b>#%d</b> %s — %s", task.ID, escape(normalizeTitle(task.Title)), status))
+	edit.ParseMode = tgbotapi.ModeHTML
+	if _, err := b.send(ctx, edit); err != nil {
+		return err
+	}
+	if action == nudgeDelegate {
+		if err := b.sendText(ctx, chatID, lang.T("📤 Перешли следующее сообщение тому, кому поручаешь задачу. У себя я её закрыл.")); err != nil {
+			return err
+		}
+		return b.sendText(ctx, chatID, delegationText(lang, *task))
+	}
+	return nil
+}
+
+// finishBreakdown replaces the task with the steps listed in the message, one per line.
+func (b *Bot) finishBreakdown(ctx context.Context, msg *tgbotapi.Message, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	var titles []string
+	for _, line := range strings.Split(msg.Text, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(line, "-•* ")); line != "" {
+			titles = append(titles, line)
+		}
+	}
+	if len(titles) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Напиши хотя бы один шаг."))
+	}
+	b.clearConversation(msg.From.ID)
+
+	owner := CurrentUser(ctx)
+	user := personalUser(owner)
+	subtasks, err := b.taskSvc.BreakDown(ctx, user, taskID, titles)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена или уже удалена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Задача уже выполнена."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось разбить задачу: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] task broken down id=%d user=%d steps=%d", taskID, user.ID, len(subtasks))
+	var builder strings.Builder
+	builder.WriteString(lang.T("✂️ <b>Задача разбита на шаги</b>\n"))
+	for _, subtask := range subtasks {
+		builder.WriteString(fmt.Sprintf("• <b>#%d</b> %s\n", subtask.ID, escape(subtask.Title)))
+	}
+	return b.sendText(ctx, msg.Chat.ID, strings.TrimSpace(builder.String()))
+}
+
+func delegationText(lang i18n.Lang, task model.Task) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📌 <b>%s</b>\n", escape(normalizeTitle(task.Title))))
+	if task.Description != "" {
+		builder.WriteString(escape(task.Description) + "\n")
+	}
+	if task.Deadline != nil {
+		builder.WriteString(lang.Tf("Срок: %s\n", task.Deadline.Format("02.01.2006")))
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// personalUser returns a copy of the user with the personal scope active.
+func personalUser(user *model.User) *model.User {
+	personal := *user
+	personal.ActiveWorkspaceID = 0
+	return &personal
+}
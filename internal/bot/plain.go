@@ -0,0 +1,189 @@
+package bot
+
+import (
+	"context"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+// handlePlain shows or switches plain text mode: /plain on|off. In it messages come without
+// emojis and formatting, which screen readers read out and some clients garble.
+func (b *Bot) handlePlain(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	var on bool
+	switch strings.ToLower(strings.TrimSpace(msg.CommandArguments())) {
+	case "":
+		state := lang.T("выключен")
+		if user.PlainText {
+			state = lang.T("включён")
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🔤 Простой текст %s. В нём сообщения приходят без эмодзи и оформления, а значки заменены словами — так их удобнее слушать с экранным диктором.\nВключить: /plain on, выключить: /plain off", state))
+	case "on":
+		on = true
+	case "off":
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /plain on или /plain off"))
+	}
+	if err := b.userRepo.SetPlainText(ctx, user, on); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] plain text user=%d on=%t", user.ID, on)
+	if on {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🔤 Простой текст включён: дальше сообщения будут без эмодзи и оформления."))
+	}
+	return b.sendText(ctx, msg.Chat.ID, lang.T("🔤 Простой текст выключен."))
+}
+
+type readerKey struct{}
+
+// withReader prepares ctx for messages to user outside of their updates, such as scheduled
+// reports: in their language and, when they chose it, in plain text.
+func withReader(ctx context.Context, user model.User) context.Context {
+	return context.WithValue(i18n.WithLang(ctx, user.Lang()), readerKey{}, &user)
+}
+
+// plainReader tells whether chatID is the private chat of a user who reads plain text.
+func plainReader(ctx context.Context, chatID int64) bool {
+	if reader, ok := ctx.Value(readerKey{}).(*model.User); ok {
+		return reader.PlainText && reader.TelegramID == chatID
+	}
+	if current, ok := ctx.Value(updateUserKey{}).(*updateUser); ok {
+		return current.self.PlainText && current.self.TelegramID == chatID
+	}
+	return false
+}
+
+// send is how messages leave the bot: for a reader of plain text it renders them plain first.
+func (b *Bot) send(ctx context.Context, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return b.api.Send(renderFor(ctx, c))
+}
+
+// renderFor swaps the text, caption and inline buttons of c for their plain renderings when
+// its chat reads plain text. Reply keyboards are kept, since their labels come back as text.
+func renderFor(ctx context.Context, c tgbotapi.Chattable) tgbotapi.Chattable {
+	lang := i18n.FromContext(ctx)
+	switch m := c.(type) {
+	case tgbotapi.MessageConfig:
+		if plainReader(ctx, m.ChatID) {
+			m.Text = plainText(lang, m.Text, m.ParseMode == tgbotapi.ModeHTML)
+			m.ParseMode, m.Entities = "", nil
+			m.ReplyMarkup = plainMarkup(lang, m.ReplyMarkup)
+		}
+		return m
+	case tgbotapi.EditMessageTextConfig:
+		if plainReader(ctx, m.ChatID) {
+			m.Text = plainText(lang, m.Text, m.ParseMode == tgbotapi.ModeHTML)
+			m.ParseMode, m.Entities = "", nil
+			if m.ReplyMarkup != nil {
+				markup := plainMarkup(lang, *m.ReplyMarkup).(tgbotapi.InlineKeyboardMarkup)
+				m.ReplyMarkup = &markup
+			}
+		}
+		return m
+	case tgbotapi.PhotoConfig:
+		if plainReader(ctx, m.ChatID) {
+			m.Caption = plainText(lang, m.Caption, m.ParseMode == tgbotapi.ModeHTML)
+			m.ParseMode, m.CaptionEntities = "", nil
+		}
+		return m
+	case tgbotapi.DocumentConfig:
+		if plainReader(ctx, m.ChatID) {
+			m.Caption = plainText(lang, m.Caption, m.ParseMode == tgbotapi.ModeHTML)
+			m.ParseMode, m.CaptionEntities = "", nil
+		}
+		return m
+	}
+	return c
+}
+
+func plainMarkup(lang i18n.Lang, markup interface{}) interface{} {
+	keyboard, ok := markup.(tgbotapi.InlineKeyboardMarkup)
+	if !ok {
+		return markup
+	}
+	rows := make([][]tgbotapi.InlineKeyboardButton, len(keyboard.InlineKeyboard))
+	for i, row := range keyboard.InlineKeyboard {
+		rows[i] = make([]tgbotapi.InlineKeyboardButton, len(row))
+		for j, button := range row {
+			// A button that is nothing but an emoji keeps it: without it there is no label.
+			if label := plainText(lang, button.Text, false); label != "" {
+				button.Text = label
+			}
+			rows[i][j] = button
+		}
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// emojiLabels are the emojis that carry meaning of their own, said in words in plain text.
+var emojiLabels = []struct{ emoji, label string }{
+	{"‼️", i18n.N("Срочно:")},
+	{"❗", i18n.N("Важно:")},
+	{"🔽", i18n.N("Не срочно:")},
+	{"⚠️", i18n.N("Внимание:")},
+	{"⏰", i18n.N("Срок:")},
+	{"📝", i18n.N("Описание:")},
+}
+
+var (
+	htmlLink = regexp.MustCompile(`<a href="([^"]*)">(.*?)</a>`)
+	htmlTag  = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	spaces   = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// plainText renders a message without emojis and, when it is HTML, without markup: links
+// become "text (address)" and entities are unescaped. The emojis of emojiLabels are
+// replaced with their labels.
+func plainText(lang i18n.Lang, text string, isHTML bool) string {
+	if isHTML {
+		text = htmlLink.ReplaceAllStringFunc(text, func(link string) string {
+			parts := htmlLink.FindStringSubmatch(link)
+			href, label := html.UnescapeString(parts[1]), parts[2]
+			if strings.HasPrefix(href, "tg://") || html.UnescapeString(label) == href {
+				return label
+			}
+			return label + " (" + href + ")"
+		})
+		text = html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+	}
+	for _, known := range emojiLabels {
+		text = strings.ReplaceAll(text, known.emoji, lang.T(known.label)+" ")
+	}
+	text = strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// isEmoji tells the runes that make up emojis: pictographs, symbols and dingbats, flags,
+// skin tones, and the joiners and selectors that glue them together.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, flags, skin tones
+		r >= 0x2600 && r <= 0x27BF,                // symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF,                // arrows and squares such as ⬜ and ⭐
+		r >= 0x2300 && r <= 0x23FF,                // ⌚, ⏰, ⏳ and the media buttons
+		r >= 0x25A0 && r <= 0x25FF && r != 0x25CF, // squares and triangles, but the ● bullet
+		r == 0x200D, r == 0x20E3, r >= 0xFE00 && r <= 0xFE0F: // joiner, keycap and variation selectors
+		return true
+	}
+	return false
+}
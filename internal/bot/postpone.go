@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// Postpone buttons: "postpone:<id>" offers the choices, "postpone:<id>:<days>" moves the
+// deadline and "postpone:<id>:pick" opens the calendar.
+const (
+	cbPostponePrefix = "postpone:"
+	postponePick     = "pick"
+)
+
+const postponeUsage = "Формат: /postpone 12 1d — на день, /postpone 12 2w — на две недели, /postpone 12 2025-11-30 — на дату"
+
+// handlePostpone moves the deadline of a task: /postpone <id> <duration or date>.
+func (b *Bot) handlePostpone(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(postponeUsage))
+	}
+	taskID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(postponeUsage))
+	}
+	user := CurrentUser(ctx)
+	now := time.Now().In(user.Location())
+	deadline, ok := b.postponeTarget(ctx, user, uint(taskID), strings.Join(args[1:], " "), now)
+	if !ok {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(postponeUsage))
+	}
+	return b.postpone(ctx, msg.Chat.ID, user, uint(taskID), deadline, now)
+}
+
+// postponeTarget turns a duration like "1d", "+2w" or "3 дня" into the new deadline of the
+// task, or reads an explicit date.
+func (b *Bot) postponeTarget(ctx context.Context, user *model.User, taskID uint, value string, now time.Time) (time.Time, bool) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true
+	}
+	days, ok := parsePostponeDays(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		// Let Postpone report the missing task.
+		return now, true
+	}
+	return service.PostponedDeadline(*task, days, now), true
+}
+
+// parsePostponeDays reads "1d", "+2w", "3д", "1 день" or "2 недели" as a number of days.
+func parsePostponeDays(value string) (int, bool) {
+	value = strings.ToLower(strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(value), "+")), ""))
+	amount, unit, ok := splitAmount(value)
+	if !ok {
+		return 0, false
+	}
+	switch unit {
+	case "d", "д", "день", "дня", "дней", "day", "days":
+		return amount, true
+	case "w", "н", "нед", "неделя", "недели", "недель", "неделю", "week", "weeks":
+		return amount * 7, true
+	}
+	return 0, false
+}
+
+// postpone moves the deadline and reports the new one.
+func (b *Bot) postpone(ctx context.Context, chatID int64, user *model.User, taskID uint, deadline, now time.Time) error {
+	lang := i18n.FromContext(ctx)
+	task, err := b.taskSvc.Postpone(ctx, user, taskID, deadline, now)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, chatID, lang.T("Задача уже выполнена."))
+	case errors.Is(err, service.ErrPostponeRecurring):
+		return b.sendText(ctx, chatID, lang.T("Регулярную задачу нельзя отложить: её сроки задаёт расписание."))
+	case errors.Is(err, service.ErrPostponeToPast):
+		return b.sendText(ctx, chatID, lang.T("Эта дата уже прошла — выбери день не раньше сегодняшнего."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось отложить задачу: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task postponed id=%d user=%d deadline=%s", task.ID, user.ID, deadline.Format("2006-01-02"))
+	return b.sendTransient(ctx, chatID, lang.Tf("⏰ Задача «%s» отложена: новый дедлайн %s.", escape(normalizeTitle(task.Title)), task.Deadline.Format("2006-01-02")), nil)
+}
+
+// postponeButtons moves the deadline of an overdue task by a day or a week, or to a picked date.
+func postponeButtons(lang i18n.Lang, taskID uint) []tgbotapi.InlineKeyboardButton {
+	button := func(label, action string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d:%s", cbPostponePrefix, taskID, action))
+	}
+	return tgbotapi.NewInlineKeyboardRow(
+		button(lang.T("⏰ +1 день"), "1"),
+		button(lang.T("+1 неделя"), "7"),
+		button(lang.T("📅 Выбрать дату"), postponePick),
+	)
+}
+
+// handlePostponeButton offers the postpone choices or applies the chosen one.
+func (b *Bot) handlePostponeButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	rawID, action, _ := strings.Cut(payload, ":")
+	taskID, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil
+	}
+	chatID := cb.Message.Chat.ID
+	switch action {
+	case "":
+		return b.sendWithReplyMarkup(ctx, chatID, lang.T("⏰ На сколько отложить задачу?"), tgbotapi.NewInlineKeyboardMarkup(postponeButtons(lang, uint(taskID))))
+	case postponePick:
+		b.setConversation(cb.From.ID, &conversationState{stage: stagePostpone, taskID: uint(taskID)})
+		return b.sendDatePicker(ctx, chatID, lang.T("📅 Выбери новый дедлайн в календаре или напиши дату вроде <code>2025-11-30</code>."), "")
+	}
+	days, err := strconv.Atoi(action)
+	if err != nil || days <= 0 {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	now := time.Now().In(user.Location())
+	deadline, _ := b.postponeTarget(ctx, user, uint(taskID), fmt.Sprintf("%dd", days), now)
+	return b.postpone(ctx, chatID, user, uint(taskID), deadline, now)
+}
+
+// finishPostpone takes the date picked or typed for the postponed task.
+func (b *Bot) finishPostpone(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	now := time.Now().In(user.Location())
+	deadline, ok := b.postponeTarget(ctx, user, state.taskID, strings.TrimSpace(msg.Text), now)
+	if !ok {
+		return b.sendDatePicker(ctx, msg.Chat.ID, lang.T("Не могу распознать дату. Выбери день в календаре или напиши его как <code>2025-11-30</code>."), "")
+	}
+	b.clearConversation(msg.From.ID)
+	return b.postpone(ctx, msg.Chat.ID, user, state.taskID, deadline, now)
+}
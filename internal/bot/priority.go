@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	btnPriorityUrgent = "‼️ Срочно"
+	btnPriorityHigh   = "❗ Высокий"
+	btnPriorityNormal = "▫️ Обычный"
+	btnPriorityLow    = "🔽 Низкий"
+)
+
+const priorityPrompt = "❗ Какой приоритет у задачи? «Пропустить» оставит обычный."
+
+// priorityButtons maps the keyboard labels to priorities.
+var priorityButtons = map[string]string{
+	btnPriorityUrgent: model.PriorityUrgent,
+	btnPriorityHigh:   model.PriorityHigh,
+	btnPriorityNormal: model.PriorityNormal,
+	btnPriorityLow:    model.PriorityLow,
+}
+
+// parsePriority accepts a priority button or a priority name in Russian or English.
+func parsePriority(text string) (string, bool) {
+	for label, priority := range priorityButtons {
+		if i18n.Matches(strings.TrimSpace(text), label) {
+			return priority, true
+		}
+	}
+	return service.ParsePriorityName(text)
+}
+
+// priorityLabel names the priority for task cards.
+func priorityLabel(lang i18n.Lang, priority string) string {
+	switch priority {
+	case model.PriorityUrgent:
+		return lang.T(btnPriorityUrgent)
+	case model.PriorityHigh:
+		return lang.T(btnPriorityHigh)
+	case model.PriorityLow:
+		return lang.T(btnPriorityLow)
+	default:
+		return lang.T(btnPriorityNormal)
+	}
+}
+
+// priorityKeyboard offers the priorities, plus "skip" while a task is being created.
+func priorityKeyboard(lang i18n.Lang, skip bool) tgbotapi.ReplyKeyboardMarkup {
+	last := tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)))
+	if skip {
+		last = tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(lang.T(btnSkip)), tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)))
+	}
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnPriorityUrgent)),
+			tgbotapi.NewKeyboardButton(lang.T(btnPriorityHigh)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnPriorityNormal)),
+			tgbotapi.NewKeyboardButton(lang.T(btnPriorityLow)),
+		),
+		last,
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+const addUsage = "Формат: /add Купить молоко #покупки !high @завтра\n" +
+	"• #категория (пробелы — через _)\n" +
+	"• !urgent, !high, !low или !срочно, !высокий, !низкий\n" +
+	"• @сегодня, @завтра, @послезавтра, @пн…@вс, @30.11 или @2025-11-30"
+
+// handleAdd creates a task from one line without the /newtask dialog.
+func (b *Bot) handleAdd(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	input, err := service.ParseQuickAdd(msg.CommandArguments(), time.Now().In(user.Location()))
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, quickAddError(lang, err))
+	}
+	log.Printf("[info] quick add user=%d category=%q priority=%s", user.ID, input.Category, input.Priority)
+	return b.finishTaskCreation(ctx, msg.From, input, nil, nil, msg.Chat.ID)
+}
+
+func quickAddError(lang i18n.Lang, err error) string {
+	var token string
+	var parseErr *service.QuickAddError
+	if errors.As(err, &parseErr) {
+		token = escape(parseErr.Token)
+	}
+	switch {
+	case errors.Is(err, service.ErrQuickAddTitle):
+		return lang.T("Не вижу названия задачи.\n") + lang.T(addUsage)
+	case errors.Is(err, service.ErrQuickAddPriority):
+		return lang.Tf("Не знаю такого приоритета (%s).\n%s", token, lang.T(addUsage))
+	case errors.Is(err, service.ErrQuickAddDeadline):
+		return lang.Tf("Не понял срок (%s).\n%s", token, lang.T(addUsage))
+	case errors.Is(err, service.ErrQuickAddRepeated):
+		return lang.Tf("Категория, приоритет и срок указываются по одному разу (%s).", token)
+	default:
+		return escape(err.Error())
+	}
+}
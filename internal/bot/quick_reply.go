@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const emojiUsage = "Формат:\n" +
+	"• /emoji ✅ done — эмодзи отмечает последнюю показанную задачу\n" +
+	"• /emoji 📋 list — открывает список задач\n" +
+	"• /emoji ➕ new — начинает новую задачу\n" +
+	"• /emoji ✅ off — убрать эмодзи, /emoji reset — вернуть стандартные"
+
+var quickActionLabels = map[string]string{
+	service.QuickComplete: i18n.N("отметить последнюю показанную задачу"),
+	service.QuickList:     i18n.N("список задач"),
+	service.QuickNew:      i18n.N("новая задача"),
+}
+
+// inDialog reports whether the user is answering a wizard or confirmation,
+// where an emoji is input rather than a quick reply.
+func (b *Bot) inDialog(userID int64) bool {
+	if b.hasConversation(userID) {
+		return true
+	}
+	_, pending := b.getConfirmation(userID)
+	return pending
+}
+
+// handleQuickReply runs the action bound to a message that is a single emoji.
+func (b *Bot) handleQuickReply(ctx context.Context, msg *tgbotapi.Message) (bool, error) {
+	emoji := service.NormalizeQuickReply(msg.Text)
+	if !service.IsStandaloneEmoji(emoji) {
+		return false, nil
+	}
+	user, err := b.ensureUser(ctx, msg.From)
+	if err != nil {
+		return true, err
+	}
+	switch service.UserQuickReplies(*user)[emoji] {
+	case service.QuickComplete:
+		return true, b.completeLastShown(ctx, msg.Chat.ID, user)
+	case service.QuickList:
+		return true, b.sendTaskList(ctx, msg.Chat.ID, user)
+	case service.QuickNew:
+		return true, b.startNewTask(ctx, msg.Chat.ID, msg.From)
+	default:
+		return false, nil
+	}
+}
+
+func (b *Bot) completeLastShown(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	task, err := b.taskSvc.CompleteLastShown(ctx, user, chatID, time.Now())
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, chatID, lang.T("Не знаю, какую задачу отметить. Открой её через /task &lt;id&gt; и пришли ✅ ещё раз."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, chatID, lang.Tf("Задача «%s» уже выполнена.", escape(normalizeTitle(task.Title))))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось отметить задачу: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task completed by quick reply id=%d user=%d", task.ID, user.ID)
+	if task.IsRecurring {
+		return b.sendTransient(ctx, chatID, lang.Tf("♻️ Задача «%s» отмечена выполненной в этом окне.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+	}
+	return b.sendTransient(ctx, chatID, lang.Tf("✅ Задача «%s» выполнена.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+}
+
+// handleEmoji shows and changes the user's quick reply emoji.
+func (b *Bot) handleEmoji(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	replies := service.UserQuickReplies(*user)
+	switch {
+	case len(args) == 0:
+		return b.sendText(ctx, msg.Chat.ID, formatQuickReplies(lang, replies))
+	case len(args) == 1 && args[0] == "reset":
+		if err := b.userRepo.SetQuickReplies(ctx, user, ""); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить: %s", errorText(lang, err)))
+		}
+		return b.sendText(ctx, msg.Chat.ID, formatQuickReplies(lang, service.UserQuickReplies(*user)))
+	case len(args) != 2:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(emojiUsage))
+	}
+
+	action := args[1]
+	if action == "off" {
+		action = ""
+	}
+	if err := service.SetQuickReply(replies, args[0], action); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(emojiUsage))
+	}
+	if err := b.userRepo.SetQuickReplies(ctx, user, service.EncodeQuickReplies(replies)); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] quick replies user=%d value=%q", user.ID, user.QuickReplies)
+	return b.sendText(ctx, msg.Chat.ID, formatQuickReplies(lang, replies))
+}
+
+func formatQuickReplies(lang i18n.Lang, replies map[string]string) string {
+	if len(replies) == 0 {
+		return lang.T("⚡️ Быстрые ответы выключены. Вернуть стандартные: /emoji reset")
+	}
+	emojis := make([]string, 0, len(replies))
+	for emoji := range replies {
+		emojis = append(emojis, emoji)
+	}
+	sort.Strings(emojis)
+	var builder strings.Builder
+	builder.WriteString(lang.T("⚡️ <b>Быстрые ответы</b> — пришли один эмодзи:\n"))
+	for _, emoji := range emojis {
+		builder.WriteString(fmt.Sprintf("• %s — %s\n", emoji, lang.T(quickActionLabels[replies[emoji]])))
+	}
+	builder.WriteString(lang.T("Изменить: /emoji &lt;эмодзи&gt; done|list|new|off"))
+	return builder.String()
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+// handleQuota shows the user's limits; admins can lift them with /quota <telegram_id> off|on.
+func (b *Bot) handleQuota(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		user := CurrentUser(ctx)
+		usage, err := b.quotaSvc.Usage(ctx, user)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить лимиты: %s", errorText(lang, err)))
+		}
+		return b.sendText(ctx, msg.Chat.ID, formatQuotaUsage(lang, usage))
+	}
+
+	admin, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 || (args[1] != "off" && args[1] != "on") {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /quota &lt;telegram_id&gt; off|on"))
+	}
+	telegramID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /quota &lt;telegram_id&gt; off|on"))
+	}
+
+	target, err := b.quotaSvc.SetExempt(ctx, admin, telegramID, args[1] == "off")
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Эта команда доступна только администраторам."))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Пользователь с таким Telegram ID ещё не пользовался ботом."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось изменить лимиты: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] quota exemption admin=%d user=%d exempt=%t", admin.ID, target.ID, target.QuotaExempt)
+	if target.QuotaExempt {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🔓 Лимиты для %s сняты.", escape(memberName(*target))))
+	}
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("🔒 Лимиты для %s снова действуют.", escape(memberName(*target))))
+}
+
+func formatQuotaUsage(lang i18n.Lang, usage service.QuotaUsage) string {
+	if usage.Exempt {
+		return lang.T("🔓 Для тебя лимиты не действуют.")
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("📏 <b>Лимиты</b>\n"))
+	builder.WriteString(lang.Tf("• Активные задачи: %d из %s\n", usage.ActiveTasks, limitLabel(int64(usage.Limits.MaxActiveTasks))))
+	builder.WriteString(lang.Tf("• Категории: %d из %s\n", usage.Categories, limitLabel(int64(usage.Limits.MaxCategories))))
+	builder.WriteString(lang.Tf("• Размер файла: до %s", sizeLabel(lang, usage.Limits.MaxAttachmentBytes)))
+	return builder.String()
+}
+
+func quotaText(lang i18n.Lang, err *service.QuotaError) string {
+	switch err.Resource {
+	case service.QuotaTasks:
+		return lang.Tf("достигнут лимит активных задач (%d). Заверши или удали старые задачи", err.Limit)
+	case service.QuotaCategories:
+		return lang.Tf("достигнут лимит категорий (%d). Выбери одну из существующих", err.Limit)
+	case service.QuotaAttachment:
+		return lang.Tf("файл больше допустимых %s", sizeLabel(lang, err.Limit))
+	default:
+		return escape(err.Error())
+	}
+}
+
+func limitLabel(limit int64) string {
+	if limit <= 0 {
+		return "∞"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+func sizeLabel(lang i18n.Lang, bytes int64) string {
+	if bytes <= 0 {
+		return "∞"
+	}
+	if bytes >= 1<<20 {
+		return lang.Tf("%d МБ", bytes>>20)
+	}
+	return lang.Tf("%d КБ", bytes>>10)
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// Reactions understood on tracked task messages. ✅ completes tasks as well, although
+// Telegram offers it only to clients that can send it.
+const (
+	completeReaction    = "👍"
+	completeAltReaction = "✅"
+	snoozeReaction      = "😴"
+)
+
+// handleReaction completes a task when 👍 or ✅ is put on its task card or deadline alert
+// and snoozes the alert on 😴.
+func (b *Bot) handleReaction(ctx context.Context, reaction *messageReactionUpdated) error {
+	lang := i18n.FromContext(ctx)
+	if reaction.Chat == nil {
+		return nil
+	}
+	complete := addedEmoji(reaction, completeReaction) || addedEmoji(reaction, completeAltReaction)
+	if !complete && !addedEmoji(reaction, snoozeReaction) {
+		return nil
+	}
+	user, err := b.ensureUser(ctx, reaction.User)
+	if err != nil {
+		return err
+	}
+	if !complete {
+		return b.snoozeByReaction(ctx, user, reaction)
+	}
+
+	task, err := b.taskSvc.CompleteByMessage(ctx, user, reaction.Chat.ID, reaction.MessageID, time.Now())
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Not a task card or the task is gone; reactions are often just reactions.
+		return nil
+	case errors.Is(err, service.ErrTaskCompleted):
+		return nil
+	case err != nil:
+		return b.sendText(ctx, reaction.Chat.ID, lang.Tf("Не удалось отметить задачу: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] task completed by reaction id=%d user=%d", task.ID, user.ID)
+	if task.IsRecurring {
+		return b.sendTransient(ctx, reaction.Chat.ID, lang.Tf("♻️ Задача «%s» отмечена выполненной в этом окне.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+	}
+	return b.sendTransient(ctx, reaction.Chat.ID, lang.Tf("✅ Задача «%s» выполнена.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+}
+
+func (b *Bot) snoozeByReaction(ctx context.Context, user *model.User, reaction *messageReactionUpdated) error {
+	lang := i18n.FromContext(ctx)
+	now := time.Now()
+	task, err := b.taskSvc.SnoozeByMessage(ctx, user, reaction.Chat.ID, reaction.MessageID, now, now.Add(snoozeDuration))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, reaction.Chat.ID, lang.T("Задача уже выполнена, напоминать не о чем."))
+	case err != nil:
+		return b.sendText(ctx, reaction.Chat.ID, lang.Tf("Не удалось отложить напоминание: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] deadline alert snoozed id=%d user=%d until=%s", task.ID, user.ID, task.SnoozedUntil.Format(time.RFC3339))
+	return b.sendText(ctx, reaction.Chat.ID, lang.Tf("😴 Напомню о «%s» в %s.", escape(normalizeTitle(task.Title)), task.SnoozedUntil.Format("15:04")))
+}
+
+// addedEmoji reports whether the emoji was just added rather than already present.
+func addedEmoji(reaction *messageReactionUpdated, emoji string) bool {
+	return hasEmoji(reaction.NewReaction, emoji) && !hasEmoji(reaction.OldReaction, emoji)
+}
+
+func hasEmoji(reactions []reactionType, emoji string) bool {
+	for _, reaction := range reactions {
+		if reaction.Type == "emoji" && reaction.Emoji == emoji {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	btnDaily    = "☀️ Каждый день"
+	btnInterval = "🔢 Раз в N дней"
+	btnWeekly   = "📅 Каждую неделю"
+	btnMonthly  = "🗓 Каждый месяц"
+	btnQuarter  = "🗓 Раз в квартал"
+	btnHalfYear = "🗓 Раз в полгода"
+)
+
+var monthsPattern = regexp.MustCompile(`^(?:(?:каждые|раз в) (\d+) (?:месяца|месяцев)|every (\d+) months)(?: (?:от|с) (?:даты )?первого выполнения| from the first completion)?$`)
+
+// weekdayNames are indexed by time.Weekday: short form, full name and "по …" form.
+var weekdayNames = [7][3]string{
+	{i18n.N("вс"), i18n.N("воскресенье"), i18n.N("воскресеньям")},
+	{i18n.N("пн"), i18n.N("понедельник"), i18n.N("понедельникам")},
+	{i18n.N("вт"), i18n.N("вторник"), i18n.N("вторникам")},
+	{i18n.N("ср"), i18n.N("среда"), i18n.N("средам")},
+	{i18n.N("чт"), i18n.N("четверг"), i18n.N("четвергам")},
+	{i18n.N("пт"), i18n.N("пятница"), i18n.N("пятницам")},
+	{i18n.N("сб"), i18n.N("суббота"), i18n.N("субботам")},
+}
+
+const weekdaysPrompt = "📆 По каким дням недели повторять? Отметь их кнопками и нажми «Готово» или напиши, например, «пн, чт»."
+
+// weekdaysDone is the weekday picker payload that ends the choice of days.
+const weekdaysDone = "done"
+
+// parseWeekday accepts a short, full or plural weekday name in any case, in English also
+// abbreviated like "mon" or "wed".
+func parseWeekday(text string) (time.Weekday, bool) {
+	value := strings.TrimSpace(strings.ToLower(text))
+	for day, names := range weekdayNames {
+		if i18n.Matches(value, names[0]) || i18n.Matches(value, names[1]) || i18n.Matches(value, names[2]) || value == "в "+names[1] ||
+			len(value) >= 3 && strings.HasPrefix(strings.ToLower(i18n.EN.T(names[1])), value) {
+			return time.Weekday(day), true
+		}
+	}
+	return 0, false
+}
+
+// parseWeekdays reads a list of weekdays such as "пн, чт" or "каждый понедельник и четверг"
+// into a model.WeekdaySet.
+func parseWeekdays(text string) (int, bool) {
+	set := 0
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	})
+	for _, word := range words {
+		switch word {
+		case "и", "по", "в", "каждый", "каждую", "каждое", "and", "on", "every":
+			continue
+		}
+		day, ok := parseWeekday(word)
+		if !ok {
+			return 0, false
+		}
+		set |= model.WeekdaySet(day)
+	}
+	return set, set != 0
+}
+
+// joinAnd lists items as "a, b и c".
+func joinAnd(lang i18n.Lang, items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + lang.T(" и ") + items[len(items)-1]
+}
+
+// recurrenceText describes how a recurring task repeats, e.g. "каждую неделю по средам".
+func recurrenceText(lang i18n.Lang, task model.Task) string {
+	switch task.RecurType {
+	case model.RecurDaily:
+		if task.RecurInterval > 1 {
+			return lang.Tf("раз в %d дн.", task.RecurInterval)
+		}
+		return lang.T("каждый день")
+	case model.RecurWeekly:
+		var days []string
+		for _, day := range task.Weekdays() {
+			days = append(days, lang.T(weekdayNames[day][2]))
+		}
+		return lang.Tf("каждую неделю по %s", joinAnd(lang, days))
+	default:
+		if months := task.RecurMonths(); months > 0 {
+			if task.RecurFrom == nil {
+				return lang.Tf("%s от первого выполнения", monthsText(lang, months))
+			}
+			return lang.Tf("%s, считая с %s", monthsText(lang, months), task.RecurFrom.Format("2006-01-02"))
+		}
+		return lang.Tf("каждый месяц %d числа", task.RecurDay)
+	}
+}
+
+// monthsText names a repeat every few months: "раз в квартал", "раз в полгода" or "раз в 4 мес.".
+func monthsText(lang i18n.Lang, months int) string {
+	switch months {
+	case service.QuarterlyMonths:
+		return lang.T("раз в квартал")
+	case service.HalfYearlyMonths:
+		return lang.T("раз в полгода")
+	default:
+		return lang.Tf("раз в %d мес.", months)
+	}
+}
+
+// frequencyLabel names how often a task repeats, for list headings.
+func frequencyLabel(lang i18n.Lang, task model.Task) string {
+	switch task.RecurType {
+	case model.RecurDaily:
+		return normalizeTitle(recurrenceText(lang, task))
+	case model.RecurWeekly:
+		return lang.T("Каждую неделю")
+	default:
+		if months := task.RecurMonths(); months > 0 {
+			return normalizeTitle(monthsText(lang, months))
+		}
+		return lang.T("Каждый месяц")
+	}
+}
+
+// windowPrompt asks for the completion window, whose limit depends on the recurrence.
+func windowPrompt(lang i18n.Lang, input service.TaskInput) string {
+	switch input.RecurType {
+	case model.RecurDaily:
+		return lang.Tf("⏳ Сколько дней до/после дня повтора считать окном выполнения? (0–%d)", service.MaxWindow(input))
+	case model.RecurWeekly:
+		return lang.Tf("⏳ Сколько дней до/после дня недели считать окном выполнения? (0–%d)", service.MaxWindow(input))
+	default:
+		if input.RecurInterval > 1 {
+			return lang.Tf("⏳ Повторы считаются от первого выполнения. Сколько дней до/после даты повтора считать окном выполнения? (0–%d)", service.MaxMonthlyWindow)
+		}
+		return lang.T("⏳ Сколько дней до/после даты считать окном выполнения? (например, 2)")
+	}
+}
+
+func frequencyKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnDaily)),
+			tgbotapi.NewKeyboardButton(lang.T(btnInterval)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnWeekly)),
+			tgbotapi.NewKeyboardButton(lang.T(btnMonthly)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnQuarter)),
+			tgbotapi.NewKeyboardButton(lang.T(btnHalfYear)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog)),
+		),
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return kb
+}
+
+// weekdayPicker toggles the days of a weekly task, Monday first, the chosen ones ticked.
+func weekdayPicker(lang i18n.Lang, set int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for i := 1; i <= 7; i++ {
+		day := i % 7
+		label := lang.T(weekdayNames[day][0])
+		if set&model.WeekdaySet(time.Weekday(day)) != 0 {
+			label = "✓" + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d", cbWeekdaysPrefix, day)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T(btnDone), cbWeekdaysPrefix+weekdaysDone),
+	))
+}
+
+// handleWeekdayPicker ticks or unticks a day of the weekly task being created and goes on
+// to the window once the days are chosen.
+func (b *Bot) handleWeekdayPicker(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	chatID, messageID := cb.Message.Chat.ID, cb.Message.MessageID
+	state := b.getConversation(cb.From.ID)
+	if state == nil || state.stage != stageRecurringWeekday {
+		_, err := b.api.Request(tgbotapi.NewCallback(cb.ID, lang.T("Этот выбор дней уже неактуален.")))
+		return err
+	}
+	if payload == weekdaysDone {
+		if state.input.RecurWeekdays == 0 {
+			_, err := b.api.Request(tgbotapi.NewCallback(cb.ID, lang.T("Отметь хотя бы один день.")))
+			return err
+		}
+		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
+			log.Printf("callback ack: %v", err)
+		}
+		if _, err := b.api.Request(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})); err != nil {
+			log.Printf("remove weekday picker: %v", err)
+		}
+		return b.askRecurringWindow(ctx, chatID, state)
+	}
+	if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, "")); err != nil {
+		log.Printf("callback ack: %v", err)
+	}
+	day, err := strconv.Atoi(payload)
+	if err != nil || day < 0 || day > 6 {
+		return nil
+	}
+	state.input.RecurWeekdays ^= model.WeekdaySet(time.Weekday(day))
+	_, err = b.api.Request(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, weekdayPicker(lang, state.input.RecurWeekdays)))
+	return err
+}
+
+// caughtUp reports whether completing the recurring task at now closed a missed occurrence
+// rather than the current one.
+func caughtUp(task model.Task, now time.Time) bool {
+	return task.IsRecurring && task.CaughtUpTo != nil && (task.LastCompletedAt == nil || !task.LastCompletedAt.Equal(now))
+}
+
+// caughtUpText confirms that the missed occurrence due on due was done late.
+func caughtUpText(lang i18n.Lang, title string, due time.Time) string {
+	return lang.Tf("✅ Пропущенный повтор «%s» от %s отмечен выполненным.", title, due.Format("2006-01-02"))
+}
+
+// parseFrequency maps the frequency answer to a recurrence type. Daily answers
+// report whether the interval still has to be asked.
+func parseFrequency(text string) (recurType string, askInterval, ok bool) {
+	value := strings.TrimSpace(strings.ToLower(text))
+	switch {
+	case i18n.Matches(value, btnDaily), value == "каждый день", value == "ежедневно", value == "daily":
+		return model.RecurDaily, false, true
+	case i18n.Matches(value, btnInterval), value == "раз в n дней":
+		return model.RecurDaily, true, true
+	case i18n.Matches(value, btnWeekly), value == "каждую неделю", value == "неделя", value == "еженедельно", value == "weekly":
+		return model.RecurWeekly, false, true
+	case i18n.Matches(value, btnMonthly), value == "каждый месяц", value == "месяц", value == "ежемесячно", value == "monthly":
+		return model.RecurMonthly, false, true
+	default:
+		return "", false, false
+	}
+}
+
+// parseMonths reads a repeat every few months, such as the quarter and half-year
+// buttons or "каждые 3 месяца", into the number of months.
+func parseMonths(text string) (int, bool) {
+	value := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	switch {
+	case i18n.Matches(value, btnQuarter), value == "раз в квартал", value == "ежеквартально", value == "quarterly":
+		return service.QuarterlyMonths, true
+	case i18n.Matches(value, btnHalfYear), value == "раз в полгода", value == "каждые полгода", value == "every six months":
+		return service.HalfYearlyMonths, true
+	}
+	m := monthsPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, false
+	}
+	months, err := strconv.Atoi(m[1] + m[2])
+	if err != nil || months < 2 || months > service.MaxRecurMonths {
+		return 0, false
+	}
+	return months, true
+}
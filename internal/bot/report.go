@@ -0,0 +1,410 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+	"daily-planner/internal/tracing"
+)
+
+// registerReports wires up the daily report, its schedule and the buttons under it.
+func (b *Bot) registerReports(r *router) {
+	r.command("report", "прислать отчёт сейчас", b.handleReport)
+	r.command("interval", "как часто присылать отчёт", b.handleInterval)
+	r.command("delivery", "как присылать отчёт: сообщением, без звука или в закрепе", b.handleDelivery)
+	r.callback(callbackRoute{prefix: cbReportPrefix, handle: b.handleReportAction})
+	r.callback(callbackRoute{prefix: cbCategoryListPrefix, handle: b.handleCategoryList})
+}
+
+// Actions of the buttons under a daily report.
+const (
+	reportActionNew  = "new"
+	reportActionList = "list"
+	reportActionDone = "done"
+)
+
+// maxReportButtons caps the completion buttons under a report; longer reports
+// get "✅ Отметить выполненные" for the full list instead.
+const maxReportButtons = 8
+
+// maxReportCategories caps the category buttons under a report.
+const maxReportCategories = 6
+
+// reportKeyboard puts a completion button under each task the report lists, then
+// buttons opening the list of each category, and a footer that lets the user act
+// on the report right away.
+func reportKeyboard(lang i18n.Lang, report service.Report) tgbotapi.InlineKeyboardMarkup {
+	tasks := report.Tasks
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, task := range tasks {
+		if i == maxReportButtons {
+			break
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(completeButton(task, 28)))
+	}
+	var categoryRow []tgbotapi.InlineKeyboardButton
+	for i, category := range report.Categories {
+		if i == maxReportCategories {
+			break
+		}
+		label := model.Category{Name: category.Name, Emoji: category.Emoji, Color: category.Color}
+		if category.ID == 0 {
+			label = noCategoryItem(lang)
+		}
+		categoryRow = append(categoryRow, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%s · %d", shortTitle(html.UnescapeString(categoryLabel(label)), 18), category.Count), fmt.Sprintf("%s%d", cbCategoryListPrefix, category.ID)))
+		if len(categoryRow) == 2 {
+			rows = append(rows, categoryRow)
+			categoryRow = nil
+		}
+	}
+	if len(categoryRow) > 0 {
+		rows = append(rows, categoryRow)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("➕ Новая задача"), cbReportPrefix+reportActionNew),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("📋 Все задачи"), cbReportPrefix+reportActionList),
+	))
+	if len(tasks) > maxReportButtons {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Отметить выполненные"), cbReportPrefix+reportActionDone),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// sendReport sends a report with its buttons. In the recipient's private chat it arrives the
+// way they chose with /delivery: a new message, a silent one, or an edit of the pinned planner
+// message, which is sent and pinned anew when it is gone.
+func (b *Bot) sendReport(ctx context.Context, chatID int64, recipient *model.User, report service.Report) error {
+	lang := i18n.FromContext(ctx)
+	markup := reportKeyboard(lang, report)
+	delivery := model.ReportChat
+	if chatID == recipient.TelegramID {
+		delivery = recipient.ReportDelivery
+	}
+	if delivery == model.ReportPinned && recipient.ReportMessageID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, recipient.ReportMessageID, report.Text, markup)
+		edit.ParseMode = tgbotapi.ModeHTML
+		_, err := b.api.Request(renderFor(ctx, edit))
+		if err == nil || strings.Contains(err.Error(), "message is not modified") {
+			return nil
+		}
+		log.Printf("[info] planner message %d of %d not editable, sending a new one: %v", recipient.ReportMessageID, chatID, err)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, report.Text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = markup
+	msg.DisableNotification = delivery != model.ReportChat
+	sent, err := b.send(ctx, msg)
+	if err != nil || delivery != model.ReportPinned {
+		return err
+	}
+	pin := tgbotapi.PinChatMessageConfig{ChatID: chatID, MessageID: sent.MessageID, DisableNotification: true}
+	if _, err := b.api.Request(pin); err != nil {
+		log.Printf("pin planner message of %d: %v", chatID, err)
+	}
+	return b.userRepo.SetReportMessage(ctx, recipient, sent.MessageID)
+}
+
+const deliveryUsage = "Формат: /delivery chat — обычным сообщением, /delivery silent — без звука, " +
+	"/delivery pin — одним закреплённым сообщением, которое обновляется с каждым отчётом."
+
+// deliveryModes maps the /delivery arguments to the ways a report can arrive.
+var deliveryModes = map[string]string{
+	"chat":   model.ReportChat,
+	"silent": model.ReportSilent,
+	"pin":    model.ReportPinned,
+}
+
+// deliveryLabel names a way of delivering reports.
+func deliveryLabel(lang i18n.Lang, delivery string) string {
+	switch delivery {
+	case model.ReportSilent:
+		return lang.T("🔕 новым сообщением без звука")
+	case model.ReportPinned:
+		return lang.T("📌 в закреплённом сообщении, которое бот обновляет")
+	default:
+		return lang.T("🔔 новым сообщением")
+	}
+}
+
+// handleDelivery shows or changes how the sender's reports arrive: /delivery chat|silent|pin.
+// Switching to the pinned planner sends its first version right away.
+func (b *Bot) handleDelivery(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	if msg.From == nil {
+		return nil
+	}
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	args := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	if args == "" {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Отчёты приходят %s.\n%s", deliveryLabel(lang, user.ReportDelivery), lang.T(deliveryUsage)))
+	}
+	delivery, ok := deliveryModes[args]
+	if !ok {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(deliveryUsage))
+	}
+	if err := b.userRepo.SetReportDelivery(ctx, user, delivery); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] report delivery user=%d mode=%q", user.ID, delivery)
+	if err := b.sendText(ctx, msg.Chat.ID, lang.Tf("Готово, отчёты будут приходить %s.", deliveryLabel(lang, delivery))); err != nil {
+		return err
+	}
+	if delivery != model.ReportPinned || msg.Chat.ID != user.TelegramID {
+		return nil
+	}
+	owner, err := b.accountSvc.Owner(ctx, user)
+	if err != nil {
+		return err
+	}
+	report, err := b.reminderSvc.DailyReport(ctx, *owner, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сформировать отчёт: %s", errorText(lang, err)))
+	}
+	return b.sendReport(ctx, msg.Chat.ID, user, report)
+}
+
+// handleReportAction handles the footer buttons of a report.
+func (b *Bot) handleReportAction(ctx context.Context, cb *tgbotapi.CallbackQuery, action string) error {
+	chatID := cb.Message.Chat.ID
+	log.Printf("[info] report action user=%d action=%s", cb.From.ID, action)
+	switch action {
+	case reportActionNew:
+		return b.startNewTask(ctx, chatID, cb.From)
+	case reportActionList:
+		user := CurrentUser(ctx)
+		return b.sendTaskList(ctx, chatID, user)
+	case reportActionDone:
+		user := CurrentUser(ctx)
+		return b.sendCompletionPicker(ctx, chatID, personalUser(user))
+	default:
+		return nil
+	}
+}
+
+// handleCategoryList opens the personal task list of the category tapped under a report;
+// "catlist:0" lists the tasks without a category.
+func (b *Bot) handleCategoryList(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	categoryID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	log.Printf("[info] category list user=%d category=%d", user.ID, categoryID)
+	return b.sendTaskPage(ctx, cb.Message.Chat.ID, 0, user, categoryView(categoryID), 0)
+}
+
+// maxPickerTasks caps the completion buttons sent under one message.
+const maxPickerTasks = 20
+
+// sendCompletionPicker offers a completion button for every open task the report covers,
+// the nearest deadlines first.
+func (b *Bot) sendCompletionPicker(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	tasks, err := b.taskSvc.ListActive(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить задачи: %s", errorText(lang, err)))
+	}
+	open := tasks[:0]
+	for _, task := range tasks {
+		if task.IsRecurring || !task.IsCompleted {
+			open = append(open, task)
+		}
+	}
+	if len(open) == 0 {
+		return b.sendText(ctx, chatID, lang.T("Отмечать нечего — открытых задач нет 🎉"))
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		x, y := open[i].Deadline, open[j].Deadline
+		if x != nil && y != nil {
+			return x.Before(*y)
+		}
+		return x != nil
+	})
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, task := range open {
+		if i == maxPickerTasks {
+			break
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(completeButton(task, 28)))
+	}
+	text := lang.T("Что уже сделано? Нажми на задачу, чтобы отметить её выполненной.")
+	if len(open) > maxPickerTasks {
+		text += lang.Tf("\nПоказаны %d из %d, остальные — в /tasks.", maxPickerTasks, len(open))
+	}
+	return b.sendWithReplyMarkup(ctx, chatID, text, tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+func (b *Bot) handleReport(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	owner, err := b.accountSvc.Owner(ctx, user)
+	if err != nil {
+		return err
+	}
+	report, err := b.reminderSvc.DailyReport(ctx, *owner, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сформировать отчёт: %s", errorText(lang, err)))
+	}
+	pinned := user.ReportMessageID
+	if err := b.sendReport(ctx, msg.Chat.ID, user, report); err != nil {
+		return err
+	}
+	if pinned != 0 && pinned == user.ReportMessageID {
+		// The edit alone would go unnoticed.
+		return b.sendTransient(ctx, msg.Chat.ID, lang.T("📌 Отчёт обновлён в закреплённом сообщении."), nil)
+	}
+	return nil
+}
+
+// SendDailyReports sends a summary to every user whose report is due, resuming a run
+// that was interrupted by a restart.
+func (b *Bot) SendDailyReports(ctx context.Context) error {
+	now := time.Now()
+	run, users, err := b.reportScheduler.Begin(ctx, now)
+	if err != nil {
+		return err
+	}
+	if run.LastUserID > 0 {
+		log.Printf("[info] resuming report run id=%d after user=%d, %d users left", run.ID, run.LastUserID, len(users))
+	}
+	for _, user := range users {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		// Failed users wait for their next interval instead of being retried every tick.
+		if err := b.reportScheduler.Claim(ctx, run, &user, now); err != nil {
+			return err
+		}
+		b.sendUserReport(ctx, user, now)
+	}
+	return b.reportScheduler.Finish(ctx, run)
+}
+
+// sendUserReport builds and sends one user's report within REPORT_TIMEOUT_SECONDS, so a user
+// with pathological data cannot hold up everyone else. Failures and panics are only logged.
+func (b *Bot) sendUserReport(ctx context.Context, user model.User, now time.Time) {
+	started := time.Now()
+	ctx, span := tracing.Start(ctx, "report.user", attribute.Int64("user.telegram_id", user.TelegramID))
+	ctx = withReader(ctx, user)
+	if b.config.ReportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.config.ReportTimeout)
+		defer cancel()
+	}
+	var err error
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+		elapsed := time.Since(started)
+		span.SetAttributes(attribute.Int64("report.duration_ms", elapsed.Milliseconds()))
+		if err != nil {
+			log.Printf("report for user %d: %v", user.TelegramID, err)
+		}
+		if slow := b.config.ReportTimeout / 2; slow > 0 && elapsed > slow {
+			span.SetAttributes(attribute.Bool("report.slow", true))
+			log.Printf("[warn] slow report user=%d took=%s", user.TelegramID, elapsed.Round(time.Millisecond))
+		}
+		tracing.End(span, err)
+	}()
+
+	owner, err := b.accountSvc.Owner(ctx, &user)
+	if err != nil {
+		err = fmt.Errorf("resolve account owner: %w", err)
+		return
+	}
+	report, err := b.reminderSvc.DailyReport(ctx, *owner, now)
+	if err != nil {
+		err = fmt.Errorf("build summary: %w", err)
+		return
+	}
+	if err = b.sendReport(ctx, user.TelegramID, &user, report); err != nil {
+		err = fmt.Errorf("send summary: %w", err)
+		return
+	}
+	// Routed categories belong to the account, so only its primary user dispatches them.
+	if owner.ID == user.ID {
+		b.dispatchRouted(ctx, model.PersonalScope(owner.ID), owner.DeadlinePolicy(), now)
+	}
+}
+
+// handleInterval shows or changes how often the sender gets reports. After a change it
+// sends a preview of the next report and says when it will arrive.
+func (b *Bot) handleInterval(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	if msg.From == nil {
+		return nil
+	}
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		hours := int(b.reportScheduler.Interval(user).Hours())
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Текущий интервал отчётов: каждые %d ч., следующий отчёт: %s.\nУкажи число часов, например: /interval 4",
+			hours, whenLabel(lang, b.reportScheduler.Next(user, now), now)))
+	}
+	hours, err := strconv.Atoi(args)
+	if err != nil || hours <= 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Интервал должен быть положительным числом часов, например /interval 6"))
+	}
+	next, err := b.reportScheduler.SetInterval(ctx, user, hours, now)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось изменить интервал: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] report interval user=%d hours=%d", user.ID, hours)
+	if err := b.sendText(ctx, msg.Chat.ID, lang.Tf("Интервал уведомлений обновлён: каждые %d ч.\nСледующий отчёт: %s. Вот как он будет выглядеть 👇", hours, whenLabel(lang, next, now))); err != nil {
+		return err
+	}
+
+	owner, err := b.accountSvc.Owner(ctx, user)
+	if err != nil {
+		return err
+	}
+	preview, err := b.reminderSvc.DailySummary(ctx, *owner, next)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать предпросмотр: %s", errorText(lang, err)))
+	}
+	return b.sendText(ctx, msg.Chat.ID, preview)
+}
+
+// whenLabel describes a moment relative to now: "сегодня в 15:00", "завтра в 9:00" or a date.
+func whenLabel(lang i18n.Lang, t, now time.Time) string {
+	clock := fmt.Sprintf("%d:%02d", t.Hour(), t.Minute())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location()); {
+	case day.Equal(today):
+		return lang.Tf("сегодня в %s", clock)
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return lang.Tf("завтра в %s", clock)
+	default:
+		return lang.Tf("%s в %s", t.Format("02.01"), clock)
+	}
+}
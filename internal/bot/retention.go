@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+const (
+	retentionKeep = "keep"
+	retentionStop = "stop"
+)
+
+// RunRetention asks inactive users whether they still want reports and archives
+// those who did not answer in time.
+func (b *Bot) RunRetention(ctx context.Context) error {
+	now := time.Now()
+	users, err := b.retentionSvc.Inactive(ctx, now)
+	if err != nil {
+		return err
+	}
+	for i := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		user := &users[i]
+		lang := user.Lang()
+		msg := tgbotapi.NewMessage(user.TelegramID, lang.T("👋 Давно не виделись! Продолжать присылать отчёты по задачам?\nЕсли не ответить, через пару недель отчёты прекратятся (задачи сохранятся)."))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Да, присылать"), cbRetentionPrefix+retentionKeep),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🔕 Нет, остановить"), cbRetentionPrefix+retentionStop),
+		))
+		// A failed send (e.g. the bot was blocked) still starts the grace period.
+		if _, err := b.send(ctx, msg); err != nil {
+			log.Printf("send retention prompt to %d: %v", user.TelegramID, err)
+		}
+		if err := b.retentionSvc.MarkPrompted(ctx, user, now); err != nil {
+			return err
+		}
+	}
+
+	archived, err := b.retentionSvc.ArchiveUnanswered(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 || archived > 0 {
+		log.Printf("[info] retention prompted=%d archived=%d", len(users), archived)
+	}
+	return nil
+}
+
+// handleRetentionAnswer processes the buttons of the "still want reports?" prompt.
+func (b *Bot) handleRetentionAnswer(ctx context.Context, cb *tgbotapi.CallbackQuery, answer string) error {
+	lang := i18n.FromContext(ctx)
+	// Registering the press as activity already clears the pending prompt.
+	user, err := b.telegramUser(ctx, cb.From)
+	if err != nil {
+		return err
+	}
+	text := lang.T("✅ Отлично, отчёты продолжат приходить.")
+	if answer == retentionStop {
+		if err := b.retentionSvc.Stop(ctx, user, time.Now()); err != nil {
+			return err
+		}
+		log.Printf("[info] user archived on request user=%d", user.ID)
+		text = lang.T("🔕 Отчёты остановлены, задачи сохранены. Напиши что-нибудь, чтобы снова их получать.")
+	}
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	_, err = b.send(ctx, edit)
+	return err
+}
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+type commandHandler func(ctx context.Context, msg *tgbotapi.Message) error
+
+// callbackHandler receives the callback data with the route prefix stripped.
+type callbackHandler func(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error
+
+// conversationHandler receives a message typed while the sender is at a dialog step.
+type conversationHandler func(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error
+
+// commandRoute binds a command; commands with a description are shown in Telegram's command menu.
+type commandRoute struct {
+	name        string
+	description string
+	handle      commandHandler
+}
+
+// callbackRoute binds a callback data prefix. The router answers the callback with ack
+// before calling the handler unless selfAck is set.
+type callbackRoute struct {
+	prefix  string
+	ack     string
+	selfAck bool
+	handle  callbackHandler
+}
+
+// router maps command names, callback prefixes and dialog steps to handlers.
+type router struct {
+	commands      map[string]commandRoute
+	order         []string
+	callbacks     []callbackRoute
+	conversations map[conversationStage]conversationHandler
+}
+
+func newRouter() *router {
+	return &router{
+		commands:      make(map[string]commandRoute),
+		conversations: make(map[conversationStage]conversationHandler),
+	}
+}
+
+// command registers a command; registering the same name twice is a programming error.
+func (r *router) command(name, description string, handle commandHandler) {
+	if _, ok := r.commands[name]; ok {
+		panic(fmt.Sprintf("bot: command /%s registered twice", name))
+	}
+	r.commands[name] = commandRoute{name: name, description: description, handle: handle}
+	r.order = append(r.order, name)
+}
+
+// callback registers a callback route. Prefixes must not overlap so lookup doesn't depend on order.
+func (r *router) callback(route callbackRoute) {
+	for _, existing := range r.callbacks {
+		if strings.HasPrefix(route.prefix, existing.prefix) || strings.HasPrefix(existing.prefix, route.prefix) {
+			panic(fmt.Sprintf("bot: callback prefix %q overlaps %q", route.prefix, existing.prefix))
+		}
+	}
+	r.callbacks = append(r.callbacks, route)
+}
+
+// conversation makes handle responsible for the messages typed at the given dialog steps.
+func (r *router) conversation(handle conversationHandler, stages ...conversationStage) {
+	for _, stage := range stages {
+		if _, ok := r.conversations[stage]; ok {
+			panic(fmt.Sprintf("bot: dialog step %d registered twice", stage))
+		}
+		r.conversations[stage] = handle
+	}
+}
+
+func (r *router) findCommand(name string) (commandRoute, bool) {
+	route, ok := r.commands[name]
+	return route, ok
+}
+
+func (r *router) findCallback(data string) (callbackRoute, string, bool) {
+	for _, route := range r.callbacks {
+		if payload, ok := strings.CutPrefix(data, route.prefix); ok {
+			return route, payload, true
+		}
+	}
+	return callbackRoute{}, "", false
+}
+
+func (r *router) findConversation(stage conversationStage) (conversationHandler, bool) {
+	handle, ok := r.conversations[stage]
+	return handle, ok
+}
+
+// menu lists the described commands in registration order for setMyCommands.
+func (r *router) menu(lang i18n.Lang) []tgbotapi.BotCommand {
+	var commands []tgbotapi.BotCommand
+	for _, name := range r.order {
+		if route := r.commands[name]; route.description != "" {
+			commands = append(commands, tgbotapi.BotCommand{Command: name, Description: lang.T(route.description)})
+		}
+	}
+	return commands
+}
+
+// taskCallback adapts a handler acting on the task ID carried in the payload; malformed IDs are ignored.
+func taskCallback(handle func(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error) callbackHandler {
+	return func(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+		taskID, err := strconv.ParseUint(payload, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return handle(ctx, cb.Message.Chat.ID, cb.From, uint(taskID))
+	}
+}
+
+// loggedCallback records the button press before handing it on.
+func loggedCallback(event string, handle callbackHandler) callbackHandler {
+	return func(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+		log.Printf("[info] callback %s user=%d task=%s", event, cb.From.ID, payload)
+		return handle(ctx, cb, payload)
+	}
+}
+
+func ignoreCallback(context.Context, *tgbotapi.CallbackQuery, string) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+func TestRouterCallbackPayload(t *testing.T) {
+	r := newRouter()
+	var got string
+	r.callback(callbackRoute{prefix: "cat:", handle: func(_ context.Context, _ *tgbotapi.CallbackQuery, payload string) error {
+		got = payload
+		return nil
+	}})
+
+	route, payload, ok := r.findCallback("cat:42")
+	if !ok {
+		t.Fatal("callback not routed")
+	}
+	if err := route.handle(context.Background(), nil, payload); err != nil || got != "42" {
+		t.Errorf("payload %q, err %v", got, err)
+	}
+	if _, _, ok := r.findCallback("dog:1"); ok {
+		t.Error("unknown prefix routed")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("overlapping prefix accepted")
+		}
+	}()
+	r.callback(callbackRoute{prefix: "cat:x", handle: ignoreCallback})
+}
+
+func TestRoutesMenu(t *testing.T) {
+	r := (&Bot{}).routes()
+	listed := make(map[string]bool)
+	for _, command := range r.menu(i18n.Default) {
+		listed[command.Command] = true
+		if _, ok := r.findCommand(command.Command); !ok {
+			t.Errorf("/%s listed but not routed", command.Command)
+		}
+	}
+	if !listed["newtask"] || !listed["cancel"] {
+		t.Errorf("menu misses main commands: %v", listed)
+	}
+	if listed["category"] {
+		t.Error("subcommand-only /category should stay out of the menu")
+	}
+}
+
+func TestFeatureModulesRegisterAlone(t *testing.T) {
+	b := &Bot{}
+	r := newRouter()
+	b.registerCategories(r)
+	if _, ok := r.findCommand("categories"); !ok {
+		t.Error("categories module lost /categories")
+	}
+	if _, ok := r.findCommand("newtask"); ok {
+		t.Error("categories module registered a task command")
+	}
+	if _, _, ok := r.findCallback(cbCategoryDeletePrefix + "1"); !ok {
+		t.Error("categories module lost its deletion buttons")
+	}
+}
+
+func TestEveryDialogStepHasOwner(t *testing.T) {
+	r := (&Bot{}).routes()
+	for stage := stageTitle; stage <= stageEdit; stage++ {
+		if _, ok := r.findConversation(stage); !ok {
+			t.Errorf("dialog step %d has no handler", stage)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+// routes collects the commands, buttons and dialog steps of every feature module.
+// The order of registration is the order of the Telegram command menu.
+func (b *Bot) routes() *router {
+	r := newRouter()
+	r.command("start", "начать работу", b.handleStartV2)
+	r.command("help", "подсказки по командам", func(ctx context.Context, msg *tgbotapi.Message) error {
+		return b.handleHelpV3(ctx, msg)
+	})
+	for _, register := range []func(*router){
+		b.registerTasks,
+		b.registerCategories,
+		b.registerReports,
+		b.registerStats,
+		b.registerContacts,
+		b.registerMedications,
+		b.registerCounters,
+		b.registerSettings,
+		b.registerExport,
+		b.registerAccess,
+	} {
+		register(r)
+	}
+	r.command("cancel", "отменить текущий ввод", func(ctx context.Context, msg *tgbotapi.Message) error {
+		b.clearConversation(msg.From.ID)
+		return b.sendText(ctx, msg.Chat.ID, i18n.FromContext(ctx).T("⏪ Диалог создания задачи отменён."))
+	})
+	return r
+}
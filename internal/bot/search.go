@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+// searchLimit caps how many matches /search shows; the rest are only counted.
+const searchLimit = 20
+
+// handleSearch lists open tasks whose title or description contains the text: /search <text>.
+func (b *Bot) handleSearch(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	query := strings.TrimSpace(msg.CommandArguments())
+	if query == "" {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Что искать? Например: /search молоко"))
+	}
+	user := CurrentUser(ctx)
+
+	tasks, total, err := b.taskSvc.Search(ctx, user, query, searchLimit, 0)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось выполнить поиск: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] search user=%d matches=%d", user.ID, total)
+	if total == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🔎 По запросу «%s» ничего не нашлось.", escape(query)))
+	}
+
+	body, buttons := formatTaskGroups(lang, tasks, b.categoriesByID(ctx, user), sortPriority, b.config.TaskAgingDays, user.DeadlinePolicy(), time.Now())
+	header := lang.Tf("🔎 <b>Найдено: %d</b> по запросу «%s»%s\n", total, escape(query), b.workspaceTitle(ctx, user))
+	if int(total) > len(tasks) {
+		header += lang.Tf("Показаны %d самых новых — уточни запрос, чтобы увидеть остальные.\n", len(tasks))
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, header+"\n"+body)
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+	reply.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, reply)
+	return err
+}
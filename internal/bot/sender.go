@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/httpclient"
+	"daily-planner/internal/tracing"
+)
+
+const (
+	// maxRetryDelay caps both the backoff and Telegram's retry_after.
+	maxRetryDelay = time.Minute
+	// sendQueueSize is how many Bot API requests may be in flight at once; the rest queue up.
+	sendQueueSize = 30
+)
+
+// sender is the transport of Bot API requests. A request Telegram answers with 429 is repeated
+// after the retry_after it names, and every other request waits that pause out as well; one
+// that failed before it was written to the connection is repeated with exponential backoff.
+// Anything else, 5xx answers included, may already have delivered a message and is returned
+// as is. At most sendQueueSize requests are in flight and the rest wait for a slot, so a burst
+// such as the report run slows down instead of losing messages.
+type sender struct {
+	next     http.RoundTripper
+	attempts int // how often one request is tried
+	slots    chan struct{}
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+
+	mu         sync.Mutex
+	pauseUntil time.Time
+}
+
+// newAPIClient builds the HTTP client of Bot API requests on top of the shared one: with its
+// own transport when TELEGRAM_PROXY is set, traced, with every request going through a sender.
+func (b *Bot) newAPIClient(shared *http.Client) (*http.Client, error) {
+	base := shared
+	if b.config.TelegramProxy != "" {
+		proxied, err := httpclient.New(httpclient.Options{Timeout: shared.Timeout, Retries: b.config.HTTPMaxRetries, Proxy: b.config.TelegramProxy})
+		if err != nil {
+			return nil, err
+		}
+		base = proxied
+	}
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	sender := newSender(tracing.Transport(transport, b.traceParent), b.config.HTTPMaxRetries+1)
+	return &http.Client{Transport: sender, Timeout: base.Timeout}, nil
+}
+
+func newSender(next http.RoundTripper, attempts int) *sender {
+	return &sender{next: next, attempts: attempts, slots: make(chan struct{}, sendQueueSize), now: time.Now, sleep: httpclient.Sleep}
+}
+
+func (s *sender) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/getUpdates") || req.Method == http.MethodGet {
+		// Long polling holds its request open for a long time and retries on its own; file
+		// downloads are retried by the shared client.
+		return s.next.RoundTrip(req)
+	}
+	ctx := req.Context()
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	method := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	backoff := httpclient.RetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		// Waits out a 429 answered to this or any other request.
+		if err := s.sleep(ctx, s.paused()); err != nil {
+			return nil, err
+		}
+		var written atomic.Bool
+		trace := &httptrace.ClientTrace{WroteHeaders: func() { written.Store(true) }}
+		resp, err := s.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(ctx, trace)))
+		// Uploads stream their body and cannot be sent again.
+		if attempt >= s.attempts || req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+		switch {
+		case err != nil:
+			// Telegram may have got a request that failed midway and acted on it.
+			if written.Load() || ctx.Err() != nil {
+				return nil, err
+			}
+			log.Printf("[warn] telegram %s: %v, retrying in %s", method, err, backoff)
+			if err := s.sleep(ctx, backoff); err != nil {
+				return nil, err
+			}
+			backoff = min(backoff*2, maxRetryDelay)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			wait := min(retryAfter(resp.Body, backoff), maxRetryDelay)
+			resp.Body.Close()
+			s.pause(wait)
+			log.Printf("[warn] telegram %s rate limited, retrying in %s", method, wait)
+		default:
+			return resp, nil
+		}
+	}
+}
+
+// pause holds every request back for d, unless a longer pause is already on.
+func (s *sender) pause(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := s.now().Add(d); until.After(s.pauseUntil) {
+		s.pauseUntil = until
+	}
+}
+
+// paused is how long requests still have to wait after a 429.
+func (s *sender) paused() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return max(s.pauseUntil.Sub(s.now()), 0)
+}
+
+// retryAfter reads the pause Telegram asks for from a 429 answer, fallback when it names none.
+func retryAfter(body io.Reader, fallback time.Duration) time.Duration {
+	var answer tgbotapi.APIResponse
+	if err := json.NewDecoder(io.LimitReader(body, 4096)).Decode(&answer); err != nil ||
+		answer.Parameters == nil || answer.Parameters.RetryAfter <= 0 {
+		return fallback
+	}
+	return time.Duration(answer.Parameters.RetryAfter) * time.Second
+}
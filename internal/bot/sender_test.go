@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/httpclient"
+)
+
+func TestSenderRetries(t *testing.T) {
+	const limited = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 3","parameters":{"retry_after":3}}`
+	answers := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusTooManyRequests, limited},
+		{http.StatusOK, `{"ok":true,"result":true}`},
+	}
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		texts = append(texts, r.FormValue("text"))
+		answer := answers[min(len(texts), len(answers))-1]
+		w.WriteHeader(answer.status)
+		io.WriteString(w, answer.body)
+	}))
+	defer server.Close()
+
+	clock := time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	// The first connection fails before anything is written.
+	refused := false
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !refused {
+			refused = true
+			return nil, errors.New("connection refused")
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	s := newSender(next, 5)
+	s.now = func() time.Time { return clock }
+	s.sleep = func(ctx context.Context, d time.Duration) error {
+		if d > 0 {
+			waits = append(waits, d)
+			clock = clock.Add(d)
+		}
+		return nil
+	}
+	client := &http.Client{Transport: s}
+
+	resp, err := client.Post(server.URL+"/bottoken/sendMessage", "application/x-www-form-urlencoded", strings.NewReader("chat_id=1&text=hi"))
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+	if strings.Join(texts, ",") != "hi,hi" {
+		t.Errorf("bodies sent: %q", texts)
+	}
+	// The backoff after the refused connection, then retry_after.
+	if len(waits) != 2 || waits[0] != httpclient.RetryBaseDelay || waits[1] != 3*time.Second {
+		t.Errorf("waits %v, want [%s 3s]", waits, httpclient.RetryBaseDelay)
+	}
+
+	// A 5xx answer may come after the message went out, so it is not repeated.
+	answers[0].status, answers[0].body = http.StatusBadGateway, `{"ok":false,"error_code":502,"description":"Bad Gateway"}`
+	texts = nil
+	resp, err = client.Post(server.URL+"/bottoken/sendMessage", "application/x-www-form-urlencoded", strings.NewReader("text=once"))
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || len(texts) != 1 {
+		t.Errorf("got %d after %d attempts, want 502 after 1", resp.StatusCode, len(texts))
+	}
+
+	// Once attempts run out the last answer is returned as is.
+	answers = answers[:1]
+	answers[0].status, answers[0].body = http.StatusTooManyRequests, limited
+	texts, waits = nil, nil
+	resp, err = client.Post(server.URL+"/bottoken/sendMessage", "application/x-www-form-urlencoded", strings.NewReader("text=again"))
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || len(texts) != 5 {
+		t.Errorf("got %d after %d attempts, want 429 after 5", resp.StatusCode, len(texts))
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
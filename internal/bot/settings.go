@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+// registerSettings wires up the per-user preferences, linked accounts, workspaces and data retention.
+func (b *Bot) registerSettings(r *router) {
+	r.command("settings", "перенос настроек", b.handleSettings)
+	r.command("timezone", "часовой пояс", b.handleTimezone)
+	r.command("language", "язык бота", b.handleLanguage)
+	r.command("plain", "простой текст без эмодзи", b.handlePlain)
+	r.command("workhours", "рабочие часы", b.handleWorkHours)
+	r.command("countdown", "обратный отсчёт до срока", b.handleCountdown)
+	r.command("grace", "когда задача считается просроченной", b.handleGrace)
+	r.command("autodelete", "автоудаление служебных сообщений", b.handleAutoDelete)
+	r.command("emoji", "быстрые ответы эмодзи", b.handleEmoji)
+	r.command("workspace", "общие пространства", b.handleWorkspace)
+	r.command("link", "привязать второй аккаунт", b.handleLink)
+	r.command("unlink", "", b.handleUnlink)
+	r.command("buddy", "партнёр по задачам", b.handleBuddy)
+	r.command("quota", "лимиты", b.handleQuota)
+	r.callback(callbackRoute{prefix: cbBuddyPrefix, handle: b.handleBuddyButton})
+	r.callback(callbackRoute{prefix: cbRetentionPrefix, handle: b.handleRetentionAnswer})
+}
+
+const settingsFileName = "planner-settings.json"
+
+const settingsUsage = "⚙️ <b>Настройки</b>\n" +
+	"• /settings export — файл с часовым поясом, рабочими часами, интервалом отчётов, быстрыми ответами и категориями\n" +
+	"• пришли этот файл боту на другом сервере или после удаления данных, чтобы восстановить настройки\n" +
+	"Задачи и история в файл не входят."
+
+// handleSettings exports the settings profile: /settings export.
+func (b *Bot) handleSettings(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	if strings.TrimSpace(msg.CommandArguments()) != "export" {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(settingsUsage))
+	}
+	self, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	owner, err := b.accountSvc.Owner(ctx, self)
+	if err != nil {
+		return err
+	}
+	profile, err := b.settingsSvc.Export(ctx, owner, self, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось выгрузить настройки: %s", errorText(lang, err)))
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.sendDocument(ctx, msg.Chat.ID, settingsFileName, data, lang.T("Пришли этот файл боту, чтобы перенести настройки."))
+}
+
+// importSettings applies a settings profile sent as a JSON file.
+func (b *Bot) importSettings(ctx context.Context, msg *tgbotapi.Message, body io.Reader) error {
+	lang := i18n.FromContext(ctx)
+	self, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	owner, err := b.accountSvc.Owner(ctx, self)
+	if err != nil {
+		return err
+	}
+	profile, err := service.ParseProfile(body)
+	if errors.Is(err, service.ErrInvalidProfile) {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Это не файл настроек или он повреждён: %s", errorText(lang, err)))
+	}
+	if err != nil {
+		return err
+	}
+	result, err := b.settingsSvc.Import(ctx, owner, self, profile, time.Now())
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось применить настройки: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] settings imported user=%d categories_created=%d categories_updated=%d", owner.ID, result.CategoriesCreated, result.CategoriesUpdated)
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("⚙️ Настройки применены. Часовой пояс: %s, рабочие часы: %s.\nКатегорий добавлено: %d, обновлено: %d.",
+		escape(owner.Location().String()), workHoursLabel(service.UserWorkingHours(*owner)), result.CategoriesCreated, result.CategoriesUpdated))
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	// cbSharePrefix sends a forwardable card of the task: "share:<id>".
+	cbSharePrefix = "share:"
+	// joinPayload starts the /start parameter of a link that joins a workspace.
+	joinPayload = "join_"
+)
+
+// shareTask sends a standalone card of the task that reads well when forwarded to any chat.
+// Tasks of a workspace carry a link that lets the recipient join it.
+func (b *Bot) shareTask(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	subtasks, err := b.taskSvc.Subtasks(ctx, user, task.ID)
+	if err != nil {
+		log.Printf("subtasks of task %d: %v", task.ID, err)
+	}
+	workspace, err := b.workspaceSvc.Active(ctx, user)
+	if err != nil {
+		log.Printf("workspace of task %d: %v", task.ID, err)
+	}
+
+	payload, label := "", lang.T("📥 Открыть планировщик")
+	if workspace != nil {
+		payload, label = joinPayload+workspace.InviteCode, lang.Tf("👥 Присоединиться к «%s»", workspace.Name)
+	}
+	msg := tgbotapi.NewMessage(chatID, formatSharedTask(lang, *task, b.categoriesByID(ctx, user), subtasks, workspace))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonURL(label, b.deepLink(payload)),
+	))
+	if _, err := b.send(ctx, msg); err != nil {
+		return err
+	}
+	log.Printf("[info] task shared id=%d user=%d workspace=%t", task.ID, user.ID, workspace != nil)
+	return b.sendTransient(ctx, chatID, lang.T("📤 Перешли карточку выше в любой чат — кнопка под ней откроет планировщик."), nil)
+}
+
+// formatSharedTask renders the task without IDs or bot commands, which mean nothing to the recipient.
+func formatSharedTask(lang i18n.Lang, task model.Task, categories map[uint]model.Category, subtasks []model.Task, workspace *model.Workspace) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📌 <b>%s</b>\n", escape(normalizeTitle(task.Title))))
+	if task.CategoryID != nil {
+		_, category := normalizedCategory(lang, task.CategoryID, categories)
+		b.WriteString(lang.Tf("• Категория: %s\n", category))
+	}
+	if task.Deadline != nil {
+		b.WriteString(lang.Tf("• Срок: %s\n", task.Deadline.Format("02.01.2006")))
+	}
+	if task.Priority != "" && task.Priority != model.PriorityNormal {
+		b.WriteString(lang.Tf("• Приоритет: %s\n", priorityLabel(lang, task.Priority)))
+	}
+	if task.IsRecurring {
+		b.WriteString(lang.Tf("• Повтор: %s\n", recurrenceText(lang, task)))
+	}
+	if !task.IsRecurring && task.IsCompleted {
+		b.WriteString(lang.T("• ✅ Выполнена\n"))
+	}
+	if task.Description != "" {
+		b.WriteString(fmt.Sprintf("\n%s\n", escape(task.Description)))
+	}
+	if len(subtasks) > 0 {
+		b.WriteString("\n")
+		for _, subtask := range subtasks {
+			mark := "▫️"
+			if subtask.IsCompleted {
+				mark = "✅"
+			}
+			b.WriteString(fmt.Sprintf("%s %s\n", mark, escape(normalizeTitle(subtask.Title))))
+		}
+	}
+	if workspace != nil {
+		b.WriteString(lang.Tf("\n🏠 Из пространства «%s»", escape(workspace.Name)))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// deepLink is the t.me link that opens the bot and sends /start with the payload.
+func (b *Bot) deepLink(payload string) string {
+	link := "https://t.me/" + b.api.Self.UserName
+	if payload != "" {
+		link += "?start=" + payload
+	}
+	return link
+}
+
+// joinByLink joins the workspace named in the /start payload of a shared card.
+func (b *Bot) joinByLink(ctx context.Context, msg *tgbotapi.Message, code string) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	workspace, err := b.workspaceSvc.Join(ctx, user, code)
+	if errors.Is(err, service.ErrAlreadyMember) {
+		if _, err := b.workspaceSvc.Switch(ctx, user, workspace.ID); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось переключиться: %s", errorText(lang, err)))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🏠 Ты уже в «%s» — переключил на это пространство. Задачи: /tasks", escape(workspace.Name)))
+	}
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Ссылка устарела: такого пространства больше нет."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось вступить: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] workspace joined by link id=%d user=%d", workspace.ID, user.ID)
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("✅ Добро пожаловать в «%s»! Общие задачи: /tasks", escape(workspace.Name)))
+}
@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+)
+
+// captchaChoices are the buttons of the anti-spam check; one of them is the answer.
+var captchaChoices = []string{"🍎", "🚗", "🐱", "🌵", "⚽", "🎈"}
+
+const captchaButtons = 4
+
+// admit lets registered users through, turns unknown ones away from a private instance and
+// walks them through invite codes and the anti-spam check elsewhere. It returns false when the message was consumed.
+func (b *Bot) admit(ctx context.Context, msg *tgbotapi.Message) (bool, error) {
+	if msg.From == nil {
+		return false, nil
+	}
+	ok, err := b.signupSvc.Admitted(ctx, msg.From.ID)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	_, err = b.userRepo.FindByTelegramID(ctx, msg.From.ID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) && b.signupSvc.Private():
+		return false, b.requestAccess(ctx, msg)
+	case errors.Is(err, gorm.ErrRecordNotFound) && b.signupSvc.Gated():
+		return false, b.redeemInvite(ctx, msg)
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return false, err
+	}
+
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return false, err
+	}
+	if b.signupSvc.Verified(user) {
+		return true, nil
+	}
+	return false, b.sendCaptcha(ctx, msg.Chat.ID, msg.From.ID)
+}
+
+// redeemInvite registers an unknown user who sent a valid invite code as text or via /start <code>.
+func (b *Bot) redeemInvite(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	code := strings.TrimSpace(msg.Text)
+	if msg.IsCommand() {
+		if msg.Command() != "start" {
+			return b.sendTextWithRemove(ctx, msg.Chat.ID, lang.T("🔐 Бот работает по приглашениям. Отправь код приглашения, чтобы начать."))
+		}
+		code = strings.TrimSpace(msg.CommandArguments())
+	}
+	if code == "" {
+		return b.sendTextWithRemove(ctx, msg.Chat.ID, lang.T("🔐 Бот работает по приглашениям. Отправь код приглашения, чтобы начать."))
+	}
+	if !b.signupSvc.ValidCode(code) {
+		return b.sendTextWithRemove(ctx, msg.Chat.ID, lang.T("Код приглашения не подошёл. Проверь его и попробуй ещё раз."))
+	}
+
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	// An invite code is proof enough that a person is behind the account.
+	if err := b.signupSvc.Verify(ctx, user); err != nil {
+		return err
+	}
+	log.Printf("[info] user signed up with invite code user=%d", user.ID)
+	return b.handleStartV2(ctx, msg)
+}
+
+// sendCaptcha asks the user to press the button with a randomly chosen picture.
+func (b *Bot) sendCaptcha(ctx context.Context, chatID, telegramID int64) error {
+	lang := i18n.FromContext(ctx)
+	choices, err := shuffle(append([]string(nil), captchaChoices...))
+	if err != nil {
+		return err
+	}
+	answer := choices[0]
+	choices, err = shuffle(choices[:captchaButtons])
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.captchas[telegramID] = answer
+	b.mu.Unlock()
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, choice := range choices {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(choice, cbCaptchaPrefix+choice))
+	}
+	msg := tgbotapi.NewMessage(chatID, lang.Tf("🤖 Проверка, что ты не бот: нажми на кнопку %s", answer))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	_, err = b.send(ctx, msg)
+	return err
+}
+
+// handleCaptcha checks the pressed button and lets the user in on the right answer.
+func (b *Bot) handleCaptcha(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	lang := i18n.FromContext(ctx)
+	if cb.From == nil || cb.Message == nil {
+		return nil
+	}
+	b.mu.Lock()
+	answer, ok := b.captchas[cb.From.ID]
+	b.mu.Unlock()
+
+	if !ok || strings.TrimPrefix(cb.Data, cbCaptchaPrefix) != answer {
+		if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, lang.T("Не та кнопка, попробуй ещё раз"))); err != nil {
+			log.Printf("callback ack: %v", err)
+		}
+		return b.sendCaptcha(ctx, cb.Message.Chat.ID, cb.From.ID)
+	}
+
+	user, err := b.telegramUser(ctx, cb.From)
+	if err != nil {
+		return err
+	}
+	if err := b.signupSvc.Verify(ctx, user); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	delete(b.captchas, cb.From.ID)
+	b.mu.Unlock()
+
+	if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, lang.T("Спасибо!"))); err != nil {
+		log.Printf("callback ack: %v", err)
+	}
+	log.Printf("[info] user verified user=%d", user.ID)
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, lang.T("✅ Проверка пройдена. Набери /start, чтобы начать."))
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+// registered reports whether the sender may use the bot; lookup failures count as no.
+func (b *Bot) registered(ctx context.Context, from *tgbotapi.User) bool {
+	if from == nil {
+		return false
+	}
+	ok, err := b.signupSvc.Admitted(ctx, from.ID)
+	if err != nil {
+		log.Printf("signup check: %v", err)
+	}
+	return ok
+}
+
+// shuffle permutes items in place using crypto/rand so answers cannot be predicted.
+func shuffle(items []string) ([]string, error) {
+	for i := len(items) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		items[i], items[j.Int64()] = items[j.Int64()], items[i]
+	}
+	return items, nil
+}
@@ -0,0 +1,118 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+func (b *Bot) registerStats(r *router) {
+	r.command("stats", "статистика", b.handleStats)
+	r.command("heatmap", "карта продуктивности за месяц", b.handleHeatmap)
+	r.command("weekly", "итоги недели", b.handleWeekly)
+}
+
+// statsPeriod is the period /stats reports on.
+const statsPeriod = 30 * 24 * time.Hour
+
+// handleStats shows the user's statistics for the last 30 days.
+func (b *Bot) handleStats(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	since := time.Now().Add(-statsPeriod)
+	timings, err := b.taskSvc.CompletionTimes(ctx, user, since)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать статистику: %s", errorText(lang, err)))
+	}
+	adherence, err := b.medicationSvc.Adherence(ctx, user, since)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать статистику: %s", errorText(lang, err)))
+	}
+	triage, err := b.triageSvc.Stats(ctx, user, since)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать статистику: %s", errorText(lang, err)))
+	}
+	postponed, err := b.taskSvc.Postponements(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось собрать статистику: %s", errorText(lang, err)))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(lang.T("📊 <b>Статистика за 30 дней</b>\n"))
+	builder.WriteString(formatCompletionTimes(lang, timings))
+	builder.WriteString(lang.T("\n💊 <b>Приём лекарств</b>\n"))
+	if len(adherence) == 0 {
+		builder.WriteString(lang.T("— расписаний нет, добавь через /med add\n"))
+	}
+	for _, entry := range adherence {
+		if entry.Taken+entry.Missed == 0 {
+			builder.WriteString(lang.Tf("• %s — пока нет отметок\n", escape(entry.Name)))
+			continue
+		}
+		builder.WriteString(lang.Tf("• %s — %d%% (принято %d из %d)\n", escape(entry.Name), entry.Percent(), entry.Taken, entry.Taken+entry.Missed))
+	}
+	builder.WriteString(lang.T("\n🧹 <b>Разбор бэклога</b>\n"))
+	if triage.Pruned()+triage.Kept == 0 {
+		builder.WriteString(lang.T("— за этот период задачи не разбирались\n"))
+	} else {
+		builder.WriteString(lang.Tf("Убрано %d: удалено %d, в архив %d; оставлено %d\n", triage.Pruned(), triage.Deleted, triage.Archived, triage.Kept))
+	}
+	builder.WriteString(lang.T("\n⏰ <b>Переносы дедлайнов</b> (открытые задачи)\n"))
+	if postponed.Tasks == 0 {
+		builder.WriteString(lang.T("— сроки не переносились\n"))
+	} else {
+		builder.WriteString(lang.Tf("Задач с перенесённым сроком: %d, переносов: %d\n", postponed.Tasks, postponed.Total))
+		for _, task := range postponed.Most {
+			builder.WriteString(lang.Tf("• #%d %s — %d раз\n", task.ID, escape(normalizeTitle(task.Title)), task.Postponements))
+		}
+	}
+	return b.sendText(ctx, msg.Chat.ID, strings.TrimSpace(builder.String()))
+}
+
+// formatCompletionTimes renders median and 90th percentile time to done per category,
+// marking categories where tasks languish.
+func formatCompletionTimes(lang i18n.Lang, stats service.CompletionStats) string {
+	var builder strings.Builder
+	builder.WriteString(lang.T("\n⏱ <b>Время до выполнения</b> (медиана · 90%)\n"))
+	if stats.Overall.Count == 0 {
+		builder.WriteString(lang.T("— за этот период задачи не выполнялись\n"))
+		return builder.String()
+	}
+	builder.WriteString(lang.Tf("Все задачи: %s · %s, выполнено %d\n", durationLabel(lang, stats.Overall.Median), durationLabel(lang, stats.Overall.P90), stats.Overall.Count))
+	for _, timing := range stats.Categories {
+		name := timing.Category
+		if name == "" {
+			name = noCategory
+		}
+		marker := "•"
+		if timing.Languish {
+			marker = "🐢"
+		}
+		builder.WriteString(fmt.Sprintf("%s %s — %s · %s (%d)\n", marker, escape(name), durationLabel(lang, timing.Median), durationLabel(lang, timing.P90), timing.Count))
+	}
+	for _, timing := range stats.Categories {
+		if timing.Languish {
+			builder.WriteString(lang.T("🐢 — здесь задачи задерживаются заметно дольше обычного\n"))
+			break
+		}
+	}
+	return builder.String()
+}
+
+// durationLabel rounds a duration to minutes, hours or days.
+func durationLabel(lang i18n.Lang, d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return lang.Tf("%d мин", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return lang.Tf("%d ч", int(d.Hours()))
+	default:
+		return lang.Tf("%d дн.", int(d.Hours()/24))
+	}
+}
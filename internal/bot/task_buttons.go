@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+// The ✅ and 🗑 buttons under lists, reports and cards ask for confirmation in place: the
+// pressed row turns into answer buttons and the outcome is shown as a callback notification,
+// so the chat gets no new messages.
+const (
+	cbDeleteConfirmPrefix = "rm:"
+	cbStopRepeatsPrefix   = "stop:"
+)
+
+// answerCallback shows text as a notification over the chat.
+func (b *Bot) answerCallback(cb *tgbotapi.CallbackQuery, text string) {
+	if _, err := b.api.Request(tgbotapi.NewCallback(cb.ID, text)); err != nil {
+		log.Printf("callback ack: %v", err)
+	}
+}
+
+// buttonTask loads the task a button refers to, answering the callback itself when it is gone.
+func (b *Bot) buttonTask(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) (*model.User, *model.Task, error) {
+	lang := i18n.FromContext(ctx)
+	taskID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		b.answerCallback(cb, "")
+		return nil, nil, nil
+	}
+	user, err := b.ensureUser(ctx, cb.From)
+	if err != nil {
+		b.answerCallback(cb, "")
+		return nil, nil, err
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, uint(taskID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			b.answerCallback(cb, lang.T("Задача не найдена или уже удалена."))
+			return nil, nil, b.dropTaskRows(ctx, cb, uint(taskID))
+		}
+		b.answerCallback(cb, lang.T("Ошибка: ")+errorText(lang, err))
+		return nil, nil, err
+	}
+	return user, task, nil
+}
+
+// handleCompleteButton asks to confirm completing the task right under the pressed button.
+func (b *Bot) handleCompleteButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	_, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	if task.IsRecurring && isRecurringDoneInWindow(*task, time.Now()) {
+		b.answerCallback(cb, lang.T("Задача уже отмечена выполненной в этом окне."))
+		return nil
+	}
+	if !task.IsRecurring && task.IsCompleted {
+		b.answerCallback(cb, lang.T("Задача уже выполнена."))
+		return b.dropTaskRows(ctx, cb, task.ID)
+	}
+	b.answerCallback(cb, lang.Tf("Отметить задачу «%s» (#%d) как выполненную?", normalizeTitle(task.Title), task.ID))
+	return b.swapTaskRow(cb, task.ID, []tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardButtonData(lang.Tf("✅ Да, #%d выполнена", task.ID), fmt.Sprintf("%s%d", cbConfirmPrefix, task.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("↩️ Нет"), fmt.Sprintf("%s%d", cbCancelPrefix, task.ID)),
+	})
+}
+
+// handleDeleteButton asks to confirm deleting the task; a running recurring task offers
+// to stop only its future repeats instead.
+func (b *Bot) handleDeleteButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	_, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	cancel := tgbotapi.NewInlineKeyboardButtonData(lang.T("↩️ Нет"), fmt.Sprintf("%s%d", cbCancelPrefix, task.ID))
+	if task.IsRecurring && task.RecurEndedAt == nil {
+		b.answerCallback(cb, lang.Tf("«%s» — повторяющаяся задача. Можно остановить только будущие повторы, сохранив историю, или удалить её целиком.", normalizeTitle(task.Title)))
+		return b.swapTaskRow(cb, task.ID, []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(lang.T(btnEndRecurrence), fmt.Sprintf("%s%d", cbStopRepeatsPrefix, task.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T(btnDeleteHistory), fmt.Sprintf("%s%d", cbDeleteConfirmPrefix, task.ID)),
+			cancel,
+		})
+	}
+	b.answerCallback(cb, lang.Tf("Удалить задачу «%s» (#%d)?", normalizeTitle(task.Title), task.ID))
+	return b.swapTaskRow(cb, task.ID, []tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardButtonData(lang.Tf("🗑 Да, удалить #%d", task.ID), fmt.Sprintf("%s%d", cbDeleteConfirmPrefix, task.ID)),
+		cancel,
+	})
+}
+
+// handleConfirmComplete completes the task and redraws the message it was shown in.
+func (b *Bot) handleConfirmComplete(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	user, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	now := time.Now()
+	if (task.IsRecurring && isRecurringDoneInWindow(*task, now)) || (!task.IsRecurring && task.IsCompleted) {
+		b.answerCallback(cb, lang.T("Задача уже выполнена."))
+		return b.redrawAfter(ctx, cb, task.ID)
+	}
+	if task, err = b.taskSvc.CompleteTask(ctx, user, task.ID, now); err != nil {
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] task completed id=%d user=%d recurring=%t", task.ID, user.ID, task.IsRecurring)
+	if caughtUp(*task, now) {
+		b.answerCallback(cb, caughtUpText(lang, normalizeTitle(task.Title), *task.CaughtUpTo))
+	} else if task.IsRecurring {
+		b.answerCallback(cb, lang.Tf("♻️ Задача «%s» отмечена выполненной в этом окне.", normalizeTitle(task.Title)))
+	} else {
+		b.answerCallback(cb, lang.Tf("✅ Задача «%s» выполнена.", normalizeTitle(task.Title)))
+	}
+	return b.redrawAfter(ctx, cb, task.ID)
+}
+
+// handleConfirmDelete deletes the task together with its history.
+func (b *Bot) handleConfirmDelete(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	user, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	if err := b.taskSvc.DeleteTask(ctx, user, task.ID); err != nil {
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] task deleted id=%d user=%d", task.ID, user.ID)
+	b.answerCallback(cb, lang.Tf("🗑 Задача «%s» в корзине, вернуть — /trash.", normalizeTitle(task.Title)))
+	return b.redrawAfter(ctx, cb, task.ID)
+}
+
+// handleStopRepeats ends a recurring task but keeps it with its history.
+func (b *Bot) handleStopRepeats(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	user, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	if task, err = b.taskSvc.EndRecurrence(ctx, user, task.ID, time.Now()); err != nil {
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] recurrence ended id=%d user=%d", task.ID, user.ID)
+	b.answerCallback(cb, lang.Tf("⏹ Повторы задачи «%s» остановлены. История сохранена: /task %d", normalizeTitle(task.Title), task.ID))
+	return b.redrawAfter(ctx, cb, task.ID)
+}
+
+// handleCancelButton puts the pressed message back the way it was.
+func (b *Bot) handleCancelButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	user, task, err := b.buttonTask(ctx, cb, payload)
+	if task == nil {
+		return err
+	}
+	b.answerCallback(cb, "")
+	if list, ok := b.listMessage(ctx, cb); ok {
+		return b.sendTaskPage(ctx, list.ChatID, list.MessageID, user, listView(list.View), list.Page)
+	}
+	row := []tgbotapi.InlineKeyboardButton{completeButton(*task, 24)}
+	if task.IsRecurring {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(lang.T("\U0001F5D1 Удалить"), fmt.Sprintf("%s%d", cbDeletePrefix, task.ID)))
+	}
+	return b.swapTaskRow(cb, task.ID, row)
+}
+
+// listMessage returns the tracked task list when the button was pressed under it.
+func (b *Bot) listMessage(ctx context.Context, cb *tgbotapi.CallbackQuery) (*model.ListMessage, bool) {
+	list, err := b.taskSvc.TrackedList(ctx, cb.Message.Chat.ID)
+	if err != nil || list.MessageID != cb.Message.MessageID {
+		return nil, false
+	}
+	return list, true
+}
+
+// redrawAfter updates the pressed message once the task changed. The tracked task list
+// has already been redrawn by refreshLists; other messages lose the task's buttons.
+func (b *Bot) redrawAfter(ctx context.Context, cb *tgbotapi.CallbackQuery, taskID uint) error {
+	if _, ok := b.listMessage(ctx, cb); ok {
+		return nil
+	}
+	return b.dropTaskRows(ctx, cb, taskID)
+}
+
+// swapTaskRow replaces the keyboard row with the task's buttons by row; a message without
+// such a row gets it appended.
+func (b *Bot) swapTaskRow(cb *tgbotapi.CallbackQuery, taskID uint, row []tgbotapi.InlineKeyboardButton) error {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	swapped := false
+	for _, existing := range currentKeyboard(cb) {
+		if !swapped && rowActsOn(existing, taskID) {
+			rows = append(rows, row)
+			swapped = true
+			continue
+		}
+		rows = append(rows, existing)
+	}
+	if !swapped {
+		rows = append(rows, row)
+	}
+	return b.editKeyboard(cb, rows)
+}
+
+// dropTaskRows removes the rows with the task's buttons from the pressed message.
+func (b *Bot) dropTaskRows(ctx context.Context, cb *tgbotapi.CallbackQuery, taskID uint) error {
+	if list, ok := b.listMessage(ctx, cb); ok {
+		user := CurrentUser(ctx)
+		return b.sendTaskPage(ctx, list.ChatID, list.MessageID, user, listView(list.View), list.Page)
+	}
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, existing := range currentKeyboard(cb) {
+		if !rowActsOn(existing, taskID) {
+			rows = append(rows, existing)
+		}
+	}
+	return b.editKeyboard(cb, rows)
+}
+
+func currentKeyboard(cb *tgbotapi.CallbackQuery) [][]tgbotapi.InlineKeyboardButton {
+	if cb.Message.ReplyMarkup == nil {
+		return nil
+	}
+	return cb.Message.ReplyMarkup.InlineKeyboard
+}
+
+// rowActsOn reports whether the row holds the ✅/🗑/↩️ buttons of the task or the answers to them.
+func rowActsOn(row []tgbotapi.InlineKeyboardButton, taskID uint) bool {
+	id := strconv.FormatUint(uint64(taskID), 10)
+	for _, button := range row {
+		if button.CallbackData == nil {
+			continue
+		}
+		for _, prefix := range []string{cbCompletePrefix, cbDeletePrefix, cbConfirmPrefix, cbCancelPrefix, cbDeleteConfirmPrefix, cbStopRepeatsPrefix, cbReopenPrefix, cbUndeletePrefix} {
+			if rest, ok := strings.CutPrefix(*button.CallbackData, prefix); ok && rest == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b *Bot) editKeyboard(cb *tgbotapi.CallbackQuery, rows [][]tgbotapi.InlineKeyboardButton) error {
+	markup := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+	if rows == nil {
+		markup.InlineKeyboard = [][]tgbotapi.InlineKeyboardButton{}
+	}
+	_, err := b.api.Request(tgbotapi.NewEditMessageReplyMarkup(cb.Message.Chat.ID, cb.Message.MessageID, markup))
+	return err
+}
@@ -0,0 +1,352 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/calendar"
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// Buttons of the task card and the 🔎 button that opens it from lists.
+const (
+	cbTaskPrefix    = "task:"
+	cbRemindPrefix  = "remind:"
+	cbSubtaskPrefix = "subtask:"
+	// taskPayload starts the /start parameter of a link that opens a task card: "task_<id>".
+	taskPayload = "task_"
+)
+
+// taskDetails is what a task card shows besides the task itself.
+type taskDetails struct {
+	fields    []service.TaskField
+	parent    *model.Task
+	subtasks  []model.Task
+	reminders []model.Reminder
+	files     []model.Attachment
+	referrers []model.Task             // tasks whose descriptions mention this one
+	link      func(taskID uint) string // where a mention of a task leads, nil for no links
+}
+
+// handleTaskCard shows a single task with its actions: /task <id>.
+func (b *Bot) handleTaskCard(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.TrimSpace(msg.CommandArguments())
+	taskID, err := strconv.ParseUint(args, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Укажи ID задачи: /task 12"))
+	}
+	user := CurrentUser(ctx)
+	return b.sendTaskCard(ctx, msg.Chat.ID, user, uint(taskID))
+}
+
+func (b *Bot) sendTaskCard(ctx context.Context, chatID int64, user *model.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	categories := b.categoriesByID(ctx, user)
+	details := b.taskDetails(ctx, user, task)
+	msg := tgbotapi.NewMessage(chatID, formatTaskCard(lang, *task, categories, details, time.Now().In(user.Location())))
+	msg.ParseMode = tgbotapi.ModeHTML
+	markup := taskCardKeyboard(lang, exportTask(*task, details.fields))
+	if len(details.files) > 0 {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, filesButton(lang, task.ID, len(details.files)))
+	}
+	msg.ReplyMarkup = markup
+	sent, err := b.send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if err := b.taskSvc.TrackMessage(ctx, task, chatID, sent.MessageID); err != nil {
+		log.Printf("track task card %d: %v", task.ID, err)
+	}
+	return nil
+}
+
+// openTaskCard shows the card of a task tapped in a list.
+func (b *Bot) openTaskCard(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, chatID, user, taskID)
+}
+
+// taskDetails collects the fields, subtasks, reminders and mentions of the task; errors are logged.
+func (b *Bot) taskDetails(ctx context.Context, user *model.User, task *model.Task) taskDetails {
+	var details taskDetails
+	var err error
+	if details.fields, err = b.fieldSvc.Values(ctx, user, task.ID); err != nil {
+		log.Printf("fields of task %d: %v", task.ID, err)
+	}
+	if details.subtasks, err = b.taskSvc.Subtasks(ctx, user, task.ID); err != nil {
+		log.Printf("subtasks of task %d: %v", task.ID, err)
+	}
+	if task.ParentID != nil {
+		if parent, err := b.taskSvc.GetTask(ctx, user, *task.ParentID); err == nil {
+			details.parent = parent
+		}
+	}
+	if details.reminders, err = b.notificationSvc.List(ctx, user, task.ID); err != nil {
+		log.Printf("reminders of task %d: %v", task.ID, err)
+	}
+	if details.files, err = b.taskSvc.Attachments(ctx, user, task.ID); err != nil {
+		log.Printf("attachments of task %d: %v", task.ID, err)
+	}
+	if details.referrers, err = b.taskSvc.Referrers(ctx, user, task.ID); err != nil {
+		log.Printf("referrers of task %d: %v", task.ID, err)
+	}
+	details.link = b.taskLink
+	return details
+}
+
+func formatTaskCard(lang i18n.Lang, task model.Task, categories map[uint]model.Category, details taskDetails, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🗂 <b>#%d</b> %s\n", task.ID, escape(normalizeTitle(task.Title))))
+	if details.parent != nil {
+		b.WriteString(lang.Tf("• <b>Подзадача для:</b> #%d %s\n", details.parent.ID, escape(normalizeTitle(details.parent.Title))))
+	}
+	_, category := normalizedCategory(lang, task.CategoryID, categories)
+	b.WriteString(lang.Tf("• <b>Категория:</b> %s\n", category))
+	if task.Deadline != nil {
+		b.WriteString(lang.Tf("• <b>Дедлайн:</b> %s\n", task.Deadline.In(now.Location()).Format("2006-01-02")))
+		if task.AlertBeforeHours > 0 {
+			b.WriteString(lang.Tf("• <b>Напоминание:</b> за %s до дедлайна\n", leadLabel(lang, task.AlertBeforeHours)))
+		}
+	}
+	b.WriteString(lang.Tf("• <b>Приоритет:</b> %s\n", priorityLabel(lang, task.Priority)))
+	if task.Escalate {
+		b.WriteString(lang.T("• <b>Партнёр:</b> узнает, если задача просрочится\n"))
+	}
+	if task.RecurEndedAt != nil {
+		b.WriteString(lang.Tf("• <b>Повтор:</b> остановлен %s\n", task.RecurEndedAt.In(now.Location()).Format("2006-01-02")))
+	} else if task.IsRecurring {
+		b.WriteString(lang.Tf("• <b>Повтор:</b> %s (окно ±%d дн.)\n", recurrenceText(lang, task), task.RecurWindow))
+		if next, ok := service.NextOccurrence(task, now); ok {
+			b.WriteString(lang.Tf("• <b>Следующий раз:</b> %s\n", next.Format("2006-01-02")))
+		}
+		if task.CatchUp {
+			b.WriteString(lang.T("• <b>Пропуски:</b> переносятся, пока не отмечены\n"))
+		}
+		if missed, ok := service.MissedOccurrence(task, now); ok {
+			b.WriteString(lang.Tf("• <b>Пропущен повтор:</b> %s\n", missed.Format("2006-01-02")))
+		}
+		if task.ReminderText != "" {
+			b.WriteString(lang.Tf("• <b>Текст напоминания:</b> %s\n", escape(task.ReminderText)))
+		}
+	}
+	switch {
+	case !task.IsRecurring && task.IsCompleted:
+		b.WriteString(lang.T("• <b>Статус:</b> выполнена\n"))
+	case task.LastCompletedAt != nil:
+		b.WriteString(lang.Tf("• <b>Последнее выполнение:</b> %s\n", task.LastCompletedAt.In(now.Location()).Format("2006-01-02")))
+	}
+	b.WriteString(formatTaskFields(details.fields))
+	if task.Description != "" {
+		b.WriteString(fmt.Sprintf("\n📝 %s\n", service.FormatTaskRefs(task.Description, escape, func(taskID uint, mention string) string {
+			return taskRefLink(details.link, taskID, mention)
+		})))
+	}
+	if len(details.referrers) > 0 {
+		mentions := make([]string, len(details.referrers))
+		for i, referrer := range details.referrers {
+			mentions[i] = taskRefLink(details.link, referrer.ID, fmt.Sprintf("#%d", referrer.ID))
+		}
+		b.WriteString(lang.Tf("\n🔗 Упоминается в %s\n", strings.Join(mentions, ", ")))
+	}
+	if len(details.subtasks) > 0 {
+		done := 0
+		var lines strings.Builder
+		for _, subtask := range details.subtasks {
+			mark := "▫️"
+			if subtask.IsCompleted {
+				mark = "✅"
+				done++
+			}
+			lines.WriteString(fmt.Sprintf("%s <b>#%d</b> %s\n", mark, subtask.ID, escape(normalizeTitle(subtask.Title))))
+		}
+		b.WriteString(lang.Tf("\n🪜 <b>Подзадачи</b> %d/%d\n", done, len(details.subtasks)))
+		b.WriteString(lines.String())
+	}
+	b.WriteString(formatTaskHistory(lang, task, details.reminders, now))
+	return strings.TrimSpace(b.String())
+}
+
+// formatTaskHistory tells when the task was created, how often its deadline was put off
+// and which reminders are still ahead.
+func formatTaskHistory(lang i18n.Lang, task model.Task, reminders []model.Reminder, now time.Time) string {
+	var lines []string
+	if !task.CreatedAt.IsZero() {
+		lines = append(lines, lang.T("создана ")+task.CreatedAt.In(now.Location()).Format("2006-01-02"))
+	}
+	if task.Postponements > 0 {
+		lines = append(lines, lang.Tf("дедлайн переносился %d раз", task.Postponements))
+	}
+	if task.PostponeCount > 0 {
+		lines = append(lines, lang.Tf("напоминание откладывалось %d раз", task.PostponeCount))
+	}
+	for _, reminder := range reminders {
+		lines = append(lines, lang.T("🔔 напомнить ")+whenLabel(lang, reminder.At.In(now.Location()), now))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n🕓 " + strings.Join(lines, "\n🕓 ") + "\n"
+}
+
+func taskCardKeyboard(lang i18n.Lang, task model.Task) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	switch {
+	case task.RecurEndedAt != nil:
+		// Only the history is left, so deleting is the one thing to do.
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🗑 Удалить"), fmt.Sprintf("%s%d", cbDeletePrefix, task.ID)),
+		))
+	case task.IsRecurring || !task.IsCompleted:
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Выполнить"), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🗑 Удалить"), fmt.Sprintf("%s%d", cbDeletePrefix, task.ID)),
+		))
+		actions := []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("✏️ Редактировать"), fmt.Sprintf("%s%d", cbEditPrefix, task.ID)),
+		}
+		if !task.IsRecurring {
+			actions = append(actions, tgbotapi.NewInlineKeyboardButtonData(lang.T("⏰ Отложить"), fmt.Sprintf("%s%d", cbPostponePrefix, task.ID)))
+		}
+		rows = append(rows, actions, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("🔔 Напомнить"), fmt.Sprintf("%s%d", cbRemindPrefix, task.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("➕ Подзадача"), fmt.Sprintf("%s%d", cbSubtaskPrefix, task.ID)),
+		))
+	}
+	if googleURL, ok := calendar.GoogleCalendarURL(task); ok {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("📅 Файл .ics"), fmt.Sprintf("%s%d", cbCalendarPrefix, task.ID)),
+			tgbotapi.NewInlineKeyboardButtonURL(lang.T("Google Календарь"), googleURL),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("📤 Поделиться"), fmt.Sprintf("%s%d", cbSharePrefix, task.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("🔀 Объединить"), fmt.Sprintf("%s%d", cbMergePrefix, task.ID)),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// sendTaskICS sends the task deadline as a downloadable calendar event.
+func (b *Bot) sendTaskICS(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	fields, err := b.fieldSvc.Values(ctx, user, task.ID)
+	if err != nil {
+		log.Printf("fields of task %d: %v", task.ID, err)
+	}
+	data, ok := calendar.EventICS(exportTask(*task, fields), time.Now())
+	if !ok {
+		return b.sendText(ctx, chatID, lang.T("У задачи нет дедлайна — добавить в календарь нечего."))
+	}
+	return b.sendDocument(ctx, chatID, fmt.Sprintf("task-%d.ics", task.ID), data, lang.T("Открой файл, чтобы добавить дедлайн в календарь."))
+}
+
+// taskLink is the deep link that opens the card of the task.
+func (b *Bot) taskLink(taskID uint) string {
+	return b.deepLink(fmt.Sprintf("%s%d", taskPayload, taskID))
+}
+
+// taskRefLink renders a mention of a task as a link to its card when link is set.
+func taskRefLink(link func(taskID uint) string, taskID uint, mention string) string {
+	if link == nil {
+		return escape(mention)
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, link(taskID), escape(mention))
+}
+
+// cardButton opens the task card from a list row.
+func cardButton(taskID uint) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData("🔎", fmt.Sprintf("%s%d", cbTaskPrefix, taskID))
+}
+
+// askReminderTime asks when to remind about the task.
+func (b *Bot) askReminderTime(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	b.setConversation(from.ID, &conversationState{stage: stageReminderTime, taskID: taskID})
+	kb := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(lang.T("через 2 часа")),
+			tgbotapi.NewKeyboardButton(lang.T("вечером")),
+			tgbotapi.NewKeyboardButton(lang.T("завтра утром")),
+		),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(lang.T(btnCancelDialog))),
+	)
+	kb.ResizeKeyboard = true
+	kb.OneTimeKeyboard = true
+	return b.sendWithReplyMarkup(ctx, chatID, lang.Tf("🔔 Когда напомнить о задаче #%d? Например <code>18:30</code>, <code>2025-11-30 09:00</code>, «вечером» или «через 2 часа».", taskID), kb)
+}
+
+// finishReminderTime sets the reminder; a time that is not understood keeps the conversation.
+func (b *Bot) finishReminderTime(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	ok, err := b.setTaskReminder(ctx, msg.Chat.ID, user, state.taskID, msg.Text)
+	if !ok {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Не понял время. Напиши, например, <code>18:30</code> или «завтра утром»."), cancelKeyboard(lang))
+	}
+	b.clearConversation(msg.From.ID)
+	return err
+}
+
+// askSubtaskTitle asks for the title of a subtask.
+func (b *Bot) askSubtaskTitle(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	b.setConversation(from.ID, &conversationState{stage: stageSubtask, taskID: taskID})
+	return b.sendWithReplyMarkup(ctx, chatID, lang.Tf("➕ Название подзадачи для #%d. Категория, дедлайн и приоритет достанутся от задачи.", taskID), cancelKeyboard(lang))
+}
+
+// finishSubtask adds the subtask and shows the updated card of its task.
+func (b *Bot) finishSubtask(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	title := strings.TrimSpace(msg.Text)
+	if title == "" {
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Название не может быть пустым."), cancelKeyboard(lang))
+	}
+	b.clearConversation(msg.From.ID)
+	user := CurrentUser(ctx)
+	subtask, err := b.taskSvc.AddSubtask(ctx, user, state.taskID, title)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена или уже удалена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Задача уже выполнена."))
+	case err != nil:
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось добавить подзадачу: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] subtask added id=%d parent=%d user=%d", subtask.ID, state.taskID, user.ID)
+	if err := b.sendText(ctx, msg.Chat.ID, lang.Tf("➕ Подзадача <b>#%d</b> %s добавлена.", subtask.ID, escape(normalizeTitle(subtask.Title)))); err != nil {
+		return err
+	}
+	return b.sendTaskCard(ctx, msg.Chat.ID, user, state.taskID)
+}
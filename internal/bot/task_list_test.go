@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares got with testdata/<name>.golden; run `go test -update` to accept changes.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create): %v", err)
+	}
+	if got != strings.TrimSuffix(string(want), "\n") {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestFormatTaskListGolden(t *testing.T) {
+	now := time.Date(2025, time.February, 28, 9, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day int) *time.Time {
+		d := time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	work, home := uint(1), uint(2)
+	categories := map[uint]model.Category{work: {Name: "работа", Emoji: "💼"}, home: {Name: " Дом "}}
+	afternoon := time.Date(2025, time.February, 28, 15, 0, 0, 0, time.UTC)
+	dueToday := []model.Task{
+		{ID: 11, Title: "Оплатить счёт", Deadline: at(time.February, 28)},
+		{ID: 12, Title: "Встреча", Deadline: &afternoon},
+	}
+
+	cases := []struct {
+		name      string
+		lang      i18n.Lang
+		title     string
+		agingDays int
+		policy    model.DeadlinePolicy
+		tasks     []model.Task
+	}{
+		{name: "task_list_empty"},
+		{
+			name: "task_list_mixed",
+			tasks: []model.Task{
+				{ID: 1, Title: "Без категории и срока"},
+				{ID: 2, Title: "Сдать отчёт", CategoryID: &work, Deadline: at(time.February, 20)},
+				{ID: 3, Title: "Созвон <важный>", CategoryID: &work, Deadline: at(time.March, 1), Description: "подготовить слайды"},
+				{ID: 4, Title: "Аренда квартиры за месяц, не забыть перевести", CategoryID: &home, IsRecurring: true, RecurType: "monthly", RecurDay: 31, RecurWindow: 1},
+				{ID: 5, Title: "Уже выполнено", CategoryID: &home, IsCompleted: true},
+			},
+		},
+		{
+			name: "task_list_mixed_en",
+			lang: i18n.EN,
+			tasks: []model.Task{
+				{ID: 2, Title: "Send the report", CategoryID: &work, Deadline: at(time.February, 20)},
+				{ID: 3, Title: "Call", CategoryID: &work, Deadline: at(time.March, 1)},
+				{ID: 4, Title: "Rent", IsRecurring: true, RecurType: "monthly", RecurDay: 31, RecurWindow: 1},
+			},
+		},
+		{
+			name:  "task_list_workspace",
+			title: " · 🏠 Семья",
+			tasks: []model.Task{
+				{ID: 7, Title: "Купить продукты", CategoryID: &home, Deadline: at(time.March, 3)},
+			},
+		},
+		{
+			name:      "task_list_aging",
+			agingDays: 14,
+			tasks: []model.Task{
+				{ID: 8, Title: "Разобрать антресоль", CreatedAt: *at(time.February, 7)},
+				{ID: 9, Title: "Прочитать статью", CreatedAt: *at(time.February, 20)},
+				{ID: 10, Title: "Продлить страховку", CreatedAt: *at(time.January, 10), Deadline: at(time.March, 10)},
+			},
+		},
+		{
+			name:  "task_list_due_today",
+			tasks: dueToday,
+		},
+		{
+			name:   "task_list_due_today_start_of_day",
+			policy: model.DeadlinePolicy{StartOfDay: true},
+			tasks:  dueToday,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lang := tc.lang
+			if lang == "" {
+				lang = i18n.RU
+			}
+			text, buttons := formatTaskList(lang, tc.tasks, categories, sortPriority, tc.agingDays, tc.policy, tc.title, now)
+			var got strings.Builder
+			got.WriteString(text)
+			for _, row := range buttons {
+				got.WriteString("\n")
+				for i, button := range row {
+					if i > 0 {
+						got.WriteString(" | ")
+					}
+					got.WriteString("[" + button.Text + " → " + *button.CallbackData + "]")
+				}
+			}
+			assertGolden(t, tc.name, got.String())
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+const (
+	cbPagePrefix = "page:"
+	// defaultTaskPageSize applies when TASK_PAGE_SIZE is not configured.
+	defaultTaskPageSize = 15
+)
+
+// listView says which tasks a list shows. It travels in the page buttons as "all",
+// "p<priority>" or "c<category ID>", so any page can be rebuilt from the button alone.
+type listView string
+
+const viewAll listView = "all"
+
+func priorityView(priority string) listView {
+	return listView("p" + priority)
+}
+
+func categoryView(categoryID uint64) listView {
+	return listView("c" + strconv.FormatUint(categoryID, 10))
+}
+
+// keep returns the filter of the view, nil for all tasks.
+func (v listView) keep() func(model.Task) bool {
+	switch {
+	case strings.HasPrefix(string(v), "p"):
+		minRank := model.PriorityRank(string(v[1:]))
+		return func(task model.Task) bool {
+			return model.PriorityRank(task.Priority) >= minRank
+		}
+	case strings.HasPrefix(string(v), "c"):
+		categoryID, err := strconv.ParseUint(string(v[1:]), 10, 64)
+		if err != nil {
+			return func(model.Task) bool { return false }
+		}
+		return func(task model.Task) bool {
+			if categoryID == 0 {
+				return task.CategoryID == nil
+			}
+			return task.CategoryID != nil && uint64(*task.CategoryID) == categoryID
+		}
+	}
+	return nil
+}
+
+// owner picks whose tasks the view lists: category buttons come from personal reports.
+func (v listView) owner(user *model.User) *model.User {
+	if strings.HasPrefix(string(v), "c") {
+		return personalUser(user)
+	}
+	return user
+}
+
+func (b *Bot) taskPageSize() int {
+	if b.config.TaskPageSize > 0 {
+		return b.config.TaskPageSize
+	}
+	return defaultTaskPageSize
+}
+
+// sendTaskPage sends one page of the list or, with a non-zero messageID, redraws that message.
+// In a chat with a dashboard the list is the dashboard: a new one replaces the old message.
+func (b *Bot) sendTaskPage(ctx context.Context, chatID int64, messageID int, user *model.User, view listView, page int) error {
+	lang := i18n.FromContext(ctx)
+	owner := view.owner(user)
+	tasks, err := b.taskSvc.ListActive(ctx, owner)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить задачи: %s", errorText(lang, err)))
+	}
+	now := time.Now()
+	board := b.trackedDashboard(ctx, chatID)
+	dashboard, previous := board != nil, 0
+	if dashboard {
+		previous = board.MessageID
+	}
+	// Buttons under an older list still redraw it, but the dashboard stays the tracked list.
+	untracked := dashboard && messageID != 0 && messageID != previous
+	if untracked {
+		dashboard = false
+	}
+	var header string
+	if dashboard {
+		header = b.dashboardHeader(ctx, lang, owner, tasks, now)
+	}
+	if keep := view.keep(); keep != nil {
+		kept := tasks[:0]
+		for _, task := range tasks {
+			if keep(task) {
+				kept = append(kept, task)
+			}
+		}
+		tasks = kept
+	}
+
+	categories := b.categoriesByID(ctx, owner)
+	order := listSort(*user, view)
+	ordered := orderTaskList(lang, tasks, categories, order, taskOrder{user.DeadlinePolicy(), now.Location()})
+	var text string
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	if len(ordered) == 0 {
+		text = lang.T("У тебя нет активных задач. Добавь новую через /newtask.")
+		if view != viewAll {
+			text = lang.T("Здесь больше нет открытых задач.")
+		}
+		if !dashboard {
+			if messageID != 0 {
+				_, err := b.api.Request(renderFor(ctx, tgbotapi.NewEditMessageText(chatID, messageID, text)))
+				return err
+			}
+			return b.sendText(ctx, chatID, text)
+		}
+		page = 0
+	} else {
+		size := b.taskPageSize()
+		pages := (len(ordered) + size - 1) / size
+		page = min(max(page, 0), pages-1)
+		text, buttons = formatTaskList(lang, ordered[page*size:min(len(ordered), (page+1)*size)], categories, order, b.config.TaskAgingDays, user.DeadlinePolicy(), b.workspaceTitle(ctx, owner), now)
+		if len(ordered) > 1 {
+			buttons = append(buttons, sortButtons(lang, view, order))
+		}
+		if pages > 1 {
+			buttons = append(buttons, pageButtons(view, page, pages))
+		}
+	}
+	if dashboard {
+		text = header + text
+		buttons = append(buttons, dashboardButtons(lang, view, page))
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(buttons...)
+
+	if messageID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup)
+		edit.ParseMode = tgbotapi.ModeHTML
+		if _, err := b.api.Request(renderFor(ctx, edit)); err != nil {
+			return err
+		}
+	} else {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = markup
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableNotification = dashboard
+		sent, err := b.send(ctx, msg)
+		if err != nil {
+			return err
+		}
+		messageID = sent.MessageID
+		if dashboard {
+			b.replaceDashboard(chatID, previous, messageID)
+		}
+	}
+	if untracked {
+		return nil
+	}
+	if err := b.taskSvc.TrackList(ctx, owner, chatID, messageID, string(view), page, dashboard); err != nil {
+		log.Printf("track list chat=%d: %v", chatID, err)
+	}
+	return nil
+}
+
+// refreshLists redraws the tracked task lists that show the scope, so a list left open on
+// another device or in a workspace member's chat stays current. Lists that can no longer
+// be edited, e.g. because the message was deleted, and workspace lists of users who have
+// since left or been removed are forgotten.
+func (b *Bot) refreshLists(ctx context.Context, scope model.Scope) {
+	lists, err := b.taskSvc.TrackedLists(ctx, scope)
+	if err != nil {
+		log.Printf("tracked lists: %v", err)
+		return
+	}
+	for _, list := range lists {
+		user, err := b.userRepo.FindByID(ctx, list.UserID)
+		if err != nil {
+			log.Printf("refresh list chat=%d: %v", list.ChatID, err)
+			continue
+		}
+		if list.WorkspaceID != 0 {
+			member, err := b.workspaceSvc.IsMember(ctx, list.WorkspaceID, user.ID)
+			if err != nil {
+				log.Printf("refresh list chat=%d: %v", list.ChatID, err)
+				continue
+			}
+			if !member {
+				if err := b.taskSvc.ForgetList(ctx, list.ChatID); err != nil {
+					log.Printf("forget list chat=%d: %v", list.ChatID, err)
+				}
+				continue
+			}
+		}
+		user.ActiveWorkspaceID = list.WorkspaceID
+		err = b.sendTaskPage(ctx, list.ChatID, list.MessageID, user, listView(list.View), list.Page)
+		if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+			log.Printf("refresh list chat=%d: %v", list.ChatID, err)
+			if err := b.taskSvc.ForgetList(ctx, list.ChatID); err != nil {
+				log.Printf("forget list chat=%d: %v", list.ChatID, err)
+			}
+		}
+	}
+}
+
+// pageButtons is the «⬅️ 2/5 ➡️» row under a long list; the counter in the middle does nothing.
+func pageButtons(view listView, page, pages int) []tgbotapi.InlineKeyboardButton {
+	button := func(label string, target int) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%s:%d", cbPagePrefix, view, target))
+	}
+	var row []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		row = append(row, button("⬅️", page-1))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, pages), cbPagePrefix+"-"))
+	if page < pages-1 {
+		row = append(row, button("➡️", page+1))
+	}
+	return row
+}
+
+// handlePageButton turns the list message to another page in place.
+func (b *Bot) handlePageButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	rawView, rawPage, ok := strings.Cut(payload, ":")
+	if !ok {
+		return nil
+	}
+	page, err := strconv.Atoi(rawPage)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	log.Printf("[info] task list page user=%d view=%s page=%d", user.ID, rawView, page)
+	return b.sendTaskPage(ctx, cb.Message.Chat.ID, cb.Message.MessageID, user, listView(rawView), page)
+}
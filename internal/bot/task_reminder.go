@@ -0,0 +1,209 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const remindUsage = "Формат:\n" +
+	"• /remind &lt;id&gt; 2025-11-30 09:00 — напомнить о задаче в это время\n" +
+	"• /remind &lt;id&gt; 18:30 или «завтра утром», «через 2 часа»\n" +
+	"• /remind &lt;id&gt; — напоминания задачи\n" +
+	"• /remind del &lt;номер&gt; — удалить напоминание"
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+
+// handleRemind sets, lists and removes reminders on single tasks.
+func (b *Bot) handleRemind(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(remindUsage))
+	}
+	user := CurrentUser(ctx)
+	if args[0] == "del" {
+		if len(args) != 2 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(remindUsage))
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(remindUsage))
+		}
+		removed, err := b.notificationSvc.Remove(ctx, user, uint(id))
+		switch {
+		case err != nil:
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось удалить напоминание: %s", errorText(lang, err)))
+		case !removed:
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Напоминание не найдено."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🗑 Напоминание удалено."))
+	}
+
+	taskID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(remindUsage))
+	}
+	if len(args) == 1 {
+		return b.sendTaskReminders(ctx, msg.Chat.ID, user, uint(taskID))
+	}
+
+	ok, err := b.setTaskReminder(ctx, msg.Chat.ID, user, uint(taskID), strings.Join(args[1:], " "))
+	if !ok {
+		return b.sendText(ctx, msg.Chat.ID, lang.T(remindUsage))
+	}
+	return err
+}
+
+// setTaskReminder adds a reminder at the moment described by when and reports the outcome.
+// It returns false without sending anything when when is not understood.
+func (b *Bot) setTaskReminder(ctx context.Context, chatID int64, user *model.User, taskID uint, when string) (bool, error) {
+	lang := i18n.FromContext(ctx)
+	now := time.Now()
+	local := now.In(user.Location())
+	at, ok := parseReminderTime(when, local, service.UserWorkingHours(*user))
+	if !ok {
+		return false, nil
+	}
+	reminder, err := b.notificationSvc.Add(ctx, user, taskID, at, now)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return true, b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+	case errors.Is(err, service.ErrTaskCompleted):
+		return true, b.sendText(ctx, chatID, lang.T("Задача уже выполнена, напоминать не о чем."))
+	case errors.Is(err, service.ErrReminderInPast):
+		return true, b.sendText(ctx, chatID, lang.T("Это время уже прошло — укажи момент в будущем."))
+	case errors.Is(err, service.ErrTooManyReminders):
+		return true, b.sendText(ctx, chatID, lang.T("У задачи уже слишком много напоминаний. Удали лишние: /remind del &lt;номер&gt;"))
+	case err != nil:
+		return true, b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить напоминание: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] task reminder set id=%d task=%d user=%d at=%s", reminder.ID, reminder.TaskID, user.ID, at.Format(time.RFC3339))
+	return true, b.sendText(ctx, chatID, lang.Tf("🔔 Напомню о задаче #%d %s.", reminder.TaskID, whenLabel(lang, at.In(local.Location()), local)))
+}
+
+func (b *Bot) sendTaskReminders(ctx context.Context, chatID int64, user *model.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	reminders, err := b.notificationSvc.List(ctx, user, task.ID)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить напоминания: %s", errorText(lang, err)))
+	}
+	if len(reminders) == 0 {
+		return b.sendText(ctx, chatID, lang.Tf("У задачи #%d нет напоминаний. Добавить: /remind %d завтра утром", task.ID, task.ID))
+	}
+	local := time.Now().In(user.Location())
+	var builder strings.Builder
+	builder.WriteString(lang.Tf("🔔 <b>Напоминания</b> о «%s»\n", escape(normalizeTitle(task.Title))))
+	for _, reminder := range reminders {
+		builder.WriteString(fmt.Sprintf("%d. %s\n", reminder.ID, whenLabel(lang, reminder.At.In(local.Location()), local)))
+	}
+	builder.WriteString(lang.T("Удалить: /remind del &lt;номер&gt;"))
+	return b.sendText(ctx, chatID, builder.String())
+}
+
+// parseReminderTime accepts "2006-01-02 15:04", a clock time for the next such
+// moment, or a relative phrase like "завтра утром". now must be in the user's time zone.
+func parseReminderTime(text string, now time.Time, hours service.WorkingHours) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	if at, err := time.ParseInLocation("2006-01-02 15:04", text, now.Location()); err == nil {
+		return at, true
+	}
+	if m := clockPattern.FindStringSubmatch(text); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour > 23 || minute > 59 {
+			return time.Time{}, false
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, true
+	}
+	return service.ParseRelativeTime(text, now, hours)
+}
+
+// SendTaskReminders delivers the task reminders whose time has come.
+func (b *Bot) SendTaskReminders(ctx context.Context) error {
+	now := time.Now()
+	due, err := b.notificationSvc.Due(ctx, now)
+	if err != nil {
+		return err
+	}
+	owners := make(map[uint]*model.User)
+	for _, item := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		user, ok := owners[item.Reminder.UserID]
+		if !ok {
+			user, err = b.userRepo.FindByID(ctx, item.Reminder.UserID)
+			if err != nil {
+				log.Printf("task reminder owner of reminder %d: %v", item.Reminder.ID, err)
+				continue
+			}
+			owners[item.Reminder.UserID] = user
+		}
+		if user.ArchivedAt == nil {
+			lang := user.Lang()
+			msg := tgbotapi.NewMessage(user.TelegramID, taskReminderText(lang, item.Task, now.In(user.Location()), user.DeadlineCountdown))
+			msg.ParseMode = tgbotapi.ModeHTML
+			msg.ReplyMarkup = taskReminderKeyboard(lang, item.Task)
+			if sent, err := b.send(ctx, msg); err != nil {
+				log.Printf("send task reminder %d to %d: %v", item.Reminder.ID, user.TelegramID, err)
+			} else if err := b.trackReminder(ctx, user, &item.Task, sent.MessageID); err != nil {
+				log.Printf("track task reminder %d: %v", item.Reminder.ID, err)
+			}
+		}
+		if err := b.notificationSvc.MarkSent(ctx, &item.Reminder, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackReminder tracks a reminder message, with a countdown when the user wants one and the task has a deadline.
+func (b *Bot) trackReminder(ctx context.Context, user *model.User, task *model.Task, messageID int) error {
+	if user.DeadlineCountdown && task.Deadline != nil && !task.IsRecurring {
+		return b.taskSvc.TrackCountdown(ctx, task, user.TelegramID, messageID, model.CountdownReminder)
+	}
+	return b.taskSvc.TrackMessage(ctx, task, user.TelegramID, messageID)
+}
+
+// taskReminderText is the text of a reminder as of now; with countdown, a deadline within
+// the hour adds the minutes left.
+func taskReminderText(lang i18n.Lang, task model.Task, now time.Time, countdown bool) string {
+	text := lang.Tf("🔔 Напоминание: «%s» (#%d)", escape(normalizeTitle(task.Title)), task.ID)
+	if task.IsRecurring && task.ReminderText != "" {
+		text += "\n" + escape(task.ReminderText)
+	}
+	if minutes, ok := service.CountdownLeft(task, now); ok && countdown {
+		text += lang.Tf("\n⏳ До срока %s, в %s", minutesLeft(lang, minutes), task.Deadline.In(now.Location()).Format("15:04"))
+	}
+	return text
+}
+
+func taskReminderKeyboard(lang i18n.Lang, task model.Task) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("✅ Выполнить"), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID)),
+	))
+}
@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const cbSortPrefix = "sort:"
+
+// Orders of tasks within a category of a list.
+const (
+	sortPriority = "priority"
+	sortDeadline = "deadline"
+	sortCreated  = "created"
+)
+
+// sortOrders lists the orders in the order of their buttons.
+var sortOrders = []string{sortPriority, sortDeadline, sortCreated}
+
+var sortLabels = map[string]string{
+	sortPriority: i18n.N("❗ Приоритет"),
+	sortDeadline: i18n.N("⏰ Дедлайн"),
+	sortCreated:  i18n.N("🆕 Новые"),
+}
+
+// taskOrder is what deadline comparisons depend on: the reader's policy and time zone.
+type taskOrder struct {
+	policy model.DeadlinePolicy
+	loc    *time.Location
+}
+
+// deadlines compares the deadlines of a and b by the moment they fall due, missing ones last.
+func (o taskOrder) deadlines(a, b model.Task) int {
+	return service.CompareDeadlines(a.Deadline, b.Deadline, o.loc, o.policy)
+}
+
+var sortComparators = map[string]func(o taskOrder, a, b model.Task) bool{
+	sortPriority: byPriority,
+	sortDeadline: byDeadline,
+	sortCreated:  byCreated,
+}
+
+// byPriority puts higher priority first, then the nearer deadline, one-time before recurring, ID.
+func byPriority(o taskOrder, a, b model.Task) bool {
+	if ra, rb := model.PriorityRank(a.Priority), model.PriorityRank(b.Priority); ra != rb {
+		return ra > rb
+	}
+	if c := o.deadlines(a, b); c != 0 {
+		return c < 0
+	}
+	if a.IsRecurring != b.IsRecurring {
+		return !a.IsRecurring && b.IsRecurring
+	}
+	return a.ID < b.ID
+}
+
+// byDeadline puts the nearer deadline first and tasks without one last, then falls back to byPriority.
+func byDeadline(o taskOrder, a, b model.Task) bool {
+	if c := o.deadlines(a, b); c != 0 {
+		return c < 0
+	}
+	return byPriority(o, a, b)
+}
+
+// byCreated puts the newest tasks first.
+func byCreated(_ taskOrder, a, b model.Task) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// listSort returns the order the user last chose for the view.
+func listSort(user model.User, view listView) string {
+	for _, pair := range strings.Split(user.ListSorts, ",") {
+		if name, order, ok := strings.Cut(pair, "="); ok && name == string(view) {
+			if _, known := sortComparators[order]; known {
+				return order
+			}
+		}
+	}
+	return sortPriority
+}
+
+// withListSort returns the stored sorts with the view's order replaced; the default order is not stored.
+func withListSort(stored string, view listView, order string) string {
+	var pairs []string
+	for _, pair := range strings.Split(stored, ",") {
+		if name, _, ok := strings.Cut(pair, "="); ok && name != string(view) {
+			pairs = append(pairs, pair)
+		}
+	}
+	if order != sortPriority {
+		pairs = append(pairs, string(view)+"="+order)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// sortButtons is the row that switches the order of the list, the current one marked.
+func sortButtons(lang i18n.Lang, view listView, current string) []tgbotapi.InlineKeyboardButton {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(sortOrders))
+	for _, order := range sortOrders {
+		label := lang.T(sortLabels[order])
+		if order == current {
+			label = "· " + label + " ·"
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%s:%s", cbSortPrefix, view, order)))
+	}
+	return row
+}
+
+// handleSortButton remembers the order chosen for the view and redraws the list from its first page.
+func (b *Bot) handleSortButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	rawView, order, ok := strings.Cut(payload, ":")
+	if _, known := sortComparators[order]; !ok || !known {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	view := listView(rawView)
+	if order != listSort(*user, view) {
+		if err := b.userRepo.SetListSorts(ctx, user, withListSort(user.ListSorts, view, order)); err != nil {
+			return err
+		}
+	}
+	log.Printf("[info] task list sort user=%d view=%s order=%s", user.ID, rawView, order)
+	return b.sendTaskPage(ctx, cb.Message.Chat.ID, cb.Message.MessageID, user, view, 0)
+}
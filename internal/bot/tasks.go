@@ -0,0 +1,688 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// registerTasks wires up creating, listing, completing and editing tasks.
+func (b *Bot) registerTasks(r *router) {
+	r.command("newtask", "добавить задачу пошагово", b.startNewTaskConversation)
+	r.command("add", "задача одной строкой", b.handleAdd)
+	r.command("tasks", "активные задачи", b.handleListTasks)
+	r.command("dashboard", "закреплённая панель задач", b.handleDashboard)
+	r.command("search", "поиск по задачам", b.handleSearch)
+	r.command("task", "карточка задачи", b.handleTaskCard)
+	r.command("complete", "отметить задачу выполненной", b.handleComplete)
+	r.command("delete", "удалить задачу", b.handleDelete)
+	r.command("merge", "объединить задачу с дубликатом", b.handleMerge)
+	r.command("edit", "изменить задачу", b.handleEdit)
+	r.command("fields", "свои поля задач", b.handleFields)
+	r.command("remind", "напомнить о задаче в точное время", b.handleRemind)
+	r.command("postpone", "отложить дедлайн задачи", b.handlePostpone)
+	r.command("done", "выполненные задачи", b.handleDone)
+	r.command("history", "", b.handleDone)
+	r.command("calendarweek", "неделя по дням", b.handleCalendarWeek)
+	r.command("archive", "задачи в архиве", b.handleArchive)
+	r.command("trash", "корзина удалённых задач", b.handleTrash)
+	r.command("ics", "подписаться на календарь", b.handleICS)
+
+	r.callback(callbackRoute{prefix: cbCompletePrefix, selfAck: true, handle: loggedCallback("complete request", b.handleCompleteButton)})
+	r.callback(callbackRoute{prefix: cbDeletePrefix, selfAck: true, handle: loggedCallback("delete request", b.handleDeleteButton)})
+	r.callback(callbackRoute{prefix: cbConfirmPrefix, selfAck: true, handle: loggedCallback("confirm complete", b.handleConfirmComplete)})
+	r.callback(callbackRoute{prefix: cbDeleteConfirmPrefix, selfAck: true, handle: loggedCallback("confirm delete", b.handleConfirmDelete)})
+	r.callback(callbackRoute{prefix: cbStopRepeatsPrefix, selfAck: true, handle: loggedCallback("stop repeats", b.handleStopRepeats)})
+	r.callback(callbackRoute{prefix: cbCancelPrefix, selfAck: true, handle: loggedCallback("cancel", b.handleCancelButton)})
+	r.callback(callbackRoute{prefix: cbReopenPrefix, selfAck: true, handle: loggedCallback("reopen", b.handleReopenButton)})
+	r.callback(callbackRoute{prefix: cbUndeletePrefix, selfAck: true, handle: loggedCallback("undelete", b.handleUndeleteButton)})
+	r.callback(callbackRoute{prefix: cbWeekPrefix, handle: b.handleWeekButton})
+	r.callback(callbackRoute{prefix: cbCalendarPrefix, handle: taskCallback(b.sendTaskICS)})
+	r.callback(callbackRoute{prefix: cbFilesPrefix, handle: taskCallback(b.sendAttachments)})
+	r.callback(callbackRoute{prefix: cbSharePrefix, handle: taskCallback(b.shareTask)})
+	r.callback(callbackRoute{prefix: cbTaskPrefix, handle: loggedCallback("task card", taskCallback(b.openTaskCard))})
+	r.callback(callbackRoute{prefix: cbRemindPrefix, handle: taskCallback(b.askReminderTime)})
+	r.callback(callbackRoute{prefix: cbSubtaskPrefix, handle: taskCallback(b.askSubtaskTitle)})
+	r.callback(callbackRoute{prefix: cbMergePrefix, handle: taskCallback(b.askMergeDuplicate)})
+	r.callback(callbackRoute{prefix: cbUnmergePrefix, selfAck: true, handle: loggedCallback("undo merge", b.handleUnmergeButton)})
+	r.callback(callbackRoute{prefix: cbPostponePrefix, handle: loggedCallback("postpone", b.handlePostponeButton)})
+	r.callback(callbackRoute{prefix: cbPagePrefix, handle: b.handlePageButton})
+	r.callback(callbackRoute{prefix: cbSortPrefix, handle: b.handleSortButton})
+	r.callback(callbackRoute{prefix: cbEditPrefix, handle: b.handleEditButton})
+	r.callback(callbackRoute{prefix: cbDatePrefix, selfAck: true, handle: b.handleDatePicker})
+	r.callback(callbackRoute{prefix: cbWeekdaysPrefix, selfAck: true, handle: b.handleWeekdayPicker})
+	r.callback(callbackRoute{prefix: cbSnoozePrefix, handle: b.handleSnoozeButton})
+	r.callback(callbackRoute{prefix: cbNudgePrefix, handle: b.handleNudgeAnswer})
+	r.callback(callbackRoute{prefix: cbTriagePrefix, handle: b.handleTriageAnswer})
+
+	r.conversation(b.handleCreationStep, creationStages...)
+	r.conversation(b.finishEdit, stageEdit)
+	r.conversation(b.finishReminderTime, stageReminderTime)
+	r.conversation(b.finishSubtask, stageSubtask)
+	r.conversation(b.finishPostpone, stagePostpone)
+	r.conversation(b.finishMerge, stageMerge)
+	r.conversation(func(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+		return b.finishBreakdown(ctx, msg, state.taskID)
+	}, stageBreakdown)
+}
+
+// reminderTextPrompt asks for the optional reminder text of a recurring task.
+func reminderTextPrompt(lang i18n.Lang) string {
+	return lang.Tf("💬 Текст напоминания для отчёта, если названия мало (или «Пропустить»).\nПодстановки: %s\nНапример: <code>Передать показания, осталось {days_left} дн., в прошлый раз {last_done}</code>",
+		strings.Join(service.ReminderPlaceholders, ", "))
+}
+
+func (b *Bot) startNewTaskConversation(ctx context.Context, msg *tgbotapi.Message) error {
+	return b.startNewTask(ctx, msg.Chat.ID, msg.From)
+}
+
+func (b *Bot) startNewTask(ctx context.Context, chatID int64, from *tgbotapi.User) error {
+	lang := i18n.FromContext(ctx)
+	if _, err := b.ensureUser(ctx, from); err != nil {
+		return err
+	}
+	log.Printf("[info] start new task conversation user=%d", from.ID)
+	b.setConversation(from.ID, &conversationState{stage: stageTitle})
+	return b.sendWithReplyMarkup(ctx, chatID, lang.T("🆕 Создаём новую задачу.\n<b>Шаг 1:</b> как её назвать?"), cancelKeyboard(lang))
+}
+
+// creationStages are the steps of the /newtask dialog.
+var creationStages = []conversationStage{
+	stageTitle, stageDescription, stageFields, stageCategory, stageDeadline, stagePriority, stageRecurring,
+	stageRecurringFrequency, stageRecurringDay, stageRecurringWeekday, stageRecurringInterval,
+	stageRecurringWindow, stageReminderText,
+}
+
+// handleCreationStep takes the answer to the current /newtask step and asks the next question.
+func (b *Bot) handleCreationStep(ctx context.Context, msg *tgbotapi.Message, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	text := strings.TrimSpace(msg.Text)
+	switch state.stage {
+	case stageTitle:
+		state.input.Title = text
+		state.stage = stageDescription
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T(descriptionPrompt), descriptionKeyboard(lang))
+	case stageDescription:
+		if !isSkipInput(text) && !isDoneInput(text) {
+			return b.collectDescription(ctx, msg, state)
+		}
+		if fields := b.userFields(ctx, msg.From); len(fields) > 0 {
+			state.stage = stageFields
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, fieldsPrompt(lang, fields, false), skipKeyboard(lang))
+		}
+		state.stage = stageCategory
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("🏷 Выбери категорию или отправь свою (можно «Пропустить»)."), categoryKeyboard(lang, b.archivedCategoryNames(ctx, msg.From)))
+	case stageFields:
+		if !isSkipInput(text) {
+			values, ok := parseFieldLines(text)
+			if !ok {
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Пиши поля строками <code>название: значение</code> или нажми «Пропустить»."), skipKeyboard(lang))
+			}
+			user := CurrentUser(ctx)
+			if err := b.fieldSvc.Check(ctx, user, values); err != nil {
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, fieldError(lang, err), skipKeyboard(lang))
+			}
+			state.fields = values
+		}
+		state.stage = stageCategory
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("🏷 Выбери категорию или отправь свою (можно «Пропустить»)."), categoryKeyboard(lang, b.archivedCategoryNames(ctx, msg.From)))
+	case stageCategory:
+		if !isSkipInput(text) {
+			state.input.Category = text
+			if hint := b.defaultDeadlineHint(ctx, msg.From, text); hint != "" {
+				// The category sets the deadline, so the deadline step is skipped.
+				state.stage = stagePriority
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, hint+"\n"+lang.T(priorityPrompt), priorityKeyboard(lang, true))
+			}
+		}
+		state.stage = stageDeadline
+		return b.sendDatePicker(ctx, msg.Chat.ID, lang.T("⏰ Выбери дедлайн в календаре, напиши дату вроде <code>2025-11-30</code> или нажми «Пропустить»."), datePickSkip)
+	case stageDeadline:
+		if !isSkipInput(text) {
+			parsed, err := time.Parse("2006-01-02", text)
+			if err != nil {
+				return b.sendDatePicker(ctx, msg.Chat.ID, lang.T("Не могу распознать дату. Выбери день в календаре или напиши его как <code>2025-11-30</code>."), datePickSkip)
+			}
+			state.input.Deadline = &parsed
+		}
+		state.stage = stagePriority
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T(priorityPrompt), priorityKeyboard(lang, true))
+	case stagePriority:
+		if !isSkipInput(text) {
+			priority, ok := parsePriority(text)
+			if !ok {
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Выбери приоритет кнопкой или нажми «Пропустить»."), priorityKeyboard(lang, true))
+			}
+			state.input.Priority = priority
+		}
+		state.stage = stageRecurring
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("🔁 Сделать задачу повторяющейся?"), yesNoKeyboard(lang))
+	case stageRecurring:
+		lower := strings.ToLower(text)
+		if lower == "да" || lower == "yes" || lower == "y" {
+			state.input.IsRecurring = true
+			state.stage = stageRecurringFrequency
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("🔁 Как часто повторять?"), frequencyKeyboard(lang))
+		}
+		if lower == "нет" || lower == "no" || lower == "n" || lower == "-" {
+			state.input.IsRecurring = false
+			err := b.finishTaskCreation(ctx, msg.From, state.input, state.fields, state.attachments, msg.Chat.ID)
+			b.clearConversation(msg.From.ID)
+			return err
+		}
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Нажми «Да» или «Нет»."), yesNoKeyboard(lang))
+	case stageRecurringFrequency:
+		if months, ok := parseMonths(text); ok {
+			state.input.RecurType, state.input.RecurInterval = model.RecurMonthly, months
+			return b.askRecurringWindow(ctx, msg.Chat.ID, state)
+		}
+		recurType, askInterval, ok := parseFrequency(text)
+		if !ok {
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Выбери частоту кнопкой."), frequencyKeyboard(lang))
+		}
+		state.input.RecurType = recurType
+		switch {
+		case askInterval:
+			state.stage = stageRecurringInterval
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.Tf("🔢 Через сколько дней повторять? (2–%d)", service.MaxRecurInterval), tgbotapi.NewRemoveKeyboard(true))
+		case recurType == model.RecurDaily:
+			state.input.RecurInterval = 1
+			return b.askRecurringWindow(ctx, msg.Chat.ID, state)
+		case recurType == model.RecurWeekly:
+			state.stage = stageRecurringWeekday
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T(weekdaysPrompt), weekdayPicker(lang, state.input.RecurWeekdays))
+		}
+		state.stage = stageRecurringDay
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("📆 В какой день месяца напоминать? (1–31). Если числа нет в месяце, возьмём последний день."), tgbotapi.NewRemoveKeyboard(true))
+	case stageRecurringWeekday:
+		set, ok := parseWeekdays(text)
+		if !ok {
+			return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T(weekdaysPrompt), weekdayPicker(lang, state.input.RecurWeekdays))
+		}
+		state.input.RecurWeekdays = set
+		return b.askRecurringWindow(ctx, msg.Chat.ID, state)
+	case stageRecurringInterval:
+		interval, err := strconv.Atoi(text)
+		if err != nil || interval < 2 || interval > service.MaxRecurInterval {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Интервал должен быть числом от 2 до %d.", service.MaxRecurInterval))
+		}
+		state.input.RecurInterval = interval
+		return b.askRecurringWindow(ctx, msg.Chat.ID, state)
+	case stageRecurringDay:
+		day, err := strconv.Atoi(text)
+		if err != nil || day < 1 || day > 31 {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("День должен быть числом от 1 до 31."))
+		}
+		state.input.RecurDay = day
+		return b.askRecurringWindow(ctx, msg.Chat.ID, state)
+	case stageRecurringWindow:
+		window, err := strconv.Atoi(text)
+		if limit := service.MaxWindow(state.input); err != nil || window < 0 || window > limit {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Окно должно быть числом от 0 до %d.", limit))
+		}
+		state.input.RecurWindow = window
+		state.stage = stageReminderText
+		return b.sendWithReplyMarkup(ctx, msg.Chat.ID, reminderTextPrompt(lang), skipKeyboard(lang))
+	case stageReminderText:
+		if !isSkipInput(text) {
+			if err := service.ValidateReminderText(text); err != nil {
+				return b.sendWithReplyMarkup(ctx, msg.Chat.ID, lang.T("Не получилось разобрать подстановки. ")+reminderTextPrompt(lang), skipKeyboard(lang))
+			}
+			state.input.ReminderText = text
+		}
+		err := b.finishTaskCreation(ctx, msg.From, state.input, state.fields, state.attachments, msg.Chat.ID)
+		b.clearConversation(msg.From.ID)
+		return err
+	}
+	return nil
+}
+
+// askRecurringWindow asks for the completion window, skipping it when the
+// recurrence leaves no room for one, as with every-day tasks.
+func (b *Bot) askRecurringWindow(ctx context.Context, chatID int64, state *conversationState) error {
+	lang := i18n.FromContext(ctx)
+	if service.MaxWindow(state.input) == 0 {
+		state.stage = stageReminderText
+		return b.sendWithReplyMarkup(ctx, chatID, reminderTextPrompt(lang), skipKeyboard(lang))
+	}
+	state.stage = stageRecurringWindow
+	return b.sendWithReplyMarkup(ctx, chatID, windowPrompt(lang, state.input), tgbotapi.NewRemoveKeyboard(true))
+}
+
+// finishTaskCreation creates the task with its custom field values, keyed by field name, and attachments.
+func (b *Bot) finishTaskCreation(ctx context.Context, from *tgbotapi.User, input service.TaskInput, fields map[string]string, attachments []model.Attachment, chatID int64) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	task, err := b.taskSvc.CreateTask(ctx, user, input)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить задачу: %s", errorText(lang, err)))
+	}
+	if err := b.fieldSvc.SetValues(ctx, user, task.ID, fields); err != nil {
+		log.Printf("set fields of task %d: %v", task.ID, err)
+		if err := b.sendText(ctx, chatID, fieldError(lang, err)); err != nil {
+			return err
+		}
+	}
+
+	if err := b.taskSvc.Attach(ctx, user, task.ID, attachments); err != nil {
+		log.Printf("attach files to task %d: %v", task.ID, err)
+		if err := b.sendText(ctx, chatID, lang.Tf("Не удалось сохранить вложения: %s", errorText(lang, err))); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[info] task created id=%d user=%d recurring=%t attachments=%d", task.ID, user.ID, task.IsRecurring, len(attachments))
+
+	var summary strings.Builder
+	summary.WriteString(lang.Tf("✅ <b>Задача сохранена</b>%s\n", b.workspaceTitle(ctx, user)))
+	summary.WriteString(fmt.Sprintf("• <b>ID:</b> %d\n", task.ID))
+	summary.WriteString(lang.Tf("• <b>Название:</b> %s\n", escape(normalizeTitle(task.Title))))
+	if task.Description != "" {
+		summary.WriteString(lang.Tf("• <b>Описание:</b> %s\n", escape(task.Description)))
+	}
+	if len(attachments) > 0 {
+		summary.WriteString(lang.Tf("• <b>Вложения:</b> %d\n", len(attachments)))
+	}
+	if task.Deadline != nil {
+		summary.WriteString(lang.Tf("• <b>Дедлайн:</b> %s\n", task.Deadline.Format("2006-01-02")))
+	}
+	if task.Priority != "" && task.Priority != model.PriorityNormal {
+		summary.WriteString(lang.Tf("• <b>Приоритет:</b> %s\n", priorityLabel(lang, task.Priority)))
+	}
+	if task.IsRecurring {
+		summary.WriteString(lang.Tf("• <b>Повтор:</b> %s (окно +%d дн.)\n", recurrenceText(lang, *task), task.RecurWindow))
+	}
+	if task.ReminderText != "" {
+		summary.WriteString(lang.Tf("• <b>Текст напоминания:</b> %s\n", escape(task.ReminderText)))
+	}
+	if values, err := b.fieldSvc.Values(ctx, user, task.ID); err == nil {
+		summary.WriteString(formatTaskFields(values))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, strings.TrimSpace(summary.String()))
+	msg.ReplyMarkup = b.closingMarkup(lang)
+	msg.ParseMode = tgbotapi.ModeHTML
+	if _, err := b.send(ctx, msg); err != nil {
+		return err
+	}
+	return b.sendTaskList(ctx, chatID, user)
+}
+
+func (b *Bot) handleListTasks(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		log.Printf("[info] list tasks for user=%d", user.ID)
+		return b.sendTaskList(ctx, msg.Chat.ID, user)
+	}
+	// "/tasks high" keeps the tasks of that priority and above.
+	priority, ok := parsePriority(args)
+	if !ok {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /tasks или /tasks high — задачи с приоритетом не ниже указанного (urgent, high, normal, low)."))
+	}
+	log.Printf("[info] list tasks for user=%d priority=%s", user.ID, priority)
+	return b.sendTaskPage(ctx, msg.Chat.ID, 0, user, priorityView(priority), 0)
+}
+
+func (b *Bot) handleComplete(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Укажи ID задачи: /complete 12"))
+	}
+
+	taskID64, err := strconv.ParseUint(args, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("ID задачи должен быть числом."))
+	}
+
+	user := CurrentUser(ctx)
+
+	now := time.Now()
+	task, err := b.taskSvc.CompleteTask(ctx, user, uint(taskID64), now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	if caughtUp(*task, now) {
+		return b.sendTransient(ctx, msg.Chat.ID, caughtUpText(lang, escape(normalizeTitle(task.Title)), *task.CaughtUpTo), mainMenuKeyboard(lang))
+	}
+	if task.IsRecurring {
+		return b.sendTransient(ctx, msg.Chat.ID, lang.Tf("✅ Повторяющаяся задача «%s» отмечена выполненной в этом окне.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+	}
+
+	return b.sendTransient(ctx, msg.Chat.ID, lang.Tf("✅ Задача «%s» выполнена.", escape(normalizeTitle(task.Title))), mainMenuKeyboard(lang))
+}
+
+func (b *Bot) handleConfirmationResponse(ctx context.Context, msg *tgbotapi.Message, req confirmationRequest) error {
+	lang := i18n.FromContext(ctx)
+	text := strings.TrimSpace(msg.Text)
+	switch {
+	case req.action == actionDeleteRecurring && isEndRecurrenceInput(text):
+		b.clearConfirmation(msg.From.ID)
+		return b.endRecurrenceAndRefresh(ctx, msg.Chat.ID, msg.From, req.taskID)
+	case req.action == actionDeleteRecurring && isDeleteHistoryInput(text):
+		b.clearConfirmation(msg.From.ID)
+		return b.deleteTaskAndRefresh(ctx, msg.Chat.ID, msg.From, req.taskID)
+	case req.action != actionDeleteRecurring && isConfirmInput(text):
+		b.clearConfirmation(msg.From.ID)
+		return b.deleteTaskAndRefresh(ctx, msg.Chat.ID, msg.From, req.taskID)
+	case isCancelInput(text):
+		b.clearConfirmation(msg.From.ID)
+		return b.restoreMenu(ctx, msg.Chat.ID, lang.T("↩️ Удаление отменено."))
+	case req.action == actionDeleteRecurring:
+		return b.sendTransient(ctx, msg.Chat.ID, lang.T("Выбери, что удалить: только будущие повторы или задачу целиком с историей."), recurringDeleteKeyboard(lang))
+	default:
+		return b.sendTransient(ctx, msg.Chat.ID, lang.T("Подтверди или отмени удаление задачи."), confirmKeyboard(lang))
+	}
+}
+
+func (b *Bot) sendTaskList(ctx context.Context, chatID int64, user *model.User) error {
+	return b.sendTaskPage(ctx, chatID, 0, user, viewAll, 0)
+}
+
+// categoriesByID maps the IDs of the user's categories, archived ones included, to the categories.
+func (b *Bot) categoriesByID(ctx context.Context, user *model.User) map[uint]model.Category {
+	categories, _ := b.categorySvc.ListAll(ctx, user)
+	byID := make(map[uint]model.Category, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = cat
+	}
+	return byID
+}
+
+// formatTaskList renders open tasks grouped by category together with their action buttons;
+// deadlines are judged by the reader's policy. It returns no buttons when
+// there is nothing to show.
+func formatTaskList(lang i18n.Lang, tasks []model.Task, categories map[uint]model.Category, order string, agingDays int, policy model.DeadlinePolicy, workspaceTitle string, now time.Time) (string, [][]tgbotapi.InlineKeyboardButton) {
+	body, buttons := formatTaskGroups(lang, tasks, categories, order, agingDays, policy, now)
+	if len(buttons) == 0 {
+		return "", nil
+	}
+	header := lang.Tf("📋 <b>Текущие задачи</b>%s\n", workspaceTitle) +
+		lang.T("Нажми на кнопку, чтобы отметить задачу выполненной или удалить повторяющуюся.\n\n")
+	return header + body, buttons
+}
+
+// formatTaskGroups renders open tasks grouped by category, without a header, and their buttons.
+// Tasks without a deadline open for agingDays or longer get an age mark; 0 turns the marks off.
+func formatTaskGroups(lang i18n.Lang, tasks []model.Task, categories map[uint]model.Category, order string, agingDays int, policy model.DeadlinePolicy, now time.Time) (string, [][]tgbotapi.InlineKeyboardButton) {
+	var builder strings.Builder
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	lastKey := ""
+	for i, task := range orderTaskList(lang, tasks, categories, order, taskOrder{policy, now.Location()}) {
+		key, display := normalizedCategory(lang, task.CategoryID, categories)
+		if i == 0 || key != lastKey {
+			if i > 0 {
+				builder.WriteByte('\n')
+			}
+			builder.WriteString(fmt.Sprintf("<b>%s</b>\n", display))
+			lastKey = key
+		}
+		var row []tgbotapi.InlineKeyboardButton
+		if task.IsRecurring {
+			builder.WriteString(formatRecurringTask(lang, task, now))
+			row = append(row, completeButton(task, 20))
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(lang.T("\U0001F5D1 Удалить"), fmt.Sprintf("%s%d", cbDeletePrefix, task.ID)))
+		} else {
+			builder.WriteString(formatTask(lang, task, agingDays, policy, now))
+			row = append(row, completeButton(task, 24))
+		}
+		row = append(row, cardButton(task.ID))
+		buttons = append(buttons, row)
+		if !task.IsRecurring && service.Overdue(task.Deadline, now, policy) {
+			buttons = append(buttons, postponeButtons(lang, task.ID))
+		}
+	}
+	return strings.TrimSpace(builder.String()), buttons
+}
+
+// orderTaskList drops closed tasks and puts the rest in list order: categories by name with
+// uncategorized last, and within a category as the sort order says.
+func orderTaskList(lang i18n.Lang, tasks []model.Task, categories map[uint]model.Category, sortBy string, due taskOrder) []model.Task {
+	type categoryGroup struct {
+		Name  string
+		Tasks []model.Task
+	}
+
+	groups := make(map[string]*categoryGroup)
+	order := make([]string, 0, len(tasks))
+
+	for _, task := range tasks {
+		if !task.IsRecurring && task.IsCompleted {
+			continue
+		}
+		key, display := normalizedCategory(lang, task.CategoryID, categories)
+		group, ok := groups[key]
+		if !ok {
+			group = &categoryGroup{Name: display}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Tasks = append(group.Tasks, task)
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		if order[i] == noCategoryKey {
+			return false
+		}
+		if order[j] == noCategoryKey {
+			return true
+		}
+		return strings.Compare(groups[order[i]].Name, groups[order[j]].Name) < 0
+	})
+
+	less := sortComparators[sortBy]
+	if less == nil {
+		less = byPriority
+	}
+	ordered := make([]model.Task, 0, len(tasks))
+	for _, key := range order {
+		section := groups[key]
+		sort.SliceStable(section.Tasks, func(i, j int) bool {
+			return less(due, section.Tasks[i], section.Tasks[j])
+		})
+		ordered = append(ordered, section.Tasks...)
+	}
+	return ordered
+}
+
+// completeButton marks the task done; task lists, reports and pickers share it.
+func completeButton(task model.Task, titleWidth int) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("\u2705 #%d · %s", task.ID, shortTitle(task.Title, titleWidth)), fmt.Sprintf("%s%d", cbCompletePrefix, task.ID))
+}
+
+func (b *Bot) askDeleteConfirmation(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, chatID, lang.T("Задача не найдена."))
+		}
+		return err
+	}
+
+	if task.IsRecurring && task.RecurEndedAt == nil {
+		text := lang.Tf("♻️ \"%s\" (#%d) — повторяющаяся задача.\nМожно удалить только будущие повторы — задача и история выполнений останутся — или удалить её полностью вместе с историей.", escape(normalizeTitle(task.Title)), task.ID)
+		b.setConfirmation(from.ID, confirmationRequest{taskID: task.ID, action: actionDeleteRecurring})
+		return b.sendTransient(ctx, chatID, text, recurringDeleteKeyboard(lang))
+	}
+
+	text := lang.Tf("Удалить задачу \"%s\" (#%d)?", escape(normalizeTitle(task.Title)), task.ID)
+	b.setConfirmation(from.ID, confirmationRequest{taskID: task.ID, action: actionDelete})
+	return b.sendTransient(ctx, chatID, text, confirmKeyboard(lang))
+}
+
+func (b *Bot) endRecurrenceAndRefresh(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	task, err := b.taskSvc.EndRecurrence(ctx, user, taskID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendTextWithRemove(ctx, chatID, lang.T("Задача не найдена или уже удалена."))
+		}
+		return b.sendTextWithRemove(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] recurrence ended id=%d user=%d", task.ID, user.ID)
+	if err := b.sendTextWithRemove(ctx, chatID, lang.Tf("⏹ Повторы задачи \"%s\" остановлены. История сохранена: /task %d", escape(normalizeTitle(task.Title)), task.ID)); err != nil {
+		return err
+	}
+
+	return b.sendTaskList(ctx, chatID, user)
+}
+
+func (b *Bot) deleteTaskAndRefresh(ctx context.Context, chatID int64, from *tgbotapi.User, taskID uint) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.ensureUser(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	task, err := b.taskSvc.GetTask(ctx, user, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendTextWithRemove(ctx, chatID, lang.T("Задача не найдена или уже удалена."))
+		}
+		return b.sendTextWithRemove(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	if err := b.taskSvc.DeleteTask(ctx, user, taskID); err != nil {
+		return b.sendTextWithRemove(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] task deleted id=%d user=%d", task.ID, user.ID)
+	if err := b.sendTextWithRemove(ctx, chatID, lang.Tf("\U0001F5D1 Задача \"%s\" удалена. Вернуть её можно из /trash.", escape(normalizeTitle(task.Title)))); err != nil {
+		return err
+	}
+
+	return b.sendTaskList(ctx, chatID, user)
+}
+
+// handleDelete удаляет задачу полностью (включая повторяющиеся).
+func (b *Bot) handleDelete(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Укажи ID задачи: /delete 12"))
+	}
+
+	taskID64, err := strconv.ParseUint(args, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("ID задачи должен быть числом."))
+	}
+
+	user := CurrentUser(ctx)
+
+	task, err := b.taskSvc.GetTask(ctx, user, uint(taskID64))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+
+	if task.IsRecurring && task.RecurEndedAt == nil {
+		return b.askDeleteConfirmation(ctx, msg.Chat.ID, msg.From, task.ID)
+	}
+
+	if err := b.taskSvc.DeleteTask(ctx, user, uint(taskID64)); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось удалить задачу: %s", errorText(lang, err)))
+	}
+
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("🗑 Задача \"%s\" удалена. Вернуть её можно из /trash.", escape(normalizeTitle(task.Title))))
+}
+
+// isRecurringDoneInWindow reports whether a recurring task has nothing left to do now: its
+// current occurrence is done and no missed one is carried forward.
+func isRecurringDoneInWindow(task model.Task, now time.Time) bool {
+	if _, missed := service.MissedOccurrence(task, now); missed {
+		return false
+	}
+	return task.IsRecurring && service.DoneInWindow(task, now)
+}
+
+func formatTask(lang i18n.Lang, task model.Task, agingDays int, policy model.DeadlinePolicy, now time.Time) string {
+	var b strings.Builder
+	overdue := service.Overdue(task.Deadline, now, policy)
+	icon := iconDefault
+	if overdue {
+		icon = iconOverdue
+	} else if task.Deadline != nil && service.DueAt(*task.Deadline, now.Location(), policy).Sub(now) <= 48*time.Hour {
+		icon = iconDue
+	}
+	b.WriteString(fmt.Sprintf("%s <b>#%d</b> %s%s%s\n", icon, task.ID, service.PriorityMark(task.Priority), escape(normalizeTitle(task.Title)), agingMark(lang, task, agingDays, now)))
+	if task.Deadline != nil {
+		d := task.Deadline.In(now.Location())
+		if overdue {
+			b.WriteString(lang.Tf("   ⏰ Дедлайн: %s — <b>просрочено</b>\n", d.Format("2006-01-02")))
+		} else {
+			b.WriteString(lang.Tf("   ⏰ Дедлайн: %s · осталось ≈%d дн.\n", d.Format("2006-01-02"), service.DaysLeft(*task.Deadline, now)))
+		}
+	}
+	if task.Description != "" {
+		b.WriteString(fmt.Sprintf("   📝 %s\n", escape(task.Description)))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// agingMark tells how long a task without a deadline has been open, once that is agingDays or more.
+func agingMark(lang i18n.Lang, task model.Task, agingDays int, now time.Time) string {
+	if agingDays <= 0 || task.Deadline != nil || task.CreatedAt.IsZero() {
+		return ""
+	}
+	days := int(now.Sub(task.CreatedAt).Hours() / 24)
+	if days < agingDays {
+		return ""
+	}
+	return lang.Tf(" <i>· %d дн. в списке</i>", days)
+}
+
+func formatRecurringTask(lang i18n.Lang, task model.Task, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s <b>#%d</b> %s%s\n", iconRecurring, task.ID, service.PriorityMark(task.Priority), escape(normalizeTitle(task.Title))))
+
+	dueDate, _ := service.NextOccurrence(task, now)
+	b.WriteString(lang.Tf("   🔄 %s: %s (окно +%d дн.)\n", frequencyLabel(lang, task), dueDate.Format("2006-01-02"), task.RecurWindow))
+	if missed, ok := service.MissedOccurrence(task, now); ok {
+		b.WriteString(lang.Tf("   ⚠️ Пропущен повтор %s\n", missed.Format("2006-01-02")))
+	}
+	if task.LastCompletedAt != nil {
+		b.WriteString(lang.Tf("   ✅ Последнее выполнение: %s\n", task.LastCompletedAt.In(now.Location()).Format("2006-01-02")))
+	} else {
+		b.WriteString(lang.T("   ✅ Пока не выполнялась\n"))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
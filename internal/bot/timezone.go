@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/service"
+)
+
+// handleTimezone shows or sets the time zone used for suggested reminder times.
+func (b *Bot) handleTimezone(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	name := strings.TrimSpace(msg.CommandArguments())
+	if name == "" {
+		now := time.Now().In(user.Location())
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🕰 Часовой пояс: %s, сейчас %s.\nИзменить: /timezone Europe/Moscow", escape(user.Location().String()), now.Format("15:04")))
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Не знаю такого часового пояса. Укажи его как в базе IANA, например Europe/Moscow или Asia/Yekaterinburg."))
+	}
+	if err := b.userRepo.SetTimezone(ctx, user, loc.String()); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить часовой пояс: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] timezone user=%d zone=%s", user.ID, loc)
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("🕰 Часовой пояс %s сохранён, у тебя сейчас %s.", escape(loc.String()), time.Now().In(loc).Format("15:04")))
+}
+
+// handleWorkHours shows or sets the working hours that relative times and reminder delivery follow.
+func (b *Bot) handleWorkHours(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		hours := service.UserWorkingHours(*user)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("💼 Рабочие часы: %s.\n%s\nИзменить: /workhours 10-19", workHoursLabel(hours), relativeTimesHint(lang, hours)))
+	}
+	rawStart, rawEnd, _ := strings.Cut(args, "-")
+	start, errStart := strconv.Atoi(strings.TrimSpace(rawStart))
+	end, errEnd := strconv.Atoi(strings.TrimSpace(rawEnd))
+	hours := service.WorkingHours{Start: start, End: end}
+	if errStart != nil || errEnd != nil || hours.Validate() != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("Укажи часы начала и конца работы, например /workhours 9-18. Работа должна заканчиваться не позже 22."))
+	}
+	if err := b.userRepo.SetWorkingHours(ctx, user, start, end); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить рабочие часы: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] working hours user=%d start=%d end=%d", user.ID, start, end)
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("💼 Рабочие часы: %s.\n%s", workHoursLabel(hours), relativeTimesHint(lang, hours)))
+}
+
+func workHoursLabel(hours service.WorkingHours) string {
+	return fmt.Sprintf("%d:00–%d:00", hours.Start, hours.End)
+}
+
+func relativeTimesHint(lang i18n.Lang, hours service.WorkingHours) string {
+	return lang.Tf("«Утром» — это %d:00, «после работы» — %d:00, «вечером» — %d:00. Напоминания о сроках приходят с %d:00 до %d:00.",
+		hours.Start, hours.End, hours.Evening(), hours.Start, hours.End+3)
+}
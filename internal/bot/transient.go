@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+)
+
+// maxAutoDeleteMinutes keeps deletions well within the 48 hours Telegram lets bots delete their messages.
+const maxAutoDeleteMinutes = 24 * 60
+
+// expiredBatch caps the messages deleted in one run of DeleteExpiredMessages.
+const expiredBatch = 100
+
+const autoDeleteUsage = "Формат: /autodelete 5 — удалять через 5 минут (до 1440), /autodelete off — не удалять"
+
+// handleAutoDelete shows or changes how soon transient messages are deleted: /autodelete <minutes>|off.
+func (b *Bot) handleAutoDelete(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user, err := b.telegramUser(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	if arg == "" {
+		state := lang.T("выключено")
+		if user.AutoDeleteMinutes > 0 {
+			state = lang.Tf("через %d мин.", user.AutoDeleteMinutes)
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🧹 Автоудаление служебных сообщений — подтверждений, «Главного меню» и уведомлений о выполнении: %s\n%s", state, lang.T(autoDeleteUsage)))
+	}
+	minutes := 0
+	if arg != "off" && arg != "0" {
+		minutes, err = strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(arg, "m"), "м"))
+		if err != nil || minutes < 1 || minutes > maxAutoDeleteMinutes {
+			return b.sendText(ctx, msg.Chat.ID, lang.T(autoDeleteUsage))
+		}
+	}
+	if err := b.userRepo.SetAutoDeleteMinutes(ctx, user, minutes); err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] auto delete user=%d minutes=%d", user.ID, minutes)
+	if minutes == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🧹 Автоудаление выключено: служебные сообщения останутся в чате."))
+	}
+	return b.sendText(ctx, msg.Chat.ID, lang.Tf("🧹 Служебные сообщения будут исчезать через %d мин.", minutes))
+}
+
+// sendTransient sends a message that is not worth keeping in the chat history;
+// it is deleted later if the chat's user turned auto-delete on.
+func (b *Bot) sendTransient(ctx context.Context, chatID int64, text string, markup interface{}) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = markup
+	sent, err := b.send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	b.expireLater(ctx, chatID, sent.MessageID)
+	return nil
+}
+
+// expireLater schedules the deletion of a bot message in a private chat; errors are logged.
+func (b *Bot) expireLater(ctx context.Context, chatID int64, messageID int) {
+	user, err := b.userRepo.FindByTelegramID(ctx, chatID)
+	if err != nil || user.AutoDeleteMinutes == 0 {
+		return
+	}
+	delay := time.Duration(user.AutoDeleteMinutes) * time.Minute
+	if err := b.taskSvc.ExpireMessage(ctx, chatID, messageID, delay, time.Now()); err != nil {
+		log.Printf("schedule deletion chat=%d message=%d: %v", chatID, messageID, err)
+	}
+}
+
+// DeleteExpiredMessages deletes the transient messages whose time is up. Messages the
+// user already deleted or that became too old to delete are dropped as well.
+func (b *Bot) DeleteExpiredMessages(ctx context.Context) error {
+	for {
+		messages, err := b.taskSvc.ExpiredMessages(ctx, time.Now(), expiredBatch)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		for _, message := range messages {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, err := b.api.Request(tgbotapi.NewDeleteMessage(message.ChatID, message.MessageID)); err != nil {
+				log.Printf("delete message chat=%d message=%d: %v", message.ChatID, message.MessageID, err)
+			}
+		}
+		if err := b.taskSvc.ForgetExpired(ctx, messages); err != nil {
+			return err
+		}
+		if len(messages) < expiredBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const cbUndeletePrefix = "undelete:"
+
+// trashLimit caps how many deleted tasks one /trash message lists.
+const trashLimit = 30
+
+// handleTrash lists the recently deleted tasks with buttons to bring them back.
+func (b *Bot) handleTrash(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	now := time.Now().In(user.Location())
+	tasks, err := b.taskSvc.ListTrash(ctx, user, now)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось открыть корзину: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] trash user=%d tasks=%d", user.ID, len(tasks))
+	if len(tasks) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🗑 Корзина пуста. Удалённые задачи хранятся здесь %s.", daysLabel(lang, int(service.TrashRetention/(24*time.Hour)))))
+	}
+
+	text, buttons := formatTrash(lang, tasks, now)
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text+b.workspaceTitle(ctx, user))
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+	_, err = b.send(ctx, reply)
+	return err
+}
+
+// formatTrash lists deleted tasks with the days left before they are purged.
+func formatTrash(lang i18n.Lang, tasks []model.Task, now time.Time) (string, [][]tgbotapi.InlineKeyboardButton) {
+	var builder strings.Builder
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	builder.WriteString(lang.Tf("🗑 <b>Корзина: %d</b>\nЗадачи удаляются насовсем через %s после удаления.\n", len(tasks), daysLabel(lang, int(service.TrashRetention/(24*time.Hour)))))
+	for i, task := range tasks {
+		if i == trashLimit {
+			builder.WriteString(lang.Tf("\n…и ещё %d", len(tasks)-trashLimit))
+			break
+		}
+		deleted := task.DeletedAt.Time.In(now.Location())
+		left := int(deleted.Add(service.TrashRetention).Sub(now).Hours()/24) + 1
+		builder.WriteString(lang.Tf("\n• #%d %s — удалена %s, осталось %s", task.ID, escape(normalizeTitle(task.Title)), deleted.Format("02.01 15:04"), daysLabel(lang, max(left, 1))))
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.Tf("♻️ Вернуть #%d · %s", task.ID, shortTitle(task.Title, 24)), fmt.Sprintf("%s%d", cbUndeletePrefix, task.ID)),
+		))
+	}
+	return builder.String(), buttons
+}
+
+// handleUndeleteButton restores a task from the trash and drops its button.
+func (b *Bot) handleUndeleteButton(ctx context.Context, cb *tgbotapi.CallbackQuery, payload string) error {
+	lang := i18n.FromContext(ctx)
+	taskID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		b.answerCallback(cb, "")
+		return nil
+	}
+	user, err := b.ensureUser(ctx, cb.From)
+	if err != nil {
+		b.answerCallback(cb, "")
+		return err
+	}
+	task, err := b.taskSvc.Undelete(ctx, user, uint(taskID))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		b.answerCallback(cb, lang.T("Задачи уже нет в корзине."))
+		return b.dropTaskRows(ctx, cb, uint(taskID))
+	case err != nil:
+		b.answerCallback(cb, lang.T("Не получилось: ")+errorText(lang, err))
+		return err
+	}
+	log.Printf("[info] task undeleted id=%d user=%d", task.ID, user.ID)
+	b.answerCallback(cb, lang.Tf("♻️ Задача «%s» возвращена.", normalizeTitle(task.Title)))
+	return b.dropTaskRows(ctx, cb, task.ID)
+}
+
+// PurgeTrash permanently removes tasks that stayed in the trash longer than service.TrashRetention.
+func (b *Bot) PurgeTrash(ctx context.Context) error {
+	purged, err := b.taskSvc.PurgeTrash(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("[info] trash purged tasks=%d", purged)
+	}
+	return nil
+}
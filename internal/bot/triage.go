@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+// triageIcons mark the decisions in the triage keyboard and in answered lines.
+var triageIcons = map[string]string{
+	model.TriageKeep:    "📌",
+	model.TriageDelete:  "🗑",
+	model.TriageArchive: "🗄",
+}
+
+// SendBacklogTriage offers owners of tasks untouched for two months to keep, delete or archive each.
+func (b *Bot) SendBacklogTriage(ctx context.Context) error {
+	runs, err := b.triageSvc.StartRuns(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		user, err := b.userRepo.FindByID(ctx, run.UserID)
+		if err != nil {
+			log.Printf("triage owner %d: %v", run.UserID, err)
+			continue
+		}
+		if user.ArchivedAt != nil {
+			continue
+		}
+		msg := tgbotapi.NewMessage(user.TelegramID, triageText(user.Lang(), run.Items))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = triageKeyboard(run.Items)
+		if _, err := b.send(ctx, msg); err != nil {
+			log.Printf("send triage to %d: %v", user.TelegramID, err)
+		}
+	}
+	return nil
+}
+
+func (b *Bot) handleTriageAnswer(ctx context.Context, cb *tgbotapi.CallbackQuery, data string) error {
+	lang := i18n.FromContext(ctx)
+	rawID, decision, _ := strings.Cut(data, ":")
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	run, err := b.triageSvc.Decide(ctx, user, uint(id), decision, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.T("Задача не найдена или уже удалена."))
+	}
+	if err != nil {
+		return b.sendText(ctx, cb.Message.Chat.ID, lang.Tf("Не удалось разобрать задачу: %s", errorText(lang, err)))
+	}
+
+	log.Printf("[info] triage decision item=%d user=%d decision=%s", id, user.ID, decision)
+	text := triageText(lang, run.Items)
+	if keyboard := triageKeyboard(run.Items); len(keyboard.InlineKeyboard) > 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(cb.Message.Chat.ID, cb.Message.MessageID, text, keyboard)
+		edit.ParseMode = tgbotapi.ModeHTML
+		_, err = b.send(ctx, edit)
+		return err
+	}
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	_, err = b.send(ctx, edit)
+	return err
+}
+
+// triageText lists the run's tasks with how long they sat idle, answered ones struck through.
+func triageText(lang i18n.Lang, items []model.TriageItem) string {
+	var builder strings.Builder
+	builder.WriteString(lang.T("🧹 <b>Разбор бэклога</b>\nЭти задачи не трогали больше двух месяцев. Что с ними сделать?\n📌 оставить · 🗑 удалить · 🗄 в архив\n\n"))
+	var stats service.TriageStats
+	pending := 0
+	for i, item := range items {
+		title := escape(normalizeTitle(item.Title))
+		switch item.Decision {
+		case "":
+			pending++
+			days := int(time.Unix(item.Run, 0).Sub(item.IdleSince) / (24 * time.Hour))
+			builder.WriteString(lang.Tf("%d. %s — %d дн.\n", i+1, title, days))
+			continue
+		case model.TriageKeep:
+			stats.Kept++
+		case model.TriageDelete:
+			stats.Deleted++
+		case model.TriageArchive:
+			stats.Archived++
+		}
+		builder.WriteString(fmt.Sprintf("%d. %s <s>%s</s>\n", i+1, triageIcons[item.Decision], title))
+	}
+	if pending == 0 {
+		builder.WriteString(lang.Tf("\nГотово: оставлено %d, удалено %d, в архиве %d.", stats.Kept, stats.Deleted, stats.Archived))
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// triageKeyboard has a row of decisions for every unanswered task, labelled by its number in the list.
+func triageKeyboard(items []model.TriageItem) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, item := range items {
+		if item.Decision != "" {
+			continue
+		}
+		var row []tgbotapi.InlineKeyboardButton
+		for _, decision := range []string{model.TriageKeep, model.TriageDelete, model.TriageArchive} {
+			label := fmt.Sprintf("%s %d", triageIcons[decision], i+1)
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d:%s", cbTriagePrefix, item.ID, decision)))
+		}
+		rows = append(rows, row)
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleArchive lists archived tasks; /archive restore <id> brings one back.
+func (b *Bot) handleArchive(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) > 0 {
+		if len(args) != 2 || strings.ToLower(args[0]) != "restore" {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /archive restore &lt;id&gt;"))
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Формат: /archive restore &lt;id&gt;"))
+		}
+		task, err := b.taskSvc.Restore(ctx, user, uint(id), time.Now())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Задача не найдена."))
+		}
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось вернуть задачу: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] task restored id=%d user=%d", task.ID, user.ID)
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("♻️ Задача «%s» снова в списке.", escape(normalizeTitle(task.Title))))
+	}
+
+	tasks, err := b.taskSvc.ListArchived(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось получить архив: %s", errorText(lang, err)))
+	}
+	if len(tasks) == 0 {
+		return b.sendText(ctx, msg.Chat.ID, lang.T("🗄 Архив пуст."))
+	}
+	var builder strings.Builder
+	builder.WriteString(lang.T("🗄 <b>Архив</b>\n"))
+	for _, task := range tasks {
+		builder.WriteString(lang.Tf("• <b>#%d</b> %s — с %s\n", task.ID, escape(normalizeTitle(task.Title)), task.ArchivedAt.Format("02.01.2006")))
+	}
+	builder.WriteString(lang.T("Вернуть задачу: /archive restore &lt;id&gt;"))
+	return b.sendText(ctx, msg.Chat.ID, builder.String())
+}
@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// allowedUpdates lists the update kinds the bot asks Telegram for. Reactions are
+// not delivered unless requested explicitly.
+var allowedUpdates = []string{"message", "callback_query", "message_reaction"}
+
+// Update extends tgbotapi.Update with kinds the library does not know yet.
+type Update struct {
+	tgbotapi.Update
+	MessageReaction *messageReactionUpdated `json:"message_reaction,omitempty"`
+}
+
+// messageReactionUpdated mirrors the Bot API MessageReactionUpdated object.
+type messageReactionUpdated struct {
+	Chat        *tgbotapi.Chat `json:"chat"`
+	MessageID   int            `json:"message_id"`
+	User        *tgbotapi.User `json:"user,omitempty"`
+	Date        int            `json:"date"`
+	OldReaction []reactionType `json:"old_reaction"`
+	NewReaction []reactionType `json:"new_reaction"`
+}
+
+type reactionType struct {
+	Type  string `json:"type"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// longPollSeconds is how long getUpdates may wait for updates: a minute, or less when the
+// HTTP timeout would cut the request short.
+func longPollSeconds(httpTimeout time.Duration) int {
+	if httpTimeout <= 0 {
+		return 60
+	}
+	return int(max(min(time.Minute, httpTimeout-10*time.Second), time.Second) / time.Second)
+}
+
+// pollUpdates long-polls getUpdates until ctx is cancelled; the channel is closed afterwards.
+func (b *Bot) pollUpdates(ctx context.Context) <-chan Update {
+	ch := make(chan Update, 100)
+	allowed, _ := json.Marshal(allowedUpdates)
+
+	go func() {
+		defer close(ch)
+		offset := 0
+		for ctx.Err() == nil {
+			params := tgbotapi.Params{"timeout": strconv.Itoa(b.pollSeconds), "allowed_updates": string(allowed)}
+			if offset > 0 {
+				params["offset"] = strconv.Itoa(offset)
+			}
+			resp, err := b.api.MakeRequest("getUpdates", params)
+			var updates []Update
+			if err == nil {
+				err = json.Unmarshal(resp.Result, &updates)
+			}
+			if err != nil {
+				log.Printf("get updates: %v, retrying in 3 seconds", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(3 * time.Second):
+				}
+				continue
+			}
+			for _, u := range updates {
+				if u.UpdateID >= offset {
+					offset = u.UpdateID + 1
+				}
+				select {
+				case ch <- u:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch
+}
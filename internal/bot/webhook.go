@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// secretTokenHeader carries the secret Telegram was given in setWebhook.
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+	// maxUpdateBytes bounds the body of a single webhook request.
+	maxUpdateBytes = 1 << 20
+)
+
+// updates returns the update stream: webhook requests when WEBHOOK_URL is set, long polling otherwise.
+func (b *Bot) updates(ctx context.Context) (<-chan Update, error) {
+	if b.config.WebhookURL == "" {
+		// A webhook left over from an earlier run makes getUpdates fail.
+		if _, err := b.api.MakeRequest("deleteWebhook", tgbotapi.Params{}); err != nil {
+			log.Printf("delete webhook: %v", err)
+		}
+		log.Println("[info] start polling updates")
+		return b.pollUpdates(ctx), nil
+	}
+	return b.webhookUpdates(ctx)
+}
+
+// webhookUpdates registers the webhook and serves Telegram's requests until ctx is
+// cancelled; the channel is closed once the server has shut down.
+func (b *Bot) webhookUpdates(ctx context.Context) (<-chan Update, error) {
+	hookURL, err := url.Parse(b.config.WebhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook url: %w", err)
+	}
+	secret := b.config.WebhookSecret
+	if secret == "" {
+		if secret, err = randomSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	ch := make(chan Update, 100)
+	path := hookURL.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, webhookHandler(ctx, secret, ch))
+	server := &http.Server{Addr: b.config.ListenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	allowed, _ := json.Marshal(allowedUpdates)
+	params := tgbotapi.Params{"url": b.config.WebhookURL, "secret_token": secret, "allowed_updates": string(allowed)}
+	if _, err := b.api.MakeRequest("setWebhook", params); err != nil {
+		return nil, fmt.Errorf("set webhook: %w", err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if b.config.WebhookTLSCert != "" {
+			serveErr <- server.ListenAndServeTLS(b.config.WebhookTLSCert, b.config.WebhookTLSKey)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+	go func() {
+		defer close(ch)
+		select {
+		case err := <-serveErr:
+			log.Printf("webhook server: %v", err)
+			return
+		case <-ctx.Done():
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("webhook shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("[info] listening for webhook updates on %s%s", b.config.ListenAddr, path)
+	return ch, nil
+}
+
+// webhookHandler accepts updates that carry the secret and queues them for the bot.
+func webhookHandler(ctx context.Context, secret string, ch chan<- Update) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secret)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var update Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateBytes)).Decode(&update); err != nil {
+			http.Error(w, "bad update", http.StatusBadRequest)
+			return
+		}
+		select {
+		case ch <- update:
+			w.WriteHeader(http.StatusOK)
+		case <-ctx.Done():
+			// Telegram redelivers updates that were not acknowledged.
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+func randomSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	ch := make(chan Update, 1)
+	handler := webhookHandler(context.Background(), "s3cret", ch)
+	body := `{"update_id": 7, "message": {"message_id": 1, "text": "hi", "chat": {"id": 5, "type": "private"}}}`
+
+	cases := []struct {
+		name   string
+		method string
+		secret string
+		want   int
+	}{
+		{"wrong secret", http.MethodPost, "nope", http.StatusForbidden},
+		{"no secret", http.MethodPost, "", http.StatusForbidden},
+		{"get", http.MethodGet, "s3cret", http.StatusMethodNotAllowed},
+		{"valid", http.MethodPost, "s3cret", http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/hook", strings.NewReader(body))
+		if tc.secret != "" {
+			req.Header.Set(secretTokenHeader, tc.secret)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+
+	select {
+	case update := <-ch:
+		if update.UpdateID != 7 || update.Message == nil || update.Message.Text != "hi" {
+			t.Errorf("unexpected update: %+v", update)
+		}
+	default:
+		t.Fatal("valid update was not queued")
+	}
+	if len(ch) != 0 {
+		t.Error("rejected requests queued updates")
+	}
+}
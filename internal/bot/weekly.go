@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const weeklyUsage = "/weekly — итоги недели сейчас, /weekly on — присылать их по воскресеньям вечером, /weekly off — не присылать"
+
+// handleWeekly shows the weekly summary or turns its Sunday delivery on and off: /weekly [on|off].
+func (b *Bot) handleWeekly(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	switch arg {
+	case "":
+		text, err := b.weeklyText(ctx, user, time.Now().In(user.Location()))
+		if err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось подвести итоги: %s", errorText(lang, err)))
+		}
+		return b.sendText(ctx, msg.Chat.ID, text)
+	case "on", "off":
+		if b.config.WeeklySummaryTime == "" {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("Рассылка итогов недели на этом сервере выключена, но /weekly покажет их в любой момент."))
+		}
+		enabled := arg == "on"
+		if err := b.userRepo.SetWeeklySummary(ctx, user, enabled); err != nil {
+			return b.sendText(ctx, msg.Chat.ID, lang.Tf("Не удалось сохранить настройку: %s", errorText(lang, err)))
+		}
+		log.Printf("[info] weekly summary user=%d enabled=%t", user.ID, enabled)
+		if !enabled {
+			return b.sendText(ctx, msg.Chat.ID, lang.T("🗓 Итоги недели больше не будут приходить сами. /weekly покажет их по запросу."))
+		}
+		return b.sendText(ctx, msg.Chat.ID, lang.Tf("🗓 Итоги недели будут приходить по воскресеньям в %s.", b.config.WeeklySummaryTime))
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(weeklyUsage))
+	}
+}
+
+// weeklyText renders the weekly summary of the user together with the dates of their
+// contacts in the week ahead.
+func (b *Bot) weeklyText(ctx context.Context, user *model.User, now time.Time) (string, error) {
+	summary, err := b.reminderSvc.WeeklySummary(ctx, user, now)
+	if err != nil {
+		return "", err
+	}
+	dates, err := b.contactSvc.WeeklyReport(ctx, user, now)
+	if err != nil {
+		return "", err
+	}
+	return formatWeeklySummary(i18n.FromContext(ctx), summary, dates), nil
+}
+
+// formatWeeklySummary renders the summary followed by dates, the section of birthdays and
+// other dates of the week ahead; it is left out when empty.
+func formatWeeklySummary(lang i18n.Lang, summary service.WeeklySummary, dates string) string {
+	var builder strings.Builder
+	builder.WriteString(lang.Tf("🗓 <b>Итоги недели</b> · %s – %s\n", summary.Since.Format("02.01"), summary.Until.Format("02.01")))
+	builder.WriteString(lang.Tf("✅ Выполнено: %d · 📌 Открыто: %d · ⚠️ Просрочено: %d\n", summary.Completed, summary.Pending, summary.Overdue))
+	if len(summary.Categories) == 0 {
+		builder.WriteString(lang.T("\nЗа неделю задач не было — самое время запланировать следующую: /newtask"))
+	} else {
+		builder.WriteString(lang.T("\n<b>По категориям</b>\n"))
+	}
+	for _, category := range summary.Categories {
+		name := category.Name
+		if name == "" {
+			name = noCategory
+		}
+		line := fmt.Sprintf("• %s — ✅ %d · 📌 %d", escape(name), category.Completed, category.Pending)
+		if category.Overdue > 0 {
+			line += fmt.Sprintf(" · ⚠️ %d", category.Overdue)
+		}
+		builder.WriteString(line + "\n")
+	}
+	text := strings.TrimSpace(builder.String())
+	if dates != "" {
+		text += "\n\n" + dates
+	}
+	return text
+}
+
+// SendWeeklySummaries sends the weekly summary of personal tasks to the users who asked for it.
+func (b *Bot) SendWeeklySummaries(ctx context.Context) error {
+	users, err := b.userRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !user.WeeklySummary || user.ArchivedAt != nil {
+			continue
+		}
+		ctx := withReader(ctx, user)
+		text, err := b.weeklyText(ctx, personalUser(&user), now.In(user.Location()))
+		if err != nil {
+			log.Printf("weekly summary for user %d: %v", user.ID, err)
+			continue
+		}
+		if err := b.sendText(ctx, user.TelegramID, text); err != nil {
+			log.Printf("send weekly summary to %d: %v", user.TelegramID, err)
+		}
+	}
+	return nil
+}
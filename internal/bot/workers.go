@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	// defaultWorkerCount applies when WORKER_COUNT is not configured.
+	defaultWorkerCount = 8
+	// workerQueueSize is how many updates may wait for one worker before intake blocks.
+	workerQueueSize = 64
+)
+
+// workerPool handles updates on a fixed set of goroutines. All updates of a chat go to the
+// same worker, so they are handled in the order they came, while a slow one holds up only
+// the chats that share its worker.
+type workerPool struct {
+	queues []chan Update
+	wg     sync.WaitGroup
+}
+
+func newWorkerPool(workers int, handle func(Update)) *workerPool {
+	p := &workerPool{queues: make([]chan Update, max(workers, 1))}
+	for i := range p.queues {
+		queue := make(chan Update, workerQueueSize)
+		p.queues[i] = queue
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for update := range queue {
+				runUpdate(handle, update)
+			}
+		}()
+	}
+	return p
+}
+
+// runUpdate handles one update; a panic that escaped the middleware is logged, and the
+// worker goes on with the next update.
+func runUpdate(handle func(Update), update Update) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("panic in worker on update %d: %v\n%s", update.UpdateID, recovered, debug.Stack())
+		}
+	}()
+	handle(update)
+}
+
+// submit queues the update for the worker of its chat, waiting while that queue is full.
+func (p *workerPool) submit(update Update) {
+	shard := updateChatID(update) % int64(len(p.queues))
+	if shard < 0 {
+		// Group chat IDs are negative.
+		shard = -shard
+	}
+	p.queues[shard] <- update
+}
+
+// stop lets the workers finish the queued updates and waits for them.
+func (p *workerPool) stop() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.wg.Wait()
+}
+
+// updateChatID is the chat the update happened in, falling back to its sender; 0 when
+// the update has neither.
+func updateChatID(update Update) int64 {
+	switch {
+	case update.MessageReaction != nil && update.MessageReaction.Chat != nil:
+		return update.MessageReaction.Chat.ID
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil && update.CallbackQuery.Message.Chat != nil:
+		return update.CallbackQuery.Message.Chat.ID
+	case update.Message != nil && update.Message.Chat != nil:
+		return update.Message.Chat.ID
+	}
+	return updateSenderID(update)
+}
@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"sync"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestWorkerPoolKeepsChatOrder(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[int64][]int)
+	pool := newWorkerPool(3, func(update Update) {
+		if update.UpdateID == 0 {
+			panic("bad update")
+		}
+		chatID := updateChatID(update)
+		mu.Lock()
+		seen[chatID] = append(seen[chatID], update.UpdateID)
+		mu.Unlock()
+	})
+	chats := []int64{5, -100200, 7, 8}
+	for i := 0; i < 40; i++ {
+		chat := &tgbotapi.Chat{ID: chats[i%len(chats)]}
+		pool.submit(Update{Update: tgbotapi.Update{UpdateID: i, Message: &tgbotapi.Message{Chat: chat}}})
+	}
+	pool.stop()
+
+	// Update 0 panicked, and its worker went on with the rest.
+	for _, chatID := range chats {
+		ids := seen[chatID]
+		if len(ids) != 10 && !(chatID == chats[0] && len(ids) == 9) {
+			t.Errorf("chat %d: handled %v", chatID, ids)
+		}
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Errorf("chat %d: out of order %v", chatID, ids)
+				break
+			}
+		}
+	}
+}
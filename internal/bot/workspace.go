@@ -0,0 +1,406 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const workspaceUsage = "Команды пространств:\n" +
+	"• /workspace create &lt;название&gt; — создать общее пространство\n" +
+	"• /workspace join &lt;код&gt; — вступить по коду приглашения\n" +
+	"• /workspace switch &lt;id&gt; — перейти в пространство\n" +
+	"• /workspace switch personal — вернуться к личным задачам\n" +
+	"• /workspace invite — показать код приглашения\n" +
+	"• /workspace members — участники и их роли\n" +
+	"• /workspace leave — покинуть пространство\n" +
+	"• /workspace digest — итоги недели по участникам (для владельца)\n" +
+	"• /workspace bind — (в группе) присылать туда отчёт пространства"
+
+// handleWorkspace routes /workspace subcommands.
+func (b *Bot) handleWorkspace(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	user := CurrentUser(ctx)
+
+	sub, arg, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	arg = strings.TrimSpace(arg)
+	switch strings.ToLower(sub) {
+	case "":
+		return b.sendWorkspaceList(ctx, msg.Chat.ID, user)
+	case "create":
+		return b.createWorkspace(ctx, msg.Chat.ID, user, arg)
+	case "join":
+		return b.joinWorkspace(ctx, msg.Chat.ID, user, arg)
+	case "switch":
+		return b.switchWorkspace(ctx, msg.Chat.ID, user, arg)
+	case "invite":
+		return b.sendWorkspaceInvite(ctx, msg.Chat.ID, user)
+	case "members":
+		return b.sendWorkspaceMembers(ctx, msg.Chat.ID, user)
+	case "role":
+		return b.setWorkspaceRole(ctx, msg.Chat.ID, user, arg)
+	case "remove":
+		return b.removeWorkspaceMember(ctx, msg.Chat.ID, user, arg)
+	case "leave":
+		return b.leaveWorkspace(ctx, msg.Chat.ID, user)
+	case "digest":
+		return b.sendManagerDigest(ctx, msg.Chat.ID, user)
+	default:
+		return b.sendText(ctx, msg.Chat.ID, lang.T(workspaceUsage))
+	}
+}
+
+func (b *Bot) sendWorkspaceList(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	workspaces, err := b.workspaceSvc.List(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить пространства: %s", errorText(lang, err)))
+	}
+	active, err := b.workspaceSvc.Active(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить пространства: %s", errorText(lang, err)))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(lang.T("🏠 <b>Пространства</b>\n"))
+	marker := func(selected bool) string {
+		if selected {
+			return "👉"
+		}
+		return "•"
+	}
+	builder.WriteString(lang.Tf("%s Личные задачи\n", marker(active == nil)))
+	for _, ws := range workspaces {
+		builder.WriteString(fmt.Sprintf("%s <b>%d</b> · %s\n", marker(active != nil && active.ID == ws.ID), ws.ID, escape(ws.Name)))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(workspaceUsage)
+	return b.sendText(ctx, chatID, builder.String())
+}
+
+func (b *Bot) createWorkspace(ctx context.Context, chatID int64, user *model.User, name string) error {
+	lang := i18n.FromContext(ctx)
+	if name == "" {
+		return b.sendText(ctx, chatID, lang.T("Укажи название: /workspace create Семья"))
+	}
+	workspace, err := b.workspaceSvc.Create(ctx, user, name)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось создать пространство: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] workspace created id=%d user=%d", workspace.ID, user.ID)
+	return b.sendText(ctx, chatID, lang.Tf(
+		"🏠 Пространство «%s» создано и выбрано.\nКод приглашения: <code>%s</code> — участники вступают командой <code>/workspace join %s</code>.",
+		escape(workspace.Name), workspace.InviteCode, workspace.InviteCode,
+	))
+}
+
+func (b *Bot) joinWorkspace(ctx context.Context, chatID int64, user *model.User, code string) error {
+	lang := i18n.FromContext(ctx)
+	if code == "" {
+		return b.sendText(ctx, chatID, lang.T("Укажи код приглашения: /workspace join ABCD2345"))
+	}
+	workspace, err := b.workspaceSvc.Join(ctx, user, code)
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, chatID, lang.T("Пространство с таким кодом не найдено."))
+	case errors.Is(err, service.ErrAlreadyMember):
+		return b.sendText(ctx, chatID, lang.Tf("Ты уже участник «%s». Переключиться: /workspace switch %d", escape(workspace.Name), workspace.ID))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось вступить: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] workspace joined id=%d user=%d", workspace.ID, user.ID)
+	return b.sendText(ctx, chatID, lang.Tf("✅ Готово, теперь ты в «%s». Новые задачи и списки теперь относятся к этому пространству.", escape(workspace.Name)))
+}
+
+func (b *Bot) switchWorkspace(ctx context.Context, chatID int64, user *model.User, arg string) error {
+	lang := i18n.FromContext(ctx)
+	lower := strings.ToLower(arg)
+	if lower == "personal" || lower == "личные" || lower == "0" {
+		if _, err := b.workspaceSvc.Switch(ctx, user, 0); err != nil {
+			return b.sendText(ctx, chatID, lang.Tf("Не удалось переключиться: %s", errorText(lang, err)))
+		}
+		return b.sendText(ctx, chatID, lang.T("👤 Теперь ты работаешь с личными задачами."))
+	}
+
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.T("Укажи номер пространства из /workspace или «personal»."))
+	}
+	workspace, err := b.workspaceSvc.Switch(ctx, user, uint(id))
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrNotWorkspaceMember):
+		return b.sendText(ctx, chatID, lang.T("Пространство не найдено среди твоих."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось переключиться: %s", errorText(lang, err)))
+	}
+	return b.sendText(ctx, chatID, lang.Tf("🏠 Активное пространство: «%s».", escape(workspace.Name)))
+}
+
+func (b *Bot) sendWorkspaceInvite(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	workspace, err := b.workspaceSvc.Active(ctx, user)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	if workspace == nil {
+		return b.sendText(ctx, chatID, lang.T("Сначала выбери пространство: /workspace switch &lt;id&gt;"))
+	}
+	return b.sendText(ctx, chatID, lang.Tf("Код приглашения в «%s»: <code>%s</code>", escape(workspace.Name), workspace.InviteCode))
+}
+
+// workspaceTitle returns a header suffix naming the active workspace, if any.
+func (b *Bot) workspaceTitle(ctx context.Context, user *model.User) string {
+	workspace, err := b.workspaceSvc.Active(ctx, user)
+	if err != nil || workspace == nil {
+		return ""
+	}
+	return fmt.Sprintf(" · 🏠 %s", escape(workspace.Name))
+}
+
+func (b *Bot) sendWorkspaceMembers(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	workspace, members, err := b.workspaceSvc.Members(ctx, user)
+	if errors.Is(err, service.ErrWorkspaceNotFound) {
+		return b.sendText(ctx, chatID, lang.T("Сначала выбери пространство: /workspace switch &lt;id&gt;"))
+	}
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось получить участников: %s", errorText(lang, err)))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(lang.Tf("👥 <b>Участники «%s»</b>\n", escape(workspace.Name)))
+	for _, member := range members {
+		builder.WriteString(fmt.Sprintf("• <b>%d</b> · %s — %s\n", member.UserID, escape(memberName(member.User)), roleLabel(lang, member.Role)))
+	}
+	if workspace.OwnerID == user.ID {
+		builder.WriteString(lang.T("\n• /workspace role &lt;id&gt; editor|viewer — сменить роль\n"))
+		builder.WriteString(lang.T("• /workspace remove &lt;id&gt; — исключить участника"))
+	}
+	return b.sendText(ctx, chatID, strings.TrimSpace(builder.String()))
+}
+
+func (b *Bot) setWorkspaceRole(ctx context.Context, chatID int64, user *model.User, arg string) error {
+	lang := i18n.FromContext(ctx)
+	rawID, role, _ := strings.Cut(arg, " ")
+	memberID, err := strconv.ParseUint(strings.TrimSpace(rawID), 10, 64)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.T("Формат: /workspace role &lt;id&gt; editor|viewer"))
+	}
+	role = strings.ToLower(strings.TrimSpace(role))
+	err = b.workspaceSvc.SetRole(ctx, user, uint(memberID), role)
+	switch {
+	case errors.Is(err, service.ErrInvalidRole):
+		return b.sendText(ctx, chatID, lang.T("Роль может быть только editor или viewer."))
+	case errors.Is(err, service.ErrNotWorkspaceMember):
+		return b.sendText(ctx, chatID, lang.T("Такого участника нет в пространстве."))
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, chatID, lang.T("Сначала выбери пространство: /workspace switch &lt;id&gt;"))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось сменить роль: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] workspace role changed workspace=%d member=%d role=%s", user.ActiveWorkspaceID, memberID, role)
+	return b.sendText(ctx, chatID, lang.Tf("✅ Роль участника %d: %s.", memberID, roleLabel(lang, role)))
+}
+
+func (b *Bot) removeWorkspaceMember(ctx context.Context, chatID int64, user *model.User, arg string) error {
+	lang := i18n.FromContext(ctx)
+	memberID, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.T("Формат: /workspace remove &lt;id&gt;"))
+	}
+	err = b.workspaceSvc.RemoveMember(ctx, user, uint(memberID))
+	switch {
+	case errors.Is(err, service.ErrNotWorkspaceMember):
+		return b.sendText(ctx, chatID, lang.T("Такого участника нет в пространстве."))
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, chatID, lang.T("Сначала выбери пространство: /workspace switch &lt;id&gt;"))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось исключить участника: %s", errorText(lang, err)))
+	}
+	log.Printf("[info] workspace member removed workspace=%d member=%d", user.ActiveWorkspaceID, memberID)
+	return b.sendText(ctx, chatID, lang.Tf("🚪 Участник %d исключён.", memberID))
+}
+
+func (b *Bot) leaveWorkspace(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	workspace, err := b.workspaceSvc.Leave(ctx, user)
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, chatID, lang.T("Ты сейчас в личных задачах — выходить неоткуда."))
+	case errors.Is(err, service.ErrForbidden):
+		return b.sendText(ctx, chatID, lang.T("Владелец не может покинуть своё пространство."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось выйти: %s", errorText(lang, err)))
+	}
+	return b.sendText(ctx, chatID, lang.Tf("🚪 Ты покинул(а) «%s». Снова открыты личные задачи.", escape(workspace.Name)))
+}
+
+func memberName(user model.User) string {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if user.Username != "" {
+		if name == "" {
+			return "@" + user.Username
+		}
+		return fmt.Sprintf("%s (@%s)", name, user.Username)
+	}
+	if name == "" {
+		return fmt.Sprintf("id %d", user.TelegramID)
+	}
+	return name
+}
+
+func roleLabel(lang i18n.Lang, role string) string {
+	switch role {
+	case model.RoleOwner:
+		return lang.T("👑 владелец")
+	case model.RoleViewer:
+		return lang.T("👀 наблюдатель")
+	default:
+		return lang.T("✏️ редактор")
+	}
+}
+
+// handleGroupMessage serves the few commands that make sense in group chats.
+func (b *Bot) handleGroupMessage(ctx context.Context, msg *tgbotapi.Message) error {
+	lang := i18n.FromContext(ctx)
+	if msg.From == nil || !msg.IsCommand() {
+		return nil
+	}
+	command := msg.Command()
+	if command != "workspace" && command != "category" {
+		return nil
+	}
+	user := CurrentUser(ctx)
+	if command == "category" {
+		return b.handleGroupCategory(ctx, msg, user)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(msg.CommandArguments())) {
+	case "bind":
+		workspace, err := b.workspaceSvc.BindReportChat(ctx, user, msg.Chat.ID)
+		if err != nil {
+			return b.sendGroupText(msg.Chat.ID, groupBindError(lang, err))
+		}
+		log.Printf("[info] workspace report bound workspace=%d chat=%d", workspace.ID, msg.Chat.ID)
+		return b.sendGroupText(msg.Chat.ID, lang.Tf("🏠 Сюда будут приходить отчёты пространства «%s».", escape(workspace.Name)))
+	case "unbind":
+		workspace, err := b.workspaceSvc.UnbindReportChat(ctx, user)
+		if err != nil {
+			return b.sendGroupText(msg.Chat.ID, groupBindError(lang, err))
+		}
+		log.Printf("[info] workspace report unbound workspace=%d", workspace.ID)
+		return b.sendGroupText(msg.Chat.ID, lang.Tf("🔕 Отчёты пространства «%s» больше не публикуются в группе.", escape(workspace.Name)))
+	default:
+		return b.sendGroupText(msg.Chat.ID, lang.T("В группе доступны /workspace bind и /workspace unbind — их выполняет владелец активного пространства."))
+	}
+}
+
+func groupBindError(lang i18n.Lang, err error) string {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return lang.T("Сначала выбери пространство в личном чате с ботом: /workspace switch &lt;id&gt;")
+	case errors.Is(err, service.ErrForbidden):
+		return lang.T("Привязать группу может только владелец пространства.")
+	default:
+		return lang.Tf("Ошибка: %s", errorText(lang, err))
+	}
+}
+
+// SendWorkspaceReports posts shared task reports into bound group chats and the parts of
+// routed categories into their chats.
+func (b *Bot) SendWorkspaceReports(ctx context.Context) error {
+	now := time.Now()
+	workspaces, err := b.workspaceSvc.ListWithReports(ctx)
+	if err != nil {
+		return err
+	}
+	for _, workspace := range workspaces {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if workspace.ReportChatID != 0 {
+			text, err := b.reminderSvc.WorkspaceSummary(ctx, workspace, now)
+			if err != nil {
+				log.Printf("build workspace summary %d: %v", workspace.ID, err)
+			} else if err := b.sendGroupText(workspace.ReportChatID, text); err != nil {
+				log.Printf("send workspace summary to %d: %v", workspace.ReportChatID, err)
+			}
+		}
+		policy, err := b.reminderSvc.WorkspacePolicy(ctx, workspace)
+		if err != nil {
+			log.Printf("workspace policy %d: %v", workspace.ID, err)
+			continue
+		}
+		b.dispatchRouted(ctx, model.Scope{WorkspaceID: workspace.ID}, policy, now)
+	}
+	return nil
+}
+
+// sendGroupText sends a message without the private-chat reply keyboard.
+func (b *Bot) sendGroupText(chatID int64, text string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	_, err := b.api.Send(msg)
+	return err
+}
+
+func (b *Bot) sendManagerDigest(ctx context.Context, chatID int64, user *model.User) error {
+	lang := i18n.FromContext(ctx)
+	workspace, err := b.workspaceSvc.OwnedActive(ctx, user)
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound):
+		return b.sendText(ctx, chatID, lang.T("Сначала выбери пространство: /workspace switch &lt;id&gt;"))
+	case errors.Is(err, service.ErrForbidden):
+		return b.sendText(ctx, chatID, lang.T("Итоги недели доступны только владельцу пространства."))
+	case err != nil:
+		return b.sendText(ctx, chatID, lang.Tf("Ошибка: %s", errorText(lang, err)))
+	}
+	text, err := b.reminderSvc.ManagerDigest(ctx, *workspace, time.Now())
+	if err != nil {
+		return b.sendText(ctx, chatID, lang.Tf("Не удалось собрать итоги: %s", errorText(lang, err)))
+	}
+	return b.sendText(ctx, chatID, text)
+}
+
+// SendManagerDigests sends the weekly team roll-up privately to every workspace owner.
+func (b *Bot) SendManagerDigests(ctx context.Context) error {
+	workspaces, err := b.workspaceSvc.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, workspace := range workspaces {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		owner, err := b.userRepo.FindByID(ctx, workspace.OwnerID)
+		if err != nil {
+			log.Printf("find owner of workspace %d: %v", workspace.ID, err)
+			continue
+		}
+		ctx := withReader(ctx, *owner)
+		text, err := b.reminderSvc.ManagerDigest(ctx, workspace, now)
+		if err != nil {
+			log.Printf("build digest for workspace %d: %v", workspace.ID, err)
+			continue
+		}
+		if err := b.sendText(ctx, owner.TelegramID, text); err != nil {
+			log.Printf("send digest to %d: %v", owner.TelegramID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Package calendar renders tasks for external calendar applications.
+package calendar
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	timedDuration  = time.Hour
+)
+
+// EventICS builds a single-event iCalendar file for the task deadline.
+// It returns false when the task has no deadline.
+func EventICS(task model.Task, now time.Time) ([]byte, bool) {
+	if task.Deadline == nil {
+		return nil, false
+	}
+	start, end := eventDates(*task.Deadline)
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//daily-planner//RU\r\n")
+	b.WriteString("BEGIN:VEVENT\r\n")
+	fmt.Fprintf(&b, "UID:task-%d@daily-planner\r\n", task.ID)
+	fmt.Fprintf(&b, "DTSTAMP:%s\r\n", now.UTC().Format(dateTimeLayout))
+	if isAllDay(*task.Deadline) {
+		fmt.Fprintf(&b, "DTSTART;VALUE=DATE:%s\r\n", start)
+		fmt.Fprintf(&b, "DTEND;VALUE=DATE:%s\r\n", end)
+	} else {
+		fmt.Fprintf(&b, "DTSTART:%s\r\n", start)
+		fmt.Fprintf(&b, "DTEND:%s\r\n", end)
+	}
+	fmt.Fprintf(&b, "SUMMARY:%s\r\n", escapeText(task.Title))
+	if task.Description != "" {
+		fmt.Fprintf(&b, "DESCRIPTION:%s\r\n", escapeText(task.Description))
+	}
+	b.WriteString("END:VEVENT\r\n")
+	b.WriteString("END:VCALENDAR\r\n")
+	return []byte(b.String()), true
+}
+
+// GoogleCalendarURL returns a Google Calendar "create event" link for the task deadline.
+func GoogleCalendarURL(task model.Task) (string, bool) {
+	if task.Deadline == nil {
+		return "", false
+	}
+	start, end := eventDates(*task.Deadline)
+	query := url.Values{}
+	query.Set("action", "TEMPLATE")
+	query.Set("text", strings.TrimSpace(task.Title))
+	query.Set("dates", start+"/"+end)
+	if task.Description != "" {
+		query.Set("details", task.Description)
+	}
+	return "https://calendar.google.com/calendar/render?" + query.Encode(), true
+}
+
+// isAllDay treats deadlines at exactly midnight as date-only deadlines.
+func isAllDay(deadline time.Time) bool {
+	h, m, s := deadline.Clock()
+	return h == 0 && m == 0 && s == 0
+}
+
+func eventDates(deadline time.Time) (string, string) {
+	if isAllDay(deadline) {
+		return deadline.Format(dateLayout), deadline.AddDate(0, 0, 1).Format(dateLayout)
+	}
+	start := deadline.UTC()
+	return start.Format(dateTimeLayout), start.Add(timedDuration).Format(dateTimeLayout)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(value string) string {
+	return textEscaper.Replace(strings.TrimSpace(value))
+}
@@ -2,34 +2,212 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// minHTTPTimeout leaves long polling, which waits up to 10 seconds less, some time to wait.
+const minHTTPTimeout = 20 * time.Second
+
 // Config keeps runtime settings for the bot.
 type Config struct {
-	TelegramToken  string
-	DatabaseURL    string
+	TelegramToken string
+	// Non-empty BotAPIURL points the bot at a self-hosted Bot API server, e.g. http://localhost:8081.
+	BotAPIURL string
+	// Non-empty TelegramProxy sends Bot API requests through an http://, https://, socks5:// or socks5h:// proxy.
+	TelegramProxy string
+	// Outbound HTTP: HTTPTimeout bounds one request, long polling included; failed requests
+	// that are safe to repeat are retried up to HTTPMaxRetries times.
+	HTTPTimeout    time.Duration
+	HTTPMaxRetries int
+	DatabaseURL    string // SQLite file or postgres:// URL
+	// DataDir keeps the files of the bot: the SQLite database unless DATABASE_URL names another place,
+	// and whatever features store on disk, each in its own subdirectory.
+	DataDir        string
 	ReportInterval time.Duration
+	// ReportTimeout bounds building and sending one user's report; reports slower than half of it are logged.
+	ReportTimeout time.Duration
+	// Connection pool tuning; zero keeps the driver defaults.
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxIdleTime time.Duration
+	// Sunday HH:MM for the weekly workspace owner digest; empty disables it.
+	ManagerDigestTime string
+	// Sunday HH:MM for the weekly summary of users who turned it on; empty disables it.
+	WeeklySummaryTime    string
+	CalendarSyncInterval time.Duration
+	// Per-user quotas; admins and users exempted by an admin are not limited.
+	MaxActiveTasks     int
+	MaxCategories      int
+	MaxAttachmentBytes int64
+	AdminIDs           []int64
+	// Non-empty AllowedUserIDs makes the instance private: other new users ask an admin for access.
+	AllowedUserIDs []int64
+	// Non-empty SIGNUP_INVITE_CODES makes new users present one of the codes first.
+	SignupInviteCodes []string
+	// RequireCaptcha makes new users press a verification button before using the bot.
+	RequireCaptcha bool
+	// Users silent for InactiveMonths are asked whether they still want reports (0 disables);
+	// without an answer within RetentionGraceDays they are archived.
+	InactiveMonths     int
+	RetentionGraceDays int
+	// Non-empty WebhookURL makes the bot receive updates over HTTP on ListenAddr instead of long polling.
+	// Telegram must send WebhookSecret back in every request; a random one is used when it is empty.
+	WebhookURL     string
+	ListenAddr     string
+	WebhookSecret  string
+	WebhookTLSCert string
+	WebhookTLSKey  string
+	// Non-empty HealthListenAddr serves the /healthz and /readyz probes.
+	HealthListenAddr string
+	// Task sync between instances: SyncListenAddr serves the sync endpoint, SyncPeerURL
+	// is pulled every SyncInterval. Only personal tasks of SyncUserIDs are exchanged.
+	SyncSecret     string
+	SyncListenAddr string
+	SyncPeerURL    string
+	SyncInterval   time.Duration
+	SyncUserIDs    []int64
+	// MaintenanceMode answers everyone except admins with a maintenance notice.
+	MaintenanceMode bool
+	// Every user may send RateLimitBurst updates at once and RateLimit more a minute after that;
+	// RateLimit 0 turns the limit off.
+	RateLimit      int
+	RateLimitBurst int
+	// WorkerCount is how many updates are handled at once; updates of one chat keep their order.
+	WorkerCount int
+	// TaskPageSize is how many tasks one page of /tasks shows.
+	TaskPageSize int
+	// TaskAgingDays marks tasks without a deadline that have been open this long; 0 disables the marks.
+	TaskAgingDays int
+	// LegacyMenuPlaceholder removes one-off keyboards and sends a separate "Главное меню" message
+	// instead of putting the main menu on the message that closes them.
+	LegacyMenuPlaceholder bool
+	// BuddyOverdueDays is how long a flagged task may stay overdue before the owner's partner hears of it.
+	BuddyOverdueDays int
+	// Non-empty TracingEndpoint exports OpenTelemetry spans over OTLP/HTTP, e.g. http://localhost:4318.
+	TracingEndpoint string
 }
 
 // Load reads configuration from environment variables with sane defaults.
 func Load() (Config, error) {
 	cfg := Config{
-		TelegramToken:  strings.TrimSpace(os.Getenv("TELEGRAM_TOKEN")),
-		DatabaseURL:    strings.TrimSpace(os.Getenv("DATABASE_URL")),
-		ReportInterval: parseInterval(strings.TrimSpace(os.Getenv("REPORT_INTERVAL_HOURS"))),
+		TelegramToken:         strings.TrimSpace(os.Getenv("TELEGRAM_TOKEN")),
+		BotAPIURL:             strings.TrimRight(strings.TrimSpace(os.Getenv("TELEGRAM_API_URL")), "/"),
+		TelegramProxy:         strings.TrimSpace(os.Getenv("TELEGRAM_PROXY")),
+		HTTPTimeout:           time.Duration(parsePositiveInt(os.Getenv("HTTP_TIMEOUT_SECONDS"), 90)) * time.Second,
+		HTTPMaxRetries:        parseNonNegativeInt(os.Getenv("HTTP_MAX_RETRIES"), 4),
+		DatabaseURL:           strings.TrimSpace(os.Getenv("DATABASE_URL")),
+		DataDir:               strings.TrimSpace(os.Getenv("DATA_DIR")),
+		ReportInterval:        parseInterval(strings.TrimSpace(os.Getenv("REPORT_INTERVAL_HOURS"))),
+		ReportTimeout:         time.Duration(parsePositiveInt(os.Getenv("REPORT_TIMEOUT_SECONDS"), 30)) * time.Second,
+		CalendarSyncInterval:  parseInterval(strings.TrimSpace(os.Getenv("CALENDAR_SYNC_HOURS"))),
+		MaxActiveTasks:        parsePositiveInt(os.Getenv("MAX_ACTIVE_TASKS"), 500),
+		MaxCategories:         parsePositiveInt(os.Getenv("MAX_CATEGORIES"), 50),
+		MaxAttachmentBytes:    int64(parsePositiveInt(os.Getenv("MAX_ATTACHMENT_MB"), 5)) << 20,
+		AdminIDs:              parseIDList(os.Getenv("ADMIN_IDS")),
+		AllowedUserIDs:        parseIDList(os.Getenv("ALLOWED_USER_IDS")),
+		SignupInviteCodes:     parseList(os.Getenv("SIGNUP_INVITE_CODES")),
+		RequireCaptcha:        parseBool(os.Getenv("REQUIRE_CAPTCHA")),
+		InactiveMonths:        parseNonNegativeInt(os.Getenv("INACTIVE_MONTHS"), 6),
+		RetentionGraceDays:    parsePositiveInt(os.Getenv("RETENTION_GRACE_DAYS"), 14),
+		DBMaxOpenConns:        parseNonNegativeInt(os.Getenv("DB_MAX_OPEN_CONNS"), 0),
+		DBMaxIdleConns:        parseNonNegativeInt(os.Getenv("DB_MAX_IDLE_CONNS"), 0),
+		DBConnMaxIdleTime:     parseDuration(os.Getenv("DB_CONN_MAX_IDLE_TIME")),
+		WebhookURL:            strings.TrimSpace(os.Getenv("WEBHOOK_URL")),
+		ListenAddr:            strings.TrimSpace(os.Getenv("LISTEN_ADDR")),
+		WebhookSecret:         strings.TrimSpace(os.Getenv("WEBHOOK_SECRET")),
+		WebhookTLSCert:        strings.TrimSpace(os.Getenv("WEBHOOK_TLS_CERT")),
+		WebhookTLSKey:         strings.TrimSpace(os.Getenv("WEBHOOK_TLS_KEY")),
+		HealthListenAddr:      strings.TrimSpace(os.Getenv("HEALTH_LISTEN_ADDR")),
+		SyncSecret:            strings.TrimSpace(os.Getenv("SYNC_SECRET")),
+		SyncListenAddr:        strings.TrimSpace(os.Getenv("SYNC_LISTEN_ADDR")),
+		SyncPeerURL:           strings.TrimSpace(os.Getenv("SYNC_PEER_URL")),
+		SyncInterval:          time.Duration(parsePositiveInt(os.Getenv("SYNC_INTERVAL_MINUTES"), 15)) * time.Minute,
+		SyncUserIDs:           parseIDList(os.Getenv("SYNC_USER_IDS")),
+		TracingEndpoint:       strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")),
+		MaintenanceMode:       parseBool(os.Getenv("MAINTENANCE_MODE")),
+		WorkerCount:           parsePositiveInt(os.Getenv("WORKER_COUNT"), 8),
+		RateLimit:             parseNonNegativeInt(os.Getenv("RATE_LIMIT_PER_MINUTE"), 30),
+		RateLimitBurst:        parsePositiveInt(os.Getenv("RATE_LIMIT_BURST"), 10),
+		TaskPageSize:          parsePositiveInt(os.Getenv("TASK_PAGE_SIZE"), 15),
+		TaskAgingDays:         parseNonNegativeInt(os.Getenv("TASK_AGING_DAYS"), 14),
+		LegacyMenuPlaceholder: parseBool(os.Getenv("LEGACY_MENU_PLACEHOLDER")),
+		BuddyOverdueDays:      parsePositiveInt(os.Getenv("BUDDY_OVERDUE_DAYS"), 3),
+	}
+
+	digest, ok := os.LookupEnv("MANAGER_DIGEST_TIME")
+	cfg.ManagerDigestTime = strings.TrimSpace(digest)
+	if !ok {
+		cfg.ManagerDigestTime = "20:00"
+	}
+
+	weekly, ok := os.LookupEnv("WEEKLY_SUMMARY_TIME")
+	cfg.WeeklySummaryTime = strings.TrimSpace(weekly)
+	if !ok {
+		cfg.WeeklySummaryTime = "19:00"
+	}
+
+	if cfg.DataDir == "" {
+		cfg.DataDir = "."
 	}
 
 	if cfg.DatabaseURL == "" {
-		cfg.DatabaseURL = "daily_planner.db"
+		cfg.DatabaseURL = filepath.Join(cfg.DataDir, "daily_planner.db")
 	}
 
 	if cfg.ReportInterval == 0 {
 		cfg.ReportInterval = 5 * time.Hour
 	}
 
+	if cfg.CalendarSyncInterval == 0 {
+		cfg.CalendarSyncInterval = 6 * time.Hour
+	}
+
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = ":8080"
+	}
+
+	if cfg.BotAPIURL != "" && !strings.HasPrefix(cfg.BotAPIURL, "http://") && !strings.HasPrefix(cfg.BotAPIURL, "https://") {
+		return cfg, fmt.Errorf("TELEGRAM_API_URL must start with http:// or https://")
+	}
+
+	if cfg.HTTPTimeout < minHTTPTimeout {
+		return cfg, fmt.Errorf("HTTP_TIMEOUT_SECONDS must be at least %d", int(minHTTPTimeout/time.Second))
+	}
+
+	if cfg.TelegramProxy != "" && !validProxy(cfg.TelegramProxy) {
+		return cfg, fmt.Errorf("TELEGRAM_PROXY must be an http://, https://, socks5:// or socks5h:// URL")
+	}
+
+	if cfg.WebhookURL != "" && !strings.HasPrefix(cfg.WebhookURL, "https://") {
+		return cfg, fmt.Errorf("WEBHOOK_URL must start with https://")
+	}
+
+	if cfg.WebhookSecret != "" && !validWebhookSecret(cfg.WebhookSecret) {
+		return cfg, fmt.Errorf("WEBHOOK_SECRET must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
+	}
+
+	if (cfg.WebhookTLSCert == "") != (cfg.WebhookTLSKey == "") {
+		return cfg, fmt.Errorf("WEBHOOK_TLS_CERT and WEBHOOK_TLS_KEY must be set together")
+	}
+
+	if cfg.HealthListenAddr != "" && (cfg.WebhookURL != "" && cfg.HealthListenAddr == cfg.ListenAddr || cfg.HealthListenAddr == cfg.SyncListenAddr) {
+		return cfg, fmt.Errorf("HEALTH_LISTEN_ADDR must differ from LISTEN_ADDR and SYNC_LISTEN_ADDR")
+	}
+
+	if cfg.SyncListenAddr != "" || cfg.SyncPeerURL != "" {
+		if len(cfg.SyncSecret) < 16 {
+			return cfg, fmt.Errorf("SYNC_SECRET of at least 16 characters is required for task sync")
+		}
+		if cfg.SyncPeerURL != "" && !strings.HasPrefix(cfg.SyncPeerURL, "https://") {
+			return cfg, fmt.Errorf("SYNC_PEER_URL must start with https://")
+		}
+	}
+
 	if cfg.TelegramToken == "" {
 		return cfg, fmt.Errorf("TELEGRAM_TOKEN is required")
 	}
@@ -37,6 +215,32 @@ func Load() (Config, error) {
 	return cfg, nil
 }
 
+// validProxy checks that the proxy URL has a host and a scheme net/http can dial through.
+func validProxy(raw string) bool {
+	proxy, err := url.Parse(raw)
+	if err != nil || proxy.Host == "" {
+		return false
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return true
+	}
+	return false
+}
+
+// validWebhookSecret checks the characters Telegram allows in a webhook secret token.
+func validWebhookSecret(secret string) bool {
+	if len(secret) > 256 {
+		return false
+	}
+	for _, r := range secret {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
 func parseInterval(raw string) time.Duration {
 	if raw == "" {
 		return 0
@@ -47,3 +251,56 @@ func parseInterval(raw string) time.Duration {
 	}
 	return hours
 }
+
+// parseDuration reads values like "5m" or "1h30m"; malformed or negative values give 0.
+func parseDuration(raw string) time.Duration {
+	value, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || value < 0 {
+		return 0
+	}
+	return value
+}
+
+func parsePositiveInt(raw string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// parseNonNegativeInt is like parsePositiveInt but accepts 0, which callers treat as "disabled".
+func parseNonNegativeInt(raw string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// parseIDList reads a comma-separated list of Telegram IDs, skipping malformed entries.
+func parseIDList(raw string) []int64 {
+	var ids []int64
+	for _, part := range strings.Split(raw, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err == nil && id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func parseList(raw string) []string {
+	var items []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+	return items
+}
+
+func parseBool(raw string) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(raw))
+	return err == nil && value
+}
@@ -0,0 +1,122 @@
+// Package datadir checks the directories the bot keeps its files in: DATA_DIR, where the
+// SQLite database lives by default and other features keep their files, and the directory
+// of the database itself.
+package datadir
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Prepare creates dir when it is missing and checks that the bot can write there.
+func Prepare(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// CheckFile checks that an existing file can be opened for writing; a missing one is fine.
+func CheckFile(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", path, err)
+	}
+	return file.Close()
+}
+
+// Sub returns the subdirectory name of dir, creating it when it is missing.
+func Sub(dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return "", fmt.Errorf("create %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// Ephemeral tells whether path is inside a container but not on a mounted volume, so
+// whatever is written there is lost when the container is recreated.
+func Ephemeral(path string) bool {
+	if !inContainer() {
+		return false
+	}
+	mounts, err := mountPoints()
+	if err != nil {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return mountPointOf(abs, mounts) == "/"
+}
+
+// inContainer detects Docker, Podman and Kubernetes.
+func inContainer() bool {
+	for _, marker := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != ""
+}
+
+// mountPoints lists the mount points of the process from /proc/self/mountinfo.
+func mountPoints() ([]string, error) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var mounts []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// The fifth field is the mount point, with spaces and the like escaped as \040.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, unescapeMount(fields[4]))
+	}
+	return mounts, scanner.Err()
+}
+
+// mountPointOf is the deepest of mounts that contains path.
+func mountPointOf(path string, mounts []string) string {
+	best := ""
+	for _, mount := range mounts {
+		if (path == mount || mount == "/" || strings.HasPrefix(path, mount+"/")) && len(mount) > len(best) {
+			best = mount
+		}
+	}
+	return best
+}
+
+func unescapeMount(raw string) string {
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' && i+3 < len(raw) {
+			if code, err := strconv.ParseUint(raw[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(raw[i])
+	}
+	return b.String()
+}
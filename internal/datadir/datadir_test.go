@@ -0,0 +1,40 @@
+package datadir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMountPointOf(t *testing.T) {
+	mounts := []string{"/", "/data", "/proc", "/my volume"}
+	cases := map[string]string{
+		"/app/daily_planner.db":  "/",
+		"/data/daily_planner.db": "/data",
+		"/data":                  "/data",
+		"/database/planner.db":   "/",
+		"/my volume/planner.db":  "/my volume",
+	}
+	for path, want := range cases {
+		if got := mountPointOf(path, mounts); got != want {
+			t.Errorf("mountPointOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if got := unescapeMount(`/my\040volume`); got != "/my volume" {
+		t.Errorf("unescapeMount = %q", got)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := Prepare(dir); err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("write check left %v (%v)", entries, err)
+	}
+	if err := CheckFile(filepath.Join(dir, "missing.db")); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+}
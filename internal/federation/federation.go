@@ -0,0 +1,202 @@
+// Package federation syncs personal tasks between two self-hosted instances.
+//
+// One instance (the client) periodically POSTs its changes to the other's sync
+// endpoint and applies the changes it gets back. Requests and responses are
+// signed with HMAC-SHA256 over the timestamp and body using a shared secret.
+package federation
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/service"
+)
+
+const (
+	// Path is where the sync endpoint is served.
+	Path = "/sync"
+
+	signatureHeader = "X-Planner-Signature"
+	timestampHeader = "X-Planner-Timestamp"
+	// maxSkew is how far a request's timestamp may be from the receiver's clock.
+	maxSkew = 5 * time.Minute
+	// maxBodyBytes bounds a single sync payload.
+	maxBodyBytes = 16 << 20
+)
+
+// ErrBadSignature is returned for payloads not signed with the shared secret.
+var ErrBadSignature = errors.New("bad sync signature")
+
+// Request carries the client's changes and asks for the server's since Since.
+type Request struct {
+	Since   time.Time           `json:"since"` // server's clock, from the previous Response.Now
+	Batches []service.SyncBatch `json:"batches"`
+}
+
+// Response carries the server's changes.
+type Response struct {
+	Now     time.Time           `json:"now"` // server's clock before collecting; the next Since
+	Batches []service.SyncBatch `json:"batches"`
+}
+
+// Sign returns the signature of body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature headers of a payload.
+func verify(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp, err := strconv.ParseInt(header.Get(timestampHeader), 10, 64)
+	if err != nil {
+		return ErrBadSignature
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > maxSkew || skew < -maxSkew {
+		return ErrBadSignature
+	}
+	want := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(header.Get(signatureHeader)), []byte(want)) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func sign(secret string, header http.Header, body []byte, now time.Time) {
+	timestamp := now.Unix()
+	header.Set(timestampHeader, strconv.FormatInt(timestamp, 10))
+	header.Set(signatureHeader, Sign(secret, timestamp, body))
+}
+
+// Handler serves the sync endpoint.
+func Handler(syncSvc *service.SyncService, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if err := verify(secret, r.Header, body, time.Now()); err != nil {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		// Collect before applying so the client's own changes are not echoed back.
+		resp := Response{Now: time.Now()}
+		if resp.Batches, err = syncSvc.Collect(r.Context(), req.Since); err != nil {
+			log.Printf("sync collect: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		result, err := syncSvc.Apply(r.Context(), req.Batches)
+		if err != nil {
+			log.Printf("sync apply: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[info] sync from peer created=%d updated=%d deleted=%d skipped=%d", result.Created, result.Updated, result.Deleted, result.Skipped)
+
+		out, err := json.Marshal(resp)
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		sign(secret, w.Header(), out, time.Now())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(out)
+	})
+}
+
+// Client syncs with one peer instance.
+type Client struct {
+	peerURL string
+	secret  string
+	http    *http.Client
+	syncSvc *service.SyncService
+}
+
+func NewClient(peerURL, secret string, httpClient *http.Client, syncSvc *service.SyncService) *Client {
+	return &Client{peerURL: peerURL, secret: secret, http: httpClient, syncSvc: syncSvc}
+}
+
+// Sync sends local changes made since the last run and applies the peer's.
+func (c *Client) Sync(ctx context.Context, now time.Time) (service.SyncResult, error) {
+	state, err := c.syncSvc.State(ctx, c.peerURL)
+	if err != nil {
+		return service.SyncResult{}, err
+	}
+	local, err := c.syncSvc.Collect(ctx, state.LocalSince)
+	if err != nil {
+		return service.SyncResult{}, err
+	}
+	resp, err := c.exchange(ctx, Request{Since: state.RemoteSince, Batches: local}, now)
+	if err != nil {
+		return service.SyncResult{}, err
+	}
+	result, err := c.syncSvc.Apply(ctx, resp.Batches)
+	if err != nil {
+		return result, err
+	}
+	if err := c.syncSvc.SaveState(ctx, &model.SyncState{ID: state.ID, PeerURL: c.peerURL, RemoteSince: resp.Now, LocalSince: now}); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (c *Client) exchange(ctx context.Context, payload Request, now time.Time) (*Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.peerURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	sign(c.secret, req.Header, body, now)
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sync request: %w", err)
+	}
+	defer res.Body.Close()
+	out, err := io.ReadAll(io.LimitReader(res.Body, maxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read sync response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sync request: peer answered %s", res.Status)
+	}
+	if err := verify(c.secret, res.Header, out, time.Now()); err != nil {
+		return nil, err
+	}
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("decode sync response: %w", err)
+	}
+	return &resp, nil
+}
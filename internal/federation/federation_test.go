@@ -0,0 +1,257 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+)
+
+const testSecret = "0123456789abcdef"
+
+type instance struct {
+	db   *gorm.DB
+	sync *service.SyncService
+	user *model.User
+}
+
+func newInstance(t *testing.T, name string, telegramID int64) *instance {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"), name)
+	db, err := repository.NewDB(dsn, repository.PoolConfig{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("db handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	userRepo := repository.NewUserRepository(db)
+	user, err := userRepo.UpsertFromTelegram(context.Background(), telegramID, "Alice", "", "", "")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	accountSvc := service.NewAccountService(repository.NewAccountRepository(db), userRepo)
+	syncSvc := service.NewSyncService(repository.NewSyncRepository(db), userRepo, repository.NewCategoryRepository(db), accountSvc, []int64{telegramID})
+	return &instance{db: db, sync: syncSvc, user: user}
+}
+
+func (i *instance) tasks(t *testing.T) map[string]model.Task {
+	t.Helper()
+	var tasks []model.Task
+	if err := i.db.Where("user_id = ?", i.user.ID).Find(&tasks).Error; err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	byTitle := make(map[string]model.Task)
+	for _, task := range tasks {
+		byTitle[task.Title] = task
+	}
+	return byTitle
+}
+
+// trashed returns the tasks in the trash of the instance by title.
+func (i *instance) trashed(t *testing.T) map[string]model.Task {
+	t.Helper()
+	var tasks []model.Task
+	if err := i.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", i.user.ID).Find(&tasks).Error; err != nil {
+		t.Fatalf("list trash: %v", err)
+	}
+	byTitle := make(map[string]model.Task)
+	for _, task := range tasks {
+		byTitle[task.Title] = task
+	}
+	return byTitle
+}
+
+func TestSyncBetweenInstances(t *testing.T) {
+	ctx := context.Background()
+	home := newInstance(t, "home", 42)
+	vps := newInstance(t, "vps", 42)
+
+	server := httptest.NewServer(Handler(vps.sync, testSecret))
+	defer server.Close()
+	client := NewClient(server.URL+Path, testSecret, server.Client(), home.sync)
+
+	homeTask := model.Task{UserID: home.user.ID, Title: "Полить цветы"}
+	if err := home.db.Create(&homeTask).Error; err != nil {
+		t.Fatal(err)
+	}
+	vpsTask := model.Task{UserID: vps.user.ID, Title: "Оплатить VPS"}
+	if err := vps.db.Create(&vpsTask).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	if _, ok := vps.tasks(t)["Полить цветы"]; !ok {
+		t.Fatal("home task did not reach the peer")
+	}
+	if _, ok := home.tasks(t)["Оплатить VPS"]; !ok {
+		t.Fatal("peer task did not reach home")
+	}
+
+	// The newer edit wins: the peer completes the task after home renamed it.
+	synced := vps.tasks(t)["Полить цветы"]
+	if err := home.db.Model(&homeTask).Updates(map[string]interface{}{"description": "старое", "updated_at": time.Now().Add(-time.Minute)}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := vps.db.Model(&synced).Updates(map[string]interface{}{"is_completed": true, "updated_at": time.Now()}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if got := home.tasks(t)["Полить цветы"]; !got.IsCompleted || got.Description != "" {
+		t.Errorf("newer peer edit lost at home: %+v", got)
+	}
+	if got := vps.tasks(t)["Полить цветы"]; !got.IsCompleted {
+		t.Errorf("older home edit overwrote the peer: %+v", got)
+	}
+	if n := len(home.tasks(t)); n != 2 {
+		t.Errorf("home has %d tasks after syncing twice, want 2", n)
+	}
+}
+
+func TestSyncDeletion(t *testing.T) {
+	ctx := context.Background()
+	home := newInstance(t, "home", 42)
+	vps := newInstance(t, "vps", 42)
+
+	server := httptest.NewServer(Handler(vps.sync, testSecret))
+	defer server.Close()
+	client := NewClient(server.URL+Path, testSecret, server.Client(), home.sync)
+
+	for _, task := range []*model.Task{{UserID: home.user.ID, Title: "Полить цветы"}, {UserID: home.user.ID, Title: "Оплатить VPS"}} {
+		if err := home.db.Create(task).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	if n := len(vps.tasks(t)); n != 2 {
+		t.Fatalf("peer has %d tasks, want 2", n)
+	}
+
+	remove := func(db *gorm.DB, task model.Task) {
+		t.Helper()
+		now := time.Now()
+		if err := db.Model(&task).Updates(map[string]interface{}{"deleted_at": now, "updated_at": now}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	remove(home.db, home.tasks(t)["Полить цветы"])
+	remove(vps.db, vps.tasks(t)["Оплатить VPS"])
+	result, err := client.Sync(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("home applied %d deletions, want 1", result.Deleted)
+	}
+	if n := len(home.tasks(t)); n != 0 {
+		t.Errorf("home has %d tasks after both were deleted, want none", n)
+	}
+	if n := len(vps.tasks(t)); n != 0 {
+		t.Errorf("peer has %d tasks after both were deleted, want none", n)
+	}
+
+	// The tombstones do not bring the tasks back on the next round.
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("third sync: %v", err)
+	}
+	if n := len(home.tasks(t)) + len(vps.tasks(t)); n != 0 {
+		t.Errorf("%d deleted tasks came back", n)
+	}
+}
+
+func TestSyncTrash(t *testing.T) {
+	ctx := context.Background()
+	home := newInstance(t, "home", 42)
+	vps := newInstance(t, "vps", 42)
+
+	server := httptest.NewServer(Handler(vps.sync, testSecret))
+	defer server.Close()
+	client := NewClient(server.URL+Path, testSecret, server.Client(), home.sync)
+
+	task := model.Task{UserID: home.user.ID, Title: "Полить цветы"}
+	if err := home.db.Create(&task).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+
+	// Trashing at home is a change the peer receives.
+	scope := model.PersonalScope(home.user.ID)
+	if err := repository.NewTaskRepository(home.db).Delete(ctx, scope, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	peerCopy, ok := vps.trashed(t)["Полить цветы"]
+	if !ok {
+		t.Fatal("the task trashed at home is not in the peer's trash")
+	}
+
+	// Restoring it on the peer brings back the copy at home instead of creating another.
+	if _, err := repository.NewTaskRepository(vps.db).Undelete(ctx, model.PersonalScope(vps.user.ID), peerCopy.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx, time.Now()); err != nil {
+		t.Fatalf("third sync: %v", err)
+	}
+	if got, ok := home.tasks(t)["Полить цветы"]; !ok || got.ID != task.ID {
+		t.Errorf("the restored task did not come back at home: %+v", got)
+	}
+	var count int64
+	if err := home.db.Unscoped().Model(&model.Task{}).Where("user_id = ?", home.user.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("home has %d rows for the task, want 1", count)
+	}
+}
+
+func TestHandlerRejectsUnsignedRequests(t *testing.T) {
+	vps := newInstance(t, "vps", 42)
+	handler := Handler(vps.sync, testSecret)
+	body := `{"since": "2025-01-01T00:00:00Z", "batches": []}`
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	sign("wrong-secret-0000", req.Header, []byte(body), time.Now())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("wrong secret: status %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	sign(testSecret, req.Header, []byte(body), time.Now().Add(-time.Hour))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("stale timestamp: status %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	sign(testSecret, req.Header, []byte(body), time.Now())
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("signed request: status %d", rec.Code)
+	}
+}
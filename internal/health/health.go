@@ -0,0 +1,75 @@
+// Package health serves the liveness and readiness probes of the bot.
+//
+// /healthz answers whether the process should keep running, /readyz whether it can do its
+// work right now. Both run their checks on every request and answer 200 with "ok" per check,
+// or 503 with the errors of the failed ones. MetricsPath serves the process's expvar counters.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// Paths the probes are served on.
+	LivePath    = "/healthz"
+	ReadyPath   = "/readyz"
+	MetricsPath = "/debug/vars"
+
+	// checkTimeout bounds a single check, well within the usual probe timeouts.
+	checkTimeout = 3 * time.Second
+)
+
+// Check is a named probe of one dependency: Probe returns why it is not usable, or nil.
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) error
+}
+
+// Handler serves LivePath with the live checks, ReadyPath with the ready ones and MetricsPath.
+func Handler(live, ready []Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(LivePath, probeHandler(live))
+	mux.Handle(ReadyPath, probeHandler(ready))
+	mux.Handle(MetricsPath, expvar.Handler())
+	return mux
+}
+
+// response is the JSON body of a probe.
+type response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func probeHandler(checks []Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body := response{Status: "ok", Checks: make(map[string]string, len(checks))}
+		status := http.StatusOK
+		for _, check := range checks {
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			err := check.Probe(ctx)
+			cancel()
+			if err != nil {
+				log.Printf("[warn] %s check %s failed: %v", r.URL.Path, check.Name, err)
+				body.Status = "fail"
+				body.Checks[check.Name] = err.Error()
+				status = http.StatusServiceUnavailable
+				continue
+			}
+			body.Checks[check.Name] = "ok"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
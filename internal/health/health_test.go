@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	ok := Check{Name: "scheduler", Probe: func(ctx context.Context) error { return nil }}
+	down := Check{Name: "database", Probe: func(ctx context.Context) error { return errors.New("connection refused") }}
+	handler := Handler([]Check{ok}, []Check{ok, down})
+
+	cases := []struct {
+		method, path string
+		want         int
+		checks       map[string]string
+	}{
+		{http.MethodGet, LivePath, http.StatusOK, map[string]string{"scheduler": "ok"}},
+		{http.MethodGet, ReadyPath, http.StatusServiceUnavailable, map[string]string{"scheduler": "ok", "database": "connection refused"}},
+		{http.MethodPost, LivePath, http.StatusMethodNotAllowed, nil},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		if rec.Code != tc.want {
+			t.Errorf("%s %s: status %d, want %d", tc.method, tc.path, rec.Code, tc.want)
+			continue
+		}
+		if tc.checks == nil {
+			continue
+		}
+		var body response
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: decode: %v", tc.path, err)
+		}
+		for name, want := range tc.checks {
+			if body.Checks[name] != want {
+				t.Errorf("%s: check %s = %q, want %q", tc.path, name, body.Checks[name], want)
+			}
+		}
+	}
+}
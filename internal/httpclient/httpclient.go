@@ -0,0 +1,122 @@
+// Package httpclient builds the HTTP client every outbound call of the bot goes through:
+// Telegram, calendar feeds and the sync peer share its connection pool and timeouts.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	dialTimeout         = 10 * time.Second
+	keepAlive           = 30 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+	idleConnTimeout     = 90 * time.Second
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 10
+)
+
+// RetryBaseDelay is the first pause before repeating a request; it doubles with every retry.
+const RetryBaseDelay = 500 * time.Millisecond
+
+// Options configure the client.
+type Options struct {
+	// Timeout bounds a whole request, reading the body included.
+	Timeout time.Duration
+	// Retries is how often a failed GET or HEAD request is repeated; 0 disables retries.
+	Retries int
+	// Non-empty Proxy sends requests through an http://, https://, socks5:// or socks5h://
+	// proxy; otherwise HTTPS_PROXY and NO_PROXY apply.
+	Proxy string
+}
+
+// New returns a client with the given options.
+func New(opts Options) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: dialTimeout, KeepAlive: keepAlive}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	var rt http.RoundTripper = transport
+	if opts.Retries > 0 {
+		rt = &retrier{next: transport, retries: opts.Retries, sleep: Sleep}
+	}
+	return &http.Client{Transport: rt, Timeout: opts.Timeout}, nil
+}
+
+// retrier repeats GET and HEAD requests that failed to connect or got 502, 503 or 504,
+// with exponential backoff. Other methods may have had an effect and are sent once.
+type retrier struct {
+	next    http.RoundTripper
+	retries int
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func (r *retrier) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return r.next.RoundTrip(req)
+	}
+	backoff := RetryBaseDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := r.next.RoundTrip(req)
+		// Cancelled requests are not worth repeating.
+		if attempt == r.retries || req.Context().Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+		if err != nil {
+			log.Printf("[warn] %s %s: %v, retrying in %s", req.Method, req.URL.Host, err, backoff)
+		} else {
+			resp.Body.Close()
+			log.Printf("[warn] %s %s answered %s, retrying in %s", req.Method, req.URL.Host, resp.Status, backoff)
+		}
+		if err := r.sleep(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// retryable tells failures that may pass on their own from those that will not.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Sleep waits for d or until ctx is done, whichever comes first, and returns ctx's error in
+// the latter case.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
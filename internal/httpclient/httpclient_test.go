@@ -0,0 +1,60 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetries(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := New(Options{Timeout: time.Second, Retries: 2})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	var waits []time.Duration
+	client.Transport.(*retrier).sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || hits != 3 {
+		t.Errorf("got %d after %d requests, want 200 after 3", resp.StatusCode, hits)
+	}
+	if len(waits) != 2 || waits[0] != RetryBaseDelay || waits[1] != 2*RetryBaseDelay {
+		t.Errorf("waits %v", waits)
+	}
+
+	// A POST may have had an effect, so its failure is returned as is.
+	hits = 0
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || hits != 1 {
+		t.Errorf("post got %d after %d requests, want 503 after 1", resp.StatusCode, hits)
+	}
+
+	if _, err := New(Options{Proxy: "://bad"}); err == nil {
+		t.Error("bad proxy accepted")
+	}
+}
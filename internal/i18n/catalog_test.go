@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// templateMessage finds messages translated inside text/template sources: {{.Lang.T "…"}}.
+var templateMessage = regexp.MustCompile(`\.Lang\.Tf? "((?:[^"\\]|\\.)*)"`)
+
+var formatVerb = regexp.MustCompile(`%[-+# 0]*\d*(?:\.\d+)?[a-zA-Z%]`)
+
+// sourceMessages collects the messages passed to T, Tf and N and the bot command
+// descriptions in the non-test Go files under root, resolving string constants.
+func sourceMessages(t *testing.T, root string) map[string]string {
+	t.Helper()
+	packages := make(map[string][]*ast.File)
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		packages[filepath.Dir(path)] = append(packages[filepath.Dir(path)], file)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := make(map[string]string)
+	for _, files := range packages {
+		consts := make(map[string]ast.Expr)
+		for _, file := range files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					value := spec.(*ast.ValueSpec)
+					for i, name := range value.Names {
+						if i < len(value.Values) {
+							consts[name.Name] = value.Values[i]
+						}
+					}
+				}
+			}
+		}
+		var eval func(ast.Expr) (string, bool)
+		eval = func(expr ast.Expr) (string, bool) {
+			switch e := expr.(type) {
+			case *ast.BasicLit:
+				if e.Kind != token.STRING {
+					return "", false
+				}
+				s, err := strconv.Unquote(e.Value)
+				return s, err == nil
+			case *ast.Ident:
+				if value, ok := consts[e.Name]; ok {
+					return eval(value)
+				}
+			case *ast.ParenExpr:
+				return eval(e.X)
+			case *ast.BinaryExpr:
+				if e.Op == token.ADD {
+					x, okX := eval(e.X)
+					y, okY := eval(e.Y)
+					return x + y, okX && okY
+				}
+			}
+			return "", false
+		}
+		for _, file := range files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				switch n := node.(type) {
+				case *ast.CallExpr:
+					sel, ok := n.Fun.(*ast.SelectorExpr)
+					if !ok || len(n.Args) == 0 {
+						return true
+					}
+					switch sel.Sel.Name {
+					case "T", "Tf", "N":
+						if msg, ok := eval(n.Args[0]); ok {
+							messages[msg] = fset.Position(n.Pos()).String()
+						}
+					case "command":
+						// Command descriptions are translated for the menu.
+						if len(n.Args) > 1 {
+							if msg, ok := eval(n.Args[1]); ok && msg != "" {
+								messages[msg] = fset.Position(n.Pos()).String()
+							}
+						}
+					}
+				case *ast.BasicLit:
+					if n.Kind == token.STRING && strings.HasPrefix(n.Value, "`") {
+						for _, m := range templateMessage.FindAllStringSubmatch(n.Value, -1) {
+							msg, err := strconv.Unquote(`"` + m[1] + `"`)
+							if err == nil {
+								messages[msg] = fset.Position(n.Pos()).String()
+							}
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+	return messages
+}
+
+// TestCatalogs checks that every message in the code has a translation with the same
+// format verbs, and that the catalogs hold nothing the code no longer says.
+func TestCatalogs(t *testing.T) {
+	messages := sourceMessages(t, "..")
+	if len(messages) == 0 {
+		t.Fatal("no messages found")
+	}
+	for lang, catalog := range catalogs {
+		var missing []string
+		for msg, pos := range messages {
+			translated, ok := catalog[msg]
+			if !ok {
+				missing = append(missing, pos+": "+strconv.Quote(msg))
+				continue
+			}
+			want, got := formatVerb.FindAllString(msg, -1), formatVerb.FindAllString(translated, -1)
+			if strings.Join(want, " ") != strings.Join(got, " ") {
+				t.Errorf("%s: %q: verbs %v, translation has %v", lang, msg, want, got)
+			}
+		}
+		sort.Strings(missing)
+		for _, entry := range missing {
+			t.Errorf("%s: no translation at %s", lang, entry)
+		}
+		for msg := range catalog {
+			if _, ok := messages[msg]; !ok {
+				t.Errorf("%s: unused translation %q", lang, msg)
+			}
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		chosen, telegram string
+		want             Lang
+	}{
+		{"", "", RU},
+		{"", "ru", RU},
+		{"", "uk", RU},
+		{"", "en-US", EN},
+		{"", "de", EN},
+		{"en", "ru", EN},
+		{"ru", "en", RU},
+		{"fr", "ru", RU},
+	}
+	for _, tt := range tests {
+		if got := Resolve(tt.chosen, tt.telegram); got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %s, want %s", tt.chosen, tt.telegram, got, tt.want)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	for _, text := range []string{"✅ Готово", "✅ готово", "✅ Done", "✅ DONE"} {
+		if !Matches(text, "✅ Готово") {
+			t.Errorf("Matches(%q) = false", text)
+		}
+	}
+	if Matches("Done", "✅ Готово") {
+		t.Error("Matches accepted a partial text")
+	}
+}
@@ -0,0 +1,1051 @@
+package i18n
+
+// en holds the English translations, grouped by the file that says the message.
+var en = map[string]string{
+	// bot/access.go
+	"⏳ Запрос на доступ уже у администратора. Я напишу, как только его одобрят.":                                                      "⏳ Your access request is already with the administrator. I'll write as soon as it's approved.",
+	"🔒 Это личный бот, и тебя пока нет в списке его пользователей. Я передал запрос администратору и напишу, как только его одобрят.": "🔒 This is a private bot, and you're not on its list of users yet. I've passed your request to the administrator and will write as soon as it's approved.",
+	"🙋 %s (ID <code>%d</code>) просит доступ к боту.": "🙋 %s (ID <code>%d</code>) asks for access to the bot.",
+	"✅ Одобрить": "✅ Approve",
+	"Не удалось получить запросы: %s": "Could not get the requests: %s",
+	"Запросов на доступ нет.":         "There are no access requests.",
+	"🙋 <b>Запросы на доступ</b>\n":    "🙋 <b>Access requests</b>\n",
+	"Формат: /approve — запросы на доступ, /approve &lt;telegram_id&gt; — впустить пользователя.": "Format: /approve — access requests, /approve &lt;telegram_id&gt; — let the user in.",
+	"Запроса от этого пользователя нет — возможно, его уже одобрили.":                             "There is no request from this user — perhaps it was already approved.",
+	"Не удалось одобрить запрос: %s":                                                              "Could not approve the request: %s",
+	"🎉 Доступ открыт! Набери /start, чтобы начать.":                                               "🎉 You're in! Type /start to begin.",
+	"✅ %s теперь может пользоваться ботом.":                                                       "✅ %s can now use the bot.",
+
+	// bot/account.go
+	"У этого аккаунта есть свои задачи, категории или привязанные аккаунты — после привязки они бы пропали. Привяжи наоборот: получи код здесь через /link и введи его на другом аккаунте.": "This account has its own tasks, categories or linked accounts, and they would be hidden after linking. Link the other way round: get a code here with /link and enter it on the other account.",
+	"Слишком много неверных кодов. Попробуй снова через час.": "Too many wrong codes. Try again in an hour.",
+	"Не удалось создать код: %s":                              "Could not create a code: %s",
+	"🔗 Код привязки: <code>%s</code>\nОтправь со второго Telegram-аккаунта команду <code>/link %s</code> в течение 10 минут — оба аккаунта будут видеть одни и те же задачи.": "🔗 Link code: <code>%s</code>\nSend <code>/link %s</code> from your second Telegram account within 10 minutes — both accounts will see the same tasks.",
+	"Код не найден или устарел. Запроси новый через /link на основном аккаунте.":                                                                                              "The code was not found or has expired. Request a new one with /link on the main account.",
+	"Этот аккаунт уже привязан.":       "This account is already linked.",
+	"Не удалось привязать аккаунт: %s": "Could not link the account: %s",
+	"✅ Аккаунт привязан. Теперь здесь доступны общие задачи. Отвязать можно командой /unlink.": "✅ Account linked. The shared tasks are available here now. Unlink with /unlink.",
+	"Это основной аккаунт — отвязать можно только дополнительные.":                             "This is the main account — only additional ones can be unlinked.",
+	"Не удалось отвязать аккаунт: %s":                                                          "Could not unlink the account: %s",
+	"🔓 Аккаунт отвязан. Здесь снова отображаются только твои собственные задачи.":              "🔓 Account unlinked. Only your own tasks are shown here again.",
+
+	// bot/alerts.go
+	"истекает ":         "expires ",
+	"⏳ %s, в %s":        "⏳ %s, at %s",
+	"<b>просрочено</b>": "<b>overdue</b>",
+	"⏰ <b>#%d</b> %s — %s\nОтложить — кнопками ниже, ответом вроде «вечером» или «завтра утром» или реакцией 😴 (на 3 часа); ✅ или 👍 — выполнено.": "⏰ <b>#%d</b> %s — %s\nSnooze with the buttons below, a reply like “tonight” or “tomorrow morning” or a 😴 reaction (for 3 hours); ✅ or 👍 marks it done.",
+	"Это время уже прошло, выбери другое или поставь реакцию 😴.":                                                                                  "That time has already passed, pick another one or react with 😴.",
+	"😴 Напомню о «%s» %s.": "😴 I will remind you about “%s” %s.",
+
+	// bot/bot.go
+	"• /dashboard — закреплённая панель задач, которая обновляется сама; /dashboard off — открепить\n": "• /dashboard — a pinned task dashboard that updates itself; /dashboard off — unpin it\n",
+	"• /delivery chat|silent|pin — присылать отчёт сообщением, без звука или обновлять закреплённое\n": "• /delivery chat|silent|pin — send the report as a message, silently or by updating a pinned one\n",
+	"⏪ Диалог создания задачи отменён. Я здесь, чтобы начать заново.":                                  "⏪ Task creation cancelled. I am here to start over.",
+	"Я пока не понял сообщение. Набери /newtask, чтобы добавить задачу, или /help для списка команд.":  "I did not understand the message. Type /newtask to add a task or /help for the list of commands.",
+	"Диалог сброшен. Попробуй ещё раз через /newtask.":                                                 "The dialog was reset. Try again with /newtask.",
+	"Команда не поддерживается. Загляни в /help.":                                                      "Unsupported command. See /help.",
+	"друг": "friend",
+	"👋 Привет, %s!\n<b>Я ежедневный планировщик: помогу не забыть задачи.</b>\n\nКоманды:\n": "👋 Hi, %s!\n<b>I am a daily planner: I help you keep track of your tasks.</b>\n\nCommands:\n",
+	"• /newtask — добавить новую задачу\n":                                                   "• /newtask — add a new task\n",
+	"• /tasks — показать текущие задачи\n":                                                   "• /tasks — show current tasks\n",
+	"• /complete &lt;id&gt; — отметить задачу выполненной\n":                                 "• /complete &lt;id&gt; — mark a task done\n",
+	"• /categories — список категорий\n":                                                     "• /categories — list categories\n",
+	"• /interval &lt;часы&gt; — интервал отчётов\n":                                          "• /interval &lt;hours&gt; — report interval\n",
+	"• /report — тестовый ежедневный отчёт\n":                                                "• /report — test daily report\n",
+	"• /help — подсказки\n":                                                                  "• /help — tips\n",
+	"ℹ️ <b>Подсказки</b>\n":                                                                  "ℹ️ <b>Tips</b>\n",
+	"• /newtask — добавить задачу пошагово\n":                                                "• /newtask — add a task step by step\n",
+	"• /tasks — показать активные задачи и завершить по кнопке\n":                            "• /tasks — show active tasks and complete them with a button\n",
+	"• /complete &lt;id&gt; — отметить задачу по номеру (например, /complete 3)\n":           "• /complete &lt;id&gt; — mark a task done by its number (e.g. /complete 3)\n",
+	"• /delete &lt;id&gt; — удалить задачу (она попадёт в корзину)\n":                        "• /delete &lt;id&gt; — delete a task (it goes to the trash)\n",
+	"• /trash — корзина: удалённые за 30 дней задачи с кнопкой «Вернуть»\n":                  "• /trash — trash: tasks deleted within 30 days with a “Restore” button\n",
+	"• /task &lt;id&gt; или 🔎 в списке — карточка задачи: описание, подзадачи, история и кнопки «Отложить», «Напомнить», «Подзадача»\n": "• /task &lt;id&gt; or 🔎 in the list — task card: description, subtasks, history and “Postpone”, “Remind”, “Subtask” buttons\n",
+	"• 📤 Поделиться в карточке — аккуратная карточка задачи, которую можно переслать в любой чат\n":                                     "• 📤 Share on the card — a neat task card you can forward to any chat\n",
+	"• /edit &lt;id&gt; — изменить название, описание, категорию, дедлайн или повтор задачи\n":                                          "• /edit &lt;id&gt; — change the title, description, category, deadline or repeat of a task\n",
+	"• /fields — свои поля задач (текст, число, дата): /fields add сумма число\n":                                                       "• /fields — custom task fields (text, number, date): /fields add amount number\n",
+	"• /remind &lt;id&gt; завтра 9:00 — напомнить о задаче в точное время\n":                                                            "• /remind &lt;id&gt; tomorrow 9:00 — remind about a task at an exact time\n",
+	"• /postpone &lt;id&gt; 1d — отложить дедлайн на день (2w — на две недели, или дата)\n":                                             "• /postpone &lt;id&gt; 1d — move the deadline by a day (2w — by two weeks, or a date)\n",
+	"• /categories — посмотреть доступные категории\n":                                                                                  "• /categories — see the available categories\n",
+	"• /category route — отправлять напоминания категории в отдельный чат\n":                                                            "• /category route — send a category's reminders to a separate chat\n",
+	"• /category defaults &lt;категория&gt; +3d 12h — дедлайн и напоминание для новых задач\n":                                          "• /category defaults &lt;category&gt; +3d 12h — deadline and reminder for new tasks\n",
+	"• /category del &lt;категория&gt; — удалить категорию, перенеся или архивировав задачи\n":                                          "• /category del &lt;category&gt; — delete a category, moving or archiving its tasks\n",
+	"• /category archive &lt;категория&gt; — убрать категорию в архив, /categories archived — вернуть\n":                                "• /category archive &lt;category&gt; — archive a category, /categories archived — bring it back\n",
+	"• /category style &lt;категория&gt; — сменить значок и цвет категории\n":                                                           "• /category style &lt;category&gt; — change the icon and color of a category\n",
+	"• /interval &lt;часы&gt; — как часто присылать отчёт (по умолчанию 5 часов)\n":                                                     "• /interval &lt;hours&gt; — how often to send the report (every 5 hours by default)\n",
+	"• /report — отправить тестовый ежедневный отчёт\n":                                                                                 "• /report — send a test daily report\n",
+	"• /link — привязать второй Telegram-аккаунт к своим задачам\n":                                                                     "• /link — link a second Telegram account to your tasks\n",
+	"• /workspace — общие пространства для семьи или команды\n":                                                                         "• /workspace — shared workspaces for a family or a team\n",
+	"• /buddy @username — партнёр, который узнает о просроченных задачах с отметкой /buddy watch &lt;id&gt;\n":                          "• /buddy @username — a partner who learns about overdue tasks flagged with /buddy watch &lt;id&gt;\n",
+	"• /ics &lt;ссылка&gt; — подписаться на календарь (или пришли .ics-файл)\n":                                                         "• /ics &lt;link&gt; — subscribe to a calendar (or send an .ics file)\n",
+	"• /quota — лимиты на задачи, категории и файлы\n":                                                                                  "• /quota — limits on tasks, categories and files\n",
+	"• /contacts — дни рождения и важные даты, /contact add 15.03 Маша — добавить\n":                                                    "• /contacts — birthdays and important dates, /contact add 15.03 Mary — add one\n",
+	"• /med add Витамин D 9:00 21:00 — напоминать о приёме лекарств, /meds — расписание\n":                                              "• /med add Vitamin D 9:00 21:00 — medication reminders, /meds — schedule\n",
+	"• /stats — статистика, в том числе соблюдение режима приёма\n":                                                                     "• /stats — statistics, including medication adherence\n",
+	"• /weekly — итоги недели; /weekly on — присылать их по воскресеньям\n":                                                             "• /weekly — weekly summary; /weekly on — send it on Sundays\n",
+	"• /heatmap [ММ.ГГГГ] — карта выполненных задач за месяц; /heatmap image — картинкой\n":                                             "• /heatmap [MM.YYYY] — map of completed tasks for a month; /heatmap image — as a picture\n",
+	"• /counter add 8 Стаканы воды — счётчик с целью на день, /counters — отметить +1\n":                                                "• /counter add 8 Glasses of water — a counter with a daily goal, /counters — add +1\n",
+	"• /calendarweek — неделя сеткой, по дням — задачи дня\n":                                                                           "• /calendarweek — the week as a grid, by day — the day's tasks\n",
+	"• /done [дней] — выполненные задачи по дням, с кнопкой «Вернуть»\n":                                                                "• /done [days] — completed tasks by day, with a “Restore” button\n",
+	"• /archive — задачи в архиве, /archive restore &lt;id&gt; — вернуть\n":                                                             "• /archive — archived tasks, /archive restore &lt;id&gt; — bring one back\n",
+	"• /timezone Europe/Moscow — часовой пояс для времени напоминаний\n":                                                                "• /timezone Europe/London — time zone for reminder times\n",
+	"• /language en — язык бота: English или русский\n":                                                                                 "• /language ru — bot language: English or Russian\n",
+	"• /emoji — быстрые ответы: ✅ отмечает последнюю показанную задачу, 📋 — список, ➕ — новая задача\n":                                 "• /emoji — quick replies: ✅ completes the last shown task, 📋 — list, ➕ — new task\n",
+	"• /grace 03:00 — считать задачи просроченными не в полночь, а в 3 часа ночи после дня дедлайна\n":                                  "• /grace 03:00 — count tasks as overdue at 3 a.m. after the deadline day instead of midnight\n",
+	"• /workhours 9-18 — рабочие часы: по ним считаются «утром», «вечером», «после работы»\n":                                           "• /workhours 9-18 — working hours: “morning”, “tonight” and “after work” are based on them\n",
+	"• /countdown on — за час до срока напоминания показывают, сколько осталось\n":                                                      "• /countdown on — reminders show how much time is left during the last hour\n",
+	"• /autodelete 5 — удалять подтверждения и уведомления о выполнении через 5 минут\n":                                                "• /autodelete 5 — delete confirmations and completion notices after 5 minutes\n",
+	"• /settings export — файл настроек для переноса на другой сервер\n":                                                                "• /settings export — settings file for moving to another server\n",
+	"• /add Купить молоко #покупки !high @завтра — задача одной строкой\n":                                                              "• /add Buy milk #shopping !high @tomorrow — a task in one line\n",
+	"• /tasks high — только задачи с высоким и срочным приоритетом\n":                                                                   "• /tasks high — only tasks of high and urgent priority\n",
+	"• /search молоко — найти открытые задачи по названию или описанию\n":                                                               "• /search milk — find open tasks by title or description\n",
+	"• /cancel — отменить текущий ввод":                                                                                                 "• /cancel — cancel the current input",
+	"🔹 Главное меню": "🔹 Main menu",
+	"✅ Подтвердить":  "✅ Confirm",
+	"📋 Задачи":       "📋 Tasks",
+	"📂 Категории":    "📂 Categories",
+	"ℹ️ Помощь":      "ℹ️ Help",
+	"Да":             "Yes",
+	"Учеба":          "Study",
+	"Работа":         "Work",
+	"Покупки":        "Shopping",
+	"Здоровье":       "Health",
+	"недостаточно прав в этом пространстве": "not enough rights in this workspace",
+
+	// bot/buddy.go
+	"🤝 Партнёра больше нет, о просрочках никто не узнает.": "🤝 You no longer have a partner, nobody will hear about overdue tasks.",
+	"🤝 Партнёра пока нет.":                                 "🤝 No partner yet.",
+	"🤝 Ждём ответа от %s.":                                 "🤝 Waiting for %s to answer.",
+	"🤝 Партнёр: %s. Узнает о задачах с отметкой, просроченных больше чем на %d дн.": "🤝 Partner: %s. They learn about flagged tasks overdue by more than %d days.",
+	"Команды партнёра:\n• /buddy @username — попросить человека стать партнёром (он должен хотя бы раз написать боту)\n• /buddy watch &lt;id&gt; — партнёр узнает, если задача просрочится\n• /buddy unwatch &lt;id&gt; — снять отметку\n• /buddy off — отказаться от партнёра": "Partner commands:\n• /buddy @username — ask someone to be your partner (they must have messaged the bot at least once)\n• /buddy watch &lt;id&gt; — the partner learns if the task becomes overdue\n• /buddy unwatch &lt;id&gt; — remove the flag\n• /buddy off — stop having a partner",
+	"Я не знаю такого пользователя. Попроси его написать боту /start и повтори.": "I do not know this user. Ask them to send /start to the bot and try again.",
+	"Себя в партнёры взять нельзя 🙂":                                             "You cannot be your own partner 🙂",
+	"Не удалось сохранить партнёра: %s":                                          "Could not save the partner: %s",
+	"🤝 %s просит тебя стать партнёром по задачам: если отмеченная задача просрочится больше чем на %d дн., я мягко сообщу тебе об этом. Согласен?": "🤝 %s asks you to be their task partner: if a flagged task is overdue by more than %d days, I will gently let you know. Do you agree?",
+	"✅ Согласиться": "✅ Agree",
+	"❌ Отказаться":  "❌ Decline",
+	"Не получилось написать этому человеку. Попроси его открыть бота и повтори.":    "Could not message this person. Ask them to open the bot and try again.",
+	"📨 Запрос отправлен %s. Партнёр начнёт получать уведомления, когда согласится.": "📨 Request sent to %s. The partner starts getting notices once they agree.",
+	"Укажи ID задачи: /buddy watch 12": "Give the task ID: /buddy watch 12",
+	"У задачи нет дедлайна — просрочить её нельзя. Добавь дедлайн через /edit.":                                              "The task has no deadline, so it cannot become overdue. Add a deadline with /edit.",
+	"Не удалось сохранить отметку: %s":                                                                                       "Could not save the flag: %s",
+	"🤝 Партнёр больше не следит за <b>#%d</b> %s.":                                                                           "🤝 The partner no longer watches <b>#%d</b> %s.",
+	"🤝 Если <b>#%d</b> %s просрочится больше чем на %d дн., партнёр узнает об этом.":                                         "🤝 If <b>#%d</b> %s is overdue by more than %d days, your partner will know.",
+	"\nПартнёра пока нет — позови его: /buddy @username":                                                                     "\nNo partner yet — invite one: /buddy @username",
+	"Этот запрос больше не действует.":                                                                                       "This request is no longer valid.",
+	"Не удалось сохранить ответ: %s":                                                                                         "Could not save the answer: %s",
+	"🤝 Ты партнёр %s. Спасибо, что поддерживаешь!":                                                                           "🤝 You are %s's partner. Thanks for the support!",
+	"🤝 %s согласился быть партнёром. Отметь важные задачи: /buddy watch &lt;id&gt;":                                          "🤝 %s agreed to be your partner. Flag important tasks: /buddy watch &lt;id&gt;",
+	"🙅 Больше не пришлю уведомлений о задачах %s.":                                                                           "🙅 I will not send notices about %s's tasks anymore.",
+	"🤝 %s больше не будет получать уведомления о просрочках. Позвать другого партнёра: /buddy @username":                     "🤝 %s will no longer get notices about overdue tasks. Invite another partner: /buddy @username",
+	"Хорошо, запрос отклонён.":                                                                                               "OK, request declined.",
+	"🤝 %s не готов быть партнёром.":                                                                                          "🤝 %s is not ready to be your partner.",
+	"🤝 %s просил присмотреть: задача «%s» просрочена на %d дн. (срок был %s). Может, стоит поддержать и спросить, как дела?": "🤝 %s asked you to keep an eye on this: the task “%s” is %d days overdue (it was due %s). Maybe ask how it is going?",
+	"🙅 Больше не присылать":                                                                                                  "🙅 Stop sending these",
+
+	// bot/calendar_week.go
+	"Не удалось собрать неделю: %s": "Could not build the week: %s",
+	"⬅️ Назад":                    "⬅️ Back",
+	"Эта неделя":                  "This week",
+	"Вперёд ➡️":                   "Next ➡️",
+	"Не удалось собрать день: %s": "Could not build the day: %s",
+	"\nНа этот день ничего не запланировано.": "\nNothing is planned for this day.",
+	"\n⏰ <b>Сроки</b>\n":       "\n⏰ <b>Deadlines</b>\n",
+	"\n♻️ <b>Регулярные</b>\n": "\n♻️ <b>Recurring</b>\n",
+	"⬅️ К неделе":              "⬅️ To the week",
+	"Сроки ":                   "Due   ",
+	"Повт. ":                   "Rep.  ",
+	"🗓 <b>Неделя %s – %s</b>\n<pre>%s</pre>\nСроки — задачи с дедлайном, Повт. — регулярные. Нажми на день, чтобы увидеть его задачи.": "🗓 <b>Week %s – %s</b>\n<pre>%s</pre>\nDue — tasks with a deadline, Rep. — recurring ones. Tap a day to see its tasks.",
+
+	// bot/category.go
+	"категории": "categories",
+	"Маршруты напоминаний:\n• в группе: /category route &lt;категория&gt; — присылать напоминания категории сюда\n• здесь: /category route &lt;категория&gt; off — вернуть их в личный отчёт\n• /category route — список маршрутов": "Reminder routes:\n• in a group: /category route &lt;category&gt; — send the category's reminders here\n• here: /category route &lt;category&gt; off — return them to the personal report\n• /category route — list of routes",
+	"Чтобы направить категорию в группу, выполни /category route &lt;категория&gt; прямо в той группе.":                                                                                                                             "To route a category to a group, run /category route &lt;category&gt; in that group.",
+	"↩️ Напоминания категории «%s» снова приходят в личный отчёт.":                                                                                                                                                                  "↩️ Reminders of the category “%s” go to the personal report again.",
+	"📮 <b>Маршруты напоминаний</b>\n": "📮 <b>Reminder routes</b>\n",
+	"чат %d": "chat %d",
+	"— все напоминания приходят в личный отчёт\n":        "— all reminders go to the personal report\n",
+	"Формат: /category route &lt;категория&gt;":          "Format: /category route &lt;category&gt;",
+	"📮 Напоминания категории «%s» теперь приходят сюда.": "📮 Reminders of the category “%s” now come here.",
+	"не заданы": "not set",
+	"⚙️ Настройки новых задач «%s»: %s\n\n%s": "⚙️ New task settings of “%s”: %s\n\n%s",
+	"Настройки новых задач категории:\n• /category defaults &lt;категория&gt; +3d — дедлайн через 3 дня (+2w — через 2 недели)\n• /category defaults &lt;категория&gt; 12h — напоминать за 12 часов до дедлайна (2d — за 2 дня)\n• /category defaults &lt;категория&gt; off — сбросить, без параметров — показать текущие": "Settings for new tasks of a category:\n• /category defaults &lt;category&gt; +3d — deadline in 3 days (+2w — in 2 weeks)\n• /category defaults &lt;category&gt; 12h — remind 12 hours before the deadline (2d — 2 days before)\n• /category defaults &lt;category&gt; off — reset; without parameters — show the current ones",
+	"Дедлайн — не дальше %d дней, напоминание — не раньше чем за %d дней.": "The deadline can be at most %d days away, the reminder at most %d days before.",
+	"⚙️ Настройки новых задач «%s» сброшены.":                              "⚙️ New task settings of “%s” reset.",
+	"⚙️ Новые задачи «%s»: %s.":                                            "⚙️ New tasks of “%s”: %s.",
+	"⏰ Дедлайн по умолчанию для «%s»: %s.":                                 "⏰ Default deadline for “%s”: %s.",
+	"дедлайн через %d дн.":                                                 "deadline in %d days",
+	"напоминание за %s":                                                    "reminder %s before",
+	"Удалить категорию: /category del &lt;категория&gt;":                   "Delete a category: /category del &lt;category&gt;",
+	"Не удалось посчитать задачи: %s":                                      "Could not count the tasks: %s",
+	"➡️ В «%s»":       "➡️ To “%s”",
+	"🚫 Без категории": "🚫 No category",
+	"🗄 В архив":       "🗄 Archive",
+	"↩️ Отмена":       "↩️ Cancel",
+	"🗑 Удалить категорию «%s»?\nАктивных задач в ней: %d. Куда их деть?": "🗑 Delete the category “%s”?\nActive tasks in it: %d. What should happen to them?",
+	"↩️ Категория осталась на месте.":                                    "↩️ The category stays.",
+	"Категория уже удалена, или та, куда переносятся задачи, пропала.":   "The category is already deleted, or the one the tasks move to is gone.",
+	"Не удалось удалить категорию: %s":                                   "Could not delete the category: %s",
+	"🗑 Категория «%s» удалена.":                                          "🗑 Category “%s” deleted.",
+	" Активных задач в ней не было.":                                     " It had no active tasks.",
+	"\nЗадачи отправлены в архив (%d), вернуть можно через /archive:\n":  "\nTasks archived (%d), restore them with /archive:\n",
+	"\nЗадачи перенесены в «%s» (%d):\n":                                 "\nTasks moved to “%s” (%d):\n",
+	"\nЗадачи остались без категории (%d):\n":                            "\nTasks left without a category (%d):\n",
+	"…и ещё %d\n": "…and %d more\n",
+	"Такой категории нет. Список — /categories в личном чате с ботом.":                                                   "No such category. See /categories in the private chat with the bot.",
+	"Не удалось изменить маршрут: %s":                                                                                    "Could not change the route: %s",
+	"🗄 Категория «%s» в архиве: её нет в списках и кнопках, а задачи остались как были. Вернуть — /categories archived.": "🗄 Category “%s” archived: it is gone from lists and buttons, its tasks stay as they were. Bring it back — /categories archived.",
+	"В архиве категорий пусто. ":                                                                                         "The category archive is empty. ",
+	"Убрать категорию в архив: /category archive &lt;категория&gt;, архив — /categories archived":                        "Archive a category: /category archive &lt;category&gt;, archive — /categories archived",
+	"🗄 <b>Категории в архиве</b>\n":                                                                                      "🗄 <b>Archived categories</b>\n",
+	"• %s — с %s\n":                                        "• %s — since %s\n",
+	"↩️ Вернуть «%s»":                                      "↩️ Restore “%s”",
+	"Не удалось вернуть категорию: %s":                     "Could not restore the category: %s",
+	"📂 Категория «%s» снова в списках.":                    "📂 Category “%s” is back in the lists.",
+	"Не удалось получить категории: %s":                    "Could not get the categories: %s",
+	"Категории пока пусты. Добавь их при создании задачи.": "No categories yet. Add them when creating a task.",
+	"📂 <b>Категории</b>\n":                                 "📂 <b>Categories</b>\n",
+	"\nВ архиве: /categories archived\n":                   "\nArchived: /categories archived\n",
+
+	// bot/category_style.go
+	"Без категории": "No category",
+	"Красный":       "Red",
+	"Оранжевый":     "Orange",
+	"Жёлтый":        "Yellow",
+	"Зелёный":       "Green",
+	"Синий":         "Blue",
+	"Фиолетовый":    "Purple",
+	"Коричневый":    "Brown",
+	"Чёрный":        "Black",
+	"Белый":         "White",
+	"Значок и цвет категории: /category style &lt;категория&gt;": "Category icon and color: /category style &lt;category&gt;",
+	"🎨 Сейчас категория выглядит так: %s\nПришли новый значок — один эмодзи — или выбери из кнопок («Пропустить» оставит прежний).": "🎨 The category currently looks like this: %s\nSend a new icon — a single emoji — or pick one of the buttons (“Skip” keeps the current one).",
+	"Значок — это один эмодзи, без букв и цифр. Пришли другой:":                                                                     "An icon is a single emoji, without letters or digits. Send another one:",
+	"Теперь цвет-метка («Пропустить» оставит прежний):":                                                                             "Now the color mark (“Skip” keeps the current one):",
+	"Выбери цвет кнопкой.":              "Pick a color with a button.",
+	"Категория не найдена.":             "Category not found.",
+	"Не удалось изменить категорию: %s": "Could not change the category: %s",
+	"🎨 Готово: ":                        "🎨 Done: ",
+	"⭕ Без цвета":                       "⭕ No color",
+
+	// bot/contact.go
+	"дни рождения и важные даты":                                               "birthdays and important dates",
+	"Не удалось получить контакты: %s":                                         "Could not get the contacts: %s",
+	"👥 Контактов пока нет. Добавь день рождения, и я напомню о нём заранее.\n": "👥 No contacts yet. Add a birthday and I will remind you in advance.\n",
+	"👥 <b>Контакты</b>\n":                                                      "👥 <b>Contacts</b>\n",
+	"\nДобавить: /contact add, удалить: /contact del &lt;id&gt;":               "\nAdd: /contact add, delete: /contact del &lt;id&gt;",
+	"Такой даты не бывает. ":                                                   "There is no such date. ",
+	"Не удалось добавить контакт: %s":                                          "Could not add the contact: %s",
+	"👤 Добавлено: %s — %s. Напоминание появится в задачах накануне.":           "👤 Added: %s — %s. A reminder will appear among the tasks the day before.",
+	"Формат: /contact del &lt;id&gt;":                                          "Format: /contact del &lt;id&gt;",
+	"Контакт не найден.":                                                       "Contact not found.",
+	"🗑 Контакт и его напоминание удалены.":                                     "🗑 The contact and its reminder are deleted.",
+	"Формат: /contact add ДД.ММ[.ГГГГ] Имя [| повод]\nНапример: /contact add 15.03.1990 Маша или /contact add 20.06 Мама и папа | годовщина свадьбы": "Format: /contact add DD.MM[.YYYY] Name [| occasion]\nFor example: /contact add 15.03.1990 Mary or /contact add 20.06 Mom and Dad | wedding anniversary",
+
+	// bot/countdown.go
+	"выключен": "off",
+	"включён":  "on",
+	"⏳ Обратный отсчёт %s. Когда до срока задачи меньше часа, напоминание о ней каждые 10 минут показывает, сколько осталось.\nВключить: /countdown on, выключить: /countdown off": "⏳ The countdown is %s. When a task is due in less than an hour, its reminder shows the time left every 10 minutes.\nTurn on: /countdown on, turn off: /countdown off",
+	"Формат: /countdown on или /countdown off": "Format: /countdown on or /countdown off",
+	"⏳ Обратный отсчёт выключен.":              "⏳ Countdown turned off.",
+	"⏳ Обратный отсчёт включён: за час до срока напоминания начнут показывать, сколько осталось.": "⏳ Countdown turned on: during the last hour before a deadline, reminders show how much time is left.",
+	"\n✅ Выполнено":      "\n✅ Done",
+	"\n⌛ Срок наступил":  "\n⌛ Time is up",
+	"осталась %d минута": "%d minute left",
+	"осталось %d минуты": "%d minutes left",
+	"осталось %d минут":  "%d minutes left",
+
+	// bot/counter.go
+	"счётчики на день":                 "daily counters",
+	"Не удалось получить счётчики: %s": "Could not get the counters: %s",
+	"📈 Счётчиков пока нет.\n":          "📈 No counters yet.\n",
+	"Не удалось добавить счётчик: %s":  "Could not add the counter: %s",
+	"📈 Счётчик «%s» — цель %d в день. Отмечай через /counters, прогресс будет в ежедневном отчёте.": "📈 Counter “%s” — goal of %d a day. Tick it in /counters, the progress shows up in the daily report.",
+	"Формат: /counter del &lt;id&gt;": "Format: /counter del &lt;id&gt;",
+	"Счётчик не найден.":              "Counter not found.",
+	"🗑 Счётчик удалён.":               "🗑 Counter deleted.",
+	"Формат: /counter add &lt;цель&gt; &lt;название&gt;\nНапример: /counter add 8 Стаканы воды": "Format: /counter add &lt;goal&gt; &lt;name&gt;\nFor example: /counter add 8 Glasses of water",
+	"Этот счётчик уже удалён.":                                          "This counter is already deleted.",
+	"Не удалось обновить счётчик: %s":                                   "Could not update the counter: %s",
+	"📈 <b>Счётчики на сегодня</b>\n":                                    "📈 <b>Today's counters</b>\n",
+	"\nСчётчики обнуляются в полночь. Удалить: /counter del &lt;id&gt;": "\nCounters reset at midnight. Delete: /counter del &lt;id&gt;",
+
+	// bot/dashboard.go
+	"Формат: /dashboard — закрепить панель задач (повторно — опустить её вниз чата), /dashboard off — открепить.": "Usage: /dashboard — pin the task dashboard (again — move it to the bottom of the chat), /dashboard off — unpin it.",
+	"Панели задач в этом чате нет. Включить: /dashboard":                                                          "There is no task dashboard in this chat. Turn it on: /dashboard",
+	"📌 Панель задач откреплена и стала обычным списком. Вернуть: /dashboard":                                      "📌 The task dashboard is unpinned and became a regular list. Bring it back: /dashboard",
+	"📌 <b>Панель задач</b> · обновлено в %s\n":                                                                    "📌 <b>Task dashboard</b> · updated at %s\n",
+	"🔥 Открыто: %d · ⚠️ Просрочено: %d · ✅ Сделано сегодня: %d\n\n":                                               "🔥 Open: %d · ⚠️ Overdue: %d · ✅ Done today: %d\n\n",
+	"🔄 Обновить": "🔄 Refresh",
+
+	// bot/datepicker.go
+	"Январь":   "January",
+	"Февраль":  "February",
+	"Март":     "March",
+	"Апрель":   "April",
+	"Май":      "May",
+	"Июнь":     "June",
+	"Июль":     "July",
+	"Август":   "August",
+	"Сентябрь": "September",
+	"Октябрь":  "October",
+	"Ноябрь":   "November",
+	"Декабрь":  "December",
+	"Пн":       "Mo",
+	"Вт":       "Tu",
+	"Ср":       "We",
+	"Чт":       "Th",
+	"Пт":       "Fr",
+	"Сб":       "Sa",
+	"Вс":       "Su",
+	"Этот календарь уже неактуален.": "This calendar is out of date.",
+
+	// bot/description.go
+	"К задаче можно приложить не больше %d файлов. Нажми «Готово».":             "A task can have at most %d files. Tap “Done”.",
+	"В описание идут текст, фото, видео и файлы. Пришли их или нажми «Готово».": "Text, photos, videos and files go to the description. Send them or tap “Done”.",
+	"📝 Записал.":               "📝 Saved.",
+	"📝 Записал, вложений: %d.": "📝 Saved, attachments: %d.",
+	" Присылай продолжение или нажми «Готово».": " Send more or tap “Done”.",
+	"✅ Готово":               "✅ Done",
+	"📎 Вложения (%d)":        "📎 Attachments (%d)",
+	"У задачи нет вложений.": "The task has no attachments.",
+
+	// bot/edit.go
+	"Задача не повторяется — пропускать у неё нечего.":                                "The task does not repeat — it has nothing to miss.",
+	"🗑 Пропущенные повторы будут просто забываться.":                                  "🗑 Missed repeats will simply be dropped.",
+	"↪️ Пропущенный повтор останется в отчёте просроченным, пока ты его не отметишь.": "↪️ A missed repeat will stay in the report as overdue until you mark it.",
+	"↪️ Переносить пропуски":                                                          "↪️ Carry missed repeats",
+	"🗑 Забывать пропуски":                                                             "🗑 Drop missed repeats",
+	"Укажи ID задачи: /edit 12":                                                       "Give the task ID: /edit 12",
+	"Задача уже закрыта, менять в ней нечего.":                                        "The task is already closed, nothing to change.",
+	"Название":  "Title",
+	"Описание":  "Description",
+	"Категория": "Category",
+	"Дедлайн":   "Deadline",
+	"Приоритет": "Priority",
+	"Повтор":    "Repeat",
+	"🧩 Поля":    "🧩 Fields",
+	"✏️ Что изменить в задаче «%s» (#%d)?":                                                                                "✏️ What to change in the task “%s” (#%d)?",
+	"✏️ Новое название задачи:":                                                                                           "✏️ New task title:",
+	"📝 Новое описание (или «Очистить», чтобы убрать его).":                                                                "📝 New description (or “Clear” to remove it).",
+	"🏷 Новая категория (или «Очистить», чтобы оставить задачу без категории).":                                            "🏷 New category (or “Clear” to leave the task without a category).",
+	"⏰ Новый дедлайн: выбери день в календаре или напиши дату вроде <code>2025-11-30</code> («Очистить» уберёт дедлайн).": "⏰ New deadline: pick a day in the calendar or type a date like <code>2025-11-30</code> (“Clear” removes the deadline).",
+	"❗ Новый приоритет задачи:":                                                                                           "❗ New task priority:",
+	"🔁 День месяца или недели и окно в днях через пробел, например <code>15 2</code> или <code>пн 1</code>, «каждый день», «раз в 3 дня» или «Нет», чтобы задача больше не повторялась.": "🔁 A day of the month or week and a window in days separated by a space, e.g. <code>15 2</code> or <code>mon 1</code>, “daily”, “every 3 days” or “No” to stop repeating the task.",
+	"Выбери приоритет кнопкой.": "Pick a priority with a button.",
+	"Укажи день месяца (1–31) и окно (0–%d), например <code>15 2</code>, дни недели и окно (0–%d), например <code>пн 1</code> или <code>пн,чт 0</code>, «каждый день», «раз в 3 дня», «раз в квартал 7», «раз в полгода» или «Нет».": "Give a day of the month (1–31) and a window (0–%d), e.g. <code>15 2</code>, weekdays and a window (0–%d), e.g. <code>mon 1</code> or <code>mon,thu 0</code>, “daily”, “every 3 days”, “quarterly 7”, “every six months” or “No”.",
+	"✏️ Задача обновлена.":                                "✏️ Task updated.",
+	"Пиши поля строками <code>название: значение</code>.": "Write fields as lines <code>name: value</code>.",
+	"🧩 Поля обновлены.":                                   "🧩 Fields updated.",
+	"🧹 Очистить":                                          "🧹 Clear",
+	"Нет":                                                 "No",
+
+	// bot/export.go
+	"загрузить задачи из CSV или JSON":                              "import tasks from CSV or JSON",
+	"выгрузить задачи в CSV или JSON":                               "export tasks to CSV or JSON",
+	"Формат: /export — таблица CSV, /export json — файл JSON.":      "Format: /export — a CSV table, /export json — a JSON file.",
+	"Не удалось выгрузить задачи: %s":                               "Could not export the tasks: %s",
+	"Задач пока нет — выгружать нечего.":                            "There are no tasks yet, nothing to export.",
+	"📦 Все задачи, включая выполненные, архивные и регулярные: %d.": "📦 All tasks, including completed, archived and recurring ones: %d.",
+
+	// bot/fields.go
+	"Тип поля — текст, число или дата.":                                                  "The field type is text, number or date.",
+	"🧩 Поле «%s» (%s) добавлено. Заполнить его — в /edit задачи или при создании новой.": "🧩 Field “%s” (%s) added. Fill it in via /edit of a task or when creating a new one.",
+	"🗑 Поле «%s» удалено вместе со значениями.":                                          "🗑 Field “%s” deleted along with its values.",
+	"Не удалось получить поля: %s":                                                       "Could not get the fields: %s",
+	"Своих полей пока нет.\n\n":                                                          "No custom fields yet.\n\n",
+	"🧩 <b>Поля задач</b>\n":                                                              "🧩 <b>Task fields</b>\n",
+	"Свои поля задач:\n• /fields add &lt;название&gt; текст|число|дата — добавить поле, например <code>/fields add сумма число</code>\n• /fields del &lt;название&gt; — удалить поле вместе со значениями\nЗаполнить поля можно при создании задачи (/newtask) и в /edit.": "Custom task fields:\n• /fields add &lt;name&gt; text|number|date — add a field, e.g. <code>/fields add amount number</code>\n• /fields del &lt;name&gt; — delete a field along with its values\nFields are filled in when creating a task (/newtask) and in /edit.",
+	"🧩 Заполни поля, по одному в строке: <code>название: значение</code>.\n": "🧩 Fill in the fields, one per line: <code>name: value</code>.\n",
+	"Чтобы очистить поле, напиши <code>название: -</code>.":                  "To clear a field, write <code>name: -</code>.",
+	"Необязательные поля можно не писать, а шаг — пропустить.":               "Optional fields can be left out, and the step can be skipped.",
+	"число": "number",
+	"дата":  "date",
+	"текст": "text",
+	"В поле «%s» нужно число, например 1500 или 12,5.":            "The field “%s” needs a number, e.g. 1500 or 12.5.",
+	"В поле «%s» нужна дата, например 2025-11-30 или 30.11.2025.": "The field “%s” needs a date, e.g. 2025-11-30 or 30.11.2025.",
+	"Значение поля «%s» длиннее %d символов.":                     "The value of the field “%s” is longer than %d characters.",
+	"Такого поля нет. Список — /fields":                           "No such field. List — /fields",
+	"Поле с таким названием уже есть.":                            "A field with this name already exists.",
+	"Полей может быть не больше %d.":                              "There can be at most %d fields.",
+	"Название поля — до %d символов, без «:» и «=».":              "A field name is up to %d characters, without “:” and “=”.",
+	"Не удалось изменить поля: %s":                                "Could not change the fields: %s",
+
+	// bot/grace.go
+	"Формат: /grace 03:00 — просрочка наступает в 3 часа ночи после дня дедлайна, /grace 6 — через 6 часов после его конца (до 48), /grace off — ровно в полночь, /grace start — уже с начала дня дедлайна": "Format: /grace 03:00 — a task becomes overdue at 3 a.m. after the deadline day, /grace 6 — 6 hours after its end (up to 48), /grace off — exactly at midnight, /grace start — as soon as the deadline day starts",
+	"🌙 Готово. ": "🌙 Done. ",
+	"Задача становится просроченной с самого начала дня дедлайна.":         "A task becomes overdue as soon as the deadline day starts.",
+	"Задача становится просроченной ровно в полночь после дня дедлайна.":   "A task becomes overdue exactly at midnight after the deadline day.",
+	"Задача становится просроченной в %02d:00 после дня дедлайна.":         "A task becomes overdue at %02d:00 after the deadline day.",
+	"Задача становится просроченной через %d ч. после конца дня дедлайна.": "A task becomes overdue %d h after the end of the deadline day.",
+
+	// bot/heatmap.go
+	"Формат: /heatmap [ММ.ГГГГ] — календарь выполненных задач за месяц.\n/heatmap image — присылать картинкой, /heatmap text — квадратиками эмодзи.": "Format: /heatmap [MM.YYYY] — calendar of completed tasks for a month.\n/heatmap image — send it as a picture, /heatmap text — as emoji squares.",
+	"Не удалось собрать историю: %s": "Could not build the history: %s",
+	"\nменьше ": "\nless ",
+	" больше":   " more",
+	"🔥 <b>%s %d</b> · выполнено: %d": "🔥 <b>%s %d</b> · completed: %d",
+	"\nЛучший день — %s: %d":         "\nBest day — %s: %d",
+
+	// bot/history.go
+	"Укажи число дней от 1 до %d, например /done 30":                 "Give a number of days from 1 to %d, e.g. /done 30",
+	"Не удалось получить историю: %s":                                "Could not get the history: %s",
+	"За %s ничего не выполнено. Посмотреть период длиннее: /done 30": "Nothing completed — %s. See a longer period: /done 30",
+	"✅ <b>Выполнено за %s: %d</b>":                                   "✅ <b>Completed — %s: %d</b>",
+	"\n\n…и ещё %d. Укажи период короче: /done %d":                   "\n\n…and %d more. Pick a shorter period: /done %d",
+	"↩️ Вернуть #%d · %s":                                            "↩️ Restore #%d · %s",
+	"Сегодня":                                                        "Today",
+	"Вчера":                                                          "Yesterday",
+	"сегодня":                                                        "today",
+	"%d день":                                                        "%d days",
+	"%d дня":                                                         "%d days",
+	"%d дней":                                                        "%d days",
+	"Задача уже открыта.":                                            "The task is already open.",
+	"↩️ Задача «%s» снова открыта.":                                  "↩️ The task “%s” is open again.",
+
+	// bot/import.go
+	"📥 Пришли файл из /export — таблицу .csv или .json, — и я добавлю задачи из него. В таблице обязателен столбец title, остальные (description, category, priority, status, deadline, recur_type…) можно опустить. Новые категории создаются сами, подзадачи по столбцам id и parent_id снова встают под свои задачи, строки с ошибками пропускаются, и я скажу, какие именно. Файл — до 5 МБ.": "📥 Send me a file from /export, a .csv table or .json, and I will add its tasks. The table needs a title column, the others (description, category, priority, status, deadline, recur_type…) may be left out. New categories are created on the way, subtasks go back under their tasks by the id and parent_id columns, rows with mistakes are skipped, and I will tell you which ones. The file may be up to 5 MB.",
+	"Не получилось прочитать файл: %s. Подойдёт файл из /export.": "Could not read the file: %s. A file from /export will do.",
+	"Задачи не добавлены: %s":                                     "No tasks were added: %s",
+	"📥 Добавлено задач: %d, новых категорий: %d.":                 "📥 Tasks added: %d, new categories: %d.",
+	"Пропущено: %d.": "Skipped: %d.",
+	"…и ещё %d.":     "…and %d more.",
+	"строка %d":      "row %d",
+	"запись %d":      "entry %d",
+	"Я умею импортировать календари в формате .ics, задачи из /export и файлы настроек из /settings export.": "I can import .ics calendars, tasks from /export and settings files from /settings export.",
+	"\n⚠️ Остальные события не добавлены: ":                                                                  "\n⚠️ Other events were not added: ",
+	"Не удалось импортировать календарь: %s":                                                                 "Could not import the calendar: %s",
+	"Формат: /ics off &lt;id&gt;": "Format: /ics off &lt;id&gt;",
+	"Подписка не найдена.":        "Subscription not found.",
+	"🔕 Подписка удалена. Уже импортированные задачи остались.":                                                           "🔕 Subscription removed. Tasks imported so far stay.",
+	"Ссылка должна начинаться с http:// или https://":                                                                    "The link must start with http:// or https://",
+	"Не удалось подписаться: %s":                                                                                         "Could not subscribe: %s",
+	"🔔 Подписка оформлена, календарь будет обновляться автоматически.\n":                                                 "🔔 Subscribed, the calendar will be updated automatically.\n",
+	"Не удалось получить подписки: %s":                                                                                   "Could not get the subscriptions: %s",
+	"📅 Подписок на календари нет.\nОтправь /ics &lt;ссылка на .ics&gt;, чтобы подписаться, или просто пришли .ics-файл.": "📅 No calendar subscriptions.\nSend /ics &lt;link to .ics&gt; to subscribe, or just send an .ics file.",
+	"📅 <b>Подписки на календари</b>\n":                                                                                   "📅 <b>Calendar subscriptions</b>\n",
+	"   🔄 обновлено %s\n":               "   🔄 updated %s\n",
+	"\nОтписаться: /ics off &lt;id&gt;": "\nUnsubscribe: /ics off &lt;id&gt;",
+	"📥 Импорт календаря: добавлено %d, обновлено %d, пропущено %d.": "📥 Calendar import: %d added, %d updated, %d skipped.",
+
+	// bot/language.go
+	"🌐 Язык: %s":        "🌐 Language: %s",
+	" (как в Telegram)": " (as in Telegram)",
+	"Формат: /language en — English, /language ru — русский, /language auto — как в Telegram, /language ru+en — заголовки отчёта ещё и на втором языке": "Format: /language en — English, /language ru — Russian, /language auto — as in Telegram, /language ru+en — report headers in a second language too",
+	"🌐 Готово, теперь я говорю: %s.":                    "🌐 Done, now I speak %s.",
+	"📋 Заголовки отчёта: %s + %s":                       "📋 Report headers: %s + %s",
+	"📋 Заголовки отчёта будут на двух языках: %s + %s.": "📋 Report headers will be in two languages: %s + %s.",
+
+	// bot/medication.go
+	"расписание лекарств":                "medication schedule",
+	"Не удалось получить расписание: %s": "Could not get the schedule: %s",
+	"💊 Расписания приёма пока нет.\n":    "💊 No medication schedule yet.\n",
+	"💊 <b>Расписание приёма</b>\n":       "💊 <b>Medication schedule</b>\n",
+	"\nВ назначенное время придёт сообщение с кнопками «Принял» / «Пропустил». Соблюдение режима — в /stats.\nУдалить: /med del &lt;id&gt;": "\nAt the set time you get a message with “Taken” / “Skipped” buttons. Adherence is in /stats.\nDelete: /med del &lt;id&gt;",
+	"Время указывается как 9:00 или 21:30, не больше восьми раз в день.\n":                                                                  "Times look like 9:00 or 21:30, at most eight times a day.\n",
+	"Не удалось добавить расписание: %s":                                                "Could not add the schedule: %s",
+	"💊 %s — каждый день в %s.":                                                          "💊 %s — every day at %s.",
+	"Формат: /med del &lt;id&gt;":                                                       "Format: /med del &lt;id&gt;",
+	"Расписание не найдено.":                                                            "Schedule not found.",
+	"🗑 Расписание и история приёма удалены.":                                            "🗑 The schedule and its dose history are deleted.",
+	"Формат: /med add Название 9:00 21:00\nНапример: /med add Витамин D в 9:00 и 21:00": "Format: /med add Name 9:00 21:00\nFor example: /med add Vitamin D at 9:00 and 21:00",
+	"✅ Принял":                      "✅ Taken",
+	"⏭ Пропустил":                   "⏭ Skipped",
+	"Это расписание уже удалено.":   "This schedule is already deleted.",
+	"Не удалось отметить приём: %s": "Could not record the dose: %s",
+	"⏭ пропущено":                   "⏭ skipped",
+	"✅ принято":                     "✅ taken",
+
+	// bot/merge.go
+	"Формат: /merge 12 15 — влить задачу #15 в #12": "Format: /merge 12 15 merges task #15 into #12",
+	"🔀 Номер задачи-дубликата, которую влить в #%d? Её описание допишется сюда, срок останется более ранний, подзадачи, напоминания и вложения перейдут, а сама она уйдёт в корзину.": "🔀 Number of the duplicate to merge into #%d? Its description is appended here, the earlier deadline stays, its subtasks, reminders and attachments move over, and the duplicate goes to the trash.",
+	"Пришли номер задачи, например <code>15</code>.":                          "Send the task number, for example <code>15</code>.",
+	"Задачу нельзя объединить с ней же самой.":                                "A task cannot be merged with itself.",
+	"Задачу нельзя объединить с её подзадачей.":                               "A task cannot be merged with its subtask.",
+	"Не удалось объединить задачи: %s":                                        "Could not merge the tasks: %s",
+	"↩️ Отменить объединение":                                                 "↩️ Undo merge",
+	"🔀 Задача #%d влита в #%d и лежит в корзине.":                             "🔀 Task #%d is merged into #%d and is in the trash.",
+	"Отменить уже нельзя: объединение отменено раньше или задачи больше нет.": "Too late to undo: the merge was undone already or a task is gone.",
+	"↩️ Объединение отменено.":                                                "↩️ Merge undone.",
+	"↩️ Объединение отменено: #%d и #%d снова отдельные задачи.":              "↩️ Merge undone: #%d and #%d are separate tasks again.",
+
+	// bot/middleware.go
+	"🐢 Не так быстро! Подожди пару секунд и попробуй снова.": "🐢 Not so fast! Wait a couple of seconds and try again.",
+	"🛠 Бот на техническом обслуживании. Загляни чуть позже!": "🛠 The bot is under maintenance. Check back a bit later!",
+
+	// bot/nudge.go
+	"Задачу не трогали уже %d дн.":                                                    "The task has not been touched for %d days.",
+	"Напоминание о ней откладывалось %d раз.":                                         "Its reminder was snoozed %d times.",
+	"🤔 <b>#%d</b> %s\n%s Может, разбить её на шаги, поручить кому-то или отказаться?": "🤔 <b>#%d</b> %s\n%s Maybe split it into steps, delegate it or drop it?",
+	"✂️ Разбить":   "✂️ Split",
+	"🤝 Поручить":   "🤝 Delegate",
+	"🗑 Отказаться": "🗑 Drop",
+	"📌 Оставить":   "📌 Keep",
+	"✂️ Напиши шаги для «%s», каждый с новой строки. Они заменят задачу.": "✂️ Write the steps for “%s”, each on a new line. They will replace the task.",
+	"Не удалось изменить задачу: %s":                                      "Could not change the task: %s",
+	"🤝 поручено":  "🤝 delegated",
+	"🗑 удалена":   "🗑 deleted",
+	"📌 оставлена": "📌 kept",
+	"📤 Перешли следующее сообщение тому, кому поручаешь задачу. У себя я её закрыл.": "📤 Forward the next message to the person you delegate the task to. I closed it on your side.",
+	"Напиши хотя бы один шаг.":           "Write at least one step.",
+	"Не удалось разбить задачу: %s":      "Could not split the task: %s",
+	"✂️ <b>Задача разбита на шаги</b>\n": "✂️ <b>The task is split into steps</b>\n",
+	"Срок: %s\n": "Due: %s\n",
+
+	// bot/plain.go
+	"🔤 Простой текст %s. В нём сообщения приходят без эмодзи и оформления, а значки заменены словами — так их удобнее слушать с экранным диктором.\nВключить: /plain on, выключить: /plain off": "🔤 Plain text is %s. In it messages come without emojis and formatting, and icons are replaced with words, which is easier to follow with a screen reader.\nTurn on: /plain on, turn off: /plain off",
+	"Формат: /plain on или /plain off": "Format: /plain on or /plain off",
+	"🔤 Простой текст включён: дальше сообщения будут без эмодзи и оформления.": "🔤 Plain text is on: from now on messages come without emojis and formatting.",
+	"🔤 Простой текст выключен.": "🔤 Plain text is off.",
+	"Срочно:":    "Urgent:",
+	"Важно:":     "Important:",
+	"Не срочно:": "Low priority:",
+	"Внимание:":  "Warning:",
+	"Срок:":      "Due:",
+	"Описание:":  "Description:",
+
+	// bot/postpone.go
+	"Формат: /postpone 12 1d — на день, /postpone 12 2w — на две недели, /postpone 12 2025-11-30 — на дату": "Format: /postpone 12 1d — by a day, /postpone 12 2w — by two weeks, /postpone 12 2025-11-30 — to a date",
+	"Регулярную задачу нельзя отложить: её сроки задаёт расписание.":                                        "A recurring task cannot be postponed: its dates follow the schedule.",
+	"Эта дата уже прошла — выбери день не раньше сегодняшнего.":                                             "That date has passed — pick today or a later day.",
+	"Не удалось отложить задачу: %s":                                                                        "Could not postpone the task: %s",
+	"⏰ Задача «%s» отложена: новый дедлайн %s.":                                                             "⏰ The task “%s” is postponed: the new deadline is %s.",
+	"⏰ +1 день":      "⏰ +1 day",
+	"+1 неделя":      "+1 week",
+	"📅 Выбрать дату": "📅 Pick a date",
+	"⏰ На сколько отложить задачу?":                                                     "⏰ How long to postpone the task?",
+	"📅 Выбери новый дедлайн в календаре или напиши дату вроде <code>2025-11-30</code>.": "📅 Pick a new deadline in the calendar or type a date like <code>2025-11-30</code>.",
+
+	// bot/priority.go
+	"⏭️ Пропустить": "⏭️ Skip",
+	"‼️ Срочно":     "‼️ Urgent",
+	"❗ Высокий":     "❗ High",
+	"▫️ Обычный":    "▫️ Normal",
+	"🔽 Низкий":      "🔽 Low",
+
+	// bot/quick_add.go
+	"Не вижу названия задачи.\n":          "I do not see the task title.\n",
+	"Не знаю такого приоритета (%s).\n%s": "Unknown priority (%s).\n%s",
+	"Не понял срок (%s).\n%s":             "Could not read the deadline (%s).\n%s",
+	"Формат: /add Купить молоко #покупки !high @завтра\n• #категория (пробелы — через _)\n• !urgent, !high, !low или !срочно, !высокий, !низкий\n• @сегодня, @завтра, @послезавтра, @пн…@вс, @30.11 или @2025-11-30": "Format: /add Buy milk #shopping !high @tomorrow\n• #category (spaces as _)\n• !urgent, !high, !normal or !low\n• @today, @tomorrow, @mon…@sun, @30.11 or @2025-11-30",
+	"Категория, приоритет и срок указываются по одному разу (%s).": "Category, priority and deadline are given once each (%s).",
+
+	// bot/quick_reply.go
+	"отметить последнюю показанную задачу": "complete the last shown task",
+	"список задач": "task list",
+	"новая задача": "new task",
+	"Не знаю, какую задачу отметить. Открой её через /task &lt;id&gt; и пришли ✅ ещё раз.": "I do not know which task to complete. Open it with /task &lt;id&gt; and send ✅ again.",
+	"Задача «%s» уже выполнена.": "The task “%s” is already done.",
+	"Формат:\n• /emoji ✅ done — эмодзи отмечает последнюю показанную задачу\n• /emoji 📋 list — открывает список задач\n• /emoji ➕ new — начинает новую задачу\n• /emoji ✅ off — убрать эмодзи, /emoji reset — вернуть стандартные": "Format:\n• /emoji ✅ done — the emoji completes the last shown task\n• /emoji 📋 list — opens the task list\n• /emoji ➕ new — starts a new task\n• /emoji ✅ off — remove the emoji, /emoji reset — bring back the defaults",
+	"Не удалось сохранить: %s":                                       "Could not save: %s",
+	"⚡️ Быстрые ответы выключены. Вернуть стандартные: /emoji reset": "⚡️ Quick replies are off. Bring back the defaults: /emoji reset",
+	"⚡️ <b>Быстрые ответы</b> — пришли один эмодзи:\n":               "⚡️ <b>Quick replies</b> — send a single emoji:\n",
+	"Изменить: /emoji &lt;эмодзи&gt; done|list|new|off":              "Change: /emoji &lt;emoji&gt; done|list|new|off",
+
+	// bot/quota.go
+	"Не удалось получить лимиты: %s":                                       "Could not get the limits: %s",
+	"Формат: /quota &lt;telegram_id&gt; off|on":                            "Format: /quota &lt;telegram_id&gt; off|on",
+	"Эта команда доступна только администраторам.":                         "This command is for administrators only.",
+	"Пользователь с таким Telegram ID ещё не пользовался ботом.":           "No user with this Telegram ID has used the bot yet.",
+	"Не удалось изменить лимиты: %s":                                       "Could not change the limits: %s",
+	"🔓 Лимиты для %s сняты.":                                               "🔓 Limits for %s are lifted.",
+	"🔒 Лимиты для %s снова действуют.":                                     "🔒 Limits for %s apply again.",
+	"🔓 Для тебя лимиты не действуют.":                                      "🔓 Limits do not apply to you.",
+	"📏 <b>Лимиты</b>\n":                                                    "📏 <b>Limits</b>\n",
+	"• Активные задачи: %d из %s\n":                                        "• Active tasks: %d of %s\n",
+	"• Категории: %d из %s\n":                                              "• Categories: %d of %s\n",
+	"• Размер файла: до %s":                                                "• File size: up to %s",
+	"достигнут лимит активных задач (%d). Заверши или удали старые задачи": "the limit of active tasks is reached (%d). Complete or delete old tasks",
+	"достигнут лимит категорий (%d). Выбери одну из существующих":          "the limit of categories is reached (%d). Pick one of the existing ones",
+	"файл больше допустимых %s":                                            "the file is larger than the allowed %s",
+	"%d МБ": "%d MB",
+	"%d КБ": "%d KB",
+
+	// bot/reaction.go
+	"Не удалось отметить задачу: %s":      "Could not complete the task: %s",
+	"Не удалось отложить напоминание: %s": "Could not snooze the reminder: %s",
+	"😴 Напомню о «%s» в %s.":              "😴 I will remind you about “%s” at %s.",
+
+	// bot/recurrence.go
+	"✅ Пропущенный повтор «%s» от %s отмечен выполненным.":                                                  "✅ The missed repeat of “%s” from %s is marked done.",
+	"📆 По каким дням недели повторять? Отметь их кнопками и нажми «Готово» или напиши, например, «пн, чт».": "📆 On which weekdays should it repeat? Tick them with the buttons and tap “Done”, or type them, e.g. “mon, thu”.",
+	"Этот выбор дней уже неактуален.":                                                                       "This choice of days is no longer active.",
+	"Отметь хотя бы один день.":                                                                             "Tick at least one day.",
+	" и ":                   " and ",
+	"вс":                    "Su",
+	"воскресенье":           "Sunday",
+	"воскресеньям":          "Sundays",
+	"пн":                    "Mo",
+	"понедельник":           "Monday",
+	"понедельникам":         "Mondays",
+	"вт":                    "Tu",
+	"вторник":               "Tuesday",
+	"вторникам":             "Tuesdays",
+	"ср":                    "We",
+	"среда":                 "Wednesday",
+	"средам":                "Wednesdays",
+	"чт":                    "Th",
+	"четверг":               "Thursday",
+	"четвергам":             "Thursdays",
+	"пт":                    "Fr",
+	"пятница":               "Friday",
+	"пятницам":              "Fridays",
+	"сб":                    "Sa",
+	"суббота":               "Saturday",
+	"субботам":              "Saturdays",
+	"раз в %d дн.":          "every %d days",
+	"каждый день":           "daily",
+	"каждую неделю по %s":   "every week on %s",
+	"каждый месяц %d числа": "every month on day %d",
+	"%s от первого выполнения": "%s from the first completion",
+	"%s, считая с %s":          "%s, counting from %s",
+	"раз в квартал":            "every quarter",
+	"раз в полгода":            "every six months",
+	"раз в %d мес.":            "every %d months",
+	"⏳ Повторы считаются от первого выполнения. Сколько дней до/после даты повтора считать окном выполнения? (0–%d)": "⏳ Repeats count from the first completion. How many days before/after the repeat date count as the window to complete it? (0–%d)",
+	"Каждую неделю": "Every week",
+	"Каждый месяц":  "Every month",
+	"⏳ Сколько дней до/после дня повтора считать окном выполнения? (0–%d)": "⏳ How many days before/after the repeat day count as the window to complete it? (0–%d)",
+	"⏳ Сколько дней до/после дня недели считать окном выполнения? (0–%d)":  "⏳ How many days before/after the weekday count as the window to complete it? (0–%d)",
+	"⏳ Сколько дней до/после даты считать окном выполнения? (например, 2)": "⏳ How many days before/after the date count as the window to complete it? (e.g. 2)",
+	"☀️ Каждый день":  "☀️ Every day",
+	"🔢 Раз в N дней":  "🔢 Every N days",
+	"📅 Каждую неделю": "📅 Every week",
+	"🗓 Каждый месяц":  "🗓 Every month",
+	"🗓 Раз в квартал": "🗓 Every quarter",
+	"🗓 Раз в полгода": "🗓 Every six months",
+
+	// bot/report.go
+	"как присылать отчёт: сообщением, без звука или в закрепе":                                                                                                        "how reports arrive: a message, a silent one or a pinned note",
+	"Формат: /delivery chat — обычным сообщением, /delivery silent — без звука, /delivery pin — одним закреплённым сообщением, которое обновляется с каждым отчётом.": "Usage: /delivery chat — as a regular message, /delivery silent — without a sound, /delivery pin — as one pinned message updated with every report.",
+	"🔕 новым сообщением без звука":                                     "🔕 as a new silent message",
+	"📌 в закреплённом сообщении, которое бот обновляет":                "📌 in a pinned message the bot keeps updating",
+	"🔔 новым сообщением":                                               "🔔 as a new message",
+	"Отчёты приходят %s.\n%s":                                          "Reports arrive %s.\n%s",
+	"Готово, отчёты будут приходить %s.":                               "Done, reports will arrive %s.",
+	"📌 Отчёт обновлён в закреплённом сообщении.":                       "📌 The report in the pinned message is updated.",
+	"прислать отчёт сейчас":                                            "send the report now",
+	"как часто присылать отчёт":                                        "how often to send the report",
+	"➕ Новая задача":                                                   "➕ New task",
+	"📋 Все задачи":                                                     "📋 All tasks",
+	"✅ Отметить выполненные":                                           "✅ Mark done",
+	"Отмечать нечего — открытых задач нет 🎉":                           "Nothing to mark — no open tasks 🎉",
+	"Что уже сделано? Нажми на задачу, чтобы отметить её выполненной.": "What is done already? Tap a task to mark it done.",
+	"\nПоказаны %d из %d, остальные — в /tasks.":                       "\nShowing %d of %d, the rest are in /tasks.",
+	"Не удалось сформировать отчёт: %s":                                "Could not build the report: %s",
+	"Текущий интервал отчётов: каждые %d ч., следующий отчёт: %s.\nУкажи число часов, например: /interval 4": "Current report interval: every %d h, next report: %s.\nGive a number of hours, e.g. /interval 4",
+	"Интервал должен быть положительным числом часов, например /interval 6":                                  "The interval must be a positive number of hours, e.g. /interval 6",
+	"Не удалось изменить интервал: %s":                                                                       "Could not change the interval: %s",
+	"Интервал уведомлений обновлён: каждые %d ч.\nСледующий отчёт: %s. Вот как он будет выглядеть 👇":         "Report interval updated: every %d h.\nNext report: %s. This is how it will look 👇",
+	"Не удалось собрать предпросмотр: %s":                                                                    "Could not build the preview: %s",
+	"сегодня в %s": "today at %s",
+	"завтра в %s":  "tomorrow at %s",
+	"%s в %s":      "%s at %s",
+
+	// bot/retention.go
+	"👋 Давно не виделись! Продолжать присылать отчёты по задачам?\nЕсли не ответить, через пару недель отчёты прекратятся (задачи сохранятся).": "👋 Long time no see! Keep sending task reports?\nWithout an answer, reports stop in a couple of weeks (your tasks are kept).",
+	"✅ Да, присылать":                        "✅ Yes, keep sending",
+	"🔕 Нет, остановить":                      "🔕 No, stop",
+	"✅ Отлично, отчёты продолжат приходить.": "✅ Great, reports will keep coming.",
+	"🔕 Отчёты остановлены, задачи сохранены. Напиши что-нибудь, чтобы снова их получать.": "🔕 Reports stopped, tasks kept. Write anything to get them again.",
+
+	// bot/routes.go
+	"начать работу":                     "get started",
+	"подсказки по командам":             "command tips",
+	"отменить текущий ввод":             "cancel the current input",
+	"⏪ Диалог создания задачи отменён.": "⏪ Task creation cancelled.",
+
+	// bot/search.go
+	"Что искать? Например: /search молоко":                                "What to search for? For example: /search milk",
+	"Не удалось выполнить поиск: %s":                                      "Could not search: %s",
+	"🔎 По запросу «%s» ничего не нашлось.":                                "🔎 Nothing found for “%s”.",
+	"🔎 <b>Найдено: %d</b> по запросу «%s»%s\n":                            "🔎 <b>Found: %d</b> for “%s”%s\n",
+	"Показаны %d самых новых — уточни запрос, чтобы увидеть остальные.\n": "Showing the %d newest — refine the query to see the rest.\n",
+
+	// bot/settings.go
+	"простой текст без эмодзи":            "plain text without emojis",
+	"перенос настроек":                    "move settings",
+	"часовой пояс":                        "time zone",
+	"язык бота":                           "bot language",
+	"рабочие часы":                        "working hours",
+	"обратный отсчёт до срока":            "countdown to the deadline",
+	"когда задача считается просроченной": "when a task counts as overdue",
+	"автоудаление служебных сообщений":    "auto-delete service messages",
+	"быстрые ответы эмодзи":               "emoji quick replies",
+	"общие пространства":                  "shared workspaces",
+	"привязать второй аккаунт":            "link a second account",
+	"партнёр по задачам":                  "task partner",
+	"лимиты":                              "limits",
+	"⚙️ <b>Настройки</b>\n• /settings export — файл с часовым поясом, рабочими часами, интервалом отчётов, быстрыми ответами и категориями\n• пришли этот файл боту на другом сервере или после удаления данных, чтобы восстановить настройки\nЗадачи и история в файл не входят.": "⚙️ <b>Settings</b>\n• /settings export — a file with the time zone, working hours, report interval, quick replies and categories\n• send this file to the bot on another server or after deleting your data to restore the settings\nTasks and history are not included.",
+	"Не удалось выгрузить настройки: %s":                "Could not export the settings: %s",
+	"Пришли этот файл боту, чтобы перенести настройки.": "Send this file to the bot to move the settings.",
+	"Файл не принят: %s":                                "File not accepted: %s",
+	"Не удалось скачать файл: %s":                       "Could not download the file: %s",
+	"Это не файл настроек или он повреждён: %s":         "This is not a settings file or it is damaged: %s",
+	"Не удалось применить настройки: %s":                "Could not apply the settings: %s",
+	"⚙️ Настройки применены. Часовой пояс: %s, рабочие часы: %s.\nКатегорий добавлено: %d, обновлено: %d.": "⚙️ Settings applied. Time zone: %s, working hours: %s.\nCategories added: %d, updated: %d.",
+
+	// bot/share.go
+	"📥 Открыть планировщик":   "📥 Open the planner",
+	"👥 Присоединиться к «%s»": "👥 Join “%s”",
+	"📤 Перешли карточку выше в любой чат — кнопка под ней откроет планировщик.": "📤 Forward the card above to any chat — the button under it opens the planner.",
+	"• Категория: %s\n":        "• Category: %s\n",
+	"• Срок: %s\n":             "• Due: %s\n",
+	"• Приоритет: %s\n":        "• Priority: %s\n",
+	"• Повтор: %s\n":           "• Repeat: %s\n",
+	"• ✅ Выполнена\n":          "• ✅ Done\n",
+	"\n🏠 Из пространства «%s»": "\n🏠 From the workspace “%s”",
+	"🏠 Ты уже в «%s» — переключил на это пространство. Задачи: /tasks": "🏠 You are already in “%s” — switched to this workspace. Tasks: /tasks",
+	"Ссылка устарела: такого пространства больше нет.":                 "The link is out of date: this workspace no longer exists.",
+	"✅ Добро пожаловать в «%s»! Общие задачи: /tasks":                  "✅ Welcome to “%s”! Shared tasks: /tasks",
+
+	// bot/signup.go
+	"🔐 Бот работает по приглашениям. Отправь код приглашения, чтобы начать.": "🔐 The bot is invite-only. Send your invite code to start.",
+	"Код приглашения не подошёл. Проверь его и попробуй ещё раз.":            "The invite code did not work. Check it and try again.",
+	"🤖 Проверка, что ты не бот: нажми на кнопку %s":                          "🤖 Checking that you are not a bot: tap the %s button",
+	"Не та кнопка, попробуй ещё раз":                                         "Wrong button, try again",
+	"Спасибо!": "Thank you!",
+	"✅ Проверка пройдена. Набери /start, чтобы начать.": "✅ Check passed. Type /start to begin.",
+
+	// bot/stats.go
+	"статистика": "statistics",
+	"карта продуктивности за месяц":                            "productivity map for a month",
+	"итоги недели":                                             "weekly summary",
+	"Не удалось собрать статистику: %s":                        "Could not collect the statistics: %s",
+	"📊 <b>Статистика за 30 дней</b>\n":                         "📊 <b>Statistics for 30 days</b>\n",
+	"\n💊 <b>Приём лекарств</b>\n":                              "\n💊 <b>Medication</b>\n",
+	"— расписаний нет, добавь через /med add\n":                "— no schedules, add one with /med add\n",
+	"• %s — пока нет отметок\n":                                "• %s — no records yet\n",
+	"• %s — %d%% (принято %d из %d)\n":                         "• %s — %d%% (taken %d of %d)\n",
+	"\n🧹 <b>Разбор бэклога</b>\n":                              "\n🧹 <b>Backlog triage</b>\n",
+	"— за этот период задачи не разбирались\n":                 "— no tasks were triaged in this period\n",
+	"Убрано %d: удалено %d, в архив %d; оставлено %d\n":        "Cleared %d: deleted %d, archived %d; kept %d\n",
+	"\n⏰ <b>Переносы дедлайнов</b> (открытые задачи)\n":        "\n⏰ <b>Deadline moves</b> (open tasks)\n",
+	"— сроки не переносились\n":                                "— no deadlines were moved\n",
+	"Задач с перенесённым сроком: %d, переносов: %d\n":         "Tasks with a moved deadline: %d, moves: %d\n",
+	"• #%d %s — %d раз\n":                                      "• #%d %s — %d times\n",
+	"\n⏱ <b>Время до выполнения</b> (медиана · 90%)\n":         "\n⏱ <b>Time to complete</b> (median · 90%)\n",
+	"— за этот период задачи не выполнялись\n":                 "— no tasks were completed in this period\n",
+	"Все задачи: %s · %s, выполнено %d\n":                      "All tasks: %s · %s, completed %d\n",
+	"🐢 — здесь задачи задерживаются заметно дольше обычного\n": "🐢 — tasks linger here noticeably longer than usual\n",
+	"%d мин": "%d min",
+	"%d ч":   "%d h",
+	"%d дн.": "%d d",
+
+	// bot/task_buttons.go
+	"Ошибка: ": "Error: ",
+	"Задача уже отмечена выполненной в этом окне.": "The task is already marked done in this window.",
+	"Отметить задачу «%s» (#%d) как выполненную?":  "Mark the task “%s” (#%d) as done?",
+	"✅ Да, #%d выполнена":                          "✅ Yes, #%d is done",
+	"↩️ Нет":                                       "↩️ No",
+	"«%s» — повторяющаяся задача. Можно остановить только будущие повторы, сохранив историю, или удалить её целиком.": "“%s” is a recurring task. You can stop only the future repeats and keep the history, or delete it entirely.",
+	"⏹ Только будущие повторы":                                       "⏹ Future repeats only",
+	"🗑 Полностью с историей":                                         "🗑 Entirely with history",
+	"Удалить задачу «%s» (#%d)?":                                     "Delete the task “%s” (#%d)?",
+	"🗑 Да, удалить #%d":                                              "🗑 Yes, delete #%d",
+	"♻️ Задача «%s» отмечена выполненной в этом окне.":               "♻️ The task “%s” is marked done in this window.",
+	"🗑 Задача «%s» в корзине, вернуть — /trash.":                     "🗑 The task “%s” is in the trash, restore it with /trash.",
+	"⏹ Повторы задачи «%s» остановлены. История сохранена: /task %d": "⏹ Repeats of the task “%s” stopped. The history is kept: /task %d",
+
+	// bot/task_card.go
+	"\n🔗 Упоминается в %s\n":                              "\n🔗 Mentioned in %s\n",
+	"• <b>Пропуски:</b> переносятся, пока не отмечены\n":  "• <b>Missed repeats:</b> carried until marked\n",
+	"• <b>Пропущен повтор:</b> %s\n":                      "• <b>Missed repeat:</b> %s\n",
+	"Укажи ID задачи: /task 12":                           "Give the task ID: /task 12",
+	"• <b>Подзадача для:</b> #%d %s\n":                    "• <b>Subtask of:</b> #%d %s\n",
+	"• <b>Категория:</b> %s\n":                            "• <b>Category:</b> %s\n",
+	"• <b>Напоминание:</b> за %s до дедлайна\n":           "• <b>Reminder:</b> %s before the deadline\n",
+	"• <b>Партнёр:</b> узнает, если задача просрочится\n": "• <b>Partner:</b> will know if the task becomes overdue\n",
+	"• <b>Повтор:</b> остановлен %s\n":                    "• <b>Repeat:</b> stopped %s\n",
+	"• <b>Повтор:</b> %s (окно ±%d дн.)\n":                "• <b>Repeat:</b> %s (window ±%d days)\n",
+	"• <b>Следующий раз:</b> %s\n":                        "• <b>Next time:</b> %s\n",
+	"• <b>Статус:</b> выполнена\n":                        "• <b>Status:</b> done\n",
+	"• <b>Последнее выполнение:</b> %s\n":                 "• <b>Last completed:</b> %s\n",
+	"\n🪜 <b>Подзадачи</b> %d/%d\n":                        "\n🪜 <b>Subtasks</b> %d/%d\n",
+	"создана ": "created ",
+	"дедлайн переносился %d раз":       "deadline moved %d times",
+	"напоминание откладывалось %d раз": "reminder snoozed %d times",
+	"🔔 напомнить ":                     "🔔 remind ",
+	"✏️ Редактировать":                 "✏️ Edit",
+	"⏰ Отложить":                       "⏰ Postpone",
+	"🔔 Напомнить":                      "🔔 Remind",
+	"➕ Подзадача":                      "➕ Subtask",
+	"📅 Файл .ics":                      "📅 .ics file",
+	"Google Календарь":                 "Google Calendar",
+	"📤 Поделиться":                     "📤 Share",
+	"🔀 Объединить":                     "🔀 Merge",
+	"У задачи нет дедлайна — добавить в календарь нечего.": "The task has no deadline — nothing to add to the calendar.",
+	"Открой файл, чтобы добавить дедлайн в календарь.":     "Open the file to add the deadline to your calendar.",
+	"через 2 часа":    "in 2 hours",
+	"вечером":         "tonight",
+	"завтра утром":    "tomorrow morning",
+	"⏪ Отменить ввод": "⏪ Cancel input",
+	"🔔 Когда напомнить о задаче #%d? Например <code>18:30</code>, <code>2025-11-30 09:00</code>, «вечером» или «через 2 часа».": "🔔 When should I remind you about task #%d? For example <code>18:30</code>, <code>2025-11-30 09:00</code>, “tonight” or “in 2 hours”.",
+	"Не понял время. Напиши, например, <code>18:30</code> или «завтра утром».":                                                  "I did not understand the time. Write, for example, <code>18:30</code> or “tomorrow morning”.",
+	"➕ Название подзадачи для #%d. Категория, дедлайн и приоритет достанутся от задачи.":                                        "➕ Subtask title for #%d. It takes the category, deadline and priority of the task.",
+	"Название не может быть пустым.":       "The title cannot be empty.",
+	"Задача уже выполнена.":                "The task is already done.",
+	"Не удалось добавить подзадачу: %s":    "Could not add the subtask: %s",
+	"➕ Подзадача <b>#%d</b> %s добавлена.": "➕ Subtask <b>#%d</b> %s added.",
+
+	// bot/task_pages.go
+	"Не удалось получить задачи: %s":                          "Could not get the tasks: %s",
+	"У тебя нет активных задач. Добавь новую через /newtask.": "You have no active tasks. Add a new one with /newtask.",
+	"Здесь больше нет открытых задач.":                        "There are no more open tasks here.",
+
+	// bot/task_reminder.go
+	"Не удалось удалить напоминание: %s": "Could not delete the reminder: %s",
+	"Напоминание не найдено.":            "Reminder not found.",
+	"🗑 Напоминание удалено.":             "🗑 Reminder deleted.",
+	"Формат:\n• /remind &lt;id&gt; 2025-11-30 09:00 — напомнить о задаче в это время\n• /remind &lt;id&gt; 18:30 или «завтра утром», «через 2 часа»\n• /remind &lt;id&gt; — напоминания задачи\n• /remind del &lt;номер&gt; — удалить напоминание": "Format:\n• /remind &lt;id&gt; 2025-11-30 09:00 — remind about the task at this time\n• /remind &lt;id&gt; 18:30 or “tomorrow morning”, “in 2 hours”\n• /remind &lt;id&gt; — reminders of the task\n• /remind del &lt;number&gt; — delete a reminder",
+	"Задача уже выполнена, напоминать не о чем.":                                      "The task is already done, nothing to remind about.",
+	"Это время уже прошло — укажи момент в будущем.":                                  "That time has passed — give a moment in the future.",
+	"У задачи уже слишком много напоминаний. Удали лишние: /remind del &lt;номер&gt;": "The task already has too many reminders. Delete some: /remind del &lt;number&gt;",
+	"Не удалось сохранить напоминание: %s":                                            "Could not save the reminder: %s",
+	"🔔 Напомню о задаче #%d %s.":                                                      "🔔 I will remind you about task #%d %s.",
+	"Не удалось получить напоминания: %s":                                             "Could not get the reminders: %s",
+	"У задачи #%d нет напоминаний. Добавить: /remind %d завтра утром":                 "Task #%d has no reminders. Add one: /remind %d tomorrow morning",
+	"🔔 <b>Напоминания</b> о «%s»\n":                                                   "🔔 <b>Reminders</b> about “%s”\n",
+	"Удалить: /remind del &lt;номер&gt;":                                              "Delete: /remind del &lt;number&gt;",
+	"🔔 Напоминание: «%s» (#%d)":                                                       "🔔 Reminder: “%s” (#%d)",
+	"\n⏳ До срока %s, в %s":                                                           "\n⏳ Due %s, at %s",
+	"✅ Выполнить":                                                                     "✅ Complete",
+
+	// bot/task_sort.go
+	"❗ Приоритет": "❗ Priority",
+	"⏰ Дедлайн":   "⏰ Deadline",
+	"🆕 Новые":     "🆕 Newest",
+
+	// bot/tasks.go
+	"   ⚠️ Пропущен повтор %s\n":        "   ⚠️ Missed repeat %s\n",
+	"добавить задачу пошагово":          "add a task step by step",
+	"задача одной строкой":              "a task in one line",
+	"активные задачи":                   "active tasks",
+	"закреплённая панель задач":         "pinned task dashboard",
+	"поиск по задачам":                  "search tasks",
+	"карточка задачи":                   "task card",
+	"отметить задачу выполненной":       "mark a task done",
+	"удалить задачу":                    "delete a task",
+	"объединить задачу с дубликатом":    "merge a task with its duplicate",
+	"изменить задачу":                   "edit a task",
+	"свои поля задач":                   "custom task fields",
+	"напомнить о задаче в точное время": "remind about a task at an exact time",
+	"отложить дедлайн задачи":           "postpone a task deadline",
+	"выполненные задачи":                "completed tasks",
+	"неделя по дням":                    "the week by day",
+	"задачи в архиве":                   "archived tasks",
+	"корзина удалённых задач":           "trash of deleted tasks",
+	"подписаться на календарь":          "subscribe to a calendar",
+	"💬 Текст напоминания для отчёта, если названия мало (или «Пропустить»).\nПодстановки: %s\nНапример: <code>Передать показания, осталось {days_left} дн., в прошлый раз {last_done}</code>": "💬 Reminder text for the report, if the title is not enough (or “Skip”).\nPlaceholders: %s\nFor example: <code>Submit the meter readings, {days_left} days left, last time {last_done}</code>",
+	"🆕 Создаём новую задачу.\n<b>Шаг 1:</b> как её назвать?": "🆕 Creating a new task.\n<b>Step 1:</b> what should it be called?",
+	"✏️ Добавь описание — можно в несколько сообщений, с фото, видео и файлами. Когда закончишь, нажми «Готово» (или «Пропустить», если описание не нужно).": "✏️ Add a description — in several messages if you like, with photos, videos and files. When you are done, tap “Done” (or “Skip” if no description is needed).",
+	"Пиши поля строками <code>название: значение</code> или нажми «Пропустить».":                                                                             "Write fields as lines <code>name: value</code> or tap “Skip”.",
+	"🏷 Выбери категорию или отправь свою (можно «Пропустить»).":                                                                                              "🏷 Pick a category or send your own (you can “Skip”).",
+	"⏰ Выбери дедлайн в календаре, напиши дату вроде <code>2025-11-30</code> или нажми «Пропустить».":                                                        "⏰ Pick a deadline in the calendar, type a date like <code>2025-11-30</code> or tap “Skip”.",
+	"Не могу распознать дату. Выбери день в календаре или напиши его как <code>2025-11-30</code>.":                                                           "I cannot read the date. Pick a day in the calendar or type it like <code>2025-11-30</code>.",
+	"❗ Какой приоритет у задачи? «Пропустить» оставит обычный.":                                                                                              "❗ What is the task's priority? “Skip” keeps it normal.",
+	"Выбери приоритет кнопкой или нажми «Пропустить».":                                                                                                       "Pick a priority with a button or tap “Skip”.",
+	"🔁 Сделать задачу повторяющейся?":                                                                                                                        "🔁 Make the task recurring?",
+	"🔁 Как часто повторять?":                 "🔁 How often should it repeat?",
+	"Нажми «Да» или «Нет».":                  "Tap “Yes” or “No”.",
+	"Выбери частоту кнопкой.":                "Pick the frequency with a button.",
+	"🔢 Через сколько дней повторять? (2–%d)": "🔢 Repeat every how many days? (2–%d)",
+	"📆 В какой день месяца напоминать? (1–31). Если числа нет в месяце, возьмём последний день.": "📆 On which day of the month should I remind you? (1–31). If the month has no such day, the last day is used.",
+	"Интервал должен быть числом от 2 до %d.":                                                    "The interval must be a number from 2 to %d.",
+	"День должен быть числом от 1 до 31.":                                                        "The day must be a number from 1 to 31.",
+	"Окно должно быть числом от 0 до %d.":                                                        "The window must be a number from 0 to %d.",
+	"Не получилось разобрать подстановки. ":                                                      "Could not read the placeholders. ",
+	"Не удалось сохранить задачу: %s":                                                            "Could not save the task: %s",
+	"Не удалось сохранить вложения: %s":                                                          "Could not save the attachments: %s",
+	"✅ <b>Задача сохранена</b>%s\n":                                                              "✅ <b>Task saved</b>%s\n",
+	"• <b>Название:</b> %s\n":                                                                    "• <b>Title:</b> %s\n",
+	"• <b>Описание:</b> %s\n":                                                                    "• <b>Description:</b> %s\n",
+	"• <b>Вложения:</b> %d\n":                                                                    "• <b>Attachments:</b> %d\n",
+	"• <b>Дедлайн:</b> %s\n":                                                                     "• <b>Deadline:</b> %s\n",
+	"• <b>Приоритет:</b> %s\n":                                                                   "• <b>Priority:</b> %s\n",
+	"• <b>Повтор:</b> %s (окно +%d дн.)\n":                                                       "• <b>Repeat:</b> %s (window +%d days)\n",
+	"• <b>Текст напоминания:</b> %s\n":                                                           "• <b>Reminder text:</b> %s\n",
+	"Формат: /tasks или /tasks high — задачи с приоритетом не ниже указанного (urgent, high, normal, low).": "Format: /tasks or /tasks high — tasks with at least the given priority (urgent, high, normal, low).",
+	"Укажи ID задачи: /complete 12": "Give the task ID: /complete 12",
+	"✅ Повторяющаяся задача «%s» отмечена выполненной в этом окне.": "✅ The recurring task “%s” is marked done in this window.",
+	"✅ Задача «%s» выполнена.": "✅ The task “%s” is done.",
+	"↩️ Удаление отменено.":    "↩️ Deletion cancelled.",
+	"Выбери, что удалить: только будущие повторы или задачу целиком с историей.":        "Choose what to delete: only the future repeats or the whole task with its history.",
+	"Подтверди или отмени удаление задачи.":                                             "Confirm or cancel deleting the task.",
+	"📋 <b>Текущие задачи</b>%s\n":                                                       "📋 <b>Current tasks</b>%s\n",
+	"Нажми на кнопку, чтобы отметить задачу выполненной или удалить повторяющуюся.\n\n": "Tap a button to mark a task done or delete a recurring one.\n\n",
+	"🗑 Удалить": "🗑 Delete",
+	"♻️ \"%s\" (#%d) — повторяющаяся задача.\nМожно удалить только будущие повторы — задача и история выполнений останутся — или удалить её полностью вместе с историей.": "♻️ \"%s\" (#%d) is a recurring task.\nYou can delete only the future repeats — the task and its completion history stay — or delete it entirely along with the history.",
+	"Удалить задачу \"%s\" (#%d)?": "Delete the task \"%s\" (#%d)?",
+	"⏹ Повторы задачи \"%s\" остановлены. История сохранена: /task %d": "⏹ Repeats of the task \"%s\" stopped. The history is kept: /task %d",
+	"Укажи ID задачи: /delete 12":                          "Give the task ID: /delete 12",
+	"ID задачи должен быть числом.":                        "The task ID must be a number.",
+	"Не удалось удалить задачу: %s":                        "Could not delete the task: %s",
+	"🗑 Задача \"%s\" удалена. Вернуть её можно из /trash.": "🗑 The task \"%s\" is deleted. You can restore it from /trash.",
+	"   ⏰ Дедлайн: %s — <b>просрочено</b>\n":               "   ⏰ Deadline: %s — <b>overdue</b>\n",
+	"   ⏰ Дедлайн: %s · осталось ≈%d дн.\n":                "   ⏰ Deadline: %s · ≈%d days left\n",
+	" <i>· %d дн. в списке</i>":                            " <i>· %d days on the list</i>",
+	"   🔄 %s: %s (окно +%d дн.)\n":                         "   🔄 %s: %s (window +%d days)\n",
+	"   ✅ Последнее выполнение: %s\n":                      "   ✅ Last completed: %s\n",
+	"   ✅ Пока не выполнялась\n":                           "   ✅ Not completed yet\n",
+
+	// bot/timezone.go
+	"🕰 Часовой пояс: %s, сейчас %s.\nИзменить: /timezone Europe/Moscow":                                               "🕰 Time zone: %s, now %s.\nChange: /timezone Europe/London",
+	"Не знаю такого часового пояса. Укажи его как в базе IANA, например Europe/Moscow или Asia/Yekaterinburg.":        "Unknown time zone. Give it as in the IANA database, e.g. Europe/London or America/New_York.",
+	"Не удалось сохранить часовой пояс: %s":                                                                           "Could not save the time zone: %s",
+	"🕰 Часовой пояс %s сохранён, у тебя сейчас %s.":                                                                   "🕰 Time zone %s saved, it is %s for you now.",
+	"💼 Рабочие часы: %s.\n%s\nИзменить: /workhours 10-19":                                                             "💼 Working hours: %s.\n%s\nChange: /workhours 10-19",
+	"Укажи часы начала и конца работы, например /workhours 9-18. Работа должна заканчиваться не позже 22.":            "Give the start and end hours of work, e.g. /workhours 9-18. Work must end no later than 22.",
+	"Не удалось сохранить рабочие часы: %s":                                                                           "Could not save the working hours: %s",
+	"💼 Рабочие часы: %s.\n%s":                                                                                         "💼 Working hours: %s.\n%s",
+	"«Утром» — это %d:00, «после работы» — %d:00, «вечером» — %d:00. Напоминания о сроках приходят с %d:00 до %d:00.": "“Morning” is %d:00, “after work” is %d:00, “tonight” is %d:00. Deadline reminders come from %d:00 to %d:00.",
+
+	// bot/transient.go
+	"выключено":     "off",
+	"через %d мин.": "in %d min",
+	"🧹 Автоудаление служебных сообщений — подтверждений, «Главного меню» и уведомлений о выполнении: %s\n%s": "🧹 Auto-delete of service messages — confirmations, the “Main menu” and completion notices: %s\n%s",
+	"Формат: /autodelete 5 — удалять через 5 минут (до 1440), /autodelete off — не удалять":                  "Format: /autodelete 5 — delete after 5 minutes (up to 1440), /autodelete off — do not delete",
+	"🧹 Автоудаление выключено: служебные сообщения останутся в чате.":                                        "🧹 Auto-delete is off: service messages stay in the chat.",
+	"🧹 Служебные сообщения будут исчезать через %d мин.":                                                     "🧹 Service messages will disappear after %d min.",
+
+	// bot/trash.go
+	"Не удалось открыть корзину: %s":                                             "Could not open the trash: %s",
+	"🗑 Корзина пуста. Удалённые задачи хранятся здесь %s.":                       "🗑 The trash is empty. Deleted tasks are kept here for %s.",
+	"🗑 <b>Корзина: %d</b>\nЗадачи удаляются насовсем через %s после удаления.\n": "🗑 <b>Trash: %d</b>\nTasks are deleted for good %s after deletion.\n",
+	"\n…и ещё %d": "\n…and %d more",
+	"\n• #%d %s — удалена %s, осталось %s": "\n• #%d %s — deleted %s, %s left",
+	"♻️ Вернуть #%d · %s":                  "♻️ Restore #%d · %s",
+	"Задачи уже нет в корзине.":            "The task is no longer in the trash.",
+	"Не получилось: ":                      "Did not work: ",
+	"♻️ Задача «%s» возвращена.":           "♻️ The task “%s” is restored.",
+
+	// bot/triage.go
+	"Задача не найдена или уже удалена.": "The task was not found or is already deleted.",
+	"Не удалось разобрать задачу: %s":    "Could not triage the task: %s",
+	"🧹 <b>Разбор бэклога</b>\nЭти задачи не трогали больше двух месяцев. Что с ними сделать?\n📌 оставить · 🗑 удалить · 🗄 в архив\n\n": "🧹 <b>Backlog triage</b>\nThese tasks have not been touched for over two months. What should happen to them?\n📌 keep · 🗑 delete · 🗄 archive\n\n",
+	"%d. %s — %d дн.\n": "%d. %s — %d days\n",
+	"\nГотово: оставлено %d, удалено %d, в архиве %d.": "\nDone: %d kept, %d deleted, %d archived.",
+	"Формат: /archive restore &lt;id&gt;":              "Format: /archive restore &lt;id&gt;",
+	"Задача не найдена.":                               "Task not found.",
+	"Не удалось вернуть задачу: %s":                    "Could not restore the task: %s",
+	"♻️ Задача «%s» снова в списке.":                   "♻️ The task “%s” is back on the list.",
+	"Не удалось получить архив: %s":                    "Could not get the archive: %s",
+	"🗄 Архив пуст.":                                    "🗄 The archive is empty.",
+	"🗄 <b>Архив</b>\n":                                 "🗄 <b>Archive</b>\n",
+	"• <b>#%d</b> %s — с %s\n":                         "• <b>#%d</b> %s — since %s\n",
+	"Вернуть задачу: /archive restore &lt;id&gt;":      "Restore a task: /archive restore &lt;id&gt;",
+
+	// bot/weekly.go
+	"Не удалось подвести итоги: %s": "Could not sum up the week: %s",
+	"Рассылка итогов недели на этом сервере выключена, но /weekly покажет их в любой момент.":                      "Weekly summaries are turned off on this server, but /weekly shows them at any time.",
+	"Не удалось сохранить настройку: %s":                                                                           "Could not save the setting: %s",
+	"🗓 Итоги недели больше не будут приходить сами. /weekly покажет их по запросу.":                                "🗓 Weekly summaries will no longer come by themselves. /weekly shows them on request.",
+	"🗓 Итоги недели будут приходить по воскресеньям в %s.":                                                         "🗓 Weekly summaries will come on Sundays at %s.",
+	"/weekly — итоги недели сейчас, /weekly on — присылать их по воскресеньям вечером, /weekly off — не присылать": "/weekly — the weekly summary now, /weekly on — send it on Sunday evenings, /weekly off — do not send",
+	"🗓 <b>Итоги недели</b> · %s – %s\n":                                                                            "🗓 <b>Weekly summary</b> · %s – %s\n",
+	"✅ Выполнено: %d · 📌 Открыто: %d · ⚠️ Просрочено: %d\n":                                                        "✅ Completed: %d · 📌 Open: %d · ⚠️ Overdue: %d\n",
+	"\nЗа неделю задач не было — самое время запланировать следующую: /newtask":                                    "\nNo tasks this week — a good time to plan the next one: /newtask",
+	"\n<b>По категориям</b>\n": "\n<b>By category</b>\n",
+
+	// bot/workspace.go
+	"Команды пространств:\n• /workspace create &lt;название&gt; — создать общее пространство\n• /workspace join &lt;код&gt; — вступить по коду приглашения\n• /workspace switch &lt;id&gt; — перейти в пространство\n• /workspace switch personal — вернуться к личным задачам\n• /workspace invite — показать код приглашения\n• /workspace members — участники и их роли\n• /workspace leave — покинуть пространство\n• /workspace digest — итоги недели по участникам (для владельца)\n• /workspace bind — (в группе) присылать туда отчёт пространства": "Workspace commands:\n• /workspace create &lt;name&gt; — create a shared workspace\n• /workspace join &lt;code&gt; — join with an invite code\n• /workspace switch &lt;id&gt; — go to a workspace\n• /workspace switch personal — back to personal tasks\n• /workspace invite — show the invite code\n• /workspace members — members and their roles\n• /workspace leave — leave the workspace\n• /workspace digest — weekly summary by member (for the owner)\n• /workspace bind — (in a group) send the workspace report there",
+	"Не удалось получить пространства: %s":    "Could not get the workspaces: %s",
+	"🏠 <b>Пространства</b>\n":                 "🏠 <b>Workspaces</b>\n",
+	"%s Личные задачи\n":                      "%s Personal tasks\n",
+	"Укажи название: /workspace create Семья": "Give a name: /workspace create Family",
+	"Не удалось создать пространство: %s":     "Could not create the workspace: %s",
+	"🏠 Пространство «%s» создано и выбрано.\nКод приглашения: <code>%s</code> — участники вступают командой <code>/workspace join %s</code>.": "🏠 Workspace “%s” created and selected.\nInvite code: <code>%s</code> — members join with <code>/workspace join %s</code>.",
+	"Укажи код приглашения: /workspace join ABCD2345":           "Give the invite code: /workspace join ABCD2345",
+	"Пространство с таким кодом не найдено.":                    "No workspace with this code.",
+	"Ты уже участник «%s». Переключиться: /workspace switch %d": "You are already a member of “%s”. Switch: /workspace switch %d",
+	"Не удалось вступить: %s":                                   "Could not join: %s",
+	"✅ Готово, теперь ты в «%s». Новые задачи и списки теперь относятся к этому пространству.": "✅ Done, you are in “%s” now. New tasks and lists now belong to this workspace.",
+	"👤 Теперь ты работаешь с личными задачами.":                                                "👤 You are working with personal tasks now.",
+	"Укажи номер пространства из /workspace или «personal».":                                   "Give the workspace number from /workspace or “personal”.",
+	"Пространство не найдено среди твоих.":                                                     "The workspace is not among yours.",
+	"Не удалось переключиться: %s":                                                             "Could not switch: %s",
+	"🏠 Активное пространство: «%s».":                                                           "🏠 Active workspace: “%s”.",
+	"Код приглашения в «%s»: <code>%s</code>":                                                  "Invite code to “%s”: <code>%s</code>",
+	"Не удалось получить участников: %s":                                                       "Could not get the members: %s",
+	"👥 <b>Участники «%s»</b>\n":                                                                "👥 <b>Members of “%s”</b>\n",
+	"\n• /workspace role &lt;id&gt; editor|viewer — сменить роль\n":                            "\n• /workspace role &lt;id&gt; editor|viewer — change the role\n",
+	"• /workspace remove &lt;id&gt; — исключить участника":                                     "• /workspace remove &lt;id&gt; — remove a member",
+	"Формат: /workspace role &lt;id&gt; editor|viewer":                                         "Format: /workspace role &lt;id&gt; editor|viewer",
+	"Роль может быть только editor или viewer.":                                                "The role can only be editor or viewer.",
+	"Не удалось сменить роль: %s":                                                              "Could not change the role: %s",
+	"✅ Роль участника %d: %s.":                                                                 "✅ Role of member %d: %s.",
+	"Формат: /workspace remove &lt;id&gt;":                                                     "Format: /workspace remove &lt;id&gt;",
+	"Такого участника нет в пространстве.":                                                     "There is no such member in the workspace.",
+	"Не удалось исключить участника: %s":                                                       "Could not remove the member: %s",
+	"🚪 Участник %d исключён.":                                                                  "🚪 Member %d removed.",
+	"Ты сейчас в личных задачах — выходить неоткуда.":                                          "You are in personal tasks — there is nothing to leave.",
+	"Владелец не может покинуть своё пространство.":                                            "The owner cannot leave their own workspace.",
+	"Не удалось выйти: %s":                                                                     "Could not leave: %s",
+	"🚪 Ты покинул(а) «%s». Снова открыты личные задачи.":                                       "🚪 You left “%s”. Personal tasks are open again.",
+	"👑 владелец":    "👑 owner",
+	"👀 наблюдатель": "👀 viewer",
+	"✏️ редактор":   "✏️ editor",
+	"🏠 Сюда будут приходить отчёты пространства «%s».":                                                      "🏠 Reports of the workspace “%s” will come here.",
+	"🔕 Отчёты пространства «%s» больше не публикуются в группе.":                                            "🔕 Reports of the workspace “%s” are no longer posted in the group.",
+	"В группе доступны /workspace bind и /workspace unbind — их выполняет владелец активного пространства.": "In a group, /workspace bind and /workspace unbind are available — the owner of the active workspace runs them.",
+	"Сначала выбери пространство в личном чате с ботом: /workspace switch &lt;id&gt;":                       "First pick a workspace in the private chat with the bot: /workspace switch &lt;id&gt;",
+	"Привязать группу может только владелец пространства.":                                                  "Only the workspace owner can bind a group.",
+	"Сначала выбери пространство: /workspace switch &lt;id&gt;":                                             "First pick a workspace: /workspace switch &lt;id&gt;",
+	"Итоги недели доступны только владельцу пространства.":                                                  "The weekly summary is available to the workspace owner only.",
+	"Ошибка: %s": "Error: %s",
+	"Не удалось собрать итоги: %s": "Could not build the summary: %s",
+
+	// service/contact_service.go
+	"🎂 <b>Дни рождения на этой неделе</b>": "🎂 <b>Birthdays this week</b>",
+	"%s, исполнится %d":                    "%s, turns %d",
+	"(с %d г.)":                            "(since %d)",
+	"🎂 День рождения: %s":                  "🎂 Birthday: %s",
+
+	// service/digest.go
+	"%d открыто":    "%d open",
+	"%d просрочено": "%d overdue",
+	"— в пространстве пока нет задач": "— no tasks in the workspace yet",
+	"📊 <b>Итоги недели · %s</b>":      "📊 <b>Weekly summary · %s</b>",
+	"бывший участник":                 "former member",
+
+	// service/reminder_service.go
+	"✍️ автор: %s": "✍️ added by %s",
+	"⚠️ Пропущен повтор %s — отметь задачу, когда сделаешь": "⚠️ Missed repeat %s — mark the task when you do it",
+	"📋 <b>Ежедневный отчёт</b>":                             "📋 <b>Daily report</b>",
+	"🏠 <b>Отчёт пространства «%s»</b>":                      "🏠 <b>Report of the workspace “%s”</b>",
+	"📋 <b>Отчёт · %s</b>":                                   "📋 <b>Report · %s</b>",
+	"🔥 <b>Текущие задачи</b>":                               "🔥 <b>Current tasks</b>",
+	"— нет открытых задач":                                  "— no open tasks",
+	"♻️ <b>Регулярные задачи</b>":                           "♻️ <b>Recurring tasks</b>",
+	"— нет задач в окне выполнения":                         "— no tasks in their window",
+	"📈 <b>Счётчики на сегодня</b>":                          "📈 <b>Today's counters</b>",
+	"⏰ до %s — <b>просрочено</b>":                           "⏰ due %s — <b>overdue</b>",
+	"⏰ до %s · осталось ≈%d дн.":                            "⏰ due %s · ≈%d days left",
+	"📆 Ближайшая дата: %s (окно ±%d дн.)":                   "📆 Next date: %s (window ±%d days)",
+	"✅ Последнее выполнение: %s":                            "✅ Last completed: %s",
+	"✅ Пока не выполнялась":                                 "✅ Not completed yet",
+
+	// service/reminder_text.go
+	"ещё не выполнялась": "not completed yet",
+
+	// service/task_import.go
+	"нет названия":                             "no title",
+	"неизвестный приоритет «%s»":               "unknown priority “%s”",
+	"не понял срок «%s»":                       "could not read the deadline “%s”",
+	"не понял дату выполнения «%s»":            "could not read the completion date “%s”",
+	"не понял дату архивации «%s»":             "could not read the archive date “%s”",
+	"не понял число «%s» в правиле повторения": "could not read the number “%s” of the recurrence",
+	"у ежемесячной задачи нет дня месяца":      "a monthly task has no day of month",
+	"не понял день недели «%s»":                "could not read the weekday “%s”",
+	"у еженедельной задачи нет дней недели":    "a weekly task has no weekdays",
+	"неизвестный тип повторения «%s»":          "unknown recurrence “%s”",
+	"не понял дату окончания повторов «%s»":    "could not read the end of the recurrence “%s”",
+	"неизвестный статус «%s»":                  "unknown status “%s”",
+	"не удалось прочитать: %s":                 "could not read it: %s",
+
+	// service/snooze.go
+	"Через 3 часа":        "In 3 hours",
+	"Вечером":             "Tonight",
+	"Завтра утром":        "Tomorrow morning",
+	"На следующей неделе": "Next week",
+	"В день срока":        "On the due day",
+}
@@ -0,0 +1,170 @@
+// Package i18n translates the bot's messages. Russian is the source language: every
+// message is written in Russian in the code and doubles as its own key in the catalogs
+// of the other languages, so a missing translation falls back to the original text.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lang is a language the bot speaks.
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+)
+
+// Default is the language of users whose Telegram client did not tell theirs.
+const Default = RU
+
+// Supported lists the languages in the order /language offers them.
+var Supported = []Lang{RU, EN}
+
+// catalogs maps a Russian message to its translation, per language.
+var catalogs = map[Lang]map[string]string{
+	EN: en,
+}
+
+// russianLike are the Telegram language codes of users who most likely read Russian.
+var russianLike = map[string]bool{"ru": true, "uk": true, "be": true, "kk": true, "uz": true}
+
+// Parse reads a language chosen by the user: "en", "English", "ru", "русский".
+func Parse(value string) (Lang, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "ru", "rus", "russian", "русский", "рус":
+		return RU, true
+	case "en", "eng", "english", "английский", "англ":
+		return EN, true
+	}
+	return "", false
+}
+
+// Resolve picks the language of a user: the one they chose, otherwise the one of their
+// Telegram client. Clients in languages the bot does not speak get English, except
+// those of Russian readers.
+func Resolve(chosen, telegramCode string) Lang {
+	if lang, ok := Parse(chosen); ok {
+		return lang
+	}
+	code, _, _ := strings.Cut(strings.ToLower(telegramCode), "-")
+	if code == "" || russianLike[code] {
+		return Default
+	}
+	return EN
+}
+
+// Name is how the language calls itself.
+func (l Lang) Name() string {
+	if l == EN {
+		return "English"
+	}
+	return "Русский"
+}
+
+// T translates the message.
+func (l Lang) T(msg string) string {
+	if translated, ok := catalogs[l][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf translates the format and fills it in like fmt.Sprintf.
+func (l Lang) Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(l.T(format), args...)
+}
+
+// Pair shows a message in two languages at once, for a report read by a family that does
+// not share one. Without a second language, or with the same one twice, it is just First.
+type Pair struct {
+	First, Second Lang
+}
+
+// T translates msg into both languages and joins the translations.
+func (p Pair) T(msg string) string {
+	if p.Second == "" || p.Second == p.First {
+		return p.First.T(msg)
+	}
+	return Join(p.First.T(msg), p.Second.T(msg))
+}
+
+// Tf is T for a format filled in like fmt.Sprintf.
+func (p Pair) Tf(format string, args ...interface{}) string {
+	if p.Second == "" || p.Second == p.First {
+		return p.First.Tf(format, args...)
+	}
+	return Join(p.First.Tf(format, args...), p.Second.Tf(format, args...))
+}
+
+// Join puts two translations of a message side by side, keeping what they start and end
+// with in common once: "🔥 <b>Текущие задачи</b>" and "🔥 <b>Current tasks</b>" make
+// "🔥 <b>Текущие задачи / Current tasks</b>". The common parts end at a space or a tag,
+// so words and markup are never cut.
+func Join(first, second string) string {
+	if first == second {
+		return first
+	}
+	prefix := 0
+	for i := 0; i < len(first) && i < len(second); {
+		r, size := utf8.DecodeRuneInString(first[i:])
+		if other, _ := utf8.DecodeRuneInString(second[i:]); r != other {
+			break
+		}
+		i += size
+		if unicode.IsSpace(r) || r == '>' {
+			prefix = i
+		}
+	}
+	suffix := 0
+	for i := 0; i < len(first)-prefix && i < len(second)-prefix; {
+		r, size := utf8.DecodeLastRuneInString(first[:len(first)-i])
+		if other, _ := utf8.DecodeLastRuneInString(second[:len(second)-i]); r != other {
+			break
+		}
+		i += size
+		if unicode.IsSpace(r) || r == '<' {
+			suffix = i
+		}
+	}
+	return first[:len(first)-suffix] + " / " + second[prefix:len(second)-suffix] + first[len(first)-suffix:]
+}
+
+// N marks msg for translation where it is stored untranslated, to be translated
+// with T when shown.
+func N(msg string) string {
+	return msg
+}
+
+// Matches reports whether text is msg in any supported language, ignoring case. Reply
+// keyboard buttons come back as text, so they are recognized with it.
+func Matches(text, msg string) bool {
+	if strings.EqualFold(text, msg) {
+		return true
+	}
+	for _, catalog := range catalogs {
+		if translated, ok := catalog[msg]; ok && strings.EqualFold(text, translated) {
+			return true
+		}
+	}
+	return false
+}
+
+type ctxKey struct{}
+
+// WithLang returns a context whose messages are in lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// FromContext returns the language of ctx, Default if none was set.
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(ctxKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
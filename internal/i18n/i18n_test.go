@@ -0,0 +1,21 @@
+package i18n
+
+import "testing"
+
+func TestJoin(t *testing.T) {
+	cases := []struct{ first, second, want string }{
+		{"🔥 <b>Текущие задачи</b>", "🔥 <b>Current tasks</b>", "🔥 <b>Текущие задачи / Current tasks</b>"},
+		{"🏠 <b>Отчёт пространства «Дом»</b>", "🏠 <b>Report of the workspace “Дом”</b>", "🏠 <b>Отчёт пространства «Дом» / Report of the workspace “Дом”</b>"},
+		{"— нет открытых задач", "— no open tasks", "— нет открытых задач / no open tasks"},
+		{"Задачи", "Tasks", "Задачи / Tasks"},
+		{"Same", "Same", "Same"},
+	}
+	for _, c := range cases {
+		if got := Join(c.first, c.second); got != c.want {
+			t.Errorf("Join(%q, %q) = %q, want %q", c.first, c.second, got, c.want)
+		}
+	}
+	if got := (Pair{First: EN}).T("📋 <b>Ежедневный отчёт</b>"); got != "📋 <b>Daily report</b>" {
+		t.Errorf("Pair without a second language = %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+// Package importer converts external calendar and task formats into planner data.
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a single VEVENT from an iCalendar file.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	AllDay      bool
+}
+
+// ParseICS reads VEVENT entries from an iCalendar (RFC 5545) stream.
+// Only the fields the planner needs are extracted; everything else is ignored.
+func ParseICS(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+		hasDate bool
+	)
+	for _, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+			hasDate = false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current != nil && hasDate {
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "DTSTART":
+			start, allDay, err := parseDateTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: %w", current.UID, err)
+			}
+			current.Start = start
+			current.AllDay = allDay
+			hasDate = true
+		}
+	}
+	return events, nil
+}
+
+// unfoldLines joins continuation lines (starting with a space or tab) to their predecessor.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ics: %w", err)
+	}
+	return lines, nil
+}
+
+// splitProperty parses "NAME;PARAM=VALUE:content" into its parts.
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		key, val, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+func parseDateTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	loc := time.UTC
+	if strings.HasSuffix(value, "Z") {
+		value = strings.TrimSuffix(value, "Z")
+	} else if tz := params["TZID"]; tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var textUnescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeText(value string) string {
+	return strings.TrimSpace(textUnescaper.Replace(value))
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// AccessRequest is an unknown user asking to use a private instance; an admin lets them in
+// with /approve.
+type AccessRequest struct {
+	TelegramID   int64 `gorm:"primaryKey;autoIncrement:false"`
+	FirstName    string
+	LastName     string
+	Username     string
+	LanguageCode string
+	CreatedAt    time.Time
+}
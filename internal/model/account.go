@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Account groups one or more Telegram users that share the same planner data.
+type Account struct {
+	ID            uint `gorm:"primaryKey"`
+	PrimaryUserID uint `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// LinkCode is a one-time code that attaches another Telegram user to an account.
+type LinkCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	Code      string `gorm:"uniqueIndex"`
+	AccountID uint   `gorm:"index"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Attachment kinds stored in Attachment.Kind.
+const (
+	AttachmentPhoto    = "photo"
+	AttachmentVideo    = "video"
+	AttachmentDocument = "document"
+)
+
+// Attachment is a file sent while describing a task. Only its Telegram file ID is kept:
+// the file itself stays with Telegram, which lets the bot send it again by that ID.
+type Attachment struct {
+	ID           uint   `gorm:"primaryKey"`
+	TaskID       uint   `gorm:"index"`
+	Kind         string // AttachmentPhoto, AttachmentVideo or AttachmentDocument
+	FileID       string
+	FileName     string // original name of a document
+	MediaGroupID string // album the file came in, empty for a single file
+	CreatedAt    time.Time
+}
@@ -1,13 +1,52 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Category groups tasks by area (work, health, study, etc.).
 type Category struct {
-	ID        uint   `gorm:"primaryKey"`
-	UserID    uint   `gorm:"index"`
-	Name      string `gorm:"index:idx_user_category_name,unique"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Tasks     []Task `gorm:"foreignKey:CategoryID"`
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"index;index:idx_category_scope_name,unique"`
+	WorkspaceID uint   `gorm:"default:0;index:idx_category_scope_name,unique"`
+	Name        string `gorm:"index:idx_category_scope_name,unique"`
+	Emoji       string // shown before the name, see DefaultCategoryEmoji
+	Color       string // optional mark, one of CategoryColors
+	RouteChatID int64  `gorm:"default:0"` // chat that receives this category's reminders, 0 for the default
+	// Defaults applied to new tasks of the category; zero means none.
+	DefaultDeadlineDays int        // deadline this many days after creation
+	DefaultAlertHours   int        // deadline alert this many hours ahead
+	ArchivedAt          *time.Time // hidden from lists and keyboards; its tasks keep it for history
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Tasks               []Task `gorm:"foreignKey:CategoryID"`
+}
+
+// CategoryColors are the colors a category can be marked with.
+var CategoryColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "brown", "black", "white"}
+
+// CategoryEmojiFallback is the emoji of categories that have none of their own.
+const CategoryEmojiFallback = "🏷️"
+
+// defaultCategoryEmoji gives the suggested categories their emoji on creation.
+var defaultCategoryEmoji = map[string]string{
+	"учеба":    "🎓",
+	"работа":   "💼",
+	"покупки":  "🛒",
+	"здоровье": "🩺",
+	"личное":   "🧩",
+	"study":    "🎓",
+	"work":     "💼",
+	"shopping": "🛒",
+	"health":   "🩺",
+	"personal": "🧩",
+}
+
+// DefaultCategoryEmoji picks the emoji a new category starts with.
+func DefaultCategoryEmoji(name string) string {
+	if emoji, ok := defaultCategoryEmoji[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return emoji
+	}
+	return CategoryEmojiFallback
 }
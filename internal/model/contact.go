@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Contact is a person with a yearly date worth remembering: a birthday
+// or another occasion such as a wedding anniversary.
+type Contact struct {
+	ID           uint `gorm:"primaryKey"`
+	UserID       uint `gorm:"index"`
+	Name         string
+	Occasion     string // empty for a birthday
+	Month        int
+	Day          int
+	Year         int        // year of birth or of the event, 0 if unknown
+	TaskID       *uint      // reminder task of the upcoming occurrence
+	ReminderDate *time.Time // occurrence the reminder task was created for
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsBirthday reports whether the contact's date is a birthday.
+func (c Contact) IsBirthday() bool {
+	return c.Occasion == ""
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Kinds of saved conversations.
+const (
+	ConversationDialog       = "dialog"       // a step-by-step dialog such as /newtask
+	ConversationConfirmation = "confirmation" // a yes/no question about a task
+)
+
+// Conversation is a dialog a user is in the middle of, saved so a restart does not drop it.
+// Payload is its state as JSON, in whatever form the bot keeps it.
+type Conversation struct {
+	TelegramID int64  `gorm:"primaryKey;autoIncrement:false"`
+	Kind       string `gorm:"primaryKey"`
+	Payload    string
+	UpdatedAt  time.Time
+}
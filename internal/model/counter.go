@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Counter is a habit measured by a daily count, e.g. glasses of water.
+type Counter struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"index"`
+	Name      string
+	Goal      int // daily target
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CounterEntry is one increment of a counter; the daily value is the sum of the day's entries.
+type CounterEntry struct {
+	ID        uint `gorm:"primaryKey"`
+	CounterID uint `gorm:"index"`
+	UserID    uint `gorm:"index"`
+	Amount    int
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Custom field types stored in CustomField.Type.
+const (
+	FieldText   = "text"
+	FieldNumber = "number"
+	FieldDate   = "date"
+)
+
+// CustomField is an attribute the user defined for their tasks, e.g. «клиент» or «сумма».
+type CustomField struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"index;index:idx_field_scope_name,unique"`
+	WorkspaceID uint   `gorm:"default:0;index:idx_field_scope_name,unique"`
+	Name        string `gorm:"index:idx_field_scope_name,unique"`
+	Type        string // FieldText, FieldNumber or FieldDate
+	CreatedAt   time.Time
+}
+
+// FieldValue is the value of a custom field on one task.
+type FieldValue struct {
+	ID        uint   `gorm:"primaryKey"`
+	TaskID    uint   `gorm:"index:idx_field_value_task,unique"`
+	FieldID   uint   `gorm:"index:idx_field_value_task,unique;index"`
+	Value     string // numbers with a dot, dates as 2006-01-02
+	UpdatedAt time.Time
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Dose statuses.
+const (
+	DosePending = "pending"
+	DoseTaken   = "taken"
+	DoseMissed  = "missed"
+)
+
+// Medication is a medicine or supplement taken at fixed times every day.
+type Medication struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"index"`
+	Name      string
+	Times     string // comma-separated HH:MM slots, e.g. "09:00,21:00"
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Slots returns the daily intake times as HH:MM strings.
+func (m Medication) Slots() []string {
+	if m.Times == "" {
+		return nil
+	}
+	return strings.Split(m.Times, ",")
+}
+
+// Dose is one scheduled intake of a medication and whether it was taken.
+type Dose struct {
+	ID           uint      `gorm:"primaryKey"`
+	MedicationID uint      `gorm:"uniqueIndex:idx_dose_slot"`
+	UserID       uint      `gorm:"index"`
+	ScheduledAt  time.Time `gorm:"uniqueIndex:idx_dose_slot"`
+	Status       string    `gorm:"default:pending;index"`
+	AnsweredAt   *time.Time
+	CreatedAt    time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// TaskMerge records a duplicate folded into the task that was kept, with what undoing it
+// needs: the fields of the kept task the merge changed and the rows it moved over.
+type TaskMerge struct {
+	ID            uint `gorm:"primaryKey"`
+	UserID        uint `gorm:"index"`
+	WorkspaceID   uint
+	TaskID        uint       `gorm:"index"` // the task that was kept
+	DuplicateID   uint       `gorm:"index"` // the task merged into it, in the trash since
+	Description   string     // description of the kept task before the merge
+	Deadline      *time.Time // deadline of the kept task before the merge
+	Postponements int        // postponements of the kept task before the merge
+	SubtaskIDs    string     // moved subtasks, reminders, attachments and field values, IDs separated by commas
+	ReminderIDs   string
+	AttachmentIDs string
+	FieldValueIDs string
+	ReferrerIDs   string // tasks whose mentions of the duplicate now point at the kept task
+	CreatedAt     time.Time
+}
@@ -0,0 +1,8 @@
+package model
+
+// TaskReference records that the description of TaskID mentions RefID as #RefID. The rows
+// are the index behind the "mentioned in" line of a task card.
+type TaskReference struct {
+	TaskID uint `gorm:"primaryKey;autoIncrement:false"`
+	RefID  uint `gorm:"primaryKey;autoIncrement:false;index"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Reminder is a one-off notification about a task at a moment the user picked.
+type Reminder struct {
+	ID          uint      `gorm:"primaryKey"`
+	UserID      uint      `gorm:"index"` // who asked for it and receives it
+	WorkspaceID uint      // scope of the task when the reminder was set
+	TaskID      uint      `gorm:"index"`
+	At          time.Time `gorm:"index"`
+	SentAt      *time.Time
+	CreatedAt   time.Time
+}
+
+// Scope returns the scope the reminder's task lives in.
+func (r Reminder) Scope() Scope {
+	return Scope{UserID: r.UserID, WorkspaceID: r.WorkspaceID}
+}
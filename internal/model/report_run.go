@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ReportRun is a report run in progress. It lets a run interrupted by a restart pick up
+// after the last user it reached instead of starting over; finished runs are deleted.
+type ReportRun struct {
+	ID         uint      `gorm:"primaryKey"`
+	DueAt      time.Time // users due at this moment belong to the run
+	LastUserID uint      // users are handled in ID order, up to and including this one
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+// Scope selects whose tasks and categories a query works with:
+// the personal data of a user or a shared workspace.
+type Scope struct {
+	UserID      uint
+	WorkspaceID uint
+}
+
+// PersonalScope returns the scope of the user's own tasks.
+func PersonalScope(userID uint) Scope {
+	return Scope{UserID: userID}
+}
+
+// IsWorkspace reports whether the scope points to a shared workspace.
+func (s Scope) IsWorkspace() bool {
+	return s.WorkspaceID != 0
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CalendarSubscription is an ICS feed that is periodically imported as tasks.
+type CalendarSubscription struct {
+	ID           uint `gorm:"primaryKey"`
+	UserID       uint `gorm:"index"`
+	WorkspaceID  uint `gorm:"default:0"`
+	URL          string
+	LastSyncedAt *time.Time
+	LastError    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Scope returns the data scope imported tasks are stored in.
+func (s CalendarSubscription) Scope() Scope {
+	return Scope{UserID: s.UserID, WorkspaceID: s.WorkspaceID}
+}
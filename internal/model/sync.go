@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// SyncState remembers how far task synchronisation with a peer instance got.
+type SyncState struct {
+	ID          uint      `gorm:"primaryKey"`
+	PeerURL     string    `gorm:"uniqueIndex"`
+	RemoteSince time.Time // peer's clock: its changes after this were not received yet
+	LocalSince  time.Time // our clock: our changes after this were not sent yet
+	UpdatedAt   time.Time
+}
@@ -1,21 +1,114 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Recurrence types stored in Task.RecurType.
+const (
+	RecurDaily   = "daily"
+	RecurWeekly  = "weekly"
+	RecurMonthly = "monthly"
+)
+
+// Task priorities stored in Task.Priority; an empty value counts as PriorityNormal.
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Priorities lists the priorities from the lowest to the highest.
+var Priorities = []string{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+// PriorityRank orders priorities, higher is more important; unknown values rank as normal.
+func PriorityRank(priority string) int {
+	for rank, known := range Priorities {
+		if priority == known {
+			return rank
+		}
+	}
+	return 1
+}
 
 // Task represents a single item in the planner.
 type Task struct {
-	ID              uint  `gorm:"primaryKey"`
-	UserID          uint  `gorm:"index"`
-	CategoryID      *uint `gorm:"index"`
-	Title           string
-	Description     string
-	Deadline        *time.Time
-	IsCompleted     bool   `gorm:"default:false"`
-	IsRecurring     bool   `gorm:"default:false"`
-	RecurType       string // e.g. monthly
-	RecurDay        int
-	RecurWindow     int
-	LastCompletedAt *time.Time
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID               uint  `gorm:"primaryKey"`
+	UserID           uint  `gorm:"index"`
+	WorkspaceID      uint  `gorm:"default:0;index"`
+	CategoryID       *uint `gorm:"index"`
+	Title            string
+	Description      string
+	Deadline         *time.Time
+	Priority         string // PriorityLow, PriorityNormal, PriorityHigh or PriorityUrgent
+	IsCompleted      bool   `gorm:"default:false"`
+	IsRecurring      bool   `gorm:"default:false"`
+	RecurType        string // RecurDaily, RecurWeekly or RecurMonthly
+	RecurDay         int    // day of month for monthly tasks
+	RecurWeekday     int    // time.Weekday for weekly tasks, the first of RecurWeekdays when those are set
+	RecurWeekdays    int    // weekly tasks due on several days: bit 1<<time.Weekday for each, 0 for RecurWeekday alone
+	RecurInterval    int    // daily tasks repeat every that many days, counted from the creation day; monthly ones every that many months, counted from RecurFrom
+	RecurWindow      int    // days around the due date the task may be done in
+	ReminderText     string // template shown in reports for recurring tasks, e.g. "осталось {days_left} дн."
+	LastCompletedAt  *time.Time
+	RecurFrom        *time.Time // first completion of a task repeating every few months; nil until then
+	CatchUp          bool       // a missed occurrence stays in reports as overdue until done late instead of being dropped
+	CaughtUpTo       *time.Time // due date of the last missed occurrence done late
+	RecurEndedAt     *time.Time // recurring task stopped repeating; kept for its history
+	ExternalUID      string     `gorm:"index"` // UID of the imported calendar event
+	AlertBeforeHours int        // deadline alert lead time, 0 for the default day
+	AlertedAt        *time.Time // last deadline alert
+	SnoozedUntil     *time.Time // deadline alert postponed until then
+	PostponeCount    int        // how many times the alert was snoozed since the task was last reviewed
+	Postponements    int        // how many times the deadline was moved later, never reset
+	TouchedAt        *time.Time // last time the owner acted on the task; nil means never since creation
+	NudgedAt         *time.Time // last procrastination nudge
+	ArchivedAt       *time.Time `gorm:"index"` // archived tasks are hidden from lists and reports
+	SyncUID          string     `gorm:"index"` // identifies the task across synced instances, set on first sync
+	ParentID         *uint      `gorm:"index"` // task this one was added to as a subtask
+	Escalate         bool       // the owner's accountability partner hears when the task stays overdue
+	EscalatedFor     *time.Time // deadline the partner was last told about
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"` // deleted tasks stay in the trash until purged
+}
+
+// RecurMonths is the number of months between occurrences of a monthly task repeating
+// every few months, such as quarterly; 0 for every other task.
+func (t Task) RecurMonths() int {
+	if t.RecurType != RecurMonthly || t.RecurInterval < 2 {
+		return 0
+	}
+	return t.RecurInterval
+}
+
+// Weekdays lists the days a weekly task is due on, Monday first.
+func (t Task) Weekdays() []time.Weekday {
+	if t.RecurWeekdays == 0 {
+		return []time.Weekday{time.Weekday(t.RecurWeekday % 7)}
+	}
+	return WeekdaysOf(t.RecurWeekdays)
+}
+
+// WeekdaySet is the bit set of the days, as stored in Task.RecurWeekdays.
+func WeekdaySet(days ...time.Weekday) int {
+	set := 0
+	for _, day := range days {
+		set |= 1 << (day % 7)
+	}
+	return set
+}
+
+// WeekdaysOf lists the days of a bit set, Monday first.
+func WeekdaysOf(set int) []time.Weekday {
+	var days []time.Weekday
+	for i := 1; i <= 7; i++ {
+		if set&(1<<(i%7)) != 0 {
+			days = append(days, time.Weekday(i%7))
+		}
+	}
+	return days
 }
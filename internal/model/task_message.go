@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// TaskMessage remembers which task a message sent by the bot shows,
+// so reactions to that message can act on the task.
+type TaskMessage struct {
+	ID          uint  `gorm:"primaryKey"`
+	ChatID      int64 `gorm:"uniqueIndex:idx_task_message_chat"`
+	MessageID   int   `gorm:"uniqueIndex:idx_task_message_chat"`
+	TaskID      uint  `gorm:"index"`
+	WorkspaceID uint  `gorm:"default:0"`
+	// Countdown is CountdownAlert or CountdownReminder while the message shows the time
+	// left to the task's deadline and is redrawn as it runs out; empty otherwise.
+	Countdown string `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// Kinds of messages whose deadline countdown is kept up to date.
+const (
+	CountdownAlert    = "alert"    // deadline alert
+	CountdownReminder = "reminder" // reminder set with /remind
+)
+
+// TransientMessage is a bot message, such as a confirmation prompt or a "done" notice,
+// due to be deleted at DeleteAt so it does not clutter the chat.
+type TransientMessage struct {
+	ID        uint      `gorm:"primaryKey"`
+	ChatID    int64     `gorm:"uniqueIndex:idx_transient_message_chat"`
+	MessageID int       `gorm:"uniqueIndex:idx_transient_message_chat"`
+	DeleteAt  time.Time `gorm:"index"`
+}
+
+// ListMessage remembers the last task list sent to a chat and what it shows,
+// so the list can be redrawn in place when its tasks change elsewhere.
+type ListMessage struct {
+	ID          uint  `gorm:"primaryKey"`
+	ChatID      int64 `gorm:"uniqueIndex"`
+	MessageID   int
+	UserID      uint   `gorm:"index"` // user whose tasks the list shows
+	WorkspaceID uint   `gorm:"index;default:0"`
+	View        string // which tasks are listed, see the bot's listView
+	Page        int
+	Dashboard   bool // the list is the chat's pinned dashboard, kept as the one list message
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Backlog triage decisions.
+const (
+	TriageKeep    = "keep"
+	TriageDelete  = "delete"
+	TriageArchive = "archive"
+)
+
+// TriageItem is a stale task offered for review in a monthly backlog triage run.
+type TriageItem struct {
+	ID        uint  `gorm:"primaryKey"`
+	UserID    uint  `gorm:"index:idx_triage_run"`
+	Run       int64 `gorm:"index:idx_triage_run"` // unix time the run started; groups its items
+	TaskID    uint
+	Title     string    // kept for the run message after the task is deleted
+	IdleSince time.Time // last time the task was touched when the run started
+	Decision  string    // empty until answered
+	DecidedAt *time.Time
+}
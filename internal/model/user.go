@@ -1,14 +1,94 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"daily-planner/internal/i18n"
+)
 
 // User stores Telegram user metadata.
 type User struct {
-	ID         uint  `gorm:"primaryKey"`
-	TelegramID int64 `gorm:"uniqueIndex"`
-	FirstName  string
-	LastName   string
-	Username   string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID                 uint  `gorm:"primaryKey"`
+	TelegramID         int64 `gorm:"uniqueIndex"`
+	AccountID          uint  `gorm:"index"`
+	FirstName          string
+	LastName           string
+	Username           string
+	ActiveWorkspaceID  uint `gorm:"default:0"` // 0 means personal tasks
+	QuotaExempt        bool `gorm:"default:false"`
+	Verified           bool `gorm:"default:false"` // passed the anti-spam check
+	LastActiveAt       *time.Time
+	RetentionPromptAt  *time.Time // asked whether they still want reports
+	ArchivedAt         *time.Time // no reports until the user writes again
+	ReportEveryHours   int        // personal report interval, 0 uses REPORT_INTERVAL_HOURS
+	NextReportAt       *time.Time // when the next daily report is due, nil means right away
+	ReportDelivery     string     // how reports arrive: ReportChat, ReportSilent or ReportPinned
+	ReportMessageID    int        // the pinned message ReportPinned delivery keeps editing, 0 for none yet
+	Timezone           string     // IANA zone name, empty for the server's zone
+	WorkStartHour      int        // working hours, both zero for the default 9–18
+	WorkEndHour        int
+	QuickReplies       string     // "emoji=action" pairs, empty for the defaults, "-" for none
+	DeadlineCountdown  bool       // redraw reminders of deadlines due within the hour with the time left
+	HeatmapImage       bool       // /heatmap comes as a picture instead of emoji squares
+	ListSorts          string     // "view=order" pairs chosen under task lists, views missing here sort by priority
+	AutoDeleteMinutes  int        // transient bot messages are deleted after this many minutes, 0 keeps them
+	WeeklySummary      bool       // the weekly summary comes on Sunday evenings
+	OverdueGraceHours  int        // deadlines count as missed this many hours after the end of their day
+	DeadlineStartOfDay bool       // date-only deadlines count as missed when their day starts, not when it ends
+	BuddyID            uint       // accountability partner told about flagged overdue tasks, 0 for none
+	BuddyAccepted      bool       // the partner agreed to get those notifications
+	LinkFailures       int        // wrong /link codes entered since the last lockout or success
+	LinkLockedUntil    *time.Time // /link <code> is refused until then after too many wrong codes
+	LanguageCode       string     // language of the user's Telegram client
+	Language           string     // language chosen with /language, empty follows LanguageCode
+	SecondLanguage     string     // report headers are repeated in it, set with /language ru+en; empty for none
+	PlainText          bool       // messages come without emojis and formatting, for screen readers
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Ways the daily report can arrive.
+const (
+	ReportChat   = ""       // a new message with a notification
+	ReportSilent = "silent" // a new message without a sound
+	ReportPinned = "pinned" // edited into one pinned message
+)
+
+// Location returns the user's time zone, falling back to the server's one.
+func (u User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// Lang is the language the user reads the bot in.
+func (u User) Lang() i18n.Lang {
+	return i18n.Resolve(u.Language, u.LanguageCode)
+}
+
+// SecondLang is the language report headers are repeated in, empty for none.
+func (u User) SecondLang() i18n.Lang {
+	lang, _ := i18n.Parse(u.SecondLanguage)
+	return lang
+}
+
+// DeadlinePolicy decides when a deadline counts as missed.
+type DeadlinePolicy struct {
+	StartOfDay bool          // a date-only deadline is missed as soon as its day starts, not at its end
+	Grace      time.Duration // how long after that moment a task still is not overdue
+}
+
+// DeadlinePolicy is the user's choice of when their deadlines count as missed.
+func (u User) DeadlinePolicy() DeadlinePolicy {
+	return DeadlinePolicy{StartOfDay: u.DeadlineStartOfDay, Grace: time.Duration(u.OverdueGraceHours) * time.Hour}
+}
+
+// Scope returns the data scope the user currently works in.
+func (u User) Scope() Scope {
+	return Scope{UserID: u.ID, WorkspaceID: u.ActiveWorkspaceID}
 }
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Workspace roles, from most to least privileged.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// Workspace is a shared space (household, team) with common categories and tasks.
+type Workspace struct {
+	ID           uint `gorm:"primaryKey"`
+	Name         string
+	OwnerID      uint   `gorm:"index"`
+	InviteCode   string `gorm:"uniqueIndex"`
+	ReportChatID int64  `gorm:"default:0;index"` // group chat for workspace reports, 0 if unbound
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// WorkspaceMember links a user to a workspace with a role.
+type WorkspaceMember struct {
+	ID          uint   `gorm:"primaryKey"`
+	WorkspaceID uint   `gorm:"index:idx_workspace_member,unique"`
+	UserID      uint   `gorm:"index:idx_workspace_member,unique"`
+	Role        string `gorm:"default:editor"`
+	User        User   `gorm:"foreignKey:UserID"`
+	CreatedAt   time.Time
+}
+
+// CanEdit reports whether the member may create, complete, edit and delete shared tasks.
+func (m WorkspaceMember) CanEdit() bool {
+	return m.Role == RoleOwner || m.Role == RoleEditor
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"daily-planner/internal/model"
+)
+
+// AccessRequestRepository keeps the requests of users waiting to be let into a private instance.
+type AccessRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessRequestRepository(db *gorm.DB) *AccessRequestRepository {
+	return &AccessRequestRepository{db: db}
+}
+
+// Create stores the request unless the user already has one; created tells which happened.
+func (r *AccessRequestRepository) Create(ctx context.Context, request *model.AccessRequest) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(request)
+	if result.Error != nil {
+		return false, fmt.Errorf("create access request: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Find returns the request of the user, gorm.ErrRecordNotFound when there is none.
+func (r *AccessRequestRepository) Find(ctx context.Context, telegramID int64) (*model.AccessRequest, error) {
+	var request model.AccessRequest
+	if err := r.db.WithContext(ctx).Where("telegram_id = ?", telegramID).First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// List returns the pending requests, oldest first.
+func (r *AccessRequestRepository) List(ctx context.Context) ([]model.AccessRequest, error) {
+	var requests []model.AccessRequest
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("list access requests: %w", err)
+	}
+	return requests, nil
+}
+
+// Delete forgets the request of the user.
+func (r *AccessRequestRepository) Delete(ctx context.Context, telegramID int64) error {
+	if err := r.db.WithContext(ctx).Where("telegram_id = ?", telegramID).Delete(&model.AccessRequest{}).Error; err != nil {
+		return fmt.Errorf("delete access request: %w", err)
+	}
+	return nil
+}
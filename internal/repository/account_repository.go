@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ErrLinkCodeInvalid is returned when a link code is unknown or expired.
+var ErrLinkCodeInvalid = errors.New("link code is invalid or expired")
+
+// AccountRepository manages accounts and their link codes.
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id uint) (*model.Account, error) {
+	var account model.Account
+	if err := r.db.WithContext(ctx).First(&account, id).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateForUser creates a fresh account owned by the given user and attaches the user to it.
+func (r *AccountRepository) CreateForUser(ctx context.Context, user *model.User) (*model.Account, error) {
+	var account model.Account
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createAccountForUser(tx, user, &account)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// AttachUser moves the user into the given account.
+func (r *AccountRepository) AttachUser(ctx context.Context, user *model.User, accountID uint) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("account_id", accountID).Error; err != nil {
+		return fmt.Errorf("attach user to account: %w", err)
+	}
+	user.AccountID = accountID
+	return nil
+}
+
+// HasOwnData reports whether the user has personal tasks or categories, or is the primary
+// user of an account other users are linked to.
+func (r *AccountRepository) HasOwnData(ctx context.Context, user *model.User) (bool, error) {
+	db := r.db.WithContext(ctx)
+	scope := model.PersonalScope(user.ID)
+	for _, table := range []any{&model.Task{}, &model.Category{}} {
+		var count int64
+		if err := applyScope(db.Model(table), scope).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("count own data: %w", err)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	var linked int64
+	owned := db.Model(&model.Account{}).Select("id").Where("primary_user_id = ?", user.ID)
+	if err := db.Model(&model.User{}).Where("account_id IN (?) AND id <> ?", owned, user.ID).Count(&linked).Error; err != nil {
+		return false, fmt.Errorf("count linked users: %w", err)
+	}
+	return linked > 0, nil
+}
+
+func (r *AccountRepository) CreateLinkCode(ctx context.Context, code *model.LinkCode) error {
+	if err := r.db.WithContext(ctx).Create(code).Error; err != nil {
+		return fmt.Errorf("create link code: %w", err)
+	}
+	return nil
+}
+
+// ConsumeLinkCode looks up a still valid code and deletes it so it cannot be reused. An
+// expired code is deleted too and reported as invalid.
+func (r *AccountRepository) ConsumeLinkCode(ctx context.Context, code string, now time.Time) (*model.LinkCode, error) {
+	var link model.LinkCode
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("code = ?", code).First(&link).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLinkCodeInvalid
+		}
+		if err != nil {
+			return fmt.Errorf("find link code: %w", err)
+		}
+		if err := tx.Delete(&link).Error; err != nil {
+			return fmt.Errorf("delete link code: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if now.After(link.ExpiresAt) {
+		return nil, ErrLinkCodeInvalid
+	}
+	return &link, nil
+}
+
+func createAccountForUser(tx *gorm.DB, user *model.User, account *model.Account) error {
+	*account = model.Account{PrimaryUserID: user.ID}
+	if err := tx.Create(account).Error; err != nil {
+		return fmt.Errorf("create account: %w", err)
+	}
+	if err := tx.Model(user).Update("account_id", account.ID).Error; err != nil {
+		return fmt.Errorf("attach user to account: %w", err)
+	}
+	user.AccountID = account.ID
+	return nil
+}
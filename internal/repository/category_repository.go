@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -18,31 +19,44 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (r *CategoryRepository) GetOrCreate(ctx context.Context, userID uint, name string) (*model.Category, error) {
+func (r *CategoryRepository) GetOrCreate(ctx context.Context, scope model.Scope, name string) (*model.Category, error) {
+	category, _, err := getOrCreateCategory(r.db.WithContext(ctx), scope, name)
+	return category, err
+}
+
+// getOrCreateCategory is GetOrCreate on db, which may be a transaction; it also tells
+// whether the category was created.
+func getOrCreateCategory(db *gorm.DB, scope model.Scope, name string) (*model.Category, bool, error) {
 	if name == "" {
-		return nil, nil
+		return nil, false, nil
 	}
 
 	var category model.Category
-	db := r.db.WithContext(ctx)
-	err := db.Where("user_id = ? AND name = ?", userID, name).First(&category).Error
+	err := applyScope(db, scope).Where("name = ?", name).First(&category).Error
 	switch {
+	case err == nil && category.ArchivedAt != nil:
+		// Using an archived category's name brings it back.
+		if err := db.Model(&category).Update("archived_at", nil).Error; err != nil {
+			return nil, false, fmt.Errorf("archive category: %w", err)
+		}
+		category.ArchivedAt = nil
+		return &category, false, nil
 	case err == nil:
-		return &category, nil
+		return &category, false, nil
 	case err == gorm.ErrRecordNotFound:
-		category = model.Category{UserID: userID, Name: name}
+		category = model.Category{UserID: scope.UserID, WorkspaceID: scope.WorkspaceID, Name: name, Emoji: model.DefaultCategoryEmoji(name)}
 		if err := db.Create(&category).Error; err != nil {
-			return nil, fmt.Errorf("create category: %w", err)
+			return nil, false, fmt.Errorf("create category: %w", err)
 		}
-		return &category, nil
+		return &category, true, nil
 	default:
-		return nil, fmt.Errorf("find category: %w", err)
+		return nil, false, fmt.Errorf("find category: %w", err)
 	}
 }
 
-func (r *CategoryRepository) ListByUser(ctx context.Context, userID uint) ([]model.Category, error) {
+func (r *CategoryRepository) ListByScope(ctx context.Context, scope model.Scope) ([]model.Category, error) {
 	var categories []model.Category
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC").Find(&categories).Error; err != nil {
+	if err := applyScope(r.db.WithContext(ctx), scope).Order("name ASC").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
@@ -55,3 +69,118 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id uint) (*model.Categ
 	}
 	return &category, nil
 }
+
+// SetArchived archives the category at the given time or restores it when at is nil.
+func (r *CategoryRepository) SetArchived(ctx context.Context, category *model.Category, at *time.Time) error {
+	if err := r.db.WithContext(ctx).Model(category).Update("archived_at", at).Error; err != nil {
+		return fmt.Errorf("archive category: %w", err)
+	}
+	category.ArchivedAt = at
+	return nil
+}
+
+// FindByID looks up a category within the scope.
+func (r *CategoryRepository) FindByID(ctx context.Context, scope model.Scope, id uint) (*model.Category, error) {
+	var category model.Category
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CountActiveTasks counts open and still repeating tasks of the category.
+func (r *CategoryRepository) CountActiveTasks(ctx context.Context, categoryID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Task{}).
+		Where("category_id = ? AND (is_completed = ? OR is_recurring = ?) AND archived_at IS NULL AND recur_ended_at IS NULL", categoryID, false, true).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete removes the category in one transaction together with re-homing its tasks:
+// they move to target (nil for no category) and, with archiveAt set, the active ones are archived.
+// It returns the tasks that were active before the deletion.
+func (r *CategoryRepository) Delete(ctx context.Context, category *model.Category, target *uint, archiveAt *time.Time) ([]model.Task, error) {
+	var affected []model.Task
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ? AND (is_completed = ? OR is_recurring = ?) AND archived_at IS NULL AND recur_ended_at IS NULL", category.ID, false, true).
+			Order("id ASC").
+			Find(&affected).Error; err != nil {
+			return err
+		}
+		if archiveAt != nil && len(affected) > 0 {
+			ids := make([]uint, 0, len(affected))
+			for _, task := range affected {
+				ids = append(ids, task.ID)
+			}
+			if err := tx.Model(&model.Task{}).Where("id IN ?", ids).Update("archived_at", *archiveAt).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&model.Task{}).Where("category_id = ?", category.ID).Update("category_id", target).Error; err != nil {
+			return err
+		}
+		return tx.Delete(category).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete category: %w", err)
+	}
+	return affected, nil
+}
+
+// SetDefaults stores the settings applied to new tasks of the category.
+func (r *CategoryRepository) SetDefaults(ctx context.Context, category *model.Category, deadlineDays, alertHours int) error {
+	if err := r.db.WithContext(ctx).Model(category).Updates(map[string]interface{}{
+		"default_deadline_days": deadlineDays,
+		"default_alert_hours":   alertHours,
+	}).Error; err != nil {
+		return fmt.Errorf("set category defaults: %w", err)
+	}
+	category.DefaultDeadlineDays = deadlineDays
+	category.DefaultAlertHours = alertHours
+	return nil
+}
+
+// SetRoute sends the category's reminders to the given chat; 0 restores the default.
+func (r *CategoryRepository) SetRoute(ctx context.Context, category *model.Category, chatID int64) error {
+	if err := r.db.WithContext(ctx).Model(category).Update("route_chat_id", chatID).Error; err != nil {
+		return fmt.Errorf("set category route: %w", err)
+	}
+	category.RouteChatID = chatID
+	return nil
+}
+
+// Exists reports whether the scope already has a category with exactly this name.
+func (r *CategoryRepository) Exists(ctx context.Context, scope model.Scope, name string) (bool, error) {
+	var count int64
+	if err := applyScope(r.db.WithContext(ctx).Model(&model.Category{}), scope).
+		Where("name = ?", name).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CountByUser counts categories created by the user across all scopes.
+func (r *CategoryRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Category{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SetStyle stores the emoji and the color mark of the category.
+func (r *CategoryRepository) SetStyle(ctx context.Context, category *model.Category, emoji, color string) error {
+	if err := r.db.WithContext(ctx).Model(category).Updates(map[string]interface{}{
+		"emoji": emoji,
+		"color": color,
+	}).Error; err != nil {
+		return fmt.Errorf("set category style: %w", err)
+	}
+	category.Emoji = emoji
+	category.Color = color
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ContactRepository stores people with birthdays and other yearly dates.
+type ContactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepository(db *gorm.DB) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+func (r *ContactRepository) Create(ctx context.Context, contact *model.Contact) error {
+	if err := r.db.WithContext(ctx).Create(contact).Error; err != nil {
+		return fmt.Errorf("create contact: %w", err)
+	}
+	return nil
+}
+
+func (r *ContactRepository) Save(ctx context.Context, contact *model.Contact) error {
+	if err := r.db.WithContext(ctx).Save(contact).Error; err != nil {
+		return fmt.Errorf("save contact: %w", err)
+	}
+	return nil
+}
+
+func (r *ContactRepository) FindByID(ctx context.Context, userID, id uint) (*model.Contact, error) {
+	var contact model.Contact
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// ListByUser returns the user's contacts in calendar order.
+func (r *ContactRepository) ListByUser(ctx context.Context, userID uint) ([]model.Contact, error) {
+	var contacts []model.Contact
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("month ASC, day ASC, id ASC").Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (r *ContactRepository) ListAll(ctx context.Context) ([]model.Contact, error) {
+	var contacts []model.Contact
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (r *ContactRepository) Delete(ctx context.Context, contact *model.Contact) error {
+	if err := r.db.WithContext(ctx).Delete(contact).Error; err != nil {
+		return fmt.Errorf("delete contact: %w", err)
+	}
+	return nil
+}
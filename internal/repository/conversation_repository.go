@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"daily-planner/internal/model"
+)
+
+// ConversationRepository keeps the dialogs users are in the middle of.
+type ConversationRepository struct {
+	db *gorm.DB
+}
+
+func NewConversationRepository(db *gorm.DB) *ConversationRepository {
+	return &ConversationRepository{db: db}
+}
+
+// ListByTelegramID returns the saved conversations of the user, of any kind.
+func (r *ConversationRepository) ListByTelegramID(ctx context.Context, telegramID int64) ([]model.Conversation, error) {
+	var conversations []model.Conversation
+	if err := r.db.WithContext(ctx).Where("telegram_id = ?", telegramID).Find(&conversations).Error; err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// Save stores the conversation, replacing the user's earlier one of the same kind.
+func (r *ConversationRepository) Save(ctx context.Context, conversation *model.Conversation) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "telegram_id"}, {Name: "kind"}},
+		DoUpdates: clause.AssignmentColumns([]string{"payload", "updated_at"}),
+	}).Create(conversation).Error
+	if err != nil {
+		return fmt.Errorf("save conversation: %w", err)
+	}
+	return nil
+}
+
+// Delete forgets the user's conversation of the kind.
+func (r *ConversationRepository) Delete(ctx context.Context, telegramID int64, kind string) error {
+	if err := r.db.WithContext(ctx).Where("telegram_id = ? AND kind = ?", telegramID, kind).Delete(&model.Conversation{}).Error; err != nil {
+		return fmt.Errorf("delete conversation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// CounterRepository stores counter habits and their entries.
+type CounterRepository struct {
+	db *gorm.DB
+}
+
+func NewCounterRepository(db *gorm.DB) *CounterRepository {
+	return &CounterRepository{db: db}
+}
+
+func (r *CounterRepository) Create(ctx context.Context, counter *model.Counter) error {
+	if err := r.db.WithContext(ctx).Create(counter).Error; err != nil {
+		return fmt.Errorf("create counter: %w", err)
+	}
+	return nil
+}
+
+func (r *CounterRepository) ListByUser(ctx context.Context, userID uint) ([]model.Counter, error) {
+	var counters []model.Counter
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&counters).Error; err != nil {
+		return nil, err
+	}
+	return counters, nil
+}
+
+func (r *CounterRepository) FindByID(ctx context.Context, userID, id uint) (*model.Counter, error) {
+	var counter model.Counter
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&counter).Error; err != nil {
+		return nil, err
+	}
+	return &counter, nil
+}
+
+// Delete removes a counter together with its entries.
+func (r *CounterRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	var removed bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("user_id = ? AND id = ?", userID, id).Delete(&model.Counter{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		removed = true
+		return tx.Where("counter_id = ?", id).Delete(&model.CounterEntry{}).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete counter: %w", err)
+	}
+	return removed, nil
+}
+
+func (r *CounterRepository) AddEntry(ctx context.Context, entry *model.CounterEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("add counter entry: %w", err)
+	}
+	return nil
+}
+
+// SumSince totals the user's entries per counter from the given time on.
+func (r *CounterRepository) SumSince(ctx context.Context, userID uint, since time.Time) (map[uint]int, error) {
+	var rows []struct {
+		CounterID uint
+		Total     int
+	}
+	err := r.db.WithContext(ctx).Model(&model.CounterEntry{}).
+		Select("counter_id, SUM(amount) AS total").
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Group("counter_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		totals[row.CounterID] = row.Total
+	}
+	return totals, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
@@ -8,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -15,13 +18,33 @@ import (
 	"daily-planner/internal/model"
 )
 
-// NewDB opens a SQLite database and runs migrations.
-func NewDB(dsn string) (*gorm.DB, error) {
+// PoolConfig tunes the database connection pool; zero values keep the driver defaults.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxIdleTime time.Duration
+}
+
+// sqliteDriver is go-sqlite3 with casefold(), a Unicode-aware LOWER: SQLite's own LOWER
+// and LIKE only fold ASCII, so searching for «молоко» would miss «Молоко».
+const sqliteDriver = "sqlite3_planner"
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("casefold", strings.ToLower, true)
+		},
+	})
+}
+
+// NewDB opens a SQLite database, or PostgreSQL for postgres:// DSNs, and runs migrations.
+func NewDB(dsn string, pool PoolConfig) (*gorm.DB, error) {
 	if dsn == "" {
 		dsn = "daily_planner.db"
 	}
 
-	if err := ensureDirForSQLite(dsn); err != nil {
+	dialector, err := dialectorFor(dsn)
+	if err != nil {
 		return nil, err
 	}
 
@@ -35,31 +58,158 @@ func NewDB(dsn string) (*gorm.DB, error) {
 		},
 	)
 
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: dbLogger,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("open db: %w", err)
 	}
+	if err := configurePool(db, pool); err != nil {
+		return nil, err
+	}
 
-	if err := db.AutoMigrate(&model.User{}, &model.Category{}, &model.Task{}); err != nil {
+	if err := dropLegacyIndexes(db); err != nil {
+		return nil, fmt.Errorf("drop legacy indexes: %w", err)
+	}
+
+	// Users registered before verification existed are trusted.
+	migrator := db.Migrator()
+	trustExisting := migrator.HasTable(&model.User{}) && !migrator.HasColumn(&model.User{}, "Verified")
+
+	if err := db.AutoMigrate(
+		&model.User{},
+		&model.Account{},
+		&model.LinkCode{},
+		&model.Workspace{},
+		&model.WorkspaceMember{},
+		&model.Category{},
+		&model.Task{},
+		&model.CalendarSubscription{},
+		&model.TaskMessage{},
+		&model.ListMessage{},
+		&model.Contact{},
+		&model.Medication{},
+		&model.Dose{},
+		&model.Counter{},
+		&model.CounterEntry{},
+		&model.TriageItem{},
+		&model.Reminder{},
+		&model.SyncState{},
+		&model.ReportRun{},
+		&model.CustomField{},
+		&model.FieldValue{},
+		&model.TransientMessage{},
+		&model.Attachment{},
+		&model.TaskReference{},
+		&model.TaskMerge{},
+		&model.Conversation{},
+		&model.AccessRequest{},
+	); err != nil {
 		return nil, fmt.Errorf("migrate db: %w", err)
 	}
 
+	if trustExisting {
+		if err := db.Model(&model.User{}).Where("verified = ?", false).Update("verified", true).Error; err != nil {
+			return nil, fmt.Errorf("backfill verified users: %w", err)
+		}
+	}
+
+	// Activity has only been recorded since the inactivity policy came in; users with none
+	// recorded count as active now, not since they registered.
+	if err := db.Model(&model.User{}).Where("last_active_at IS NULL").UpdateColumn("last_active_at", time.Now()).Error; err != nil {
+		return nil, fmt.Errorf("backfill last activity: %w", err)
+	}
+
+	if err := backfillAccounts(db); err != nil {
+		return nil, fmt.Errorf("backfill accounts: %w", err)
+	}
+
+	if err := backfillWorkspaceOwners(db); err != nil {
+		return nil, fmt.Errorf("backfill workspace owners: %w", err)
+	}
+
+	if err := backfillCategoryEmoji(db); err != nil {
+		return nil, fmt.Errorf("backfill category emoji: %w", err)
+	}
+
 	return db, nil
 }
 
+// dialectorFor picks the driver by DSN: postgres:// and postgresql:// URLs go to
+// PostgreSQL, everything else is a SQLite file or URI.
+func dialectorFor(dsn string) (gorm.Dialector, error) {
+	if isPostgresDSN(dsn) {
+		return postgres.Open(dsn), nil
+	}
+	if err := ensureDirForSQLite(dsn); err != nil {
+		return nil, err
+	}
+	return sqlite.New(sqlite.Config{DriverName: sqliteDriver, DSN: dsn}), nil
+}
+
+func isPostgresDSN(dsn string) bool {
+	lower := strings.ToLower(dsn)
+	return strings.HasPrefix(lower, "postgres://") || strings.HasPrefix(lower, "postgresql://")
+}
+
+func configurePool(db *gorm.DB, pool PoolConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("db handle: %w", err)
+	}
+	if pool.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+// dropLegacyIndexes removes the old category name index that was unique across all users.
+func dropLegacyIndexes(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if migrator.HasTable(&model.Category{}) && migrator.HasIndex(&model.Category{}, "idx_user_category_name") {
+		return migrator.DropIndex(&model.Category{}, "idx_user_category_name")
+	}
+	return nil
+}
+
+// backfillAccounts gives every user created before accounts existed an account of their own.
+func backfillAccounts(db *gorm.DB) error {
+	var users []model.User
+	if err := db.Where("account_id = 0 OR account_id IS NULL").Find(&users).Error; err != nil {
+		return err
+	}
+	for i := range users {
+		var account model.Account
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return createAccountForUser(tx, &users[i], &account)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillWorkspaceOwners grants the owner role to workspace creators added before roles existed.
+func backfillWorkspaceOwners(db *gorm.DB) error {
+	return db.Model(&model.WorkspaceMember{}).
+		Where("role <> ? AND user_id = (SELECT owner_id FROM workspaces WHERE workspaces.id = workspace_members.workspace_id)", model.RoleOwner).
+		Update("role", model.RoleOwner).Error
+}
+
 // ensureDirForSQLite creates parent dir for SQLite file if needed.
 func ensureDirForSQLite(dsn string) error {
-	// Ignore DSNs with explicit mode=memory or network.
-	if strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory") {
+	path := SQLitePath(dsn)
+	if path == "" {
 		return nil
 	}
-	// Strip file: prefix if present.
-	clean := strings.TrimPrefix(dsn, "file:")
-	clean = strings.Split(clean, "?")[0]
-	dir := filepath.Dir(clean)
-	if dir == "." || dir == "" {
+	dir := filepath.Dir(path)
+	if dir == "." {
 		return nil
 	}
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -67,3 +217,26 @@ func ensureDirForSQLite(dsn string) error {
 	}
 	return nil
 }
+
+// SQLitePath is the file of a SQLite DSN; empty for PostgreSQL and in-memory databases.
+func SQLitePath(dsn string) string {
+	if dsn == "" || isPostgresDSN(dsn) || strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory") {
+		return ""
+	}
+	return strings.Split(strings.TrimPrefix(dsn, "file:"), "?")[0]
+}
+
+// backfillCategoryEmoji gives categories created before emoji were stored the ones
+// they used to be shown with.
+func backfillCategoryEmoji(db *gorm.DB) error {
+	var categories []model.Category
+	if err := db.Where("emoji = ? OR emoji IS NULL", "").Find(&categories).Error; err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if err := db.Model(&category).Update("emoji", model.DefaultCategoryEmoji(category.Name)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"daily-planner/internal/model"
+)
+
+// FieldRepository stores custom task fields and their values.
+type FieldRepository struct {
+	db *gorm.DB
+}
+
+func NewFieldRepository(db *gorm.DB) *FieldRepository {
+	return &FieldRepository{db: db}
+}
+
+func (r *FieldRepository) Create(ctx context.Context, field *model.CustomField) error {
+	if err := r.db.WithContext(ctx).Create(field).Error; err != nil {
+		return fmt.Errorf("create field: %w", err)
+	}
+	return nil
+}
+
+// ListByScope returns the fields of the scope in the order they were defined.
+func (r *FieldRepository) ListByScope(ctx context.Context, scope model.Scope) ([]model.CustomField, error) {
+	var fields []model.CustomField
+	if err := applyScope(r.db.WithContext(ctx), scope).Order("id ASC").Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// Delete removes the field together with its values on every task.
+func (r *FieldRepository) Delete(ctx context.Context, field *model.CustomField) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", field.ID).Delete(&model.FieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(field).Error
+	})
+	if err != nil {
+		return fmt.Errorf("delete field: %w", err)
+	}
+	return nil
+}
+
+// ListValues returns the field values of the given tasks.
+func (r *FieldRepository) ListValues(ctx context.Context, taskIDs []uint) ([]model.FieldValue, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+	var values []model.FieldValue
+	if err := r.db.WithContext(ctx).Where("task_id IN ?", taskIDs).Order("field_id ASC").Find(&values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SetValues stores the task's values by field ID; an empty value removes it.
+func (r *FieldRepository) SetValues(ctx context.Context, taskID uint, values map[uint]string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for fieldID, value := range values {
+			if value == "" {
+				if err := tx.Where("task_id = ? AND field_id = ?", taskID, fieldID).Delete(&model.FieldValue{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "task_id"}, {Name: "field_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+			}).Create(&model.FieldValue{TaskID: taskID, FieldID: fieldID, Value: value}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("set field values: %w", err)
+	}
+	return nil
+}
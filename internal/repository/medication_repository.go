@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"daily-planner/internal/model"
+)
+
+// MedicationRepository stores medication schedules and the doses taken or missed.
+type MedicationRepository struct {
+	db *gorm.DB
+}
+
+func NewMedicationRepository(db *gorm.DB) *MedicationRepository {
+	return &MedicationRepository{db: db}
+}
+
+func (r *MedicationRepository) Create(ctx context.Context, medication *model.Medication) error {
+	if err := r.db.WithContext(ctx).Create(medication).Error; err != nil {
+		return fmt.Errorf("create medication: %w", err)
+	}
+	return nil
+}
+
+func (r *MedicationRepository) ListByUser(ctx context.Context, userID uint) ([]model.Medication, error) {
+	var medications []model.Medication
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&medications).Error; err != nil {
+		return nil, err
+	}
+	return medications, nil
+}
+
+func (r *MedicationRepository) ListAll(ctx context.Context) ([]model.Medication, error) {
+	var medications []model.Medication
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&medications).Error; err != nil {
+		return nil, err
+	}
+	return medications, nil
+}
+
+// Delete removes a medication together with its dose history.
+func (r *MedicationRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	var removed bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("user_id = ? AND id = ?", userID, id).Delete(&model.Medication{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		removed = true
+		return tx.Where("medication_id = ?", id).Delete(&model.Dose{}).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete medication: %w", err)
+	}
+	return removed, nil
+}
+
+// CreateDose stores a dose unless one for the same slot exists; it reports whether it was created.
+func (r *MedicationRepository) CreateDose(ctx context.Context, dose *model.Dose) (bool, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(dose)
+	if res.Error != nil {
+		return false, fmt.Errorf("create dose: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *MedicationRepository) FindDose(ctx context.Context, userID, id uint) (*model.Dose, error) {
+	var dose model.Dose
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&dose).Error; err != nil {
+		return nil, err
+	}
+	return &dose, nil
+}
+
+func (r *MedicationRepository) FindByID(ctx context.Context, userID, id uint) (*model.Medication, error) {
+	var medication model.Medication
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&medication).Error; err != nil {
+		return nil, err
+	}
+	return &medication, nil
+}
+
+func (r *MedicationRepository) SetDoseStatus(ctx context.Context, dose *model.Dose, status string, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(dose).Updates(map[string]interface{}{"status": status, "answered_at": at}).Error; err != nil {
+		return fmt.Errorf("update dose: %w", err)
+	}
+	dose.Status = status
+	dose.AnsweredAt = &at
+	return nil
+}
+
+// MissPending marks doses scheduled before the given time and still unanswered as missed.
+func (r *MedicationRepository) MissPending(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&model.Dose{}).
+		Where("status = ? AND scheduled_at < ?", model.DosePending, before).
+		Update("status", model.DoseMissed)
+	if res.Error != nil {
+		return 0, fmt.Errorf("miss pending doses: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+// DoseCount is the number of doses of a medication in one status.
+type DoseCount struct {
+	MedicationID uint
+	Status       string
+	Count        int
+}
+
+// CountDoses groups the user's doses scheduled since the given time by medication and status.
+func (r *MedicationRepository) CountDoses(ctx context.Context, userID uint, since time.Time) ([]DoseCount, error) {
+	var counts []DoseCount
+	err := r.db.WithContext(ctx).Model(&model.Dose{}).
+		Select("medication_id, status, COUNT(*) AS count").
+		Where("user_id = ? AND scheduled_at >= ?", userID, since).
+		Group("medication_id, status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ReminderRepository stores the reminders users attach to tasks.
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *model.Reminder) error {
+	if err := r.db.WithContext(ctx).Create(reminder).Error; err != nil {
+		return fmt.Errorf("create reminder: %w", err)
+	}
+	return nil
+}
+
+// ListPending returns the user's reminders for a task that were not sent yet, earliest first.
+func (r *ReminderRepository) ListPending(ctx context.Context, userID, taskID uint) ([]model.Reminder, error) {
+	var reminders []model.Reminder
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND task_id = ? AND sent_at IS NULL", userID, taskID).
+		Order("at ASC, id ASC").
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// ListDue returns unsent reminders whose time has come.
+func (r *ReminderRepository) ListDue(ctx context.Context, now time.Time) ([]model.Reminder, error) {
+	var reminders []model.Reminder
+	err := r.db.WithContext(ctx).
+		Where("sent_at IS NULL AND at <= ?", now).
+		Order("at ASC, id ASC").
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *ReminderRepository) MarkSent(ctx context.Context, reminder *model.Reminder, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(reminder).Update("sent_at", at).Error; err != nil {
+		return fmt.Errorf("mark reminder sent: %w", err)
+	}
+	reminder.SentAt = &at
+	return nil
+}
+
+// Delete removes one of the user's reminders and reports whether it existed.
+func (r *ReminderRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	res := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&model.Reminder{})
+	if res.Error != nil {
+		return false, fmt.Errorf("delete reminder: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
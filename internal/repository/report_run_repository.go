@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ReportRunRepository keeps the progress of report runs.
+type ReportRunRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRunRepository(db *gorm.DB) *ReportRunRepository {
+	return &ReportRunRepository{db: db}
+}
+
+// Unfinished returns the run left over from an interrupted process, or nil.
+func (r *ReportRunRepository) Unfinished(ctx context.Context) (*model.ReportRun, error) {
+	var run model.ReportRun
+	err := r.db.WithContext(ctx).Order("id ASC").First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *ReportRunRepository) Create(ctx context.Context, run *model.ReportRun) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("create report run: %w", err)
+	}
+	return nil
+}
+
+// Advance records that the run got as far as userID.
+func (r *ReportRunRepository) Advance(ctx context.Context, run *model.ReportRun, userID uint) error {
+	if err := r.db.WithContext(ctx).Model(run).Update("last_user_id", userID).Error; err != nil {
+		return fmt.Errorf("advance report run: %w", err)
+	}
+	run.LastUserID = userID
+	return nil
+}
+
+// Finish forgets a completed run.
+func (r *ReportRunRepository) Finish(ctx context.Context, run *model.ReportRun) error {
+	if err := r.db.WithContext(ctx).Delete(&model.ReportRun{}, run.ID).Error; err != nil {
+		return fmt.Errorf("finish report run: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// applyScope restricts a query to the user's personal rows or to a workspace.
+func applyScope(db *gorm.DB, scope model.Scope) *gorm.DB {
+	if scope.IsWorkspace() {
+		return db.Where("workspace_id = ?", scope.WorkspaceID)
+	}
+	return db.Where("user_id = ? AND workspace_id = ?", scope.UserID, 0)
+}
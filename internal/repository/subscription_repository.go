@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// SubscriptionRepository stores calendar feed subscriptions.
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, sub *model.CalendarSubscription) error {
+	if err := r.db.WithContext(ctx).Create(sub).Error; err != nil {
+		return fmt.Errorf("create subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) Save(ctx context.Context, sub *model.CalendarSubscription) error {
+	if err := r.db.WithContext(ctx).Save(sub).Error; err != nil {
+		return fmt.Errorf("save subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) ListByUser(ctx context.Context, userID uint) ([]model.CalendarSubscription, error) {
+	var subs []model.CalendarSubscription
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *SubscriptionRepository) ListAll(ctx context.Context) ([]model.CalendarSubscription, error) {
+	var subs []model.CalendarSubscription
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	res := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&model.CalendarSubscription{})
+	if res.Error != nil {
+		return false, fmt.Errorf("delete subscription: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// SyncRepository reads and writes tasks for synchronisation with peer instances.
+type SyncRepository struct {
+	db *gorm.DB
+}
+
+func NewSyncRepository(db *gorm.DB) *SyncRepository {
+	return &SyncRepository{db: db}
+}
+
+// ListChangedSince returns the tasks of the scope updated after since, deleted ones
+// included, oldest change first.
+func (r *SyncRepository) ListChangedSince(ctx context.Context, scope model.Scope, since time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx).Unscoped(), scope).Where("updated_at > ?", since).Order("updated_at ASC, id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// AssignSyncUID stores the task's sync UID without counting it as a change.
+func (r *SyncRepository) AssignSyncUID(ctx context.Context, task *model.Task, uid string) error {
+	if err := r.db.WithContext(ctx).Model(task).UpdateColumn("sync_uid", uid).Error; err != nil {
+		return fmt.Errorf("assign sync uid: %w", err)
+	}
+	task.SyncUID = uid
+	return nil
+}
+
+// FindBySyncUID finds the task with the sync UID, in the trash too, so a peer's change to a
+// deleted task is matched to it instead of creating a second one.
+func (r *SyncRepository) FindBySyncUID(ctx context.Context, scope model.Scope, uid string) (*model.Task, error) {
+	var task model.Task
+	if err := applyScope(r.db.WithContext(ctx).Unscoped(), scope).Where("sync_uid = ?", uid).First(&task).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// SaveRemote writes a task received from a peer, keeping its UpdatedAt so it is
+// not sent back as a local change. A task in the trash is taken out of it.
+func (r *SyncRepository) SaveRemote(ctx context.Context, task *model.Task) error {
+	db := r.db.WithContext(ctx)
+	if task.ID == 0 {
+		if err := db.Create(task).Error; err != nil {
+			return fmt.Errorf("create synced task: %w", err)
+		}
+		return nil
+	}
+	if err := db.Unscoped().Model(task).Select("*").Omit("id", "user_id", "workspace_id", "created_at").UpdateColumns(task).Error; err != nil {
+		return fmt.Errorf("update synced task: %w", err)
+	}
+	return nil
+}
+
+// DeleteRemote applies a peer's deletion of the task, keeping the peer's UpdatedAt like
+// SaveRemote does.
+func (r *SyncRepository) DeleteRemote(ctx context.Context, task *model.Task, deletedAt, updatedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Unscoped().Model(task).UpdateColumns(map[string]interface{}{"deleted_at": deletedAt, "updated_at": updatedAt}).Error; err != nil {
+		return fmt.Errorf("delete synced task: %w", err)
+	}
+	task.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+	task.UpdatedAt = updatedAt
+	return nil
+}
+
+// State returns the progress of synchronisation with the peer, zero for a new peer.
+func (r *SyncRepository) State(ctx context.Context, peerURL string) (*model.SyncState, error) {
+	state := model.SyncState{PeerURL: peerURL}
+	err := r.db.WithContext(ctx).Where("peer_url = ?", peerURL).First(&state).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *SyncRepository) SaveState(ctx context.Context, state *model.SyncState) error {
+	if err := r.db.WithContext(ctx).Save(state).Error; err != nil {
+		return fmt.Errorf("save sync state: %w", err)
+	}
+	return nil
+}
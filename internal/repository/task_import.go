@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ImportedTask is a task of an imported file with the name of its category, "" for none,
+// and the position of its parent in the batch, -1 for none.
+type ImportedTask struct {
+	Task     model.Task
+	Category string
+	Parent   int
+}
+
+// ImportBatch stores the tasks of the scope in one transaction together with the categories
+// they name, so either all of it is created or none. Missing categories are created and
+// archived ones brought back; subtasks are linked to their parents once all have IDs. It
+// returns how many categories were created.
+func (r *TaskRepository) ImportBatch(ctx context.Context, scope model.Scope, batch []ImportedTask) (int, error) {
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	created := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		categories := make(map[string]uint)
+		tasks := make([]model.Task, len(batch))
+		for i, item := range batch {
+			tasks[i] = item.Task
+			if item.Category == "" {
+				continue
+			}
+			if _, ok := categories[item.Category]; !ok {
+				category, isNew, err := getOrCreateCategory(tx, scope, item.Category)
+				if err != nil {
+					return err
+				}
+				if isNew {
+					created++
+				}
+				categories[item.Category] = category.ID
+			}
+			id := categories[item.Category]
+			tasks[i].CategoryID = &id
+		}
+		if err := tx.CreateInBatches(tasks, 100).Error; err != nil {
+			return err
+		}
+		for i, item := range batch {
+			if item.Parent < 0 {
+				continue
+			}
+			if err := tx.Model(&tasks[i]).Update("parent_id", tasks[item.Parent].ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("import tasks: %w", err)
+	}
+	return created, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// mergeMove is a kind of row a merge moves from the duplicate to the kept task: the column
+// that points at the task, the one that tells the rows apart and where TaskMerge keeps them.
+// free, if set, leaves out the rows the kept task already has a counterpart of; those stay
+// with the duplicate.
+type mergeMove struct {
+	table  interface{}
+	column string
+	key    string
+	free   func(query, tx *gorm.DB, keptID uint) *gorm.DB
+	ids    *string
+}
+
+func mergeMoves(merge *model.TaskMerge) []mergeMove {
+	return []mergeMove{
+		{&model.Task{}, "parent_id", "id", nil, &merge.SubtaskIDs},
+		{&model.Reminder{}, "task_id", "id", nil, &merge.ReminderIDs},
+		{&model.Attachment{}, "task_id", "id", nil, &merge.AttachmentIDs},
+		{&model.FieldValue{}, "task_id", "id", func(query, tx *gorm.DB, keptID uint) *gorm.DB {
+			return query.Where("field_id NOT IN (?)", tx.Model(&model.FieldValue{}).Select("field_id").Where("task_id = ?", keptID))
+		}, &merge.FieldValueIDs},
+		{&model.TaskReference{}, "ref_id", "task_id", func(query, tx *gorm.DB, keptID uint) *gorm.DB {
+			return query.Where("task_id <> ? AND task_id NOT IN (?)", keptID, tx.Model(&model.TaskReference{}).Select("task_id").Where("ref_id = ?", keptID))
+		}, &merge.ReferrerIDs},
+	}
+}
+
+// Merge folds the duplicate into the kept task in one transaction: it moves the subtasks,
+// reminders, attachments, field values and mentions of the duplicate over, saves the
+// description, deadline and postponements of kept, puts the duplicate into the trash and
+// records merge, which should hold the values kept had before.
+func (r *TaskRepository) Merge(ctx context.Context, kept, duplicate *model.Task, merge *model.TaskMerge) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, move := range mergeMoves(merge) {
+			query := tx.Model(move.table).Where(move.column+" = ?", duplicate.ID)
+			if move.free != nil {
+				query = move.free(query, tx, kept.ID)
+			}
+			var ids []uint
+			if err := query.Pluck(move.key, &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			if err := tx.Model(move.table).Where(move.key+" IN ? AND "+move.column+" = ?", ids, duplicate.ID).Update(move.column, kept.ID).Error; err != nil {
+				return err
+			}
+			*move.ids = joinIDs(ids)
+		}
+		if err := tx.Model(kept).Select("description", "deadline", "postponements", "alerted_at", "snoozed_until").Updates(kept).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(duplicate).UpdateColumns(trashed(time.Now())).Error; err != nil {
+			return err
+		}
+		return tx.Create(merge).Error
+	})
+	if err != nil {
+		return fmt.Errorf("merge tasks: %w", err)
+	}
+	return nil
+}
+
+// UndoMerge takes the duplicate of a merge of the scope out of the trash, moves its rows back
+// and restores the kept task as it was. It returns gorm.ErrRecordNotFound when the merge was
+// undone already or either task is gone.
+func (r *TaskRepository) UndoMerge(ctx context.Context, scope model.Scope, mergeID uint) (*model.TaskMerge, error) {
+	var merge model.TaskMerge
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := applyScope(tx, scope).Where("id = ?", mergeID).First(&merge).Error; err != nil {
+			return err
+		}
+		var kept, duplicate model.Task
+		if err := applyScope(tx, scope).Where("id = ?", merge.TaskID).First(&kept).Error; err != nil {
+			return err
+		}
+		if err := applyScope(tx.Unscoped(), scope).Where("id = ? AND deleted_at IS NOT NULL", merge.DuplicateID).First(&duplicate).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&duplicate).UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		for _, move := range mergeMoves(&merge) {
+			ids := splitIDs(*move.ids)
+			if len(ids) == 0 {
+				continue
+			}
+			if err := tx.Model(move.table).Where(move.key+" IN ? AND "+move.column+" = ?", ids, kept.ID).Update(move.column, duplicate.ID).Error; err != nil {
+				return err
+			}
+		}
+		restored := map[string]interface{}{"description": merge.Description, "deadline": merge.Deadline, "postponements": merge.Postponements}
+		if !sameTime(kept.Deadline, merge.Deadline) {
+			restored["alerted_at"], restored["snoozed_until"] = nil, nil
+		}
+		if err := tx.Model(&kept).Updates(restored).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&merge).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("undo merge: %w", err)
+	}
+	return &merge, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func joinIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitIDs(joined string) []uint {
+	var ids []uint
+	for _, part := range strings.Split(joined, ",") {
+		if id, err := strconv.ParseUint(part, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
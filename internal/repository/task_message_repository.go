@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"daily-planner/internal/model"
+)
+
+// TaskMessageRepository maps bot messages to the tasks they show.
+type TaskMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskMessageRepository(db *gorm.DB) *TaskMessageRepository {
+	return &TaskMessageRepository{db: db}
+}
+
+// Save stores the mapping, replacing an older one for the same message.
+func (r *TaskMessageRepository) Save(ctx context.Context, message *model.TaskMessage) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"task_id", "workspace_id", "countdown"}),
+	}).Create(message).Error
+	if err != nil {
+		return fmt.Errorf("save task message: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskMessageRepository) Find(ctx context.Context, chatID int64, messageID int) (*model.TaskMessage, error) {
+	var message model.TaskMessage
+	if err := r.db.WithContext(ctx).Where("chat_id = ? AND message_id = ?", chatID, messageID).First(&message).Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// Latest returns the mapping of the most recent message in the chat that showed a task.
+func (r *TaskMessageRepository) Latest(ctx context.Context, chatID int64) (*model.TaskMessage, error) {
+	var message model.TaskMessage
+	if err := r.db.WithContext(ctx).Where("chat_id = ?", chatID).Order("message_id DESC").First(&message).Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// ListCountdowns returns the messages whose deadline countdown is running.
+func (r *TaskMessageRepository) ListCountdowns(ctx context.Context) ([]model.TaskMessage, error) {
+	var messages []model.TaskMessage
+	if err := r.db.WithContext(ctx).Where("countdown <> ?", "").Order("id").Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// StopCountdowns stops the countdowns in the chat, or only in one message when messageID is not 0.
+func (r *TaskMessageRepository) StopCountdowns(ctx context.Context, chatID int64, messageID int) error {
+	query := r.db.WithContext(ctx).Model(&model.TaskMessage{}).Where("chat_id = ?", chatID)
+	if messageID != 0 {
+		query = query.Where("message_id = ?", messageID)
+	}
+	if err := query.Update("countdown", "").Error; err != nil {
+		return fmt.Errorf("stop countdowns: %w", err)
+	}
+	return nil
+}
+
+// SaveList makes the message the tracked task list of its chat, replacing the previous one.
+func (r *TaskMessageRepository) SaveList(ctx context.Context, list *model.ListMessage) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"message_id", "user_id", "workspace_id", "view", "page", "dashboard", "updated_at"}),
+	}).Create(list).Error
+	if err != nil {
+		return fmt.Errorf("save list message: %w", err)
+	}
+	return nil
+}
+
+// FindList returns the tracked task list of the chat.
+func (r *TaskMessageRepository) FindList(ctx context.Context, chatID int64) (*model.ListMessage, error) {
+	var list model.ListMessage
+	if err := r.db.WithContext(ctx).Where("chat_id = ?", chatID).First(&list).Error; err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListsByScope returns the tracked task lists that show the tasks of the scope.
+func (r *TaskMessageRepository) ListsByScope(ctx context.Context, scope model.Scope) ([]model.ListMessage, error) {
+	var lists []model.ListMessage
+	if err := applyScope(r.db.WithContext(ctx), scope).Find(&lists).Error; err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// ForgetList stops tracking the task list of the chat.
+func (r *TaskMessageRepository) ForgetList(ctx context.Context, chatID int64) error {
+	if err := r.db.WithContext(ctx).Where("chat_id = ?", chatID).Delete(&model.ListMessage{}).Error; err != nil {
+		return fmt.Errorf("forget list message: %w", err)
+	}
+	return nil
+}
+
+// ScheduleDeletion marks the message to be deleted at the given time.
+func (r *TaskMessageRepository) ScheduleDeletion(ctx context.Context, message *model.TransientMessage) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"delete_at"}),
+	}).Create(message).Error
+	if err != nil {
+		return fmt.Errorf("schedule message deletion: %w", err)
+	}
+	return nil
+}
+
+// DueDeletions returns the messages due to be deleted by now, oldest first.
+func (r *TaskMessageRepository) DueDeletions(ctx context.Context, now time.Time, limit int) ([]model.TransientMessage, error) {
+	var messages []model.TransientMessage
+	if err := r.db.WithContext(ctx).Where("delete_at <= ?", now).Order("delete_at, id").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ForgetDeletions drops the given scheduled deletions.
+func (r *TaskMessageRepository) ForgetDeletions(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.TransientMessage{}).Error; err != nil {
+		return fmt.Errorf("forget message deletions: %w", err)
+	}
+	return nil
+}
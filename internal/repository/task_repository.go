@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -26,9 +27,9 @@ func (r *TaskRepository) Create(ctx context.Context, task *model.Task) error {
 	return nil
 }
 
-func (r *TaskRepository) ListActiveOrRecurring(ctx context.Context, userID uint) ([]model.Task, error) {
+func (r *TaskRepository) ListActiveOrRecurring(ctx context.Context, scope model.Scope) ([]model.Task, error) {
 	var tasks []model.Task
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND (is_completed = ? OR is_recurring = ?)", userID, false, true).
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("(is_completed = ? OR is_recurring = ?) AND archived_at IS NULL AND recur_ended_at IS NULL", false, true).
 		Order("deadline NULLS LAST, created_at DESC").
 		Find(&tasks).Error; err != nil {
 		return nil, err
@@ -36,14 +37,77 @@ func (r *TaskRepository) ListActiveOrRecurring(ctx context.Context, userID uint)
 	return tasks, nil
 }
 
-func (r *TaskRepository) FindByID(ctx context.Context, userID, taskID uint) (*model.Task, error) {
+// Search finds open tasks of the scope whose title or description contains query, ignoring case.
+// It returns one page of matches, newest first, and the total number of matches.
+func (r *TaskRepository) Search(ctx context.Context, scope model.Scope, query string, limit, offset int) ([]model.Task, int64, error) {
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+	match := `(casefold(title) LIKE ? ESCAPE '\' OR casefold(description) LIKE ? ESCAPE '\')`
+	if r.db.Dialector.Name() == "postgres" {
+		match = `(title ILIKE ? ESCAPE '\' OR description ILIKE ? ESCAPE '\')`
+	}
+	base := applyScope(r.db.WithContext(ctx).Model(&model.Task{}), scope).
+		Where("(is_completed = ? OR is_recurring = ?) AND archived_at IS NULL AND recur_ended_at IS NULL", false, true).
+		Where(match, pattern, pattern).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count search results: %w", err)
+	}
+	var tasks []model.Task
+	if err := base.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&tasks).Error; err != nil {
+		return nil, 0, fmt.Errorf("search tasks: %w", err)
+	}
+	return tasks, total, nil
+}
+
+// likeEscaper makes user input match literally inside a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *TaskRepository) FindByID(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error) {
+	var task model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("id = ?", taskID).First(&task).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// CountActiveByUser counts open and recurring tasks created by the user across all scopes.
+// ListSubtasks returns the subtasks added to the task, in the order they were added.
+func (r *TaskRepository) ListSubtasks(ctx context.Context, scope model.Scope, parentID uint) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("parent_id = ?", parentID).Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) CountActiveByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Task{}).
+		Where("user_id = ? AND (is_completed = ? OR is_recurring = ?) AND archived_at IS NULL AND recur_ended_at IS NULL", userID, false, true).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindByExternalUID looks up a task imported from an external calendar event.
+func (r *TaskRepository) FindByExternalUID(ctx context.Context, scope model.Scope, uid string) (*model.Task, error) {
 	var task model.Task
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, taskID).First(&task).Error; err != nil {
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("external_uid = ?", uid).First(&task).Error; err != nil {
 		return nil, err
 	}
 	return &task, nil
 }
 
+func (r *TaskRepository) Save(ctx context.Context, task *model.Task) error {
+	if err := r.db.WithContext(ctx).Save(task).Error; err != nil {
+		return fmt.Errorf("save task: %w", err)
+	}
+	return nil
+}
+
 func (r *TaskRepository) MarkCompleted(ctx context.Context, task *model.Task, completedAt time.Time) error {
 	task.IsCompleted = true
 	task.LastCompletedAt = &completedAt
@@ -61,11 +125,376 @@ func (r *TaskRepository) MarkRecurringDone(ctx context.Context, task *model.Task
 	return nil
 }
 
-// Delete removes a task for the given user, regardless of it being recurring or not.
-func (r *TaskRepository) Delete(ctx context.Context, userID, taskID uint) error {
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, taskID).
-		Delete(&model.Task{}).Error; err != nil {
+// Reopen makes a completed one-time task open again and forgets its completion.
+func (r *TaskRepository) Reopen(ctx context.Context, task *model.Task) error {
+	if err := r.db.WithContext(ctx).Model(task).Updates(map[string]interface{}{
+		"is_completed":      false,
+		"last_completed_at": nil,
+	}).Error; err != nil {
+		return fmt.Errorf("reopen task: %w", err)
+	}
+	task.IsCompleted = false
+	task.LastCompletedAt = nil
+	return nil
+}
+
+// ListCompletedSince returns tasks whose last completion happened at or after since.
+func (r *TaskRepository) ListCompletedSince(ctx context.Context, scope model.Scope, since time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).
+		Where("last_completed_at >= ?", since).
+		Order("last_completed_at DESC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// MemberActivity aggregates per-user task counters inside a workspace.
+type MemberActivity struct {
+	UserID    uint
+	Completed int
+	Overdue   int
+	Open      int
+}
+
+// WorkspaceActivity counts completions since the given time and currently overdue
+// and open one-time tasks for every member that has tasks in the workspace.
+func (r *TaskRepository) WorkspaceActivity(ctx context.Context, workspaceID uint, since, now time.Time) ([]MemberActivity, error) {
+	var rows []MemberActivity
+	if err := r.db.WithContext(ctx).Model(&model.Task{}).
+		Select(`user_id,
+			SUM(CASE WHEN last_completed_at >= ? THEN 1 ELSE 0 END) AS completed,
+			SUM(CASE WHEN is_recurring = ? AND is_completed = ? AND deadline < ? THEN 1 ELSE 0 END) AS overdue,
+			SUM(CASE WHEN is_recurring = ? AND is_completed = ? THEN 1 ELSE 0 END) AS open`,
+			since, false, false, now, false, false).
+		Where("workspace_id = ?", workspaceID).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("workspace activity: %w", err)
+	}
+	return rows, nil
+}
+
+// ListDueForAlert returns open one-time tasks that need a deadline alert: never alerted
+// with a deadline between from and to, or snoozed until a moment that has passed.
+func (r *TaskRepository) ListDueForAlert(ctx context.Context, from, to, now time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := r.db.WithContext(ctx).
+		Where("is_recurring = ? AND is_completed = ? AND archived_at IS NULL AND deadline IS NOT NULL", false, false).
+		Where("(snoozed_until IS NOT NULL AND snoozed_until <= ?) OR (snoozed_until IS NULL AND alerted_at IS NULL AND deadline BETWEEN ? AND ?)", now, from, to).
+		Order("deadline ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) MarkAlerted(ctx context.Context, task *model.Task, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Updates(map[string]interface{}{"alerted_at": at, "snoozed_until": nil}).Error; err != nil {
+		return fmt.Errorf("mark task alerted: %w", err)
+	}
+	task.AlertedAt = &at
+	task.SnoozedUntil = nil
+	return nil
+}
+
+// Snooze postpones the next deadline alert of the task and counts the postponement.
+func (r *TaskRepository) Snooze(ctx context.Context, task *model.Task, until, now time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Updates(map[string]interface{}{
+		"snoozed_until":  until,
+		"postpone_count": gorm.Expr("postpone_count + 1"),
+		"touched_at":     now,
+	}).Error; err != nil {
+		return fmt.Errorf("snooze task: %w", err)
+	}
+	task.SnoozedUntil = &until
+	task.PostponeCount++
+	task.TouchedAt = &now
+	return nil
+}
+
+// Postpone moves the deadline of the task, re-arms its deadline alert and counts the postponement.
+func (r *TaskRepository) Postpone(ctx context.Context, task *model.Task, deadline, now time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Updates(map[string]interface{}{
+		"deadline":      deadline,
+		"alerted_at":    nil,
+		"snoozed_until": nil,
+		"postponements": gorm.Expr("postponements + 1"),
+		"touched_at":    now,
+	}).Error; err != nil {
+		return fmt.Errorf("postpone task: %w", err)
+	}
+	task.Deadline = &deadline
+	task.AlertedAt = nil
+	task.SnoozedUntil = nil
+	task.Postponements++
+	task.TouchedAt = &now
+	return nil
+}
+
+// ListForNudge returns open personal one-time tasks postponed more than maxPostpones times
+// or untouched since idleBefore, skipping those nudged after nudgedBefore.
+func (r *TaskRepository) ListForNudge(ctx context.Context, maxPostpones int, idleBefore, nudgedBefore time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := r.db.WithContext(ctx).
+		Where("workspace_id = ? AND is_recurring = ? AND is_completed = ? AND archived_at IS NULL", 0, false, false).
+		Where("nudged_at IS NULL OR nudged_at < ?", nudgedBefore).
+		Where("postpone_count > ? OR COALESCE(touched_at, created_at) < ?", maxPostpones, idleBefore).
+		Order("user_id ASC, id ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) MarkNudged(ctx context.Context, task *model.Task, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("nudged_at", at).Error; err != nil {
+		return fmt.Errorf("mark task nudged: %w", err)
+	}
+	task.NudgedAt = &at
+	return nil
+}
+
+func (r *TaskRepository) SetEscalate(ctx context.Context, task *model.Task, on bool) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("escalate", on).Error; err != nil {
+		return fmt.Errorf("set task escalation: %w", err)
+	}
+	task.Escalate = on
+	return nil
+}
+
+// SetCatchUp chooses whether missed occurrences of the recurring task are carried forward.
+func (r *TaskRepository) SetCatchUp(ctx context.Context, task *model.Task, on bool) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("catch_up", on).Error; err != nil {
+		return fmt.Errorf("set task catch-up: %w", err)
+	}
+	task.CatchUp = on
+	return nil
+}
+
+// MarkCaughtUp records that the missed occurrence due on due was done late.
+func (r *TaskRepository) MarkCaughtUp(ctx context.Context, task *model.Task, due time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("caught_up_to", due).Error; err != nil {
+		return fmt.Errorf("mark task caught up: %w", err)
+	}
+	task.CaughtUpTo = &due
+	return nil
+}
+
+// ListForEscalation returns open flagged tasks whose deadline passed before the given time
+// and whose current deadline the owner's partner has not been told about.
+func (r *TaskRepository) ListForEscalation(ctx context.Context, overdueBefore time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := r.db.WithContext(ctx).
+		Where("escalate = ? AND is_recurring = ? AND is_completed = ? AND archived_at IS NULL", true, false, false).
+		Where("deadline < ?", overdueBefore).
+		Where("escalated_for IS NULL OR escalated_for <> deadline").
+		Order("user_id ASC, id ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) MarkEscalated(ctx context.Context, task *model.Task) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("escalated_for", task.Deadline).Error; err != nil {
+		return fmt.Errorf("mark task escalated: %w", err)
+	}
+	task.EscalatedFor = task.Deadline
+	return nil
+}
+
+// Touch records that the owner reviewed the task and resets its postponement counter.
+func (r *TaskRepository) Touch(ctx context.Context, task *model.Task, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Updates(map[string]interface{}{
+		"touched_at":     at,
+		"postpone_count": 0,
+		"nudged_at":      nil,
+	}).Error; err != nil {
+		return fmt.Errorf("touch task: %w", err)
+	}
+	task.TouchedAt = &at
+	task.PostponeCount = 0
+	task.NudgedAt = nil
+	return nil
+}
+
+// ListStale returns open personal one-time tasks nobody acted on since idleBefore, least recently touched first.
+func (r *TaskRepository) ListStale(ctx context.Context, idleBefore time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := r.db.WithContext(ctx).
+		Where("workspace_id = ? AND is_recurring = ? AND is_completed = ? AND archived_at IS NULL", 0, false, false).
+		Where("COALESCE(touched_at, created_at) < ?", idleBefore).
+		Order("user_id ASC, COALESCE(touched_at, created_at) ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListAll returns every task of the scope, open, completed, archived and ended, but not
+// deleted ones, oldest first.
+func (r *TaskRepository) ListAll(ctx context.Context, scope model.Scope) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListArchived returns archived tasks of the scope, most recently archived first.
+func (r *TaskRepository) ListArchived(ctx context.Context, scope model.Scope) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).Where("archived_at IS NOT NULL").
+		Order("archived_at DESC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// SetArchived archives the task at the given moment or, with nil, restores it.
+func (r *TaskRepository) SetArchived(ctx context.Context, task *model.Task, at *time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("archived_at", at).Error; err != nil {
+		return fmt.Errorf("archive task: %w", err)
+	}
+	task.ArchivedAt = at
+	return nil
+}
+
+// EndRecurrence stops a recurring task from repeating while keeping the task and its completion history.
+func (r *TaskRepository) EndRecurrence(ctx context.Context, task *model.Task, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(task).Update("recur_ended_at", at).Error; err != nil {
+		return fmt.Errorf("end recurrence: %w", err)
+	}
+	task.RecurEndedAt = &at
+	return nil
+}
+
+// Delete moves a task within the given scope to the trash, regardless of it being recurring
+// or not; see Undelete and PurgeDeleted. For recurring tasks that is deleting with history,
+// unlike EndRecurrence.
+func (r *TaskRepository) Delete(ctx context.Context, scope model.Scope, taskID uint) error {
+	if err := applyScope(r.db.WithContext(ctx).Model(&model.Task{}), scope).Where("id = ?", taskID).
+		UpdateColumns(trashed(time.Now())).Error; err != nil {
 		return fmt.Errorf("delete task: %w", err)
 	}
 	return nil
 }
+
+// trashed are the columns that move a task to the trash. UpdatedAt moves along, so sync
+// sees the deletion as a change and carries it to peers.
+func trashed(now time.Time) map[string]interface{} {
+	return map[string]interface{}{"deleted_at": now, "updated_at": now}
+}
+
+// ListDeleted returns the scope's tasks deleted since the given time, most recently deleted first.
+func (r *TaskRepository) ListDeleted(ctx context.Context, scope model.Scope, since time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx).Unscoped(), scope).
+		Where("deleted_at IS NOT NULL AND deleted_at >= ?", since).
+		Order("deleted_at DESC, id DESC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Undelete takes a task of the scope out of the trash.
+func (r *TaskRepository) Undelete(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error) {
+	var task model.Task
+	if err := applyScope(r.db.WithContext(ctx).Unscoped(), scope).Where("id = ? AND deleted_at IS NOT NULL", taskID).First(&task).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := r.db.WithContext(ctx).Unscoped().Model(&task).UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": now}).Error; err != nil {
+		return nil, fmt.Errorf("undelete task: %w", err)
+	}
+	task.DeletedAt = gorm.DeletedAt{}
+	task.UpdatedAt = now
+	return &task, nil
+}
+
+// PurgeDeleted permanently removes the tasks deleted before the given time together with
+// their custom field values, references and merge records and returns how many tasks
+// were removed.
+func (r *TaskRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Unscoped().Model(&model.Task{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Where("task_id IN ?", ids).Delete(&model.FieldValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN ? OR ref_id IN ?", ids, ids).Delete(&model.TaskReference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN ? OR duplicate_id IN ?", ids, ids).Delete(&model.TaskMerge{}).Error; err != nil {
+			return err
+		}
+		deleted := tx.Unscoped().Where("id IN ?", ids).Delete(&model.Task{})
+		purged = deleted.RowsAffected
+		return deleted.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted tasks: %w", err)
+	}
+	return purged, nil
+}
+
+// AddAttachments stores files sent for a task.
+func (r *TaskRepository) AddAttachments(ctx context.Context, attachments []model.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&attachments).Error; err != nil {
+		return fmt.Errorf("add attachments: %w", err)
+	}
+	return nil
+}
+
+// ListAttachments returns the files of the task in the order they were sent.
+func (r *TaskRepository) ListAttachments(ctx context.Context, taskID uint) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// SetReferences replaces the tasks the description of the task mentions.
+func (r *TaskRepository) SetReferences(ctx context.Context, taskID uint, refIDs []uint) error {
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&model.TaskReference{}).Error; err != nil {
+			return err
+		}
+		if len(refIDs) == 0 {
+			return nil
+		}
+		references := make([]model.TaskReference, len(refIDs))
+		for i, refID := range refIDs {
+			references[i] = model.TaskReference{TaskID: taskID, RefID: refID}
+		}
+		return tx.Create(&references).Error
+	}); err != nil {
+		return fmt.Errorf("set task references: %w", err)
+	}
+	return nil
+}
+
+// ListReferrers returns the tasks of the scope whose descriptions mention the task, oldest first.
+func (r *TaskRepository) ListReferrers(ctx context.Context, scope model.Scope, taskID uint) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := applyScope(r.db.WithContext(ctx), scope).
+		Where("id IN (?)", r.db.Model(&model.TaskReference{}).Select("task_id").Where("ref_id = ?", taskID)).
+		Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
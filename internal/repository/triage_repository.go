@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// TriageRepository stores backlog triage runs and their decisions.
+type TriageRepository struct {
+	db *gorm.DB
+}
+
+func NewTriageRepository(db *gorm.DB) *TriageRepository {
+	return &TriageRepository{db: db}
+}
+
+func (r *TriageRepository) CreateRun(ctx context.Context, items []model.TriageItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&items).Error; err != nil {
+		return fmt.Errorf("create triage run: %w", err)
+	}
+	return nil
+}
+
+func (r *TriageRepository) FindByID(ctx context.Context, userID, id uint) (*model.TriageItem, error) {
+	var item model.TriageItem
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListRun returns the items of one triage run in the order they were offered.
+func (r *TriageRepository) ListRun(ctx context.Context, userID uint, run int64) ([]model.TriageItem, error) {
+	var items []model.TriageItem
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND run = ?", userID, run).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *TriageRepository) Decide(ctx context.Context, item *model.TriageItem, decision string, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(item).Updates(map[string]interface{}{"decision": decision, "decided_at": at}).Error; err != nil {
+		return fmt.Errorf("record triage decision: %w", err)
+	}
+	item.Decision = decision
+	item.DecidedAt = &at
+	return nil
+}
+
+// CountDecisions counts the user's triage decisions made since the given time by kind.
+func (r *TriageRepository) CountDecisions(ctx context.Context, userID uint, since time.Time) (map[string]int, error) {
+	var rows []struct {
+		Decision string
+		Total    int
+	}
+	err := r.db.WithContext(ctx).Model(&model.TriageItem{}).
+		Select("decision, COUNT(*) AS total").
+		Where("user_id = ? AND decided_at >= ?", userID, since).
+		Group("decision").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Decision] = row.Total
+	}
+	return counts, nil
+}
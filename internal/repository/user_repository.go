@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -19,16 +21,22 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 // UpsertFromTelegram finds or creates a user based on TelegramID and updates basic profile info.
-func (r *UserRepository) UpsertFromTelegram(ctx context.Context, telegramID int64, firstName, lastName, username string) (*model.User, error) {
+func (r *UserRepository) UpsertFromTelegram(ctx context.Context, telegramID int64, firstName, lastName, username, languageCode string) (*model.User, error) {
 	var user model.User
+	now := time.Now()
 	db := r.db.WithContext(ctx)
 	err := db.Where("telegram_id = ?", telegramID).First(&user).Error
 	switch {
 	case err == nil:
+		// Any interaction counts as activity and cancels a pending retention prompt or archive.
 		updates := map[string]interface{}{
-			"first_name": firstName,
-			"last_name":  lastName,
-			"username":   username,
+			"first_name":          firstName,
+			"last_name":           lastName,
+			"username":            username,
+			"language_code":       languageCode,
+			"last_active_at":      now,
+			"retention_prompt_at": nil,
+			"archived_at":         nil,
 		}
 		if err := db.Model(&user).Updates(updates).Error; err != nil {
 			return nil, fmt.Errorf("update user: %w", err)
@@ -36,13 +44,22 @@ func (r *UserRepository) UpsertFromTelegram(ctx context.Context, telegramID int6
 		return &user, nil
 	case err == gorm.ErrRecordNotFound:
 		user = model.User{
-			TelegramID: telegramID,
-			FirstName:  firstName,
-			LastName:   lastName,
-			Username:   username,
+			TelegramID:   telegramID,
+			FirstName:    firstName,
+			LastName:     lastName,
+			Username:     username,
+			LanguageCode: languageCode,
+			LastActiveAt: &now,
 		}
-		if err := db.Create(&user).Error; err != nil {
-			return nil, fmt.Errorf("create user: %w", err)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&user).Error; err != nil {
+				return fmt.Errorf("create user: %w", err)
+			}
+			var account model.Account
+			return createAccountForUser(tx, &user, &account)
+		})
+		if err != nil {
+			return nil, err
 		}
 		return &user, nil
 	default:
@@ -58,6 +75,14 @@ func (r *UserRepository) FindByTelegramID(ctx context.Context, telegramID int64)
 	return &user, nil
 }
 
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *UserRepository) ListAll(ctx context.Context) ([]model.User, error) {
 	var users []model.User
 	if err := r.db.WithContext(ctx).Find(&users).Error; err != nil {
@@ -65,3 +90,249 @@ func (r *UserRepository) ListAll(ctx context.Context) ([]model.User, error) {
 	}
 	return users, nil
 }
+
+// SetQuotaExempt lifts or restores quotas for the user with the given Telegram ID.
+func (r *UserRepository) SetQuotaExempt(ctx context.Context, telegramID int64, exempt bool) (*model.User, error) {
+	user, err := r.FindByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Model(user).Update("quota_exempt", exempt).Error; err != nil {
+		return nil, fmt.Errorf("update quota exemption: %w", err)
+	}
+	user.QuotaExempt = exempt
+	return user, nil
+}
+
+// MarkVerified records that the user passed the anti-spam check.
+func (r *UserRepository) MarkVerified(ctx context.Context, user *model.User) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("verified", true).Error; err != nil {
+		return fmt.Errorf("mark user verified: %w", err)
+	}
+	user.Verified = true
+	return nil
+}
+
+// ListInactive returns users silent since before who were not yet asked about reports.
+// Users without recorded activity are judged by their registration time.
+func (r *UserRepository) ListInactive(ctx context.Context, before time.Time) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.WithContext(ctx).
+		Where("COALESCE(last_active_at, created_at) < ? AND retention_prompt_at IS NULL AND archived_at IS NULL", before).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListUnansweredPrompts returns users asked about reports before the given time who never replied.
+func (r *UserRepository) ListUnansweredPrompts(ctx context.Context, before time.Time) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.WithContext(ctx).
+		Where("retention_prompt_at < ? AND archived_at IS NULL", before).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepository) SetRetentionPrompt(ctx context.Context, user *model.User, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("retention_prompt_at", at).Error; err != nil {
+		return fmt.Errorf("set retention prompt: %w", err)
+	}
+	user.RetentionPromptAt = &at
+	return nil
+}
+
+// Archive stops reports for the user; their tasks are kept.
+func (r *UserRepository) Archive(ctx context.Context, user *model.User, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("archived_at", at).Error; err != nil {
+		return fmt.Errorf("archive user: %w", err)
+	}
+	user.ArchivedAt = &at
+	return nil
+}
+
+// ListDueForReport returns users whose next daily report is due; archived users are skipped.
+func (r *UserRepository) ListDueForReport(ctx context.Context, now time.Time) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.WithContext(ctx).
+		Where("archived_at IS NULL AND (next_report_at IS NULL OR next_report_at <= ?)", now).
+		Order("id ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SetReportSchedule stores the user's report interval and the time of their next report.
+func (r *UserRepository) SetTimezone(ctx context.Context, user *model.User, timezone string) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("timezone", timezone).Error; err != nil {
+		return fmt.Errorf("set timezone: %w", err)
+	}
+	user.Timezone = timezone
+	return nil
+}
+
+func (r *UserRepository) SetWorkingHours(ctx context.Context, user *model.User, start, end int) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"work_start_hour": start,
+		"work_end_hour":   end,
+	}).Error; err != nil {
+		return fmt.Errorf("set working hours: %w", err)
+	}
+	user.WorkStartHour = start
+	user.WorkEndHour = end
+	return nil
+}
+
+func (r *UserRepository) SetQuickReplies(ctx context.Context, user *model.User, value string) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("quick_replies", value).Error; err != nil {
+		return fmt.Errorf("set quick replies: %w", err)
+	}
+	user.QuickReplies = value
+	return nil
+}
+
+func (r *UserRepository) SetDeadlineCountdown(ctx context.Context, user *model.User, on bool) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("deadline_countdown", on).Error; err != nil {
+		return fmt.Errorf("set deadline countdown: %w", err)
+	}
+	user.DeadlineCountdown = on
+	return nil
+}
+
+func (r *UserRepository) SetHeatmapImage(ctx context.Context, user *model.User, image bool) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("heatmap_image", image).Error; err != nil {
+		return fmt.Errorf("set heatmap style: %w", err)
+	}
+	user.HeatmapImage = image
+	return nil
+}
+
+func (r *UserRepository) SetPlainText(ctx context.Context, user *model.User, plain bool) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("plain_text", plain).Error; err != nil {
+		return fmt.Errorf("set plain text: %w", err)
+	}
+	user.PlainText = plain
+	return nil
+}
+
+func (r *UserRepository) SetListSorts(ctx context.Context, user *model.User, value string) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("list_sorts", value).Error; err != nil {
+		return fmt.Errorf("set list sorts: %w", err)
+	}
+	user.ListSorts = value
+	return nil
+}
+
+func (r *UserRepository) SetAutoDeleteMinutes(ctx context.Context, user *model.User, minutes int) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("auto_delete_minutes", minutes).Error; err != nil {
+		return fmt.Errorf("set auto delete: %w", err)
+	}
+	user.AutoDeleteMinutes = minutes
+	return nil
+}
+
+func (r *UserRepository) SetWeeklySummary(ctx context.Context, user *model.User, enabled bool) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("weekly_summary", enabled).Error; err != nil {
+		return fmt.Errorf("set weekly summary: %w", err)
+	}
+	user.WeeklySummary = enabled
+	return nil
+}
+
+// FindByUsername looks a user up by their Telegram username, ignoring case and a leading @.
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	var user model.User
+	username = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+	if err := r.db.WithContext(ctx).Where("LOWER(username) = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) SetBuddy(ctx context.Context, user *model.User, buddyID uint, accepted bool) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"buddy_id":       buddyID,
+		"buddy_accepted": accepted,
+	}).Error; err != nil {
+		return fmt.Errorf("set buddy: %w", err)
+	}
+	user.BuddyID = buddyID
+	user.BuddyAccepted = accepted
+	return nil
+}
+
+// SetLinkFailures stores the count of wrong /link codes and until when /link is locked, nil for not locked.
+func (r *UserRepository) SetLinkFailures(ctx context.Context, user *model.User, failures int, lockedUntil *time.Time) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"link_failures":     failures,
+		"link_locked_until": lockedUntil,
+	}).Error; err != nil {
+		return fmt.Errorf("set link failures: %w", err)
+	}
+	user.LinkFailures = failures
+	user.LinkLockedUntil = lockedUntil
+	return nil
+}
+
+// SetDeadlinePolicy stores when the user's date-only deadlines fall due and the grace period after it.
+func (r *UserRepository) SetDeadlinePolicy(ctx context.Context, user *model.User, startOfDay bool, graceHours int) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"deadline_start_of_day": startOfDay,
+		"overdue_grace_hours":   graceHours,
+	}).Error; err != nil {
+		return fmt.Errorf("set deadline policy: %w", err)
+	}
+	user.DeadlineStartOfDay = startOfDay
+	user.OverdueGraceHours = graceHours
+	return nil
+}
+
+// SetLanguage stores the language chosen by the user, empty to follow their Telegram client,
+// and the second language of their reports, empty for none.
+func (r *UserRepository) SetLanguage(ctx context.Context, user *model.User, language, second string) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"language":        language,
+		"second_language": second,
+	}).Error; err != nil {
+		return fmt.Errorf("set language: %w", err)
+	}
+	user.Language = language
+	user.SecondLanguage = second
+	return nil
+}
+
+func (r *UserRepository) SetReportSchedule(ctx context.Context, user *model.User, everyHours int, next time.Time) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"report_every_hours": everyHours,
+		"next_report_at":     next,
+	}).Error; err != nil {
+		return fmt.Errorf("set report schedule: %w", err)
+	}
+	user.ReportEveryHours = everyHours
+	user.NextReportAt = &next
+	return nil
+}
+
+// SetReportDelivery changes how the user's reports arrive and forgets the pinned message
+// of the previous choice.
+func (r *UserRepository) SetReportDelivery(ctx context.Context, user *model.User, delivery string) error {
+	if err := r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"report_delivery":   delivery,
+		"report_message_id": 0,
+	}).Error; err != nil {
+		return fmt.Errorf("set report delivery: %w", err)
+	}
+	user.ReportDelivery = delivery
+	user.ReportMessageID = 0
+	return nil
+}
+
+func (r *UserRepository) SetReportMessage(ctx context.Context, user *model.User, messageID int) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("report_message_id", messageID).Error; err != nil {
+		return fmt.Errorf("set report message: %w", err)
+	}
+	user.ReportMessageID = messageID
+	return nil
+}
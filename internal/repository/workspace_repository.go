@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// WorkspaceRepository manages shared workspaces and their members.
+type WorkspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{db: db}
+}
+
+// Create stores a workspace and adds its owner as the first member.
+func (r *WorkspaceRepository) Create(ctx context.Context, workspace *model.Workspace) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return fmt.Errorf("create workspace: %w", err)
+		}
+		member := model.WorkspaceMember{WorkspaceID: workspace.ID, UserID: workspace.OwnerID, Role: model.RoleOwner}
+		if err := tx.Create(&member).Error; err != nil {
+			return fmt.Errorf("add workspace owner: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WorkspaceRepository) GetByID(ctx context.Context, id uint) (*model.Workspace, error) {
+	var workspace model.Workspace
+	if err := r.db.WithContext(ctx).First(&workspace, id).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *WorkspaceRepository) FindByInviteCode(ctx context.Context, code string) (*model.Workspace, error) {
+	var workspace model.Workspace
+	if err := r.db.WithContext(ctx).Where("invite_code = ?", code).First(&workspace).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// ListByUser returns the workspaces the user is a member of.
+func (r *WorkspaceRepository) ListByUser(ctx context.Context, userID uint) ([]model.Workspace, error) {
+	var workspaces []model.Workspace
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.name ASC").
+		Find(&workspaces).Error; err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID uint, role string) error {
+	member := model.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: role}
+	if err := r.db.WithContext(ctx).Create(&member).Error; err != nil {
+		return fmt.Errorf("add workspace member: %w", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) IsMember(ctx context.Context, workspaceID, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindMember returns the membership of the user in the workspace.
+func (r *WorkspaceRepository) FindMember(ctx context.Context, workspaceID, userID uint) (*model.WorkspaceMember, error) {
+	var member model.WorkspaceMember
+	if err := r.db.WithContext(ctx).Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListMembers returns workspace members with their user profiles.
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID uint) ([]model.WorkspaceMember, error) {
+	var members []model.WorkspaceMember
+	if err := r.db.WithContext(ctx).Preload("User").
+		Where("workspace_id = ?", workspaceID).
+		Order("id ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *WorkspaceRepository) UpdateRole(ctx context.Context, workspaceID, userID uint, role string) error {
+	if err := r.db.WithContext(ctx).Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role).Error; err != nil {
+		return fmt.Errorf("update member role: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember deletes the membership and moves the user back to personal tasks if needed.
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+			Delete(&model.WorkspaceMember{}).Error; err != nil {
+			return fmt.Errorf("remove member: %w", err)
+		}
+		if err := tx.Model(&model.User{}).
+			Where("id = ? AND active_workspace_id = ?", userID, workspaceID).
+			Update("active_workspace_id", 0).Error; err != nil {
+			return fmt.Errorf("reset active workspace: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetActive stores the workspace the user works in; 0 switches back to personal tasks.
+func (r *WorkspaceRepository) SetActive(ctx context.Context, user *model.User, workspaceID uint) error {
+	if err := r.db.WithContext(ctx).Model(user).Update("active_workspace_id", workspaceID).Error; err != nil {
+		return fmt.Errorf("switch workspace: %w", err)
+	}
+	user.ActiveWorkspaceID = workspaceID
+	return nil
+}
+
+// SetReportChat binds the workspace report to a group chat; 0 unbinds it.
+func (r *WorkspaceRepository) SetReportChat(ctx context.Context, workspace *model.Workspace, chatID int64) error {
+	if err := r.db.WithContext(ctx).Model(workspace).Update("report_chat_id", chatID).Error; err != nil {
+		return fmt.Errorf("set report chat: %w", err)
+	}
+	workspace.ReportChatID = chatID
+	return nil
+}
+
+func (r *WorkspaceRepository) ListAll(ctx context.Context) ([]model.Workspace, error) {
+	var workspaces []model.Workspace
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&workspaces).Error; err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+// ListWithReports returns workspaces bound to a group chat or with categories routed to one.
+func (r *WorkspaceRepository) ListWithReports(ctx context.Context) ([]model.Workspace, error) {
+	routed := r.db.Model(&model.Category{}).Select("workspace_id").Where("workspace_id <> ? AND route_chat_id <> ?", 0, 0)
+	var workspaces []model.Workspace
+	if err := r.db.WithContext(ctx).Where("report_chat_id <> ? OR id IN (?)", 0, routed).Order("id ASC").Find(&workspaces).Error; err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+const (
+	linkCodeTTL    = 10 * time.Minute
+	linkCodeLength = 10
+	// After maxLinkFailures wrong codes in a row /link <code> is refused for linkLockout.
+	maxLinkFailures = 5
+	linkLockout     = time.Hour
+)
+
+var (
+	// ErrAlreadyLinked is returned when the user already belongs to the target account.
+	ErrAlreadyLinked = errors.New("account is already linked")
+	// ErrPrimaryUnlink is returned when the account owner tries to detach themselves.
+	ErrPrimaryUnlink = errors.New("primary user cannot unlink the account")
+	// ErrLinkOwnData is returned when linking would hide the user's own tasks, categories or
+	// linked users; the link has to go the other way.
+	ErrLinkOwnData = errors.New("account has its own data")
+	// ErrLinkLocked is returned while the user is locked out of /link after too many wrong codes.
+	ErrLinkLocked = errors.New("too many wrong link codes")
+)
+
+// AccountService links several Telegram users to one shared account.
+type AccountService struct {
+	accountRepo *repository.AccountRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewAccountService(accountRepo *repository.AccountRepository, userRepo *repository.UserRepository) *AccountService {
+	return &AccountService{accountRepo: accountRepo, userRepo: userRepo}
+}
+
+// Owner returns the user whose tasks and categories are shared within the account.
+func (s *AccountService) Owner(ctx context.Context, user *model.User) (*model.User, error) {
+	if user.AccountID == 0 {
+		return user, nil
+	}
+	account, err := s.accountRepo.GetByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("load account: %w", err)
+	}
+	if account.PrimaryUserID == 0 || account.PrimaryUserID == user.ID {
+		return user, nil
+	}
+	return s.userRepo.FindByID(ctx, account.PrimaryUserID)
+}
+
+// IssueLinkCode creates a one-time code that another Telegram user can redeem with /link.
+func (s *AccountService) IssueLinkCode(ctx context.Context, user *model.User, now time.Time) (*model.LinkCode, error) {
+	if user.AccountID == 0 {
+		if _, err := s.accountRepo.CreateForUser(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	code, err := randomCode(inviteAlphabet, linkCodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate link code: %w", err)
+	}
+	link := model.LinkCode{
+		Code:      code,
+		AccountID: user.AccountID,
+		ExpiresAt: now.Add(linkCodeTTL),
+	}
+	if err := s.accountRepo.CreateLinkCode(ctx, &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// Link attaches the user to the account that issued the code. Codes are not case-sensitive;
+// after maxLinkFailures wrong ones in a row the user is locked out for linkLockout. A user
+// with tasks, categories or linked users of their own cannot be linked, as those would be hidden.
+func (s *AccountService) Link(ctx context.Context, user *model.User, code string, now time.Time) error {
+	if user.LinkLockedUntil != nil && now.Before(*user.LinkLockedUntil) {
+		return ErrLinkLocked
+	}
+	link, err := s.accountRepo.ConsumeLinkCode(ctx, strings.ToUpper(code), now)
+	if errors.Is(err, repository.ErrLinkCodeInvalid) {
+		failures, lockedUntil := user.LinkFailures+1, (*time.Time)(nil)
+		if failures >= maxLinkFailures {
+			until := now.Add(linkLockout)
+			failures, lockedUntil = 0, &until
+		}
+		if err := s.userRepo.SetLinkFailures(ctx, user, failures, lockedUntil); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if user.LinkFailures > 0 {
+		if err := s.userRepo.SetLinkFailures(ctx, user, 0, nil); err != nil {
+			return err
+		}
+	}
+	if link.AccountID == user.AccountID {
+		return ErrAlreadyLinked
+	}
+	hasData, err := s.accountRepo.HasOwnData(ctx, user)
+	if err != nil {
+		return err
+	}
+	if hasData {
+		return ErrLinkOwnData
+	}
+	return s.accountRepo.AttachUser(ctx, user, link.AccountID)
+}
+
+// Unlink detaches a secondary user and gives them a fresh account with their own data.
+func (s *AccountService) Unlink(ctx context.Context, user *model.User) error {
+	owner, err := s.Owner(ctx, user)
+	if err != nil {
+		return err
+	}
+	if owner.ID == user.ID {
+		return ErrPrimaryUnlink
+	}
+	_, err = s.accountRepo.CreateForUser(ctx, user)
+	return err
+}
+
+// randomCode builds a random string of length n from the given alphabet.
+func randomCode(alphabet string, n int) (string, error) {
+	buf := make([]byte, n)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range buf {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = alphabet[idx.Int64()]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+func TestLinkExpiredCode(t *testing.T) {
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	other := f.user(2, "Bob")
+	svc := NewAccountService(f.accounts, f.users)
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	link, err := svc.IssueLinkCode(f.ctx, owner, now)
+	if err != nil {
+		t.Fatalf("IssueLinkCode: %v", err)
+	}
+	if err := svc.Link(f.ctx, other, link.Code, now.Add(linkCodeTTL+time.Minute)); !errors.Is(err, repository.ErrLinkCodeInvalid) {
+		t.Fatalf("Link with an expired code = %v, want ErrLinkCodeInvalid", err)
+	}
+	// The expired code is gone, so rewinding the clock does not bring it back.
+	if err := svc.Link(f.ctx, other, link.Code, now); !errors.Is(err, repository.ErrLinkCodeInvalid) {
+		t.Errorf("Link after the expired code was tried = %v, want ErrLinkCodeInvalid", err)
+	}
+}
+
+func TestLinkLockout(t *testing.T) {
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	other := f.user(2, "Bob")
+	svc := NewAccountService(f.accounts, f.users)
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	link, err := svc.IssueLinkCode(f.ctx, owner, now)
+	if err != nil {
+		t.Fatalf("IssueLinkCode: %v", err)
+	}
+	if len(link.Code) != linkCodeLength {
+		t.Errorf("code %q, want %d characters", link.Code, linkCodeLength)
+	}
+	for i := 0; i < maxLinkFailures; i++ {
+		if err := svc.Link(f.ctx, other, "WRONG", now); !errors.Is(err, repository.ErrLinkCodeInvalid) {
+			t.Fatalf("Link with a wrong code = %v, want ErrLinkCodeInvalid", err)
+		}
+	}
+	if err := svc.Link(f.ctx, other, link.Code, now.Add(time.Minute)); !errors.Is(err, ErrLinkLocked) {
+		t.Fatalf("Link while locked out = %v, want ErrLinkLocked", err)
+	}
+	link, err = svc.IssueLinkCode(f.ctx, owner, now.Add(linkLockout))
+	if err != nil {
+		t.Fatalf("IssueLinkCode: %v", err)
+	}
+	if err := svc.Link(f.ctx, other, strings.ToLower(link.Code), now.Add(linkLockout+time.Minute)); err != nil {
+		t.Fatalf("Link after the lockout: %v", err)
+	}
+	if other.AccountID != owner.AccountID {
+		t.Errorf("user attached to account %d, want %d", other.AccountID, owner.AccountID)
+	}
+}
+
+func TestLinkRefusesOwnData(t *testing.T) {
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	busy := f.user(2, "Bob")
+	lead := f.user(3, "Carol")
+	svc := NewAccountService(f.accounts, f.users)
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	link := func(user *model.User) error {
+		t.Helper()
+		code, err := svc.IssueLinkCode(f.ctx, owner, now)
+		if err != nil {
+			t.Fatalf("IssueLinkCode: %v", err)
+		}
+		return svc.Link(f.ctx, user, code.Code, now)
+	}
+
+	f.task(model.Task{UserID: busy.ID, Title: "Купить молоко"})
+	if err := link(busy); !errors.Is(err, ErrLinkOwnData) {
+		t.Errorf("Link with own tasks = %v, want ErrLinkOwnData", err)
+	}
+
+	// Carol's account already has Dave linked to it.
+	code, err := svc.IssueLinkCode(f.ctx, lead, now)
+	if err != nil {
+		t.Fatalf("IssueLinkCode: %v", err)
+	}
+	if err := svc.Link(f.ctx, f.user(4, "Dave"), code.Code, now); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if err := link(lead); !errors.Is(err, ErrLinkOwnData) {
+		t.Errorf("Link with linked users = %v, want ErrLinkOwnData", err)
+	}
+	if err := link(f.user(5, "Erin")); err != nil {
+		t.Errorf("Link of a new user: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"daily-planner/internal/model"
+)
+
+// MaxTaskAttachments caps the files of one task at the size of a Telegram album.
+const MaxTaskAttachments = 10
+
+// ErrTooManyAttachments is returned when a task would get more than MaxTaskAttachments files.
+var ErrTooManyAttachments = errors.New("too many attachments")
+
+// Attach stores files sent for the task.
+func (s *TaskService) Attach(ctx context.Context, user *model.User, taskID uint, attachments []model.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return err
+	}
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return err
+	}
+	existing, err := s.taskRepo.ListAttachments(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if len(existing)+len(attachments) > MaxTaskAttachments {
+		return ErrTooManyAttachments
+	}
+	for i := range attachments {
+		attachments[i].ID = 0
+		attachments[i].TaskID = task.ID
+	}
+	return s.taskRepo.AddAttachments(ctx, attachments)
+}
+
+// Attachments returns the files of the task.
+func (s *TaskService) Attachments(ctx context.Context, user *model.User, taskID uint) ([]model.Attachment, error) {
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskRepo.ListAttachments(ctx, task.ID)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+var (
+	// ErrBuddyUnknown is returned for a username that never wrote to the bot.
+	ErrBuddyUnknown = errors.New("partner has not started the bot")
+	// ErrBuddySelf is returned when users nominate themselves.
+	ErrBuddySelf = errors.New("cannot be your own partner")
+	// ErrNoBuddyRequest is returned when the partner answers a request that was withdrawn.
+	ErrNoBuddyRequest = errors.New("no pending partner request")
+	// ErrNoDeadline is returned when a task without a deadline is flagged for the partner.
+	ErrNoDeadline = errors.New("task has no deadline")
+)
+
+// NominateBuddy asks the user with the given username to become the user's accountability
+// partner. The request replaces the previous partner, who stops getting notifications.
+func (s *AccountService) NominateBuddy(ctx context.Context, user *model.User, username string) (*model.User, error) {
+	buddy, err := s.userRepo.FindByUsername(ctx, username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBuddyUnknown
+	}
+	if err != nil {
+		return nil, err
+	}
+	if buddy.ID == user.ID {
+		return nil, ErrBuddySelf
+	}
+	if err := s.userRepo.SetBuddy(ctx, user, buddy.ID, false); err != nil {
+		return nil, err
+	}
+	return buddy, nil
+}
+
+// AnswerBuddy records the partner's answer to the request of the user with ownerID.
+func (s *AccountService) AnswerBuddy(ctx context.Context, buddy *model.User, ownerID uint, accept bool) (*model.User, error) {
+	owner, err := s.userRepo.FindByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.BuddyID != buddy.ID {
+		return owner, ErrNoBuddyRequest
+	}
+	if accept {
+		return owner, s.userRepo.SetBuddy(ctx, owner, buddy.ID, true)
+	}
+	return owner, s.userRepo.SetBuddy(ctx, owner, 0, false)
+}
+
+// DropBuddy ends the partnership from the owner's side.
+func (s *AccountService) DropBuddy(ctx context.Context, user *model.User) error {
+	return s.userRepo.SetBuddy(ctx, user, 0, false)
+}
+
+// Buddy returns the user's partner, nil when there is none.
+func (s *AccountService) Buddy(ctx context.Context, user *model.User) (*model.User, error) {
+	if user.BuddyID == 0 {
+		return nil, nil
+	}
+	buddy, err := s.userRepo.FindByID(ctx, user.BuddyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return buddy, err
+}
+
+// SetEscalate flags an open task with a deadline so that the partner hears when it stays overdue.
+func (s *TaskService) SetEscalate(ctx context.Context, user *model.User, taskID uint, on bool) (*model.Task, error) {
+	task, err := s.openTask(ctx, user, taskID)
+	if err != nil {
+		return task, err
+	}
+	if on && task.Deadline == nil {
+		return task, ErrNoDeadline
+	}
+	return task, s.taskRepo.SetEscalate(ctx, task, on)
+}
+
+// Escalations lists flagged tasks overdue by more than days whose partner has not heard of
+// their current deadline yet; postponing a task arms it again.
+func (s *ReminderService) Escalations(ctx context.Context, now time.Time, days int) ([]model.Task, error) {
+	return s.taskRepo.ListForEscalation(ctx, overdueBefore(now, days))
+}
+
+// OverdueDays counts the whole days the deadline is behind now.
+func OverdueDays(deadline, now time.Time) int {
+	due := time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)
+	return int(overdueBefore(now, 0).Sub(due) / (24 * time.Hour))
+}
+
+// overdueBefore is the latest deadline that is more than days behind now. Deadlines are
+// stored as midnight UTC of their date.
+func overdueBefore(now time.Time, days int) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -days)
+}
+
+func (s *ReminderService) MarkEscalated(ctx context.Context, task *model.Task) error {
+	return s.taskRepo.MarkEscalated(ctx, task)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestEscalations(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Аня")
+	now := time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time { return ptr(time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)) }
+
+	late := f.task(model.Task{UserID: user.ID, Title: "просрочена на 4 дня", Deadline: day(8), Escalate: true})
+	f.task(model.Task{UserID: user.ID, Title: "просрочена на 3 дня", Deadline: day(9), Escalate: true})
+	f.task(model.Task{UserID: user.ID, Title: "без отметки", Deadline: day(1)})
+	f.task(model.Task{UserID: user.ID, Title: "выполнена", Deadline: day(1), Escalate: true, IsCompleted: true})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	tasks, err := svc.Escalations(f.ctx, now, 3)
+	if err != nil {
+		t.Fatalf("escalations: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != late.ID {
+		t.Fatalf("escalations = %+v, want only task %d", tasks, late.ID)
+	}
+	if days := OverdueDays(*tasks[0].Deadline, now); days != 4 {
+		t.Errorf("overdue days = %d, want 4", days)
+	}
+
+	if err := svc.MarkEscalated(f.ctx, &tasks[0]); err != nil {
+		t.Fatalf("mark escalated: %v", err)
+	}
+	if tasks, _ := svc.Escalations(f.ctx, now, 3); len(tasks) != 0 {
+		t.Errorf("task escalated twice for the same deadline: %+v", tasks)
+	}
+	if err := f.tasks.Postpone(f.ctx, &late, *day(10), now); err != nil {
+		t.Fatalf("postpone: %v", err)
+	}
+	if tasks, _ := svc.Escalations(f.ctx, now.AddDate(0, 0, 3), 3); len(tasks) != 2 {
+		t.Errorf("moved deadline is not escalated again: %+v", tasks)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestApplyCategoryDefaults(t *testing.T) {
+	now := time.Date(2025, time.March, 30, 22, 0, 0, 0, time.Local)
+	category := model.Category{DefaultDeadlineDays: 3, DefaultAlertHours: 12}
+
+	task := model.Task{Title: "отчёт"}
+	applyCategoryDefaults(&task, category, now)
+	if task.Deadline == nil || task.Deadline.Format("2006-01-02") != "2025-04-02" || task.AlertBeforeHours != 12 {
+		t.Errorf("defaults not applied: %+v", task)
+	}
+
+	own := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	task = model.Task{Title: "отчёт", Deadline: &own}
+	applyCategoryDefaults(&task, category, now)
+	if !task.Deadline.Equal(own) {
+		t.Errorf("explicit deadline overridden: %v", task.Deadline)
+	}
+
+	task = model.Task{Title: "оплата", IsRecurring: true}
+	applyCategoryDefaults(&task, category, now)
+	if task.Deadline != nil {
+		t.Errorf("recurring task got a deadline: %v", task.Deadline)
+	}
+}
+
+func TestDueAlertsHonourLeadTime(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Анна")
+	now := time.Now()
+	deadline := func(in time.Duration) *time.Time {
+		at := now.Add(in)
+		return &at
+	}
+	f.task(model.Task{UserID: user.ID, Title: "за двое суток", Deadline: deadline(30 * time.Hour), AlertBeforeHours: 48})
+	f.task(model.Task{UserID: user.ID, Title: "по умолчанию", Deadline: deadline(30 * time.Hour)})
+	f.task(model.Task{UserID: user.ID, Title: "за 12 часов, рано", Deadline: deadline(20 * time.Hour), AlertBeforeHours: 12})
+	f.task(model.Task{UserID: user.ID, Title: "за 12 часов", Deadline: deadline(10 * time.Hour), AlertBeforeHours: 12})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	tasks, err := svc.DueAlerts(f.ctx, now)
+	if err != nil {
+		t.Fatalf("due alerts: %v", err)
+	}
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	if len(titles) != 2 || titles[0] != "за 12 часов" || titles[1] != "за двое суток" {
+		t.Errorf("unexpected alerts: %v", titles)
+	}
+}
+
+func TestDueAlertsFollowDeadlinePolicy(t *testing.T) {
+	f := newFixture(t)
+	endOfDay := f.user(1, "Анна")
+	startOfDay := f.user(2, "Борис")
+	if err := f.users.SetDeadlinePolicy(f.ctx, startOfDay, true, 0); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.Local)
+	tomorrow := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)
+	f.task(model.Task{UserID: endOfDay.ID, Title: "до конца дня", Deadline: &tomorrow})
+	f.task(model.Task{UserID: startOfDay.ID, Title: "с начала дня", Deadline: &tomorrow})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	tasks, err := svc.DueAlerts(f.ctx, now)
+	if err != nil {
+		t.Fatalf("due alerts: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "с начала дня" {
+		t.Errorf("unexpected alerts: %v", tasks)
+	}
+}
@@ -2,20 +2,252 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"daily-planner/internal/model"
 	"daily-planner/internal/repository"
 )
 
+// ErrCategoryNotFound is returned when no category with the given name exists.
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrSameCategory is returned when tasks of a deleted category would move into itself.
+var ErrSameCategory = errors.New("target is the deleted category")
+
+// CategoryDeletion describes where the tasks of a deleted category go.
+type CategoryDeletion struct {
+	TargetID uint // category receiving the tasks, 0 for no category
+	Archive  bool // archive the active tasks as well
+}
+
+// ErrInvalidDefaults is returned for category defaults out of range.
+var ErrInvalidDefaults = errors.New("invalid category defaults")
+
+// Limits of category defaults.
+const (
+	MaxDefaultDeadlineDays = 365
+	MaxDefaultAlertHours   = 7 * 24
+)
+
+// DeletedCategory summarizes a category deletion.
+type DeletedCategory struct {
+	Category model.Category
+	Target   *model.Category // nil when the tasks were left without a category
+	Tasks    []model.Task    // tasks that were active in the category
+	Archived bool
+}
+
 // CategoryService provides helpers around categories.
 type CategoryService struct {
-	repo *repository.CategoryRepository
+	repo         *repository.CategoryRepository
+	workspaceSvc *WorkspaceService
 }
 
-func NewCategoryService(repo *repository.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(repo *repository.CategoryRepository, workspaceSvc *WorkspaceService) *CategoryService {
+	return &CategoryService{repo: repo, workspaceSvc: workspaceSvc}
 }
 
+// List returns the categories of the active scope that are not archived.
 func (s *CategoryService) List(ctx context.Context, user *model.User) ([]model.Category, error) {
-	return s.repo.ListByUser(ctx, user.ID)
+	categories, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	active := categories[:0]
+	for _, category := range categories {
+		if category.ArchivedAt == nil {
+			active = append(active, category)
+		}
+	}
+	return active, nil
+}
+
+// ListAll returns every category of the active scope, archived ones included,
+// for showing the names of tasks that still refer to them.
+func (s *CategoryService) ListAll(ctx context.Context, user *model.User) ([]model.Category, error) {
+	return s.repo.ListByScope(ctx, user.Scope())
+}
+
+// ListArchived returns the archived categories of the active scope.
+func (s *CategoryService) ListArchived(ctx context.Context, user *model.User) ([]model.Category, error) {
+	categories, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	archived := categories[:0]
+	for _, category := range categories {
+		if category.ArchivedAt != nil {
+			archived = append(archived, category)
+		}
+	}
+	return archived, nil
+}
+
+// Archive hides a category from lists and keyboards; its tasks stay as they are.
+func (s *CategoryService) Archive(ctx context.Context, user *model.User, name string, now time.Time) (*model.Category, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.FindByName(ctx, user, name)
+	if err != nil {
+		return nil, err
+	}
+	if category.ArchivedAt != nil {
+		return category, nil
+	}
+	if err := s.repo.SetArchived(ctx, category, &now); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// Restore brings an archived category back.
+func (s *CategoryService) Restore(ctx context.Context, user *model.User, id uint) (*model.Category, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.repo.FindByID(ctx, user.Scope(), id)
+	if err != nil {
+		return nil, err
+	}
+	if category.ArchivedAt == nil {
+		return category, nil
+	}
+	if err := s.repo.SetArchived(ctx, category, nil); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// FindByName looks up a category of the active scope ignoring letter case.
+func (s *CategoryService) FindByName(ctx context.Context, user *model.User, name string) (*model.Category, error) {
+	categories, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	for i := range categories {
+		if strings.EqualFold(strings.TrimSpace(categories[i].Name), name) {
+			return &categories[i], nil
+		}
+	}
+	return nil, ErrCategoryNotFound
+}
+
+// Get returns a category of the active scope.
+func (s *CategoryService) Get(ctx context.Context, user *model.User, id uint) (*model.Category, error) {
+	return s.repo.FindByID(ctx, user.Scope(), id)
+}
+
+// CountActiveTasks counts the open and still repeating tasks of the category.
+func (s *CategoryService) CountActiveTasks(ctx context.Context, category *model.Category) (int64, error) {
+	return s.repo.CountActiveTasks(ctx, category.ID)
+}
+
+// Delete removes a category of the active scope, moving or archiving its tasks atomically.
+func (s *CategoryService) Delete(ctx context.Context, user *model.User, id uint, deletion CategoryDeletion, now time.Time) (*DeletedCategory, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.repo.FindByID(ctx, user.Scope(), id)
+	if err != nil {
+		return nil, err
+	}
+	result := &DeletedCategory{Category: *category, Archived: deletion.Archive}
+	var target *uint
+	if deletion.TargetID != 0 {
+		if deletion.TargetID == category.ID {
+			return nil, ErrSameCategory
+		}
+		if result.Target, err = s.repo.FindByID(ctx, user.Scope(), deletion.TargetID); err != nil {
+			return nil, err
+		}
+		target = &result.Target.ID
+	}
+	var archiveAt *time.Time
+	if deletion.Archive {
+		archiveAt = &now
+	}
+	if result.Tasks, err = s.repo.Delete(ctx, category, target, archiveAt); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetDefaults changes the deadline and alert lead time applied to new tasks of the category.
+func (s *CategoryService) SetDefaults(ctx context.Context, user *model.User, name string, deadlineDays, alertHours int) (*model.Category, error) {
+	if deadlineDays < 0 || deadlineDays > MaxDefaultDeadlineDays || alertHours < 0 || alertHours > MaxDefaultAlertHours {
+		return nil, ErrInvalidDefaults
+	}
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.FindByName(ctx, user, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetDefaults(ctx, category, deadlineDays, alertHours); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// ErrInvalidStyle is returned for a category emoji that is not a short emoji or an unknown color.
+var ErrInvalidStyle = errors.New("invalid category style")
+
+// maxEmojiRunes leaves room for emoji built of several code points, such as flags and ZWJ sequences.
+const maxEmojiRunes = 8
+
+// SetStyle changes the emoji and the color mark of a category of the active scope;
+// an empty color removes the mark.
+func (s *CategoryService) SetStyle(ctx context.Context, user *model.User, id uint, emoji, color string) (*model.Category, error) {
+	emoji = strings.TrimSpace(emoji)
+	if !ValidEmoji(emoji) || (color != "" && !slices.Contains(model.CategoryColors, color)) {
+		return nil, ErrInvalidStyle
+	}
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.repo.FindByID(ctx, user.Scope(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetStyle(ctx, category, emoji, color); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// ValidEmoji reports whether value can be a category emoji: a few code points without letters, digits or spaces.
+func ValidEmoji(value string) bool {
+	runes := []rune(value)
+	if len(runes) == 0 || len(runes) > maxEmojiRunes {
+		return false
+	}
+	for _, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || r < utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Route delivers reminders of the category to another chat; chatID 0 restores the default.
+func (s *CategoryService) Route(ctx context.Context, user *model.User, name string, chatID int64) (*model.Category, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	category, err := s.FindByName(ctx, user, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRoute(ctx, category, chatID); err != nil {
+		return nil, err
+	}
+	return category, nil
 }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// languishFactor flags categories whose median time to done is this many times the overall one.
+const languishFactor = 2
+
+// CompletionTiming describes how long tasks of one category take from creation to completion.
+type CompletionTiming struct {
+	Category string // empty for tasks without a category
+	Count    int
+	Median   time.Duration
+	P90      time.Duration
+	Languish bool // tasks here take much longer than usual
+}
+
+// CompletionStats is the time to done across all tasks and per category, slowest first.
+type CompletionStats struct {
+	Overall    CompletionTiming
+	Categories []CompletionTiming
+}
+
+// CompletionTimes reports time to done for one-time tasks of the user's active scope
+// completed since the given time.
+func (s *TaskService) CompletionTimes(ctx context.Context, user *model.User, since time.Time) (CompletionStats, error) {
+	scope := user.Scope()
+	tasks, err := s.taskRepo.ListCompletedSince(ctx, scope, since)
+	if err != nil {
+		return CompletionStats{}, err
+	}
+	categories, err := s.categoryRepo.ListByScope(ctx, scope)
+	if err != nil {
+		return CompletionStats{}, err
+	}
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = strings.TrimSpace(category.Name)
+	}
+
+	byCategory := make(map[string][]time.Duration)
+	var all []time.Duration
+	for _, task := range tasks {
+		if task.IsRecurring || !task.IsCompleted || task.LastCompletedAt == nil {
+			continue
+		}
+		took := task.LastCompletedAt.Sub(task.CreatedAt)
+		if took < 0 {
+			took = 0
+		}
+		name := ""
+		if task.CategoryID != nil {
+			name = names[*task.CategoryID]
+		}
+		byCategory[name] = append(byCategory[name], took)
+		all = append(all, took)
+	}
+	if len(all) == 0 {
+		return CompletionStats{}, nil
+	}
+
+	overall := timing("", all)
+	timings := make([]CompletionTiming, 0, len(byCategory))
+	for name, durations := range byCategory {
+		entry := timing(name, durations)
+		// A single slow task is not a pattern.
+		entry.Languish = len(byCategory) > 1 && entry.Count > 1 && entry.Median >= languishFactor*overall.Median
+		timings = append(timings, entry)
+	}
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Median != timings[j].Median {
+			return timings[i].Median > timings[j].Median
+		}
+		return timings[i].Category < timings[j].Category
+	})
+	return CompletionStats{Overall: overall, Categories: timings}, nil
+}
+
+func timing(category string, durations []time.Duration) CompletionTiming {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return CompletionTiming{
+		Category: category,
+		Count:    len(durations),
+		Median:   percentile(durations, 50),
+		P90:      percentile(durations, 90),
+	}
+}
+
+// percentile picks the nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// MonthCompletions counts completions in the user's active scope per day of the month that
+// contains month, in month's location; index 0 is the 1st. A recurring task counts on the
+// day of its latest completion only.
+func (s *TaskService) MonthCompletions(ctx context.Context, user *model.User, month time.Time) ([]int, error) {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	next := first.AddDate(0, 1, 0)
+	tasks, err := s.taskRepo.ListCompletedSince(ctx, user.Scope(), storedTime(first))
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]int, daysInMonth(first.Month(), first.Year()))
+	for _, task := range tasks {
+		done := task.LastCompletedAt.In(first.Location())
+		if done.Before(next) {
+			counts[done.Day()-1]++
+		}
+	}
+	return counts, nil
+}
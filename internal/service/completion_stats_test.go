@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestCompletionTimes(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Анна")
+	home := f.category(user.Scope(), "Дом")
+	docs := f.category(user.Scope(), "Документы")
+	created := date(2025, time.March, 1, 9)
+
+	done := func(category *uint, took time.Duration) {
+		f.task(model.Task{UserID: user.ID, Title: "задача", CategoryID: category, CreatedAt: created,
+			IsCompleted: true, LastCompletedAt: ptr(created.Add(took))})
+	}
+	for _, hours := range []int{1, 2, 3, 5} {
+		done(home, time.Duration(hours)*time.Hour)
+	}
+	done(docs, 10*24*time.Hour)
+	done(docs, 20*24*time.Hour)
+	done(nil, 4*time.Hour)
+	// Open, recurring and long-ago tasks are left out.
+	f.task(model.Task{UserID: user.ID, Title: "открыта", CategoryID: home, CreatedAt: created})
+	f.task(model.Task{UserID: user.ID, Title: "регулярная", IsRecurring: true, RecurDay: 5, CreatedAt: created, LastCompletedAt: ptr(created.Add(90 * 24 * time.Hour))})
+	done(home, -time.Hour) // completed before the period below starts
+
+	svc := NewTaskService(f.tasks, f.categories, nil, nil, nil)
+	stats, err := svc.CompletionTimes(f.ctx, user, created)
+	if err != nil {
+		t.Fatalf("completion times: %v", err)
+	}
+	if stats.Overall.Count != 7 || stats.Overall.Median != 4*time.Hour || stats.Overall.P90 != 20*24*time.Hour {
+		t.Errorf("overall = %+v", stats.Overall)
+	}
+	if len(stats.Categories) != 3 {
+		t.Fatalf("categories = %+v", stats.Categories)
+	}
+	slowest := stats.Categories[0]
+	if slowest.Category != "Документы" || !slowest.Languish || slowest.Median != 10*24*time.Hour {
+		t.Errorf("slowest category = %+v, want languishing documents", slowest)
+	}
+	if fastest := stats.Categories[2]; fastest.Category != "Дом" || fastest.Languish || fastest.Median != 2*time.Hour {
+		t.Errorf("fastest category = %+v", fastest)
+	}
+	if uncategorized := stats.Categories[1]; uncategorized.Category != "" || uncategorized.Languish {
+		t.Errorf("single uncategorized task flagged or misplaced: %+v", uncategorized)
+	}
+}
+
+func TestListCompletedInUserZone(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Анна")
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// 22:30 UTC on the 9th is already 01:30 on the 10th in Moscow; times are stored in the server's zone.
+	f.task(model.Task{UserID: user.ID, Title: "ночная", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 9, 22).Add(30 * time.Minute).In(time.Local))})
+	f.task(model.Task{UserID: user.ID, Title: "вчерашняя", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 9, 20).In(time.Local))})
+
+	svc := NewTaskService(f.tasks, f.categories, nil, nil, nil)
+	tasks, err := svc.ListCompleted(f.ctx, user, time.Date(2025, time.March, 10, 0, 0, 0, 0, moscow))
+	if err != nil {
+		t.Fatalf("ListCompleted: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "ночная" {
+		t.Errorf("completed since Moscow midnight = %v, want the task done at 01:30 Moscow time", tasks)
+	}
+}
+
+func TestMonthCompletionsInUserZone(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Анна")
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// 22:30 UTC on the last day of February is 01:30 on March 1 in Moscow.
+	f.task(model.Task{UserID: user.ID, Title: "ночная", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.February, 28, 22).Add(30 * time.Minute).In(time.Local))})
+	f.task(model.Task{UserID: user.ID, Title: "февральская", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.February, 28, 20).In(time.Local))})
+
+	svc := NewTaskService(f.tasks, f.categories, nil, nil, nil)
+	counts, err := svc.MonthCompletions(f.ctx, user, time.Date(2025, time.March, 10, 12, 0, 0, 0, moscow))
+	if err != nil {
+		t.Fatalf("MonthCompletions: %v", err)
+	}
+	if len(counts) != 31 || counts[0] != 1 {
+		t.Errorf("March counts = %v, want the 01:30 Moscow completion on the 1st", counts)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// ErrInvalidDate is returned for contact dates that do not exist in any year.
+var ErrInvalidDate = errors.New("invalid date")
+
+// contactReminderHour is the deadline hour of reminder tasks, so the deadline alert
+// arrives the evening before and the task is not overdue during the day itself.
+const contactReminderHour = 20
+
+// contactWeek is how far ahead the weekly summary looks for dates.
+const contactWeek = 7
+
+var weekdayShort = [...]string{i18n.N("вс"), i18n.N("пн"), i18n.N("вт"), i18n.N("ср"), i18n.N("чт"), i18n.N("пт"), i18n.N("сб")}
+
+// ContactInput describes a person and their yearly date.
+type ContactInput struct {
+	Name     string
+	Occasion string
+	Month    int
+	Day      int
+	Year     int
+}
+
+// ContactService keeps birthdays and other yearly dates and turns each upcoming
+// occurrence into a task with a deadline.
+type ContactService struct {
+	contactRepo *repository.ContactRepository
+	taskRepo    *repository.TaskRepository
+	userRepo    *repository.UserRepository
+	quotaSvc    *QuotaService
+}
+
+func NewContactService(contactRepo *repository.ContactRepository, taskRepo *repository.TaskRepository, userRepo *repository.UserRepository, quotaSvc *QuotaService) *ContactService {
+	return &ContactService{contactRepo: contactRepo, taskRepo: taskRepo, userRepo: userRepo, quotaSvc: quotaSvc}
+}
+
+// Add stores a contact and creates the reminder for its next occurrence.
+func (s *ContactService) Add(ctx context.Context, user *model.User, input ContactInput, now time.Time) (*model.Contact, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	// 2000 is a leap year, so 29 February is accepted.
+	if input.Month < 1 || input.Month > 12 || input.Day < 1 || input.Day > daysInMonth(time.Month(input.Month), 2000) {
+		return nil, ErrInvalidDate
+	}
+	if input.Year != 0 && (input.Year < 1900 || input.Year > now.Year()) {
+		return nil, ErrInvalidDate
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+		return nil, err
+	}
+
+	contact := model.Contact{
+		UserID:   user.ID,
+		Name:     name,
+		Occasion: strings.TrimSpace(input.Occasion),
+		Month:    input.Month,
+		Day:      input.Day,
+		Year:     input.Year,
+	}
+	if err := s.contactRepo.Create(ctx, &contact); err != nil {
+		return nil, err
+	}
+	if err := s.ensureReminder(ctx, &contact, now); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (s *ContactService) List(ctx context.Context, user *model.User) ([]model.Contact, error) {
+	return s.contactRepo.ListByUser(ctx, user.ID)
+}
+
+// Remove deletes a contact together with its open reminder task.
+func (s *ContactService) Remove(ctx context.Context, user *model.User, id uint) (bool, error) {
+	contact, err := s.contactRepo.FindByID(ctx, user.ID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := s.dropReminder(ctx, contact); err != nil {
+		return false, err
+	}
+	if err := s.contactRepo.Delete(ctx, contact); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SyncReminders creates reminders for the next occurrence once the previous one has passed.
+// Contacts of archived users are skipped.
+func (s *ContactService) SyncReminders(ctx context.Context, now time.Time) error {
+	contacts, err := s.contactRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	owners := make(map[uint]*model.User)
+	for i := range contacts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		contact := &contacts[i]
+		owner, ok := owners[contact.UserID]
+		if !ok {
+			user, err := s.userRepo.FindByID(ctx, contact.UserID)
+			if err != nil {
+				return err
+			}
+			if user.ArchivedAt == nil {
+				owner = user
+			}
+			owners[contact.UserID] = owner
+		}
+		if owner == nil {
+			continue
+		}
+		if err := s.ensureReminder(i18n.WithLang(ctx, owner.Lang()), contact, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureReminder replaces the reminder task when the contact's next occurrence moved on.
+// A reminder the user deleted is not recreated until the next year.
+func (s *ContactService) ensureReminder(ctx context.Context, contact *model.Contact, now time.Time) error {
+	next := nextOccurrence(*contact, now)
+	if contact.ReminderDate != nil && contact.ReminderDate.Equal(next) {
+		return nil
+	}
+	if err := s.dropReminder(ctx, contact); err != nil {
+		return err
+	}
+
+	deadline := next.Add(contactReminderHour * time.Hour)
+	task := model.Task{
+		UserID:   contact.UserID,
+		Title:    reminderTitle(i18n.FromContext(ctx), *contact, next.Year()),
+		Deadline: &deadline,
+	}
+	if err := s.taskRepo.Create(ctx, &task); err != nil {
+		return err
+	}
+	contact.TaskID = &task.ID
+	contact.ReminderDate = &next
+	return s.contactRepo.Save(ctx, contact)
+}
+
+// dropReminder deletes the contact's reminder task unless it was already completed.
+func (s *ContactService) dropReminder(ctx context.Context, contact *model.Contact) error {
+	if contact.TaskID == nil {
+		return nil
+	}
+	scope := model.PersonalScope(contact.UserID)
+	task, err := s.taskRepo.FindByID(ctx, scope, *contact.TaskID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if task.IsCompleted {
+		return nil
+	}
+	return s.taskRepo.Delete(ctx, scope, task.ID)
+}
+
+// WeeklyReport lists the user's dates in the coming week for the weekly summary; it is
+// empty when there are none.
+func (s *ContactService) WeeklyReport(ctx context.Context, user *model.User, now time.Time) (string, error) {
+	contacts, err := s.contactRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+	today := startOfDay(now)
+	end := today.AddDate(0, 0, contactWeek)
+
+	type upcoming struct {
+		date    time.Time
+		contact model.Contact
+	}
+	var week []upcoming
+	for _, contact := range contacts {
+		if date := nextOccurrence(contact, now); date.Before(end) {
+			week = append(week, upcoming{date: date, contact: contact})
+		}
+	}
+	if len(week) == 0 {
+		return "", nil
+	}
+	sort.SliceStable(week, func(i, j int) bool { return week[i].date.Before(week[j].date) })
+
+	lang := i18n.FromContext(ctx)
+	var builder strings.Builder
+	builder.WriteString(lang.T("🎂 <b>Дни рождения на этой неделе</b>") + "\n")
+	for _, entry := range week {
+		builder.WriteString(fmt.Sprintf("• %s, %s — %s\n", entry.date.Format("02.01"), lang.T(weekdayShort[entry.date.Weekday()]),
+			html.EscapeString(ContactLabel(lang, entry.contact, entry.date.Year()))))
+	}
+	return strings.TrimSpace(builder.String()), nil
+}
+
+// ContactLabel describes the contact's occurrence in the given year.
+func ContactLabel(lang i18n.Lang, contact model.Contact, year int) string {
+	if contact.IsBirthday() {
+		if contact.Year != 0 {
+			return lang.Tf("%s, исполнится %d", contact.Name, year-contact.Year)
+		}
+		return contact.Name
+	}
+	label := fmt.Sprintf("%s: %s", contact.Name, contact.Occasion)
+	if contact.Year != 0 {
+		label += " " + lang.Tf("(с %d г.)", contact.Year)
+	}
+	return label
+}
+
+func reminderTitle(lang i18n.Lang, contact model.Contact, year int) string {
+	if contact.IsBirthday() {
+		return lang.Tf("🎂 День рождения: %s", ContactLabel(lang, contact, year))
+	}
+	return "📅 " + ContactLabel(lang, contact, year)
+}
+
+// nextOccurrence returns the contact's date today or later; 29 February falls
+// on the 28th in other years.
+func nextOccurrence(contact model.Contact, now time.Time) time.Time {
+	today := startOfDay(now)
+	date := occurrenceIn(contact, today.Year(), now.Location())
+	if date.Before(today) {
+		date = occurrenceIn(contact, today.Year()+1, now.Location())
+	}
+	return date
+}
+
+func occurrenceIn(contact model.Contact, year int, loc *time.Location) time.Time {
+	month := time.Month(contact.Month)
+	day := contact.Day
+	if last := daysInMonth(month, year); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newContactService(f *fixture) *ContactService {
+	quotaSvc := NewQuotaService(f.tasks, f.categories, f.users, Limits{}, nil)
+	return NewContactService(f.contacts, f.tasks, f.users, quotaSvc)
+}
+
+func TestContactRemindersRollOver(t *testing.T) {
+	f := newFixture(t)
+	svc := newContactService(f)
+	user := f.user(1, "Анна")
+
+	contact, err := svc.Add(f.ctx, user, ContactInput{Name: "Маша", Month: 2, Day: 29, Year: 1996}, date(2025, time.February, 10, 9))
+	if err != nil {
+		t.Fatalf("add contact: %v", err)
+	}
+	first, err := f.tasks.FindByID(f.ctx, user.Scope(), *contact.TaskID)
+	if err != nil {
+		t.Fatalf("find reminder: %v", err)
+	}
+	// 2025 is not a leap year, so the birthday falls on 28 February.
+	if want := date(2025, time.February, 28, contactReminderHour); !first.Deadline.Equal(want) || first.Title != "🎂 День рождения: Маша, исполнится 29" {
+		t.Errorf("unexpected reminder: %q at %s", first.Title, first.Deadline)
+	}
+
+	if err := svc.SyncReminders(f.ctx, date(2025, time.February, 28, 21)); err != nil {
+		t.Fatalf("sync on the day: %v", err)
+	}
+	if err := svc.SyncReminders(f.ctx, date(2025, time.March, 1, 0)); err != nil {
+		t.Fatalf("sync after the day: %v", err)
+	}
+	contacts, err := svc.List(f.ctx, user)
+	if err != nil || len(contacts) != 1 {
+		t.Fatalf("list contacts: %v %v", contacts, err)
+	}
+	next, err := f.tasks.FindByID(f.ctx, user.Scope(), *contacts[0].TaskID)
+	if err != nil {
+		t.Fatalf("find next reminder: %v", err)
+	}
+	if want := date(2026, time.February, 28, contactReminderHour); !next.Deadline.Equal(want) {
+		t.Errorf("next reminder at %s, want %s", next.Deadline, want)
+	}
+	if _, err := f.tasks.FindByID(f.ctx, user.Scope(), first.ID); err == nil {
+		t.Error("reminder for the past occurrence was not removed")
+	}
+}
+
+func TestContactWeeklyReportGolden(t *testing.T) {
+	f := newFixture(t)
+	svc := newContactService(f)
+	user := f.user(1, "Анна")
+	now := date(2025, time.December, 29, 9)
+
+	for _, input := range []ContactInput{
+		{Name: "Маша", Month: 1, Day: 2, Year: 1990},
+		{Name: "Мама и папа", Occasion: "годовщина свадьбы", Month: 12, Day: 31, Year: 1985},
+		{Name: "Коля", Month: 12, Day: 30},
+		{Name: "Уже прошло", Month: 12, Day: 28},
+		{Name: "Через месяц", Month: 1, Day: 29},
+	} {
+		if _, err := svc.Add(f.ctx, user, input, now); err != nil {
+			t.Fatalf("add %s: %v", input.Name, err)
+		}
+	}
+
+	got, err := svc.WeeklyReport(f.ctx, user, now)
+	if err != nil {
+		t.Fatalf("weekly report: %v", err)
+	}
+	assertGolden(t, "contacts_weekly", got)
+
+	other := f.user(2, "Борис")
+	if empty, err := svc.WeeklyReport(f.ctx, other, now); err != nil || empty != "" {
+		t.Errorf("report without contacts = %q, %v; want empty", empty, err)
+	}
+}
+
+func TestContactRejectsImpossibleDates(t *testing.T) {
+	f := newFixture(t)
+	svc := newContactService(f)
+	user := f.user(1, "Анна")
+	for _, input := range []ContactInput{
+		{Name: "Никто", Month: 2, Day: 30},
+		{Name: "Никто", Month: 13, Day: 1},
+		{Name: "Из будущего", Month: 1, Day: 1, Year: 2100},
+	} {
+		if _, err := svc.Add(f.ctx, user, input, date(2025, time.March, 1, 9)); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("Add(%+v) error = %v, want ErrInvalidDate", input, err)
+		}
+	}
+}
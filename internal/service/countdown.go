@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+const (
+	// CountdownLead is how long before a deadline its reminders start showing the time left.
+	CountdownLead = time.Hour
+	// CountdownStep is how often a running countdown is redrawn.
+	CountdownStep = 10 * time.Minute
+)
+
+// CountdownLeft returns the whole minutes left to the deadline of an open one-time task
+// and reports whether the deadline is close enough for a countdown.
+func CountdownLeft(task model.Task, now time.Time) (int, bool) {
+	if task.IsRecurring || task.IsCompleted || task.Deadline == nil {
+		return 0, false
+	}
+	left := task.Deadline.Sub(now)
+	if left <= 0 || left > CountdownLead {
+		return 0, false
+	}
+	return int(math.Ceil(left.Minutes())), true
+}
+
+// TrackCountdown tracks a reminder or alert message like TrackMessage and keeps the time
+// left to the deadline in it up to date; kind is model.CountdownAlert or model.CountdownReminder.
+func (s *TaskService) TrackCountdown(ctx context.Context, task *model.Task, chatID int64, messageID int, kind string) error {
+	return s.messageRepo.Save(ctx, &model.TaskMessage{ChatID: chatID, MessageID: messageID, TaskID: task.ID, WorkspaceID: task.WorkspaceID, Countdown: kind})
+}
+
+// Countdowns returns the messages whose countdown is running.
+func (s *TaskService) Countdowns(ctx context.Context) ([]model.TaskMessage, error) {
+	return s.messageRepo.ListCountdowns(ctx)
+}
+
+// CountdownTask returns the task a countdown message shows to user, the owner of the chat.
+func (s *TaskService) CountdownTask(ctx context.Context, user *model.User, message model.TaskMessage) (*model.Task, error) {
+	_, task, err := s.taskByMessage(ctx, user, message.ChatID, message.MessageID)
+	return task, err
+}
+
+// StopCountdown leaves the message as it is from now on.
+func (s *TaskService) StopCountdown(ctx context.Context, message model.TaskMessage) error {
+	return s.messageRepo.StopCountdowns(ctx, message.ChatID, message.MessageID)
+}
+
+// StopCountdowns stops every countdown running in the chat.
+func (s *TaskService) StopCountdowns(ctx context.Context, chatID int64) error {
+	return s.messageRepo.StopCountdowns(ctx, chatID, 0)
+}
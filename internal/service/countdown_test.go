@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestCountdownLeft(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 14, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		deadline := now.Add(d)
+		return &deadline
+	}
+
+	tests := []struct {
+		name    string
+		task    model.Task
+		minutes int
+		live    bool
+	}{
+		{"no deadline", model.Task{}, 0, false},
+		{"due later today", model.Task{Deadline: at(3 * time.Hour)}, 0, false},
+		{"due in an hour", model.Task{Deadline: at(time.Hour)}, 60, true},
+		{"rounds up", model.Task{Deadline: at(39*time.Minute + 10*time.Second)}, 40, true},
+		{"overdue", model.Task{Deadline: at(-time.Minute)}, 0, false},
+		{"completed", model.Task{Deadline: at(30 * time.Minute), IsCompleted: true}, 0, false},
+		{"recurring", model.Task{Deadline: at(30 * time.Minute), IsRecurring: true}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minutes, live := CountdownLeft(tt.task, now)
+			if minutes != tt.minutes || live != tt.live {
+				t.Errorf("got %d, %t; want %d, %t", minutes, live, tt.minutes, tt.live)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+const (
+	// maxCounterGoal keeps goals within what a row of +1 presses can reach.
+	maxCounterGoal = 1000
+	progressCells  = 10
+)
+
+// CounterProgress is a counter together with today's value.
+type CounterProgress struct {
+	Counter model.Counter
+	Done    int
+}
+
+// CounterService manages counter habits that reset every day.
+type CounterService struct {
+	repo *repository.CounterRepository
+}
+
+func NewCounterService(repo *repository.CounterRepository) *CounterService {
+	return &CounterService{repo: repo}
+}
+
+// Add creates a counter with a daily goal.
+func (s *CounterService) Add(ctx context.Context, user *model.User, name string, goal int) (*model.Counter, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if goal < 1 || goal > maxCounterGoal {
+		return nil, fmt.Errorf("goal must be between 1 and %d", maxCounterGoal)
+	}
+	counter := model.Counter{UserID: user.ID, Name: name, Goal: goal}
+	if err := s.repo.Create(ctx, &counter); err != nil {
+		return nil, err
+	}
+	return &counter, nil
+}
+
+// Remove deletes a counter with its history.
+func (s *CounterService) Remove(ctx context.Context, user *model.User, id uint) (bool, error) {
+	return s.repo.Delete(ctx, user.ID, id)
+}
+
+// Increment adds amount to today's value of the counter.
+func (s *CounterService) Increment(ctx context.Context, user *model.User, id uint, amount int, now time.Time) error {
+	counter, err := s.repo.FindByID(ctx, user.ID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddEntry(ctx, &model.CounterEntry{CounterID: counter.ID, UserID: user.ID, Amount: amount, CreatedAt: now})
+}
+
+// Today returns every counter of the user with its value since midnight in the user's time zone.
+func (s *CounterService) Today(ctx context.Context, user *model.User, now time.Time) ([]CounterProgress, error) {
+	return counterProgress(ctx, s.repo, *user, now)
+}
+
+func counterProgress(ctx context.Context, repo *repository.CounterRepository, user model.User, now time.Time) ([]CounterProgress, error) {
+	counters, err := repo.ListByUser(ctx, user.ID)
+	if err != nil || len(counters) == 0 {
+		return nil, err
+	}
+	// Midnight is found in the user's zone but passed on in now's, the zone entries are
+	// stored in, since SQLite compares times as text.
+	midnight := startOfDay(now.In(user.Location())).In(now.Location())
+	totals, err := repo.SumSince(ctx, user.ID, midnight)
+	if err != nil {
+		return nil, err
+	}
+	progress := make([]CounterProgress, len(counters))
+	for i, counter := range counters {
+		progress[i] = CounterProgress{Counter: counter, Done: totals[counter.ID]}
+	}
+	return progress, nil
+}
+
+// ProgressBar renders today's value as a ten-cell bar, e.g. "▓▓▓▓▓░░░░░ 4/8".
+func ProgressBar(progress CounterProgress) string {
+	filled := progressCells
+	if progress.Done < progress.Counter.Goal {
+		filled = progress.Done * progressCells / progress.Counter.Goal
+	}
+	bar := strings.Repeat("▓", filled) + strings.Repeat("░", progressCells-filled)
+	text := fmt.Sprintf("%s %d/%d", bar, progress.Done, progress.Counter.Goal)
+	if progress.Done >= progress.Counter.Goal {
+		text += " ✅"
+	}
+	return text
+}
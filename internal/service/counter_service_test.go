@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCounterTodayInUserZone(t *testing.T) {
+	f := newFixture(t)
+	svc := NewCounterService(f.counters)
+	user := f.user(1, "Анна")
+	if err := f.users.SetTimezone(f.ctx, user, "Asia/Novosibirsk"); err != nil {
+		t.Fatalf("set timezone: %v", err)
+	}
+	water, err := svc.Add(f.ctx, user, "Вода", 8)
+	if err != nil {
+		t.Fatalf("add counter: %v", err)
+	}
+	// 20:00 UTC on the 9th is already 03:00 on the 10th in Novosibirsk.
+	svc.Increment(f.ctx, user, water.ID, 2, date(2025, time.March, 9, 20))
+	svc.Increment(f.ctx, user, water.ID, 5, date(2025, time.March, 9, 16))
+
+	progress, err := svc.Today(f.ctx, user, date(2025, time.March, 10, 9))
+	if err != nil || len(progress) != 1 {
+		t.Fatalf("Today = %v, %v", progress, err)
+	}
+	if progress[0].Done != 2 {
+		t.Errorf("done today = %d, want 2: the day starts at midnight in the user's zone", progress[0].Done)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// MaxGraceHours caps the overdue grace period: the end of the deadline day plus two days.
+const MaxGraceHours = 48
+
+// ErrInvalidGrace is returned for a grace period that is not 0–48 hours or a time of day.
+var ErrInvalidGrace = errors.New("invalid grace period")
+
+// OverdueAt is the moment a deadline counts as missed. A date-only deadline, stored as
+// midnight UTC, lasts until the end of that day in loc, or only until its start with
+// policy.StartOfDay; the grace period pushes the moment later. Every overdue mark,
+// report line, alert and deadline order is decided by it.
+func OverdueAt(deadline time.Time, loc *time.Location, policy model.DeadlinePolicy) time.Time {
+	return DueAt(deadline, loc, policy).Add(policy.Grace)
+}
+
+// DueAt is the moment the deadline falls due, before any grace period.
+func DueAt(deadline time.Time, loc *time.Location, policy model.DeadlinePolicy) time.Time {
+	utc := deadline.UTC()
+	if utc.Hour() != 0 || utc.Minute() != 0 || utc.Second() != 0 || utc.Nanosecond() != 0 {
+		return deadline
+	}
+	day := utc.Day()
+	if !policy.StartOfDay {
+		day++
+	}
+	return time.Date(utc.Year(), utc.Month(), day, 0, 0, 0, 0, loc)
+}
+
+// Overdue reports whether the deadline is missed as of now, judged in now's time zone.
+func Overdue(deadline *time.Time, now time.Time, policy model.DeadlinePolicy) bool {
+	return deadline != nil && !now.Before(OverdueAt(*deadline, now.Location(), policy))
+}
+
+// DaysLeft counts calendar days from today to the deadline day, both in now's time zone:
+// 0 on the day itself, 1 the day before.
+func DaysLeft(deadline, now time.Time) int {
+	day := DueAt(deadline, now.Location(), model.DeadlinePolicy{StartOfDay: true})
+	return daysBetween(now, day.In(now.Location()))
+}
+
+// CompareDeadlines is -1, 0 or 1 as deadline a falls due before, together with or after
+// b; a missing deadline comes after any other.
+func CompareDeadlines(a, b *time.Time, loc *time.Location, policy model.DeadlinePolicy) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return DueAt(*a, loc, policy).Compare(DueAt(*b, loc, policy))
+}
+
+// ParseGrace reads a grace period in hours after the end of the deadline day: "3", "3h",
+// "3ч" or the time of day it lasts until, "03:00". "off" and "0" mean no grace.
+func ParseGrace(text string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(text))
+	if value == "off" || value == "нет" {
+		return 0, nil
+	}
+	if clock, err := time.Parse("15:04", value); err == nil {
+		if clock.Minute() != 0 {
+			return 0, ErrInvalidGrace
+		}
+		return clock.Hour(), nil
+	}
+	hours, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(value, "h"), "ч"))
+	if err != nil || hours < 0 || hours > MaxGraceHours {
+		return 0, ErrInvalidGrace
+	}
+	return hours, nil
+}
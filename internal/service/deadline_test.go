@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestOverdue(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	dateOnly := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	timed := time.Date(2025, time.March, 10, 15, 30, 0, 0, msk)
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		now      time.Time
+		policy   model.DeadlinePolicy
+		want     bool
+	}{
+		{"deadline day itself", dateOnly, time.Date(2025, time.March, 10, 23, 59, 0, 0, msk), model.DeadlinePolicy{}, false},
+		{"local midnight after it", dateOnly, time.Date(2025, time.March, 11, 0, 0, 0, 0, msk), model.DeadlinePolicy{}, true},
+		{"within grace", dateOnly, time.Date(2025, time.March, 11, 2, 59, 0, 0, msk), model.DeadlinePolicy{Grace: 3 * time.Hour}, false},
+		{"grace is over", dateOnly, time.Date(2025, time.March, 11, 3, 0, 0, 0, msk), model.DeadlinePolicy{Grace: 3 * time.Hour}, true},
+		{"day before, start of day", dateOnly, time.Date(2025, time.March, 9, 23, 59, 0, 0, msk), model.DeadlinePolicy{StartOfDay: true}, false},
+		{"deadline day, start of day", dateOnly, time.Date(2025, time.March, 10, 0, 0, 0, 0, msk), model.DeadlinePolicy{StartOfDay: true}, true},
+		{"timed deadline", timed, time.Date(2025, time.March, 10, 15, 31, 0, 0, msk), model.DeadlinePolicy{}, true},
+		{"timed deadline, start of day", timed, time.Date(2025, time.March, 10, 15, 29, 0, 0, msk), model.DeadlinePolicy{StartOfDay: true}, false},
+		{"timed deadline with grace", timed, time.Date(2025, time.March, 10, 16, 0, 0, 0, msk), model.DeadlinePolicy{Grace: time.Hour}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Overdue(&tt.deadline, tt.now, tt.policy); got != tt.want {
+				t.Errorf("Overdue = %t, want %t", got, tt.want)
+			}
+		})
+	}
+	if Overdue(nil, timed, model.DeadlinePolicy{}) {
+		t.Error("a task without a deadline is overdue")
+	}
+}
+
+func TestDaysLeft(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	nyc := time.FixedZone("EST", -5*60*60)
+	dateOnly := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		now      time.Time
+		want     int
+	}{
+		{"deadline day itself", dateOnly, time.Date(2025, time.March, 10, 23, 0, 0, 0, msk), 0},
+		{"late the day before", dateOnly, time.Date(2025, time.March, 9, 23, 0, 0, 0, msk), 1},
+		{"early the day before", dateOnly, time.Date(2025, time.March, 9, 0, 30, 0, 0, msk), 1},
+		{"west of UTC", dateOnly, time.Date(2025, time.March, 9, 22, 0, 0, 0, nyc), 1},
+		{"timed deadline", time.Date(2025, time.March, 12, 1, 0, 0, 0, msk), time.Date(2025, time.March, 10, 23, 0, 0, 0, msk), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysLeft(tt.deadline, tt.now); got != tt.want {
+				t.Errorf("DaysLeft = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareDeadlines(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	dateOnly := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	timed := time.Date(2025, time.March, 10, 15, 30, 0, 0, msk)
+
+	if got := CompareDeadlines(&dateOnly, &timed, msk, model.DeadlinePolicy{}); got != 1 {
+		t.Errorf("end of day: date-only vs timed = %d, want 1", got)
+	}
+	if got := CompareDeadlines(&dateOnly, &timed, msk, model.DeadlinePolicy{StartOfDay: true}); got != -1 {
+		t.Errorf("start of day: date-only vs timed = %d, want -1", got)
+	}
+	if got := CompareDeadlines(nil, &timed, msk, model.DeadlinePolicy{}); got != 1 {
+		t.Errorf("missing vs timed = %d, want 1", got)
+	}
+}
+
+func TestParseGrace(t *testing.T) {
+	for input, want := range map[string]int{"off": 0, "0": 0, "03:00": 3, "3": 3, "6ч": 6, "30h": 30, "48": 48} {
+		if got, err := ParseGrace(input); err != nil || got != want {
+			t.Errorf("ParseGrace(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"49", "-1", "03:30", "завтра"} {
+		if _, err := ParseGrace(input); err == nil {
+			t.Errorf("ParseGrace(%q) accepted", input)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+const digestPeriod = 7 * 24 * time.Hour
+
+// managerDigestTemplate renders the weekly roll-up sent to workspace owners.
+var managerDigestTemplate = template.Must(template.New("manager_digest").Funcs(template.FuncMap{
+	"esc":  html.EscapeString,
+	"date": func(t time.Time) string { return t.Format("02.01") },
+}).Parse(`{{.Lang.Tf "📊 <b>Итоги недели · %s</b>" (esc .Workspace)}}
+🗓 {{date .Since}} – {{date .Until}}
+{{range .Members}}
+👤 <b>{{esc .Name}}</b>: ✅ {{.Completed}} · ⚠️ {{$.Lang.Tf "%d просрочено" .Overdue}} · 📌 {{$.Lang.Tf "%d открыто" .Open}}
+{{- range .Done}}
+   ✔️ {{esc .}}
+{{- end}}
+{{- else}}
+{{.Lang.T "— в пространстве пока нет задач"}}
+{{end}}`))
+
+type digestMember struct {
+	Name      string
+	Completed int
+	Overdue   int
+	Open      int
+	Done      []string
+}
+
+type managerDigest struct {
+	Lang      i18n.Lang
+	Workspace string
+	Since     time.Time
+	Until     time.Time
+	Members   []digestMember
+}
+
+// ManagerDigest builds the weekly team activity roll-up for a workspace owner:
+// what each member completed and how many of their tasks are overdue.
+func (s *ReminderService) ManagerDigest(ctx context.Context, workspace model.Workspace, now time.Time) (string, error) {
+	lang := i18n.FromContext(ctx)
+	since := now.Add(-digestPeriod)
+
+	members, err := s.workspaceRepo.ListMembers(ctx, workspace.ID)
+	if err != nil {
+		return "", err
+	}
+	activity, err := s.taskRepo.WorkspaceActivity(ctx, workspace.ID, since, now)
+	if err != nil {
+		return "", err
+	}
+	completed, err := s.taskRepo.ListCompletedSince(ctx, model.Scope{WorkspaceID: workspace.ID}, since)
+	if err != nil {
+		return "", err
+	}
+
+	byUser := make(map[uint]*digestMember, len(members))
+	order := make([]uint, 0, len(members))
+	for _, member := range members {
+		byUser[member.UserID] = &digestMember{Name: displayName(member.User)}
+		order = append(order, member.UserID)
+	}
+	for _, row := range activity {
+		entry, ok := byUser[row.UserID]
+		if !ok {
+			// Tasks of former members are still worth showing.
+			entry = &digestMember{Name: lang.T("бывший участник")}
+			byUser[row.UserID] = entry
+			order = append(order, row.UserID)
+		}
+		entry.Completed = row.Completed
+		entry.Overdue = row.Overdue
+		entry.Open = row.Open
+	}
+	for _, task := range completed {
+		if entry, ok := byUser[task.UserID]; ok {
+			entry.Done = append(entry.Done, strings.TrimSpace(task.Title))
+		}
+	}
+
+	data := managerDigest{Lang: lang, Workspace: workspace.Name, Since: since, Until: now}
+	for _, id := range order {
+		data.Members = append(data.Members, *byUser[id])
+	}
+	sort.SliceStable(data.Members, func(i, j int) bool {
+		return data.Members[i].Overdue > data.Members[j].Overdue
+	})
+
+	var builder strings.Builder
+	if err := managerDigestTemplate.Execute(&builder, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(builder.String()), nil
+}
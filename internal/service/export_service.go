@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// ErrUnknownExportFormat is returned for export formats other than ExportCSV and ExportJSON.
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+// Formats of task exports.
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// ExportedTask is one task of an export, with times in the reader's time zone.
+type ExportedTask struct {
+	ID          uint           `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Category    string         `json:"category,omitempty"`
+	Priority    string         `json:"priority"`
+	Status      string         `json:"status"` // open, done or archived
+	Deadline    *time.Time     `json:"deadline,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"` // last completion of a recurring task
+	ArchivedAt  *time.Time     `json:"archived_at,omitempty"`
+	ParentID    *uint          `json:"parent_id,omitempty"`
+	Recurrence  *ExportedRecur `json:"recurrence,omitempty"`
+}
+
+// ExportedRecur is how an exported recurring task repeats.
+type ExportedRecur struct {
+	Type     string     `json:"type"`               // daily, weekly or monthly
+	Interval int        `json:"interval,omitempty"` // every that many days or months
+	Day      int        `json:"day,omitempty"`      // day of month of monthly tasks
+	Weekdays []string   `json:"weekdays,omitempty"` // mon…sun of weekly tasks
+	Window   int        `json:"window,omitempty"`   // days around the due date the task may be done in
+	EndedAt  *time.Time `json:"ended_at,omitempty"`
+}
+
+// TaskExport is a file with all the tasks of a user.
+type TaskExport struct {
+	Name  string
+	Data  []byte
+	Count int
+}
+
+// ExportService writes a user's tasks to a file they can keep or take elsewhere, and reads
+// such files back in; see ImportTasks.
+type ExportService struct {
+	taskRepo     TaskStore
+	categoryRepo CategoryStore
+	workspaceSvc *WorkspaceService
+	quotaSvc     *QuotaService
+}
+
+func NewExportService(taskRepo TaskStore, categoryRepo CategoryStore, workspaceSvc *WorkspaceService, quotaSvc *QuotaService) *ExportService {
+	return &ExportService{taskRepo: taskRepo, categoryRepo: categoryRepo, workspaceSvc: workspaceSvc, quotaSvc: quotaSvc}
+}
+
+// Export writes every task of the user's scope, completed, archived and recurring ones
+// included, in format.
+func (s *ExportService) Export(ctx context.Context, user *model.User, format string, now time.Time) (*TaskExport, error) {
+	if format != ExportCSV && format != ExportJSON {
+		return nil, ErrUnknownExportFormat
+	}
+	tasks, err := s.Tasks(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if format == ExportJSON {
+		data, err = json.MarshalIndent(tasks, "", "  ")
+	} else {
+		data, err = tasksCSV(tasks)
+	}
+	if err != nil {
+		return nil, err
+	}
+	name := "tasks-" + now.In(user.Location()).Format("2006-01-02") + "." + format
+	return &TaskExport{Name: name, Data: data, Count: len(tasks)}, nil
+}
+
+// Tasks lists every task of the user's scope the way Export writes them.
+func (s *ExportService) Tasks(ctx context.Context, user *model.User) ([]ExportedTask, error) {
+	scope := user.Scope()
+	tasks, err := s.taskRepo.ListAll(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.ListByScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	loc := user.Location()
+	exported := make([]ExportedTask, 0, len(tasks))
+	for _, task := range tasks {
+		exported = append(exported, exportedTask(task, names, loc))
+	}
+	return exported, nil
+}
+
+func exportedTask(task model.Task, categories map[uint]string, loc *time.Location) ExportedTask {
+	exported := ExportedTask{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Priority:    task.Priority,
+		Status:      "open",
+		Deadline:    inLocation(task.Deadline, loc),
+		CreatedAt:   task.CreatedAt.In(loc),
+		CompletedAt: inLocation(task.LastCompletedAt, loc),
+		ArchivedAt:  inLocation(task.ArchivedAt, loc),
+		ParentID:    task.ParentID,
+	}
+	if exported.Priority == "" {
+		exported.Priority = model.PriorityNormal
+	}
+	if task.CategoryID != nil {
+		exported.Category = categories[*task.CategoryID]
+	}
+	switch {
+	case task.ArchivedAt != nil:
+		exported.Status = "archived"
+	case task.IsCompleted && !task.IsRecurring, task.RecurEndedAt != nil:
+		exported.Status = "done"
+	}
+	if task.IsRecurring {
+		recur := &ExportedRecur{Type: task.RecurType, Interval: task.RecurInterval, Window: task.RecurWindow, EndedAt: inLocation(task.RecurEndedAt, loc)}
+		switch task.RecurType {
+		case model.RecurMonthly:
+			recur.Day = task.RecurDay
+		case model.RecurWeekly:
+			for _, day := range task.Weekdays() {
+				recur.Weekdays = append(recur.Weekdays, strings.ToLower(day.String()[:3]))
+			}
+		}
+		exported.Recurrence = recur
+	}
+	return exported
+}
+
+func inLocation(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(loc)
+	return &local
+}
+
+var csvHeader = []string{"id", "title", "description", "category", "priority", "status", "deadline", "created_at", "completed_at", "archived_at", "parent_id", "recur_type", "recur_interval", "recur_day", "recur_weekdays", "recur_window", "recur_ended_at"}
+
+// tasksCSV writes one row per task, times in RFC 3339 and empty cells for what is not set.
+func tasksCSV(tasks []ExportedTask) ([]byte, error) {
+	var buf bytes.Buffer
+	// A byte order mark makes spreadsheets read the file as UTF-8.
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		row := []string{
+			strconv.FormatUint(uint64(task.ID), 10),
+			task.Title,
+			task.Description,
+			task.Category,
+			task.Priority,
+			task.Status,
+			csvTime(task.Deadline),
+			csvTime(&task.CreatedAt),
+			csvTime(task.CompletedAt),
+			csvTime(task.ArchivedAt),
+			"",
+		}
+		if task.ParentID != nil {
+			row[10] = strconv.FormatUint(uint64(*task.ParentID), 10)
+		}
+		if recur := task.Recurrence; recur != nil {
+			row = append(row, recur.Type, csvInt(recur.Interval), csvInt(recur.Day), strings.Join(recur.Weekdays, " "), csvInt(recur.Window), csvTime(recur.EndedAt))
+		} else {
+			row = append(row, "", "", "", "", "", "")
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func csvInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
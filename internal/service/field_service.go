@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+var (
+	// ErrFieldNotFound is returned when the scope has no field with the given name.
+	ErrFieldNotFound = errors.New("field not found")
+	// ErrFieldExists is returned when a field with the same name is already defined.
+	ErrFieldExists = errors.New("field already exists")
+	// ErrInvalidField is returned for field names and types that cannot be defined.
+	ErrInvalidField = errors.New("invalid field")
+	// ErrInvalidFieldValue is returned for values that do not match the field type.
+	ErrInvalidFieldValue = errors.New("invalid field value")
+	// ErrTooManyFields is returned when the scope already has MaxFields fields.
+	ErrTooManyFields = errors.New("too many fields")
+)
+
+// Limits of custom fields.
+const (
+	MaxFields           = 10
+	MaxFieldNameLength  = 30
+	MaxFieldValueLength = 200
+)
+
+// FieldTypes lists the supported custom field types.
+var FieldTypes = []string{model.FieldText, model.FieldNumber, model.FieldDate}
+
+// FieldValueError tells which field a value was rejected for.
+type FieldValueError struct {
+	Field model.CustomField
+}
+
+func (e *FieldValueError) Error() string {
+	return fmt.Sprintf("invalid value of field %q", e.Field.Name)
+}
+
+func (e *FieldValueError) Unwrap() error { return ErrInvalidFieldValue }
+
+// TaskField is a custom field together with its value on a task.
+type TaskField struct {
+	Field model.CustomField
+	Value string
+}
+
+// FieldService manages user-defined task fields and their values.
+type FieldService struct {
+	repo         *repository.FieldRepository
+	workspaceSvc *WorkspaceService
+}
+
+func NewFieldService(repo *repository.FieldRepository, workspaceSvc *WorkspaceService) *FieldService {
+	return &FieldService{repo: repo, workspaceSvc: workspaceSvc}
+}
+
+// List returns the fields of the user's active scope.
+func (s *FieldService) List(ctx context.Context, user *model.User) ([]model.CustomField, error) {
+	return s.repo.ListByScope(ctx, user.Scope())
+}
+
+// Define adds a field of the given type to the user's active scope.
+func (s *FieldService) Define(ctx context.Context, user *model.User, name, fieldType string) (*model.CustomField, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > MaxFieldNameLength || strings.ContainsAny(name, ":=") {
+		return nil, ErrInvalidField
+	}
+	known := false
+	for _, t := range FieldTypes {
+		known = known || t == fieldType
+	}
+	if !known {
+		return nil, ErrInvalidField
+	}
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, err
+	}
+	fields, err := s.repo.ListByScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if findField(fields, name) != nil {
+		return nil, ErrFieldExists
+	}
+	if len(fields) >= MaxFields {
+		return nil, ErrTooManyFields
+	}
+	field := model.CustomField{UserID: user.ID, WorkspaceID: scope.WorkspaceID, Name: name, Type: fieldType}
+	if err := s.repo.Create(ctx, &field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// Remove deletes a field and its values on all tasks.
+func (s *FieldService) Remove(ctx context.Context, user *model.User, name string) (*model.CustomField, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	fields, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	field := findField(fields, name)
+	if field == nil {
+		return nil, ErrFieldNotFound
+	}
+	if err := s.repo.Delete(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Values returns the fields set on the task, in the order the fields were defined.
+func (s *FieldService) Values(ctx context.Context, user *model.User, taskID uint) ([]TaskField, error) {
+	fields, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+	values, err := s.repo.ListValues(ctx, []uint{taskID})
+	if err != nil {
+		return nil, err
+	}
+	byField := make(map[uint]string, len(values))
+	for _, value := range values {
+		byField[value.FieldID] = value.Value
+	}
+	var result []TaskField
+	for _, field := range fields {
+		if value, ok := byField[field.ID]; ok {
+			result = append(result, TaskField{Field: field, Value: value})
+		}
+	}
+	return result, nil
+}
+
+// Check validates values keyed by field name without storing them; see SetValues.
+func (s *FieldService) Check(ctx context.Context, user *model.User, values map[string]string) error {
+	_, err := s.resolve(ctx, user, values)
+	return err
+}
+
+// SetValues stores values keyed by field name on the task; an empty value clears the field.
+// Nothing is stored when any name or value is rejected.
+func (s *FieldService) SetValues(ctx context.Context, user *model.User, taskID uint, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return err
+	}
+	resolved, err := s.resolve(ctx, user, values)
+	if err != nil {
+		return err
+	}
+	return s.repo.SetValues(ctx, taskID, resolved)
+}
+
+// resolve maps field names to IDs and normalizes the values.
+func (s *FieldService) resolve(ctx context.Context, user *model.User, values map[string]string) (map[uint]string, error) {
+	fields, err := s.repo.ListByScope(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[uint]string, len(values))
+	for name, raw := range values {
+		field := findField(fields, name)
+		if field == nil {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+		}
+		value, err := NormalizeFieldValue(*field, raw)
+		if err != nil {
+			return nil, err
+		}
+		resolved[field.ID] = value
+	}
+	return resolved, nil
+}
+
+// NormalizeFieldValue checks raw against the field type and returns it in the stored form:
+// numbers accept a decimal comma, dates are 2006-01-02 or 02.01.2006. Blank values stay empty.
+func NormalizeFieldValue(field model.CustomField, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	switch field.Type {
+	case model.FieldNumber:
+		number := strings.ReplaceAll(strings.ReplaceAll(raw, " ", ""), ",", ".")
+		parsed, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return "", &FieldValueError{Field: field}
+		}
+		return strconv.FormatFloat(parsed, 'f', -1, 64), nil
+	case model.FieldDate:
+		for _, layout := range []string{"2006-01-02", "02.01.2006"} {
+			if parsed, err := time.Parse(layout, raw); err == nil {
+				return parsed.Format("2006-01-02"), nil
+			}
+		}
+		return "", &FieldValueError{Field: field}
+	}
+	if utf8.RuneCountInString(raw) > MaxFieldValueLength {
+		return "", &FieldValueError{Field: field}
+	}
+	return raw, nil
+}
+
+func findField(fields []model.CustomField, name string) *model.CustomField {
+	name = strings.Join(strings.Fields(name), " ")
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"daily-planner/internal/model"
+)
+
+func TestNormalizeFieldValue(t *testing.T) {
+	number := model.CustomField{Name: "сумма", Type: model.FieldNumber}
+	date := model.CustomField{Name: "оплата", Type: model.FieldDate}
+	text := model.CustomField{Name: "клиент", Type: model.FieldText}
+
+	for _, tc := range []struct {
+		field model.CustomField
+		raw   string
+		want  string
+		ok    bool
+	}{
+		{number, "12 500,50", "12500.5", true},
+		{number, "-3", "-3", true},
+		{number, "много", "", false},
+		{date, "30.11.2025", "2025-11-30", true},
+		{date, "2025-11-30", "2025-11-30", true},
+		{date, "31.02.2025", "", false},
+		{text, "  ООО Ромашка ", "ООО Ромашка", true},
+		{text, "   ", "", true},
+	} {
+		got, err := NormalizeFieldValue(tc.field, tc.raw)
+		if tc.ok && (err != nil || got != tc.want) {
+			t.Errorf("%s %q: got %q, %v; want %q", tc.field.Type, tc.raw, got, err, tc.want)
+		}
+		if !tc.ok && !errors.Is(err, ErrInvalidFieldValue) {
+			t.Errorf("%s %q: got %q, %v; want an invalid value", tc.field.Type, tc.raw, got, err)
+		}
+	}
+}
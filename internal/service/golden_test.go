@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares got with testdata/<name>.golden; run `go test -update` to accept changes.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create): %v", err)
+	}
+	if got != strings.TrimSuffix(string(want), "\n") {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+type fixture struct {
+	t          *testing.T
+	ctx        context.Context
+	users      *repository.UserRepository
+	tasks      *repository.TaskRepository
+	categories *repository.CategoryRepository
+	workspaces *repository.WorkspaceRepository
+	contacts   *repository.ContactRepository
+	meds       *repository.MedicationRepository
+	counters   *repository.CounterRepository
+	runs       *repository.ReportRunRepository
+	accounts   *repository.AccountRepository
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := repository.NewDB(dsn, repository.PoolConfig{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("db handle: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return &fixture{
+		t:          t,
+		ctx:        context.Background(),
+		users:      repository.NewUserRepository(db),
+		tasks:      repository.NewTaskRepository(db),
+		categories: repository.NewCategoryRepository(db),
+		workspaces: repository.NewWorkspaceRepository(db),
+		contacts:   repository.NewContactRepository(db),
+		meds:       repository.NewMedicationRepository(db),
+		counters:   repository.NewCounterRepository(db),
+		runs:       repository.NewReportRunRepository(db),
+		accounts:   repository.NewAccountRepository(db),
+	}
+}
+
+func (f *fixture) user(telegramID int64, firstName string) *model.User {
+	f.t.Helper()
+	user, err := f.users.UpsertFromTelegram(f.ctx, telegramID, firstName, "", "", "")
+	if err != nil {
+		f.t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+func (f *fixture) category(scope model.Scope, name string) *uint {
+	f.t.Helper()
+	category, err := f.categories.GetOrCreate(f.ctx, scope, name)
+	if err != nil {
+		f.t.Fatalf("create category: %v", err)
+	}
+	return &category.ID
+}
+
+func (f *fixture) task(task model.Task) model.Task {
+	f.t.Helper()
+	if task.IsRecurring && task.RecurType == "" {
+		task.RecurType = "monthly"
+	}
+	if err := f.tasks.Create(f.ctx, &task); err != nil {
+		f.t.Fatalf("create task: %v", err)
+	}
+	return task
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/importer"
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// maxICSSize caps how much of an uploaded or fetched calendar is read.
+const maxICSSize = 5 << 20
+
+// ErrInvalidFeedURL is returned for subscription URLs that are not http(s).
+var ErrInvalidFeedURL = errors.New("calendar URL must start with http:// or https://")
+
+// ImportResult summarises what an import changed.
+type ImportResult struct {
+	Created int
+	Updated int
+	Skipped int
+}
+
+// ImportService turns calendar events into tasks with deadlines.
+type ImportService struct {
+	taskRepo     *repository.TaskRepository
+	subRepo      *repository.SubscriptionRepository
+	userRepo     *repository.UserRepository
+	workspaceSvc *WorkspaceService
+	quotaSvc     *QuotaService
+	client       *http.Client
+}
+
+func NewImportService(taskRepo *repository.TaskRepository, subRepo *repository.SubscriptionRepository, userRepo *repository.UserRepository, workspaceSvc *WorkspaceService, quotaSvc *QuotaService, client *http.Client) *ImportService {
+	return &ImportService{
+		taskRepo:     taskRepo,
+		subRepo:      subRepo,
+		userRepo:     userRepo,
+		workspaceSvc: workspaceSvc,
+		quotaSvc:     quotaSvc,
+		client:       client,
+	}
+}
+
+// ImportICS imports upcoming events from an .ics stream into the user's active scope.
+func (s *ImportService) ImportICS(ctx context.Context, user *model.User, r io.Reader, now time.Time) (ImportResult, error) {
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return ImportResult{}, err
+	}
+	events, err := importer.ParseICS(io.LimitReader(r, maxICSSize))
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return s.importEvents(ctx, user, scope, events, now)
+}
+
+// Subscribe stores a feed URL and performs the first import right away.
+func (s *ImportService) Subscribe(ctx context.Context, user *model.User, rawURL string, now time.Time) (*model.CalendarSubscription, ImportResult, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ImportResult{}, ErrInvalidFeedURL
+	}
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, ImportResult{}, err
+	}
+
+	sub := model.CalendarSubscription{UserID: user.ID, WorkspaceID: scope.WorkspaceID, URL: parsed.String()}
+	result, err := s.fetchAndImport(ctx, user, sub, now)
+	if err != nil {
+		return nil, ImportResult{}, err
+	}
+	sub.LastSyncedAt = &now
+	if err := s.subRepo.Create(ctx, &sub); err != nil {
+		return nil, ImportResult{}, err
+	}
+	return &sub, result, nil
+}
+
+func (s *ImportService) ListSubscriptions(ctx context.Context, user *model.User) ([]model.CalendarSubscription, error) {
+	return s.subRepo.ListByUser(ctx, user.ID)
+}
+
+// Unsubscribe removes a feed; already imported tasks stay.
+func (s *ImportService) Unsubscribe(ctx context.Context, user *model.User, id uint) (bool, error) {
+	return s.subRepo.Delete(ctx, user.ID, id)
+}
+
+// SyncSubscriptions re-imports every feed, recording per-feed errors instead of aborting.
+func (s *ImportService) SyncSubscriptions(ctx context.Context, now time.Time) error {
+	subs, err := s.subRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range subs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sub := &subs[i]
+		sub.LastError = ""
+		user, err := s.userRepo.FindByID(ctx, sub.UserID)
+		if err == nil && user.ArchivedAt != nil {
+			continue
+		}
+		if err == nil {
+			_, err = s.fetchAndImport(ctx, user, *sub, now)
+		}
+		if err != nil {
+			sub.LastError = err.Error()
+		} else {
+			sub.LastSyncedAt = &now
+		}
+		if err := s.subRepo.Save(ctx, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ImportService) fetchAndImport(ctx context.Context, user *model.User, sub model.CalendarSubscription, now time.Time) (ImportResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sub.URL, nil)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ImportResult{}, fmt.Errorf("fetch calendar: unexpected status %s", resp.Status)
+	}
+	events, err := importer.ParseICS(io.LimitReader(resp.Body, maxICSSize))
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return s.importEvents(ctx, user, sub.Scope(), events, now)
+}
+
+// importEvents creates tasks for upcoming events and refreshes those imported earlier,
+// matching them by event UID. New tasks stop being created once the task quota is reached.
+func (s *ImportService) importEvents(ctx context.Context, user *model.User, scope model.Scope, events []importer.Event, now time.Time) (ImportResult, error) {
+	var result ImportResult
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, event := range events {
+		title := strings.TrimSpace(event.Summary)
+		if event.UID == "" || title == "" || event.Start.Before(today) {
+			result.Skipped++
+			continue
+		}
+		deadline := event.Start
+
+		existing, err := s.taskRepo.FindByExternalUID(ctx, scope, event.UID)
+		switch {
+		case err == nil:
+			if existing.Title == title && existing.Description == event.Description &&
+				existing.Deadline != nil && existing.Deadline.Equal(deadline) {
+				result.Skipped++
+				continue
+			}
+			existing.Title = title
+			existing.Description = event.Description
+			existing.Deadline = &deadline
+			if err := s.taskRepo.Save(ctx, existing); err != nil {
+				return result, err
+			}
+			result.Updated++
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+				return result, err
+			}
+			task := model.Task{
+				UserID:      user.ID,
+				WorkspaceID: scope.WorkspaceID,
+				Title:       title,
+				Description: event.Description,
+				Deadline:    &deadline,
+				ExternalUID: event.UID,
+			}
+			if err := s.taskRepo.Create(ctx, &task); err != nil {
+				return result, err
+			}
+			result.Created++
+		default:
+			return result, err
+		}
+	}
+	return result, nil
+}
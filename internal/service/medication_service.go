@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// ErrInvalidTime is returned for intake times that are not HH:MM.
+var ErrInvalidTime = errors.New("invalid time")
+
+const (
+	// doseCheckinWindow is how late a check-in is still sent, e.g. after a restart.
+	doseCheckinWindow = time.Hour
+	// doseGrace is how long a dose may stay unanswered before it counts as missed.
+	doseGrace = 6 * time.Hour
+	// maxDailySlots caps how many intake times a medication may have.
+	maxDailySlots = 8
+)
+
+// DueDose is a dose whose check-in should be sent now.
+type DueDose struct {
+	Dose       model.Dose
+	Medication model.Medication
+}
+
+// Adherence summarises how doses of one medication went over a period.
+type Adherence struct {
+	Name    string
+	Taken   int
+	Missed  int
+	Pending int
+}
+
+// Percent is the share of answered doses that were taken.
+func (a Adherence) Percent() int {
+	if a.Taken+a.Missed == 0 {
+		return 0
+	}
+	return a.Taken * 100 / (a.Taken + a.Missed)
+}
+
+// MedicationService manages medication schedules with several intakes per day
+// and tracks whether each dose was taken.
+type MedicationService struct {
+	repo     *repository.MedicationRepository
+	userRepo UserStore
+}
+
+func NewMedicationService(repo *repository.MedicationRepository, userRepo UserStore) *MedicationService {
+	return &MedicationService{repo: repo, userRepo: userRepo}
+}
+
+// Add stores a medication taken daily at the given HH:MM times.
+func (s *MedicationService) Add(ctx context.Context, user *model.User, name string, times []string) (*model.Medication, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	slots, err := normalizeSlots(times)
+	if err != nil {
+		return nil, err
+	}
+	medication := model.Medication{UserID: user.ID, Name: name, Times: strings.Join(slots, ",")}
+	if err := s.repo.Create(ctx, &medication); err != nil {
+		return nil, err
+	}
+	return &medication, nil
+}
+
+func (s *MedicationService) List(ctx context.Context, user *model.User) ([]model.Medication, error) {
+	return s.repo.ListByUser(ctx, user.ID)
+}
+
+// Remove deletes a medication and its dose history.
+func (s *MedicationService) Remove(ctx context.Context, user *model.User, id uint) (bool, error) {
+	return s.repo.Delete(ctx, user.ID, id)
+}
+
+// DueDoses records doses whose slot has come within the check-in window and returns them.
+// Slots are times of day in the user's time zone. Each slot yields a dose only once, so
+// repeated calls do not send duplicate check-ins.
+func (s *MedicationService) DueDoses(ctx context.Context, now time.Time) ([]DueDose, error) {
+	medications, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locations := make(map[uint]*time.Location)
+	var due []DueDose
+	for _, medication := range medications {
+		loc, ok := locations[medication.UserID]
+		if !ok {
+			user, err := s.userRepo.FindByID(ctx, medication.UserID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return due, fmt.Errorf("find medication user: %w", err)
+			}
+			loc = user.Location()
+			locations[medication.UserID] = loc
+		}
+		for _, slot := range medication.Slots() {
+			at, err := slotTime(slot, now.In(loc))
+			if err != nil || at.After(now) || now.Sub(at) > doseCheckinWindow {
+				continue
+			}
+			// Stored in now's zone like every other dose time, since SQLite compares them as text.
+			at = at.In(now.Location())
+			dose := model.Dose{MedicationID: medication.ID, UserID: medication.UserID, ScheduledAt: at, Status: model.DosePending}
+			created, err := s.repo.CreateDose(ctx, &dose)
+			if err != nil {
+				return due, err
+			}
+			if created {
+				due = append(due, DueDose{Dose: dose, Medication: medication})
+			}
+		}
+	}
+	return due, nil
+}
+
+// Record answers a dose check-in as taken or missed.
+func (s *MedicationService) Record(ctx context.Context, user *model.User, doseID uint, taken bool, now time.Time) (*DueDose, error) {
+	dose, err := s.repo.FindDose(ctx, user.ID, doseID)
+	if err != nil {
+		return nil, err
+	}
+	medication, err := s.repo.FindByID(ctx, user.ID, dose.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	status := model.DoseMissed
+	if taken {
+		status = model.DoseTaken
+	}
+	if err := s.repo.SetDoseStatus(ctx, dose, status, now); err != nil {
+		return nil, err
+	}
+	return &DueDose{Dose: *dose, Medication: *medication}, nil
+}
+
+// MissOverdue marks doses left unanswered for too long as missed.
+func (s *MedicationService) MissOverdue(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.MissPending(ctx, now.Add(-doseGrace))
+}
+
+// Adherence reports per-medication dose outcomes since the given time.
+func (s *MedicationService) Adherence(ctx context.Context, user *model.User, since time.Time) ([]Adherence, error) {
+	medications, err := s.repo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountDoses(ctx, user.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*Adherence, len(medications))
+	stats := make([]Adherence, len(medications))
+	for i, medication := range medications {
+		stats[i].Name = medication.Name
+		byID[medication.ID] = &stats[i]
+	}
+	for _, count := range counts {
+		entry, ok := byID[count.MedicationID]
+		if !ok {
+			continue
+		}
+		switch count.Status {
+		case model.DoseTaken:
+			entry.Taken += count.Count
+		case model.DoseMissed:
+			entry.Missed += count.Count
+		default:
+			entry.Pending += count.Count
+		}
+	}
+	return stats, nil
+}
+
+// normalizeSlots validates HH:MM times and returns them sorted without duplicates.
+func normalizeSlots(times []string) ([]string, error) {
+	seen := make(map[string]bool, len(times))
+	var slots []string
+	for _, raw := range times {
+		hour, minute, err := parseClock(raw)
+		if err != nil {
+			return nil, err
+		}
+		slot := fmt.Sprintf("%02d:%02d", hour, minute)
+		if !seen[slot] {
+			seen[slot] = true
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 || len(slots) > maxDailySlots {
+		return nil, ErrInvalidTime
+	}
+	sort.Strings(slots)
+	return slots, nil
+}
+
+// slotTime places an HH:MM slot on the day of now.
+func slotTime(slot string, now time.Time) (time.Time, error) {
+	hour, minute, err := parseClock(slot)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location()), nil
+}
+
+// parseClock reads H:MM or HH:MM.
+func parseClock(value string) (int, int, error) {
+	hourPart, minutePart, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok || len(minutePart) != 2 {
+		return 0, 0, ErrInvalidTime
+	}
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, ErrInvalidTime
+	}
+	minute, err := strconv.Atoi(minutePart)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, ErrInvalidTime
+	}
+	return hour, minute, nil
+}
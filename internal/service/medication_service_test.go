@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMedicationDosesAndAdherence(t *testing.T) {
+	f := newFixture(t)
+	svc := NewMedicationService(f.meds, f.users)
+	user := f.user(1, "Анна")
+
+	medication, err := svc.Add(f.ctx, user, "Витамин D", []string{"21:00", "9:00", "09:00"})
+	if err != nil {
+		t.Fatalf("add medication: %v", err)
+	}
+	if medication.Times != "09:00,21:00" {
+		t.Errorf("times = %q, want sorted unique slots", medication.Times)
+	}
+
+	// Day 1: the morning dose is taken, the evening one is never answered.
+	due, err := svc.DueDoses(f.ctx, date(2025, time.March, 10, 9).Add(10*time.Minute))
+	if err != nil || len(due) != 1 {
+		t.Fatalf("morning doses = %v, %v; want one", due, err)
+	}
+	if again, _ := svc.DueDoses(f.ctx, date(2025, time.March, 10, 9).Add(20*time.Minute)); len(again) != 0 {
+		t.Errorf("dose check-in repeated: %v", again)
+	}
+	if _, err := svc.Record(f.ctx, user, due[0].Dose.ID, true, date(2025, time.March, 10, 9)); err != nil {
+		t.Fatalf("record dose: %v", err)
+	}
+	if due, _ := svc.DueDoses(f.ctx, date(2025, time.March, 10, 21)); len(due) != 1 {
+		t.Fatalf("evening doses = %v; want one", due)
+	}
+	// A slot long past (the bot was down) gets no check-in.
+	if due, _ := svc.DueDoses(f.ctx, date(2025, time.March, 11, 12)); len(due) != 0 {
+		t.Errorf("stale morning dose sent: %v", due)
+	}
+	if missed, err := svc.MissOverdue(f.ctx, date(2025, time.March, 11, 12)); err != nil || missed != 1 {
+		t.Errorf("missed = %d, %v; want the unanswered evening dose", missed, err)
+	}
+
+	stats, err := svc.Adherence(f.ctx, user, date(2025, time.March, 1, 0))
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("adherence = %v, %v", stats, err)
+	}
+	if got := stats[0]; got.Taken != 1 || got.Missed != 1 || got.Percent() != 50 {
+		t.Errorf("adherence = %+v, want 1 taken and 1 missed", got)
+	}
+
+	other := f.user(2, "Борис")
+	if _, err := svc.Record(f.ctx, other, due[0].Dose.ID, false, date(2025, time.March, 10, 9)); err == nil {
+		t.Error("another user answered the dose")
+	}
+	// Slots are times of day where the user lives: 09:00 in Moscow is 06:00 UTC.
+	if err := f.users.SetTimezone(f.ctx, other, "Europe/Moscow"); err != nil {
+		t.Fatalf("set timezone: %v", err)
+	}
+	if _, err := svc.Add(f.ctx, other, "Железо", []string{"09:00"}); err != nil {
+		t.Fatalf("add medication: %v", err)
+	}
+	if due, err := svc.DueDoses(f.ctx, date(2025, time.March, 12, 6).Add(10*time.Minute)); err != nil || len(due) != 1 || due[0].Medication.Name != "Железо" {
+		t.Errorf("Moscow morning doses = %v, %v; want the 09:00 Moscow one", due, err)
+	}
+	if _, err := svc.Add(f.ctx, user, "Кальций", []string{"25:00"}); !errors.Is(err, ErrInvalidTime) {
+		t.Errorf("Add with 25:00 error = %v, want ErrInvalidTime", err)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// ErrReminderInPast is returned for reminder times that already passed.
+var ErrReminderInPast = errors.New("reminder time is in the past")
+
+// ErrTooManyReminders is returned when a task already has maxTaskReminders pending reminders.
+var ErrTooManyReminders = errors.New("too many reminders")
+
+// maxTaskReminders caps how many pending reminders one task may have per user.
+const maxTaskReminders = 10
+
+// DueReminder is a reminder whose notification should be sent now.
+type DueReminder struct {
+	Reminder model.Reminder
+	Task     model.Task
+}
+
+// NotificationService manages reminders set for exact moments on single tasks.
+type NotificationService struct {
+	reminderRepo *repository.ReminderRepository
+	taskRepo     *repository.TaskRepository
+}
+
+func NewNotificationService(reminderRepo *repository.ReminderRepository, taskRepo *repository.TaskRepository) *NotificationService {
+	return &NotificationService{reminderRepo: reminderRepo, taskRepo: taskRepo}
+}
+
+// Add sets a reminder about a task at the given moment.
+func (s *NotificationService) Add(ctx context.Context, user *model.User, taskID uint, at, now time.Time) (*model.Reminder, error) {
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.IsCompleted && !task.IsRecurring {
+		return nil, ErrTaskCompleted
+	}
+	if !at.After(now) {
+		return nil, ErrReminderInPast
+	}
+	pending, err := s.reminderRepo.ListPending(ctx, user.ID, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) >= maxTaskReminders {
+		return nil, ErrTooManyReminders
+	}
+	scope := user.Scope()
+	reminder := model.Reminder{UserID: user.ID, WorkspaceID: scope.WorkspaceID, TaskID: task.ID, At: at}
+	if err := s.reminderRepo.Create(ctx, &reminder); err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// List returns the user's pending reminders for a task, earliest first.
+func (s *NotificationService) List(ctx context.Context, user *model.User, taskID uint) ([]model.Reminder, error) {
+	return s.reminderRepo.ListPending(ctx, user.ID, taskID)
+}
+
+// Remove deletes one of the user's reminders and reports whether it existed.
+func (s *NotificationService) Remove(ctx context.Context, user *model.User, id uint) (bool, error) {
+	return s.reminderRepo.Delete(ctx, user.ID, id)
+}
+
+// Due returns reminders to send now. Reminders whose task was deleted or
+// completed in the meantime are marked sent and dropped.
+func (s *NotificationService) Due(ctx context.Context, now time.Time) ([]DueReminder, error) {
+	reminders, err := s.reminderRepo.ListDue(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	var due []DueReminder
+	for _, reminder := range reminders {
+		task, err := s.taskRepo.FindByID(ctx, reminder.Scope(), reminder.TaskID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return nil, err
+		case task.ArchivedAt == nil && (task.IsRecurring || !task.IsCompleted):
+			due = append(due, DueReminder{Reminder: reminder, Task: *task})
+			continue
+		}
+		if err := s.reminderRepo.MarkSent(ctx, &reminder, now); err != nil {
+			return nil, err
+		}
+	}
+	return due, nil
+}
+
+// MarkSent records that the reminder's notification went out.
+func (s *NotificationService) MarkSent(ctx context.Context, reminder *model.Reminder, at time.Time) error {
+	return s.reminderRepo.MarkSent(ctx, reminder, at)
+}
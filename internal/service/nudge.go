@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+const (
+	// nudgePostpones is how many snoozes a task may collect before the bot questions it.
+	nudgePostpones = 3
+	// nudgeIdle is how long a task may sit untouched; it is also the pause between nudges.
+	nudgeIdle = 14 * 24 * time.Hour
+)
+
+// Nudge is a task the owner keeps putting off.
+type Nudge struct {
+	Task      model.Task
+	Postponed bool // postponed too often, as opposed to just untouched
+	IdleDays  int
+}
+
+// Nudges lists personal one-time tasks postponed more than three times or untouched
+// for two weeks, except those nudged within the last two weeks.
+func (s *ReminderService) Nudges(ctx context.Context, now time.Time) ([]Nudge, error) {
+	tasks, err := s.taskRepo.ListForNudge(ctx, nudgePostpones, now.Add(-nudgeIdle), now.Add(-nudgeIdle))
+	if err != nil {
+		return nil, err
+	}
+	nudges := make([]Nudge, 0, len(tasks))
+	for _, task := range tasks {
+		touched := task.CreatedAt
+		if task.TouchedAt != nil {
+			touched = *task.TouchedAt
+		}
+		nudges = append(nudges, Nudge{
+			Task:      task,
+			Postponed: task.PostponeCount > nudgePostpones,
+			IdleDays:  int(now.Sub(touched) / (24 * time.Hour)),
+		})
+	}
+	return nudges, nil
+}
+
+func (s *ReminderService) MarkNudged(ctx context.Context, task *model.Task, now time.Time) error {
+	return s.taskRepo.MarkNudged(ctx, task, now)
+}
+
+// KeepTask records that the owner still wants the task as is, restarting its nudge counters.
+func (s *TaskService) KeepTask(ctx context.Context, user *model.User, taskID uint, now time.Time) (*model.Task, error) {
+	task, err := s.openTask(ctx, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.taskRepo.Touch(ctx, task, now); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// BreakDown replaces a task with smaller ones that keep its category and deadline.
+func (s *TaskService) BreakDown(ctx context.Context, user *model.User, taskID uint, titles []string) ([]model.Task, error) {
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("at least one subtask is required")
+	}
+	task, err := s.openTask(ctx, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+	// The original task goes away, so only the extra subtasks count against the quota.
+	if err := s.quotaSvc.CheckTasks(ctx, user, len(titles)-1); err != nil {
+		return nil, err
+	}
+
+	subtasks := make([]model.Task, 0, len(titles))
+	for _, title := range titles {
+		subtask := model.Task{
+			UserID:      task.UserID,
+			WorkspaceID: task.WorkspaceID,
+			CategoryID:  task.CategoryID,
+			Title:       title,
+			Deadline:    task.Deadline,
+		}
+		if err := s.taskRepo.Create(ctx, &subtask); err != nil {
+			return nil, err
+		}
+		subtasks = append(subtasks, subtask)
+	}
+	if err := s.taskRepo.Delete(ctx, user.Scope(), task.ID); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
+
+// openTask loads an open one-time task the user may change.
+func (s *TaskService) openTask(ctx context.Context, user *model.User, taskID uint) (*model.Task, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !task.IsRecurring && task.IsCompleted {
+		return task, ErrTaskCompleted
+	}
+	return task, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+var (
+	// ErrPostponeRecurring is returned for recurring tasks, whose dates follow the schedule.
+	ErrPostponeRecurring = errors.New("recurring task cannot be postponed")
+	// ErrPostponeToPast is returned when the new deadline is before today.
+	ErrPostponeToPast = errors.New("new deadline is in the past")
+)
+
+// postponeTop is how many of the most postponed tasks PostponeStats names.
+const postponeTop = 3
+
+// PostponedDeadline is the deadline of the task moved by days. An overdue deadline, or a
+// missing one, is moved from today, so "+1 day" always lands in the future. now must be
+// in the user's time zone; deadlines are dates at midnight UTC.
+func PostponedDeadline(task model.Task, days int, now time.Time) time.Time {
+	base := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if task.Deadline != nil && task.Deadline.After(base) {
+		base = *task.Deadline
+	}
+	return base.AddDate(0, 0, days)
+}
+
+// Postpone moves the deadline of an open one-time task to the given day and counts it.
+// now must be in the user's time zone.
+func (s *TaskService) Postpone(ctx context.Context, user *model.User, taskID uint, deadline, now time.Time) (*model.Task, error) {
+	task, err := s.openTask(ctx, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.IsRecurring {
+		return task, ErrPostponeRecurring
+	}
+	deadline = time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)
+	if deadline.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return task, ErrPostponeToPast
+	}
+	if err := s.taskRepo.Postpone(ctx, task, deadline, now); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
+
+// PostponeStats sums up how often the deadlines of open tasks were moved.
+type PostponeStats struct {
+	Tasks int          // open tasks postponed at least once
+	Total int          // postponements of those tasks
+	Most  []model.Task // the most postponed tasks, most postponed first
+}
+
+// Postponements reports the postponed open tasks of the user's active scope.
+func (s *TaskService) Postponements(ctx context.Context, user *model.User) (PostponeStats, error) {
+	tasks, err := s.taskRepo.ListActiveOrRecurring(ctx, user.Scope())
+	if err != nil {
+		return PostponeStats{}, err
+	}
+	var stats PostponeStats
+	for _, task := range tasks {
+		if task.Postponements == 0 {
+			continue
+		}
+		stats.Tasks++
+		stats.Total += task.Postponements
+		stats.Most = append(stats.Most, task)
+	}
+	sort.SliceStable(stats.Most, func(i, j int) bool {
+		return stats.Most[i].Postponements > stats.Most[j].Postponements
+	})
+	if len(stats.Most) > postponeTop {
+		stats.Most = stats.Most[:postponeTop]
+	}
+	return stats, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestPostponedDeadline(t *testing.T) {
+	// Deadlines are UTC dates; "today" is the date on the user's clock.
+	now := time.Date(2025, time.March, 12, 23, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	day := func(d int) *time.Time {
+		deadline := time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)
+		return &deadline
+	}
+
+	tests := []struct {
+		name string
+		task model.Task
+		days int
+		want string
+	}{
+		{"no deadline", model.Task{}, 1, "2025-03-13"},
+		{"overdue moves from today", model.Task{Deadline: day(3)}, 1, "2025-03-13"},
+		{"due today", model.Task{Deadline: day(12)}, 7, "2025-03-19"},
+		{"due later moves from the deadline", model.Task{Deadline: day(20)}, 7, "2025-03-27"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PostponedDeadline(tt.task, tt.days, now).Format("2006-01-02"); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
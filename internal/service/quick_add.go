@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// Reasons of a QuickAddError.
+var (
+	ErrQuickAddTitle    = errors.New("task title is missing")
+	ErrQuickAddPriority = errors.New("unknown priority")
+	ErrQuickAddDeadline = errors.New("unknown deadline")
+	ErrQuickAddRepeated = errors.New("token repeated")
+)
+
+// QuickAddError points at the token ParseQuickAdd could not accept.
+type QuickAddError struct {
+	Reason error
+	Token  string
+}
+
+func (e *QuickAddError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Reason, e.Token)
+}
+
+func (e *QuickAddError) Unwrap() error {
+	return e.Reason
+}
+
+// priorityWords are the Russian priority names accepted next to the stored English ones.
+var priorityWords = map[string]string{
+	"срочно":  model.PriorityUrgent,
+	"срочный": model.PriorityUrgent,
+	"высокий": model.PriorityHigh,
+	"важно":   model.PriorityHigh,
+	"обычный": model.PriorityNormal,
+	"низкий":  model.PriorityLow,
+}
+
+// ParsePriorityName accepts a priority in English ("high") or Russian ("высокий").
+func ParsePriorityName(word string) (string, bool) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	for _, priority := range model.Priorities {
+		if word == priority {
+			return priority, true
+		}
+	}
+	priority, ok := priorityWords[word]
+	return priority, ok
+}
+
+// quickWeekdays are the short Russian and English weekday names, indexed by time.Weekday.
+var quickWeekdays = [7][2]string{{"вс", "sun"}, {"пн", "mon"}, {"вт", "tue"}, {"ср", "wed"}, {"чт", "thu"}, {"пт", "fri"}, {"сб", "sat"}}
+
+// ParseQuickAdd reads a one-line task such as "Купить молоко #покупки !high @завтра":
+// #category, !priority and @deadline tokens may stand anywhere, the rest is the title.
+// Deadlines are сегодня, завтра, послезавтра, a weekday (пн…вс, the next one to come),
+// their English today, tomorrow and mon…sun,
+// 2025-11-30, 30.11 or 30.11.2025. now must be in the user's time zone.
+func ParseQuickAdd(text string, now time.Time) (TaskInput, error) {
+	var input TaskInput
+	var title []string
+	for _, token := range strings.Fields(text) {
+		switch {
+		case len(token) > 1 && token[0] == '#':
+			if input.Category != "" {
+				return TaskInput{}, &QuickAddError{Reason: ErrQuickAddRepeated, Token: token}
+			}
+			input.Category = strings.ReplaceAll(token[1:], "_", " ")
+		case len(token) > 1 && token[0] == '!':
+			if input.Priority != "" {
+				return TaskInput{}, &QuickAddError{Reason: ErrQuickAddRepeated, Token: token}
+			}
+			priority, ok := ParsePriorityName(token[1:])
+			if !ok {
+				return TaskInput{}, &QuickAddError{Reason: ErrQuickAddPriority, Token: token}
+			}
+			input.Priority = priority
+		case len(token) > 1 && token[0] == '@':
+			if input.Deadline != nil {
+				return TaskInput{}, &QuickAddError{Reason: ErrQuickAddRepeated, Token: token}
+			}
+			deadline, ok := parseQuickDeadline(token[1:], now)
+			if !ok {
+				return TaskInput{}, &QuickAddError{Reason: ErrQuickAddDeadline, Token: token}
+			}
+			input.Deadline = &deadline
+		default:
+			title = append(title, token)
+		}
+	}
+	input.Title = strings.Join(title, " ")
+	if input.Title == "" {
+		return TaskInput{}, ErrQuickAddTitle
+	}
+	return input, nil
+}
+
+// parseQuickDeadline returns the day as a UTC midnight, the way deadlines typed in the dialog are stored.
+func parseQuickDeadline(word string, now time.Time) (time.Time, bool) {
+	word = strings.ToLower(word)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch word {
+	case "сегодня", "today":
+		return today, true
+	case "завтра", "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "послезавтра":
+		return today.AddDate(0, 0, 2), true
+	}
+	for day, names := range quickWeekdays {
+		if word == names[0] || word == names[1] {
+			ahead := (day - int(today.Weekday()) + 7) % 7
+			if ahead == 0 {
+				ahead = 7
+			}
+			return today.AddDate(0, 0, ahead), true
+		}
+	}
+	if parsed, err := time.Parse("2006-01-02", word); err == nil {
+		return parsed, true
+	}
+	if parsed, err := time.Parse("02.01.2006", word); err == nil {
+		return parsed, true
+	}
+	if parsed, err := time.Parse("02.01", word); err == nil {
+		deadline := time.Date(today.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC)
+		// A day that already passed this year means the next one.
+		if deadline.Before(today) {
+			deadline = deadline.AddDate(1, 0, 0)
+		}
+		return deadline, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2025, time.December, 10, 15, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+	day := func(month time.Month, d, year int) string {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		text     string
+		title    string
+		category string
+		priority string
+		deadline string
+	}{
+		{"Купить молоко #покупки !high @завтра", "Купить молоко", "покупки", model.PriorityHigh, day(time.December, 11, 2025)},
+		{"!срочно Позвонить в банк", "Позвонить в банк", "", model.PriorityUrgent, ""},
+		{"Отчёт @пн #работа_и_учёба", "Отчёт", "работа и учёба", "", day(time.December, 15, 2025)},
+		{"Оплатить налог @ср", "Оплатить налог", "", "", day(time.December, 17, 2025)},
+		{"Подарок @05.01", "Подарок", "", "", day(time.January, 5, 2026)},
+		{"Паспорт @2026-03-01", "Паспорт", "", "", day(time.March, 1, 2026)},
+	}
+	for _, tt := range tests {
+		input, err := ParseQuickAdd(tt.text, now)
+		if err != nil {
+			t.Errorf("%q: %v", tt.text, err)
+			continue
+		}
+		var deadline string
+		if input.Deadline != nil {
+			deadline = input.Deadline.Format("2006-01-02")
+		}
+		if input.Title != tt.title || input.Category != tt.category || input.Priority != tt.priority || deadline != tt.deadline {
+			t.Errorf("%q: got %q #%q !%q @%q", tt.text, input.Title, input.Category, input.Priority, deadline)
+		}
+	}
+
+	for text, want := range map[string]error{
+		"#покупки !high":       ErrQuickAddTitle,
+		"Молоко !важнее":       ErrQuickAddPriority,
+		"Молоко @когда-нибудь": ErrQuickAddDeadline,
+		"Молоко #дом #работа":  ErrQuickAddRepeated,
+	} {
+		if _, err := ParseQuickAdd(text, now); !errors.Is(err, want) {
+			t.Errorf("%q: got %v, want %v", text, err, want)
+		}
+	}
+}
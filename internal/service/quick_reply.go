@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"daily-planner/internal/model"
+)
+
+// ErrInvalidQuickReply is returned for quick replies that are not a short emoji with a known action.
+var ErrInvalidQuickReply = errors.New("invalid quick reply")
+
+// Quick reply actions.
+const (
+	QuickComplete = "done"
+	QuickList     = "list"
+	QuickNew      = "new"
+)
+
+// quickRepliesNone marks a user who turned all quick replies off.
+const quickRepliesNone = "-"
+
+// maxQuickReplies caps how many emoji a user may bind.
+const maxQuickReplies = 10
+
+// DefaultQuickReplies are used until the user changes them.
+var DefaultQuickReplies = map[string]string{
+	"✅": QuickComplete,
+	"📋": QuickList,
+	"➕": QuickNew,
+}
+
+// UserQuickReplies returns the user's emoji bindings or the default ones.
+func UserQuickReplies(user model.User) map[string]string {
+	switch user.QuickReplies {
+	case "":
+		return copyQuickReplies(DefaultQuickReplies)
+	case quickRepliesNone:
+		return map[string]string{}
+	}
+	replies := make(map[string]string)
+	for _, pair := range strings.Split(user.QuickReplies, ",") {
+		emoji, action, ok := strings.Cut(pair, "=")
+		if ok && validQuickAction(action) {
+			replies[emoji] = action
+		}
+	}
+	return replies
+}
+
+// EncodeQuickReplies turns bindings into the form stored on the user.
+func EncodeQuickReplies(replies map[string]string) string {
+	if len(replies) == 0 {
+		return quickRepliesNone
+	}
+	pairs := make([]string, 0, len(replies))
+	for emoji, action := range replies {
+		pairs = append(pairs, emoji+"="+action)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// SetQuickReply binds emoji to action, or unbinds it when action is empty.
+func SetQuickReply(replies map[string]string, emoji, action string) error {
+	emoji = NormalizeQuickReply(emoji)
+	if !IsStandaloneEmoji(emoji) {
+		return ErrInvalidQuickReply
+	}
+	if action == "" {
+		delete(replies, emoji)
+		return nil
+	}
+	if !validQuickAction(action) {
+		return ErrInvalidQuickReply
+	}
+	if _, exists := replies[emoji]; !exists && len(replies) >= maxQuickReplies {
+		return ErrInvalidQuickReply
+	}
+	replies[emoji] = action
+	return nil
+}
+
+// NormalizeQuickReply drops spaces and emoji variation selectors, which
+// clients add inconsistently, so "➕" and "➕️" match.
+func NormalizeQuickReply(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\ufe0f' || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// IsStandaloneEmoji accepts up to four non-ASCII symbols that are not letters or digits.
+func IsStandaloneEmoji(text string) bool {
+	runes := []rune(text)
+	if len(runes) == 0 || len(runes) > 4 {
+		return false
+	}
+	for _, r := range runes {
+		if r < 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func validQuickAction(action string) bool {
+	return action == QuickComplete || action == QuickList || action == QuickNew
+}
+
+func copyQuickReplies(replies map[string]string) map[string]string {
+	copied := make(map[string]string, len(replies))
+	for emoji, action := range replies {
+		copied[emoji] = action
+	}
+	return copied
+}
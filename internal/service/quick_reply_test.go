@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"daily-planner/internal/model"
+)
+
+func TestQuickRepliesRoundTrip(t *testing.T) {
+	replies := UserQuickReplies(model.User{})
+	if replies["✅"] != QuickComplete || len(replies) != len(DefaultQuickReplies) {
+		t.Fatalf("defaults not used: %v", replies)
+	}
+	if err := SetQuickReply(replies, "👀", QuickList); err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if err := SetQuickReply(replies, "➕️", ""); err != nil {
+		t.Fatalf("unbind: %v", err)
+	}
+	for _, bad := range []string{"ok", "✅=", "👀", ""} {
+		if err := SetQuickReply(replies, bad, QuickNew+"x"); err == nil {
+			t.Errorf("SetQuickReply(%q) accepted an unknown action", bad)
+		}
+	}
+	if err := SetQuickReply(replies, "a", QuickNew); err == nil {
+		t.Error("letters accepted as an emoji")
+	}
+
+	user := model.User{QuickReplies: EncodeQuickReplies(replies)}
+	got := UserQuickReplies(user)
+	if got["👀"] != QuickList || got["✅"] != QuickComplete || got["➕"] != "" {
+		t.Errorf("round trip lost bindings: %q -> %v", user.QuickReplies, got)
+	}
+
+	none := model.User{QuickReplies: EncodeQuickReplies(map[string]string{})}
+	if got := UserQuickReplies(none); len(got) != 0 {
+		t.Errorf("turned off replies came back: %v", got)
+	}
+}
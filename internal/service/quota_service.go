@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"daily-planner/internal/model"
+)
+
+// Limits are the per-user quotas; zero disables a limit.
+type Limits struct {
+	MaxActiveTasks     int
+	MaxCategories      int
+	MaxAttachmentBytes int64
+}
+
+// Quota resources reported in QuotaError.
+const (
+	QuotaTasks      = "tasks"
+	QuotaCategories = "categories"
+	QuotaAttachment = "attachment"
+)
+
+// QuotaError is returned when an action would exceed one of the user's limits.
+type QuotaError struct {
+	Resource string
+	Limit    int64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s quota exceeded (limit %d)", e.Resource, e.Limit)
+}
+
+// QuotaUsage describes how much of the quotas a user consumes.
+type QuotaUsage struct {
+	ActiveTasks int64
+	Categories  int64
+	Limits      Limits
+	Exempt      bool
+}
+
+// QuotaService enforces per-user limits; admins and exempted users bypass them.
+type QuotaService struct {
+	taskRepo     TaskStore
+	categoryRepo CategoryStore
+	userRepo     UserStore
+	limits       Limits
+	admins       map[int64]bool
+}
+
+func NewQuotaService(taskRepo TaskStore, categoryRepo CategoryStore, userRepo UserStore, limits Limits, adminIDs []int64) *QuotaService {
+	admins := make(map[int64]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return &QuotaService{taskRepo: taskRepo, categoryRepo: categoryRepo, userRepo: userRepo, limits: limits, admins: admins}
+}
+
+// IsAdmin reports whether the user's Telegram ID is listed in ADMIN_IDS.
+func (s *QuotaService) IsAdmin(user *model.User) bool {
+	return s.admins[user.TelegramID]
+}
+
+func (s *QuotaService) exempt(user *model.User) bool {
+	return user.QuotaExempt || s.IsAdmin(user)
+}
+
+// CheckTasks fails if creating count more tasks would exceed the active task limit.
+func (s *QuotaService) CheckTasks(ctx context.Context, user *model.User, count int) error {
+	if s.limits.MaxActiveTasks <= 0 || s.exempt(user) {
+		return nil
+	}
+	active, err := s.taskRepo.CountActiveByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if active+int64(count) > int64(s.limits.MaxActiveTasks) {
+		return &QuotaError{Resource: QuotaTasks, Limit: int64(s.limits.MaxActiveTasks)}
+	}
+	return nil
+}
+
+// CheckCategory fails if name would be a new category beyond the category limit.
+func (s *QuotaService) CheckCategory(ctx context.Context, user *model.User, scope model.Scope, name string) error {
+	if name == "" || s.limits.MaxCategories <= 0 || s.exempt(user) {
+		return nil
+	}
+	exists, err := s.categoryRepo.Exists(ctx, scope, name)
+	if err != nil || exists {
+		return err
+	}
+	total, err := s.categoryRepo.CountByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if total >= int64(s.limits.MaxCategories) {
+		return &QuotaError{Resource: QuotaCategories, Limit: int64(s.limits.MaxCategories)}
+	}
+	return nil
+}
+
+// CheckAttachment fails for uploads larger than the attachment limit.
+func (s *QuotaService) CheckAttachment(user *model.User, size int64) error {
+	if s.limits.MaxAttachmentBytes <= 0 || s.exempt(user) {
+		return nil
+	}
+	if size > s.limits.MaxAttachmentBytes {
+		return &QuotaError{Resource: QuotaAttachment, Limit: s.limits.MaxAttachmentBytes}
+	}
+	return nil
+}
+
+func (s *QuotaService) Usage(ctx context.Context, user *model.User) (QuotaUsage, error) {
+	tasks, err := s.taskRepo.CountActiveByUser(ctx, user.ID)
+	if err != nil {
+		return QuotaUsage{}, err
+	}
+	categories, err := s.categoryRepo.CountByUser(ctx, user.ID)
+	if err != nil {
+		return QuotaUsage{}, err
+	}
+	return QuotaUsage{ActiveTasks: tasks, Categories: categories, Limits: s.limits, Exempt: s.exempt(user)}, nil
+}
+
+// SetExempt lets an admin lift or restore the quotas of another user.
+func (s *QuotaService) SetExempt(ctx context.Context, admin *model.User, telegramID int64, exempt bool) (*model.User, error) {
+	if !s.IsAdmin(admin) {
+		return nil, ErrForbidden
+	}
+	return s.userRepo.SetQuotaExempt(ctx, telegramID, exempt)
+}
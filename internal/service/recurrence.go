@@ -0,0 +1,311 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// ErrInvalidRecurrence is returned for a recurrence whose day or window is out of range.
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// Window limits in days; weekly windows stay short so consecutive occurrences do not overlap.
+const (
+	MaxMonthlyWindow = 14
+	MaxWeeklyWindow  = 3
+	MaxRecurInterval = 365
+	MaxRecurMonths   = 12
+)
+
+// Presets for tasks repeating every few months.
+const (
+	QuarterlyMonths  = 3
+	HalfYearlyMonths = 6
+)
+
+// MaxWindow is the widest window a recurrence allows: daily ones repeating every
+// interval days and weekly ones on several days keep their windows apart the same way
+// weekly ones on a single day do.
+func MaxWindow(input TaskInput) int {
+	switch input.RecurType {
+	case model.RecurDaily:
+		interval := input.RecurInterval
+		if interval < 1 {
+			interval = 1
+		}
+		return (interval - 1) / 2
+	case model.RecurWeekly:
+		days := model.WeekdaysOf(input.RecurWeekdays)
+		if len(days) < 2 {
+			return MaxWeeklyWindow
+		}
+		gap := 7
+		for i, day := range days {
+			next := days[(i+1)%len(days)]
+			gap = min(gap, (int(next)-int(day)+7)%7)
+		}
+		return (gap - 1) / 2
+	default:
+		return MaxMonthlyWindow
+	}
+}
+
+// validateRecurrence checks the recurrence part of a task input and fills in its type.
+func validateRecurrence(input *TaskInput) error {
+	if !input.IsRecurring {
+		return nil
+	}
+	if input.RecurType == "" {
+		input.RecurType = model.RecurMonthly
+	}
+	if input.RecurType != model.RecurWeekly {
+		input.RecurWeekdays = 0
+	}
+	switch input.RecurType {
+	case model.RecurDaily:
+		if input.RecurInterval == 0 {
+			input.RecurInterval = 1
+		}
+		if input.RecurInterval < 1 || input.RecurInterval > MaxRecurInterval || input.RecurWindow < 0 ||
+			input.RecurWindow > MaxWindow(*input) {
+			return ErrInvalidRecurrence
+		}
+	case model.RecurMonthly:
+		if input.RecurInterval > 1 {
+			// The day comes from the first completion.
+			input.RecurDay = 0
+			if input.RecurInterval > MaxRecurMonths || input.RecurWindow < 0 || input.RecurWindow > MaxMonthlyWindow {
+				return ErrInvalidRecurrence
+			}
+			return nil
+		}
+		if input.RecurDay < 1 || input.RecurDay > 31 || input.RecurWindow < 0 || input.RecurWindow > MaxMonthlyWindow {
+			return ErrInvalidRecurrence
+		}
+	case model.RecurWeekly:
+		if input.RecurWeekdays != 0 {
+			if input.RecurWeekdays < 0 || input.RecurWeekdays >= 1<<7 {
+				return ErrInvalidRecurrence
+			}
+			// A set of one day is stored the way weekly tasks always were.
+			days := model.WeekdaysOf(input.RecurWeekdays)
+			input.RecurWeekday = int(days[0])
+			if len(days) == 1 {
+				input.RecurWeekdays = 0
+			}
+		}
+		if input.RecurWeekday < 0 || input.RecurWeekday > 6 || input.RecurWindow < 0 || input.RecurWindow > MaxWindow(*input) {
+			return ErrInvalidRecurrence
+		}
+	default:
+		return ErrInvalidRecurrence
+	}
+	return nil
+}
+
+// Occurrence returns the due date of the recurring task's occurrence around now, at
+// midnight in now's location: this month's day for monthly tasks, the nearest of the
+// weekdays for weekly ones (the coming one on a tie) and the nearest day of the interval
+// for daily ones. Tasks repeating every few months are due today until their first
+// completion and then on the nearest date that many months apart from it.
+func Occurrence(task model.Task, now time.Time) (time.Time, bool) {
+	if !task.IsRecurring {
+		return time.Time{}, false
+	}
+	today := startOfDay(now)
+	switch strings.ToLower(task.RecurType) {
+	case model.RecurDaily:
+		interval := task.RecurInterval
+		if interval < 1 {
+			interval = 1
+		}
+		anchor := startOfDay(task.CreatedAt.In(now.Location()))
+		if task.CreatedAt.IsZero() || !today.After(anchor) {
+			return today, true
+		}
+		elapsed := daysBetween(anchor, today)
+		offset := elapsed % interval
+		if offset*2 > interval {
+			offset -= interval
+		}
+		return today.AddDate(0, 0, -offset), true
+	case model.RecurMonthly:
+		if months := task.RecurMonths(); months > 0 {
+			if task.RecurFrom == nil {
+				return today, true
+			}
+			from := task.RecurFrom.In(now.Location())
+			elapsed := (today.Year()-from.Year())*12 + int(today.Month()) - int(from.Month())
+			step := max(elapsed, 0) / months
+			due := addMonths(from, step*months)
+			for _, k := range []int{step - 1, step + 1} {
+				if k < 0 {
+					continue
+				}
+				other := addMonths(from, k*months)
+				if d, best := abs(daysBetween(today, other)), abs(daysBetween(today, due)); d < best || d == best && other.After(due) {
+					due = other
+				}
+			}
+			return due, true
+		}
+		if task.RecurDay <= 0 {
+			return time.Time{}, false
+		}
+		year, month, _ := now.Date()
+		dueDay := task.RecurDay
+		if endOfMonth := daysInMonth(month, year); dueDay > endOfMonth {
+			dueDay = endOfMonth
+		}
+		return time.Date(year, month, dueDay, 0, 0, 0, 0, now.Location()), true
+	case model.RecurWeekly:
+		nearest := 7
+		for _, day := range task.Weekdays() {
+			offset := (int(day) - int(today.Weekday()) + 7) % 7
+			if offset > 3 {
+				offset -= 7
+			}
+			if abs(offset) < abs(nearest) || abs(offset) == abs(nearest) && offset > nearest {
+				nearest = offset
+			}
+		}
+		return today.AddDate(0, 0, nearest), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// NextOccurrence returns the due date the recurring task is waiting for: the current
+// occurrence while it is still open, the one after it once it is done or missed.
+func NextOccurrence(task model.Task, now time.Time) (time.Time, bool) {
+	due, _, end, ok := occurrenceWindow(task, now)
+	if !ok {
+		return time.Time{}, false
+	}
+	if now.Before(end) && !DoneInWindow(task, now) {
+		return due, true
+	}
+	return stepOccurrence(task, due, 1)
+}
+
+// MissedOccurrence returns the due date of the task's last occurrence whose window passed
+// without a completion, for tasks that carry missed occurrences forward until they are
+// done late. Only the last one is carried: older ones are dropped.
+func MissedOccurrence(task model.Task, now time.Time) (time.Time, bool) {
+	if !task.CatchUp || task.RecurEndedAt != nil {
+		return time.Time{}, false
+	}
+	due, _, end, ok := occurrenceWindow(task, now)
+	if !ok {
+		return time.Time{}, false
+	}
+	if now.Before(end) {
+		if due, ok = stepOccurrence(task, due, -1); !ok {
+			return time.Time{}, false
+		}
+		end = due.AddDate(0, 0, task.RecurWindow+1)
+	}
+	start := due.AddDate(0, 0, -task.RecurWindow)
+	if !task.CreatedAt.IsZero() && start.Before(startOfDay(task.CreatedAt.In(now.Location()))) {
+		return time.Time{}, false
+	}
+	if last := task.LastCompletedAt; last != nil && !last.Before(start) && last.Before(end) {
+		return time.Time{}, false
+	}
+	if task.CaughtUpTo != nil && !task.CaughtUpTo.Before(due) {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+// stepOccurrence returns the occurrence right after the one due on due, or right before it
+// when dir is negative. Tasks repeating every few months have none before their first.
+func stepOccurrence(task model.Task, due time.Time, dir int) (time.Time, bool) {
+	switch strings.ToLower(task.RecurType) {
+	case model.RecurDaily:
+		return due.AddDate(0, 0, dir*max(task.RecurInterval, 1)), true
+	case model.RecurWeekly:
+		set := model.WeekdaySet(task.Weekdays()...)
+		for i := 1; ; i++ {
+			if day := due.AddDate(0, 0, dir*i); set&model.WeekdaySet(day.Weekday()) != 0 {
+				return day, true
+			}
+		}
+	default:
+		if months := task.RecurMonths(); months > 0 {
+			if task.RecurFrom == nil {
+				return due, dir > 0
+			}
+			from := task.RecurFrom.In(due.Location())
+			elapsed := (due.Year()-from.Year())*12 + int(due.Month()) - int(from.Month()) + dir*months
+			if elapsed < 0 {
+				return time.Time{}, false
+			}
+			return addMonths(from, elapsed), true
+		}
+		month := time.Date(due.Year(), due.Month()+time.Month(dir), 1, 0, 0, 0, 0, due.Location())
+		return time.Date(month.Year(), month.Month(), min(task.RecurDay, daysInMonth(month.Month(), month.Year())), 0, 0, 0, 0, due.Location()), true
+	}
+}
+
+// addMonths returns the midnight of the day that many months after from, taking the
+// month's last day when it is shorter.
+func addMonths(from time.Time, months int) time.Time {
+	first := time.Date(from.Year(), from.Month()+time.Month(months), 1, 0, 0, 0, 0, from.Location())
+	day := min(from.Day(), daysInMonth(first.Month(), first.Year()))
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, from.Location())
+}
+
+// occurrenceWindow returns the current occurrence with the bounds of its window.
+// The window covers whole days, so end is the midnight after its last day.
+func occurrenceWindow(task model.Task, now time.Time) (due, start, end time.Time, ok bool) {
+	due, ok = Occurrence(task, now)
+	if !ok {
+		return time.Time{}, time.Time{}, time.Time{}, false
+	}
+	return due, due.AddDate(0, 0, -task.RecurWindow), due.AddDate(0, 0, task.RecurWindow+1), true
+}
+
+// InWindow reports whether now falls into the window of the task's current occurrence.
+func InWindow(task model.Task, now time.Time) bool {
+	_, start, end, ok := occurrenceWindow(task, now)
+	return ok && !now.Before(start) && now.Before(end)
+}
+
+// DoneInWindow reports whether the current occurrence of a recurring task is already done.
+func DoneInWindow(task model.Task, now time.Time) bool {
+	if task.LastCompletedAt == nil {
+		return false
+	}
+	_, start, end, ok := occurrenceWindow(task, now)
+	if !ok {
+		return false
+	}
+	last := task.LastCompletedAt.In(now.Location())
+	if last.Before(start) || !last.Before(end) {
+		return false
+	}
+	if task.RecurMonths() > 0 {
+		return task.RecurFrom != nil
+	}
+	if strings.ToLower(task.RecurType) == model.RecurMonthly {
+		return last.Month() == now.Month() && last.Year() == now.Year()
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// daysBetween counts calendar days from one midnight to another, ignoring DST shifts.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
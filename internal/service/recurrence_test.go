@@ -0,0 +1,194 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestWeeklyOccurrence(t *testing.T) {
+	// Wednesday, 12 March 2025.
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		weekday  time.Weekday
+		expected string
+	}{
+		{time.Wednesday, "2025-03-12"},
+		{time.Friday, "2025-03-14"},
+		{time.Saturday, "2025-03-15"},
+		{time.Sunday, "2025-03-09"},
+		{time.Monday, "2025-03-10"},
+	}
+	for _, tt := range tests {
+		task := model.Task{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: int(tt.weekday)}
+		due, ok := Occurrence(task, now)
+		if !ok || due.Format("2006-01-02") != tt.expected {
+			t.Errorf("%s: got %v, want %s", tt.weekday, due, tt.expected)
+		}
+	}
+}
+
+func TestWeeklyRecurringDue(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	svc := &ReminderService{}
+	friday := model.Task{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: int(time.Friday), RecurWindow: 2}
+	if !svc.recurringDue(friday, now) {
+		t.Error("Friday task with a two-day window should be due on Wednesday")
+	}
+	friday.RecurWindow = 1
+	if svc.recurringDue(friday, now) {
+		t.Error("Friday task with a one-day window should not be due on Wednesday")
+	}
+
+	monday := model.Task{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: int(time.Monday), RecurWindow: 3,
+		LastCompletedAt: ptr(time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))}
+	if svc.recurringDue(monday, now) || !DoneInWindow(monday, now) {
+		t.Error("Monday task done this Monday should stay closed")
+	}
+	monday.LastCompletedAt = ptr(time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC))
+	if !svc.recurringDue(monday, now) {
+		t.Error("Monday task done a week ago should be due again")
+	}
+}
+
+func TestValidateRecurrence(t *testing.T) {
+	input := TaskInput{IsRecurring: true, RecurDay: 5, RecurWindow: 2}
+	if err := validateRecurrence(&input); err != nil || input.RecurType != model.RecurMonthly {
+		t.Errorf("monthly by default: %v %q", err, input.RecurType)
+	}
+	weekly := TaskInput{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: 1, RecurWindow: MaxWeeklyWindow + 1}
+	if err := validateRecurrence(&weekly); err != ErrInvalidRecurrence {
+		t.Errorf("weekly window over the limit: got %v", err)
+	}
+}
+
+func TestWeekdaySet(t *testing.T) {
+	// Wednesday, 12 March 2025.
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	task := model.Task{IsRecurring: true, RecurType: model.RecurWeekly,
+		RecurWeekdays: model.WeekdaySet(time.Monday, time.Thursday)}
+	if due, ok := Occurrence(task, now); !ok || due.Format("2006-01-02") != "2025-03-13" {
+		t.Errorf("Monday and Thursday on Wednesday: got %v, want 2025-03-13", due)
+	}
+	task.RecurWeekdays = model.WeekdaySet(time.Monday, time.Saturday)
+	if due, ok := Occurrence(task, now); !ok || due.Format("2006-01-02") != "2025-03-10" {
+		t.Errorf("Monday and Saturday on Wednesday: got %v, want 2025-03-10", due)
+	}
+
+	input := TaskInput{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekdays: model.WeekdaySet(time.Monday, time.Thursday)}
+	if got := MaxWindow(input); got != 1 {
+		t.Errorf("MaxWindow for Monday and Thursday = %d, want 1", got)
+	}
+	input.RecurWindow = 2
+	if err := validateRecurrence(&input); err != ErrInvalidRecurrence {
+		t.Errorf("window over the gap between days: got %v", err)
+	}
+	input.RecurWindow = 1
+	if err := validateRecurrence(&input); err != nil || input.RecurWeekday != int(time.Monday) {
+		t.Errorf("set not accepted: %v, first day %d", err, input.RecurWeekday)
+	}
+	single := TaskInput{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekdays: model.WeekdaySet(time.Friday)}
+	if err := validateRecurrence(&single); err != nil || single.RecurWeekdays != 0 || single.RecurWeekday != int(time.Friday) {
+		t.Errorf("single day not folded into RecurWeekday: %v %+v", err, single)
+	}
+}
+
+func TestDailyOccurrence(t *testing.T) {
+	created := time.Date(2025, time.March, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		interval int
+		window   int
+		now      time.Time
+		due      string
+		open     bool
+	}{
+		{"every day", 1, 0, time.Date(2025, time.March, 7, 20, 0, 0, 0, time.UTC), "2025-03-07", true},
+		{"every 3 days, repeat day", 3, 0, time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC), "2025-03-07", true},
+		{"every 3 days, day after", 3, 0, time.Date(2025, time.March, 8, 9, 0, 0, 0, time.UTC), "2025-03-07", false},
+		{"every 3 days, day before", 3, 0, time.Date(2025, time.March, 9, 9, 0, 0, 0, time.UTC), "2025-03-10", false},
+		{"every 5 days with a window", 5, 2, time.Date(2025, time.March, 9, 9, 0, 0, 0, time.UTC), "2025-03-11", true},
+	}
+	svc := &ReminderService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := model.Task{IsRecurring: true, RecurType: model.RecurDaily, RecurInterval: tt.interval, RecurWindow: tt.window, CreatedAt: created}
+			due, ok := Occurrence(task, tt.now)
+			if !ok || due.Format("2006-01-02") != tt.due {
+				t.Errorf("due %v, want %s", due, tt.due)
+			}
+			if got := svc.recurringDue(task, tt.now); got != tt.open {
+				t.Errorf("due now: got %t, want %t", got, tt.open)
+			}
+		})
+	}
+
+	task := model.Task{IsRecurring: true, RecurType: model.RecurDaily, RecurInterval: 1, CreatedAt: created,
+		LastCompletedAt: ptr(time.Date(2025, time.March, 7, 8, 0, 0, 0, time.UTC))}
+	if !DoneInWindow(task, time.Date(2025, time.March, 7, 20, 0, 0, 0, time.UTC)) {
+		t.Error("done this morning should close today's repeat")
+	}
+	if DoneInWindow(task, time.Date(2025, time.March, 8, 8, 0, 0, 0, time.UTC)) {
+		t.Error("yesterday's completion should not close today's repeat")
+	}
+}
+
+func TestQuarterlyOccurrence(t *testing.T) {
+	// Wednesday, 12 March 2025.
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	task := model.Task{IsRecurring: true, RecurType: model.RecurMonthly, RecurInterval: QuarterlyMonths, RecurWindow: 7}
+	if due, ok := Occurrence(task, now); !ok || !due.Equal(startOfDay(now)) {
+		t.Errorf("before the first completion: got %v, want today", due)
+	}
+	if DoneInWindow(task, now) || !InWindow(task, now) {
+		t.Error("a quarterly task should wait for its first completion")
+	}
+
+	task.RecurFrom = ptr(time.Date(2024, time.November, 30, 10, 0, 0, 0, time.UTC))
+	task.LastCompletedAt = task.RecurFrom
+	tests := []struct {
+		now, due, next string
+	}{
+		{"2025-01-05", "2024-11-30", "2025-02-28"},
+		{"2025-02-25", "2025-02-28", "2025-02-28"},
+		{"2025-03-12", "2025-02-28", "2025-05-30"},
+		{"2025-05-31", "2025-05-30", "2025-05-30"},
+	}
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.now)
+		due, _ := Occurrence(task, day.Add(12*time.Hour))
+		next, _ := NextOccurrence(task, day.Add(12*time.Hour))
+		if due.Format("2006-01-02") != tt.due || next.Format("2006-01-02") != tt.next {
+			t.Errorf("%s: occurrence %s, next %s; want %s, %s", tt.now, due.Format("2006-01-02"), next.Format("2006-01-02"), tt.due, tt.next)
+		}
+	}
+}
+
+func TestMissedOccurrence(t *testing.T) {
+	// Wednesday, 12 March 2025.
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	task := model.Task{IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: int(time.Monday), RecurWindow: 1,
+		CreatedAt: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)}
+	if _, ok := MissedOccurrence(task, now); ok {
+		t.Error("missed occurrences are dropped unless the task carries them")
+	}
+	task.CatchUp = true
+	if missed, ok := MissedOccurrence(task, now); !ok || missed.Format("2006-01-02") != "2025-03-10" {
+		t.Errorf("got %v %v, want this Monday", missed, ok)
+	}
+	task.LastCompletedAt = ptr(time.Date(2025, time.March, 9, 20, 0, 0, 0, time.UTC))
+	if _, ok := MissedOccurrence(task, now); ok {
+		t.Error("done the evening before, inside the window")
+	}
+	task.LastCompletedAt = ptr(time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC))
+	task.CaughtUpTo = ptr(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC))
+	if _, ok := MissedOccurrence(task, now); ok {
+		t.Error("done late already")
+	}
+	task.CaughtUpTo = nil
+	task.CreatedAt = time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)
+	if _, ok := MissedOccurrence(task, now); ok {
+		t.Error("an occurrence before the task existed cannot be missed")
+	}
+}
@@ -8,135 +8,365 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"daily-planner/internal/i18n"
 	"daily-planner/internal/model"
 	"daily-planner/internal/repository"
+	"daily-planner/internal/tracing"
 )
 
 // ReminderService builds human-readable summaries for daily notifications.
 type ReminderService struct {
-	taskRepo     *repository.TaskRepository
-	categoryRepo *repository.CategoryRepository
+	taskRepo      TaskStore
+	categoryRepo  CategoryStore
+	workspaceRepo *repository.WorkspaceRepository
+	counterRepo   *repository.CounterRepository
+	userRepo      UserStore
+}
+
+func NewReminderService(taskRepo TaskStore, categoryRepo CategoryStore, workspaceRepo *repository.WorkspaceRepository, counterRepo *repository.CounterRepository, userRepo UserStore) *ReminderService {
+	return &ReminderService{taskRepo: taskRepo, categoryRepo: categoryRepo, workspaceRepo: workspaceRepo, counterRepo: counterRepo, userRepo: userRepo}
 }
 
-func NewReminderService(taskRepo *repository.TaskRepository, categoryRepo *repository.CategoryRepository) *ReminderService {
-	return &ReminderService{taskRepo: taskRepo, categoryRepo: categoryRepo}
+// Report is a rendered report together with the tasks it lists, in the order they are shown.
+type Report struct {
+	Text       string
+	Tasks      []model.Task
+	Categories []ReportCategory
 }
 
+// ReportCategory is a category with open tasks in a report; ID 0 stands for tasks without one.
+type ReportCategory struct {
+	ID    uint
+	Name  string
+	Emoji string
+	Color string
+	Count int
+}
+
+// DailySummary renders the report for the user's personal tasks and today's counters.
+// Tasks of categories routed to other chats are left out; see RoutedSummaries.
 func (s *ReminderService) DailySummary(ctx context.Context, user model.User, now time.Time) (string, error) {
-	tasks, err := s.taskRepo.ListActiveOrRecurring(ctx, user.ID)
+	report, err := s.DailyReport(ctx, user, now)
+	return report.Text, err
+}
+
+// DailyReport is DailySummary along with the open tasks it lists, for attaching actions to them.
+func (s *ReminderService) DailyReport(ctx context.Context, user model.User, now time.Time) (Report, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.DailyReport", attribute.Int64("user.id", int64(user.ID)))
+	defer span.End()
+
+	data, err := s.collect(ctx, model.PersonalScope(user.ID), 0, user.DeadlinePolicy(), now)
 	if err != nil {
-		return "", err
+		return Report{}, err
+	}
+	if data.counters, err = counterProgress(ctx, s.counterRepo, user, now); err != nil {
+		return Report{}, err
+	}
+	data.second = user.SecondLang()
+	tasks := append(append([]model.Task(nil), data.pending...), data.recurringDue...)
+	title := i18n.Pair{First: data.lang, Second: data.second}.T("📋 <b>Ежедневный отчёт</b>")
+	return Report{Text: renderSummary(data, title, nil, now), Tasks: tasks, Categories: reportCategories(tasks, data.categories)}, nil
+}
+
+// reportCategories counts the listed tasks per category, named ones alphabetically
+// and tasks without a category last.
+func reportCategories(tasks []model.Task, known map[uint]model.Category) []ReportCategory {
+	counts := make(map[uint]int)
+	for _, task := range tasks {
+		var id uint
+		if task.CategoryID != nil {
+			if _, ok := known[*task.CategoryID]; ok {
+				id = *task.CategoryID
+			}
+		}
+		counts[id]++
+	}
+	categories := make([]ReportCategory, 0, len(counts))
+	for id, count := range counts {
+		category := known[id]
+		categories = append(categories, ReportCategory{ID: id, Name: strings.TrimSpace(category.Name), Emoji: category.Emoji, Color: category.Color, Count: count})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if (a.ID == 0) != (b.ID == 0) {
+			return b.ID == 0
+		}
+		return a.Name < b.Name
+	})
+	return categories
+}
+
+// deadlineAlertWindow is how long before and after a deadline its alert may go out by default;
+// older overdue tasks are left to the daily report instead of alerting all at once.
+const deadlineAlertWindow = 24 * time.Hour
+
+// dueShift bounds how far the moment a date-only deadline falls due, in any time zone and
+// by any policy, is from the midnight UTC it is stored as.
+const dueShift = 48 * time.Hour
+
+// DueAlerts lists tasks whose deadline falls due, by the owner's policy, within their alert
+// lead time (a day by default) or just did and that were not alerted yet, plus those whose
+// snooze has run out.
+func (s *ReminderService) DueAlerts(ctx context.Context, now time.Time) ([]model.Task, error) {
+	maxLead := time.Duration(MaxDefaultAlertHours) * time.Hour
+	tasks, err := s.taskRepo.ListDueForAlert(ctx, now.Add(-deadlineAlertWindow-dueShift), now.Add(maxLead+dueShift), now)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[uint]*model.User)
+	due := tasks[:0]
+	for _, task := range tasks {
+		if task.SnoozedUntil != nil {
+			due = append(due, task)
+			continue
+		}
+		owner, ok := owners[task.UserID]
+		if !ok {
+			if owner, err = s.userRepo.FindByID(ctx, task.UserID); err != nil {
+				return nil, err
+			}
+			owners[task.UserID] = owner
+		}
+		at := DueAt(*task.Deadline, owner.Location(), owner.DeadlinePolicy())
+		if !at.Add(-alertLead(task)).After(now) && at.After(now.Add(-deadlineAlertWindow)) {
+			due = append(due, task)
+		}
+	}
+	return due, nil
+}
+
+func alertLead(task model.Task) time.Duration {
+	if task.AlertBeforeHours > 0 {
+		return time.Duration(task.AlertBeforeHours) * time.Hour
 	}
+	return deadlineAlertWindow
+}
+
+func (s *ReminderService) MarkAlerted(ctx context.Context, task *model.Task, now time.Time) error {
+	return s.taskRepo.MarkAlerted(ctx, task, now)
+}
 
-	categories, err := s.categoryRepo.ListByUser(ctx, user.ID)
+// WorkspaceSummary renders the shared task report for a workspace group chat,
+// naming the member who added each task.
+func (s *ReminderService) WorkspaceSummary(ctx context.Context, workspace model.Workspace, now time.Time) (string, error) {
+	authors, err := s.authors(ctx, workspace.ID)
+	if err != nil {
+		return "", err
+	}
+	policy, err := s.WorkspacePolicy(ctx, workspace)
+	if err != nil {
+		return "", err
+	}
+	data, err := s.collect(ctx, model.Scope{WorkspaceID: workspace.ID}, 0, policy, now)
 	if err != nil {
 		return "", err
 	}
-	catNames := make(map[uint]string)
+	title := data.lang.Tf("🏠 <b>Отчёт пространства «%s»</b>", html.EscapeString(workspace.Name))
+	return renderSummary(data, title, authors, now), nil
+}
+
+// WorkspacePolicy returns the deadline policy shared reports of the workspace follow, its owner's.
+func (s *ReminderService) WorkspacePolicy(ctx context.Context, workspace model.Workspace) (model.DeadlinePolicy, error) {
+	owner, err := s.userRepo.FindByID(ctx, workspace.OwnerID)
+	if err != nil {
+		return model.DeadlinePolicy{}, fmt.Errorf("find workspace owner: %w", err)
+	}
+	return owner.DeadlinePolicy(), nil
+}
+
+// RoutedReport is the part of a report that is delivered to a separate chat.
+type RoutedReport struct {
+	ChatID int64
+	Text   string
+}
+
+// RoutedSummaries renders one report per chat that categories of the scope are routed to,
+// judging deadlines by the reader's policy. Chats without pending or due tasks are skipped.
+func (s *ReminderService) RoutedSummaries(ctx context.Context, scope model.Scope, policy model.DeadlinePolicy, now time.Time) ([]RoutedReport, error) {
+	categories, err := s.categoryRepo.ListByScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64][]string)
+	var chats []int64
 	for _, cat := range categories {
-		catNames[cat.ID] = cat.Name
+		if cat.RouteChatID == 0 {
+			continue
+		}
+		if _, ok := names[cat.RouteChatID]; !ok {
+			chats = append(chats, cat.RouteChatID)
+		}
+		names[cat.RouteChatID] = append(names[cat.RouteChatID], html.EscapeString(strings.TrimSpace(cat.Name)))
+	}
+
+	var authors map[uint]string
+	if scope.IsWorkspace() && len(chats) > 0 {
+		if authors, err = s.authors(ctx, scope.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	var reports []RoutedReport
+	for _, chatID := range chats {
+		data, err := s.collect(ctx, scope, chatID, policy, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(data.pending) == 0 && len(data.recurringDue) == 0 {
+			continue
+		}
+		title := data.lang.Tf("📋 <b>Отчёт · %s</b>", strings.Join(names[chatID], ", "))
+		reports = append(reports, RoutedReport{ChatID: chatID, Text: renderSummary(data, title, authors, now)})
+	}
+	return reports, nil
+}
+
+// summaryData holds the tasks that make up one report.
+type summaryData struct {
+	pending      []model.Task
+	recurringDue []model.Task
+	catNames     map[uint]string
+	categories   map[uint]model.Category
+	counters     []CounterProgress
+	policy       model.DeadlinePolicy // deadline policy of the report's reader, the default for shared reports
+	lang         i18n.Lang
+	second       i18n.Lang // section headers are repeated in it, empty for none
+}
+
+// collect gathers open and due recurring tasks of the scope whose category
+// is routed to routeChatID (0 selects tasks for the main report), ordering deadlines by policy.
+func (s *ReminderService) collect(ctx context.Context, scope model.Scope, routeChatID int64, policy model.DeadlinePolicy, now time.Time) (summaryData, error) {
+	tasks, err := s.taskRepo.ListActiveOrRecurring(ctx, scope)
+	if err != nil {
+		return summaryData{}, err
 	}
 
-	var pending []model.Task
-	var recurringDue []model.Task
+	categories, err := s.categoryRepo.ListByScope(ctx, scope)
+	if err != nil {
+		return summaryData{}, err
+	}
+	data := summaryData{catNames: make(map[uint]string), categories: make(map[uint]model.Category), policy: policy, lang: i18n.FromContext(ctx)}
+	routes := make(map[uint]int64)
+	for _, cat := range categories {
+		data.catNames[cat.ID] = cat.Name
+		data.categories[cat.ID] = cat
+		routes[cat.ID] = cat.RouteChatID
+	}
 
 	for _, task := range tasks {
+		var route int64
+		if task.CategoryID != nil {
+			route = routes[*task.CategoryID]
+		}
+		if route != routeChatID {
+			continue
+		}
 		if task.IsRecurring {
-			if s.recurringDue(task, now) {
-				recurringDue = append(recurringDue, task)
+			if _, missed := MissedOccurrence(task, now); missed || s.recurringDue(task, now) {
+				data.recurringDue = append(data.recurringDue, task)
 			}
 			continue
 		}
 		if !task.IsCompleted {
-			pending = append(pending, task)
+			data.pending = append(data.pending, task)
 		}
 	}
 
-	sort.SliceStable(pending, func(i, j int) bool {
-		switch {
-		case pending[i].Deadline == nil && pending[j].Deadline == nil:
-			return pending[i].CreatedAt.After(pending[j].CreatedAt)
-		case pending[i].Deadline == nil:
-			return false
-		case pending[j].Deadline == nil:
-			return true
-		default:
-			return pending[i].Deadline.Before(*pending[j].Deadline)
+	sort.SliceStable(data.pending, func(i, j int) bool {
+		a, b := data.pending[i], data.pending[j]
+		if ra, rb := model.PriorityRank(a.Priority), model.PriorityRank(b.Priority); ra != rb {
+			return ra > rb
 		}
+		if c := CompareDeadlines(a.Deadline, b.Deadline, now.Location(), policy); c != 0 {
+			return c < 0
+		}
+		return a.CreatedAt.After(b.CreatedAt)
 	})
+	return data, nil
+}
+
+func (s *ReminderService) authors(ctx context.Context, workspaceID uint) (map[uint]string, error) {
+	members, err := s.workspaceRepo.ListMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	authors := make(map[uint]string, len(members))
+	for _, member := range members {
+		authors[member.UserID] = displayName(member.User)
+	}
+	return authors, nil
+}
 
+func renderSummary(data summaryData, title string, authors map[uint]string, now time.Time) string {
+	lang := data.lang
+	headers := i18n.Pair{First: lang, Second: data.second}
 	var builder strings.Builder
-	builder.WriteString("📋 <b>Ежедневный отчёт</b>\n")
+	builder.WriteString(title + "\n")
 	builder.WriteString(fmt.Sprintf("🗓 %s\n\n", now.Format("02.01.2006")))
 
-	builder.WriteString("🔥 <b>Текущие задачи</b>\n")
-	if len(pending) == 0 {
-		builder.WriteString("— нет открытых задач\n")
+	builder.WriteString(headers.T("🔥 <b>Текущие задачи</b>") + "\n")
+	if len(data.pending) == 0 {
+		builder.WriteString(lang.T("— нет открытых задач") + "\n")
 	} else {
-		for _, task := range pending {
-			builder.WriteString(formatTask(task, catNames, now))
+		for _, task := range data.pending {
+			builder.WriteString(formatTask(lang, task, data.catNames, authors, data.policy, now))
 		}
 	}
 
-	builder.WriteString("\n♻️ <b>Регулярные задачи</b>\n")
-	if len(recurringDue) == 0 {
-		builder.WriteString("— нет задач в окне выполнения\n")
+	builder.WriteString("\n" + headers.T("♻️ <b>Регулярные задачи</b>") + "\n")
+	if len(data.recurringDue) == 0 {
+		builder.WriteString(lang.T("— нет задач в окне выполнения") + "\n")
 	} else {
-		for _, task := range recurringDue {
-			builder.WriteString(formatRecurring(task, now, catNames))
+		for _, task := range data.recurringDue {
+			builder.WriteString(formatRecurring(lang, task, now, data.catNames, authors))
 		}
 	}
 
-	return strings.TrimSpace(builder.String()), nil
-}
-
-func (s *ReminderService) recurringDue(task model.Task, now time.Time) bool {
-	if !task.IsRecurring || strings.ToLower(task.RecurType) != "monthly" || task.RecurDay <= 0 {
-		return false
-	}
-
-	year, month, _ := now.Date()
-	dueDay := task.RecurDay
-	endOfMonth := daysInMonth(month, year)
-	if dueDay > endOfMonth {
-		dueDay = endOfMonth
+	if len(data.counters) > 0 {
+		builder.WriteString("\n" + headers.T("📈 <b>Счётчики на сегодня</b>") + "\n")
+		for _, progress := range data.counters {
+			builder.WriteString(fmt.Sprintf("• %s: %s\n", html.EscapeString(progress.Counter.Name), ProgressBar(progress)))
+		}
 	}
 
-	dueDate := time.Date(year, month, dueDay, 0, 0, 0, 0, now.Location())
-	window := time.Duration(task.RecurWindow) * 24 * time.Hour
-	start := dueDate.Add(-window)
-	end := dueDate.Add(window)
+	return strings.TrimSpace(builder.String())
+}
 
-	if now.Before(start) || now.After(end) {
-		return false
-	}
+func (s *ReminderService) recurringDue(task model.Task, now time.Time) bool {
+	return InWindow(task, now) && !DoneInWindow(task, now)
+}
 
-	if task.LastCompletedAt != nil {
-		if !task.LastCompletedAt.Before(start) && !task.LastCompletedAt.After(end) &&
-			task.LastCompletedAt.Month() == now.Month() && task.LastCompletedAt.Year() == now.Year() {
-			return false
-		}
+// PriorityMark prefixes the title of a task whose priority is not normal.
+func PriorityMark(priority string) string {
+	switch priority {
+	case model.PriorityUrgent:
+		return "‼️ "
+	case model.PriorityHigh:
+		return "❗ "
+	case model.PriorityLow:
+		return "🔽 "
+	default:
+		return ""
 	}
-
-	return true
 }
 
-func formatTask(task model.Task, catNames map[uint]string, now time.Time) string {
+func formatTask(lang i18n.Lang, task model.Task, catNames map[uint]string, authors map[uint]string, policy model.DeadlinePolicy, now time.Time) string {
 	var sb strings.Builder
 
 	icon := "🟢"
 	if task.Deadline != nil {
-		d := task.Deadline.In(now.Location())
 		switch {
-		case now.After(d):
+		case Overdue(task.Deadline, now, policy):
 			icon = "⚠️"
-		case d.Sub(now) <= 48*time.Hour:
+		case DueAt(*task.Deadline, now.Location(), policy).Sub(now) <= 48*time.Hour:
 			icon = "⏳"
 		}
 	}
 
 	title := html.EscapeString(strings.TrimSpace(task.Title))
-	sb.WriteString(fmt.Sprintf("%s %s", icon, title))
+	sb.WriteString(fmt.Sprintf("%s %s%s", icon, PriorityMark(task.Priority), title))
 
 	if task.CategoryID != nil {
 		if name, ok := catNames[*task.CategoryID]; ok {
@@ -147,13 +377,14 @@ func formatTask(task model.Task, catNames map[uint]string, now time.Time) string
 		}
 	}
 
+	writeAuthor(lang, &sb, task, authors)
+
 	if task.Deadline != nil {
 		d := task.Deadline.In(now.Location())
-		if now.After(d) {
-			sb.WriteString(fmt.Sprintf("\n   ⏰ до %s — <b>просрочено</b>", d.Format("2006-01-02")))
+		if Overdue(task.Deadline, now, policy) {
+			sb.WriteString("\n   " + lang.Tf("⏰ до %s — <b>просрочено</b>", d.Format("2006-01-02")))
 		} else {
-			daysLeft := int(d.Sub(now).Hours()/24) + 1
-			sb.WriteString(fmt.Sprintf("\n   ⏰ до %s · осталось ≈%d дн.", d.Format("2006-01-02"), daysLeft))
+			sb.WriteString("\n   " + lang.Tf("⏰ до %s · осталось ≈%d дн.", d.Format("2006-01-02"), DaysLeft(*task.Deadline, now)))
 		}
 	}
 
@@ -165,10 +396,10 @@ func formatTask(task model.Task, catNames map[uint]string, now time.Time) string
 	return sb.String()
 }
 
-func formatRecurring(task model.Task, now time.Time, catNames map[uint]string) string {
+func formatRecurring(lang i18n.Lang, task model.Task, now time.Time, catNames map[uint]string, authors map[uint]string) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("♻️ %s", html.EscapeString(strings.TrimSpace(task.Title))))
+	sb.WriteString(fmt.Sprintf("♻️ %s%s", PriorityMark(task.Priority), html.EscapeString(strings.TrimSpace(task.Title))))
 
 	if task.CategoryID != nil {
 		if name, ok := catNames[*task.CategoryID]; ok {
@@ -179,25 +410,45 @@ func formatRecurring(task model.Task, now time.Time, catNames map[uint]string) s
 		}
 	}
 
-	year, month, _ := now.Date()
-	dueDay := task.RecurDay
-	endOfMonth := daysInMonth(month, year)
-	if dueDay > endOfMonth {
-		dueDay = endOfMonth
-	}
-	dueDate := time.Date(year, month, dueDay, 0, 0, 0, 0, now.Location())
+	writeAuthor(lang, &sb, task, authors)
 
-	sb.WriteString(fmt.Sprintf("\n   📆 Ближайшая дата: %s (окно ±%d дн.)", dueDate.Format("2006-01-02"), task.RecurWindow))
+	dueDate, _ := NextOccurrence(task, now)
+
+	if missed, ok := MissedOccurrence(task, now); ok {
+		sb.WriteString("\n   " + lang.Tf("⚠️ Пропущен повтор %s — отметь задачу, когда сделаешь", missed.Format("2006-01-02")))
+	}
+	if task.ReminderText != "" {
+		sb.WriteString(fmt.Sprintf("\n   💬 %s", html.EscapeString(renderReminderText(lang, task, dueDate, now))))
+	}
+	sb.WriteString("\n   " + lang.Tf("📆 Ближайшая дата: %s (окно ±%d дн.)", dueDate.Format("2006-01-02"), task.RecurWindow))
 	if task.LastCompletedAt != nil {
-		sb.WriteString(fmt.Sprintf("\n   ✅ Последнее выполнение: %s", task.LastCompletedAt.In(now.Location()).Format("2006-01-02")))
+		sb.WriteString("\n   " + lang.Tf("✅ Последнее выполнение: %s", task.LastCompletedAt.In(now.Location()).Format("2006-01-02")))
 	} else {
-		sb.WriteString("\n   ✅ Пока не выполнялась")
+		sb.WriteString("\n   " + lang.T("✅ Пока не выполнялась"))
 	}
 
 	sb.WriteByte('\n')
 	return sb.String()
 }
 
+// writeAuthor names the member who added the workspace task; tasks have no assignee of their own.
+func writeAuthor(lang i18n.Lang, sb *strings.Builder, task model.Task, authors map[uint]string) {
+	if name, ok := authors[task.UserID]; ok && name != "" {
+		sb.WriteString(" · " + lang.Tf("✍️ автор: %s", html.EscapeString(name)))
+	}
+}
+
+// displayName picks the friendliest available name for a Telegram user.
+func displayName(user model.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return fmt.Sprintf("id %d", user.TelegramID)
+}
+
 func daysInMonth(month time.Month, year int) int {
 	// Move to next month, roll back a day.
 	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
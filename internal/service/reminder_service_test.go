@@ -0,0 +1,194 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestDailySummaryGolden(t *testing.T) {
+	cases := []struct {
+		name  string
+		now   time.Time
+		setup func(f *fixture, user *model.User)
+	}{
+		{
+			name:  "daily_summary_empty",
+			now:   date(2025, time.March, 10, 9),
+			setup: func(*fixture, *model.User) {},
+		},
+		{
+			name: "daily_summary_overdue",
+			now:  date(2025, time.March, 10, 9),
+			setup: func(f *fixture, user *model.User) {
+				work := f.category(user.Scope(), "Работа")
+				f.task(model.Task{UserID: user.ID, Title: "Сдать отчёт", CategoryID: work, Deadline: ptr(date(2025, time.March, 8, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Созвон <с командой>", Description: "обсудить & решить", CategoryID: work, Deadline: ptr(date(2025, time.March, 11, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Отпуск", Deadline: ptr(date(2025, time.April, 20, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Без срока", CreatedAt: date(2025, time.March, 1, 0)})
+				f.task(model.Task{UserID: user.ID, Title: "Уже сделано", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 9, 0))})
+			},
+		},
+		{
+			name: "daily_summary_recurring_month_edge",
+			now:  date(2025, time.February, 28, 9),
+			setup: func(f *fixture, user *model.User) {
+				bills := f.category(user.Scope(), "Счета")
+				// The 31st does not exist in February, so the last day of the month is used.
+				f.task(model.Task{UserID: user.ID, Title: "Аренда", CategoryID: bills, IsRecurring: true, RecurDay: 31, RecurWindow: 1})
+				f.task(model.Task{UserID: user.ID, Title: "Интернет", IsRecurring: true, RecurDay: 27, RecurWindow: 2, LastCompletedAt: ptr(date(2025, time.January, 27, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Уже оплачено", IsRecurring: true, RecurDay: 28, RecurWindow: 2, LastCompletedAt: ptr(date(2025, time.February, 27, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Не в окне", IsRecurring: true, RecurDay: 10, RecurWindow: 2})
+			},
+		},
+		{
+			name: "daily_summary_reminder_text",
+			now:  date(2025, time.March, 23, 9),
+			setup: func(f *fixture, user *model.User) {
+				f.task(model.Task{UserID: user.ID, Title: "Показания счётчиков", IsRecurring: true, RecurDay: 25, RecurWindow: 3,
+					ReminderText:    "Передать показания до {due_date}: осталось {days_left} дн., в прошлый раз {last_done}",
+					LastCompletedAt: ptr(date(2025, time.February, 24, 0))})
+				f.task(model.Task{UserID: user.ID, Title: "Новая подписка", IsRecurring: true, RecurDay: 24, RecurWindow: 2,
+					ReminderText: "{title} <оплата> ±{window} дн., {last_done}"})
+			},
+		},
+		{
+			name: "daily_summary_counters",
+			now:  date(2025, time.March, 10, 18),
+			setup: func(f *fixture, user *model.User) {
+				svc := NewCounterService(f.counters)
+				water, _ := svc.Add(f.ctx, user, "Вода", 8)
+				pushups, _ := svc.Add(f.ctx, user, "Отжимания", 3)
+				svc.Add(f.ctx, user, "Прогулка", 1)
+				// Yesterday's entries do not count: counters reset at midnight.
+				svc.Increment(f.ctx, user, water.ID, 5, date(2025, time.March, 9, 20))
+				for hour := 8; hour < 13; hour++ {
+					svc.Increment(f.ctx, user, water.ID, 1, date(2025, time.March, 10, hour))
+				}
+				svc.Increment(f.ctx, user, pushups.ID, 4, date(2025, time.March, 10, 7))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			user := f.user(1, "Alice")
+			tc.setup(f, user)
+
+			svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+			got, err := svc.DailySummary(f.ctx, *user, tc.now)
+			if err != nil {
+				t.Fatalf("DailySummary: %v", err)
+			}
+			assertGolden(t, tc.name, got)
+		})
+	}
+}
+
+func TestWorkspaceSummaryGolden(t *testing.T) {
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	member := f.user(2, "Bob")
+	workspace := model.Workspace{Name: "Семья", OwnerID: owner.ID, InviteCode: "FAMILY01"}
+	if err := f.workspaces.Create(f.ctx, &workspace); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := f.workspaces.AddMember(f.ctx, workspace.ID, member.ID, model.RoleEditor); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	home := f.category(model.Scope{UserID: owner.ID, WorkspaceID: workspace.ID}, "Дом")
+	f.task(model.Task{UserID: owner.ID, WorkspaceID: workspace.ID, Title: "Купить продукты", CategoryID: home, Deadline: ptr(date(2025, time.March, 11, 0))})
+	f.task(model.Task{UserID: member.ID, WorkspaceID: workspace.ID, Title: "Починить кран", Deadline: ptr(date(2025, time.March, 5, 0))})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	got, err := svc.WorkspaceSummary(f.ctx, workspace, date(2025, time.March, 10, 9))
+	if err != nil {
+		t.Fatalf("WorkspaceSummary: %v", err)
+	}
+	assertGolden(t, "workspace_summary", got)
+}
+
+func TestWorkspacesWithReports(t *testing.T) {
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	var workspaces []model.Workspace
+	for _, code := range []string{"BOUND001", "ROUTED01", "SILENT01"} {
+		workspace := model.Workspace{Name: code, OwnerID: owner.ID, InviteCode: code}
+		if err := f.workspaces.Create(f.ctx, &workspace); err != nil {
+			t.Fatalf("create workspace: %v", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	if err := f.workspaces.SetReportChat(f.ctx, &workspaces[0], -100); err != nil {
+		t.Fatalf("bind report chat: %v", err)
+	}
+	category, err := f.categories.GetOrCreate(f.ctx, model.Scope{UserID: owner.ID, WorkspaceID: workspaces[1].ID}, "Работа")
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	if err := f.categories.SetRoute(f.ctx, category, -200); err != nil {
+		t.Fatalf("route category: %v", err)
+	}
+	f.category(model.Scope{UserID: owner.ID, WorkspaceID: workspaces[2].ID}, "Дом")
+
+	got, err := f.workspaces.ListWithReports(f.ctx)
+	if err != nil {
+		t.Fatalf("ListWithReports: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != workspaces[0].ID || got[1].ID != workspaces[1].ID {
+		t.Errorf("ListWithReports = %+v, want the bound and the routed workspace", got)
+	}
+}
+
+func TestManagerDigestGolden(t *testing.T) {
+	now := date(2025, time.March, 16, 20)
+	f := newFixture(t)
+	owner := f.user(1, "Alice")
+	member := f.user(2, "Bob")
+	workspace := model.Workspace{Name: "Команда", OwnerID: owner.ID, InviteCode: "TEAM0001"}
+	if err := f.workspaces.Create(f.ctx, &workspace); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := f.workspaces.AddMember(f.ctx, workspace.ID, member.ID, model.RoleEditor); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	f.task(model.Task{UserID: owner.ID, WorkspaceID: workspace.ID, Title: "Релиз", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 14, 12))})
+	f.task(model.Task{UserID: owner.ID, WorkspaceID: workspace.ID, Title: "Ретро", Deadline: ptr(date(2025, time.March, 20, 0))})
+	f.task(model.Task{UserID: member.ID, WorkspaceID: workspace.ID, Title: "Тесты", Deadline: ptr(date(2025, time.March, 12, 0))})
+	f.task(model.Task{UserID: member.ID, WorkspaceID: workspace.ID, Title: "Старое", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.February, 1, 0))})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	got, err := svc.ManagerDigest(f.ctx, workspace, now)
+	if err != nil {
+		t.Fatalf("ManagerDigest: %v", err)
+	}
+	assertGolden(t, "manager_digest", got)
+}
+
+func TestValidateReminderText(t *testing.T) {
+	for text, valid := range map[string]bool{
+		"Осталось {days_left} дн.": true,
+		"{title}: до {due_date}":   true,
+		"Без подстановок":          true,
+		"Оплатить {сумма}":         false,
+		"Незакрытая { скобка":      false,
+		"{ title } к {window}":     true,
+		"{printf \"%v\" 1}":        false,
+		"{html .}":                 false,
+		"{call title}":             false,
+	} {
+		if err := ValidateReminderText(text); (err == nil) != valid {
+			t.Errorf("ValidateReminderText(%q) = %v, want valid=%t", text, err, valid)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+// ErrInvalidReminderText is returned for reminder texts with unknown or broken placeholders.
+var ErrInvalidReminderText = errors.New("invalid reminder text")
+
+// ReminderPlaceholders lists the placeholders a recurring task's reminder text may use.
+var ReminderPlaceholders = []string{"{title}", "{days_left}", "{due_date}", "{last_done}", "{window}"}
+
+// reminderPlaceholder matches a pair of braces with what is between them.
+var reminderPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// reminderVars are the values substituted into a reminder text.
+type reminderVars struct {
+	Title    string
+	DaysLeft int
+	DueDate  time.Time
+	LastDone *time.Time
+	Window   int
+	Lang     i18n.Lang
+}
+
+// value is what the placeholder stands for; ok is false for anything but a known one.
+func (vars reminderVars) value(placeholder string) (string, bool) {
+	switch strings.TrimSpace(strings.Trim(placeholder, "{}")) {
+	case "title":
+		return vars.Title, true
+	case "days_left":
+		return strconv.Itoa(vars.DaysLeft), true
+	case "due_date":
+		return vars.DueDate.Format("2006-01-02"), true
+	case "last_done":
+		if vars.LastDone == nil {
+			return vars.Lang.T("ещё не выполнялась"), true
+		}
+		return vars.LastDone.Format("2006-01-02"), true
+	case "window":
+		return strconv.Itoa(vars.Window), true
+	}
+	return "", false
+}
+
+// fillReminderText substitutes the placeholders of a reminder text. Nothing but the known
+// placeholders is evaluated; any other pair of braces, or a brace left open, is an error.
+func fillReminderText(text string, vars reminderVars) (string, error) {
+	valid := true
+	out := reminderPlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		value, ok := vars.value(placeholder)
+		valid = valid && ok
+		return value
+	})
+	if !valid || strings.Contains(reminderPlaceholder.ReplaceAllString(text, ""), "{") {
+		return "", ErrInvalidReminderText
+	}
+	return out, nil
+}
+
+// ValidateReminderText checks that a reminder text only uses known placeholders.
+func ValidateReminderText(text string) error {
+	_, err := fillReminderText(text, reminderVars{DueDate: time.Now()})
+	return err
+}
+
+// renderReminderText fills in the reminder text of a recurring task due on dueDate.
+// A text that no longer renders falls back to the task title.
+func renderReminderText(lang i18n.Lang, task model.Task, dueDate, now time.Time) string {
+	today := startOfDay(now)
+	daysLeft := int(dueDate.Sub(today).Hours() / 24)
+	if daysLeft < 0 {
+		daysLeft = 0
+	}
+	var lastDone *time.Time
+	if task.LastCompletedAt != nil {
+		local := task.LastCompletedAt.In(now.Location())
+		lastDone = &local
+	}
+	vars := reminderVars{Title: strings.TrimSpace(task.Title), DaysLeft: daysLeft, DueDate: dueDate, LastDone: lastDone, Window: task.RecurWindow, Lang: lang}
+
+	out, err := fillReminderText(task.ReminderText, vars)
+	if err != nil {
+		return vars.Title
+	}
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// maxReportHours caps the personal report interval at a week.
+const maxReportHours = 7 * 24
+
+// ReportScheduler decides when each user's daily report is due. Reports are checked
+// every tick, so a report is sent at the first tick after it becomes due.
+type ReportScheduler struct {
+	userRepo        *repository.UserRepository
+	runRepo         *repository.ReportRunRepository
+	defaultInterval time.Duration
+	tick            time.Duration
+}
+
+func NewReportScheduler(userRepo *repository.UserRepository, runRepo *repository.ReportRunRepository, defaultInterval, tick time.Duration) *ReportScheduler {
+	return &ReportScheduler{userRepo: userRepo, runRepo: runRepo, defaultInterval: defaultInterval, tick: tick}
+}
+
+// Interval is how often the user gets a report.
+func (s *ReportScheduler) Interval(user *model.User) time.Duration {
+	if user.ReportEveryHours > 0 {
+		return time.Duration(user.ReportEveryHours) * time.Hour
+	}
+	return s.defaultInterval
+}
+
+// Due lists users whose report should go out now.
+func (s *ReportScheduler) Due(ctx context.Context, now time.Time) ([]model.User, error) {
+	return s.userRepo.ListDueForReport(ctx, now)
+}
+
+// Begin starts a report run, or resumes the one an interrupted process left behind, and
+// returns the users it still has to reach in ID order. No run is stored when nobody is due.
+func (s *ReportScheduler) Begin(ctx context.Context, now time.Time) (*model.ReportRun, []model.User, error) {
+	run, err := s.runRepo.Unfinished(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if run == nil {
+		run = &model.ReportRun{DueAt: now}
+	}
+	users, err := s.userRepo.ListDueForReport(ctx, run.DueAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	remaining := users[:0]
+	for _, user := range users {
+		if user.ID > run.LastUserID {
+			remaining = append(remaining, user)
+		}
+	}
+	if run.ID == 0 && len(remaining) > 0 {
+		if err := s.runRepo.Create(ctx, run); err != nil {
+			return nil, nil, err
+		}
+	}
+	return run, remaining, nil
+}
+
+// Claim schedules the user's next report and records the run's progress before the report
+// goes out, so a restart never sends it twice; a report lost in a crash waits for the next interval.
+func (s *ReportScheduler) Claim(ctx context.Context, run *model.ReportRun, user *model.User, now time.Time) error {
+	if err := s.MarkSent(ctx, user, now); err != nil {
+		return err
+	}
+	return s.runRepo.Advance(ctx, run, user.ID)
+}
+
+// Finish closes a run once every user in it was reached.
+func (s *ReportScheduler) Finish(ctx context.Context, run *model.ReportRun) error {
+	if run.ID == 0 {
+		return nil
+	}
+	return s.runRepo.Finish(ctx, run)
+}
+
+// MarkSent schedules the user's next report one interval after now.
+func (s *ReportScheduler) MarkSent(ctx context.Context, user *model.User, now time.Time) error {
+	return s.userRepo.SetReportSchedule(ctx, user, user.ReportEveryHours, now.Add(s.Interval(user)))
+}
+
+// SetInterval changes the user's report interval, counting it from now, and
+// returns when the next report arrives.
+func (s *ReportScheduler) SetInterval(ctx context.Context, user *model.User, hours int, now time.Time) (time.Time, error) {
+	if hours < 1 || hours > maxReportHours {
+		return time.Time{}, fmt.Errorf("interval must be between 1 and %d hours", maxReportHours)
+	}
+	if err := s.userRepo.SetReportSchedule(ctx, user, hours, now.Add(time.Duration(hours)*time.Hour)); err != nil {
+		return time.Time{}, err
+	}
+	return s.Next(user, now), nil
+}
+
+// Next returns when the user's next report will actually be sent: the first tick
+// at or after the time it is due.
+func (s *ReportScheduler) Next(user *model.User, now time.Time) time.Time {
+	due := now
+	if user.NextReportAt != nil && user.NextReportAt.After(now) {
+		due = *user.NextReportAt
+	}
+	if s.tick <= 0 {
+		return due
+	}
+	next := due.Truncate(s.tick)
+	if next.Before(due) {
+		next = next.Add(s.tick)
+	}
+	return next
+}
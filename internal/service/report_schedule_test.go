@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportSchedulerNext(t *testing.T) {
+	f := newFixture(t)
+	scheduler := NewReportScheduler(f.users, f.runs, 5*time.Hour, 15*time.Minute)
+	user := f.user(1, "Анна")
+	now := date(2025, time.March, 10, 9).Add(7 * time.Minute)
+
+	if got := scheduler.Next(user, now); !got.Equal(date(2025, time.March, 10, 9).Add(15 * time.Minute)) {
+		t.Errorf("first report at %s, want the next tick", got)
+	}
+	next, err := scheduler.SetInterval(f.ctx, user, 24, now)
+	if err != nil {
+		t.Fatalf("set interval: %v", err)
+	}
+	if want := date(2025, time.March, 11, 9).Add(15 * time.Minute); !next.Equal(want) {
+		t.Errorf("next report at %s, want %s", next, want)
+	}
+	if due, _ := scheduler.Due(f.ctx, next); len(due) != 1 {
+		t.Errorf("user not due at %s", next)
+	}
+	if due, _ := scheduler.Due(f.ctx, now.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("user due before the interval passed: %v", due)
+	}
+	if _, err := scheduler.SetInterval(f.ctx, user, 0, now); err == nil {
+		t.Error("zero interval accepted")
+	}
+}
+
+func TestReportRunResumesAfterLastUser(t *testing.T) {
+	f := newFixture(t)
+	scheduler := NewReportScheduler(f.users, f.runs, 5*time.Hour, time.Minute)
+	first, second, third := f.user(1, "Анна"), f.user(2, "Борис"), f.user(3, "Вера")
+	now := date(2025, time.March, 10, 9)
+
+	run, users, err := scheduler.Begin(f.ctx, now)
+	if err != nil || len(users) != 3 {
+		t.Fatalf("begin: %v, %d users", err, len(users))
+	}
+	if err := scheduler.Claim(f.ctx, run, first, now); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	// The process restarts: the same run continues after the first user.
+	resumed, users, err := scheduler.Begin(f.ctx, now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if resumed.ID != run.ID || len(users) != 2 || users[0].ID != second.ID || users[1].ID != third.ID {
+		t.Fatalf("resumed run %d with %v, want run %d with the other two users", resumed.ID, users, run.ID)
+	}
+	for _, user := range users {
+		if err := scheduler.Claim(f.ctx, resumed, &user, now); err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+	}
+	if err := scheduler.Finish(f.ctx, resumed); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	next, users, err := scheduler.Begin(f.ctx, now.Add(3*time.Minute))
+	if err != nil || len(users) != 0 || next.ID != 0 {
+		t.Fatalf("after finishing: run %d with %d users, err %v", next.ID, len(users), err)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// RetentionService finds users who stopped using the bot and archives them
+// if they do not confirm they still want reports.
+type RetentionService struct {
+	userRepo       *repository.UserRepository
+	inactiveMonths int
+	graceDays      int
+}
+
+func NewRetentionService(userRepo *repository.UserRepository, inactiveMonths, graceDays int) *RetentionService {
+	return &RetentionService{userRepo: userRepo, inactiveMonths: inactiveMonths, graceDays: graceDays}
+}
+
+// Enabled reports whether the retention policy is configured.
+func (s *RetentionService) Enabled() bool {
+	return s.inactiveMonths > 0
+}
+
+// Inactive lists users who should be asked whether they still want reports.
+func (s *RetentionService) Inactive(ctx context.Context, now time.Time) ([]model.User, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+	return s.userRepo.ListInactive(ctx, now.AddDate(0, -s.inactiveMonths, 0))
+}
+
+func (s *RetentionService) MarkPrompted(ctx context.Context, user *model.User, now time.Time) error {
+	return s.userRepo.SetRetentionPrompt(ctx, user, now)
+}
+
+// ArchiveUnanswered archives users whose prompt went unanswered for the grace period.
+func (s *RetentionService) ArchiveUnanswered(ctx context.Context, now time.Time) (int, error) {
+	if !s.Enabled() {
+		return 0, nil
+	}
+	users, err := s.userRepo.ListUnansweredPrompts(ctx, now.AddDate(0, 0, -s.graceDays))
+	if err != nil {
+		return 0, err
+	}
+	for i := range users {
+		if err := s.userRepo.Archive(ctx, &users[i], now); err != nil {
+			return i, err
+		}
+	}
+	return len(users), nil
+}
+
+// Stop archives the user right away at their own request.
+func (s *RetentionService) Stop(ctx context.Context, user *model.User, now time.Time) error {
+	return s.userRepo.Archive(ctx, user, now)
+}
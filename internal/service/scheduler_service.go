@@ -4,20 +4,36 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+// heartbeatEvery is how often the scheduler proves to Running that its jobs still fire.
+const heartbeatEvery = 10 * time.Second
+
 // SchedulerService wraps cron-based jobs.
 type SchedulerService struct {
 	cron *cron.Cron
+	beat atomic.Int64 // unix nanoseconds of the last heartbeat, 0 while stopped
 }
 
 func NewSchedulerService(loc *time.Location) *SchedulerService {
-	return &SchedulerService{
+	s := &SchedulerService{
 		cron: cron.New(cron.WithLocation(loc), cron.WithSeconds()),
 	}
+	s.cron.Schedule(cron.Every(heartbeatEvery), cron.FuncJob(func() {
+		s.beat.Store(time.Now().UnixNano())
+	}))
+	return s
+}
+
+// Running reports whether the scheduler was started and still fires jobs; a heartbeat
+// missed several times over means the cron loop is stuck.
+func (s *SchedulerService) Running(now time.Time) bool {
+	beat := s.beat.Load()
+	return beat != 0 && now.Sub(time.Unix(0, beat)) < 3*heartbeatEvery
 }
 
 // ScheduleDaily registers a daily job at the given HH:MM time string.
@@ -29,13 +45,40 @@ func (s *SchedulerService) ScheduleDaily(timeStr string, job func()) (cron.Entry
 	return s.cron.AddFunc(spec, job)
 }
 
+// ScheduleWeekly registers a job on the given weekday at the HH:MM time string.
+func (s *SchedulerService) ScheduleWeekly(day time.Weekday, timeStr string, job func()) (cron.EntryID, error) {
+	spec, err := buildDailySpec(timeStr)
+	if err != nil {
+		return 0, err
+	}
+	// Replace the trailing "every weekday" field with a concrete day.
+	spec = strings.TrimSuffix(spec, "*") + strconv.Itoa(int(day))
+	return s.cron.AddFunc(spec, job)
+}
+
+// ScheduleMonthly registers a job on the given day of month (1–28) at the HH:MM time string.
+func (s *SchedulerService) ScheduleMonthly(day int, timeStr string, job func()) (cron.EntryID, error) {
+	if day < 1 || day > 28 {
+		return 0, fmt.Errorf("invalid day of month %d, expected 1-28", day)
+	}
+	spec, err := buildDailySpec(timeStr)
+	if err != nil {
+		return 0, err
+	}
+	// Replace the "every day of month" field with a concrete day.
+	spec = strings.Replace(spec, "* * *", strconv.Itoa(day)+" * *", 1)
+	return s.cron.AddFunc(spec, job)
+}
+
 func (s *SchedulerService) Start() {
+	s.beat.Store(time.Now().UnixNano())
 	s.cron.Start()
 }
 
 func (s *SchedulerService) Stop() {
 	ctx := s.cron.Stop()
 	<-ctx.Done()
+	s.beat.Store(0)
 }
 
 // ScheduleInterval registers a periodic job every given duration.
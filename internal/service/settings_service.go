@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// ErrInvalidProfile is returned for settings files that cannot be applied.
+var ErrInvalidProfile = errors.New("invalid settings profile")
+
+// settingsVersion is the format of exported settings profiles.
+const settingsVersion = 1
+
+// SettingsProfile is the portable part of a user's configuration: everything
+// except tasks and history, so it can move between self-hosted instances.
+type SettingsProfile struct {
+	Version          int                `json:"version"`
+	ExportedAt       time.Time          `json:"exported_at"`
+	Timezone         string             `json:"timezone,omitempty"`
+	ReportEveryHours int                `json:"report_every_hours,omitempty"`
+	WorkStartHour    int                `json:"work_start_hour"`
+	WorkEndHour      int                `json:"work_end_hour"`
+	QuickReplies     map[string]string  `json:"quick_replies"` // missing means the defaults
+	Categories       []CategorySettings `json:"categories,omitempty"`
+}
+
+// CategorySettings are the per-category options of a settings profile.
+type CategorySettings struct {
+	Name                string `json:"name"`
+	DefaultDeadlineDays int    `json:"default_deadline_days,omitempty"`
+	DefaultAlertHours   int    `json:"default_alert_hours,omitempty"`
+	RouteChatID         int64  `json:"route_chat_id,omitempty"`
+	Archived            bool   `json:"archived,omitempty"`
+	Emoji               string `json:"emoji,omitempty"`
+	Color               string `json:"color,omitempty"`
+}
+
+// SettingsImport summarises what an imported profile changed.
+type SettingsImport struct {
+	CategoriesCreated int
+	CategoriesUpdated int
+}
+
+// SettingsService exports and imports settings profiles.
+type SettingsService struct {
+	userRepo        *repository.UserRepository
+	categoryRepo    *repository.CategoryRepository
+	quotaSvc        *QuotaService
+	reportScheduler *ReportScheduler
+}
+
+func NewSettingsService(userRepo *repository.UserRepository, categoryRepo *repository.CategoryRepository, quotaSvc *QuotaService, reportScheduler *ReportScheduler) *SettingsService {
+	return &SettingsService{userRepo: userRepo, categoryRepo: categoryRepo, quotaSvc: quotaSvc, reportScheduler: reportScheduler}
+}
+
+// Export collects the settings of owner, whose personal categories are included,
+// and of self, the sender's own record that keeps the report interval.
+func (s *SettingsService) Export(ctx context.Context, owner, self *model.User, now time.Time) (*SettingsProfile, error) {
+	profile := &SettingsProfile{
+		Version:          settingsVersion,
+		ExportedAt:       now.UTC(),
+		Timezone:         owner.Timezone,
+		ReportEveryHours: self.ReportEveryHours,
+		WorkStartHour:    owner.WorkStartHour,
+		WorkEndHour:      owner.WorkEndHour,
+		QuickReplies:     UserQuickReplies(*owner),
+	}
+	categories, err := s.categoryRepo.ListByScope(ctx, model.PersonalScope(owner.ID))
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		profile.Categories = append(profile.Categories, CategorySettings{
+			Name:                category.Name,
+			DefaultDeadlineDays: category.DefaultDeadlineDays,
+			DefaultAlertHours:   category.DefaultAlertHours,
+			RouteChatID:         category.RouteChatID,
+			Archived:            category.ArchivedAt != nil,
+			Emoji:               category.Emoji,
+			Color:               category.Color,
+		})
+	}
+	return profile, nil
+}
+
+// ParseProfile reads and validates an exported profile.
+func ParseProfile(r io.Reader) (*SettingsProfile, error) {
+	var profile SettingsProfile
+	if err := json.NewDecoder(r).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+	if profile.Version != settingsVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidProfile, profile.Version)
+	}
+	if profile.Timezone != "" {
+		if _, err := time.LoadLocation(profile.Timezone); err != nil || profile.Timezone == "Local" {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidProfile, profile.Timezone)
+		}
+	}
+	if profile.WorkStartHour != 0 || profile.WorkEndHour != 0 {
+		if err := (WorkingHours{Start: profile.WorkStartHour, End: profile.WorkEndHour}).Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+		}
+	}
+	if profile.ReportEveryHours < 0 || profile.ReportEveryHours > maxReportHours {
+		return nil, fmt.Errorf("%w: report interval %d", ErrInvalidProfile, profile.ReportEveryHours)
+	}
+	if profile.QuickReplies != nil {
+		replies := make(map[string]string)
+		for emoji, action := range profile.QuickReplies {
+			if err := SetQuickReply(replies, emoji, action); err != nil {
+				return nil, fmt.Errorf("%w: quick reply %q", ErrInvalidProfile, emoji)
+			}
+		}
+		profile.QuickReplies = replies
+	}
+	for i, category := range profile.Categories {
+		profile.Categories[i].Name = strings.TrimSpace(category.Name)
+		if profile.Categories[i].Name == "" ||
+			category.DefaultDeadlineDays < 0 || category.DefaultDeadlineDays > MaxDefaultDeadlineDays ||
+			category.DefaultAlertHours < 0 || category.DefaultAlertHours > MaxDefaultAlertHours ||
+			(category.Emoji != "" && !ValidEmoji(category.Emoji)) ||
+			(category.Color != "" && !slices.Contains(model.CategoryColors, category.Color)) {
+			return nil, fmt.Errorf("%w: category %q", ErrInvalidProfile, category.Name)
+		}
+	}
+	return &profile, nil
+}
+
+// Import applies a profile on top of the current settings: user options are
+// replaced, categories are created or updated but never removed.
+func (s *SettingsService) Import(ctx context.Context, owner, self *model.User, profile *SettingsProfile, now time.Time) (SettingsImport, error) {
+	var result SettingsImport
+	if err := s.userRepo.SetTimezone(ctx, owner, profile.Timezone); err != nil {
+		return result, err
+	}
+	if err := s.userRepo.SetWorkingHours(ctx, owner, profile.WorkStartHour, profile.WorkEndHour); err != nil {
+		return result, err
+	}
+	quickReplies := ""
+	if profile.QuickReplies != nil {
+		quickReplies = EncodeQuickReplies(profile.QuickReplies)
+	}
+	if err := s.userRepo.SetQuickReplies(ctx, owner, quickReplies); err != nil {
+		return result, err
+	}
+	if profile.ReportEveryHours > 0 && profile.ReportEveryHours != self.ReportEveryHours {
+		if _, err := s.reportScheduler.SetInterval(ctx, self, profile.ReportEveryHours, now); err != nil {
+			return result, err
+		}
+	}
+
+	scope := model.PersonalScope(owner.ID)
+	for _, settings := range profile.Categories {
+		exists, err := s.categoryRepo.Exists(ctx, scope, settings.Name)
+		if err != nil {
+			return result, err
+		}
+		if !exists {
+			if err := s.quotaSvc.CheckCategory(ctx, owner, scope, settings.Name); err != nil {
+				return result, err
+			}
+		}
+		category, err := s.categoryRepo.GetOrCreate(ctx, scope, settings.Name)
+		if err != nil {
+			return result, err
+		}
+		if err := s.categoryRepo.SetDefaults(ctx, category, settings.DefaultDeadlineDays, settings.DefaultAlertHours); err != nil {
+			return result, err
+		}
+		if err := s.categoryRepo.SetRoute(ctx, category, settings.RouteChatID); err != nil {
+			return result, err
+		}
+		if settings.Emoji != "" || settings.Color != "" {
+			emoji := settings.Emoji
+			if emoji == "" {
+				emoji = category.Emoji
+			}
+			if err := s.categoryRepo.SetStyle(ctx, category, emoji, settings.Color); err != nil {
+				return result, err
+			}
+		}
+		if settings.Archived != (category.ArchivedAt != nil) {
+			var archivedAt *time.Time
+			if settings.Archived {
+				archivedAt = &now
+			}
+			if err := s.categoryRepo.SetArchived(ctx, category, archivedAt); err != nil {
+				return result, err
+			}
+		}
+		if exists {
+			result.CategoriesUpdated++
+		} else {
+			result.CategoriesCreated++
+		}
+	}
+	return result, nil
+}
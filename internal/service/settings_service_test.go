@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestSettingsProfileRoundTrip(t *testing.T) {
+	f := newFixture(t)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	quotas := NewQuotaService(f.tasks, f.categories, f.users, Limits{}, nil)
+	svc := NewSettingsService(f.users, f.categories, quotas, NewReportScheduler(f.users, f.runs, 5*time.Hour, time.Minute))
+
+	alice := f.user(1, "Alice")
+	if err := f.users.SetTimezone(f.ctx, alice, "Europe/Moscow"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.users.SetWorkingHours(f.ctx, alice, 10, 19); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.users.SetQuickReplies(f.ctx, alice, "👀=list"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.users.SetReportSchedule(f.ctx, alice, 3, now); err != nil {
+		t.Fatal(err)
+	}
+	work, err := f.categories.GetOrCreate(f.ctx, model.PersonalScope(alice.ID), "Работа")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.categories.SetDefaults(f.ctx, work, 3, 12); err != nil {
+		t.Fatal(err)
+	}
+	old, err := f.categories.GetOrCreate(f.ctx, model.PersonalScope(alice.ID), "Учеба")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.categories.SetArchived(f.ctx, old, &now); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := svc.Export(f.ctx, alice, alice, now)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseProfile(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	bob := f.user(2, "Bob")
+	result, err := svc.Import(f.ctx, bob, bob, parsed, now)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.CategoriesCreated != 2 || result.CategoriesUpdated != 0 {
+		t.Errorf("import result = %+v", result)
+	}
+	if bob.Timezone != "Europe/Moscow" || bob.WorkStartHour != 10 || bob.WorkEndHour != 19 || bob.ReportEveryHours != 3 {
+		t.Errorf("user settings not applied: %+v", bob)
+	}
+	if replies := UserQuickReplies(*bob); len(replies) != 1 || replies["👀"] != QuickList {
+		t.Errorf("quick replies = %v", replies)
+	}
+	categories, err := f.categories.ListByScope(f.ctx, model.PersonalScope(bob.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]model.Category)
+	for _, category := range categories {
+		got[category.Name] = category
+	}
+	if c := got["Работа"]; c.DefaultDeadlineDays != 3 || c.DefaultAlertHours != 12 || c.ArchivedAt != nil {
+		t.Errorf("work category = %+v", c)
+	}
+	if c := got["Учеба"]; c.ArchivedAt == nil {
+		t.Errorf("archived category restored as active: %+v", c)
+	}
+}
+
+func TestParseProfileRejectsBadFiles(t *testing.T) {
+	for _, raw := range []string{
+		`not json`,
+		`{"version": 2}`,
+		`{"version": 1, "timezone": "Mars/Base"}`,
+		`{"version": 1, "work_start_hour": 18, "work_end_hour": 9}`,
+		`{"version": 1, "quick_replies": {"ok": "list"}}`,
+		`{"version": 1, "categories": [{"name": " "}]}`,
+	} {
+		if _, err := ParseProfile(strings.NewReader(raw)); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("ParseProfile(%s) error = %v, want ErrInvalidProfile", raw, err)
+		}
+	}
+}
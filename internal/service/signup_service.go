@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// SignupService gates registration of new Telegram users behind an allowlist, invite codes
+// and, on open instances, an anti-spam button check.
+type SignupService struct {
+	userRepo    *repository.UserRepository
+	requestRepo *repository.AccessRequestRepository
+	codes       map[string]bool
+	allowed     map[int64]bool
+	admins      map[int64]bool
+	captcha     bool
+}
+
+// NewSignupService keeps signup open when neither an allowlist nor invite codes are configured;
+// admins are always let in.
+func NewSignupService(userRepo *repository.UserRepository, requestRepo *repository.AccessRequestRepository, inviteCodes []string, allowedIDs, adminIDs []int64, requireCaptcha bool) *SignupService {
+	codes := make(map[string]bool, len(inviteCodes))
+	for _, code := range inviteCodes {
+		if code = strings.TrimSpace(code); code != "" {
+			codes[code] = true
+		}
+	}
+	return &SignupService{
+		userRepo:    userRepo,
+		requestRepo: requestRepo,
+		codes:       codes,
+		allowed:     idSet(allowedIDs),
+		admins:      idSet(adminIDs),
+		captcha:     requireCaptcha,
+	}
+}
+
+func idSet(ids []int64) map[int64]bool {
+	set := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// Private reports whether only listed and approved users may register.
+func (s *SignupService) Private() bool {
+	return len(s.allowed) > 0
+}
+
+// Gated reports whether new users need an invite code.
+func (s *SignupService) Gated() bool {
+	return len(s.codes) > 0
+}
+
+// Admitted reports whether the Telegram user may use the bot without further checks.
+func (s *SignupService) Admitted(ctx context.Context, telegramID int64) (bool, error) {
+	if s.admins[telegramID] || s.allowed[telegramID] || (!s.Private() && !s.Gated() && !s.captcha) {
+		return true, nil
+	}
+	user, err := s.userRepo.FindByTelegramID(ctx, telegramID)
+	switch {
+	case err == nil:
+		return s.Verified(user), nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Verified reports whether the user has passed the anti-spam check or does not need it.
+func (s *SignupService) Verified(user *model.User) bool {
+	return !s.captcha || user.Verified || s.admins[user.TelegramID] || s.allowed[user.TelegramID]
+}
+
+// Verify marks the user as a human, e.g. after redeeming an invite code or pressing the right button.
+func (s *SignupService) Verify(ctx context.Context, user *model.User) error {
+	if user.Verified {
+		return nil
+	}
+	return s.userRepo.MarkVerified(ctx, user)
+}
+
+// ValidCode reports whether code is one of the configured invite codes.
+func (s *SignupService) ValidCode(code string) bool {
+	return s.codes[strings.TrimSpace(code)]
+}
+
+// RequestAccess records that an unknown user wants to use a private instance; created is
+// false when they asked before.
+func (s *SignupService) RequestAccess(ctx context.Context, request *model.AccessRequest) (created bool, err error) {
+	return s.requestRepo.Create(ctx, request)
+}
+
+// PendingRequests lists the users waiting for an admin, oldest first.
+func (s *SignupService) PendingRequests(ctx context.Context, adminTelegramID int64) ([]model.AccessRequest, error) {
+	if !s.admins[adminTelegramID] {
+		return nil, ErrForbidden
+	}
+	return s.requestRepo.List(ctx)
+}
+
+// Approve lets the user who asked for access in: they are registered as a verified user and
+// their request is dropped. gorm.ErrRecordNotFound means there is no such request.
+func (s *SignupService) Approve(ctx context.Context, adminTelegramID, telegramID int64) (*model.User, error) {
+	if !s.admins[adminTelegramID] {
+		return nil, ErrForbidden
+	}
+	request, err := s.requestRepo.Find(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.UpsertFromTelegram(ctx, request.TelegramID, request.FirstName, request.LastName, request.Username, request.LanguageCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Verify(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.requestRepo.Delete(ctx, telegramID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
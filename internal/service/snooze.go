@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+)
+
+// ErrInvalidWorkingHours is returned for working hours that are not a span within a day.
+var ErrInvalidWorkingHours = errors.New("invalid working hours")
+
+// WorkingHours is the part of the day, in whole hours, the user is at work.
+type WorkingHours struct {
+	Start int
+	End   int
+}
+
+// DefaultWorkingHours is used for users who did not set their own.
+var DefaultWorkingHours = WorkingHours{Start: 9, End: 18}
+
+// UserWorkingHours returns the user's working hours or the default ones.
+func UserWorkingHours(user model.User) WorkingHours {
+	if user.WorkStartHour == 0 && user.WorkEndHour == 0 {
+		return DefaultWorkingHours
+	}
+	return WorkingHours{Start: user.WorkStartHour, End: user.WorkEndHour}
+}
+
+// Validate leaves room for the evening after work within the same day.
+func (h WorkingHours) Validate() error {
+	if h.Start < 0 || h.End <= h.Start || h.End > 22 {
+		return ErrInvalidWorkingHours
+	}
+	return nil
+}
+
+// Evening is the hour evening reminders go out: an hour after work.
+func (h WorkingHours) Evening() int {
+	return h.End + 1
+}
+
+// Deliverable reports whether unrequested reminders may be sent at t: from the start
+// of work until three hours after it ends.
+func (h WorkingHours) Deliverable(t time.Time) bool {
+	return t.Hour() >= h.Start && t.Hour() < h.End+3
+}
+
+// quickSnooze is the snooze that is always offered, the same as the 😴 reaction.
+const quickSnooze = 3 * time.Hour
+
+// SnoozeOption is a suggested moment to repeat a deadline alert.
+type SnoozeOption struct {
+	Label string
+	Until time.Time
+}
+
+// SnoozeOptions suggests when to repeat the deadline alert of a task: in the evening or
+// tomorrow morning when it is due today, up to the deadline day or next week otherwise.
+// now must be in the user's time zone.
+func SnoozeOptions(task model.Task, now time.Time, hours WorkingHours) []SnoozeOption {
+	options := []SnoozeOption{{Label: i18n.N("Через 3 часа"), Until: now.Add(quickSnooze)}}
+	if task.Deadline == nil {
+		return options
+	}
+
+	today := startOfDay(now)
+	tomorrowMorning := atHour(today.AddDate(0, 0, 1), hours.Start)
+	deadlineDay := startOfDay(task.Deadline.In(now.Location()))
+
+	if !deadlineDay.After(today) {
+		// Too close to the evening, the quick snooze does the job.
+		if evening := atHour(today, hours.Evening()); evening.Sub(now) > quickSnooze {
+			options = append(options, SnoozeOption{Label: i18n.N("Вечером"), Until: evening})
+		}
+		return append(options, SnoozeOption{Label: i18n.N("Завтра утром"), Until: tomorrowMorning})
+	}
+
+	options = append(options, SnoozeOption{Label: i18n.N("Завтра утром"), Until: tomorrowMorning})
+	daysToMonday := (8 - int(today.Weekday())) % 7
+	if daysToMonday == 0 {
+		daysToMonday = 7
+	}
+	nextWeek := atHour(today.AddDate(0, 0, daysToMonday), hours.Start)
+	switch {
+	case !deadlineDay.Before(startOfDay(nextWeek)):
+		options = append(options, SnoozeOption{Label: i18n.N("На следующей неделе"), Until: nextWeek})
+	case deadlineDay.After(startOfDay(tomorrowMorning)):
+		options = append(options, SnoozeOption{Label: i18n.N("В день срока"), Until: atHour(deadlineDay, hours.Start)})
+	}
+	return options
+}
+
+var inHoursPattern = regexp.MustCompile(`^(?:через|in)\s+(?:(\d+)|an)?\s*(час|часа|часов|ч|hours?|h)$`)
+
+// ParseRelativeTime turns phrases like "вечером", "после работы", "завтра утром" or
+// "через 2 часа", and their English "tonight", "after work", "tomorrow morning" or
+// "in 2 hours", into a moment based on the working hours. Parts of the day that
+// already began today move to tomorrow. now must be in the user's time zone.
+func ParseRelativeTime(phrase string, now time.Time, hours WorkingHours) (time.Time, bool) {
+	phrase = strings.Join(strings.Fields(strings.ToLower(strings.TrimSpace(phrase))), " ")
+	if m := inHoursPattern.FindStringSubmatch(phrase); m != nil {
+		amount := 1
+		if m[1] != "" {
+			amount, _ = strconv.Atoi(m[1])
+		}
+		if amount <= 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(amount) * time.Hour), true
+	}
+
+	day := startOfDay(now)
+	explicitDay := false
+	for _, tomorrow := range []string{"завтра", "tomorrow"} {
+		if rest, ok := strings.CutPrefix(phrase, tomorrow); ok {
+			day = day.AddDate(0, 0, 1)
+			explicitDay = true
+			phrase = strings.TrimSpace(rest)
+			break
+		}
+	}
+
+	var hour int
+	switch phrase {
+	case "утром", "с утра", "morning", "in the morning":
+		hour = hours.Start
+	case "днём", "днем", "в обед", "afternoon", "at noon":
+		hour = (hours.Start + hours.End) / 2
+	case "после работы", "after work":
+		hour = hours.End
+	case "вечером", "tonight", "evening", "in the evening":
+		hour = hours.Evening()
+	case "":
+		if !explicitDay {
+			return time.Time{}, false
+		}
+		hour = hours.Start
+	default:
+		return time.Time{}, false
+	}
+
+	at := atHour(day, hour)
+	if !explicitDay && !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+func atHour(day time.Time, hour int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, day.Location())
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestSnoozeOptions(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, loc)
+	day := func(d int) *time.Time {
+		deadline := time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)
+		return &deadline
+	}
+
+	tests := []struct {
+		name     string
+		task     model.Task
+		now      time.Time
+		expected []string
+	}{
+		{"no deadline", model.Task{}, now, []string{"Через 3 часа"}},
+		{"due today", model.Task{Deadline: day(12)}, now, []string{"Через 3 часа", "Вечером 19:00", "Завтра утром 09:00"}},
+		{"due today, evening is near", model.Task{Deadline: day(12)}, now.Add(7 * time.Hour), []string{"Через 3 часа", "Завтра утром 09:00"}},
+		{"overdue", model.Task{Deadline: day(10)}, now, []string{"Через 3 часа", "Вечером 19:00", "Завтра утром 09:00"}},
+		{"due on Friday", model.Task{Deadline: day(14)}, now, []string{"Через 3 часа", "Завтра утром 09:00", "В день срока 09:00"}},
+		{"due tomorrow", model.Task{Deadline: day(13)}, now, []string{"Через 3 часа", "Завтра утром 09:00"}},
+		{"due next week", model.Task{Deadline: day(20)}, now, []string{"Через 3 часа", "Завтра утром 09:00", "На следующей неделе 09:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := SnoozeOptions(tt.task, tt.now, DefaultWorkingHours)
+			var got []string
+			for i, option := range options {
+				if i == 0 {
+					got = append(got, option.Label)
+					continue
+				}
+				got = append(got, option.Label+" "+option.Until.In(loc).Format("15:04"))
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("got %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("got %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+	if monday := SnoozeOptions(model.Task{Deadline: day(20)}, now, DefaultWorkingHours)[2].Until; monday.Weekday() != time.Monday || monday.Day() != 17 {
+		t.Errorf("next week should start on Monday the 17th, got %v", monday)
+	}
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2025, time.March, 12, 14, 30, 0, 0, loc)
+	hours := WorkingHours{Start: 10, End: 19}
+
+	tests := []struct {
+		phrase   string
+		expected string
+	}{
+		{"вечером", "12 20:00"},
+		{"После работы", "12 19:00"},
+		{"утром", "13 10:00"},
+		{"днём", "13 14:00"},
+		{"завтра", "13 10:00"},
+		{"завтра  вечером", "13 20:00"},
+		{"через 2 часа", "12 16:30"},
+		{"через час", "12 15:30"},
+		{"tomorrow morning", "13 10:00"},
+		{"in 2 hours", "12 16:30"},
+		{"tonight", "12 20:00"},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRelativeTime(tt.phrase, now, hours)
+		if !ok {
+			t.Errorf("%q: not recognised", tt.phrase)
+			continue
+		}
+		if formatted := got.Format("02 15:04"); formatted != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.phrase, formatted, tt.expected)
+		}
+	}
+
+	for _, phrase := range []string{"", "когда-нибудь", "через 0 часов", "купить молоко"} {
+		if _, ok := ParseRelativeTime(phrase, now, hours); ok {
+			t.Errorf("%q should not be recognised", phrase)
+		}
+	}
+}
+
+func TestWorkingHoursDeliverable(t *testing.T) {
+	hours := WorkingHours{Start: 9, End: 18}
+	for hour, expected := range map[int]bool{8: false, 9: true, 18: true, 20: true, 21: false, 23: false} {
+		at := time.Date(2025, time.March, 12, hour, 0, 0, 0, time.UTC)
+		if got := hours.Deliverable(at); got != expected {
+			t.Errorf("%d:00: got %t, want %t", hour, got, expected)
+		}
+	}
+	if err := (WorkingHours{Start: 18, End: 9}).Validate(); err == nil {
+		t.Error("end before start should be rejected")
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out mocks/stores.go -pkg mocks . TaskStore CategoryStore UserStore
+
+// TaskStore keeps tasks and their attachments; *repository.TaskRepository is the database one.
+type TaskStore interface {
+	AddAttachments(ctx context.Context, attachments []model.Attachment) error
+	CountActiveByUser(ctx context.Context, userID uint) (int64, error)
+	Create(ctx context.Context, task *model.Task) error
+	Delete(ctx context.Context, scope model.Scope, taskID uint) error
+	EndRecurrence(ctx context.Context, task *model.Task, at time.Time) error
+	FindByID(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error)
+	ImportBatch(ctx context.Context, scope model.Scope, batch []repository.ImportedTask) (int, error)
+	ListActiveOrRecurring(ctx context.Context, scope model.Scope) ([]model.Task, error)
+	ListAll(ctx context.Context, scope model.Scope) ([]model.Task, error)
+	ListArchived(ctx context.Context, scope model.Scope) ([]model.Task, error)
+	ListAttachments(ctx context.Context, taskID uint) ([]model.Attachment, error)
+	ListCompletedSince(ctx context.Context, scope model.Scope, since time.Time) ([]model.Task, error)
+	ListDeleted(ctx context.Context, scope model.Scope, since time.Time) ([]model.Task, error)
+	ListDueForAlert(ctx context.Context, from, to, now time.Time) ([]model.Task, error)
+	ListForEscalation(ctx context.Context, overdueBefore time.Time) ([]model.Task, error)
+	ListForNudge(ctx context.Context, maxPostpones int, idleBefore, nudgedBefore time.Time) ([]model.Task, error)
+	ListReferrers(ctx context.Context, scope model.Scope, taskID uint) ([]model.Task, error)
+	ListSubtasks(ctx context.Context, scope model.Scope, parentID uint) ([]model.Task, error)
+	MarkAlerted(ctx context.Context, task *model.Task, at time.Time) error
+	MarkCaughtUp(ctx context.Context, task *model.Task, due time.Time) error
+	MarkCompleted(ctx context.Context, task *model.Task, completedAt time.Time) error
+	MarkEscalated(ctx context.Context, task *model.Task) error
+	MarkNudged(ctx context.Context, task *model.Task, at time.Time) error
+	MarkRecurringDone(ctx context.Context, task *model.Task, completedAt time.Time) error
+	Merge(ctx context.Context, kept, duplicate *model.Task, merge *model.TaskMerge) error
+	Postpone(ctx context.Context, task *model.Task, deadline, now time.Time) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	Reopen(ctx context.Context, task *model.Task) error
+	Save(ctx context.Context, task *model.Task) error
+	Search(ctx context.Context, scope model.Scope, query string, limit, offset int) ([]model.Task, int64, error)
+	SetArchived(ctx context.Context, task *model.Task, at *time.Time) error
+	SetCatchUp(ctx context.Context, task *model.Task, on bool) error
+	SetEscalate(ctx context.Context, task *model.Task, on bool) error
+	SetReferences(ctx context.Context, taskID uint, refIDs []uint) error
+	Snooze(ctx context.Context, task *model.Task, until, now time.Time) error
+	Touch(ctx context.Context, task *model.Task, at time.Time) error
+	Undelete(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error)
+	UndoMerge(ctx context.Context, scope model.Scope, mergeID uint) (*model.TaskMerge, error)
+	WorkspaceActivity(ctx context.Context, workspaceID uint, since, now time.Time) ([]repository.MemberActivity, error)
+}
+
+// CategoryStore keeps categories; *repository.CategoryRepository is the database one.
+type CategoryStore interface {
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+	Exists(ctx context.Context, scope model.Scope, name string) (bool, error)
+	FindByID(ctx context.Context, scope model.Scope, id uint) (*model.Category, error)
+	GetOrCreate(ctx context.Context, scope model.Scope, name string) (*model.Category, error)
+	ListByScope(ctx context.Context, scope model.Scope) ([]model.Category, error)
+}
+
+// UserStore keeps users and their settings; *repository.UserRepository is the database one.
+type UserStore interface {
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	FindByTelegramID(ctx context.Context, telegramID int64) (*model.User, error)
+	ListAll(ctx context.Context) ([]model.User, error)
+	SetAutoDeleteMinutes(ctx context.Context, user *model.User, minutes int) error
+	SetDeadlineCountdown(ctx context.Context, user *model.User, on bool) error
+	SetDeadlinePolicy(ctx context.Context, user *model.User, startOfDay bool, graceHours int) error
+	SetHeatmapImage(ctx context.Context, user *model.User, image bool) error
+	SetPlainText(ctx context.Context, user *model.User, plain bool) error
+	SetLanguage(ctx context.Context, user *model.User, language, second string) error
+	SetListSorts(ctx context.Context, user *model.User, value string) error
+	SetQuickReplies(ctx context.Context, user *model.User, value string) error
+	SetQuotaExempt(ctx context.Context, telegramID int64, exempt bool) (*model.User, error)
+	SetReportDelivery(ctx context.Context, user *model.User, delivery string) error
+	SetReportMessage(ctx context.Context, user *model.User, messageID int) error
+	SetTimezone(ctx context.Context, user *model.User, timezone string) error
+	SetWeeklySummary(ctx context.Context, user *model.User, enabled bool) error
+	SetWorkingHours(ctx context.Context, user *model.User, start, end int) error
+	UpsertFromTelegram(ctx context.Context, telegramID int64, firstName, lastName, username, languageCode string) (*model.User, error)
+}
+
+var (
+	_ TaskStore     = (*repository.TaskRepository)(nil)
+	_ CategoryStore = (*repository.CategoryRepository)(nil)
+	_ UserStore     = (*repository.UserRepository)(nil)
+)
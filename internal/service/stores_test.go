@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+	"daily-planner/internal/service"
+	"daily-planner/internal/service/mocks"
+)
+
+func newTaskService(tasks *mocks.TaskStoreMock, limits service.Limits) *service.TaskService {
+	categories := &mocks.CategoryStoreMock{}
+	quota := service.NewQuotaService(tasks, categories, &mocks.UserStoreMock{}, limits, nil)
+	return service.NewTaskService(tasks, categories, nil, service.NewWorkspaceService(nil), quota)
+}
+
+func TestTaskServiceCreateTask(t *testing.T) {
+	tasks := &mocks.TaskStoreMock{
+		CountActiveByUserFunc: func(ctx context.Context, userID uint) (int64, error) { return 1, nil },
+		CreateFunc: func(ctx context.Context, task *model.Task) error {
+			task.ID = 7
+			return nil
+		},
+		FindByIDFunc: func(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error) {
+			if taskID != 3 {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &model.Task{ID: taskID}, nil
+		},
+		SetReferencesFunc: func(ctx context.Context, taskID uint, refIDs []uint) error { return nil },
+	}
+	svc := newTaskService(tasks, service.Limits{MaxActiveTasks: 2})
+	user := &model.User{ID: 3}
+
+	task, err := svc.CreateTask(context.Background(), user, service.TaskInput{
+		Title: "Поменять фильтр", Description: "Как в #3, не как в #99; сама задача — #7, а код &#3 не ссылка.",
+		IsRecurring: true, RecurType: model.RecurMonthly, RecurInterval: service.HalfYearlyMonths,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if task.ID != 7 || task.UserID != 3 || task.RecurMonths() != service.HalfYearlyMonths {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if calls := tasks.CreateCalls(); len(calls) != 1 || calls[0].Task.Title != "Поменять фильтр" {
+		t.Errorf("Create calls: %+v", calls)
+	}
+	if calls := tasks.SetReferencesCalls(); len(calls) != 1 || calls[0].TaskID != 7 || len(calls[0].RefIDs) != 1 || calls[0].RefIDs[0] != 3 {
+		t.Errorf("SetReferences calls: %+v", calls)
+	}
+
+	tasks.CountActiveByUserFunc = func(ctx context.Context, userID uint) (int64, error) { return 2, nil }
+	var quotaErr *service.QuotaError
+	if _, err := svc.CreateTask(context.Background(), user, service.TaskInput{Title: "Ещё одна"}); !errors.As(err, &quotaErr) {
+		t.Errorf("over the limit: got %v, want a quota error", err)
+	}
+	if len(tasks.CreateCalls()) != 1 {
+		t.Error("a task over the limit was stored")
+	}
+}
+
+func TestTaskServiceCompleteRecurring(t *testing.T) {
+	stored := model.Task{ID: 5, UserID: 3, IsRecurring: true, RecurType: model.RecurMonthly, RecurInterval: service.QuarterlyMonths}
+	tasks := &mocks.TaskStoreMock{
+		FindByIDFunc: func(ctx context.Context, scope model.Scope, taskID uint) (*model.Task, error) {
+			task := stored
+			return &task, nil
+		},
+		MarkRecurringDoneFunc: func(ctx context.Context, task *model.Task, completedAt time.Time) error {
+			task.LastCompletedAt = &completedAt
+			return nil
+		},
+	}
+	svc := newTaskService(tasks, service.Limits{})
+	done := time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	task, err := svc.CompleteTask(context.Background(), &model.User{ID: 3}, 5, done)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if task.IsCompleted || task.RecurFrom == nil || !task.RecurFrom.Equal(done) {
+		t.Errorf("first completion should start the schedule: %+v", task)
+	}
+	if calls := tasks.FindByIDCalls(); len(calls) != 1 || calls[0].Scope != (model.Scope{UserID: 3}) {
+		t.Errorf("FindByID calls: %+v", calls)
+	}
+}
+
+func TestReminderServiceDueAlerts(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, time.March, 13, 0, 0, 0, 0, time.UTC)
+	tasks := &mocks.TaskStoreMock{
+		ListDueForAlertFunc: func(ctx context.Context, from, to, now time.Time) ([]model.Task, error) {
+			return []model.Task{
+				{ID: 1, UserID: 10, Deadline: &deadline},
+				{ID: 2, UserID: 20, Deadline: &deadline},
+				{ID: 3, UserID: 20, Deadline: &deadline, AlertBeforeHours: 1},
+			}, nil
+		},
+	}
+	users := &mocks.UserStoreMock{
+		FindByIDFunc: func(ctx context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Timezone: "UTC", DeadlineStartOfDay: id == 20}, nil
+		},
+	}
+	svc := service.NewReminderService(tasks, &mocks.CategoryStoreMock{}, nil, nil, users)
+
+	due, err := svc.DueAlerts(context.Background(), now)
+	if err != nil {
+		t.Fatalf("due alerts: %v", err)
+	}
+	// Tomorrow's deadline falls due when tomorrow ends, more than a day away, except for the
+	// owner counting from the start of the day; their one-hour alert is still ahead.
+	if len(due) != 1 || due[0].ID != 2 {
+		t.Errorf("got %+v, want only task 2", due)
+	}
+	if calls := users.FindByIDCalls(); len(calls) != 2 {
+		t.Errorf("owners looked up %d times, want once each", len(calls))
+	}
+}
+
+func TestExportServiceExport(t *testing.T) {
+	category := uint(4)
+	created := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	tasks := &mocks.TaskStoreMock{
+		ListAllFunc: func(ctx context.Context, scope model.Scope) ([]model.Task, error) {
+			return []model.Task{
+				{ID: 1, Title: "Оплатить, наконец, счёт", CategoryID: &category, Deadline: &deadline, Priority: model.PriorityHigh, CreatedAt: created},
+				{ID: 2, Title: "Полить цветы", IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekdays: 1<<time.Monday | 1<<time.Thursday, CreatedAt: created},
+				{ID: 3, Title: "Старое", IsCompleted: true, CreatedAt: created},
+			}, nil
+		},
+	}
+	categories := &mocks.CategoryStoreMock{
+		ListByScopeFunc: func(ctx context.Context, scope model.Scope) ([]model.Category, error) {
+			return []model.Category{{ID: 4, Name: "Дом"}}, nil
+		},
+	}
+	svc := service.NewExportService(tasks, categories, service.NewWorkspaceService(nil), service.NewQuotaService(tasks, categories, &mocks.UserStoreMock{}, service.Limits{}, nil))
+	user := &model.User{ID: 3, Timezone: "UTC"}
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	export, err := svc.Export(context.Background(), user, service.ExportCSV, now)
+	if err != nil {
+		t.Fatalf("export csv: %v", err)
+	}
+	if export.Name != "tasks-2025-03-10.csv" || export.Count != 3 {
+		t.Errorf("export %q of %d tasks", export.Name, export.Count)
+	}
+	for _, want := range []string{
+		`1,"Оплатить, наконец, счёт",,Дом,high,open,2025-03-20T00:00:00Z,2025-03-01T09:00:00Z`,
+		`2,Полить цветы,,,normal,open,,2025-03-01T09:00:00Z,,,,weekly,,,mon thu,,`,
+		`3,Старое,,,normal,done,`,
+	} {
+		if !strings.Contains(string(export.Data), want) {
+			t.Errorf("csv lacks %q:\n%s", want, export.Data)
+		}
+	}
+
+	export, err = svc.Export(context.Background(), user, service.ExportJSON, now)
+	if err != nil {
+		t.Fatalf("export json: %v", err)
+	}
+	var exported []service.ExportedTask
+	if err := json.Unmarshal(export.Data, &exported); err != nil || len(exported) != 3 {
+		t.Fatalf("json: %v\n%s", err, export.Data)
+	}
+	if recur := exported[1].Recurrence; recur == nil || strings.Join(recur.Weekdays, ",") != "mon,thu" {
+		t.Errorf("recurrence: %+v", recur)
+	}
+
+	if _, err := svc.Export(context.Background(), user, "xlsx", now); !errors.Is(err, service.ErrUnknownExportFormat) {
+		t.Errorf("xlsx: got %v", err)
+	}
+}
+
+func TestExportServiceImportTasks(t *testing.T) {
+	var created []repository.ImportedTask
+	tasks := &mocks.TaskStoreMock{
+		ImportBatchFunc: func(ctx context.Context, scope model.Scope, batch []repository.ImportedTask) (int, error) {
+			created = batch
+			return 1, nil
+		},
+	}
+	categories := &mocks.CategoryStoreMock{}
+	svc := service.NewExportService(tasks, categories, service.NewWorkspaceService(nil), service.NewQuotaService(tasks, categories, &mocks.UserStoreMock{}, service.Limits{}, nil))
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	file := "\ufeffid,title,category,status,parent_id,recur_type,recur_day\n" +
+		"7,Оплатить счёт,Дом,,,monthly,5\n" +
+		"8,Старое,Архив,archived,7,,\n" +
+		"9,Без дня,,,,monthly,\n" +
+		"10,По кругу,,,11,,\n" +
+		"11,И обратно,,,10,,\n"
+
+	result, err := svc.ImportTasks(context.Background(), &model.User{ID: 3, Timezone: "UTC"}, strings.NewReader(file), service.ExportCSV, now)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Created != 4 || result.Categories != 1 || len(result.Problems) != 1 || result.Problems[0].Row != 4 {
+		t.Errorf("result: %+v", result)
+	}
+	if len(created) != 4 || created[0].Task.UserID != 3 || created[0].Task.RecurDay != 5 || created[0].Category != "Дом" || created[1].Task.ArchivedAt == nil {
+		t.Fatalf("created: %+v", created)
+	}
+	if created[0].Parent != -1 || created[1].Parent != 0 {
+		t.Errorf("parents: %d, %d", created[0].Parent, created[1].Parent)
+	}
+	if (created[2].Parent == 3) == (created[3].Parent == 2) {
+		t.Errorf("a loop of parents should be broken once: %d, %d", created[2].Parent, created[3].Parent)
+	}
+
+	big := strings.NewReader("title\n" + strings.Repeat("x", 5<<20))
+	if _, err := svc.ImportTasks(context.Background(), &model.User{ID: 3, Timezone: "UTC"}, big, service.ExportCSV, now); !errors.Is(err, service.ErrInvalidImport) {
+		t.Errorf("oversized file: got %v", err)
+	}
+}
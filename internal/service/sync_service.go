@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// SyncTask is a task as exchanged between instances. Tasks are matched by UID
+// and the copy with the later UpdatedAt wins; a copy with DeletedAt set is a
+// tombstone that deletes the task on the peer.
+type SyncTask struct {
+	UID              string     `json:"uid"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description,omitempty"`
+	Category         string     `json:"category,omitempty"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	Priority         string     `json:"priority,omitempty"`
+	IsCompleted      bool       `json:"is_completed,omitempty"`
+	IsRecurring      bool       `json:"is_recurring,omitempty"`
+	RecurType        string     `json:"recur_type,omitempty"`
+	RecurDay         int        `json:"recur_day,omitempty"`
+	RecurWeekday     int        `json:"recur_weekday,omitempty"`
+	RecurWeekdays    int        `json:"recur_weekdays,omitempty"`
+	RecurInterval    int        `json:"recur_interval,omitempty"`
+	RecurWindow      int        `json:"recur_window,omitempty"`
+	ReminderText     string     `json:"reminder_text,omitempty"`
+	LastCompletedAt  *time.Time `json:"last_completed_at,omitempty"`
+	RecurFrom        *time.Time `json:"recur_from,omitempty"`
+	CatchUp          bool       `json:"catch_up,omitempty"`
+	CaughtUpTo       *time.Time `json:"caught_up_to,omitempty"`
+	RecurEndedAt     *time.Time `json:"recur_ended_at,omitempty"`
+	AlertBeforeHours int        `json:"alert_before_hours,omitempty"`
+	ArchivedAt       *time.Time `json:"archived_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+}
+
+// SyncBatch carries the changed personal tasks of one Telegram user.
+type SyncBatch struct {
+	TelegramID int64      `json:"telegram_id"`
+	Tasks      []SyncTask `json:"tasks"`
+}
+
+// SyncResult counts what applying a peer's changes did.
+type SyncResult struct {
+	Created int
+	Updated int
+	Deleted int
+	Skipped int // older than the local copy or for users unknown here
+}
+
+// SyncService exchanges personal tasks of selected users with peer instances.
+type SyncService struct {
+	syncRepo     *repository.SyncRepository
+	userRepo     *repository.UserRepository
+	categoryRepo *repository.CategoryRepository
+	accountSvc   *AccountService
+	telegramIDs  map[int64]bool
+}
+
+func NewSyncService(syncRepo *repository.SyncRepository, userRepo *repository.UserRepository, categoryRepo *repository.CategoryRepository, accountSvc *AccountService, telegramIDs []int64) *SyncService {
+	ids := make(map[int64]bool, len(telegramIDs))
+	for _, id := range telegramIDs {
+		ids[id] = true
+	}
+	return &SyncService{syncRepo: syncRepo, userRepo: userRepo, categoryRepo: categoryRepo, accountSvc: accountSvc, telegramIDs: ids}
+}
+
+// owner resolves a synced Telegram user to the user whose tasks are exchanged;
+// it returns nil for users who are not synced or never used this instance.
+func (s *SyncService) owner(ctx context.Context, telegramID int64) (*model.User, error) {
+	if !s.telegramIDs[telegramID] {
+		return nil, nil
+	}
+	user, err := s.userRepo.FindByTelegramID(ctx, telegramID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.accountSvc.Owner(ctx, user)
+}
+
+// Collect returns the personal tasks of synced users changed after since, deleted ones
+// included as tombstones.
+func (s *SyncService) Collect(ctx context.Context, since time.Time) ([]SyncBatch, error) {
+	var batches []SyncBatch
+	for telegramID := range s.telegramIDs {
+		owner, err := s.owner(ctx, telegramID)
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil {
+			continue
+		}
+		scope := model.PersonalScope(owner.ID)
+		tasks, err := s.syncRepo.ListChangedSince(ctx, scope, since)
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+		categories, err := s.categoryRepo.ListByScope(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[uint]string, len(categories))
+		for _, category := range categories {
+			names[category.ID] = category.Name
+		}
+		batch := SyncBatch{TelegramID: telegramID}
+		for i := range tasks {
+			if tasks[i].SyncUID == "" && tasks[i].DeletedAt.Valid {
+				// Deleted before it was ever synced: no peer has it.
+				continue
+			}
+			if tasks[i].SyncUID == "" {
+				uid, err := newSyncUID()
+				if err != nil {
+					return nil, err
+				}
+				if err := s.syncRepo.AssignSyncUID(ctx, &tasks[i], uid); err != nil {
+					return nil, err
+				}
+			}
+			batch.Tasks = append(batch.Tasks, toSyncTask(tasks[i], names))
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// Apply stores a peer's changes, keeping local copies that are at least as new.
+func (s *SyncService) Apply(ctx context.Context, batches []SyncBatch) (SyncResult, error) {
+	var result SyncResult
+	for _, batch := range batches {
+		owner, err := s.owner(ctx, batch.TelegramID)
+		if err != nil {
+			return result, err
+		}
+		if owner == nil {
+			result.Skipped += len(batch.Tasks)
+			continue
+		}
+		scope := model.PersonalScope(owner.ID)
+		for _, remote := range batch.Tasks {
+			if strings.TrimSpace(remote.UID) == "" || strings.TrimSpace(remote.Title) == "" {
+				result.Skipped++
+				continue
+			}
+			task, err := s.syncRepo.FindBySyncUID(ctx, scope, remote.UID)
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				task = &model.Task{UserID: owner.ID, SyncUID: remote.UID, CreatedAt: remote.CreatedAt}
+			case err != nil:
+				return result, err
+			case !remote.UpdatedAt.After(task.UpdatedAt):
+				result.Skipped++
+				continue
+			}
+			if remote.DeletedAt != nil {
+				if task.ID == 0 || task.DeletedAt.Valid {
+					// Deleted before it ever got here, or here as well.
+					result.Skipped++
+					continue
+				}
+				if err := s.syncRepo.DeleteRemote(ctx, task, *remote.DeletedAt, remote.UpdatedAt); err != nil {
+					return result, err
+				}
+				result.Deleted++
+				continue
+			}
+			created := task.ID == 0
+			if err := s.fromSyncTask(ctx, scope, remote, task); err != nil {
+				return result, err
+			}
+			if err := s.syncRepo.SaveRemote(ctx, task); err != nil {
+				return result, err
+			}
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+	}
+	return result, nil
+}
+
+// State returns how far synchronisation with the peer got.
+func (s *SyncService) State(ctx context.Context, peerURL string) (*model.SyncState, error) {
+	return s.syncRepo.State(ctx, peerURL)
+}
+
+func (s *SyncService) SaveState(ctx context.Context, state *model.SyncState) error {
+	return s.syncRepo.SaveState(ctx, state)
+}
+
+func toSyncTask(task model.Task, categoryNames map[uint]string) SyncTask {
+	synced := SyncTask{
+		UID:              task.SyncUID,
+		Title:            task.Title,
+		Description:      task.Description,
+		Deadline:         task.Deadline,
+		Priority:         task.Priority,
+		IsCompleted:      task.IsCompleted,
+		IsRecurring:      task.IsRecurring,
+		RecurType:        task.RecurType,
+		RecurDay:         task.RecurDay,
+		RecurWeekday:     task.RecurWeekday,
+		RecurWeekdays:    task.RecurWeekdays,
+		RecurInterval:    task.RecurInterval,
+		RecurWindow:      task.RecurWindow,
+		ReminderText:     task.ReminderText,
+		LastCompletedAt:  task.LastCompletedAt,
+		RecurFrom:        task.RecurFrom,
+		CatchUp:          task.CatchUp,
+		CaughtUpTo:       task.CaughtUpTo,
+		RecurEndedAt:     task.RecurEndedAt,
+		AlertBeforeHours: task.AlertBeforeHours,
+		ArchivedAt:       task.ArchivedAt,
+		CreatedAt:        task.CreatedAt,
+		UpdatedAt:        task.UpdatedAt,
+	}
+	if task.CategoryID != nil {
+		synced.Category = categoryNames[*task.CategoryID]
+	}
+	if task.DeletedAt.Valid {
+		synced.DeletedAt = &task.DeletedAt.Time
+	}
+	return synced
+}
+
+func (s *SyncService) fromSyncTask(ctx context.Context, scope model.Scope, remote SyncTask, task *model.Task) error {
+	task.CategoryID = nil
+	if name := strings.TrimSpace(remote.Category); name != "" {
+		category, err := s.categoryRepo.GetOrCreate(ctx, scope, name)
+		if err != nil {
+			return err
+		}
+		task.CategoryID = &category.ID
+	}
+	task.Title = remote.Title
+	task.Description = remote.Description
+	task.Deadline = remote.Deadline
+	task.Priority = remote.Priority
+	task.IsCompleted = remote.IsCompleted
+	task.IsRecurring = remote.IsRecurring
+	task.RecurType = remote.RecurType
+	task.RecurDay = remote.RecurDay
+	task.RecurWeekday = remote.RecurWeekday
+	task.RecurWeekdays = remote.RecurWeekdays
+	task.RecurInterval = remote.RecurInterval
+	task.RecurWindow = remote.RecurWindow
+	task.ReminderText = remote.ReminderText
+	task.LastCompletedAt = remote.LastCompletedAt
+	task.RecurFrom = remote.RecurFrom
+	task.CatchUp = remote.CatchUp
+	task.CaughtUpTo = remote.CaughtUpTo
+	task.RecurEndedAt = remote.RecurEndedAt
+	task.AlertBeforeHours = remote.AlertBeforeHours
+	task.ArchivedAt = remote.ArchivedAt
+	task.UpdatedAt = remote.UpdatedAt
+	// A newer live copy wins over a deletion here: the task comes back from the trash.
+	task.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func newSyncUID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate sync uid: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
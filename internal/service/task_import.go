@@ -0,0 +1,376 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-planner/internal/i18n"
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// maxImportSize caps how much of an uploaded task file is read.
+const maxImportSize = 5 << 20
+
+// ErrInvalidImport is returned for task files that cannot be read at all.
+var ErrInvalidImport = errors.New("invalid task file")
+
+// ImportProblem is a row of a task file that was left out, and why. Reason is a message
+// for i18n, with a %s for Value when that is set.
+type ImportProblem struct {
+	Row    int
+	Reason string
+	Value  string
+}
+
+// TaskImport summarises an imported task file.
+type TaskImport struct {
+	Created    int
+	Categories int // categories created for the tasks
+	Problems   []ImportProblem
+}
+
+// importRow is one task of a CSV or JSON file, as text.
+type importRow struct {
+	row                                                    int
+	id, parentID                                           string
+	title, description, category, priority, status         string
+	deadline, completedAt, archivedAt                      string
+	recurType, recurInterval, recurDay, recurWindow, ended string
+	weekdays                                               []string
+}
+
+// ImportTasks adds the tasks of a file written by Export, or by hand in its format, to the
+// user's scope. Rows that do not make a valid task are reported and skipped; the rest are
+// created in one transaction along with their categories. A task whose parent_id names the
+// id of another task of the file becomes its subtask.
+func (s *ExportService) ImportTasks(ctx context.Context, user *model.User, r io.Reader, format string, now time.Time) (TaskImport, error) {
+	var result TaskImport
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return result, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxImportSize+1))
+	if err != nil {
+		return result, err
+	}
+	if len(data) > maxImportSize {
+		return result, fmt.Errorf("%w: the file is larger than %d MB", ErrInvalidImport, maxImportSize>>20)
+	}
+	var rows []importRow
+	switch format {
+	case ExportCSV:
+		rows, err = csvRows(data)
+	case ExportJSON:
+		rows, result.Problems, err = jsonRows(data)
+	default:
+		err = ErrUnknownExportFormat
+	}
+	if err != nil {
+		return result, err
+	}
+
+	loc := user.Location()
+	var batch []repository.ImportedTask
+	var parents []string
+	var categories []string
+	positions := make(map[string]int)
+	open := 0
+	for _, row := range rows {
+		task, problem := row.task(loc, now)
+		if problem != nil {
+			result.Problems = append(result.Problems, *problem)
+			continue
+		}
+		task.UserID, task.WorkspaceID = user.ID, scope.WorkspaceID
+		if id := strings.TrimSpace(row.id); id != "" {
+			if _, ok := positions[id]; !ok {
+				positions[id] = len(batch)
+			}
+		}
+		batch = append(batch, repository.ImportedTask{Task: task, Category: row.category, Parent: -1})
+		parents = append(parents, strings.TrimSpace(row.parentID))
+		if row.category != "" && !slices.Contains(categories, row.category) {
+			categories = append(categories, row.category)
+		}
+		if !task.IsCompleted && task.ArchivedAt == nil && task.RecurEndedAt == nil {
+			open++
+		}
+	}
+	slices.SortFunc(result.Problems, func(a, b ImportProblem) int { return a.Row - b.Row })
+	if len(batch) == 0 {
+		return result, nil
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, open); err != nil {
+		return result, err
+	}
+	for _, name := range categories {
+		if err := s.quotaSvc.CheckCategory(ctx, user, scope, name); err != nil {
+			return result, err
+		}
+	}
+	linkParents(batch, parents, positions)
+
+	if result.Categories, err = s.taskRepo.ImportBatch(ctx, scope, batch); err != nil {
+		return result, err
+	}
+	result.Created = len(batch)
+	return result, nil
+}
+
+// linkParents points each task of the batch at the one its parent ID names. Parents that
+// are not in the file stay unset, and so does the link that would close a loop.
+func linkParents(batch []repository.ImportedTask, parents []string, positions map[string]int) {
+	for i, parent := range parents {
+		if position, ok := positions[parent]; ok && position != i {
+			batch[i].Parent = position
+		}
+	}
+	for i := range batch {
+		for j, steps := batch[i].Parent, 0; j >= 0 && steps < len(batch); j, steps = batch[j].Parent, steps+1 {
+			if j == i {
+				batch[i].Parent = -1
+				break
+			}
+		}
+	}
+}
+
+// task checks the row and turns it into a task without an owner and a category.
+func (row importRow) task(loc *time.Location, now time.Time) (model.Task, *ImportProblem) {
+	problem := func(reason, value string) (model.Task, *ImportProblem) {
+		return model.Task{}, &ImportProblem{Row: row.row, Reason: reason, Value: value}
+	}
+	task := model.Task{Title: strings.TrimSpace(row.title), Description: strings.TrimSpace(row.description)}
+	if task.Title == "" {
+		return problem(i18n.N("нет названия"), "")
+	}
+	task.Priority = strings.ToLower(strings.TrimSpace(row.priority))
+	if task.Priority != "" && !slices.Contains(model.Priorities, task.Priority) {
+		return problem(i18n.N("неизвестный приоритет «%s»"), row.priority)
+	}
+	var err error
+	if task.Deadline, err = importTime(row.deadline, loc); err != nil {
+		return problem(i18n.N("не понял срок «%s»"), row.deadline)
+	}
+	if task.LastCompletedAt, err = importTime(row.completedAt, loc); err != nil {
+		return problem(i18n.N("не понял дату выполнения «%s»"), row.completedAt)
+	}
+	archivedAt, err := importTime(row.archivedAt, loc)
+	if err != nil {
+		return problem(i18n.N("не понял дату архивации «%s»"), row.archivedAt)
+	}
+
+	if recur := strings.ToLower(strings.TrimSpace(row.recurType)); recur != "" {
+		task.IsRecurring, task.RecurType = true, recur
+		numbers := []struct {
+			raw    string
+			target *int
+		}{{row.recurInterval, &task.RecurInterval}, {row.recurDay, &task.RecurDay}, {row.recurWindow, &task.RecurWindow}}
+		for _, number := range numbers {
+			if raw := strings.TrimSpace(number.raw); raw != "" {
+				if *number.target, err = strconv.Atoi(raw); err != nil || *number.target < 0 {
+					return problem(i18n.N("не понял число «%s» в правиле повторения"), number.raw)
+				}
+			}
+		}
+		switch recur {
+		case model.RecurDaily:
+		case model.RecurMonthly:
+			if task.RecurDay < 1 || task.RecurDay > 31 {
+				return problem(i18n.N("у ежемесячной задачи нет дня месяца"), "")
+			}
+		case model.RecurWeekly:
+			var days []time.Weekday
+			for _, name := range row.weekdays {
+				day, ok := parseExportWeekday(name)
+				if !ok {
+					return problem(i18n.N("не понял день недели «%s»"), name)
+				}
+				days = append(days, day)
+			}
+			if len(days) == 0 {
+				return problem(i18n.N("у еженедельной задачи нет дней недели"), "")
+			}
+			task.RecurWeekday = int(days[0])
+			if len(days) > 1 {
+				task.RecurWeekdays = model.WeekdaySet(days...)
+			}
+		default:
+			return problem(i18n.N("неизвестный тип повторения «%s»"), row.recurType)
+		}
+		if task.RecurEndedAt, err = importTime(row.ended, loc); err != nil {
+			return problem(i18n.N("не понял дату окончания повторов «%s»"), row.ended)
+		}
+	}
+
+	switch status := strings.ToLower(strings.TrimSpace(row.status)); status {
+	case "", "open":
+	case "done":
+		if task.IsRecurring {
+			if task.RecurEndedAt == nil {
+				task.RecurEndedAt = &now
+			}
+			break
+		}
+		task.IsCompleted = true
+		if task.LastCompletedAt == nil {
+			task.LastCompletedAt = &now
+		}
+	case "archived":
+		task.ArchivedAt = archivedAt
+		if task.ArchivedAt == nil {
+			task.ArchivedAt = &now
+		}
+	default:
+		return problem(i18n.N("неизвестный статус «%s»"), row.status)
+	}
+	return task, nil
+}
+
+// importTime reads a time written by Export, or a bare date; empty is no time.
+func importTime(raw string, loc *time.Location) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02", "02.01.2006"} {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time %q", raw)
+}
+
+func parseExportWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if english := strings.ToLower(day.String()); name == english || name == english[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// csvRows reads a CSV file by the names in its header; only title is required.
+func csvRows(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImport)
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("%w: no title column", ErrInvalidImport)
+	}
+	var rows []importRow
+	for i, record := range records[1:] {
+		cell := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(record) {
+				return record[index]
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows = append(rows, importRow{
+			row:           i + 2,
+			id:            cell("id"),
+			parentID:      cell("parent_id"),
+			title:         cell("title"),
+			description:   cell("description"),
+			category:      strings.TrimSpace(cell("category")),
+			priority:      cell("priority"),
+			status:        cell("status"),
+			deadline:      cell("deadline"),
+			completedAt:   cell("completed_at"),
+			archivedAt:    cell("archived_at"),
+			recurType:     cell("recur_type"),
+			recurInterval: cell("recur_interval"),
+			recurDay:      cell("recur_day"),
+			recurWindow:   cell("recur_window"),
+			ended:         cell("recur_ended_at"),
+			weekdays:      strings.Fields(strings.ReplaceAll(cell("recur_weekdays"), ",", " ")),
+		})
+	}
+	return rows, nil
+}
+
+// jsonTask is an element of a JSON task file; times are kept as text to be checked per row.
+type jsonTask struct {
+	ID          uint   `json:"id"`
+	ParentID    uint   `json:"parent_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Priority    string `json:"priority"`
+	Status      string `json:"status"`
+	Deadline    string `json:"deadline"`
+	CompletedAt string `json:"completed_at"`
+	ArchivedAt  string `json:"archived_at"`
+	Recurrence  *struct {
+		Type     string   `json:"type"`
+		Interval int      `json:"interval"`
+		Day      int      `json:"day"`
+		Weekdays []string `json:"weekdays"`
+		Window   int      `json:"window"`
+		EndedAt  string   `json:"ended_at"`
+	} `json:"recurrence"`
+}
+
+// jsonRows reads a JSON array of tasks; elements that are not tasks are reported as problems.
+func jsonRows(data []byte) ([]importRow, []ImportProblem, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	var rows []importRow
+	var problems []ImportProblem
+	for i, element := range elements {
+		var task jsonTask
+		if err := json.Unmarshal(element, &task); err != nil {
+			problems = append(problems, ImportProblem{Row: i + 1, Reason: i18n.N("не удалось прочитать: %s"), Value: err.Error()})
+			continue
+		}
+		row := importRow{
+			row:         i + 1,
+			title:       task.Title,
+			description: task.Description,
+			category:    strings.TrimSpace(task.Category),
+			priority:    task.Priority,
+			status:      task.Status,
+			deadline:    task.Deadline,
+			completedAt: task.CompletedAt,
+			archivedAt:  task.ArchivedAt,
+		}
+		if task.ID != 0 {
+			row.id = strconv.FormatUint(uint64(task.ID), 10)
+		}
+		if task.ParentID != 0 {
+			row.parentID = strconv.FormatUint(uint64(task.ParentID), 10)
+		}
+		if recur := task.Recurrence; recur != nil {
+			row.recurType, row.weekdays, row.ended = recur.Type, recur.Weekdays, recur.EndedAt
+			row.recurInterval, row.recurDay, row.recurWindow = strconv.Itoa(recur.Interval), strconv.Itoa(recur.Day), strconv.Itoa(recur.Window)
+		}
+		rows = append(rows, row)
+	}
+	return rows, problems, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// ErrMergeSame is returned when a task is merged into itself.
+var ErrMergeSame = errors.New("cannot merge a task into itself")
+
+// ErrMergeSubtask is returned when one of the merged tasks is a subtask of the other, at any depth.
+var ErrMergeSubtask = errors.New("cannot merge a task with its subtask")
+
+// MergeTasks folds a duplicate into the task that is kept: the description of the duplicate
+// is appended, the earlier deadline wins, the postponements add up, and its subtasks,
+// reminders, attachments, field values the kept task lacks and mentions in other tasks move
+// over. The duplicate goes to the trash. All of it happens in one transaction, and UndoMerge
+// takes it back.
+func (s *TaskService) MergeTasks(ctx context.Context, user *model.User, keptID, duplicateID uint) (*model.TaskMerge, error) {
+	if keptID == duplicateID {
+		return nil, ErrMergeSame
+	}
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, err
+	}
+	kept, err := s.taskRepo.FindByID(ctx, scope, keptID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.taskRepo.FindByID(ctx, scope, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range [][2]*model.Task{{kept, duplicate}, {duplicate, kept}} {
+		nested, err := s.descendsFrom(ctx, scope, pair[0], pair[1].ID)
+		if err != nil {
+			return nil, err
+		}
+		if nested {
+			return nil, ErrMergeSubtask
+		}
+	}
+
+	merge := &model.TaskMerge{
+		UserID:        scope.UserID,
+		WorkspaceID:   scope.WorkspaceID,
+		TaskID:        kept.ID,
+		DuplicateID:   duplicate.ID,
+		Description:   kept.Description,
+		Deadline:      kept.Deadline,
+		Postponements: kept.Postponements,
+	}
+	kept.Description = mergedDescription(kept.Description, duplicate.Description)
+	if duplicate.Deadline != nil && (kept.Deadline == nil || duplicate.Deadline.Before(*kept.Deadline)) {
+		kept.Deadline = duplicate.Deadline
+		kept.AlertedAt = nil
+		kept.SnoozedUntil = nil
+	}
+	kept.Postponements += duplicate.Postponements
+	if err := s.taskRepo.Merge(ctx, kept, duplicate, merge); err != nil {
+		return nil, err
+	}
+	if err := s.indexRefs(ctx, scope, kept); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, scope)
+	return merge, nil
+}
+
+// descendsFrom reports whether the task is a subtask of ancestorID at any depth, walking up
+// its parents.
+func (s *TaskService) descendsFrom(ctx context.Context, scope model.Scope, task *model.Task, ancestorID uint) (bool, error) {
+	seen := map[uint]bool{task.ID: true}
+	for parentID := task.ParentID; parentID != nil && !seen[*parentID]; {
+		if *parentID == ancestorID {
+			return true, nil
+		}
+		seen[*parentID] = true
+		parent, err := s.taskRepo.FindByID(ctx, scope, *parentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		parentID = parent.ParentID
+	}
+	return false, nil
+}
+
+// mergedDescription appends the description of the duplicate unless the kept one has it already.
+func mergedDescription(kept, duplicate string) string {
+	duplicate = strings.TrimSpace(duplicate)
+	switch {
+	case duplicate == "" || strings.Contains(kept, duplicate):
+		return kept
+	case strings.TrimSpace(kept) == "":
+		return duplicate
+	}
+	return strings.TrimSpace(kept) + "\n\n" + duplicate
+}
+
+// UndoMerge brings the duplicate of a merge back from the trash with what moved over and
+// restores the kept task as it was before.
+func (s *TaskService) UndoMerge(ctx context.Context, user *model.User, mergeID uint) (*model.TaskMerge, error) {
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+		return nil, err
+	}
+	merge, err := s.taskRepo.UndoMerge(ctx, scope, mergeID)
+	if err != nil {
+		return nil, err
+	}
+	kept, err := s.taskRepo.FindByID(ctx, scope, merge.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.indexRefs(ctx, scope, kept); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, scope)
+	return merge, nil
+}
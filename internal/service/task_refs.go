@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+)
+
+// maxTaskRefs caps how many mentions of one description are indexed.
+const maxTaskRefs = 20
+
+// taskRef matches a mention of a task such as #42. A # right after a word or & is not one,
+// so neither page#42 nor the &#39; of escaped HTML counts.
+var taskRef = regexp.MustCompile(`(^|[^\p{L}\p{N}_&])#(\d+)`)
+
+// TaskRefs returns the IDs of the tasks the text mentions, each once, in order.
+func TaskRefs(text string) []uint {
+	var ids []uint
+	for _, match := range taskRef.FindAllStringSubmatch(text, -1) {
+		id, err := strconv.ParseUint(match[2], 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		if !slices.Contains(ids, uint(id)) {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// FormatTaskRefs renders the text with ref making what each mention of a task becomes and
+// plain the text around the mentions, such as escape for HTML.
+func FormatTaskRefs(text string, plain func(string) string, ref func(taskID uint, mention string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range taskRef.FindAllStringSubmatchIndex(text, -1) {
+		hash, end := match[4]-1, match[1]
+		id, err := strconv.ParseUint(text[match[4]:end], 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		b.WriteString(plain(text[last:hash]))
+		b.WriteString(ref(uint(id), text[hash:end]))
+		last = end
+	}
+	b.WriteString(plain(text[last:]))
+	return b.String()
+}
+
+// indexRefs records which tasks of its scope the description of the task mentions. Mentions
+// of itself and of tasks that do not exist are left out.
+func (s *TaskService) indexRefs(ctx context.Context, scope model.Scope, task *model.Task) error {
+	var refIDs []uint
+	for _, id := range TaskRefs(task.Description) {
+		if id == task.ID {
+			continue
+		}
+		if _, err := s.taskRepo.FindByID(ctx, scope, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		if refIDs = append(refIDs, id); len(refIDs) == maxTaskRefs {
+			break
+		}
+	}
+	return s.taskRepo.SetReferences(ctx, task.ID, refIDs)
+}
+
+// Referrers returns the tasks whose descriptions mention the task.
+func (s *TaskService) Referrers(ctx context.Context, user *model.User, taskID uint) ([]model.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskRepo.ListReferrers(ctx, user.Scope(), task.ID)
+}
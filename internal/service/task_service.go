@@ -2,101 +2,624 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+
 	"daily-planner/internal/model"
 	"daily-planner/internal/repository"
+	"daily-planner/internal/tracing"
 )
 
+// ErrTaskCompleted is returned when a one-time task is completed twice.
+var ErrTaskCompleted = errors.New("task already completed")
+
+// ErrInvalidPriority is returned for a priority other than the model.Priorities.
+var ErrInvalidPriority = errors.New("unknown priority")
+
+// ErrTaskNotCompleted is returned when reopening a task that is open or recurring.
+var ErrTaskNotCompleted = errors.New("task is not completed")
+
+// ErrNotRecurring is returned when a recurring-only action targets a one-time task.
+var ErrNotRecurring = errors.New("task is not recurring")
+
 // TaskInput represents data required to create a task.
 type TaskInput struct {
 	Title       string
 	Description string
 	Category    string
 	Deadline    *time.Time
+	Priority    string // one of model.Priorities, empty for normal
 	IsRecurring bool
-	RecurDay    int
-	RecurWindow int
+	// RecurType is model.RecurMonthly (the default), model.RecurWeekly or model.RecurDaily.
+	RecurType     string
+	RecurDay      int
+	RecurWeekday  int
+	RecurWeekdays int // several weekdays of a weekly task as model.WeekdaySet, 0 for RecurWeekday alone
+	RecurInterval int // days between daily repeats, 1 when empty; months between monthly ones, see MaxRecurMonths
+	RecurWindow   int
+	// ReminderText is an optional template for recurring tasks, see ReminderPlaceholders.
+	ReminderText string
 }
 
 // TaskService wraps task-related business logic.
 type TaskService struct {
-	taskRepo     *repository.TaskRepository
-	categoryRepo *repository.CategoryRepository
+	taskRepo     TaskStore
+	categoryRepo CategoryStore
+	messageRepo  *repository.TaskMessageRepository
+	workspaceSvc *WorkspaceService
+	quotaSvc     *QuotaService
+	onChange     func(ctx context.Context, scope model.Scope)
+}
+
+func NewTaskService(taskRepo TaskStore, categoryRepo CategoryStore, messageRepo *repository.TaskMessageRepository, workspaceSvc *WorkspaceService, quotaSvc *QuotaService) *TaskService {
+	return &TaskService{taskRepo: taskRepo, categoryRepo: categoryRepo, messageRepo: messageRepo, workspaceSvc: workspaceSvc, quotaSvc: quotaSvc}
 }
 
-func NewTaskService(taskRepo *repository.TaskRepository, categoryRepo *repository.CategoryRepository) *TaskService {
-	return &TaskService{taskRepo: taskRepo, categoryRepo: categoryRepo}
+// OnChange registers fn to run after tasks of a scope are created, completed, edited or removed.
+func (s *TaskService) OnChange(fn func(ctx context.Context, scope model.Scope)) {
+	s.onChange = fn
+}
+
+func (s *TaskService) changed(ctx context.Context, scope model.Scope) {
+	if s.onChange != nil {
+		s.onChange(ctx, scope)
+	}
 }
 
 func (s *TaskService) CreateTask(ctx context.Context, user *model.User, input TaskInput) (*model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.CreateTask", attribute.Int64("user.id", int64(user.ID)))
+	defer span.End()
+
 	if input.Title == "" {
 		return nil, fmt.Errorf("title is required")
 	}
+	if err := validatePriority(&input); err != nil {
+		return nil, err
+	}
+	if err := validateRecurrence(&input); err != nil {
+		return nil, err
+	}
+	if input.IsRecurring && input.ReminderText != "" {
+		if err := ValidateReminderText(input.ReminderText); err != nil {
+			return nil, err
+		}
+	}
 
-	var categoryID *uint
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckCategory(ctx, user, scope, input.Category); err != nil {
+		return nil, err
+	}
+
+	var category *model.Category
 	if input.Category != "" {
-		category, err := s.categoryRepo.GetOrCreate(ctx, user.ID, input.Category)
-		if err != nil {
+		var err error
+		if category, err = s.categoryRepo.GetOrCreate(ctx, scope, input.Category); err != nil {
 			return nil, err
 		}
-		if category != nil {
-			categoryID = &category.ID
-		}
 	}
 
 	task := model.Task{
 		UserID:      user.ID,
-		CategoryID:  categoryID,
+		WorkspaceID: scope.WorkspaceID,
 		Title:       input.Title,
 		Description: input.Description,
 		Deadline:    input.Deadline,
+		Priority:    input.Priority,
 		IsRecurring: input.IsRecurring,
 	}
+	if category != nil {
+		task.CategoryID = &category.ID
+		applyCategoryDefaults(&task, *category, time.Now())
+	}
 
 	if input.IsRecurring {
-		task.RecurType = "monthly"
+		task.RecurType = input.RecurType
 		task.RecurDay = input.RecurDay
+		task.RecurWeekday = input.RecurWeekday
+		task.RecurWeekdays = input.RecurWeekdays
+		task.RecurInterval = input.RecurInterval
 		task.RecurWindow = input.RecurWindow
+		task.ReminderText = input.ReminderText
 	}
 
 	if err := s.taskRepo.Create(ctx, &task); err != nil {
 		return nil, err
 	}
+	if err := s.indexRefs(ctx, scope, &task); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, scope)
 
 	return &task, nil
 }
 
+// validatePriority fills in the normal priority for an empty one.
+func validatePriority(input *TaskInput) error {
+	if input.Priority == "" {
+		input.Priority = model.PriorityNormal
+		return nil
+	}
+	for _, priority := range model.Priorities {
+		if input.Priority == priority {
+			return nil
+		}
+	}
+	return ErrInvalidPriority
+}
+
+// applyCategoryDefaults fills in what the category prescribes and the input left empty.
+func applyCategoryDefaults(task *model.Task, category model.Category, now time.Time) {
+	if task.Deadline == nil && !task.IsRecurring && category.DefaultDeadlineDays > 0 {
+		// Deadlines are calendar dates, stored like the ones typed in the dialog.
+		deadline := time.Date(now.Year(), now.Month(), now.Day()+category.DefaultDeadlineDays, 0, 0, 0, 0, time.UTC)
+		task.Deadline = &deadline
+	}
+	task.AlertBeforeHours = category.DefaultAlertHours
+}
+
 func (s *TaskService) ListActive(ctx context.Context, user *model.User) ([]model.Task, error) {
-	return s.taskRepo.ListActiveOrRecurring(ctx, user.ID)
+	return s.taskRepo.ListActiveOrRecurring(ctx, user.Scope())
+}
+
+// Search finds open tasks by a fragment of their title or description; see TaskRepository.Search.
+func (s *TaskService) Search(ctx context.Context, user *model.User, query string, limit, offset int) ([]model.Task, int64, error) {
+	return s.taskRepo.Search(ctx, user.Scope(), strings.TrimSpace(query), limit, offset)
 }
 
 func (s *TaskService) GetTask(ctx context.Context, user *model.User, taskID uint) (*model.Task, error) {
-	return s.taskRepo.FindByID(ctx, user.ID, taskID)
+	return s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+}
+
+// Subtasks returns the subtasks added to the task.
+func (s *TaskService) Subtasks(ctx context.Context, user *model.User, taskID uint) ([]model.Task, error) {
+	return s.taskRepo.ListSubtasks(ctx, user.Scope(), taskID)
 }
 
-// CompleteTask marks a task as done. For recurring tasks, it stores completion time without closing the task forever.
+// AddSubtask creates a task that belongs to an open one and inherits its category, deadline and priority.
+func (s *TaskService) AddSubtask(ctx context.Context, user *model.User, parentID uint, title string) (*model.Task, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	parent, err := s.openTask(ctx, user, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+		return nil, err
+	}
+	subtask := model.Task{
+		UserID:      parent.UserID,
+		WorkspaceID: parent.WorkspaceID,
+		CategoryID:  parent.CategoryID,
+		Title:       title,
+		Deadline:    parent.Deadline,
+		Priority:    parent.Priority,
+		ParentID:    &parent.ID,
+	}
+	if err := s.taskRepo.Create(ctx, &subtask); err != nil {
+		return nil, err
+	}
+	return &subtask, nil
+}
+
+// CompleteTask marks a task as done. For recurring tasks, it stores completion time without closing the task forever;
+// a missed occurrence carried forward is done first.
 func (s *TaskService) CompleteTask(ctx context.Context, user *model.User, taskID uint, completedAt time.Time) (*model.Task, error) {
-	task, err := s.taskRepo.FindByID(ctx, user.ID, taskID)
+	ctx, span := tracing.Start(ctx, "TaskService.CompleteTask", attribute.Int64("user.id", int64(user.ID)))
+	defer span.End()
+
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
 	if err != nil {
 		return nil, err
 	}
 
 	if task.IsRecurring {
+		if missed, ok := MissedOccurrence(*task, completedAt); ok {
+			if err := s.taskRepo.MarkCaughtUp(ctx, task, missed); err != nil {
+				return nil, err
+			}
+			s.changed(ctx, user.Scope())
+			return task, nil
+		}
+		if task.RecurFrom == nil && task.RecurMonths() > 0 {
+			task.RecurFrom = &completedAt
+		}
 		if err := s.taskRepo.MarkRecurringDone(ctx, task, completedAt); err != nil {
 			return nil, err
 		}
-		return task, nil
+	} else if err := s.taskRepo.MarkCompleted(ctx, task, completedAt); err != nil {
+		return nil, err
 	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
 
-	if err := s.taskRepo.MarkCompleted(ctx, task, completedAt); err != nil {
+// ReopenTask puts a completed one-time task back among the open ones.
+func (s *TaskService) ReopenTask(ctx context.Context, user *model.User, taskID uint) (*model.Task, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
 		return nil, err
 	}
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.IsRecurring || !task.IsCompleted {
+		return task, ErrTaskNotCompleted
+	}
+	if err := s.taskRepo.Reopen(ctx, task); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, user.Scope())
 	return task, nil
 }
 
+// ListCompleted returns the tasks of the user's active scope completed since the given time,
+// recurring ones by their latest completion, most recent first.
+func (s *TaskService) ListCompleted(ctx context.Context, user *model.User, since time.Time) ([]model.Task, error) {
+	return s.taskRepo.ListCompletedSince(ctx, user.Scope(), storedTime(since))
+}
+
+// storedTime moves a query bound into the server's zone, the one task times are written in:
+// SQLite compares them as text, so a bound in the user's zone would miss rows.
+func storedTime(t time.Time) time.Time {
+	return t.In(time.Local)
+}
+
 // DeleteTask removes a task completely (for both one-time and recurring tasks).
 func (s *TaskService) DeleteTask(ctx context.Context, user *model.User, taskID uint) error {
-	return s.taskRepo.Delete(ctx, user.ID, taskID)
+	ctx, span := tracing.Start(ctx, "TaskService.DeleteTask", attribute.Int64("user.id", int64(user.ID)))
+	defer span.End()
+
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return err
+	}
+	if err := s.taskRepo.Delete(ctx, user.Scope(), taskID); err != nil {
+		return err
+	}
+	s.changed(ctx, user.Scope())
+	return nil
+}
+
+// SetCatchUp chooses whether a missed occurrence of the recurring task is carried forward
+// as overdue or dropped.
+func (s *TaskService) SetCatchUp(ctx context.Context, user *model.User, taskID uint, on bool) (*model.Task, error) {
+	task, err := s.openTask(ctx, user, taskID)
+	if err != nil {
+		return task, err
+	}
+	if !task.IsRecurring {
+		return task, ErrNotRecurring
+	}
+	if err := s.taskRepo.SetCatchUp(ctx, task, on); err != nil {
+		return task, err
+	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
+
+// EndRecurrence stops future repeats of a recurring task but keeps it with its history.
+func (s *TaskService) EndRecurrence(ctx context.Context, user *model.User, taskID uint, now time.Time) (*model.Task, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !task.IsRecurring {
+		return nil, ErrNotRecurring
+	}
+	if task.RecurEndedAt != nil {
+		return task, nil
+	}
+	if err := s.taskRepo.EndRecurrence(ctx, task, now); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
+
+// EditInput returns the task together with its current values as an input for UpdateTask.
+func (s *TaskService) EditInput(ctx context.Context, user *model.User, taskID uint) (*model.Task, TaskInput, error) {
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, TaskInput{}, err
+	}
+	input := TaskInput{
+		Title:         task.Title,
+		Description:   task.Description,
+		Deadline:      task.Deadline,
+		Priority:      task.Priority,
+		IsRecurring:   task.IsRecurring,
+		RecurType:     task.RecurType,
+		RecurDay:      task.RecurDay,
+		RecurWeekday:  task.RecurWeekday,
+		RecurWeekdays: task.RecurWeekdays,
+		RecurInterval: task.RecurInterval,
+		RecurWindow:   task.RecurWindow,
+		ReminderText:  task.ReminderText,
+	}
+	if task.CategoryID != nil {
+		category, err := s.categoryRepo.FindByID(ctx, user.Scope(), *task.CategoryID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TaskInput{}, err
+		}
+		if category != nil {
+			input.Category = category.Name
+		}
+	}
+	return task, input, nil
+}
+
+// UpdateTask replaces the editable fields of a task with input. A new deadline re-arms
+// the deadline alert, and a new category brings its alert lead time along.
+func (s *TaskService) UpdateTask(ctx context.Context, user *model.User, taskID uint, input TaskInput, now time.Time) (*model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.UpdateTask", attribute.Int64("user.id", int64(user.ID)))
+	defer span.End()
+
+	if input.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if err := validatePriority(&input); err != nil {
+		return nil, err
+	}
+	if err := validateRecurrence(&input); err != nil {
+		return nil, err
+	}
+	if input.IsRecurring && input.ReminderText != "" {
+		if err := ValidateReminderText(input.ReminderText); err != nil {
+			return nil, err
+		}
+	}
+
+	scope := user.Scope()
+	if err := s.workspaceSvc.Authorize(ctx, user, scope); err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.FindByID(ctx, scope, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckCategory(ctx, user, scope, input.Category); err != nil {
+		return nil, err
+	}
+
+	var categoryID *uint
+	if input.Category != "" {
+		category, err := s.categoryRepo.GetOrCreate(ctx, scope, input.Category)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = &category.ID
+		if task.CategoryID == nil || *task.CategoryID != category.ID {
+			task.AlertBeforeHours = category.DefaultAlertHours
+		}
+	} else if task.CategoryID != nil {
+		task.AlertBeforeHours = 0
+	}
+	task.CategoryID = categoryID
+
+	if !sameDeadline(task.Deadline, input.Deadline) {
+		task.AlertedAt = nil
+		task.SnoozedUntil = nil
+	}
+	task.Title = input.Title
+	task.Description = input.Description
+	task.Deadline = input.Deadline
+	task.Priority = input.Priority
+	if !input.IsRecurring || input.RecurType != task.RecurType || input.RecurInterval != task.RecurInterval {
+		// A new schedule counts from the next completion again and has missed nothing yet.
+		task.RecurFrom = nil
+		task.CaughtUpTo = &now
+	}
+	task.IsRecurring = input.IsRecurring
+	if input.IsRecurring {
+		task.RecurType = input.RecurType
+		task.RecurDay = input.RecurDay
+		task.RecurWeekday = input.RecurWeekday
+		task.RecurWeekdays = input.RecurWeekdays
+		task.RecurInterval = input.RecurInterval
+		task.RecurWindow = input.RecurWindow
+		task.ReminderText = input.ReminderText
+	} else {
+		task.RecurType = ""
+		task.RecurDay = 0
+		task.RecurWeekday = 0
+		task.RecurWeekdays = 0
+		task.RecurInterval = 0
+		task.RecurWindow = 0
+		task.ReminderText = ""
+	}
+	// Editing counts as attention, like Touch.
+	task.TouchedAt = &now
+	task.PostponeCount = 0
+	task.NudgedAt = nil
+
+	if err := s.taskRepo.Save(ctx, task); err != nil {
+		return nil, err
+	}
+	if err := s.indexRefs(ctx, scope, task); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, scope)
+	return task, nil
+}
+
+func sameDeadline(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// TrackMessage remembers that the bot message shows the task.
+func (s *TaskService) TrackMessage(ctx context.Context, task *model.Task, chatID int64, messageID int) error {
+	return s.messageRepo.Save(ctx, &model.TaskMessage{ChatID: chatID, MessageID: messageID, TaskID: task.ID, WorkspaceID: task.WorkspaceID})
+}
+
+// TrackList remembers the task list message shown in the chat; see TaskMessageRepository.SaveList.
+func (s *TaskService) TrackList(ctx context.Context, user *model.User, chatID int64, messageID int, view string, page int, dashboard bool) error {
+	return s.messageRepo.SaveList(ctx, &model.ListMessage{ChatID: chatID, MessageID: messageID, UserID: user.ID, WorkspaceID: user.Scope().WorkspaceID, View: view, Page: page, Dashboard: dashboard})
+}
+
+// TrackedList returns the task list message tracked in the chat.
+func (s *TaskService) TrackedList(ctx context.Context, chatID int64) (*model.ListMessage, error) {
+	return s.messageRepo.FindList(ctx, chatID)
+}
+
+// TrackedLists returns the task list messages that show the tasks of the scope.
+func (s *TaskService) TrackedLists(ctx context.Context, scope model.Scope) ([]model.ListMessage, error) {
+	return s.messageRepo.ListsByScope(ctx, scope)
+}
+
+// ForgetList stops tracking the chat's task list, e.g. after the message was deleted.
+func (s *TaskService) ForgetList(ctx context.Context, chatID int64) error {
+	return s.messageRepo.ForgetList(ctx, chatID)
+}
+
+// ExpireMessage schedules the bot message to be deleted after the delay.
+func (s *TaskService) ExpireMessage(ctx context.Context, chatID int64, messageID int, delay time.Duration, now time.Time) error {
+	return s.messageRepo.ScheduleDeletion(ctx, &model.TransientMessage{ChatID: chatID, MessageID: messageID, DeleteAt: now.Add(delay)})
+}
+
+// ExpiredMessages returns up to limit messages whose deletion is due.
+func (s *TaskService) ExpiredMessages(ctx context.Context, now time.Time, limit int) ([]model.TransientMessage, error) {
+	return s.messageRepo.DueDeletions(ctx, now, limit)
+}
+
+// ForgetExpired drops the scheduled deletions of messages that were handled.
+func (s *TaskService) ForgetExpired(ctx context.Context, messages []model.TransientMessage) error {
+	ids := make([]uint, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return s.messageRepo.ForgetDeletions(ctx, ids)
+}
+
+// taskByMessage finds the task shown in a tracked bot message within the scope it was shown in,
+// which may differ from the user's active one. The returned user carries that scope.
+func (s *TaskService) taskByMessage(ctx context.Context, user *model.User, chatID int64, messageID int) (*model.User, *model.Task, error) {
+	message, err := s.messageRepo.Find(ctx, chatID, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	scoped := *user
+	scoped.ActiveWorkspaceID = message.WorkspaceID
+	task, err := s.taskRepo.FindByID(ctx, scoped.Scope(), message.TaskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &scoped, task, nil
+}
+
+// CompleteByMessage completes the task shown in a tracked bot message.
+func (s *TaskService) CompleteByMessage(ctx context.Context, user *model.User, chatID int64, messageID int, completedAt time.Time) (*model.Task, error) {
+	scoped, task, err := s.taskByMessage(ctx, user, chatID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if !task.IsRecurring && task.IsCompleted {
+		return task, ErrTaskCompleted
+	}
+	return s.CompleteTask(ctx, scoped, task.ID, completedAt)
+}
+
+// CompleteLastShown completes the task shown in the most recent tracked message of the chat.
+func (s *TaskService) CompleteLastShown(ctx context.Context, user *model.User, chatID int64, completedAt time.Time) (*model.Task, error) {
+	message, err := s.messageRepo.Latest(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return s.CompleteByMessage(ctx, user, chatID, message.MessageID, completedAt)
+}
+
+// SnoozeByMessage postpones the deadline alert of the task shown in a tracked bot message.
+func (s *TaskService) SnoozeByMessage(ctx context.Context, user *model.User, chatID int64, messageID int, now, until time.Time) (*model.Task, error) {
+	scoped, task, err := s.taskByMessage(ctx, user, chatID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if task.IsCompleted {
+		return task, ErrTaskCompleted
+	}
+	if err := s.workspaceSvc.Authorize(ctx, scoped, scoped.Scope()); err != nil {
+		return nil, err
+	}
+	if err := s.taskRepo.Snooze(ctx, task, until, now); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ListArchived returns the archived tasks of the user's active scope.
+func (s *TaskService) ListArchived(ctx context.Context, user *model.User) ([]model.Task, error) {
+	return s.taskRepo.ListArchived(ctx, user.Scope())
+}
+
+// Restore brings an archived task back; it counts as touched so triage leaves it alone for a while.
+func (s *TaskService) Restore(ctx context.Context, user *model.User, taskID uint, now time.Time) (*model.Task, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.FindByID(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt == nil {
+		return task, nil
+	}
+	if err := s.taskRepo.SetArchived(ctx, task, nil); err != nil {
+		return nil, err
+	}
+	if err := s.taskRepo.Touch(ctx, task, now); err != nil {
+		return nil, err
+	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
+
+// TrashRetention is how long deleted tasks can be restored before PurgeTrash removes them.
+const TrashRetention = 30 * 24 * time.Hour
+
+// ListTrash returns the tasks of the user's active scope that are still restorable,
+// most recently deleted first.
+func (s *TaskService) ListTrash(ctx context.Context, user *model.User, now time.Time) ([]model.Task, error) {
+	return s.taskRepo.ListDeleted(ctx, user.Scope(), now.Add(-TrashRetention))
+}
+
+// Undelete brings a task back from the trash.
+func (s *TaskService) Undelete(ctx context.Context, user *model.User, taskID uint) (*model.Task, error) {
+	if err := s.workspaceSvc.Authorize(ctx, user, user.Scope()); err != nil {
+		return nil, err
+	}
+	if err := s.quotaSvc.CheckTasks(ctx, user, 1); err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.Undelete(ctx, user.Scope(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	s.changed(ctx, user.Scope())
+	return task, nil
+}
+
+// PurgeTrash permanently removes tasks deleted more than TrashRetention ago.
+func (s *TaskService) PurgeTrash(ctx context.Context, now time.Time) (int64, error) {
+	return s.taskRepo.PurgeDeleted(ctx, now.Add(-TrashRetention))
 }
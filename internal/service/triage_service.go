@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+// ErrInvalidDecision is returned for an unknown backlog triage decision.
+var ErrInvalidDecision = errors.New("invalid triage decision")
+
+const (
+	// triageIdle is how long a task must sit untouched to be offered for triage.
+	triageIdle = 60 * 24 * time.Hour
+	// triageLimit caps a run so it fits one message; the rest waits for the next month.
+	triageLimit = 15
+)
+
+// TriageRun is the set of stale tasks offered to one user at once.
+type TriageRun struct {
+	UserID uint
+	Items  []model.TriageItem
+}
+
+// TriageStats counts backlog triage decisions.
+type TriageStats struct {
+	Kept     int
+	Deleted  int
+	Archived int
+}
+
+// Pruned is how many tasks left the backlog.
+func (s TriageStats) Pruned() int {
+	return s.Deleted + s.Archived
+}
+
+// TriageService runs the monthly review of tasks nobody touched for two months.
+type TriageService struct {
+	taskRepo   *repository.TaskRepository
+	triageRepo *repository.TriageRepository
+}
+
+func NewTriageService(taskRepo *repository.TaskRepository, triageRepo *repository.TriageRepository) *TriageService {
+	return &TriageService{taskRepo: taskRepo, triageRepo: triageRepo}
+}
+
+// StartRuns gathers stale personal tasks and records a triage run for each of their owners.
+func (s *TriageService) StartRuns(ctx context.Context, now time.Time) ([]TriageRun, error) {
+	tasks, err := s.taskRepo.ListStale(ctx, now.Add(-triageIdle))
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []TriageRun
+	for _, task := range tasks {
+		if len(runs) == 0 || runs[len(runs)-1].UserID != task.UserID {
+			runs = append(runs, TriageRun{UserID: task.UserID})
+		}
+		run := &runs[len(runs)-1]
+		if len(run.Items) == triageLimit {
+			continue
+		}
+		idleSince := task.CreatedAt
+		if task.TouchedAt != nil {
+			idleSince = *task.TouchedAt
+		}
+		run.Items = append(run.Items, model.TriageItem{
+			UserID:    task.UserID,
+			Run:       now.Unix(),
+			TaskID:    task.ID,
+			Title:     task.Title,
+			IdleSince: idleSince,
+		})
+	}
+	for i := range runs {
+		if err := s.triageRepo.CreateRun(ctx, runs[i].Items); err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+// Decide applies the user's decision on a triage item and returns the whole run.
+// Answering an item twice keeps the first decision.
+func (s *TriageService) Decide(ctx context.Context, user *model.User, itemID uint, decision string, now time.Time) (*TriageRun, error) {
+	item, err := s.triageRepo.FindByID(ctx, user.ID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Decision == "" {
+		if err := s.apply(ctx, item, decision, now); err != nil {
+			return nil, err
+		}
+		if err := s.triageRepo.Decide(ctx, item, decision, now); err != nil {
+			return nil, err
+		}
+	}
+	items, err := s.triageRepo.ListRun(ctx, user.ID, item.Run)
+	if err != nil {
+		return nil, err
+	}
+	return &TriageRun{UserID: user.ID, Items: items}, nil
+}
+
+func (s *TriageService) apply(ctx context.Context, item *model.TriageItem, decision string, now time.Time) error {
+	scope := model.PersonalScope(item.UserID)
+	task, err := s.taskRepo.FindByID(ctx, scope, item.TaskID)
+	if err != nil {
+		return err
+	}
+	switch decision {
+	case model.TriageKeep:
+		return s.taskRepo.Touch(ctx, task, now)
+	case model.TriageDelete:
+		return s.taskRepo.Delete(ctx, scope, task.ID)
+	case model.TriageArchive:
+		return s.taskRepo.SetArchived(ctx, task, &now)
+	default:
+		return ErrInvalidDecision
+	}
+}
+
+// Stats counts the user's triage decisions since the given time.
+func (s *TriageService) Stats(ctx context.Context, user *model.User, since time.Time) (TriageStats, error) {
+	counts, err := s.triageRepo.CountDecisions(ctx, user.ID, since)
+	if err != nil {
+		return TriageStats{}, err
+	}
+	return TriageStats{Kept: counts[model.TriageKeep], Deleted: counts[model.TriageDelete], Archived: counts[model.TriageArchive]}, nil
+}
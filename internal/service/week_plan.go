@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// DayPlan is what falls due on one calendar day.
+type DayPlan struct {
+	Day       time.Time    // midnight in the user's time zone
+	Deadlines []model.Task // open one-time tasks with the deadline on that day
+	Recurring []model.Task // recurring tasks whose occurrence is due that day
+}
+
+// WeekStart returns the Monday midnight of the week containing t, in t's location.
+func WeekStart(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// WeekPlan lays out the open tasks of the user's active scope over the seven days from
+// start, a midnight in the user's time zone.
+func (s *TaskService) WeekPlan(ctx context.Context, user *model.User, start time.Time) ([]DayPlan, error) {
+	tasks, err := s.taskRepo.ListActiveOrRecurring(ctx, user.Scope())
+	if err != nil {
+		return nil, err
+	}
+	return PlanDays(tasks, start, 7), nil
+}
+
+// PlanDays places one-time tasks on the day of their deadline and recurring ones on every
+// day their occurrence is due, over the given number of days from start.
+func PlanDays(tasks []model.Task, start time.Time, days int) []DayPlan {
+	plans := make([]DayPlan, days)
+	for i := range plans {
+		plans[i].Day = start.AddDate(0, 0, i)
+	}
+	for _, task := range tasks {
+		if !task.IsRecurring {
+			if task.IsCompleted || task.Deadline == nil {
+				continue
+			}
+			offset := daysBetween(start, task.Deadline.In(start.Location()))
+			if offset >= 0 && offset < days {
+				plans[offset].Deadlines = append(plans[offset].Deadlines, task)
+			}
+			continue
+		}
+		if task.RecurMonths() > 0 && task.RecurFrom == nil {
+			// Its dates count from a first completion that has not happened yet.
+			continue
+		}
+		created := startOfDay(task.CreatedAt.In(start.Location()))
+		for i := range plans {
+			day := plans[i].Day
+			if day.Before(created) {
+				continue
+			}
+			if due, ok := Occurrence(task, day); ok && due.Equal(day) {
+				plans[i].Recurring = append(plans[i].Recurring, task)
+			}
+		}
+	}
+	return plans
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestPlanDays(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, loc)
+	start := WeekStart(now)
+	if want := time.Date(2025, time.March, 10, 0, 0, 0, 0, loc); !start.Equal(want) {
+		t.Fatalf("week starts %s, want %s", start, want)
+	}
+	created := time.Date(2025, time.January, 1, 0, 0, 0, 0, loc)
+	deadline := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2025, time.March, 17, 0, 0, 0, 0, time.UTC)
+
+	tasks := []model.Task{
+		{ID: 1, Deadline: &deadline},
+		{ID: 2, Deadline: &late},
+		{ID: 3, Deadline: &deadline, IsCompleted: true},
+		{ID: 4, IsRecurring: true, RecurType: model.RecurWeekly, RecurWeekday: int(time.Tuesday), CreatedAt: created},
+		{ID: 5, IsRecurring: true, RecurType: model.RecurDaily, RecurInterval: 2, CreatedAt: time.Date(2025, time.March, 11, 9, 0, 0, 0, loc)},
+		{ID: 6, IsRecurring: true, RecurType: model.RecurMonthly, RecurDay: 16, CreatedAt: created},
+	}
+	plans := PlanDays(tasks, start, 7)
+
+	ids := func(tasks []model.Task) []uint {
+		var ids []uint
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	want := map[int][2][]uint{
+		1: {nil, {4, 5}}, // Tuesday: the weekly task, the daily one starts that day
+		3: {nil, {5}},    // Thursday: every other day from Tuesday
+		4: {{1}, nil},    // Friday: the deadline
+		5: {nil, {5}},    // Saturday
+		6: {nil, {6}},    // Sunday: the 16th
+	}
+	for i, plan := range plans {
+		got := [2][]uint{ids(plan.Deadlines), ids(plan.Recurring)}
+		if !equalIDs(got[0], want[i][0]) || !equalIDs(got[1], want[i][1]) {
+			t.Errorf("%s: got %v, want %v", plan.Day.Format("Mon 02"), got, want[i])
+		}
+	}
+}
+
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+// WeeklyCategory is one category's share of the weekly summary.
+type WeeklyCategory struct {
+	Name      string // empty for tasks without a category
+	Completed int
+	Pending   int
+	Overdue   int
+}
+
+// WeeklySummary sums up the past week of the user's active scope: what was done, what is
+// still open and what of it is overdue, in total and per category.
+type WeeklySummary struct {
+	Since      time.Time
+	Until      time.Time
+	Completed  int // one-time tasks done and recurring tasks last done within the week
+	Pending    int // open one-time tasks
+	Overdue    int // pending tasks past their deadline
+	Categories []WeeklyCategory
+}
+
+// WeeklySummary builds the summary of the seven days before now. Categories with more
+// activity come first.
+func (s *ReminderService) WeeklySummary(ctx context.Context, user *model.User, now time.Time) (WeeklySummary, error) {
+	scope := user.Scope()
+	summary := WeeklySummary{Since: now.Add(-digestPeriod), Until: now}
+
+	completed, err := s.taskRepo.ListCompletedSince(ctx, scope, storedTime(summary.Since))
+	if err != nil {
+		return WeeklySummary{}, err
+	}
+	open, err := s.taskRepo.ListActiveOrRecurring(ctx, scope)
+	if err != nil {
+		return WeeklySummary{}, err
+	}
+	categories, err := s.categoryRepo.ListByScope(ctx, scope)
+	if err != nil {
+		return WeeklySummary{}, err
+	}
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = strings.TrimSpace(category.Name)
+	}
+
+	byName := make(map[string]*WeeklyCategory)
+	category := func(task model.Task) *WeeklyCategory {
+		name := ""
+		if task.CategoryID != nil {
+			name = names[*task.CategoryID]
+		}
+		if byName[name] == nil {
+			byName[name] = &WeeklyCategory{Name: name}
+		}
+		return byName[name]
+	}
+	for _, task := range completed {
+		summary.Completed++
+		category(task).Completed++
+	}
+	for _, task := range open {
+		if task.IsRecurring {
+			continue
+		}
+		entry := category(task)
+		summary.Pending++
+		entry.Pending++
+		if Overdue(task.Deadline, now, user.DeadlinePolicy()) {
+			summary.Overdue++
+			entry.Overdue++
+		}
+	}
+
+	for _, entry := range byName {
+		summary.Categories = append(summary.Categories, *entry)
+	}
+	sort.Slice(summary.Categories, func(i, j int) bool {
+		a, b := summary.Categories[i], summary.Categories[j]
+		if a.Completed+a.Pending != b.Completed+b.Pending {
+			return a.Completed+a.Pending > b.Completed+b.Pending
+		}
+		return a.Name < b.Name
+	})
+	return summary, nil
+}
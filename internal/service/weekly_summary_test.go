@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-planner/internal/model"
+)
+
+func TestWeeklySummary(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Алиса")
+	now := date(2025, time.March, 16, 19)
+	work := f.category(user.Scope(), "Работа")
+	home := f.category(user.Scope(), "Дом")
+
+	f.task(model.Task{UserID: user.ID, Title: "Отчёт", CategoryID: work, IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 12, 10))})
+	f.task(model.Task{UserID: user.ID, Title: "Старый отчёт", CategoryID: work, IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 1, 10))})
+	f.task(model.Task{UserID: user.ID, Title: "Презентация", CategoryID: work, Deadline: ptr(date(2025, time.March, 14, 0))})
+	f.task(model.Task{UserID: user.ID, Title: "Созвон", CategoryID: work, Deadline: ptr(date(2025, time.March, 20, 0))})
+	f.task(model.Task{UserID: user.ID, Title: "Полить цветы", CategoryID: home, IsRecurring: true, RecurDay: 15, LastCompletedAt: ptr(date(2025, time.March, 15, 9))})
+	f.task(model.Task{UserID: user.ID, Title: "Разобрать почту"})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	summary, err := svc.WeeklySummary(f.ctx, user, now)
+	if err != nil {
+		t.Fatalf("weekly summary: %v", err)
+	}
+	if summary.Completed != 2 || summary.Pending != 3 || summary.Overdue != 1 {
+		t.Errorf("got completed=%d pending=%d overdue=%d, want 2, 3, 1", summary.Completed, summary.Pending, summary.Overdue)
+	}
+	want := []WeeklyCategory{
+		{Name: "Работа", Completed: 1, Pending: 2, Overdue: 1},
+		{Name: "", Pending: 1},
+		{Name: "Дом", Completed: 1},
+	}
+	if len(summary.Categories) != len(want) {
+		t.Fatalf("got categories %+v, want %+v", summary.Categories, want)
+	}
+	for i := range want {
+		if summary.Categories[i] != want[i] {
+			t.Errorf("category %d: got %+v, want %+v", i, summary.Categories[i], want[i])
+		}
+	}
+}
+
+func TestWeeklySummaryInUserZone(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(1, "Алиса")
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// 10:00 UTC on March 9 is 13:00 in Moscow, an hour inside a week that began at 12:00.
+	f.task(model.Task{UserID: user.ID, Title: "Отчёт", IsCompleted: true, LastCompletedAt: ptr(date(2025, time.March, 9, 10).In(time.Local))})
+
+	svc := NewReminderService(f.tasks, f.categories, f.workspaces, f.counters, f.users)
+	summary, err := svc.WeeklySummary(f.ctx, user, time.Date(2025, time.March, 16, 12, 0, 0, 0, moscow))
+	if err != nil {
+		t.Fatalf("weekly summary: %v", err)
+	}
+	if summary.Completed != 1 {
+		t.Errorf("got completed=%d, want the task done at 13:00 Moscow time", summary.Completed)
+	}
+}
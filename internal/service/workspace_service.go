@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"daily-planner/internal/model"
+	"daily-planner/internal/repository"
+)
+
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var (
+	// ErrWorkspaceNotFound is returned for unknown invite codes or workspace IDs.
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	// ErrAlreadyMember is returned when joining a workspace twice.
+	ErrAlreadyMember = errors.New("already a workspace member")
+	// ErrNotWorkspaceMember is returned when switching to a workspace the user does not belong to.
+	ErrNotWorkspaceMember = errors.New("not a workspace member")
+	// ErrForbidden is returned when the user's workspace role does not allow the action.
+	ErrForbidden = errors.New("not allowed by your workspace role")
+	// ErrInvalidRole is returned for roles that cannot be assigned.
+	ErrInvalidRole = errors.New("role must be editor or viewer")
+)
+
+// WorkspaceService manages shared workspaces and the user's active one.
+type WorkspaceService struct {
+	repo *repository.WorkspaceRepository
+}
+
+func NewWorkspaceService(repo *repository.WorkspaceRepository) *WorkspaceService {
+	return &WorkspaceService{repo: repo}
+}
+
+// Create makes a new workspace owned by the user and switches the user into it.
+func (s *WorkspaceService) Create(ctx context.Context, user *model.User, name string) (*model.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("workspace name is required")
+	}
+	code, err := randomCode(inviteAlphabet, 8)
+	if err != nil {
+		return nil, fmt.Errorf("generate invite code: %w", err)
+	}
+	workspace := model.Workspace{Name: name, OwnerID: user.ID, InviteCode: code}
+	if err := s.repo.Create(ctx, &workspace); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetActive(ctx, user, workspace.ID); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// Join adds the user to the workspace with the given invite code and switches into it.
+func (s *WorkspaceService) Join(ctx context.Context, user *model.User, code string) (*model.Workspace, error) {
+	workspace, err := s.repo.FindByInviteCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	member, err := s.repo.IsMember(ctx, workspace.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member {
+		return workspace, ErrAlreadyMember
+	}
+	if err := s.repo.AddMember(ctx, workspace.ID, user.ID, model.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetActive(ctx, user, workspace.ID); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// Switch changes the active workspace; workspaceID 0 returns to personal tasks.
+func (s *WorkspaceService) Switch(ctx context.Context, user *model.User, workspaceID uint) (*model.Workspace, error) {
+	if workspaceID == 0 {
+		return nil, s.repo.SetActive(ctx, user, 0)
+	}
+	workspace, err := s.repo.GetByID(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	member, err := s.repo.IsMember(ctx, workspace.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, ErrNotWorkspaceMember
+	}
+	if err := s.repo.SetActive(ctx, user, workspace.ID); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+func (s *WorkspaceService) List(ctx context.Context, user *model.User) ([]model.Workspace, error) {
+	return s.repo.ListByUser(ctx, user.ID)
+}
+
+// Active returns the user's current workspace or nil for personal tasks.
+// A user who lost access to the workspace is moved back to personal tasks.
+func (s *WorkspaceService) Active(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	if user.ActiveWorkspaceID == 0 {
+		return nil, nil
+	}
+	workspace, err := s.repo.GetByID(ctx, user.ActiveWorkspaceID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	member := false
+	if workspace != nil {
+		if member, err = s.repo.IsMember(ctx, workspace.ID, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if !member {
+		return nil, s.repo.SetActive(ctx, user, 0)
+	}
+	return workspace, nil
+}
+
+// Members lists members of the user's active workspace.
+func (s *WorkspaceService) Members(ctx context.Context, user *model.User) (*model.Workspace, []model.WorkspaceMember, error) {
+	workspace, err := s.requireActive(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.repo.ListMembers(ctx, workspace.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return workspace, members, nil
+}
+
+// SetRole changes a member's role; only the owner may do it.
+func (s *WorkspaceService) SetRole(ctx context.Context, user *model.User, memberUserID uint, role string) error {
+	if role != model.RoleEditor && role != model.RoleViewer {
+		return ErrInvalidRole
+	}
+	workspace, err := s.requireOwner(ctx, user)
+	if err != nil {
+		return err
+	}
+	if memberUserID == workspace.OwnerID {
+		return ErrForbidden
+	}
+	if _, err := s.member(ctx, workspace.ID, memberUserID); err != nil {
+		return err
+	}
+	return s.repo.UpdateRole(ctx, workspace.ID, memberUserID, role)
+}
+
+// RemoveMember excludes a member from the active workspace; only the owner may do it.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, user *model.User, memberUserID uint) error {
+	workspace, err := s.requireOwner(ctx, user)
+	if err != nil {
+		return err
+	}
+	if memberUserID == workspace.OwnerID {
+		return ErrForbidden
+	}
+	if _, err := s.member(ctx, workspace.ID, memberUserID); err != nil {
+		return err
+	}
+	return s.repo.RemoveMember(ctx, workspace.ID, memberUserID)
+}
+
+// Leave removes the user from the active workspace. The owner cannot leave.
+func (s *WorkspaceService) Leave(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	workspace, err := s.requireActive(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.OwnerID == user.ID {
+		return nil, ErrForbidden
+	}
+	if err := s.repo.RemoveMember(ctx, workspace.ID, user.ID); err != nil {
+		return nil, err
+	}
+	user.ActiveWorkspaceID = 0
+	return workspace, nil
+}
+
+// BindReportChat sends the active workspace report to the given group chat; only the owner may do it.
+func (s *WorkspaceService) BindReportChat(ctx context.Context, user *model.User, chatID int64) (*model.Workspace, error) {
+	workspace, err := s.requireOwner(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetReportChat(ctx, workspace, chatID); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// UnbindReportChat stops posting the active workspace report to its group chat.
+func (s *WorkspaceService) UnbindReportChat(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	return s.BindReportChat(ctx, user, 0)
+}
+
+func (s *WorkspaceService) ListAll(ctx context.Context) ([]model.Workspace, error) {
+	return s.repo.ListAll(ctx)
+}
+
+// OwnedActive returns the active workspace if the user owns it.
+func (s *WorkspaceService) OwnedActive(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	return s.requireOwner(ctx, user)
+}
+
+// ListWithReports returns workspaces whose reports, whole or routed by category, go to group chats.
+func (s *WorkspaceService) ListWithReports(ctx context.Context) ([]model.Workspace, error) {
+	return s.repo.ListWithReports(ctx)
+}
+
+// Authorize checks that the user may modify tasks in the given scope.
+// Personal scopes are always writable.
+func (s *WorkspaceService) Authorize(ctx context.Context, user *model.User, scope model.Scope) error {
+	if !scope.IsWorkspace() {
+		return nil
+	}
+	member, err := s.member(ctx, scope.WorkspaceID, user.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotWorkspaceMember) {
+			return ErrForbidden
+		}
+		return err
+	}
+	if !member.CanEdit() {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// IsMember reports whether the user still belongs to the workspace.
+func (s *WorkspaceService) IsMember(ctx context.Context, workspaceID, userID uint) (bool, error) {
+	_, err := s.member(ctx, workspaceID, userID)
+	if errors.Is(err, ErrNotWorkspaceMember) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *WorkspaceService) member(ctx context.Context, workspaceID, userID uint) (*model.WorkspaceMember, error) {
+	member, err := s.repo.FindMember(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotWorkspaceMember
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *WorkspaceService) requireActive(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	workspace, err := s.Active(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	return workspace, nil
+}
+
+func (s *WorkspaceService) requireOwner(ctx context.Context, user *model.User) (*model.Workspace, error) {
+	workspace, err := s.requireActive(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.OwnerID != user.ID {
+		return nil, ErrForbidden
+	}
+	return workspace, nil
+}
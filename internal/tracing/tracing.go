@@ -0,0 +1,138 @@
+// Package tracing sets up optional OpenTelemetry tracing: a span per Telegram
+// update with child spans for services, database queries and Bot API calls.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	serviceName = "daily-planner"
+	// spanKey keeps the query span on the GORM statement between callbacks.
+	spanKey = "tracing:span"
+)
+
+// Setup exports spans over OTLP/HTTP to endpoint, e.g. http://localhost:4318.
+// With an empty endpoint tracing stays disabled and spans cost next to nothing.
+// The returned function flushes pending spans.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start begins a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(serviceName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// GormPlugin traces every query with the statement's context as the parent.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "tracing" }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	steps := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+	for _, step := range steps {
+		if err := step.before("tracing:before_"+step.operation, startQuery(step.operation)); err != nil {
+			return err
+		}
+		if err := step.after("tracing:after_"+step.operation, endQuery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuery(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		_, span := Start(tx.Statement.Context, "db."+operation, semconv.DBSystemKey.String(tx.Dialector.Name()))
+		tx.InstanceSet(spanKey, span)
+	}
+}
+
+func endQuery(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table), attribute.Int64("db.rows_affected", tx.RowsAffected))
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
+
+// Transport traces Bot API calls. The Telegram client does not pass contexts
+// to its requests, so parent returns the context of the work in progress.
+func Transport(base http.RoundTripper, parent func() context.Context) http.RoundTripper {
+	return roundTripper{base: base, parent: parent}
+}
+
+type roundTripper struct {
+	base   http.RoundTripper
+	parent func() context.Context
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The token is part of the path, so only the method name goes into the span.
+	method := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	_, span := Start(t.parent(), "telegram."+method, attribute.String("telegram.method", method))
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode >= 400 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	End(span, err)
+	return resp, err
+}
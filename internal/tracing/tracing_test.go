@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSpansJoinParent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	ctx, root := Start(context.Background(), "telegram.update")
+	var count int64
+	if err := db.WithContext(ctx).Table("sqlite_master").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: Transport(http.DefaultTransport, func() context.Context { return ctx })}
+	resp, err := client.Get(server.URL + "/botSECRET/sendMessage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	root.End()
+
+	spans := recorder.Ended()
+	names := make(map[string]bool)
+	for _, span := range spans {
+		names[span.Name()] = true
+		if span.Name() != "telegram.update" && span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the update span", span.Name())
+		}
+	}
+	for _, want := range []string{"telegram.update", "db.query", "telegram.sendMessage"} {
+		if !names[want] {
+			t.Errorf("missing span %s, got %v", want, names)
+		}
+	}
+}